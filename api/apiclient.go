@@ -197,6 +197,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 	}
 
 	client := rpc.NewConn(jsoncodec.New(dialResult.conn), nil)
+	client.SetTracer(opts.Tracer)
 	client.Start(ctx)
 
 	bakeryClient := opts.BakeryClient
@@ -112,22 +112,93 @@ type DeployArgs struct {
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	deployArgs, err := c.deployArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// DeployDryRunArgs holds the arguments to Client.DeployDryRun.
+type DeployDryRunArgs struct {
+	DeployArgs
+
+	// Charm is the charm to deploy. It need not have been added
+	// to the model: only its metadata and config are sent.
+	Charm charm.Charm
+
+	// DryRunResources describes the resources the application
+	// would use. DeployArgs.Resources is ignored.
+	DryRunResources []params.DryRunResource
+}
+
+// DeployDryRun validates the arguments for deploying an application,
+// and reports what the deployment would do, without changing the
+// model. Neither the charm nor the resources are uploaded.
+func (c *Client) DeployDryRun(args DeployDryRunArgs) (params.DeployDryRunResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return params.DeployDryRunResult{}, errors.NotSupportedf("deploy dry-run on this controller")
+	}
+	args.Resources = nil
+	deployArgs, err := c.deployArgs(args.DeployArgs)
+	if err != nil {
+		return params.DeployDryRunResult{}, errors.Trace(err)
+	}
+	dryRunArgs := params.DeployDryRunArgs{
+		Applications: []params.DeployDryRunArg{{
+			ApplicationDeploy: deployArgs.Applications[0],
+			Charm:             dryRunCharm(args.Charm),
+			DryRunResources:   args.DryRunResources,
+		}},
+	}
+	var results params.DeployDryRunResults
+	if err := c.facade.FacadeCall("DeployDryRun", dryRunArgs, &results); err != nil {
+		return params.DeployDryRunResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.DeployDryRunResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.DeployDryRunResult{}, errors.Trace(result.Error)
+	}
+	return result, nil
+}
+
+// dryRunCharm returns the description of the charm sent
+// with the arguments of a dry run, or nil if there is none.
+func dryRunCharm(ch charm.Charm) *params.DryRunCharm {
+	if ch == nil {
+		return nil
+	}
+	return &params.DryRunCharm{
+		Meta:   ch.Meta(),
+		Config: ch.Config(),
+	}
+}
+
+func (c *Client) deployArgs(args DeployArgs) (params.ApplicationsDeploy, error) {
 	if len(args.AttachStorage) > 0 {
 		if args.NumUnits != 1 {
-			return errors.New("cannot attach existing storage when more than one unit is requested")
+			return params.ApplicationsDeploy{}, errors.New("cannot attach existing storage when more than one unit is requested")
 		}
 		if c.BestAPIVersion() < 5 {
-			return errors.New("this juju controller does not support AttachStorage")
+			return params.ApplicationsDeploy{}, errors.New("this juju controller does not support AttachStorage")
 		}
 	}
 	attachStorage := make([]string, len(args.AttachStorage))
 	for i, id := range args.AttachStorage {
 		if !names.IsValidStorage(id) {
-			return errors.NotValidf("storage ID %q", id)
+			return params.ApplicationsDeploy{}, errors.NotValidf("storage ID %q", id)
 		}
 		attachStorage[i] = names.NewStorageTag(id).String()
 	}
-	deployArgs := params.ApplicationsDeploy{
+	return params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName:  args.ApplicationName,
 			Series:           args.Series,
@@ -143,14 +214,7 @@ func (c *Client) Deploy(args DeployArgs) error {
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		}},
-	}
-	var results params.ErrorResults
-	var err error
-	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	return errors.Trace(results.OneError())
+	}, nil
 }
 
 // GetCharmURL returns the charm URL the given service is
@@ -279,6 +343,44 @@ type SetCharmConfig struct {
 
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	return c.facade.FacadeCall("SetCharm", setCharmArgs(cfg), nil)
+}
+
+// SetCharmDryRunArgs holds the arguments to Client.SetCharmDryRun.
+type SetCharmDryRunArgs struct {
+	SetCharmConfig
+
+	// Charm is the new charm. It need not have been added to
+	// the model: only its metadata and config are sent.
+	Charm charm.Charm
+
+	// DryRunResources describes the resources the application
+	// would use. SetCharmConfig.ResourceIDs is ignored.
+	DryRunResources []params.DryRunResource
+}
+
+// SetCharmDryRun validates the arguments for setting the charm of an
+// application, and reports what the upgrade would change, without
+// changing the model. Neither the charm nor the resources are
+// uploaded.
+func (c *Client) SetCharmDryRun(args SetCharmDryRunArgs) (params.SetCharmDryRunResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return params.SetCharmDryRunResult{}, errors.NotSupportedf("upgrade-charm dry-run on this controller")
+	}
+	args.ResourceIDs = nil
+	dryRunArgs := params.SetCharmDryRunArg{
+		ApplicationSetCharm: setCharmArgs(args.SetCharmConfig),
+		Charm:               dryRunCharm(args.Charm),
+		DryRunResources:     args.DryRunResources,
+	}
+	var result params.SetCharmDryRunResult
+	if err := c.facade.FacadeCall("SetCharmDryRun", dryRunArgs, &result); err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func setCharmArgs(cfg SetCharmConfig) params.ApplicationSetCharm {
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -298,7 +400,7 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 			}
 		}
 	}
-	args := params.ApplicationSetCharm{
+	return params.ApplicationSetCharm{
 		ApplicationName:    cfg.ApplicationName,
 		CharmURL:           cfg.CharmID.URL.String(),
 		Channel:            string(cfg.CharmID.Channel),
@@ -309,7 +411,6 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
 	}
-	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
 // Update updates the application attributes, including charm URL,
@@ -358,29 +459,55 @@ type AddUnitsParams struct {
 // AddUnits adds a given number of units to an application using the specified
 // placement directives to assign units to machines.
 func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
+	addArgs, err := c.addUnitsArgs(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := new(params.AddApplicationUnitsResults)
+	err = c.facade.FacadeCall("AddUnits", addArgs, results)
+	return results.Units, err
+}
+
+// AddUnitsDryRun validates the arguments for adding units to an
+// application, and reports where the units would be placed, without
+// changing the model.
+func (c *Client) AddUnitsDryRun(args AddUnitsParams) (params.AddUnitsDryRunResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return params.AddUnitsDryRunResult{}, errors.NotSupportedf("add-unit dry-run on this controller")
+	}
+	addArgs, err := c.addUnitsArgs(args)
+	if err != nil {
+		return params.AddUnitsDryRunResult{}, errors.Trace(err)
+	}
+	var result params.AddUnitsDryRunResult
+	if err := c.facade.FacadeCall("AddUnitsDryRun", addArgs, &result); err != nil {
+		return params.AddUnitsDryRunResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func (c *Client) addUnitsArgs(args AddUnitsParams) (params.AddApplicationUnits, error) {
 	if len(args.AttachStorage) > 0 {
 		if args.NumUnits != 1 {
-			return nil, errors.New("cannot attach existing storage when more than one unit is requested")
+			return params.AddApplicationUnits{}, errors.New("cannot attach existing storage when more than one unit is requested")
 		}
 		if c.BestAPIVersion() < 5 {
-			return nil, errors.New("this juju controller does not support AttachStorage")
+			return params.AddApplicationUnits{}, errors.New("this juju controller does not support AttachStorage")
 		}
 	}
 	attachStorage := make([]string, len(args.AttachStorage))
 	for i, id := range args.AttachStorage {
 		if !names.IsValidStorage(id) {
-			return nil, errors.NotValidf("storage ID %q", id)
+			return params.AddApplicationUnits{}, errors.NotValidf("storage ID %q", id)
 		}
 		attachStorage[i] = names.NewStorageTag(id).String()
 	}
-	results := new(params.AddApplicationUnitsResults)
-	err := c.facade.FacadeCall("AddUnits", params.AddApplicationUnits{
+	return params.AddApplicationUnits{
 		ApplicationName: args.ApplicationName,
 		NumUnits:        args.NumUnits,
 		Placement:       args.Placement,
 		AttachStorage:   attachStorage,
-	}, results)
-	return results.Units, err
+	}, nil
 }
 
 // DestroyUnitsDeprecated decreases the number of units dedicated to an
@@ -772,3 +899,84 @@ func (c *Client) UnsetApplicationConfig(application string, options []string) er
 	}
 	return results.OneError()
 }
+
+// ApplicationsInfo returns the details of the given applications.
+func (c *Client) ApplicationsInfo(applications []names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("ApplicationsInfo not supported by this version of Juju")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(applications))}
+	for i, tag := range applications {
+		args.Entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var results params.ApplicationInfoResults
+	if err := c.facade.FacadeCall("ApplicationsInfo", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n, expected := len(results.Results), len(applications); n != expected {
+		return nil, errors.Errorf("expected %d results, got %d", expected, n)
+	}
+	return results.Results, nil
+}
+
+// UnitsInfo returns the details of the given units.
+func (c *Client) UnitsInfo(units []names.UnitTag) ([]params.UnitInfoResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("UnitsInfo not supported by this version of Juju")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(units))}
+	for i, tag := range units {
+		args.Entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var results params.UnitInfoResults
+	if err := c.facade.FacadeCall("UnitsInfo", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n, expected := len(results.Results), len(units); n != expected {
+		return nil, errors.Errorf("expected %d results, got %d", expected, n)
+	}
+	return results.Results, nil
+}
+
+// UpdateEndpointBindings changes the spaces the given endpoints of an
+// application are bound to. An empty endpoint name sets the default
+// space of the application.
+func (c *Client) UpdateEndpointBindings(application string, bindings map[string]string) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("UpdateEndpointBindings not supported by this version of Juju")
+	}
+	args := params.ApplicationEndpointBindingsArgs{
+		Args: []params.ApplicationEndpointBindings{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Bindings:       bindings,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdateEndpointBindings", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ScaleApplication sets the number of units, and hence pods, the given
+// application should have. It is only valid for applications in CAAS
+// models.
+func (c *Client) ScaleApplication(application string, scale int) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("ScaleApplications not supported by this version of Juju")
+	}
+	if scale < 0 {
+		return errors.NotValidf("negative scale %d", scale)
+	}
+	args := params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Scale:          scale,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ScaleApplications", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api/application"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -296,6 +298,174 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestDeployDryRun(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("dummy")
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "DeployDryRun")
+				args, ok := a.(params.DeployDryRunArgs)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Applications, gc.HasLen, 1)
+				c.Assert(args.Applications[0].ApplicationName, gc.Equals, "serviceA")
+				c.Assert(args.Applications[0].CharmURL, gc.Equals, "cs:trusty/a-charm-1")
+				c.Assert(args.Applications[0].Resources, gc.IsNil)
+				c.Assert(args.Applications[0].Charm, jc.DeepEquals, &params.DryRunCharm{
+					Meta:   ch.Meta(),
+					Config: ch.Config(),
+				})
+				c.Assert(args.Applications[0].DryRunResources, jc.DeepEquals, []params.DryRunResource{{
+					Name:     "data",
+					Origin:   "upload",
+					Revision: -1,
+				}})
+				result := response.(*params.DeployDryRunResults)
+				result.Results = []params.DeployDryRunResult{{
+					ApplicationName: "serviceA",
+					NumUnits:        1,
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	result, err := client.DeployDryRun(application.DeployDryRunArgs{
+		DeployArgs: application.DeployArgs{
+			CharmID: charmstore.CharmID{
+				URL: charm.MustParseURL("trusty/a-charm-1"),
+			},
+			ApplicationName: "serviceA",
+			NumUnits:        1,
+			Resources:       map[string]string{"data": "pending-id"},
+		},
+		Charm: ch,
+		DryRunResources: []params.DryRunResource{{
+			Name:     "data",
+			Origin:   "upload",
+			Revision: -1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DeployDryRunResult{
+		ApplicationName: "serviceA",
+		NumUnits:        1,
+	})
+}
+
+func (s *applicationSuite) TestDeployDryRunError(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				result := response.(*params.DeployDryRunResults)
+				result.Results = []params.DeployDryRunResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	_, err := client.DeployDryRun(application.DeployDryRunArgs{
+		DeployArgs: application.DeployArgs{
+			CharmID: charmstore.CharmID{
+				URL: charm.MustParseURL("trusty/a-charm-1"),
+			},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestDeployDryRunNotSupported(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	_, err := client.DeployDryRun(application.DeployDryRunArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestSetCharmDryRun(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetCharmDryRun")
+				args, ok := a.(params.SetCharmDryRunArg)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.ApplicationName, gc.Equals, "application")
+				c.Assert(args.CharmURL, gc.Equals, "cs:trusty/application-2")
+				c.Assert(args.ResourceIDs, gc.IsNil)
+				c.Assert(args.Charm, gc.IsNil)
+				c.Assert(args.DryRunResources, jc.DeepEquals, []params.DryRunResource{{
+					Name:     "data",
+					Origin:   "store",
+					Revision: 3,
+				}})
+				result := response.(*params.SetCharmDryRunResult)
+				result.ConfigAdded = []string{"new"}
+				result.BrokenRelations = []string{"application:db mysql:db"}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	result, err := client.SetCharmDryRun(application.SetCharmDryRunArgs{
+		SetCharmConfig: application.SetCharmConfig{
+			ApplicationName: "application",
+			CharmID: charmstore.CharmID{
+				URL: charm.MustParseURL("trusty/application-2"),
+			},
+		},
+		DryRunResources: []params.DryRunResource{{
+			Name:     "data",
+			Origin:   "store",
+			Revision: 3,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SetCharmDryRunResult{
+		ConfigAdded:     []string{"new"},
+		BrokenRelations: []string{"application:db mysql:db"},
+	})
+}
+
+func (s *applicationSuite) TestAddUnitsDryRun(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "AddUnitsDryRun")
+				args, ok := a.(params.AddApplicationUnits)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.ApplicationName, gc.Equals, "foo")
+				c.Assert(args.NumUnits, gc.Equals, 2)
+				result := response.(*params.AddUnitsDryRunResult)
+				result.ApplicationName = "foo"
+				result.NumUnits = 2
+				result.Placement = []string{"0", ""}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	result, err := client.AddUnitsDryRun(application.AddUnitsParams{
+		ApplicationName: "foo",
+		NumUnits:        2,
+		Placement:       []*instance.Placement{{"#", "0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AddUnitsDryRunResult{
+		ApplicationName: "foo",
+		NumUnits:        2,
+		Placement:       []string{"0", ""},
+	})
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -1041,3 +1211,182 @@ func (s *applicationSuite) TestUnsetApplicationConfigAPIv5(c *gc.C) {
 	err := client.UnsetApplicationConfig("foo", []string{})
 	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
+
+func (s *applicationSuite) TestApplicationsInfo(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ApplicationsInfo")
+				c.Assert(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+					{Tag: "application-foo"},
+					{Tag: "application-bar"},
+				}})
+				result := response.(*params.ApplicationInfoResults)
+				result.Results = []params.ApplicationInfoResult{{
+					Result: &params.ApplicationInfo{Tag: "application-foo", Series: "bionic"},
+				}, {
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	results, err := client.ApplicationsInfo([]names.ApplicationTag{
+		names.NewApplicationTag("foo"),
+		names.NewApplicationTag("bar"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ApplicationInfoResult{{
+		Result: &params.ApplicationInfo{Tag: "application-foo", Series: "bionic"},
+	}, {
+		Error: &params.Error{Message: "boom"},
+	}})
+}
+
+func (s *applicationSuite) TestApplicationsInfoNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	_, err := client.ApplicationsInfo([]names.ApplicationTag{names.NewApplicationTag("foo")})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "UnitsInfo")
+				c.Assert(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+					{Tag: "unit-foo-0"},
+				}})
+				result := response.(*params.UnitInfoResults)
+				result.Results = []params.UnitInfoResult{{
+					Result: &params.UnitInfo{Tag: "unit-foo-0", Machine: "1", Leader: true},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	results, err := client.UnitsInfo([]names.UnitTag{names.NewUnitTag("foo/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.UnitInfoResult{{
+		Result: &params.UnitInfo{Tag: "unit-foo-0", Machine: "1", Leader: true},
+	}})
+}
+
+func (s *applicationSuite) TestUnitsInfoResultCountMismatch(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	_, err := client.UnitsInfo([]names.UnitTag{names.NewUnitTag("foo/0")})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *applicationSuite) TestUpdateEndpointBindings(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "UpdateEndpointBindings")
+				c.Assert(a, jc.DeepEquals, params.ApplicationEndpointBindingsArgs{
+					Args: []params.ApplicationEndpointBindings{{
+						ApplicationTag: "application-mysql",
+						Bindings:       map[string]string{"": "alpha", "db": "beta"},
+					}},
+				})
+				result := response.(*params.ErrorResults)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.UpdateEndpointBindings("mysql", map[string]string{"": "alpha", "db": "beta"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestUpdateEndpointBindingsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	err := client.UpdateEndpointBindings("mysql", map[string]string{"db": "beta"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestScaleApplication(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "ScaleApplications")
+				c.Assert(a, jc.DeepEquals, params.ScaleApplicationsParams{
+					Applications: []params.ScaleApplicationParams{{
+						ApplicationTag: "application-gitlab",
+						Scale:          5,
+					}},
+				})
+				result := response.(*params.ErrorResults)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+	err := client.ScaleApplication("gitlab", 5)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestScaleApplicationNegative(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+	err := client.ScaleApplication("gitlab", -1)
+	c.Assert(err, gc.ErrorMatches, "negative scale -1 not valid")
+}
+
+func (s *applicationSuite) TestScaleApplicationNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.ScaleApplication("gitlab", 5)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
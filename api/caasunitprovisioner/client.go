@@ -10,8 +10,10 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/watcher"
 )
 
@@ -35,11 +37,15 @@ func applicationTag(application string) (names.ApplicationTag, error) {
 	return names.NewApplicationTag(application), nil
 }
 
-func unitTag(unit string) (names.UnitTag, error) {
-	if !names.IsValidUnit(unit) {
-		return names.UnitTag{}, errors.NotValidf("unit name %q", unit)
+func applicationOrUnitTag(entityName string) (names.Tag, error) {
+	switch {
+	case names.IsValidApplication(entityName):
+		return names.NewApplicationTag(entityName), nil
+	case names.IsValidUnit(entityName):
+		return names.NewUnitTag(entityName), nil
+	default:
+		return nil, errors.NotValidf("application or unit name %q", entityName)
 	}
-	return names.NewUnitTag(unit), nil
 }
 
 func entities(tags ...names.Tag) params.Entities {
@@ -106,15 +112,67 @@ func (c *Client) WatchUnits(application string) (watcher.StringsWatcher, error)
 	return w, nil
 }
 
+// WatchApplicationScale returns a NotifyWatcher that notifies of
+// changes to the desired scale of the specified CAAS application
+// in the current model.
+func (c *Client) WatchApplicationScale(application string) (watcher.NotifyWatcher, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("watching application scale on this controller")
+	}
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(applicationTag)
+
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsScale", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// ApplicationScale returns the desired scale of the specified
+// CAAS application in the current model.
+func (c *Client) ApplicationScale(application string) (int, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return 0, errors.NotSupportedf("application scale on this controller")
+	}
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	args := entities(applicationTag)
+
+	var results params.IntResults
+	if err := c.facade.FacadeCall("ApplicationsScale", args, &results); err != nil {
+		return 0, err
+	}
+	if n := len(results.Results); n != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return 0, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
 // WatchContainerSpec returns a NotifyWatcher that notifies of
-// changes to the container spec of the specified CAAS units in
-// the current model.
-func (c *Client) WatchContainerSpec(unit string) (watcher.NotifyWatcher, error) {
-	unitTag, err := unitTag(unit)
+// changes to the container spec of the specified CAAS application
+// or unit in the current model.
+func (c *Client) WatchContainerSpec(entityName string) (watcher.NotifyWatcher, error) {
+	tag, err := applicationOrUnitTag(entityName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	args := entities(unitTag)
+	args := entities(tag)
 
 	var results params.NotifyWatchResults
 	if err := c.facade.FacadeCall("WatchContainerSpec", args, &results); err != nil {
@@ -131,13 +189,13 @@ func (c *Client) WatchContainerSpec(unit string) (watcher.NotifyWatcher, error)
 }
 
 // ContainerSpec returns the container spec for the specified CAAS
-// unit in the current model.
-func (c *Client) ContainerSpec(unit string) (string, error) {
-	unitTag, err := unitTag(unit)
+// application or unit in the current model.
+func (c *Client) ContainerSpec(entityName string) (string, error) {
+	tag, err := applicationOrUnitTag(entityName)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	args := entities(unitTag)
+	args := entities(tag)
 
 	var results params.StringResults
 	if err := c.facade.FacadeCall("ContainerSpec", args, &results); err != nil {
@@ -155,14 +213,9 @@ func (c *Client) ContainerSpec(unit string) (string, error) {
 // Life returns the lifecycle state for the specified CAAS application
 // or unit in the current model.
 func (c *Client) Life(entityName string) (life.Value, error) {
-	var tag names.Tag
-	switch {
-	case names.IsValidApplication(entityName):
-		tag = names.NewApplicationTag(entityName)
-	case names.IsValidUnit(entityName):
-		tag = names.NewUnitTag(entityName)
-	default:
-		return "", errors.NotValidf("application or unit name %q", entityName)
+	tag, err := applicationOrUnitTag(entityName)
+	if err != nil {
+		return "", errors.Trace(err)
 	}
 	args := entities(tag)
 
@@ -179,6 +232,63 @@ func (c *Client) Life(entityName string) (life.Value, error) {
 	return life.Value(results.Results[0].Life), nil
 }
 
+// ProvisioningInfo holds the information needed to provision
+// the pods of a CAAS application.
+type ProvisioningInfo struct {
+	Filesystems []storage.KubernetesFilesystemParams
+	Constraints constraints.Value
+}
+
+// ProvisioningInfo returns the information needed to provision
+// the pods of the specified CAAS application.
+func (c *Client) ProvisioningInfo(application string) (*ProvisioningInfo, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("provisioning info on this controller")
+	}
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(applicationTag)
+
+	var results params.KubernetesProvisioningInfoResults
+	if err := c.facade.FacadeCall("ProvisioningInfo", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	result := results.Results[0].Result
+	info := &ProvisioningInfo{
+		Filesystems: make([]storage.KubernetesFilesystemParams, len(result.Filesystems)),
+		Constraints: result.Constraints,
+	}
+	for i, fs := range result.Filesystems {
+		info.Filesystems[i] = filesystemParamsFromParams(fs)
+	}
+	return info, nil
+}
+
+func filesystemParamsFromParams(in params.KubernetesFilesystemParams) storage.KubernetesFilesystemParams {
+	out := storage.KubernetesFilesystemParams{
+		StorageName:  in.StorageName,
+		Size:         in.Size,
+		Provider:     storage.ProviderType(in.Provider),
+		Attributes:   in.Attributes,
+		ResourceTags: in.Tags,
+	}
+	if in.Attachment != nil {
+		out.Attachment = &storage.KubernetesFilesystemAttachmentParams{
+			Path:     in.Attachment.MountPoint,
+			ReadOnly: in.Attachment.ReadOnly,
+		}
+	}
+	return out
+}
+
 func maybeNotFound(err *params.Error) error {
 	if !params.IsCodeNotFound(err) {
 		return err
@@ -13,8 +13,10 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/storage"
 )
 
 type unitprovisionerSuite struct {
@@ -74,8 +76,30 @@ func (s *unitprovisionerSuite) TestContainerSpecInvalidEntityame(c *gc.C) {
 	client := caasunitprovisioner.NewClient(basetesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
 		return errors.New("should not be called")
 	}))
-	_, err := client.ContainerSpec("gitlab")
-	c.Assert(err, gc.ErrorMatches, `unit name "gitlab" not valid`)
+	_, err := client.ContainerSpec("")
+	c.Assert(err, gc.ErrorMatches, `application or unit name "" not valid`)
+}
+
+func (s *unitprovisionerSuite) TestContainerSpecApplication(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ContainerSpec")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{
+				Result: "foo",
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(apiCaller)
+	spec, err := client.ContainerSpec("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.Equals, "foo")
 }
 
 func (s *unitprovisionerSuite) TestLife(c *gc.C) {
@@ -284,3 +308,141 @@ func (s *unitprovisionerSuite) TestUpdateUnitsCount(c *gc.C) {
 	})
 	c.Check(err, gc.ErrorMatches, `expected 1 result\(s\), got 2`)
 }
+
+func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ProvisioningInfo")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.KubernetesProvisioningInfoResults{})
+		*(result.(*params.KubernetesProvisioningInfoResults)) = params.KubernetesProvisioningInfoResults{
+			Results: []params.KubernetesProvisioningInfoResult{{
+				Result: &params.KubernetesProvisioningInfo{
+					Filesystems: []params.KubernetesFilesystemParams{{
+						StorageName: "data",
+						Size:        1024,
+						Provider:    "kubernetes",
+						Attributes:  map[string]interface{}{"storage-class": "ssd"},
+						Attachment: &params.KubernetesFilesystemAttachmentParams{
+							MountPoint: "/srv/data",
+							ReadOnly:   true,
+						},
+					}},
+					Constraints: constraints.MustParse("mem=4G"),
+				},
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	info, err := client.ProvisioningInfo("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &caasunitprovisioner.ProvisioningInfo{
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "data",
+			Size:        1024,
+			Provider:    storage.ProviderType("kubernetes"),
+			Attributes:  map[string]interface{}{"storage-class": "ssd"},
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path:     "/srv/data",
+				ReadOnly: true,
+			},
+		}},
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+}
+
+func (s *unitprovisionerSuite) TestProvisioningInfoNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.ProvisioningInfo("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *unitprovisionerSuite) TestWatchApplicationScale(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchApplicationsScale")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 3})
+	watcher, err := client.WatchApplicationScale("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *unitprovisionerSuite) TestApplicationScale(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ApplicationsScale")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.IntResults{})
+		*(result.(*params.IntResults)) = params.IntResults{
+			Results: []params.IntResult{{
+				Result: 5,
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 3})
+	scale, err := client.ApplicationScale("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 5)
+}
+
+func (s *unitprovisionerSuite) TestApplicationScaleError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.IntResults)) = params.IntResults{
+			Results: []params.IntResult{{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: "bletch",
+			}}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 3})
+	_, err := client.ApplicationScale("gitlab")
+	c.Assert(err, gc.ErrorMatches, "bletch")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *unitprovisionerSuite) TestApplicationScaleNotSupported(c *gc.C) {
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}), 2,
+	})
+	_, err := client.ApplicationScale("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.WatchApplicationScale("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -103,6 +103,20 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 	return history, nil
 }
 
+// ModelStatusHistory returns a page of the status history entries from
+// across the model that match the query, oldest first. If the result's
+// Next field is set, it is the query's After field for the next page.
+func (c *Client) ModelStatusHistory(query params.StatusHistoryQuery) (params.StatusHistoryEntries, error) {
+	var result params.StatusHistoryEntries
+	if c.BestAPIVersion() < 2 {
+		return result, errors.NotSupportedf("model status history")
+	}
+	if err := c.facade.FacadeCall("ModelStatusHistory", query, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // Resolved clears errors on a unit.
 func (c *Client) Resolved(unit string, retry bool) error {
 	p := params.Resolved{
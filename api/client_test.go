@@ -439,16 +439,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
 
 	params := common.DebugLogParams{
-		IncludeEntity: []string{"a", "b"},
-		IncludeModule: []string{"c", "d"},
-		ExcludeEntity: []string{"e", "f"},
-		ExcludeModule: []string{"g", "h"},
-		Limit:         100,
-		Backlog:       200,
-		Level:         loggo.ERROR,
-		Replay:        true,
-		NoTail:        true,
-		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		IncludeEntity:   []string{"a", "b"},
+		IncludeModule:   []string{"c", "d"},
+		ExcludeEntity:   []string{"e", "f"},
+		ExcludeModule:   []string{"g", "h"},
+		IncludeLabels:   []string{"i"},
+		IncludeFields:   map[string]string{"k": "l", "j": "m"},
+		MessageContains: "n.*o",
+		Limit:           100,
+		Backlog:         200,
+		Level:           loggo.ERROR,
+		Replay:          true,
+		NoTail:          true,
+		StartTime:       time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		EndTime:         time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
 	}
 
 	client := s.APIState.Client()
@@ -460,16 +464,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 
 	values := connectURL.Query()
 	c.Assert(values, jc.DeepEquals, url.Values{
-		"includeEntity": params.IncludeEntity,
-		"includeModule": params.IncludeModule,
-		"excludeEntity": params.ExcludeEntity,
-		"excludeModule": params.ExcludeModule,
-		"maxLines":      {"100"},
-		"backlog":       {"200"},
-		"level":         {"ERROR"},
-		"replay":        {"true"},
-		"noTail":        {"true"},
-		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"includeEntity":   params.IncludeEntity,
+		"includeModule":   params.IncludeModule,
+		"excludeEntity":   params.ExcludeEntity,
+		"excludeModule":   params.ExcludeModule,
+		"includeLabel":    params.IncludeLabels,
+		"includeField":    {"j=m", "k=l"},
+		"messageContains": {"n.*o"},
+		"maxLines":        {"100"},
+		"backlog":         {"200"},
+		"level":           {"ERROR"},
+		"replay":          {"true"},
+		"noTail":          {"true"},
+		"startTime":       {"2016-11-30T11:48:00.0000001Z"},
+		"endTime":         {"2016-11-30T12:48:00Z"},
 	})
 }
 
@@ -6,6 +6,7 @@ package common
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -34,6 +35,15 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeLabels lists labels that records must all have to be
+	// included in the response.
+	IncludeLabels []string
+	// IncludeFields holds structured field values that records must
+	// all match to be included in the response.
+	IncludeFields map[string]string
+	// MessageContains is text that the message of records must
+	// contain to be included in the response.
+	MessageContains string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -53,6 +63,10 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means that only records with a log time on or
+	// before EndTime will be returned, and that new records are not
+	// waited for.
+	EndTime time.Time
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -62,6 +76,19 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
 	}
+	if len(args.IncludeLabels) > 0 {
+		attrs["includeLabel"] = args.IncludeLabels
+	}
+	if len(args.IncludeFields) > 0 {
+		names := make([]string, 0, len(args.IncludeFields))
+		for name := range args.IncludeFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			attrs.Add("includeField", name+"="+args.IncludeFields[name])
+		}
+	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
 	}
@@ -80,6 +107,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.MessageContains != "" {
+		attrs.Set("messageContains", args.MessageContains)
+	}
 	return attrs
 }
 
@@ -91,6 +124,8 @@ type LogMessage struct {
 	Module    string
 	Location  string
 	Message   string
+	Labels    []string
+	Fields    map[string]string
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -128,6 +163,8 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+				Labels:    msg.Labels,
+				Fields:    msg.Fields,
 			}
 		}
 	}()
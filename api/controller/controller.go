@@ -159,6 +159,31 @@ func (c *Client) DestroyController(args DestroyControllerParams) error {
 	}, nil)
 }
 
+// ConfigSet updates controller configuration with the given values.
+// Only settings that may be changed after bootstrap are accepted.
+func (c *Client) ConfigSet(values map[string]interface{}) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.Errorf("this controller version doesn't support updating controller config")
+	}
+	return errors.Trace(
+		c.facade.FacadeCall("ConfigSet", params.ControllerConfigSet{Config: values}, nil),
+	)
+}
+
+// AuditLog returns the requests matching the filter from the
+// controller's audit log, each preceded by its conversation and
+// followed by any errors returned in response.
+func (c *Client) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogRecord, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.Errorf("this controller version doesn't support querying the audit log")
+	}
+	var result params.AuditLogResults
+	if err := c.facade.FacadeCall("AuditLog", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Records, nil
+}
+
 // ListBlockedModels returns a list of all models within the controller
 // which have at least one block in place.
 func (c *Client) ListBlockedModels() ([]params.ModelBlockInfo, error) {
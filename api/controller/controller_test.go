@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/environs"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -79,6 +80,78 @@ func (s *Suite) TestDestroyControllerError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "nope")
 }
 
+func (s *Suite) TestConfigSet(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{
+		"max-logs-age": "48h",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ConfigSet", []interface{}{params.ControllerConfigSet{
+			Config: map[string]interface{}{"max-logs-age": "48h"},
+		}}},
+	})
+}
+
+func (s *Suite) TestConfigSetAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 4}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{
+		"max-logs-age": "48h",
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support updating controller config")
+}
+
+func (s *Suite) TestAuditLog(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.AuditLogResults)) = params.AuditLogResults{
+				Records: []params.AuditLogRecord{{
+					ControllerID: "0",
+					Request: &auditlog.Request{
+						ConversationID: "abc",
+						Facade:         "Client",
+						Method:         "FullStatus",
+					},
+				}},
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	records, err := client.AuditLog(params.AuditLogFilter{User: "bob", Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []params.AuditLogRecord{{
+		ControllerID: "0",
+		Request: &auditlog.Request{
+			ConversationID: "abc",
+			Facade:         "Client",
+			Method:         "FullStatus",
+		},
+	}})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.AuditLog", []interface{}{params.AuditLogFilter{User: "bob", Limit: 10}}},
+	})
+}
+
+func (s *Suite) TestAuditLogAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 5}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AuditLog(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support querying the audit log")
+}
+
 func (s *Suite) TestInitiateMigration(c *gc.C) {
 	s.checkInitiateMigration(c, makeSpec())
 }
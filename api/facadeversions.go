@@ -16,24 +16,24 @@ var facadeVersions = map[string]int{
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
-	"AllWatcher":                   1,
+	"AllWatcher":                   2,
 	"Annotations":                  2,
-	"Application":                  6,
+	"Application":                  9,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       2,
 	"CAASFirewaller":               1,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
-	"CAASUnitProvisioner":          1,
+	"CAASUnitProvisioner":          3,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   4,
+	"Controller":                   6,
 	"CrossController":              1,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
@@ -57,7 +57,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               4,
+	"MachineManager":               5,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -70,6 +70,7 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  1,
+	"ModelGeneration":              1,
 	"ModelManager":                 4,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
@@ -97,8 +98,9 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       7,
+	"Uniter":                       8,
 	"Upgrader":                     1,
+	"UpgradeSeries":                1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
 }
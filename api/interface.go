@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/api/unitassigner"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/api/upgrader"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc/jsoncodec"
 )
@@ -175,6 +176,11 @@ type DialOpts struct {
 	// Clock is used as a time source for retries.
 	// If it is nil, clock.WallClock will be used.
 	Clock clock.Clock
+
+	// Tracer, if non-nil, is used to record a span for each API
+	// call made on the connection. The trace context is sent with
+	// each request, so the server's spans join the client's trace.
+	Tracer *tracing.Tracer
 }
 
 // IPAddrResolver implements a resolved from host name to the
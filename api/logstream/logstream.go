@@ -135,6 +135,8 @@ func recordFromAPI(apiRec params.LogStreamRecord, controllerUUID string) (logfwd
 		ID:        apiRec.ID,
 		Timestamp: apiRec.Timestamp,
 		Message:   apiRec.Message,
+		Labels:    apiRec.Labels,
+		Fields:    apiRec.Fields,
 	}
 
 	origin, err := originFromAPI(apiRec, controllerUUID)
@@ -135,3 +135,59 @@ func (client *Client) UpdateMachineSeries(machineName, series string, force bool
 	}
 	return results.OneError()
 }
+
+// UpgradeSeriesPrepare starts a managed series upgrade of the machine,
+// running the pre-series-upgrade hooks of the units on it.
+func (client *Client) UpgradeSeriesPrepare(machineName, series string, force bool) error {
+	if client.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("upgrade-series prepare")
+	}
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewMachineTag(machineName).String()},
+			Series: series,
+			Force:  force,
+		}},
+	}
+	results := new(params.ErrorResults)
+	err := client.facade.FacadeCall("UpgradeSeriesPrepare", args, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// UpgradeSeriesComplete records that the operating system of the
+// machine has been upgraded, so that the post-series-upgrade hooks of
+// the units on it can run.
+func (client *Client) UpgradeSeriesComplete(machineName string) error {
+	if client.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("upgrade-series complete")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineName).String()}},
+	}
+	results := new(params.ErrorResults)
+	err := client.facade.FacadeCall("UpgradeSeriesComplete", args, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// UpgradeSeriesAbort abandons the series upgrade of the machine,
+// returning its units to normal operation on the current series.
+func (client *Client) UpgradeSeriesAbort(machineName string) error {
+	if client.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("upgrade-series abort")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineName).String()}},
+	}
+	results := new(params.ErrorResults)
+	err := client.facade.FacadeCall("UpgradeSeriesAbort", args, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -210,3 +210,75 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "UpgradeSeriesPrepare")
+			c.Assert(a, jc.DeepEquals, params.UpdateSeriesArgs{
+				Args: []params.UpdateSeriesArg{{
+					Entity: params.Entity{Tag: "machine-0"},
+					Series: "bionic",
+					Force:  true,
+				}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			*(response.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	})
+	err := client.UpgradeSeriesPrepare("0", "bionic", true)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "UpgradeSeriesComplete")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			*(response.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	})
+	err := client.UpgradeSeriesComplete("0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesAbort(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "UpgradeSeriesAbort")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			*(response.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	})
+	err := client.UpgradeSeriesAbort("0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	})
+	err := client.UpgradeSeriesPrepare("0", "bionic", false)
+	c.Assert(err, gc.ErrorMatches, "upgrade-series prepare not supported")
+	err = client.UpgradeSeriesComplete("0")
+	c.Assert(err, gc.ErrorMatches, "upgrade-series complete not supported")
+	err = client.UpgradeSeriesAbort("0")
+	c.Assert(err, gc.ErrorMatches, "upgrade-series abort not supported")
+}
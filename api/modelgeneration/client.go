@@ -0,0 +1,124 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the model generation API end point, which
+// manages branches of staged charm config changes.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the model generation api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelGeneration")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddBranch creates a new branch in the model.
+func (c *Client) AddBranch(branchName string) error {
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("AddBranch", params.BranchArg{BranchName: branchName}, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// TrackBranch moves the named units, or all the units of the named
+// applications, onto the branch.
+func (c *Client) TrackBranch(branchName string, entities []string) error {
+	arg := params.BranchTrackArg{
+		BranchName: branchName,
+		Entities:   make([]params.Entity, len(entities)),
+	}
+	for i, entity := range entities {
+		switch {
+		case names.IsValidUnit(entity):
+			arg.Entities[i].Tag = names.NewUnitTag(entity).String()
+		case names.IsValidApplication(entity):
+			arg.Entities[i].Tag = names.NewApplicationTag(entity).String()
+		default:
+			return errors.NotValidf("unit or application name %q", entity)
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("TrackBranch", arg, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// SetBranchConfig stages changes to an application's config and charm
+// settings on the branch. Settings named in reset are restored to their
+// defaults when the branch is committed.
+func (c *Client) SetBranchConfig(branchName, application string, config map[string]string, reset []string) error {
+	arg := params.BranchConfigArg{
+		BranchName:  branchName,
+		Application: application,
+		Config:      config,
+		Reset:       reset,
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SetBranchConfig", arg, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// CommitBranch applies the charm config staged on the branch to all
+// units and removes the branch.
+func (c *Client) CommitBranch(branchName string) error {
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("CommitBranch", params.BranchArg{BranchName: branchName}, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// AbortBranch discards the charm config staged on the branch and
+// removes it.
+func (c *Client) AbortBranch(branchName string) error {
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("AbortBranch", params.BranchArg{BranchName: branchName}, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// BranchInfo returns details of the named branches, or of all the
+// model's branches if no names are given.
+func (c *Client) BranchInfo(branchNames ...string) ([]params.Branch, error) {
+	var result params.BranchResults
+	err := c.facade.FacadeCall("BranchInfo", params.BranchInfoArgs{BranchNames: branchNames}, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Branches, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type ModelGenerationSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ModelGenerationSuite{})
+
+func (s *ModelGenerationSuite) TestAddBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelGeneration")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AddBranch")
+			c.Check(a, jc.DeepEquals, params.BranchArg{BranchName: "new-config"})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+			*(result.(*params.ErrorResult)) = params.ErrorResult{
+				Error: common.ServerError(errors.AlreadyExistsf("branch %q", "new-config")),
+			}
+			return nil
+		})
+	client := modelgeneration.NewClient(apiCaller)
+	err := client.AddBranch("new-config")
+	c.Assert(err, gc.ErrorMatches, `branch "new-config" already exists`)
+}
+
+func (s *ModelGenerationSuite) TestTrackBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelGeneration")
+			c.Check(request, gc.Equals, "TrackBranch")
+			c.Check(a, jc.DeepEquals, params.BranchTrackArg{
+				BranchName: "new-config",
+				Entities: []params.Entity{
+					{Tag: "unit-mysql-0"},
+					{Tag: "application-wordpress"},
+				},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {}},
+			}
+			return nil
+		})
+	client := modelgeneration.NewClient(apiCaller)
+	err := client.TrackBranch("new-config", []string{"mysql/0", "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelGenerationSuite) TestTrackBranchInvalidEntity(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fail()
+			return nil
+		})
+	client := modelgeneration.NewClient(apiCaller)
+	err := client.TrackBranch("new-config", []string{"0"})
+	c.Assert(err, gc.ErrorMatches, `unit or application name "0" not valid`)
+}
+
+func (s *ModelGenerationSuite) TestSetBranchConfig(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelGeneration")
+			c.Check(request, gc.Equals, "SetBranchConfig")
+			c.Check(a, jc.DeepEquals, params.BranchConfigArg{
+				BranchName:  "new-config",
+				Application: "mysql",
+				Config:      map[string]string{"workers": "4"},
+				Reset:       []string{"title"},
+			})
+			return nil
+		})
+	client := modelgeneration.NewClient(apiCaller)
+	err := client.SetBranchConfig("new-config", "mysql", map[string]string{"workers": "4"}, []string{"title"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelGenerationSuite) TestCommitBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelGeneration")
+			c.Check(request, gc.Equals, "CommitBranch")
+			c.Check(a, jc.DeepEquals, params.BranchArg{BranchName: "new-config"})
+			return errors.New("boom")
+		})
+	client := modelgeneration.NewClient(apiCaller)
+	err := client.CommitBranch("new-config")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ModelGenerationSuite) TestAbortBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelGeneration")
+			c.Check(request, gc.Equals, "AbortBranch")
+			c.Check(a, jc.DeepEquals, params.BranchArg{BranchName: "new-config"})
+			return nil
+		})
+	client := modelgeneration.NewClient(apiCaller)
+	err := client.AbortBranch("new-config")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelGenerationSuite) TestBranchInfo(c *gc.C) {
+	branch := params.Branch{
+		BranchName:    "new-config",
+		Created:       1500000000,
+		CreatedBy:     "admin",
+		AssignedUnits: map[string][]string{"mysql": {"mysql/0"}},
+		Config:        map[string]map[string]interface{}{"mysql": {"title": "x"}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelGeneration")
+			c.Check(request, gc.Equals, "BranchInfo")
+			c.Check(a, jc.DeepEquals, params.BranchInfoArgs{BranchNames: []string{"new-config"}})
+			*(result.(*params.BranchResults)) = params.BranchResults{
+				Branches: []params.Branch{branch},
+			}
+			return nil
+		})
+	client := modelgeneration.NewClient(apiCaller)
+	branches, err := client.BranchInfo("new-config")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branches, jc.DeepEquals, []params.Branch{branch})
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type cloudSpecSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&cloudSpecSuite{})
+
+func (s *cloudSpecSuite) TestCloudSpec(c *gc.C) {
+	spec := &params.CloudSpec{
+		Type:   "ec2",
+		Name:   "aws",
+		Region: "us-east-1",
+		Credential: &params.CloudCredential{
+			AuthType:   "access-key",
+			Attributes: map[string]string{"access-key": "key", "secret-key": "secret"},
+		},
+	}
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(version, gc.Equals, expectedVersion)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "CloudSpec")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.CloudSpecResult{})
+		*(result.(*params.CloudSpecResult)) = params.CloudSpecResult{Result: spec}
+		called = true
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	result, err := st.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, spec)
+}
+
+func (s *cloudSpecSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.CloudSpecResult)) = params.CloudSpecResult{
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	_, err := st.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
+
+func (s *cloudSpecSuite) TestCloudSpecOldFacadeVersion(c *gc.C) {
+	s.PatchValue(&uniter.NewState, uniter.NewStateV4)
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	_, err := st.CloudSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
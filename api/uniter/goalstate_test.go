@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type goalStateSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&goalStateSuite{})
+
+func (s *goalStateSuite) TestGoalState(c *gc.C) {
+	since := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(version, gc.Equals, expectedVersion)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "GoalStates")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.GoalStateResults{})
+		*(result.(*params.GoalStateResults)) = params.GoalStateResults{
+			Results: []params.GoalStateResult{{
+				Result: &params.GoalState{
+					Units: params.UnitsGoalState{
+						"mysql/0": {Status: "active", Since: &since},
+					},
+					Relations: map[string]params.UnitsGoalState{
+						"server": {
+							"wordpress/0": {Status: "dying", Since: &since},
+						},
+					},
+				},
+			}},
+		}
+		called = true
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	goalState, err := st.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(goalState, jc.DeepEquals, application.GoalState{
+		Units: application.UnitsGoalState{
+			"mysql/0": {Status: "active", Since: &since},
+		},
+		Relations: map[string]application.UnitsGoalState{
+			"server": {
+				"wordpress/0": {Status: "dying", Since: &since},
+			},
+		},
+	})
+}
+
+func (s *goalStateSuite) TestGoalStateError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.GoalStateResults)) = params.GoalStateResults{
+			Results: []params.GoalStateResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	_, err := st.GoalState()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *goalStateSuite) TestGoalStateOldFacadeVersion(c *gc.C) {
+	s.PatchValue(&uniter.NewState, uniter.NewStateV4)
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	_, err := st.GoalState()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
@@ -70,12 +71,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV7 creates a new client-side Uniter facade, version 7
-var newStateV7 = newStateForVersionFn(7)
+// newStateV8 creates a new client-side Uniter facade, version 8
+var newStateV8 = newStateForVersionFn(8)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV7
+var NewState = newStateV8
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -422,3 +423,67 @@ func (st *State) SLALevel() (string, error) {
 	}
 	return result.Result, nil
 }
+
+// GoalState returns the expected units of the authenticated unit's
+// application and of each related application, along with their
+// current status.
+func (st *State) GoalState() (application.GoalState, error) {
+	if st.BestAPIVersion() < 8 {
+		return application.GoalState{}, errors.NotImplementedf("GoalState() (need V8+)")
+	}
+	var result params.GoalStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.unitTag.String()}},
+	}
+	err := st.facade.FacadeCall("GoalStates", args, &result)
+	if err != nil {
+		return application.GoalState{}, errors.Trace(err)
+	}
+	if len(result.Results) != 1 {
+		return application.GoalState{}, errors.Errorf("expected 1 result, got %d", len(result.Results))
+	}
+	if err := result.Results[0].Error; err != nil {
+		return application.GoalState{}, errors.Trace(err)
+	}
+	return goalStateFromParams(result.Results[0].Result), nil
+}
+
+// CloudSpec returns the cloud spec of the model in which the
+// authenticated unit resides. The unit's application must have been
+// granted trust.
+func (st *State) CloudSpec() (*params.CloudSpec, error) {
+	if st.BestAPIVersion() < 8 {
+		return nil, errors.NotImplementedf("CloudSpec() (need V8+)")
+	}
+	var result params.CloudSpecResult
+	err := st.facade.FacadeCall("CloudSpec", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := result.Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Result, nil
+}
+
+func goalStateFromParams(in *params.GoalState) application.GoalState {
+	out := application.GoalState{
+		Units:     unitsGoalStateFromParams(in.Units),
+		Relations: make(map[string]application.UnitsGoalState),
+	}
+	for endpoint, units := range in.Relations {
+		out.Relations[endpoint] = unitsGoalStateFromParams(units)
+	}
+	return out
+}
+
+func unitsGoalStateFromParams(in params.UnitsGoalState) application.UnitsGoalState {
+	out := make(application.UnitsGoalState)
+	for name, status := range in {
+		out[name] = application.GoalStateStatus{
+			Status: status.Status,
+			Since:  status.Since,
+		}
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries implements the client-side API facade used
+// by the upgradeseries worker.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/watcher"
+)
+
+// Facade provides access to the UpgradeSeries API facade.
+type Facade struct {
+	machineTag names.MachineTag
+	caller     base.FacadeCaller
+}
+
+// NewFacade creates a new client-side UpgradeSeries facade for the
+// given machine.
+func NewFacade(caller base.APICaller, machineTag names.MachineTag) *Facade {
+	return &Facade{
+		machineTag: machineTag,
+		caller:     base.NewFacadeCaller(caller, "UpgradeSeries"),
+	}
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher that fires
+// when the series upgrade of the machine progresses.
+func (f *Facade) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := f.caller.FacadeCall("WatchUpgradeSeriesNotifications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// MachineStatus returns the progress of the machine through its series
+// upgrade.
+func (f *Facade) MachineStatus() (model.UpgradeSeriesStatus, error) {
+	var result params.UpgradeSeriesStatusResult
+	if err := f.caller.FacadeCall("MachineStatus", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return model.UpgradeSeriesStatus(result.Status), nil
+}
+
+// SetMachineStatus records the progress of the machine through its
+// series upgrade.
+func (f *Facade) SetMachineStatus(status model.UpgradeSeriesStatus) error {
+	args := params.UpgradeSeriesStatusParam{
+		Entity: params.Entity{Tag: f.machineTag.String()},
+		Status: string(status),
+	}
+	var result params.ErrorResult
+	if err := f.caller.FacadeCall("SetMachineStatus", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// TargetSeries returns the series to which the machine is being
+// upgraded.
+func (f *Facade) TargetSeries() (string, error) {
+	var result params.StringResult
+	if err := f.caller.FacadeCall("TargetSeries", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// UnitStatuses returns the progress of each unit on the machine through
+// the series upgrade, keyed on unit name.
+func (f *Facade) UnitStatuses() (map[string]model.UpgradeSeriesStatus, error) {
+	var result params.UpgradeSeriesUnitStatusesResult
+	if err := f.caller.FacadeCall("UnitStatuses", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	statuses := make(map[string]model.UpgradeSeriesStatus, len(result.Statuses))
+	for _, s := range result.Statuses {
+		tag, err := names.ParseUnitTag(s.Entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses[tag.Id()] = model.UpgradeSeriesStatus(s.Status)
+	}
+	return statuses, nil
+}
+
+// FinishUpgradeSeries records the new series of the machine and its
+// units and removes the series upgrade lock.
+func (f *Facade) FinishUpgradeSeries() error {
+	var result params.ErrorResult
+	if err := f.caller.FacadeCall("FinishUpgradeSeries", nil, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) newFacade(c *gc.C, stub *testing.Stub, result interface{}) *upgradeseries.Facade {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "UpgradeSeries")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		switch r := response.(type) {
+		case *params.UpgradeSeriesStatusResult:
+			*r = result.(params.UpgradeSeriesStatusResult)
+		case *params.ErrorResult:
+			*r = result.(params.ErrorResult)
+		case *params.StringResult:
+			*r = result.(params.StringResult)
+		case *params.UpgradeSeriesUnitStatusesResult:
+			*r = result.(params.UpgradeSeriesUnitStatusesResult)
+		default:
+			c.Fatalf("unexpected response type %T", response)
+		}
+		return stub.NextErr()
+	})
+	return upgradeseries.NewFacade(apiCaller, names.NewMachineTag("0"))
+}
+
+func (s *facadeSuite) TestMachineStatus(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, params.UpgradeSeriesStatusResult{Status: "prepare started"})
+
+	status, err := facade.MachineStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, model.UpgradeSeriesPrepareStarted)
+	stub.CheckCalls(c, []testing.StubCall{{"MachineStatus", []interface{}{nil}}})
+}
+
+func (s *facadeSuite) TestMachineStatusError(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, params.UpgradeSeriesStatusResult{
+		Error: &params.Error{Message: "boom"},
+	})
+
+	_, err := facade.MachineStatus()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestSetMachineStatus(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, params.ErrorResult{})
+
+	err := facade.SetMachineStatus(model.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetMachineStatus", []interface{}{params.UpgradeSeriesStatusParam{
+			Entity: params.Entity{Tag: "machine-0"},
+			Status: "prepare completed",
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestTargetSeries(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, params.StringResult{Result: "bionic"})
+
+	series, err := facade.TargetSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(series, gc.Equals, "bionic")
+}
+
+func (s *facadeSuite) TestUnitStatuses(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, params.UpgradeSeriesUnitStatusesResult{
+		Statuses: []params.UpgradeSeriesUnitStatus{{
+			Entity: params.Entity{Tag: "unit-mysql-0"},
+			Status: "prepare completed",
+		}},
+	})
+
+	statuses, err := facade.UnitStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses, jc.DeepEquals, map[string]model.UpgradeSeriesStatus{
+		"mysql/0": model.UpgradeSeriesPrepareCompleted,
+	})
+}
+
+func (s *facadeSuite) TestFinishUpgradeSeries(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, params.ErrorResult{
+		Error: &params.Error{Message: "not yet"},
+	})
+
+	err := facade.FinishUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, "not yet")
+	stub.CheckCallNames(c, "FinishUpgradeSeries")
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
@@ -136,13 +137,14 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		modelTag = a.root.model.Tag().String()
 	}
 
-	auditRecorder, err := a.getAuditRecorder(req, authResult)
+	auditConfig, auditLogger := a.srv.auditConfig()
+	auditRecorder, err := a.getAuditRecorder(req, authResult, auditConfig, auditLogger)
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
 
 	recorderFactory := observer.NewRecorderFactory(
-		a.apiObserver, auditRecorder, a.srv.auditLogConfig.CaptureAPIArgs)
+		a.apiObserver, auditRecorder, auditConfig.CaptureAPIArgs)
 
 	a.root.rpcConn.ServeRoot(apiRoot, recorderFactory, serverError)
 	return params.LoginResult{
@@ -156,16 +158,35 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	}, nil
 }
 
-func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult) (*auditlog.Recorder, error) {
-	if !authResult.userLogin || a.srv.auditLogger == nil {
+// auditedAgentMethods holds the methods which are recorded in the
+// audit log when they are called by agents.
+var auditedAgentMethods = set.NewStrings("Uniter.CloudSpec")
+
+func (a *admin) getAuditRecorder(
+	req params.LoginRequest,
+	authResult *authResult,
+	config AuditLogConfig,
+	auditLogger auditlog.AuditLog,
+) (*auditlog.Recorder, error) {
+	if auditLogger == nil {
+		return nil, nil
+	}
+	var log auditlog.AuditLog
+	switch {
+	case authResult.userLogin:
+		// Wrap the audit logger in a filter that prevents us from logging
+		// lots of readonly conversations (like "juju status" requests).
+		filter := observer.MakeInterestingRequestFilter(config.ExcludeMethods)
+		log = observer.NewAuditLogFilter(auditLogger, filter)
+	case authResult.tag != nil && authResult.tag.Kind() == names.UnitTagKind:
+		// Unit agents are only audited when they
+		// access the model's cloud credential.
+		log = observer.NewAuditLogMethodFilter(auditLogger, auditedAgentMethods)
+	default:
 		return nil, nil
 	}
-	// Wrap the audit logger in a filter that prevents us from logging
-	// lots of readonly conversations (like "juju status" requests).
-	filter := observer.MakeInterestingRequestFilter(a.srv.auditLogConfig.ExcludeMethods)
 	result, err := auditlog.NewRecorder(
-		observer.NewAuditLogFilter(
-			a.srv.auditLogger, filter),
+		log,
 		a.srv.clock,
 		auditlog.ConversationArgs{
 			Who:          req.AuthTag,
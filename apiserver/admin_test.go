@@ -1090,6 +1090,50 @@ func (s *loginSuite) TestAuditLoggingUsesExcludeMethods(c *gc.C) {
 	c.Assert(req2.Method, gc.Equals, "DestroyMachines")
 }
 
+func (s *loginSuite) TestAuditLoggingUnitCredentialAccess(c *gc.C) {
+	log := &servertesting.FakeAuditLog{}
+	cfg := defaultServerConfig(c)
+	cfg.AuditLogConfig.Enabled = true
+	cfg.AuditLog = log
+	info, srv := newServerWithConfig(c, s.StatePool, cfg)
+	defer assertStop(c, srv)
+	info.ModelTag = s.IAASModel.Tag().(names.ModelTag)
+
+	unit, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	conn := s.openAPIWithoutLogin(c, info)
+
+	var result params.LoginResult
+	request := &params.LoginRequest{
+		AuthTag:     unit.Tag().String(),
+		Credentials: password,
+	}
+	err := conn.APICall("Admin", 3, "", "Login", request, &result)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var modelResult params.ModelResult
+	err = conn.APICall("Uniter", 8, "", "CurrentModel", nil, &modelResult)
+	c.Assert(err, jc.ErrorIsNil)
+	// Only credential access is audited for agents.
+	log.CheckCallNames(c)
+
+	var cloudSpecResult params.CloudSpecResult
+	err = conn.APICall("Uniter", 8, "", "CloudSpec", nil, &cloudSpecResult)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudSpecResult.Error, gc.ErrorMatches, "permission denied")
+
+	log.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse")
+	convo := log.Calls()[0].Args[0].(auditlog.Conversation)
+	c.Assert(convo.Who, gc.Equals, unit.Tag().String())
+	auditReq := log.Calls()[1].Args[0].(auditlog.Request)
+	c.Assert(auditReq.Facade, gc.Equals, "Uniter")
+	c.Assert(auditReq.Method, gc.Equals, "CloudSpec")
+	auditResp := log.Calls()[2].Args[0].(auditlog.ResponseErrors)
+	c.Assert(auditResp.Errors, jc.DeepEquals, []*auditlog.Error{{
+		Message: "permission denied",
+		Code:    params.CodeUnauthorized,
+	}})
+}
+
 var _ = gc.Suite(&macaroonLoginSuite{})
 
 type macaroonLoginSuite struct {
@@ -37,6 +37,7 @@ import (
 	"github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/logsink"
+	"github.com/juju/juju/apiserver/modelevents"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/websocket"
 	"github.com/juju/juju/core/auditlog"
@@ -70,6 +71,7 @@ type Server struct {
 	offerAuthCtxt          *crossmodel.AuthContext
 	lastConnectionID       uint64
 	centralHub             *pubsub.StructuredHub
+	modelEvents            *modelevents.Streams
 	newObserver            observer.ObserverFactory
 	connCount              int64
 	totalConn              int64
@@ -80,8 +82,6 @@ type Server struct {
 	logSinkWriter          io.WriteCloser
 	logsinkRateLimitConfig logsink.RateLimitConfig
 	dbloggers              dbloggers
-	auditLogConfig         AuditLogConfig
-	auditLogger            auditlog.AuditLog
 	upgradeComplete        func() bool
 	restoreStatus          func() state.RestoreStatus
 
@@ -95,6 +95,12 @@ type Server struct {
 	// hence it's here guarded by the mutex.
 	publicDNSName_ string
 
+	// auditLogConfig and auditLogger hold the audit logging
+	// configuration. They are replaced when the controller
+	// configuration changes; see UpdateAuditLogConfig.
+	auditLogConfig AuditLogConfig
+	auditLogger    auditlog.AuditLog
+
 	// registerIntrospectionHandlers is a function that will
 	// call a function with (path, http.Handler) tuples. This
 	// is to support registering the handlers underneath the
@@ -304,6 +310,24 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 	}
 	srv.logSinkWriter = logSinkWriter
 
+	// Model events are delivered through the central hub when there is
+	// one; they are never forwarded to other controllers, so a private
+	// hub does just as well otherwise.
+	eventsHub := cfg.Hub
+	if eventsHub == nil {
+		eventsHub = pubsub.NewStructuredHub(nil)
+	}
+	srv.modelEvents, err = modelevents.NewStreams(modelevents.Config{
+		Hub:         eventsHub,
+		Clock:       cfg.Clock,
+		NewWatcher:  srv.newModelWatcher,
+		BufferSize:  modelevents.DefaultBufferSize,
+		IdleTimeout: modelevents.DefaultIdleTimeout,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating model event streams")
+	}
+
 	if cfg.PrometheusRegisterer != nil {
 		apiserverCollectior := NewMetricsCollector(&metricAdaptor{srv})
 		cfg.PrometheusRegisterer.Unregister(apiserverCollectior)
@@ -440,6 +464,7 @@ func (srv *Server) loop() error {
 		logger.Infof("closed listening socket %q with final error: %v", addr, err)
 
 		srv.wg.Wait() // wait for any outstanding requests to complete.
+		srv.modelEvents.Stop()
 		srv.dbloggers.dispose()
 		srv.logSinkWriter.Close()
 	}()
@@ -512,6 +537,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	logStreamHandler := srv.trackRequests(newLogStreamEndpointHandler(httpCtxt))
 	debugLogHandler := srv.trackRequests(newDebugLogDBHandler(httpCtxt))
 	pubsubHandler := srv.trackRequests(newPubSubHandler(httpCtxt, srv.centralHub))
+	modelEventsHandler := srv.trackRequests(newModelEventsHandler(httpCtxt, srv.modelEvents))
 
 	// This handler is model specific even though it only ever makes sense
 	// for a controller because the API caller that is handed to the worker
@@ -520,6 +546,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/pubsub", pubsubHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/events", modelEventsHandler)
 
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
@@ -851,6 +878,29 @@ func (srv *Server) publicDNSName() string {
 	return srv.publicDNSName_
 }
 
+// auditConfig returns the current audit logging configuration and the
+// log that conversations are recorded in, which is nil if auditing is
+// disabled.
+func (srv *Server) auditConfig() (AuditLogConfig, auditlog.AuditLog) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.auditLogConfig, srv.auditLogger
+}
+
+// UpdateAuditLogConfig replaces the audit logging configuration used
+// for connections that log in after the call. Conversations already
+// in progress continue to be recorded in the log they started in.
+func (srv *Server) UpdateAuditLogConfig(config AuditLogConfig, log auditlog.AuditLog) error {
+	if config.Enabled && log == nil {
+		return errors.NotValidf("audit logging enabled but no logger provided")
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.auditLogConfig = config
+	srv.auditLogger = log
+	return nil
+}
+
 // localCertificate returns the local server certificate and reports
 // whether it should be used to serve a connection addressed to the
 // given server name.
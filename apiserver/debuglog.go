@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/state"
 )
 
@@ -60,6 +61,15 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeLabel -> []string - only include records with all of these labels
+//   includeField -> []string - only include records with all of these fields,
+//      - each given as name=value
+//   messageContains -> string - only include records whose message contains
+//      - this text
+//   startTime -> string - only include records logged at or after this
+//      - time, given in RFC3339 format
+//   endTime -> string - only include records logged at or before this
+//      - time, given in RFC3339 format; new records are not waited for
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
@@ -148,16 +158,20 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime       time.Time
+	endTime         time.Time
+	maxLines        uint
+	fromTheStart    bool
+	noTail          bool
+	backlog         uint
+	filterLevel     loggo.Level
+	includeEntity   []string
+	excludeEntity   []string
+	includeModule   []string
+	excludeModule   []string
+	includeLabels   []string
+	includeFields   map[string]string
+	messageContains string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -213,10 +227,41 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before start time", value)
+		}
+		params.endTime = endTime
+	}
+
+	params.messageContains = queryMap.Get("messageContains")
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
 
+	if labels := queryMap["includeLabel"]; len(labels) > 0 {
+		data := logfields.Data{Labels: labels}
+		if err := data.Validate(); err != nil {
+			return params, errors.Trace(err)
+		}
+		params.includeLabels = labels
+	}
+	for _, value := range queryMap["includeField"] {
+		name, fieldValue, err := logfields.ParseField(value)
+		if err != nil {
+			return params, errors.Trace(err)
+		}
+		if params.includeFields == nil {
+			params.includeFields = make(map[string]string)
+		}
+		params.includeFields[name] = fieldValue
+	}
+
 	return params, nil
 }
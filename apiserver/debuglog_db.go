@@ -56,14 +56,18 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:        reqParams.filterLevel,
+		NoTail:          reqParams.noTail,
+		StartTime:       reqParams.startTime,
+		EndTime:         reqParams.endTime,
+		InitialLines:    int(reqParams.backlog),
+		IncludeEntity:   reqParams.includeEntity,
+		ExcludeEntity:   reqParams.excludeEntity,
+		IncludeModule:   reqParams.includeModule,
+		ExcludeModule:   reqParams.excludeModule,
+		IncludeLabels:   reqParams.includeLabels,
+		IncludeFields:   reqParams.includeFields,
+		MessageContains: reqParams.messageContains,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -79,6 +83,8 @@ func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 		Module:    r.Module,
 		Location:  r.Location,
 		Message:   r.Message,
+		Labels:    r.Labels,
+		Fields:    r.Fields,
 	}
 }
 
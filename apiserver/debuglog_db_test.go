@@ -31,6 +31,27 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogDBSuite) TestBadFieldParam(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"includeField": {"hook"}})
+	websockettest.AssertJSONError(c, reader, `expected name=value, got "hook"`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestBadLabelParam(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"includeLabel": {"$bad"}})
+	websockettest.AssertJSONError(c, reader, `label "\$bad" not valid`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestBadEndTimeParam(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{
+		"startTime": {"2018-05-02T10:00:00Z"},
+		"endTime":   {"2018-05-01T10:00:00Z"},
+	})
+	websockettest.AssertJSONError(c, reader, `end time "2018-05-01T10:00:00Z" is before start time`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
@@ -278,8 +278,8 @@ func (f *Facade) SetContainerSpec(args params.SetContainerSpecParams) (params.Er
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		if _, err := caas.ParseContainerSpec(arg.Value); err != nil {
-			results.Results[i].Error = common.ServerError(errors.New("invalid container spec"))
+		if _, err := caas.ParsePodSpec(arg.Value); err != nil {
+			results.Results[i].Error = common.ServerError(errors.Annotate(err, "invalid container spec"))
 			continue
 		}
 		results.Results[i].Error = common.ServerError(
@@ -195,7 +195,7 @@ image-name: gitlab/latest
 		Entities: []params.EntityString{
 			{Tag: "application-gitlab", Value: validSpecStr},
 			{Tag: "unit-gitlab-0", Value: validSpecStr},
-			{Tag: "unit-gitlab-1", Value: "bad spec"},
+			{Tag: "unit-gitlab-1", Value: "version: 1\ncontainers: []"},
 			{Tag: "unit-gitlab-2", Value: validSpecStr},
 			{Tag: "application-other"},
 			{Tag: "unit-other-0"},
@@ -214,7 +214,7 @@ image-name: gitlab/latest
 			Error: nil,
 		}, {
 			Error: &params.Error{
-				Message: "invalid container spec",
+				Message: "invalid container spec: spec has no containers",
 			},
 		}, {
 			Error: &params.Error{
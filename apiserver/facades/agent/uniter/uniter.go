@@ -15,12 +15,15 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/facade"
 	leadershipapiserver "github.com/juju/juju/apiserver/facades/agent/leadership"
 	"github.com/juju/juju/apiserver/facades/agent/meterstatus"
 	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -31,7 +34,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v7) of the Uniter API.
+// UniterAPI implements the latest version (v8) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -50,12 +53,20 @@ type UniterAPI struct {
 	accessApplication common.GetAuthFunc
 	unit              *state.Unit
 	accessMachine     common.GetAuthFunc
+	cloudSpec         cloudspec.CloudSpecAPI
 	StorageAPI
 }
 
+// UniterAPIV7 doesn't have the UpgradeSeriesStatus,
+// SetUpgradeSeriesStatus, WatchUpgradeSeriesNotifications, GoalStates
+// or CloudSpec methods.
+type UniterAPIV7 struct {
+	UniterAPI
+}
+
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
 type UniterAPIV6 struct {
-	UniterAPI
+	UniterAPIV7
 }
 
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
@@ -166,18 +177,33 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 		accessApplication: accessApplication,
 		accessMachine:     accessMachine,
 		unit:              unit,
-		StorageAPI:        *storageAPI,
+		cloudSpec: cloudspec.NewCloudSpec(
+			cloudspec.MakeCloudSpecGetterForModel(st),
+			common.AuthFuncForTag(m.ModelTag()),
+		),
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
+// NewUniterAPIV7 creates an instance of the V7 uniter API.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{
+		UniterAPI: *uniterAPI,
 	}, nil
 }
 
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
 func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV6{
-		UniterAPI: *uniterAPI,
+		UniterAPIV7: *uniterAPI,
 	}, nil
 }
 
@@ -2177,6 +2203,293 @@ func (u *UniterAPIV4) watchOneApplicationRelations(tag names.ApplicationTag) (pa
 	return nothing, watcher.EnsureErr(watch)
 }
 
+// UpgradeSeriesStatus returns the progress of each given unit through
+// the series upgrade of its machine.
+func (u *UniterAPI) UpgradeSeriesStatus(args params.Entities) (params.UpgradeSeriesStatusResults, error) {
+	result := params.UpgradeSeriesStatusResults{
+		Results: make([]params.UpgradeSeriesStatusResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UpgradeSeriesStatusResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		status, err := unit.UpgradeSeriesStatus()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Status = string(status)
+	}
+	return result, nil
+}
+
+// SetUpgradeSeriesStatus records the progress of each given unit
+// through the series upgrade of its machine.
+func (u *UniterAPI) SetUpgradeSeriesStatus(args params.UpgradeSeriesStatusParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Params)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Params {
+		tag, err := names.ParseUnitTag(arg.Entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.SetUpgradeSeriesStatus(model.UpgradeSeriesStatus(arg.Status))
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for observing
+// changes to the series upgrade of the machine of each given unit.
+func (u *UniterAPI) WatchUpgradeSeriesNotifications(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneUnitUpgradeSeriesNotifications(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneUnitUpgradeSeriesNotifications(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	watch, err := unit.WatchUpgradeSeriesNotifications()
+	if err != nil {
+		return "", err
+	}
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// GoalStates returns the expected units of the application of each
+// given unit, and of each application related to it, along with their
+// current status.
+func (u *UniterAPI) GoalStates(args params.Entities) (params.GoalStateResults, error) {
+	result := params.GoalStateResults{
+		Results: make([]params.GoalStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.GoalStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		goalState, err := u.oneGoalState(unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = goalState
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) oneGoalState(unit *state.Unit) (*params.GoalState, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Subordinate units only see the subordinates of their own
+	// principal.
+	principalName, _ := unit.PrincipalName()
+
+	units, err := u.goalStateUnits(app, principalName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	goalState := &params.GoalState{
+		Units:     units,
+		Relations: make(map[string]params.UnitsGoalState),
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		related, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relUnits := goalState.Relations[ep.Name]
+		if relUnits == nil {
+			relUnits = make(params.UnitsGoalState)
+			goalState.Relations[ep.Name] = relUnits
+		}
+		for _, relatedEp := range related {
+			if err := u.addRelatedGoalState(relUnits, relatedEp.ApplicationName, principalName); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	return goalState, nil
+}
+
+// addRelatedGoalState adds the goal state of the named application's
+// units to relUnits. Applications offered from other models have no
+// visible units, so the status of the application itself is added.
+func (u *UniterAPI) addRelatedGoalState(relUnits params.UnitsGoalState, appName, principalName string) error {
+	app, err := u.st.Application(appName)
+	if errors.IsNotFound(err) {
+		remoteApp, err := u.st.RemoteApplication(appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		statusInfo, err := remoteApp.Status()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		relUnits[appName] = goalStateStatus(remoteApp.Life(), statusInfo)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := u.goalStateUnits(app, principalName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name, status := range units {
+		relUnits[name] = status
+	}
+	return nil
+}
+
+// goalStateUnits returns the goal state of the units of the given
+// application. If principalName is set, subordinate units of other
+// principals are excluded.
+func (u *UniterAPI) goalStateUnits(app *state.Application, principalName string) (params.UnitsGoalState, error) {
+	allUnits, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units := make(params.UnitsGoalState)
+	for _, unit := range allUnits {
+		if unitPrincipal, ok := unit.PrincipalName(); ok && principalName != "" && unitPrincipal != principalName {
+			continue
+		}
+		if unit.Life() == state.Dead {
+			continue
+		}
+		statusInfo, err := unit.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units[unit.Name()] = goalStateStatus(unit.Life(), statusInfo)
+	}
+	return units, nil
+}
+
+func goalStateStatus(life state.Life, statusInfo status.StatusInfo) params.GoalStateStatus {
+	result := params.GoalStateStatus{
+		Status: statusInfo.Status.String(),
+		Since:  statusInfo.Since,
+	}
+	if life == state.Dying {
+		result.Status = life.String()
+	}
+	return result
+}
+
+// CloudSpec returns the cloud spec of the model in which the
+// authenticated unit resides. Only units of applications which have
+// been granted trust may see it. Each call, allowed or not, is
+// recorded in the audit log when auditing is enabled.
+func (u *UniterAPI) CloudSpec() (params.CloudSpecResult, error) {
+	trusted, err := u.isTrusted()
+	if err != nil {
+		return params.CloudSpecResult{Error: common.ServerError(err)}, nil
+	}
+	if !trusted {
+		logger.Warningf("unit %q denied access to the cloud credential of model %q", u.unit.Name(), u.m.Name())
+		return params.CloudSpecResult{Error: common.ServerError(common.ErrPerm)}, nil
+	}
+	logger.Infof("unit %q accessed the cloud credential of model %q", u.unit.Name(), u.m.Name())
+	return u.cloudSpec.GetCloudSpec(u.m.ModelTag()), nil
+}
+
+// isTrusted reports whether the authenticated unit's application has
+// been granted access to the model's cloud credential.
+func (u *UniterAPI) isTrusted() (bool, error) {
+	app, err := u.unit.Application()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return config.GetBool(coreapplication.TrustConfigOptionName, false), nil
+}
+
 // Mask the new methods from the V4 API. The API reflection code in
 // rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
 // removes the method as far as the RPC machinery is concerned.
@@ -2190,6 +2503,21 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
 
+// UpgradeSeriesStatus isn't on the V7 API.
+func (u *UniterAPIV7) UpgradeSeriesStatus(_, _ struct{}) {}
+
+// SetUpgradeSeriesStatus isn't on the V7 API.
+func (u *UniterAPIV7) SetUpgradeSeriesStatus(_, _ struct{}) {}
+
+// WatchUpgradeSeriesNotifications isn't on the V7 API.
+func (u *UniterAPIV7) WatchUpgradeSeriesNotifications(_, _ struct{}) {}
+
+// GoalStates isn't on the V7 API.
+func (u *UniterAPIV7) GoalStates(_, _ struct{}) {}
+
+// CloudSpec isn't on the V7 API.
+func (u *UniterAPIV7) CloudSpec(_, _ struct{}) {}
+
 func networkInfoResultsToV6(v7Results params.NetworkInfoResults) params.NetworkInfoResultsV6 {
 	results := make(map[string]params.NetworkInfoResultV6)
 	for k, v6Result := range v7Results.Results {
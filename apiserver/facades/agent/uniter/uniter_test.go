@@ -8,10 +8,12 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -989,6 +992,149 @@ func (s *uniterSuite) TestWatchConfigSettings(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *uniterSuite) TestUpgradeSeriesStatus(c *gc.C) {
+	err := s.machine0.CreateUpgradeSeriesLock("xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.UpgradeSeriesStatusParams{Params: []params.UpgradeSeriesStatusParam{
+		{Entity: params.Entity{Tag: "unit-mysql-0"}, Status: "prepare completed"},
+		{Entity: params.Entity{Tag: "unit-wordpress-0"}, Status: "prepare completed"},
+		{Entity: params.Entity{Tag: "unit-foo-42"}, Status: "prepare completed"},
+	}}
+	setResult, err := s.uniter.SetUpgradeSeriesStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setResult, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	result, err := s.uniter.UpgradeSeriesStatus(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Status: "prepare completed"},
+		},
+	})
+}
+
+func (s *uniterSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+	}}
+	result, err := s.uniter.WatchUpgradeSeriesNotifications(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.machine0.CreateUpgradeSeriesLock("xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *uniterSuite) TestGoalStates(c *gc.C) {
+	s.addRelation(c, "wordpress", "mysql")
+	now := time.Now()
+	err := s.wordpressUnit.SetStatus(status.StatusInfo{
+		Status: status.Active,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysqlUnit.SetStatus(status.StatusInfo{
+		Status: status.Waiting,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// A unit whose agent is running stays dying when destroyed.
+	dyingUnit := s.Factory.MakeUnit(c, &jujufactory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine0,
+	})
+	err = dyingUnit.Agent().SetStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = dyingUnit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.GoalStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	goalState := result.Results[1].Result
+	c.Assert(goalStateStatuses(goalState.Units), jc.DeepEquals, map[string]string{
+		"wordpress/0": "active",
+		"wordpress/1": "dying",
+	})
+	c.Assert(goalState.Relations, gc.HasLen, 1)
+	c.Assert(goalStateStatuses(goalState.Relations["db"]), jc.DeepEquals, map[string]string{
+		"mysql/0": "waiting",
+	})
+	c.Assert(goalState.Units["wordpress/0"].Since, gc.NotNil)
+}
+
+func goalStateStatuses(units params.UnitsGoalState) map[string]string {
+	result := make(map[string]string)
+	for name, unitStatus := range units {
+		result[name] = unitStatus.Status
+	}
+	return result
+}
+
+func (s *uniterSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudSpecResult{
+		Error: apiservertesting.ErrUnauthorized,
+	})
+}
+
+func (s *uniterSuite) TestCloudSpecTrusted(c *gc.C) {
+	trustFields := environschema.Fields{
+		coreapplication.TrustConfigOptionName: {Type: environschema.Tbool},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.TrustConfigOptionName: true,
+	}, nil, trustFields, schema.Defaults{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+	c.Assert(result.Result.Type, gc.Equals, "dummy")
+	c.Assert(result.Result.Name, gc.Equals, "dummy")
+}
+
 func (s *uniterSuite) TestWatchActionNotifications(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Machine defines the machine functionality required by the
+// upgradeseries facade. For details on the methods, see the methods on
+// state.Machine with the same names.
+type Machine interface {
+	Tag() names.Tag
+	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	SetUpgradeSeriesStatus(model.UpgradeSeriesStatus) error
+	UpgradeSeriesTarget() (string, error)
+	UpgradeSeriesUnitStatuses() (map[string]model.UpgradeSeriesStatus, error)
+	CompleteUpgradeSeries() error
+	WatchUpgradeSeriesNotifications() state.NotifyWatcher
+}
+
+// API provides the machine agent's view of the series upgrade of its
+// machine.
+type API struct {
+	machine   Machine
+	resources facade.Resources
+	auth      facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	auth := ctx.Auth()
+	if !auth.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	tag, ok := auth.GetAuthTag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected names.MachineTag, got %T", auth.GetAuthTag())
+	}
+	machine, err := ctx.State().Machine(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(machine, ctx.Resources(), auth)
+}
+
+// NewAPI returns a new upgradeseries API facade for the given machine.
+func NewAPI(machine Machine, resources facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthMachineAgent() || !auth.AuthOwner(machine.Tag()) {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		machine:   machine,
+		resources: resources,
+		auth:      auth,
+	}, nil
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher that fires
+// when the series upgrade lock of the machine changes.
+func (api *API) WatchUpgradeSeriesNotifications() (params.NotifyWatchResult, error) {
+	var result params.NotifyWatchResult
+	watch := api.machine.WatchUpgradeSeriesNotifications()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = api.resources.Register(watch)
+	} else {
+		result.Error = common.ServerError(watcher.EnsureErr(watch))
+	}
+	return result, nil
+}
+
+// MachineStatus returns the progress of the machine through its series
+// upgrade.
+func (api *API) MachineStatus() (params.UpgradeSeriesStatusResult, error) {
+	status, err := api.machine.UpgradeSeriesStatus()
+	if err != nil {
+		return params.UpgradeSeriesStatusResult{Error: common.ServerError(err)}, nil
+	}
+	return params.UpgradeSeriesStatusResult{Status: string(status)}, nil
+}
+
+// SetMachineStatus records the progress of the machine through its
+// series upgrade.
+func (api *API) SetMachineStatus(arg params.UpgradeSeriesStatusParam) (params.ErrorResult, error) {
+	if arg.Entity.Tag != api.machine.Tag().String() {
+		return params.ErrorResult{Error: common.ServerError(common.ErrPerm)}, nil
+	}
+	err := api.machine.SetUpgradeSeriesStatus(model.UpgradeSeriesStatus(arg.Status))
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// TargetSeries returns the series to which the machine is being
+// upgraded.
+func (api *API) TargetSeries() (params.StringResult, error) {
+	series, err := api.machine.UpgradeSeriesTarget()
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: series}, nil
+}
+
+// UnitStatuses returns the progress of each unit on the machine through
+// the series upgrade.
+func (api *API) UnitStatuses() (params.UpgradeSeriesUnitStatusesResult, error) {
+	statuses, err := api.machine.UpgradeSeriesUnitStatuses()
+	if err != nil {
+		return params.UpgradeSeriesUnitStatusesResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.UpgradeSeriesUnitStatusesResult{
+		Statuses: make([]params.UpgradeSeriesUnitStatus, 0, len(statuses)),
+	}
+	for unitName, status := range statuses {
+		result.Statuses = append(result.Statuses, params.UpgradeSeriesUnitStatus{
+			Entity: params.Entity{Tag: names.NewUnitTag(unitName).String()},
+			Status: string(status),
+		})
+	}
+	return result, nil
+}
+
+// FinishUpgradeSeries records the new series of the machine and its
+// units, once they have all completed the upgrade, and removes the
+// series upgrade lock.
+func (api *API) FinishUpgradeSeries() (params.ErrorResult, error) {
+	err := api.machine.CompleteUpgradeSeries()
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
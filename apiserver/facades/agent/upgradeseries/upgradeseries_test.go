@@ -0,0 +1,159 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/upgradeseries"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.IsolationSuite
+
+	machine    *mockMachine
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *upgradeseries.API
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.machine = &mockMachine{
+		tag:    names.NewMachineTag("0"),
+		status: model.UpgradeSeriesPrepareStarted,
+		unitStatuses: map[string]model.UpgradeSeriesStatus{
+			"mysql/0": model.UpgradeSeriesPrepareCompleted,
+		},
+		target:  "bionic",
+		watcher: apiservertesting.NewFakeNotifyWatcher(),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: s.machine.tag}
+
+	var err error
+	s.api, err = upgradeseries.NewAPI(s.machine, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestNewAPIRequiresOwnMachine(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("1")
+	_, err := upgradeseries.NewAPI(s.machine, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err = upgradeseries.NewAPI(s.machine, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *UpgradeSeriesSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	result, err := s.api.WatchUpgradeSeriesNotifications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.Equals, s.machine.watcher)
+}
+
+func (s *UpgradeSeriesSuite) TestMachineStatus(c *gc.C) {
+	result, err := s.api.MachineStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpgradeSeriesStatusResult{Status: "prepare started"})
+}
+
+func (s *UpgradeSeriesSuite) TestSetMachineStatus(c *gc.C) {
+	result, err := s.api.SetMachineStatus(params.UpgradeSeriesStatusParam{
+		Entity: params.Entity{Tag: "machine-0"},
+		Status: "prepare completed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.machine.CheckCall(c, 0, "SetUpgradeSeriesStatus", model.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *UpgradeSeriesSuite) TestSetMachineStatusOtherMachine(c *gc.C) {
+	result, err := s.api.SetMachineStatus(params.UpgradeSeriesStatusParam{
+		Entity: params.Entity{Tag: "machine-1"},
+		Status: "prepare completed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "permission denied")
+	s.machine.CheckNoCalls(c)
+}
+
+func (s *UpgradeSeriesSuite) TestTargetSeries(c *gc.C) {
+	result, err := s.api.TargetSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResult{Result: "bionic"})
+}
+
+func (s *UpgradeSeriesSuite) TestUnitStatuses(c *gc.C) {
+	result, err := s.api.UnitStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpgradeSeriesUnitStatusesResult{
+		Statuses: []params.UpgradeSeriesUnitStatus{{
+			Entity: params.Entity{Tag: "unit-mysql-0"},
+			Status: "prepare completed",
+		}},
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestFinishUpgradeSeries(c *gc.C) {
+	s.machine.SetErrors(errors.New("not yet"))
+	result, err := s.api.FinishUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "not yet")
+	s.machine.CheckCallNames(c, "CompleteUpgradeSeries")
+}
+
+type mockMachine struct {
+	testing.Stub
+
+	tag          names.MachineTag
+	status       model.UpgradeSeriesStatus
+	unitStatuses map[string]model.UpgradeSeriesStatus
+	target       string
+	watcher      *apiservertesting.FakeNotifyWatcher
+}
+
+func (m *mockMachine) Tag() names.Tag {
+	return m.tag
+}
+
+func (m *mockMachine) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus) error {
+	m.MethodCall(m, "SetUpgradeSeriesStatus", status)
+	return m.NextErr()
+}
+
+func (m *mockMachine) UpgradeSeriesTarget() (string, error) {
+	return m.target, nil
+}
+
+func (m *mockMachine) UpgradeSeriesUnitStatuses() (map[string]model.UpgradeSeriesStatus, error) {
+	return m.unitStatuses, nil
+}
+
+func (m *mockMachine) CompleteUpgradeSeries() error {
+	m.MethodCall(m, "CompleteUpgradeSeries")
+	return m.NextErr()
+}
+
+func (m *mockMachine) WatchUpgradeSeriesNotifications() state.NotifyWatcher {
+	return m.watcher
+}
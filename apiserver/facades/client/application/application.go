@@ -7,8 +7,11 @@
 package application
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -30,6 +33,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -51,6 +55,21 @@ type APIv6 struct {
 	*APIv5
 }
 
+// APIv7 provides the Application API facade for version 7.
+type APIv7 struct {
+	*APIv6
+}
+
+// APIv8 provides the Application API facade for version 8.
+type APIv8 struct {
+	*APIv7
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*APIv8
+}
+
 // API implements the application interface and is the concrete
 // implementation of the api end point.
 //
@@ -90,6 +109,36 @@ func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
 	return &APIv6{apiV5}, nil
 }
 
+// NewFacadeV7 provides the signature required for facade registration
+// for version 7.
+func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
+	apiV6, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{apiV6}, nil
+}
+
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
+func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
+	apiV7, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{apiV7}, nil
+}
+
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	apiV8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{apiV8}, nil
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -173,7 +222,7 @@ func (api *APIv5) SetMetricCredentials(args params.ApplicationMetricCredentials)
 
 // Deploy fetches the charms from the charm store and deploys them
 // using the specified placement directives.
-func (api *APIv5) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
+func (api *APIv5) Deploy(ctx context.Context, args params.ApplicationsDeploy) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
@@ -183,8 +232,9 @@ func (api *APIv5) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, e
 	if err := api.check.ChangeAllowed(); err != nil {
 		return result, errors.Trace(err)
 	}
+	backend := api.backend.WithSpanContext(tracing.FromContext(ctx))
 	for i, arg := range args.Applications {
-		err := deployApplication(api.backend, api.stateCharm, arg, api.deployApplicationFunc)
+		err := deployApplication(backend, api.stateCharm, arg, api.deployApplicationFunc)
 		result.Results[i].Error = common.ServerError(err)
 
 		if err != nil && len(arg.Resources) != 0 {
@@ -195,28 +245,90 @@ func (api *APIv5) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, e
 			// TODO(babbageclunk): rework the deploy API so the
 			// resources are created transactionally to avoid needing
 			// to do this.
-			resources, err := api.backend.Resources()
-			if err != nil {
-				logger.Errorf("couldn't get backend.Resources")
-				continue
-			}
-			err = resources.RemovePendingAppResources(arg.ApplicationName, arg.Resources)
-			if err != nil {
-				logger.Errorf("couldn't remove pending resources for %q", arg.ApplicationName)
-			}
+			api.removePendingResources(arg.ApplicationName, arg.Resources)
 		}
 	}
 	return result, nil
 }
 
-func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
+// removePendingResources removes the pending resources uploaded for an
+// application that was not deployed. Failures are logged, as there is
+// nothing more the caller can do about them.
+func (api *APIv5) removePendingResources(appName string, pendingIDs map[string]string) {
+	resources, err := api.backend.Resources()
+	if err != nil {
+		logger.Errorf("couldn't get backend.Resources")
+		return
+	}
+	err = resources.RemovePendingAppResources(appName, pendingIDs)
+	if err != nil {
+		logger.Errorf("couldn't remove pending resources for %q", appName)
+	}
+}
+
+// trustFields holds the schema of the application config option used
+// to grant an application access to the model's cloud credential. It
+// is valid for all model types.
+var trustFields = environschema.Fields{
+	application.TrustConfigOptionName: {
+		Description: "Does this application have access to trusted credentials",
+		Type:        environschema.Tbool,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var trustDefaults = schema.Defaults{
+	application.TrustConfigOptionName: false,
+}
+
+// ApplicationConfigSchema returns the schema and defaults of the
+// application config for applications in a model of the given type.
+func ApplicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return environschema.Fields{}, schema.Defaults{}, nil
+		return addTrustSchemaAndDefaults(environschema.Fields{}, schema.Defaults{})
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
-	return schema, defaults, err
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return addTrustSchemaAndDefaults(schema, defaults)
+}
+
+// validateCAASConstraints returns an error if the constraints
+// include any which are not supported in a CAAS model.
+func validateCAASConstraints(cons constraints.Value) error {
+	unsupported, err := k8s.ConstraintsValidator().Validate(cons)
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return errors.NewNotSupported(nil, fmt.Sprintf(
+			"constraints %s not supported in %s models",
+			strings.Join(unsupported, ", "), state.ModelTypeCAAS,
+		))
+	}
+	return errors.Trace(err)
+}
+
+func addTrustSchemaAndDefaults(fields environschema.Fields, defaults schema.Defaults) (environschema.Fields, schema.Defaults, error) {
+	newFields := make(environschema.Fields)
+	for name, field := range fields {
+		newFields[name] = field
+	}
+	for name, field := range trustFields {
+		if _, ok := newFields[name]; ok {
+			return nil, nil, errors.Errorf("config field %q clashes with trust config", name)
+		}
+		newFields[name] = field
+	}
+	newDefaults := make(schema.Defaults)
+	for name, value := range defaults {
+		newDefaults[name] = value
+	}
+	for name, value := range trustDefaults {
+		newDefaults[name] = value
+	}
+	return newFields, newDefaults, nil
 }
 
 func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[string]string) (
@@ -225,7 +337,7 @@ func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[stri
 	_ error,
 ) {
 
-	providerSchema, _, err := applicationConfigSchema(modelType)
+	providerSchema, _, err := ApplicationConfigSchema(modelType)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -243,6 +355,31 @@ func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[stri
 	return appConfigAttrs, charmConfig, nil
 }
 
+// CheckCharmConfigClash returns an error if any of the application
+// config attributes are also options of the charm. Such attributes are
+// always taken to be application config, so the charm's option could
+// never be set; rather than silently setting the wrong one, it is an
+// error to set them at all.
+func CheckCharmConfigClash(charmConfig *charm.Config, appConfigAttrs map[string]interface{}) error {
+	if charmConfig == nil {
+		return nil
+	}
+	var clashes []string
+	for name := range appConfigAttrs {
+		if _, ok := charmConfig.Options[name]; ok {
+			clashes = append(clashes, name)
+		}
+	}
+	if len(clashes) == 0 {
+		return nil
+	}
+	sort.Strings(clashes)
+	return errors.NewNotValid(nil, fmt.Sprintf(
+		"cannot set %s: application config clashes with charm option of the same name",
+		strings.Join(clashes, ", "),
+	))
+}
+
 // deployApplication fetches the charm from the charm store and deploys it.
 // The logic has been factored out into a common function which is called by
 // both the legacy API on the client facade, as well as the new application facade.
@@ -252,28 +389,49 @@ func deployApplication(
 	args params.ApplicationDeploy,
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error),
 ) error {
-	curl, err := charm.ParseURL(args.CharmURL)
+	deployParams, _, err := deployApplicationParams(backend, stateCharm, args)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	_, err = deployApplicationFunc(backend, deployParams)
+	return errors.Trace(err)
+}
+
+// deployApplicationParams validates the deploy arguments and converts
+// them into DeployApplicationParams. The charm being deployed is also
+// returned.
+func deployApplicationParams(
+	backend Backend,
+	stateCharm func(Charm) *state.Charm,
+	args params.ApplicationDeploy,
+) (DeployApplicationParams, Charm, error) {
+	curl, err := charm.ParseURL(args.CharmURL)
+	if err != nil {
+		return DeployApplicationParams{}, nil, errors.Trace(err)
+	}
 	if curl.Revision < 0 {
-		return errors.Errorf("charm url must include revision")
+		return DeployApplicationParams{}, nil, errors.Errorf("charm url must include revision")
 	}
 
 	if backend.ModelType() != state.ModelTypeIAAS {
 		if len(args.AttachStorage) > 0 {
-			return errors.Errorf(
+			return DeployApplicationParams{}, nil, errors.Errorf(
 				"AttachStorage may not be specified for %s models",
 				backend.ModelType(),
 			)
 		}
 		if len(args.Placement) > 0 {
-			return errors.Errorf(
+			return DeployApplicationParams{}, nil, errors.Errorf(
 				"Placement may not be specified for %s models",
 				backend.ModelType(),
 			)
 		}
 	}
+	if backend.ModelType() == state.ModelTypeCAAS {
+		if err := validateCAASConstraints(args.Constraints); err != nil {
+			return DeployApplicationParams{}, nil, errors.Trace(err)
+		}
+	}
 
 	// Do a quick but not complete validation check before going any further.
 	for _, p := range args.Placement {
@@ -282,34 +440,37 @@ func deployApplication(
 		}
 		_, err = backend.Machine(p.Directive)
 		if err != nil {
-			return errors.Annotatef(err, `cannot deploy "%v" to machine %v`, args.ApplicationName, p.Directive)
+			return DeployApplicationParams{}, nil, errors.Annotatef(err, `cannot deploy "%v" to machine %v`, args.ApplicationName, p.Directive)
 		}
 	}
 
 	// Try to find the charm URL in state first.
 	ch, err := backend.Charm(curl)
 	if err != nil {
-		return errors.Trace(err)
+		return DeployApplicationParams{}, nil, errors.Trace(err)
 	}
 
 	if err := checkMinVersion(ch); err != nil {
-		return errors.Trace(err)
+		return DeployApplicationParams{}, nil, errors.Trace(err)
 	}
 
 	appConfigAttrs, charmConfig, err := splitApplicationAndCharmConfig(backend.ModelType(), args.Config)
 	if err != nil {
-		return errors.Trace(err)
+		return DeployApplicationParams{}, nil, errors.Trace(err)
+	}
+	if err := CheckCharmConfigClash(ch.Config(), appConfigAttrs); err != nil {
+		return DeployApplicationParams{}, nil, errors.Trace(err)
 	}
 
 	var applicationConfig *application.Config
 	if len(appConfigAttrs) > 0 {
-		schema, defaults, err := applicationConfigSchema(backend.ModelType())
+		schema, defaults, err := ApplicationConfigSchema(backend.ModelType())
 		if err != nil {
-			return errors.Trace(err)
+			return DeployApplicationParams{}, nil, errors.Trace(err)
 		}
 		applicationConfig, err = application.NewConfig(appConfigAttrs, schema, defaults)
 		if err != nil {
-			return errors.Trace(err)
+			return DeployApplicationParams{}, nil, errors.Trace(err)
 		}
 	}
 
@@ -317,7 +478,7 @@ func deployApplication(
 	if len(args.ConfigYAML) > 0 {
 		settings, err = ch.Config().ParseSettingsYAML([]byte(args.ConfigYAML), args.ApplicationName)
 		if err != nil {
-			return errors.Trace(err)
+			return DeployApplicationParams{}, nil, errors.Trace(err)
 		}
 	}
 	// Overlay any settings in YAML with those from config map.
@@ -325,7 +486,7 @@ func deployApplication(
 		// Parse config in a compatible way (see function comment).
 		overrideSettings, err := parseSettingsCompatible(ch.Config(), charmConfig)
 		if err != nil {
-			return errors.Trace(err)
+			return DeployApplicationParams{}, nil, errors.Trace(err)
 		}
 		for k, v := range overrideSettings {
 			settings[k] = v
@@ -334,18 +495,18 @@ func deployApplication(
 
 	// Parse storage tags in AttachStorage.
 	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
-		return errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
+		return DeployApplicationParams{}, nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
 	}
 	attachStorage := make([]names.StorageTag, len(args.AttachStorage))
 	for i, tagString := range args.AttachStorage {
 		tag, err := names.ParseStorageTag(tagString)
 		if err != nil {
-			return errors.Trace(err)
+			return DeployApplicationParams{}, nil, errors.Trace(err)
 		}
 		attachStorage[i] = tag
 	}
 
-	_, err = deployApplicationFunc(backend, DeployApplicationParams{
+	return DeployApplicationParams{
 		ApplicationName:   args.ApplicationName,
 		Series:            args.Series,
 		Charm:             stateCharm(ch),
@@ -359,8 +520,7 @@ func deployApplication(
 		AttachStorage:     attachStorage,
 		EndpointBindings:  args.EndpointBindings,
 		Resources:         args.Resources,
-	})
-	return errors.Trace(err)
+	}, ch, nil
 }
 
 // ApplicationSetSettingsStrings updates the settings for the given application,
@@ -465,6 +625,9 @@ func (api *APIv5) Update(args params.ApplicationUpdate) error {
 	}
 	// Update application's constraints.
 	if args.Constraints != nil {
+		if err := api.validateConstraints(*args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
 		return app.SetConstraints(*args.Constraints)
 	}
 	return nil
@@ -601,13 +764,46 @@ func (api *APIv5) applicationSetCharm(
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
 ) error {
-	curl, err := charm.ParseURL(url)
+	cfg, _, err := api.setCharmConfig(
+		api.backend,
+		appName,
+		url,
+		channel,
+		configSettingsStrings,
+		configSettingsYAML,
+		forceSeries,
+		forceUnits,
+		resourceIDs,
+		storageConstraints,
+	)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	sch, err := api.backend.Charm(curl)
+	return application.SetCharm(cfg)
+}
+
+// setCharmConfig parses the arguments for setting an application's
+// charm into a state.SetCharmConfig, finding the new charm with the
+// given backend. The new charm is also returned.
+func (api *APIv5) setCharmConfig(
+	backend Backend,
+	appName string,
+	url string,
+	channel csparams.Channel,
+	configSettingsStrings map[string]string,
+	configSettingsYAML string,
+	forceSeries,
+	forceUnits bool,
+	resourceIDs map[string]string,
+	storageConstraints map[string]params.StorageConstraints,
+) (state.SetCharmConfig, Charm, error) {
+	curl, err := charm.ParseURL(url)
 	if err != nil {
-		return errors.Trace(err)
+		return state.SetCharmConfig{}, nil, errors.Trace(err)
+	}
+	sch, err := backend.Charm(curl)
+	if err != nil {
+		return state.SetCharmConfig{}, nil, errors.Trace(err)
 	}
 	var settings charm.Settings
 	if configSettingsYAML != "" {
@@ -616,7 +812,7 @@ func (api *APIv5) applicationSetCharm(
 		settings, err = parseSettingsCompatible(sch.Config(), configSettingsStrings)
 	}
 	if err != nil {
-		return errors.Annotate(err, "parsing config settings")
+		return state.SetCharmConfig{}, nil, errors.Annotate(err, "parsing config settings")
 	}
 	var stateStorageConstraints map[string]state.StorageConstraints
 	if len(storageConstraints) > 0 {
@@ -632,7 +828,7 @@ func (api *APIv5) applicationSetCharm(
 			stateStorageConstraints[name] = stateCons
 		}
 	}
-	cfg := state.SetCharmConfig{
+	return state.SetCharmConfig{
 		Charm:              api.stateCharm(sch),
 		Channel:            channel,
 		ConfigSettings:     settings,
@@ -640,8 +836,7 @@ func (api *APIv5) applicationSetCharm(
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
-	}
-	return application.SetCharm(cfg)
+	}, sch, nil
 }
 
 // charmConfigFromGetYaml will parse a yaml produced by juju get and generate
@@ -828,14 +1023,15 @@ func (api *APIv5) Unexpose(args params.ApplicationUnexpose) error {
 }
 
 // AddUnits adds a given number of units to an application.
-func (api *APIv5) AddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
+func (api *APIv5) AddUnits(ctx context.Context, args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
-	units, err := addApplicationUnits(api.backend, args)
+	backend := api.backend.WithSpanContext(tracing.FromContext(ctx))
+	units, err := addApplicationUnits(backend, args)
 	if err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
@@ -848,8 +1044,34 @@ func (api *APIv5) AddUnits(args params.AddApplicationUnits) (params.AddApplicati
 
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]Unit, error) {
+	assignUnits, attachStorage, err := validateAddApplicationUnits(backend, args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	application, err := backend.Application(args.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := addUnits(
+		application,
+		args.ApplicationName,
+		args.NumUnits,
+		args.Placement,
+		attachStorage,
+		assignUnits,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return units, nil
+}
+
+// validateAddApplicationUnits checks the arguments for adding units
+// to an application. It returns whether the units should be assigned
+// to machines, and the storage to attach to the new unit.
+func validateAddApplicationUnits(backend Backend, args params.AddApplicationUnits) (bool, []names.StorageTag, error) {
 	if args.NumUnits < 1 {
-		return nil, errors.New("must add at least one unit")
+		return false, nil, errors.New("must add at least one unit")
 	}
 
 	assignUnits := true
@@ -858,13 +1080,13 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]Un
 		// units to be assigned to.
 		assignUnits = false
 		if len(args.AttachStorage) > 0 {
-			return nil, errors.Errorf(
+			return false, nil, errors.Errorf(
 				"AttachStorage may not be specified for %s models",
 				backend.ModelType(),
 			)
 		}
 		if len(args.Placement) > 0 {
-			return nil, errors.Errorf(
+			return false, nil, errors.Errorf(
 				"Placement may not be specified for %s models",
 				backend.ModelType(),
 			)
@@ -873,28 +1095,17 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]Un
 
 	// Parse storage tags in AttachStorage.
 	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
-		return nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
+		return false, nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
 	}
 	attachStorage := make([]names.StorageTag, len(args.AttachStorage))
 	for i, tagString := range args.AttachStorage {
 		tag, err := names.ParseStorageTag(tagString)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return false, nil, errors.Trace(err)
 		}
 		attachStorage[i] = tag
 	}
-	application, err := backend.Application(args.ApplicationName)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return addUnits(
-		application,
-		args.ApplicationName,
-		args.NumUnits,
-		args.Placement,
-		attachStorage,
-		assignUnits,
-	)
+	return assignUnits, attachStorage, nil
 }
 
 // DestroyUnits removes a given set of application units.
@@ -993,6 +1204,9 @@ func (api *APIv5) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUni
 		}
 		op := unit.DestroyOperation()
 		op.DestroyStorage = arg.DestroyStorage
+		// The pod backing a unit in a CAAS model is no
+		// longer needed, so scale the application down.
+		op.ScaleDown = api.backend.ModelType() == state.ModelTypeCAAS
 		if err := api.backend.ApplyOperation(op); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1209,6 +1423,9 @@ func (api *APIv5) SetConstraints(args params.SetConstraints) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.validateConstraints(args.Constraints); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return err
@@ -1216,6 +1433,16 @@ func (api *APIv5) SetConstraints(args params.SetConstraints) error {
 	return app.SetConstraints(args.Constraints)
 }
 
+// validateConstraints returns an error if the constraints are
+// not supported by the model's type. Constraints unsupported by
+// the providers of IAAS models are reported as warnings by state.
+func (api *APIv5) validateConstraints(cons constraints.Value) error {
+	if api.backend.ModelType() != state.ModelTypeCAAS {
+		return nil
+	}
+	return validateCAASConstraints(cons)
+}
+
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
 func (api *APIv5) AddRelation(args params.AddRelation) (_ params.AddRelationResults, err error) {
 	var rel Relation
@@ -1586,21 +1813,27 @@ func (api *APIv6) setApplicationConfig(arg params.ApplicationConfigSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	schema, defaults, err := applicationConfigSchema(api.backend.ModelType())
+	schema, defaults, err := ApplicationConfigSchema(api.backend.ModelType())
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	if len(appConfigAttrs) == 0 && len(charmConfig) == 0 {
+		return nil
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return err
+	}
 	if len(appConfigAttrs) > 0 {
+		if err := CheckCharmConfigClash(ch.Config(), appConfigAttrs); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
 	}
 	if len(charmConfig) > 0 {
-		ch, _, err := app.Charm()
-		if err != nil {
-			return err
-		}
 		// Validate the charm and application config.
 		charmConfigChanges, err := ch.Config().ParseSettingsStrings(charmConfig)
 		if err != nil {
@@ -1636,7 +1869,7 @@ func (api *APIv6) unsetApplicationConfig(arg params.ApplicationUnset) error {
 		return errors.Trace(err)
 	}
 
-	schema, defaults, err := applicationConfigSchema(api.backend.ModelType())
+	schema, defaults, err := ApplicationConfigSchema(api.backend.ModelType())
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"regexp"
@@ -323,7 +324,7 @@ func (s *applicationSuite) TestApplicationDeployWithStorage(c *gc.C) {
 		Constraints:     cons,
 		Storage:         storageConstraints,
 	}
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -378,7 +379,7 @@ func (s *applicationSuite) TestApplicationDeployWithInvalidStoragePool(c *gc.C)
 		Constraints:     cons,
 		Storage:         storageConstraints,
 	}
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -399,7 +400,7 @@ func (s *applicationSuite) TestApplicationDeployDefaultFilesystemStorage(c *gc.C
 		NumUnits:        1,
 		Constraints:     cons,
 	}
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -434,7 +435,7 @@ func (s *applicationSuite) TestApplicationDeploy(c *gc.C) {
 			{"deadbeef-0bad-400d-8000-4b1d0d06f00d", "valid"},
 		},
 	}
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -468,7 +469,7 @@ func (s *applicationSuite) TestApplicationDeployWithInvalidPlacement(c *gc.C) {
 			{"deadbeef-0bad-400d-8000-4b1d0d06f00d", "invalid"},
 		},
 	}
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -487,7 +488,7 @@ func (s *applicationSuite) TestApplicationDeploymentRemovesPendingResourcesOnFai
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName: "haha/borken",
 			NumUnits:        1,
@@ -514,7 +515,7 @@ func (s *applicationSuite) TestApplicationDeploymentLeavesResourcesOnSuccess(c *
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName: "unborken",
 			NumUnits:        1,
@@ -547,7 +548,7 @@ func (s *applicationSuite) testClientApplicationsDeployWithBindings(c *gc.C, end
 		EndpointBindings: endpointBindings,
 	}
 
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -778,7 +779,7 @@ func (s *applicationSuite) TestApplicationSetCharm(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -813,7 +814,7 @@ func (s *applicationSuite) setupApplicationSetCharm(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -876,7 +877,7 @@ func (s *applicationSuite) TestApplicationSetCharmForceUnits(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -948,7 +949,7 @@ func (s *applicationSuite) TestApplicationSetCharmLegacy(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -978,7 +979,7 @@ func (s *applicationSuite) TestApplicationSetCharmUnsupportedSeries(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -1006,7 +1007,7 @@ func (s *applicationSuite) assertApplicationSetCharmSeries(c *gc.C, upgradeCharm
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -1053,7 +1054,7 @@ func (s *applicationSuite) TestApplicationSetCharmWrongOS(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -1105,7 +1106,7 @@ func (s *applicationSuite) TestSpecializeStoreOnDeployApplicationSetCharmAndAddC
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -1142,7 +1143,7 @@ func (s *applicationSuite) setupApplicationDeploy(c *gc.C, args string) (*charm.
 }
 
 func (s *applicationSuite) assertApplicationDeployPrincipal(c *gc.C, curl *charm.URL, ch charm.Charm, mem4g constraints.Value) {
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -1156,7 +1157,7 @@ func (s *applicationSuite) assertApplicationDeployPrincipal(c *gc.C, curl *charm
 }
 
 func (s *applicationSuite) assertApplicationDeployPrincipalBlocked(c *gc.C, msg string, curl *charm.URL, mem4g constraints.Value) {
-	_, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	_, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -1190,7 +1191,7 @@ func (s *applicationSuite) TestApplicationDeploySubordinate(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application-name",
@@ -1227,7 +1228,7 @@ func (s *applicationSuite) TestApplicationDeployConfig(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application-name",
@@ -1255,7 +1256,7 @@ func (s *applicationSuite) TestApplicationDeployConfigError(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application-name",
@@ -1278,7 +1279,7 @@ func (s *applicationSuite) TestApplicationDeployToMachine(c *gc.C) {
 
 	machine, err := s.State.AddMachine("precise", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application-name",
@@ -1312,7 +1313,7 @@ func (s *applicationSuite) TestApplicationDeployToMachine(c *gc.C) {
 }
 
 func (s *applicationSuite) TestApplicationDeployToMachineNotFound(c *gc.C) {
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        "cs:precise/application-name-1",
 			ApplicationName: "application-name",
@@ -1333,7 +1334,7 @@ func (s *applicationSuite) deployApplicationForUpdateTests(c *gc.C) {
 		URL: curl.String(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+	results, err := s.applicationAPI.Deploy(context.Background(), params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			CharmURL:        curl.String(),
 			ApplicationName: "application",
@@ -1840,7 +1841,7 @@ func (s *applicationSuite) TestClientAddApplicationUnits(c *gc.C) {
 		if t.to != "" {
 			args.Placement = []*instance.Placement{instance.MustParsePlacement(t.to)}
 		}
-		result, err := s.applicationAPI.AddUnits(args)
+		result, err := s.applicationAPI.AddUnits(context.Background(), args)
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 			continue
@@ -1861,7 +1862,7 @@ func (s *applicationSuite) TestAddApplicationUnitsToNewContainer(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
 
-	_, err = s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	_, err = s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        1,
 		Placement:       []*instance.Placement{instance.MustParsePlacement("lxd:" + machine.Id())},
@@ -1912,7 +1913,7 @@ func (s *applicationSuite) TestAddApplicationUnits(c *gc.C) {
 		if applicationName == "" {
 			applicationName = "dummy"
 		}
-		result, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+		result, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 			ApplicationName: applicationName,
 			NumUnits:        len(t.expected),
 			Placement:       t.placement,
@@ -1934,7 +1935,7 @@ func (s *applicationSuite) TestAddApplicationUnits(c *gc.C) {
 }
 
 func (s *applicationSuite) assertAddApplicationUnits(c *gc.C) {
-	result, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	result, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        3,
 	})
@@ -1968,7 +1969,7 @@ func (s *applicationSuite) TestApplicationCharmRelations(c *gc.C) {
 }
 
 func (s *applicationSuite) assertAddApplicationUnitsBlocked(c *gc.C, msg string) {
-	_, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	_, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        3,
 	})
@@ -1995,7 +1996,7 @@ func (s *applicationSuite) TestBlockChangeAddApplicationUnits(c *gc.C) {
 
 func (s *applicationSuite) TestAddUnitToMachineNotFound(c *gc.C) {
 	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
-	_, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	_, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        3,
 		Placement:       []*instance.Placement{instance.MustParsePlacement("42")},
@@ -4,12 +4,17 @@
 package application_test
 
 import (
+	"context"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
@@ -18,8 +23,10 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/constraints"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -471,7 +478,7 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 			AttachStorage:   []string{"volume-baz-0"},
 		}},
 	}
-	results, err := s.api.Deploy(args)
+	results, err := s.api.Deploy(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 3)
 	c.Assert(results.Results[0].Error, gc.IsNil)
@@ -479,6 +486,22 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeployTrustClash(c *gc.C) {
+	s.backend.charm.config.Options["trust"] = charm.Option{Type: "boolean"}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Config:          map[string]string{"trust": "true"},
+		}},
+	}
+	results, err := s.api.Deploy(context.Background(), args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "cannot set trust: application config clashes with charm option of the same name")
+}
+
 func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 	s.backend.modelType = state.ModelTypeCAAS
 	args := params.ApplicationsDeploy{
@@ -498,7 +521,7 @@ func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 			Placement:       []*instance.Placement{{}},
 		}},
 	}
-	results, err := s.api.Deploy(args)
+	results, err := s.api.Deploy(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 3)
 	c.Assert(results.Results[0].Error, gc.IsNil)
@@ -506,8 +529,40 @@ func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, "Placement may not be specified for caas models")
 }
 
+func (s *ApplicationSuite) TestDeployCAASModelConstraints(c *gc.C) {
+	s.backend.modelType = state.ModelTypeCAAS
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Constraints:     constraints.MustParse("mem=1G cores=2 arch=amd64 tags=pool=gpu"),
+		}, {
+			ApplicationName: "bar",
+			CharmURL:        "local:bar-0",
+			NumUnits:        1,
+			Constraints:     constraints.MustParse("mem=1G root-disk=10G instance-type=large"),
+		}},
+	}
+	results, err := s.api.Deploy(context.Background(), args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "constraints instance-type, root-disk not supported in caas models")
+}
+
+func (s *ApplicationSuite) TestSetConstraintsCAASModelUnsupported(c *gc.C) {
+	s.backend.modelType = state.ModelTypeCAAS
+	err := s.api.SetConstraints(params.SetConstraints{
+		ApplicationName: "postgresql",
+		Constraints:     constraints.MustParse("cpu-power=100"),
+	})
+	c.Assert(err, gc.ErrorMatches, "constraints cpu-power not supported in caas models")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
-	results, err := s.api.AddUnits(params.AddApplicationUnits{
+	results, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "postgresql",
 		NumUnits:        1,
 	})
@@ -521,9 +576,20 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
 }
 
+func (s *ApplicationSuite) TestAddUnitsSpanContext(c *gc.C) {
+	spanContext := tracing.SpanContext{TraceID: "0123456789abcdef0123456789abcdef", SpanID: "fedcba9876543210"}
+	ctx := tracing.NewContext(context.Background(), spanContext)
+	_, err := s.api.AddUnits(ctx, params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "WithSpanContext", spanContext)
+}
+
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	s.backend.modelType = state.ModelTypeCAAS
-	results, err := s.api.AddUnits(params.AddApplicationUnits{
+	results, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "postgresql",
 		NumUnits:        1,
 	})
@@ -533,12 +599,13 @@ func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 		Units: []string{"postgresql/99"},
 	})
 	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "AddUnit")
 	app.CheckCall(c, 0, "AddUnit", state.AddUnitParams{})
 	app.addedUnit.CheckNoCalls(c) // no assignment
 }
 
 func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
-	_, err := s.api.AddUnits(params.AddApplicationUnits{
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "postgresql",
 		NumUnits:        1,
 		AttachStorage:   []string{"storage-pgdata-0"},
@@ -552,7 +619,7 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
 }
 
 func (s *ApplicationSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
-	_, err := s.api.AddUnits(params.AddApplicationUnits{
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "foo",
 		NumUnits:        2,
 		AttachStorage:   []string{"storage-foo-0"},
@@ -561,7 +628,7 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
 }
 
 func (s *ApplicationSuite) TestAddUnitsAttachStorageInvalidStorageTag(c *gc.C) {
-	_, err := s.api.AddUnits(params.AddApplicationUnits{
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "foo",
 		NumUnits:        1,
 		AttachStorage:   []string{"volume-0"},
@@ -571,7 +638,7 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorageInvalidStorageTag(c *gc.C) {
 
 func (s *ApplicationSuite) TestAddUnitsAttachStorageCAASModel(c *gc.C) {
 	s.backend.modelType = state.ModelTypeCAAS
-	_, err := s.api.AddUnits(params.AddApplicationUnits{
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "postgresql",
 		NumUnits:        1,
 		AttachStorage:   []string{"storage-pgdata-0"},
@@ -581,7 +648,7 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorageCAASModel(c *gc.C) {
 
 func (s *ApplicationSuite) TestAddUnitsPlacementCAASModel(c *gc.C) {
 	s.backend.modelType = state.ModelTypeCAAS
-	_, err := s.api.AddUnits(params.AddApplicationUnits{
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "postgresql",
 		NumUnits:        1,
 		Placement:       []*instance.Placement{{}},
@@ -589,6 +656,199 @@ func (s *ApplicationSuite) TestAddUnitsPlacementCAASModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "Placement may not be specified for caas models")
 }
 
+func (s *ApplicationSuite) TestAddUnitsDryRun(c *gc.C) {
+	s.backend.machines = map[string]*mockMachine{
+		"1": {series: "quantal"},
+	}
+	api := &application.APIv7{s.api}
+	result, err := api.AddUnitsDryRun(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        3,
+		Placement: []*instance.Placement{
+			{Scope: instance.MachineScope, Directive: "1"},
+			{Scope: "lxd", Directive: "1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AddUnitsDryRunResult{
+		ApplicationName: "postgresql",
+		NumUnits:        3,
+		Placement:       []string{"1", "lxd:1", ""},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckCallNames(c, "Application", "Machine", "Machine")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "IsPrincipal", "Series", "Series")
+}
+
+func (s *ApplicationSuite) TestAddUnitsDryRunSeriesMismatch(c *gc.C) {
+	s.backend.machines = map[string]*mockMachine{
+		"1": {series: "xenial"},
+	}
+	api := &application.APIv7{s.api}
+	_, err := api.AddUnitsDryRun(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+		Placement:       []*instance.Placement{{Scope: instance.MachineScope, Directive: "1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit 1/1 to application "postgresql": `+
+		`series does not match: unit has "quantal", machine 1 has "xenial"`)
+}
+
+func (s *ApplicationSuite) TestAddUnitsDryRunMachineNotFound(c *gc.C) {
+	api := &application.APIv7{s.api}
+	_, err := api.AddUnitsDryRun(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+		Placement:       []*instance.Placement{{Scope: "lxd", Directive: "42"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit 1/1 to application "postgresql": machine 42 not found`)
+}
+
+func (s *ApplicationSuite) TestAddUnitsDryRunSubordinate(c *gc.C) {
+	api := &application.APIv7{s.api}
+	_, err := api.AddUnitsDryRun(params.AddApplicationUnits{
+		ApplicationName: "postgresql-subordinate",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add units to subordinate application "postgresql-subordinate"`)
+}
+
+func (s *ApplicationSuite) TestAddUnitsDryRunAttachStorageNotFound(c *gc.C) {
+	api := &application.APIv7{s.api}
+	_, err := api.AddUnitsDryRun(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+		AttachStorage:   []string{"storage-pgdata-42"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot attach pgdata/42: storage pgdata/42 not found`)
+}
+
+func (s *ApplicationSuite) TestSetCharmDryRun(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.curl = charm.MustParseURL("cs:quantal/postgresql-1")
+	app.relations = []application.Relation{
+		&mockRelation{
+			tag: names.NewRelationTag("wordpress:db postgresql:db"),
+			endpoint: state.Endpoint{
+				ApplicationName: "postgresql",
+				Relation: charm.Relation{
+					Name:      "db",
+					Interface: "pgsql",
+					Role:      charm.RoleProvider,
+					Scope:     charm.ScopeGlobal,
+				},
+			},
+		},
+		&mockRelation{
+			tag: names.NewRelationTag("postgresql:replicas"),
+			endpoint: state.Endpoint{
+				ApplicationName: "postgresql",
+				Relation: charm.Relation{
+					Name:      "replicas",
+					Interface: "pgpeer",
+					Role:      charm.RolePeer,
+					Scope:     charm.ScopeGlobal,
+				},
+			},
+		},
+	}
+	s.backend.charm = &mockCharm{
+		meta: &charm.Meta{
+			Peers: map[string]charm.Relation{
+				"replicas": {
+					Name:      "replicas",
+					Interface: "pgpeer",
+					Role:      charm.RolePeer,
+					Scope:     charm.ScopeGlobal,
+				},
+			},
+		},
+		config: &charm.Config{
+			Options: map[string]charm.Option{
+				"stringOption": {Type: "string"},
+				"boolOption":   {Type: "boolean"},
+			},
+		},
+	}
+
+	api := &application.APIv7{s.api}
+	result, err := api.SetCharmDryRun(params.SetCharmDryRunArg{
+		ApplicationSetCharm: params.ApplicationSetCharm{
+			ApplicationName: "postgresql",
+			CharmURL:        "cs:quantal/postgresql-2",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SetCharmDryRunResult{
+		ApplicationName: "postgresql",
+		CurrentCharmURL: "cs:quantal/postgresql-1",
+		CharmURL:        "cs:quantal/postgresql-2",
+		ConfigAdded:     []string{"boolOption"},
+		ConfigRemoved:   []string{"intOption"},
+		BrokenRelations: []string{"wordpress:db postgresql:db"},
+	})
+	app.CheckCallNames(c, "ValidateSetCharm", "Relations")
+	app.CheckCall(c, 0, "ValidateSetCharm", state.SetCharmConfig{
+		Charm: &state.Charm{},
+	})
+}
+
+func (s *ApplicationSuite) TestSetCharmDryRunInvalid(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.SetErrors(errors.New("cannot upgrade application"))
+	api := &application.APIv7{s.api}
+	_, err := api.SetCharmDryRun(params.SetCharmDryRunArg{
+		ApplicationSetCharm: params.ApplicationSetCharm{
+			ApplicationName: "postgresql",
+			CharmURL:        "cs:quantal/postgresql-2",
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot upgrade application")
+}
+
+func (s *ApplicationSuite) TestSetCharmDryRunUnstoredCharm(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.curl = charm.MustParseURL("cs:quantal/postgresql-1")
+	meta := &charm.Meta{Name: "postgresql"}
+	config := &charm.Config{
+		Options: map[string]charm.Option{
+			"stringOption": {Type: "string"},
+		},
+	}
+	s.backend.ResetCalls()
+
+	api := &application.APIv7{s.api}
+	result, err := api.SetCharmDryRun(params.SetCharmDryRunArg{
+		ApplicationSetCharm: params.ApplicationSetCharm{
+			ApplicationName: "postgresql",
+			CharmURL:        "cs:quantal/postgresql-2",
+		},
+		Charm: &params.DryRunCharm{Meta: meta, Config: config},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SetCharmDryRunResult{
+		ApplicationName: "postgresql",
+		CurrentCharmURL: "cs:quantal/postgresql-1",
+		CharmURL:        "cs:quantal/postgresql-2",
+		ConfigRemoved:   []string{"intOption"},
+	})
+	s.backend.CheckCallNames(c, "Application", "UnstoredCharm")
+	s.backend.CheckCall(c, 1, "UnstoredCharm", charm.MustParseURL("cs:quantal/postgresql-2"), meta, config)
+}
+
+func (s *ApplicationSuite) TestSetCharmDryRunCharmNotFound(c *gc.C) {
+	s.backend.charm = nil
+	api := &application.APIv7{s.api}
+	_, err := api.SetCharmDryRun(params.SetCharmDryRunArg{
+		ApplicationSetCharm: params.ApplicationSetCharm{
+			ApplicationName: "postgresql",
+			CharmURL:        "cs:quantal/postgresql-2",
+		},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *ApplicationSuite) TestSetRelationSuspended(c *gc.C) {
 	s.backend.offerConnections["wordpress:db mysql:db"] = &mockOfferConnection{}
 	results, err := s.api.SetRelationsSuspended(params.RelationSuspendedArgs{
@@ -1078,15 +1338,51 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
 
-	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
+	fields, err := caas.ConfigSchema(k8s.ConfigSchema())
+	c.Assert(err, jc.ErrorIsNil)
+	fields, defaults, err := application.AddTrustSchemaAndDefaults(fields, caas.ConfigDefaults(k8s.ConfigDefaults()))
 	c.Assert(err, jc.ErrorIsNil)
-	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
-	}, []string(nil), schema, defaults)
+	}, []string(nil), fields, defaults)
 	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigTrust(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"trust": "true",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+
+	fields, defaults, err := application.AddTrustSchemaAndDefaults(environschema.Fields{}, schema.Defaults{})
+	c.Assert(err, jc.ErrorIsNil)
+	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
+		"trust": "true",
+	}, []string(nil), fields, defaults)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigTrustClash(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.charm.config.Options["trust"] = charm.Option{Type: "boolean"}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"trust": "true",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "cannot set trust: application config clashes with charm option of the same name")
+	app.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
@@ -1132,11 +1428,12 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
 
-	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
+	fields, err := caas.ConfigSchema(k8s.ConfigSchema())
+	c.Assert(err, jc.ErrorIsNil)
+	fields, defaults, err := application.AddTrustSchemaAndDefaults(fields, caas.ConfigDefaults(k8s.ConfigDefaults()))
 	c.Assert(err, jc.ErrorIsNil)
-	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
-		[]string{"juju-external-hostname"}, schema, defaults)
+		[]string{"juju-external-hostname"}, fields, defaults)
 	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringVal": nil})
 }
 
@@ -1192,3 +1489,295 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
+
+func (s *ApplicationSuite) TestApplicationsInfo(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.curl = charm.MustParseURL("cs:postgresql-42")
+	app.exposed = true
+	app.channel = "stable"
+	app.constraints = constraints.MustParse("mem=4G")
+	app.bindings = map[string]string{"db": "alpha"}
+	app.relations = []application.Relation{
+		&mockRelation{
+			endpoint: state.Endpoint{
+				ApplicationName: "postgresql",
+				Relation:        charm.Relation{Name: "juju-info", Scope: charm.ScopeContainer},
+			},
+			relatedEndpoints: []state.Endpoint{{ApplicationName: "postgresql-subordinate"}},
+		},
+		&mockRelation{
+			endpoint: state.Endpoint{
+				ApplicationName: "postgresql",
+				Relation:        charm.Relation{Name: "db", Scope: charm.ScopeGlobal},
+			},
+		},
+	}
+
+	api := &application.APIv8{&application.APIv7{s.api}}
+	results, err := api.ApplicationsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-unknown"},
+		{Tag: "unit-postgresql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ApplicationInfoResults{
+		Results: []params.ApplicationInfoResult{{
+			Result: &params.ApplicationInfo{
+				Tag:              "application-postgresql",
+				Charm:            "cs:postgresql-42",
+				Series:           "quantal",
+				Channel:          "stable",
+				Constraints:      constraints.MustParse("mem=4G"),
+				Principal:        true,
+				Exposed:          true,
+				EndpointBindings: map[string]string{"db": "alpha"},
+				Subordinates:     []string{"postgresql-subordinate"},
+			},
+		}, {
+			Error: &params.Error{Code: params.CodeNotFound, Message: `application "unknown" not found`},
+		}, {
+			Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`},
+		}},
+	})
+}
+
+func (s *ApplicationSuite) TestApplicationsInfoRemote(c *gc.C) {
+	s.backend.remoteApplications["hosted-db2"] = &mockRemoteApplication{
+		name:           "hosted-db2",
+		sourceModelTag: coretesting.ModelTag,
+		offerURL:       "othermodel.hosted-db2",
+	}
+	api := &application.APIv8{&application.APIv7{s.api}}
+	results, err := api.ApplicationsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "application-hosted-db2"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ApplicationInfoResult{{
+		Result: &params.ApplicationInfo{
+			Tag:            "application-hosted-db2",
+			Remote:         true,
+			OfferURL:       "othermodel.hosted-db2",
+			SourceModelTag: coretesting.ModelTag.String(),
+		},
+	}})
+	s.backend.CheckCallNames(c, "Application", "RemoteApplication")
+}
+
+func (s *ApplicationSuite) TestApplicationsInfoPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	api := &application.APIv8{&application.APIv7{s.api}}
+	_, err := api.ApplicationsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
+	since := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	s.backend.leaders = map[string]string{"postgresql": "postgresql/0"}
+	app := s.backend.applications["postgresql"]
+	app.units[0] = mockUnit{
+		tag:             names.NewUnitTag("postgresql/0"),
+		charmURL:        charm.MustParseURL("cs:postgresql-42"),
+		workloadVersion: "9.5",
+		status:          status.StatusInfo{Status: status.Active, Message: "ready", Since: &since},
+		agentStatus:     status.StatusInfo{Status: status.Idle, Since: &since},
+		machineId:       "1",
+		publicAddress:   network.NewAddress("10.0.0.1"),
+		privateAddress:  network.NewAddress("192.168.0.1"),
+		ports:           []network.PortRange{{FromPort: 5432, ToPort: 5432, Protocol: "tcp"}},
+		relations: []application.Relation{
+			&mockRelation{
+				id:               2,
+				endpoint:         state.Endpoint{Relation: charm.Relation{Name: "db"}},
+				relatedEndpoints: []state.Endpoint{{Relation: charm.Relation{Name: "server"}}},
+				relatedUnits:     []string{"wordpress/1", "wordpress/0"},
+				settings: map[string]map[string]interface{}{
+					"postgresql/0": {"host": "10.0.0.1"},
+					"wordpress/0":  {"user": "wp0"},
+					"wordpress/1":  {"user": "wp1"},
+				},
+			},
+		},
+	}
+
+	api := &application.APIv8{&application.APIv7{s.api}}
+	results, err := api.UnitsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-postgresql-0"},
+		{Tag: "unit-postgresql-1"},
+		{Tag: "unit-postgresql-42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UnitInfoResults{
+		Results: []params.UnitInfoResult{{
+			Result: &params.UnitInfo{
+				Tag:             "unit-postgresql-0",
+				Life:            "alive",
+				Charm:           "cs:postgresql-42",
+				WorkloadVersion: "9.5",
+				WorkloadStatus:  params.EntityStatus{Status: status.Active, Info: "ready", Since: &since},
+				AgentStatus:     params.EntityStatus{Status: status.Idle, Since: &since},
+				Leader:          true,
+				Machine:         "1",
+				PublicAddress:   "10.0.0.1",
+				PrivateAddress:  "192.168.0.1",
+				OpenedPorts:     []string{"5432/tcp"},
+				RelationData: []params.EndpointRelationData{{
+					RelationId:      2,
+					Endpoint:        "db",
+					RelatedEndpoint: "server",
+					UnitData:        map[string]interface{}{"host": "10.0.0.1"},
+					RelatedUnits: map[string]map[string]interface{}{
+						"wordpress/0": {"user": "wp0"},
+						"wordpress/1": {"user": "wp1"},
+					},
+				}},
+			},
+		}, {
+			Result: &params.UnitInfo{
+				Tag:  "unit-postgresql-1",
+				Life: "alive",
+			},
+		}, {
+			Error: &params.Error{Code: params.CodeNotFound, Message: `unit "postgresql/42" not found`},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ApplicationLeaders", "Unit", "Unit", "Unit")
+}
+
+func (s *ApplicationSuite) TestUnitsInfoPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	api := &application.APIv8{&application.APIv7{s.api}}
+	_, err := api.UnitsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-postgresql-0"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestUpdateEndpointBindings(c *gc.C) {
+	api := &application.APIv8{&application.APIv7{s.api}}
+	results, err := api.UpdateEndpointBindings(params.ApplicationEndpointBindingsArgs{
+		Args: []params.ApplicationEndpointBindings{{
+			ApplicationTag: "application-postgresql",
+			Bindings:       map[string]string{"db": "alpha"},
+		}, {
+			ApplicationTag: "application-postgresql",
+		}, {
+			ApplicationTag: "application-unknown",
+			Bindings:       map[string]string{"db": "alpha"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "empty endpoint bindings not valid"}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "unknown" not found`}},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateEndpointBindings")
+	app.CheckCall(c, 0, "UpdateEndpointBindings", map[string]string{"db": "alpha"})
+}
+
+func (s *ApplicationSuite) TestUpdateEndpointBindingsCAAS(c *gc.C) {
+	s.backend.modelType = state.ModelTypeCAAS
+	api := &application.APIv8{&application.APIv7{s.api}}
+	results, err := api.UpdateEndpointBindings(params.ApplicationEndpointBindingsArgs{
+		Args: []params.ApplicationEndpointBindings{{
+			ApplicationTag: "application-postgresql",
+			Bindings:       map[string]string{"db": "alpha"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "endpoint bindings for caas models not supported")
+}
+
+func (s *ApplicationSuite) TestUpdateEndpointBindingsBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	api := &application.APIv8{&application.APIv7{s.api}}
+	_, err := api.UpdateEndpointBindings(params.ApplicationEndpointBindingsArgs{
+		Args: []params.ApplicationEndpointBindings{{
+			ApplicationTag: "application-postgresql",
+			Bindings:       map[string]string{"db": "alpha"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDestroyUnitCAASModel(c *gc.C) {
+	s.backend.modelType = state.ModelTypeCAAS
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{
+			{UnitTag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{},
+	}})
+	s.backend.CheckCallNames(c, "Unit", "ApplyOperation")
+	s.backend.CheckCall(c, 1, "ApplyOperation", &state.DestroyUnitOperation{
+		ScaleDown: true,
+	})
+}
+
+func (s *ApplicationSuite) TestScaleApplications(c *gc.C) {
+	s.backend.modelType = state.ModelTypeCAAS
+	api := &application.APIv9{&application.APIv8{&application.APIv7{s.api}}}
+	results, err := api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}, {
+			ApplicationTag: "application-postgresql",
+			Scale:          -1,
+		}, {
+			ApplicationTag: "application-unknown",
+			Scale:          1,
+		}, {
+			ApplicationTag: "unit-postgresql-0",
+			Scale:          1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "negative scale -1 not valid"}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "unknown" not found`}},
+		{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetScale")
+	app.CheckCall(c, 0, "SetScale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsIAAS(c *gc.C) {
+	api := &application.APIv9{&application.APIv8{&application.APIv7{s.api}}}
+	results, err := api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "scaling applications in iaas models not supported")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsBlocked(c *gc.C) {
+	s.backend.modelType = state.ModelTypeCAAS
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	api := &application.APIv9{&application.APIv8{&application.APIv7{s.api}}}
+	_, err := api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
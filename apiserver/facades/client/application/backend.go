@@ -15,9 +15,12 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
+	statewatcher "github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
 )
 
@@ -29,6 +32,7 @@ type Backend interface {
 
 	AllModelUUIDs() ([]string, error)
 	Application(string) (Application, error)
+	ApplicationLeaders() (map[string]string, error)
 	ApplyOperation(state.ModelOperation) error
 	AddApplication(state.AddApplicationArgs) (Application, error)
 	RemoteApplication(string) (RemoteApplication, error)
@@ -47,6 +51,12 @@ type Backend interface {
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	ValidateAddApplication(state.AddApplicationArgs) (state.AddApplicationArgs, error)
+	UnstoredCharm(*charm.URL, *charm.Meta, *charm.Config) (Charm, error)
+
+	// WithSpanContext returns a Backend whose transactions are
+	// traced as children of the given span.
+	WithSpanContext(tracing.SpanContext) Backend
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -72,16 +82,24 @@ type Application interface {
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
+	EndpointBindings() (map[string]string, error)
 	Endpoints() ([]state.Endpoint, error)
+	IsExposed() bool
 	IsPrincipal() bool
+	Life() state.Life
+	Name() string
+	Relations() ([]Relation, error)
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	SetScale(int) error
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(charm.Settings) error
+	UpdateEndpointBindings(map[string]string) error
+	ValidateSetCharm(state.SetCharmConfig) error
 	ApplicationConfig() (application.ConfigAttributes, error)
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
 }
@@ -99,6 +117,8 @@ type Charm interface {
 // details on the methods, see the methods on state.Machine with
 // the same names.
 type Machine interface {
+	Life() state.Life
+	Series() string
 }
 
 // Relation defines a subset of the functionality provided by the
@@ -110,9 +130,20 @@ type Relation interface {
 	Tag() names.Tag
 	Destroy() error
 	Endpoint(string) (state.Endpoint, error)
+	Id() int
+	RelatedEndpoints(string) ([]state.Endpoint, error)
 	SetSuspended(bool, string) error
 	Suspended() bool
 	SuspendedReason() string
+
+	// ReadUnitSettings returns the settings of the named unit
+	// in the relation.
+	ReadUnitSettings(unitName string) (map[string]interface{}, error)
+
+	// RelatedUnitsInScope returns the names of the units on the
+	// other side of the relation that are in scope with the named
+	// unit.
+	RelatedUnitsInScope(unitName string) ([]string, error)
 }
 
 // Unit defines a subset of the functionality provided by the
@@ -121,10 +152,22 @@ type Relation interface {
 // the same names.
 type Unit interface {
 	UnitTag() names.UnitTag
+	Name() string
+	ApplicationName() string
 	Destroy() error
 	DestroyOperation() *state.DestroyUnitOperation
 	IsPrincipal() bool
 	Life() state.Life
+	PrincipalName() (string, bool)
+	CharmURL() (*charm.URL, bool)
+	WorkloadVersion() (string, error)
+	Status() (status.StatusInfo, error)
+	AgentStatus() (status.StatusInfo, error)
+	AssignedMachineId() (string, error)
+	PublicAddress() (network.Address, error)
+	PrivateAddress() (network.Address, error)
+	OpenedPorts() ([]network.PortRange, error)
+	RelationsInScope() ([]Relation, error)
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
@@ -146,6 +189,7 @@ type Model interface {
 // state.Resources type, as required by the application facade. See
 // the state.Resources type for details on the methods.
 type Resources interface {
+	ListResources(string) (resource.ServiceResources, error)
 	RemovePendingAppResources(string, map[string]string) error
 }
 
@@ -199,6 +243,20 @@ func NewStateBackend(st *state.State) (Backend, error) {
 	return result, nil
 }
 
+func (s stateShim) WithSpanContext(spanContext tracing.SpanContext) Backend {
+	st := s.State.WithSpanContext(spanContext)
+	if st == s.State {
+		return s
+	}
+	backend, err := NewStateBackend(st)
+	if err != nil {
+		// The request can still be served, just not traced.
+		logger.Warningf("cannot trace transactions: %v", err)
+		return s
+	}
+	return backend
+}
+
 // NewStateApplication converts a state.Application into an Application.
 func NewStateApplication(st *state.State, app *state.Application) Application {
 	return stateApplicationShim{app, st}
@@ -235,6 +293,7 @@ type remoteApplicationShim struct {
 type RemoteApplication interface {
 	Name() string
 	SourceModel() names.ModelTag
+	URL() (string, bool)
 	Endpoints() ([]state.Endpoint, error)
 	AddEndpoints(eps []charm.Relation) error
 	Bindings() map[string]string
@@ -257,7 +316,7 @@ func (s stateShim) AddRelation(eps ...state.Endpoint) (Relation, error) {
 	if err != nil {
 		return nil, err
 	}
-	return stateRelationShim{r}, nil
+	return stateRelationShim{r, s.State}, nil
 }
 
 func (s stateShim) SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error) {
@@ -273,12 +332,20 @@ func (s stateShim) Charm(curl *charm.URL) (Charm, error) {
 	return stateCharmShim{ch}, nil
 }
 
+func (s stateShim) UnstoredCharm(curl *charm.URL, meta *charm.Meta, config *charm.Config) (Charm, error) {
+	ch, err := s.State.UnstoredCharm(curl, meta, config)
+	if err != nil {
+		return nil, err
+	}
+	return stateCharmShim{ch}, nil
+}
+
 func (s stateShim) EndpointsRelation(eps ...state.Endpoint) (Relation, error) {
 	r, err := s.State.EndpointsRelation(eps...)
 	if err != nil {
 		return nil, err
 	}
-	return stateRelationShim{r}, nil
+	return stateRelationShim{r, s.State}, nil
 }
 
 func (s stateShim) Relation(id int) (Relation, error) {
@@ -286,7 +353,7 @@ func (s stateShim) Relation(id int) (Relation, error) {
 	if err != nil {
 		return nil, err
 	}
-	return stateRelationShim{r}, nil
+	return stateRelationShim{r, s.State}, nil
 }
 
 func (s stateShim) Machine(name string) (Machine, error) {
@@ -336,6 +403,18 @@ func (a stateApplicationShim) Charm() (Charm, bool, error) {
 	return ch, force, nil
 }
 
+func (a stateApplicationShim) Relations() ([]Relation, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Relation, len(relations))
+	for i, r := range relations {
+		out[i] = stateRelationShim{r, a.st}
+	}
+	return out, nil
+}
+
 func (a stateApplicationShim) AllUnits() ([]Unit, error) {
 	units, err := a.Application.AllUnits()
 	if err != nil {
@@ -358,6 +437,39 @@ type stateMachineShim struct {
 
 type stateRelationShim struct {
 	*state.Relation
+	st *state.State
+}
+
+func (r stateRelationShim) relationUnit(unitName string) (*state.RelationUnit, error) {
+	u, err := r.st.Unit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r.Relation.Unit(u)
+}
+
+func (r stateRelationShim) ReadUnitSettings(unitName string) (map[string]interface{}, error) {
+	ru, err := r.relationUnit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ru.ReadSettings(unitName)
+}
+
+func (r stateRelationShim) RelatedUnitsInScope(unitName string) ([]string, error) {
+	ru, err := r.relationUnit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := ru.WatchScope()
+	change, ok := <-w.Changes()
+	if !ok {
+		return nil, statewatcher.EnsureErr(w)
+	}
+	if err := w.Stop(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return change.Entered, nil
 }
 
 type stateUnitShim struct {
@@ -365,6 +477,18 @@ type stateUnitShim struct {
 	st *state.State
 }
 
+func (u stateUnitShim) RelationsInScope() ([]Relation, error) {
+	relations, err := u.Unit.RelationsInScope()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Relation, len(relations))
+	for i, r := range relations {
+		out[i] = stateRelationShim{r, u.st}
+	}
+	return out, nil
+}
+
 func (u stateUnitShim) AssignWithPolicy(policy state.AssignmentPolicy) error {
 	return u.st.AssignUnit(u.Unit, policy)
 }
@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// UpdateEndpointBindings changes the spaces the endpoints of each of
+// the given applications are bound to. Units of an application see the
+// change as a config-changed hook, after which network-get reports the
+// addresses in the new spaces.
+func (api *APIv8) UpdateEndpointBindings(args params.ApplicationEndpointBindingsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.updateEndpointBindings(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIv8) updateEndpointBindings(arg params.ApplicationEndpointBindings) error {
+	if api.backend.ModelType() == state.ModelTypeCAAS {
+		return errors.NotSupportedf("endpoint bindings for caas models")
+	}
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(arg.Bindings) == 0 {
+		return errors.NotValidf("empty endpoint bindings")
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.UpdateEndpointBindings(arg.Bindings)
+}
@@ -52,24 +52,34 @@ type UnitAdder interface {
 
 // DeployApplication takes a charm and various parameters and deploys it.
 func DeployApplication(st ApplicationDeployer, args DeployApplicationParams) (Application, error) {
-	charmConfig, err := args.Charm.Config().ValidateSettings(args.CharmConfig)
+	asa, err := addApplicationArgs(args.Charm, args)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if args.Charm.Meta().Subordinate {
+	return st.AddApplication(asa)
+}
+
+// addApplicationArgs validates the deploy parameters against the
+// charm, and returns the arguments with which to add the application.
+func addApplicationArgs(ch Charm, args DeployApplicationParams) (state.AddApplicationArgs, error) {
+	charmConfig, err := ch.Config().ValidateSettings(args.CharmConfig)
+	if err != nil {
+		return state.AddApplicationArgs{}, errors.Trace(err)
+	}
+	if ch.Meta().Subordinate {
 		if args.NumUnits != 0 {
-			return nil, fmt.Errorf("subordinate application must be deployed without units")
+			return state.AddApplicationArgs{}, fmt.Errorf("subordinate application must be deployed without units")
 		}
 		if !constraints.IsEmpty(&args.Constraints) {
-			return nil, fmt.Errorf("subordinate application must be deployed without constraints")
+			return state.AddApplicationArgs{}, fmt.Errorf("subordinate application must be deployed without constraints")
 		}
 	}
 	// TODO(fwereade): transactional State.AddApplication including settings, constraints
 	// (minimumUnitCount, initialMachineIds?).
 
-	effectiveBindings, err := getEffectiveBindingsForCharmMeta(args.Charm.Meta(), args.EndpointBindings)
+	effectiveBindings, err := getEffectiveBindingsForCharmMeta(ch.Meta(), args.EndpointBindings)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return state.AddApplicationArgs{}, errors.Trace(err)
 	}
 
 	asa := state.AddApplicationArgs{
@@ -87,10 +97,10 @@ func DeployApplication(st ApplicationDeployer, args DeployApplicationParams) (Ap
 		EndpointBindings:  effectiveBindings,
 	}
 
-	if !args.Charm.Meta().Subordinate {
+	if !ch.Meta().Subordinate {
 		asa.Constraints = args.Constraints
 	}
-	return st.AddApplication(asa)
+	return asa, nil
 }
 
 func quoteStrings(vals []string) string {
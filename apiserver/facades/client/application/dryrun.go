@@ -0,0 +1,378 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+// DeployDryRun validates the arguments for deploying each of the given
+// applications, and reports what deploying them would do. Neither the
+// charm nor the resources need have been uploaded, and the model is
+// not changed.
+func (api *APIv7) DeployDryRun(args params.DeployDryRunArgs) (params.DeployDryRunResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.DeployDryRunResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.DeployDryRunResults{}, errors.Trace(err)
+	}
+	results := params.DeployDryRunResults{
+		Results: make([]params.DeployDryRunResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		result, err := api.deployDryRun(arg)
+		if err != nil {
+			result = params.DeployDryRunResult{
+				ApplicationName: arg.ApplicationName,
+				Error:           common.ServerError(err),
+			}
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *APIv7) deployDryRun(arg params.DeployDryRunArg) (params.DeployDryRunResult, error) {
+	backend := dryRunBackend{Backend: api.backend, charmURL: arg.CharmURL, charm: arg.Charm}
+	deployParams, ch, err := deployApplicationParams(backend, api.stateCharm, arg.ApplicationDeploy)
+	if err != nil {
+		return params.DeployDryRunResult{}, errors.Trace(err)
+	}
+	addArgs, err := addApplicationArgs(ch, deployParams)
+	if err != nil {
+		return params.DeployDryRunResult{}, errors.Trace(err)
+	}
+	addArgs, err = api.backend.ValidateAddApplication(addArgs)
+	if err != nil {
+		return params.DeployDryRunResult{}, errors.Trace(err)
+	}
+	specified := set.NewStrings()
+	for _, res := range arg.DryRunResources {
+		specified.Add(res.Name)
+	}
+	for name := range ch.Meta().Resources {
+		if !specified.Contains(name) {
+			return params.DeployDryRunResult{}, errors.Errorf("resource %q has not been specified", name)
+		}
+	}
+	resources, err := api.dryRunResources(arg.ApplicationName, ch.Meta().Resources, arg.DryRunResources)
+	if err != nil {
+		return params.DeployDryRunResult{}, errors.Trace(err)
+	}
+	return params.DeployDryRunResult{
+		ApplicationName:  addArgs.Name,
+		CharmURL:         arg.CharmURL,
+		Series:           addArgs.Series,
+		NumUnits:         addArgs.NumUnits,
+		Placement:        api.unitPlacements(addArgs.NumUnits, addArgs.Placement),
+		Storage:          storageConstraintsParams(addArgs.Storage),
+		EndpointBindings: addArgs.EndpointBindings,
+		Resources:        resources,
+	}, nil
+}
+
+// SetCharmDryRun validates the arguments for setting an application's
+// charm, and reports what the upgrade would change. Neither the charm
+// nor the resources need have been uploaded, and the model is not
+// changed.
+func (api *APIv7) SetCharmDryRun(args params.SetCharmDryRunArg) (params.SetCharmDryRunResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+	if !args.ForceUnits {
+		if err := api.check.ChangeAllowed(); err != nil {
+			return params.SetCharmDryRunResult{}, errors.Trace(err)
+		}
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+	cfg, newCharm, err := api.setCharmConfig(
+		dryRunBackend{Backend: api.backend, charmURL: args.CharmURL, charm: args.Charm},
+		args.ApplicationName,
+		args.CharmURL,
+		csparams.Channel(args.Channel),
+		args.ConfigSettings,
+		args.ConfigSettingsYAML,
+		args.ForceSeries,
+		args.ForceUnits,
+		nil,
+		args.StorageConstraints,
+	)
+	if err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+	if err := app.ValidateSetCharm(cfg); err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+
+	oldCharm, _, err := app.Charm()
+	if err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+	oldURL, _ := app.CharmURL()
+	added, removed := configChanges(oldCharm.Config(), newCharm.Config())
+	broken, err := brokenRelations(args.ApplicationName, app, newCharm)
+	if err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+	resources, err := api.dryRunResources(args.ApplicationName, newCharm.Meta().Resources, args.DryRunResources)
+	if err != nil {
+		return params.SetCharmDryRunResult{}, errors.Trace(err)
+	}
+	return params.SetCharmDryRunResult{
+		ApplicationName: args.ApplicationName,
+		CurrentCharmURL: oldURL.String(),
+		CharmURL:        args.CharmURL,
+		ConfigAdded:     added,
+		ConfigRemoved:   removed,
+		BrokenRelations: broken,
+		Resources:       resources,
+	}, nil
+}
+
+// dryRunBackend is a Backend which uses the charm described by the
+// arguments of a dry run, which need not have been added to the model.
+// A local charm may differ from one already stored under its URL.
+type dryRunBackend struct {
+	Backend
+	charmURL string
+	charm    *params.DryRunCharm
+}
+
+// Charm is part of the Backend interface.
+func (b dryRunBackend) Charm(curl *charm.URL) (Charm, error) {
+	if b.charm == nil || curl.String() != b.charmURL {
+		return b.Backend.Charm(curl)
+	}
+	return b.Backend.UnstoredCharm(curl, b.charm.Meta, b.charm.Config)
+}
+
+// AddUnitsDryRun validates the arguments for adding units to an
+// application, and reports where the units would be placed. The model
+// is not changed.
+func (api *APIv7) AddUnitsDryRun(args params.AddApplicationUnits) (params.AddUnitsDryRunResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.AddUnitsDryRunResult{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.AddUnitsDryRunResult{}, errors.Trace(err)
+	}
+	_, attachStorage, err := validateAddApplicationUnits(api.backend, args)
+	if err != nil {
+		return params.AddUnitsDryRunResult{}, errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return params.AddUnitsDryRunResult{}, errors.Trace(err)
+	}
+	if app.Life() != state.Alive {
+		return params.AddUnitsDryRunResult{}, errors.Errorf("application %q is not alive", args.ApplicationName)
+	}
+	if !app.IsPrincipal() {
+		return params.AddUnitsDryRunResult{}, errors.Errorf(
+			"cannot add units to subordinate application %q", args.ApplicationName,
+		)
+	}
+	for _, tag := range attachStorage {
+		if _, err := api.backend.StorageInstance(tag); err != nil {
+			return params.AddUnitsDryRunResult{}, errors.Annotatef(err, "cannot attach %s", tag.Id())
+		}
+	}
+	for i, p := range args.Placement {
+		if i >= args.NumUnits {
+			break
+		}
+		if err := api.checkPlacementMachine(p, app.Series()); err != nil {
+			return params.AddUnitsDryRunResult{}, errors.Annotatef(
+				err, "cannot add unit %d/%d to application %q", i+1, args.NumUnits, args.ApplicationName,
+			)
+		}
+	}
+	return params.AddUnitsDryRunResult{
+		ApplicationName: args.ApplicationName,
+		NumUnits:        args.NumUnits,
+		Placement:       api.unitPlacements(args.NumUnits, args.Placement),
+	}, nil
+}
+
+// checkPlacementMachine checks that the machine named by a machine or
+// container placement directive exists, and can host a unit of the
+// given series.
+func (api *APIv7) checkPlacementMachine(p *instance.Placement, series string) error {
+	if p == nil || p.Directive == "" {
+		return nil
+	}
+	if p.Scope != instance.MachineScope && !isContainerScope(p.Scope) {
+		return nil
+	}
+	m, err := api.backend.Machine(p.Directive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Life() != state.Alive {
+		return errors.Errorf("machine %s is not alive", p.Directive)
+	}
+	if p.Scope == instance.MachineScope && m.Series() != series {
+		return errors.Errorf(
+			"series does not match: unit has %q, machine %s has %q",
+			series, p.Directive, m.Series(),
+		)
+	}
+	return nil
+}
+
+func isContainerScope(scope string) bool {
+	for _, ctype := range instance.ContainerTypes {
+		if scope == string(ctype) {
+			return true
+		}
+	}
+	return false
+}
+
+// unitPlacements returns a description of the placement of each of
+// numUnits units, given the placement directives.
+func (api *APIv7) unitPlacements(numUnits int, placement []*instance.Placement) []string {
+	if numUnits == 0 {
+		return nil
+	}
+	modelUUID := api.backend.ModelTag().Id()
+	result := make([]string, numUnits)
+	for i := range result {
+		if i >= len(placement) || placement[i] == nil {
+			continue
+		}
+		switch p := placement[i]; p.Scope {
+		case instance.MachineScope, modelUUID:
+			result[i] = p.Directive
+		default:
+			result[i] = p.String()
+		}
+	}
+	return result
+}
+
+// dryRunResources checks that the resources a dry run would use are
+// the charm's, and describes them along with the revisions currently
+// used by the application.
+func (api *APIv7) dryRunResources(
+	appName string,
+	metas map[string]charmresource.Meta,
+	planned []params.DryRunResource,
+) ([]params.DryRunResource, error) {
+	if len(planned) == 0 {
+		return nil, nil
+	}
+	resources, err := api.backend.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current := make(map[string]int)
+	if appResources, err := resources.ListResources(appName); err == nil {
+		for _, res := range appResources.Resources {
+			current[res.Name] = res.Revision
+		}
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	result := make([]params.DryRunResource, len(planned))
+	for i, res := range planned {
+		if _, ok := metas[res.Name]; !ok {
+			return nil, errors.Errorf("charm has no resource %q", res.Name)
+		}
+		if _, err := charmresource.ParseOrigin(res.Origin); err != nil {
+			return nil, errors.Annotatef(err, "resource %q", res.Name)
+		}
+		currentRevision, ok := current[res.Name]
+		if !ok {
+			currentRevision = -1
+		}
+		res.CurrentRevision = currentRevision
+		result[i] = res
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// configChanges returns the names of the config options that are in
+// the new config but not the old, and those in the old but not the
+// new.
+func configChanges(oldConfig, newConfig *charm.Config) (added, removed []string) {
+	oldNames := set.NewStrings()
+	if oldConfig != nil {
+		for name := range oldConfig.Options {
+			oldNames.Add(name)
+		}
+	}
+	newNames := set.NewStrings()
+	if newConfig != nil {
+		for name := range newConfig.Options {
+			newNames.Add(name)
+		}
+	}
+	return newNames.Difference(oldNames).SortedValues(), oldNames.Difference(newNames).SortedValues()
+}
+
+// brokenRelations returns the keys of the application's relations with
+// endpoints that the new charm does not implement. As with upgrading
+// the charm, a peer relation of an application with a single unit is
+// not considered broken.
+func brokenRelations(appName string, app Application, newCharm Charm) ([]string, error) {
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var broken []string
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.ImplementedBy(newCharm) {
+			continue
+		}
+		if ep.Role == charm.RolePeer {
+			units, err := app.AllUnits()
+			if err == nil && len(units) == 1 {
+				continue
+			}
+		}
+		broken = append(broken, rel.Tag().Id())
+	}
+	sort.Strings(broken)
+	return broken, nil
+}
+
+func storageConstraintsParams(cons map[string]state.StorageConstraints) map[string]storage.Constraints {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]storage.Constraints, len(cons))
+	for name, c := range cons {
+		result[name] = storage.Constraints{
+			Pool:  c.Pool,
+			Size:  c.Size,
+			Count: c.Count,
+		}
+	}
+	return result
+}
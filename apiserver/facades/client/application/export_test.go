@@ -4,6 +4,7 @@
 package application
 
 var (
-	ParseSettingsCompatible = parseSettingsCompatible
-	NewStateStorage         = &newStateStorage
+	ParseSettingsCompatible   = parseSettingsCompatible
+	NewStateStorage           = &newStateStorage
+	AddTrustSchemaAndDefaults = addTrustSchemaAndDefaults
 )
@@ -68,7 +68,7 @@ func (api *APIv5) getConfig(
 		return params.ApplicationGetResults{}, err
 	}
 
-	providerSchema, providerDefaults, err := applicationConfigSchema(api.backend.ModelType())
+	providerSchema, providerDefaults, err := ApplicationConfigSchema(api.backend.ModelType())
 	if err != nil {
 		return params.ApplicationGetResults{}, err
 	}
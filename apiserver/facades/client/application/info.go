@@ -0,0 +1,278 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+// ApplicationsInfo returns the details of each of the given
+// applications. Remote applications consumed from offers are
+// reported along with their offer details.
+func (api *APIv8) ApplicationsInfo(args params.Entities) (params.ApplicationInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationInfoResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationInfoResults{
+		Results: make([]params.ApplicationInfoResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		info, err := api.applicationInfo(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = info
+	}
+	return results, nil
+}
+
+func (api *APIv8) applicationInfo(tagString string) (*params.ApplicationInfo, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if errors.IsNotFound(err) {
+		return api.remoteApplicationInfo(tag)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := app.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bindings, err := app.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related, err := containerRelatedApplications(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.ApplicationInfo{
+		Tag:              tag.String(),
+		Series:           app.Series(),
+		Channel:          string(app.Channel()),
+		Constraints:      cons,
+		Principal:        app.IsPrincipal(),
+		Exposed:          app.IsExposed(),
+		EndpointBindings: bindings,
+	}
+	if curl, _ := app.CharmURL(); curl != nil {
+		info.Charm = curl.String()
+	}
+	if info.Principal {
+		info.Subordinates = related
+	} else {
+		info.Principals = related
+	}
+	return info, nil
+}
+
+func (api *APIv8) remoteApplicationInfo(tag names.ApplicationTag) (*params.ApplicationInfo, error) {
+	app, err := api.backend.RemoteApplication(tag.Id())
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("application %q", tag.Id())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.ApplicationInfo{
+		Tag:              tag.String(),
+		Remote:           true,
+		EndpointBindings: app.Bindings(),
+		SourceModelTag:   app.SourceModel().String(),
+	}
+	if url, ok := app.URL(); ok {
+		info.OfferURL = url
+	}
+	return info, nil
+}
+
+// containerRelatedApplications returns the sorted names of the
+// applications related to the given one by container scoped
+// relations; for a principal these are its subordinates, and for a
+// subordinate its principals.
+func containerRelatedApplications(app Application) ([]string, error) {
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.Scope != charm.ScopeContainer {
+			continue
+		}
+		eps, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range eps {
+			related.Add(ep.ApplicationName)
+		}
+	}
+	return related.SortedValues(), nil
+}
+
+// UnitsInfo returns the details of each of the given units, including
+// the relation settings of the unit and of its related units.
+func (api *APIv8) UnitsInfo(args params.Entities) (params.UnitInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitInfoResults{}, errors.Trace(err)
+	}
+	leaders, err := api.backend.ApplicationLeaders()
+	if err != nil {
+		return params.UnitInfoResults{}, errors.Trace(err)
+	}
+	results := params.UnitInfoResults{
+		Results: make([]params.UnitInfoResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		info, err := api.unitInfo(arg.Tag, leaders)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = info
+	}
+	return results, nil
+}
+
+func (api *APIv8) unitInfo(tagString string, leaders map[string]string) (*params.UnitInfo, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.UnitInfo{
+		Tag:    tag.String(),
+		Life:   unit.Life().String(),
+		Leader: leaders[unit.ApplicationName()] == unit.Name(),
+	}
+	if curl, ok := unit.CharmURL(); ok {
+		info.Charm = curl.String()
+	}
+	if principal, ok := unit.PrincipalName(); ok {
+		info.Principal = principal
+	}
+	if info.WorkloadVersion, err = unit.WorkloadVersion(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info.WorkloadStatus = common.EntityStatusFromState(workloadStatus)
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info.AgentStatus = common.EntityStatusFromState(agentStatus)
+
+	if err := unitMachineInfo(unit, info); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.RelationData, err = unitRelationData(unit); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info, nil
+}
+
+// unitMachineInfo fills in the machine, addresses and opened ports of
+// a unit that has been assigned to a machine.
+func unitMachineInfo(unit Unit, info *params.UnitInfo) error {
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	info.Machine = machineId
+
+	publicAddress, err := unit.PublicAddress()
+	if err != nil && !network.IsNoAddressError(err) {
+		return errors.Trace(err)
+	}
+	info.PublicAddress = publicAddress.Value
+	privateAddress, err := unit.PrivateAddress()
+	if err != nil && !network.IsNoAddressError(err) {
+		return errors.Trace(err)
+	}
+	info.PrivateAddress = privateAddress.Value
+
+	ports, err := unit.OpenedPorts()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, port := range ports {
+		info.OpenedPorts = append(info.OpenedPorts, port.String())
+	}
+	return nil
+}
+
+// unitRelationData returns the settings of the unit, and of the units
+// related to it, for each relation the unit is in scope of.
+func unitRelationData(unit Unit) ([]params.EndpointRelationData, error) {
+	relations, err := unit.RelationsInScope()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.EndpointRelationData
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(unit.ApplicationName())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data := params.EndpointRelationData{
+			RelationId: rel.Id(),
+			Endpoint:   ep.Name,
+		}
+		related, err := rel.RelatedEndpoints(unit.ApplicationName())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(related) > 0 {
+			data.RelatedEndpoint = related[0].Name
+		}
+		if data.UnitData, err = rel.ReadUnitSettings(unit.Name()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		relatedUnits, err := rel.RelatedUnitsInScope(unit.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sort.Strings(relatedUnits)
+		for _, name := range relatedUnits {
+			settings, err := rel.ReadUnitSettings(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if data.RelatedUnits == nil {
+				data.RelatedUnits = make(map[string]map[string]interface{})
+			}
+			data.RelatedUnits[name] = settings
+		}
+		result = append(result, data)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RelationId < result[j].RelationId
+	})
+	return result, nil
+}
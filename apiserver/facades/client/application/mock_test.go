@@ -13,13 +13,16 @@ import (
 	jtesting "github.com/juju/testing"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/constraints"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -95,6 +98,12 @@ type mockApplication struct {
 	units       []mockUnit
 	addedUnit   mockUnit
 	config      coreapplication.ConfigAttributes
+	life        state.Life
+	relations   []application.Relation
+	exposed     bool
+	channel     csparams.Channel
+	constraints constraints.Value
+	scale       int
 }
 
 func (m *mockApplication) Name() string {
@@ -134,6 +143,20 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) ValidateSetCharm(cfg state.SetCharmConfig) error {
+	a.MethodCall(a, "ValidateSetCharm", cfg)
+	return a.NextErr()
+}
+
+func (a *mockApplication) Life() state.Life {
+	return a.life
+}
+
+func (a *mockApplication) Relations() ([]application.Relation, error) {
+	a.MethodCall(a, "Relations")
+	return a.relations, a.NextErr()
+}
+
 func (a *mockApplication) DestroyOperation() *state.DestroyApplicationOperation {
 	a.MethodCall(a, "DestroyOperation")
 	return &state.DestroyApplicationOperation{}
@@ -179,11 +202,38 @@ func (a *mockApplication) UpdateApplicationConfig(
 	return a.NextErr()
 }
 
+func (a *mockApplication) UpdateEndpointBindings(bindings map[string]string) error {
+	a.MethodCall(a, "UpdateEndpointBindings", bindings)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetScale(scale int) error {
+	a.MethodCall(a, "SetScale", scale)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	a.scale = scale
+	return nil
+}
+
 func (a *mockApplication) UpdateCharmConfig(settings charm.Settings) error {
 	a.MethodCall(a, "UpdateCharmConfig", settings)
 	return a.NextErr()
 }
 
+func (a *mockApplication) IsExposed() bool {
+	return a.exposed
+}
+
+func (a *mockApplication) Channel() csparams.Channel {
+	return a.channel
+}
+
+func (a *mockApplication) Constraints() (constraints.Value, error) {
+	a.MethodCall(a, "Constraints")
+	return a.constraints, a.NextErr()
+}
+
 func (a *mockApplication) SetExposed() error {
 	a.MethodCall(a, "SetExposed")
 	return a.NextErr()
@@ -209,6 +259,10 @@ func (m *mockRemoteApplication) SourceModel() names.ModelTag {
 	return m.sourceModelTag
 }
 
+func (m *mockRemoteApplication) URL() (string, bool) {
+	return m.offerURL, m.offerURL != ""
+}
+
 func (m *mockRemoteApplication) Endpoints() ([]state.Endpoint, error) {
 	return m.endpoints, nil
 }
@@ -297,6 +351,8 @@ type mockBackend struct {
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
+	machines                   map[string]*mockMachine
+	leaders                    map[string]string
 }
 
 func (m *mockBackend) ControllerTag() names.ControllerTag {
@@ -318,6 +374,14 @@ func (m *mockBackend) Charm(curl *charm.URL) (application.Charm, error) {
 	return nil, errors.NotFoundf("charm %q", curl)
 }
 
+func (m *mockBackend) UnstoredCharm(curl *charm.URL, meta *charm.Meta, config *charm.Config) (application.Charm, error) {
+	m.MethodCall(m, "UnstoredCharm", curl, meta, config)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &mockCharm{meta: meta, config: config}, nil
+}
+
 func (m *mockBackend) Unit(name string) (application.Unit, error) {
 	m.MethodCall(m, "Unit", name)
 	if err := m.NextErr(); err != nil {
@@ -489,6 +553,28 @@ func (m *mockBackend) Application(name string) (application.Application, error)
 	return app, nil
 }
 
+func (m *mockBackend) ApplicationLeaders() (map[string]string, error) {
+	m.MethodCall(m, "ApplicationLeaders")
+	return m.leaders, m.NextErr()
+}
+
+func (m *mockBackend) Machine(id string) (application.Machine, error) {
+	m.MethodCall(m, "Machine", id)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	machine, ok := m.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return machine, nil
+}
+
+func (m *mockBackend) ValidateAddApplication(args state.AddApplicationArgs) (state.AddApplicationArgs, error) {
+	m.MethodCall(m, "ValidateAddApplication", args)
+	return args, m.NextErr()
+}
+
 func (m *mockBackend) ApplyOperation(op state.ModelOperation) error {
 	m.MethodCall(m, "ApplyOperation", op)
 	return m.NextErr()
@@ -532,6 +618,11 @@ func (m *mockBackend) ModelType() state.ModelType {
 	return m.modelType
 }
 
+func (m *mockBackend) WithSpanContext(spanContext tracing.SpanContext) application.Backend {
+	m.MethodCall(m, "WithSpanContext", spanContext)
+	return m
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
@@ -550,17 +641,46 @@ type mockRelation struct {
 	application.Relation
 	jtesting.Stub
 
-	tag             names.Tag
-	status          status.Status
-	message         string
-	suspended       bool
-	suspendedReason string
+	tag              names.Tag
+	id               int
+	status           status.Status
+	message          string
+	suspended        bool
+	suspendedReason  string
+	endpoint         state.Endpoint
+	relatedEndpoints []state.Endpoint
+	relatedUnits     []string
+	settings         map[string]map[string]interface{}
 }
 
 func (r *mockRelation) Tag() names.Tag {
 	return r.tag
 }
 
+func (r *mockRelation) Endpoint(appName string) (state.Endpoint, error) {
+	r.MethodCall(r, "Endpoint", appName)
+	return r.endpoint, r.NextErr()
+}
+
+func (r *mockRelation) Id() int {
+	return r.id
+}
+
+func (r *mockRelation) RelatedEndpoints(appName string) ([]state.Endpoint, error) {
+	r.MethodCall(r, "RelatedEndpoints", appName)
+	return r.relatedEndpoints, r.NextErr()
+}
+
+func (r *mockRelation) ReadUnitSettings(unitName string) (map[string]interface{}, error) {
+	r.MethodCall(r, "ReadUnitSettings", unitName)
+	return r.settings[unitName], r.NextErr()
+}
+
+func (r *mockRelation) RelatedUnitsInScope(unitName string) ([]string, error) {
+	r.MethodCall(r, "RelatedUnitsInScope", unitName)
+	return r.relatedUnits, r.NextErr()
+}
+
 func (r *mockRelation) SetStatus(status status.StatusInfo) error {
 	r.MethodCall(r, "SetStatus")
 	r.status = status.Status
@@ -590,16 +710,109 @@ func (r *mockRelation) Destroy() error {
 	return r.NextErr()
 }
 
+type mockMachine struct {
+	application.Machine
+
+	life   state.Life
+	series string
+}
+
+func (m *mockMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *mockMachine) Series() string {
+	return m.series
+}
+
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag names.UnitTag
+	tag             names.UnitTag
+	life            state.Life
+	charmURL        *charm.URL
+	principal       string
+	workloadVersion string
+	status          status.StatusInfo
+	agentStatus     status.StatusInfo
+	machineId       string
+	publicAddress   network.Address
+	privateAddress  network.Address
+	ports           []network.PortRange
+	relations       []application.Relation
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
 	return u.tag
 }
 
+func (u *mockUnit) Name() string {
+	return u.tag.Id()
+}
+
+func (u *mockUnit) ApplicationName() string {
+	appName, _ := names.UnitApplication(u.tag.Id())
+	return appName
+}
+
+func (u *mockUnit) Life() state.Life {
+	return u.life
+}
+
+func (u *mockUnit) CharmURL() (*charm.URL, bool) {
+	return u.charmURL, u.charmURL != nil
+}
+
+func (u *mockUnit) PrincipalName() (string, bool) {
+	return u.principal, u.principal != ""
+}
+
+func (u *mockUnit) WorkloadVersion() (string, error) {
+	u.MethodCall(u, "WorkloadVersion")
+	return u.workloadVersion, u.NextErr()
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	u.MethodCall(u, "Status")
+	return u.status, u.NextErr()
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	return u.agentStatus, u.NextErr()
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	u.MethodCall(u, "AssignedMachineId")
+	if err := u.NextErr(); err != nil {
+		return "", err
+	}
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.tag.Id())
+	}
+	return u.machineId, nil
+}
+
+func (u *mockUnit) PublicAddress() (network.Address, error) {
+	u.MethodCall(u, "PublicAddress")
+	return u.publicAddress, u.NextErr()
+}
+
+func (u *mockUnit) PrivateAddress() (network.Address, error) {
+	u.MethodCall(u, "PrivateAddress")
+	return u.privateAddress, u.NextErr()
+}
+
+func (u *mockUnit) OpenedPorts() ([]network.PortRange, error) {
+	u.MethodCall(u, "OpenedPorts")
+	return u.ports, u.NextErr()
+}
+
+func (u *mockUnit) RelationsInScope() ([]application.Relation, error) {
+	u.MethodCall(u, "RelationsInScope")
+	return u.relations, u.NextErr()
+}
+
 func (u *mockUnit) IsPrincipal() bool {
 	u.MethodCall(u, "IsPrincipal")
 	u.PopNoErr()
@@ -0,0 +1,51 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ScaleApplications sets the number of units, and hence pods, each of
+// the given applications should have. The units themselves are added
+// and removed as the pods are started and stopped by the cloud.
+func (api *APIv9) ScaleApplications(args params.ScaleApplicationsParams) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		err := api.scaleApplication(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIv9) scaleApplication(arg params.ScaleApplicationParams) error {
+	if modelType := api.backend.ModelType(); modelType != state.ModelTypeCAAS {
+		return errors.NotSupportedf("scaling applications in %s models", modelType)
+	}
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Scale < 0 {
+		return errors.NotValidf("negative scale %d", arg.Scale)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetScale(arg.Scale)
+}
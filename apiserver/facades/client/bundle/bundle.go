@@ -5,48 +5,98 @@
 package bundle
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/bundlechanges"
+	"github.com/juju/description"
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
-// NewFacade provides the required signature for facade registration.
-func NewFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewBundle(auth)
+// Backend defines the state functionality required by the bundle
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ExportPartial(cfg state.ExportConfig) (description.Model, error)
 }
 
-// NewBundle creates and returns a new Bundle API facade.
-func NewBundle(auth facade.Authorizer) (Bundle, error) {
-	if !auth.AuthClient() {
-		return nil, common.ErrPerm
+// APIv1 provides the Bundle API facade for version 1.
+type APIv1 struct {
+	*APIv2
+}
+
+// APIv2 provides the Bundle API facade for version 2. It implements
+// the bundle-related API end points.
+type APIv2 struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// NewFacadeV1 provides the signature required for facade registration
+// for version 1.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
-	return &bundleAPI{}, nil
+	return &APIv1{api}, nil
+}
+
+// NewFacadeV2 provides the signature required for facade registration
+// for version 2.
+func NewFacadeV2(ctx facade.Context) (*APIv2, error) {
+	st := ctx.State()
+	return NewBundleAPI(st, ctx.Auth(), names.NewModelTag(st.ModelUUID()))
 }
 
-// Bundle defines the API endpoint used to retrieve bundle changes.
-type Bundle interface {
-	// GetChanges returns the list of changes required to deploy the given
-	// bundle data.
-	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
+// NewBundleAPI creates and returns a new Bundle API facade.
+func NewBundleAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	modelTag names.ModelTag,
+) (*APIv2, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &APIv2{
+		backend:    backend,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
 }
 
-// bundleAPI implements the Bundle interface and is the concrete implementation
-// of the API end point.
-type bundleAPI struct{}
+// ExportBundle isn't on the V1 API.
+func (*APIv1) ExportBundle(_, _ struct{}) {}
+
+func (b *APIv2) checkCanRead() error {
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
 // order.
-func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
+func (b *APIv2) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
 	var results params.BundleChangesResults
 	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
 	if err != nil {
@@ -86,3 +136,215 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	return results, nil
 }
+
+// ExportBundle exports the current model configuration as a bundle. The
+// result is the YAML representation of the bundle, suitable for passing
+// to "juju deploy".
+func (b *APIv2) ExportBundle() (params.StringResult, error) {
+	fail := func(failErr error) (params.StringResult, error) {
+		return params.StringResult{}, common.ServerError(failErr)
+	}
+
+	if err := b.checkCanRead(); err != nil {
+		return fail(err)
+	}
+
+	model, err := b.backend.ExportPartial(state.ExportConfig{
+		SkipActions:            true,
+		SkipCloudImageMetadata: true,
+		SkipCredentials:        true,
+		SkipIPAddresses:        true,
+		SkipSSHHostKeys:        true,
+		SkipStatusHistory:      true,
+		SkipLinkLayerDevices:   true,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	data, err := b.fillBundleData(model)
+	if err != nil {
+		return fail(err)
+	}
+
+	bytes, err := goyaml.Marshal(data)
+	if err != nil {
+		return fail(err)
+	}
+
+	return params.StringResult{Result: string(bytes)}, nil
+}
+
+// fillBundleData creates a charm.BundleData from the exported model
+// description. Series, machine and storage information that matches
+// the model defaults is omitted so that the resulting bundle is as
+// concise as possible.
+func (b *APIv2) fillBundleData(model description.Model) (*charm.BundleData, error) {
+	cfg := model.Config()
+	defaultSeries, _ := cfg["default-series"].(string)
+
+	data := &charm.BundleData{
+		Series:       defaultSeries,
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+	if len(model.Applications()) == 0 {
+		return nil, errors.NotFoundf("applications in model %q", b.modelTag.Id())
+	}
+
+	// The units of CAAS applications are not assigned
+	// to machines, so they have no placement.
+	isCAAS := model.Type() == string(state.ModelTypeCAAS)
+	usedMachines := set.NewStrings()
+	for _, application := range model.Applications() {
+		appSeries := application.Series()
+		if appSeries == defaultSeries {
+			appSeries = ""
+		}
+		spec := &charm.ApplicationSpec{
+			Charm:            application.CharmURL(),
+			Series:           appSeries,
+			Expose:           application.Exposed(),
+			Options:          application.CharmConfig(),
+			Annotations:      application.Annotations(),
+			EndpointBindings: endpointBindings(application.EndpointBindings()),
+			Storage:          storageDirectives(application.StorageConstraints()),
+		}
+		if !application.Subordinate() {
+			spec.NumUnits = len(application.Units())
+			spec.Constraints = constraintsString(application.Constraints())
+		}
+		if !application.Subordinate() && !isCAAS {
+			for _, unit := range application.Units() {
+				machineId := unit.Machine().Id()
+				usedMachines.Add(state.TopParentId(machineId))
+				spec.To = append(spec.To, unitPlacement(machineId))
+			}
+		}
+		data.Applications[application.Name()] = spec
+	}
+
+	for _, machine := range model.Machines() {
+		if !usedMachines.Contains(machine.Id()) {
+			continue
+		}
+		machineSeries := machine.Series()
+		if machineSeries == defaultSeries {
+			machineSeries = ""
+		}
+		data.Machines[machine.Id()] = &charm.MachineSpec{
+			Constraints: constraintsString(machine.Constraints()),
+			Annotations: machine.Annotations(),
+			Series:      machineSeries,
+		}
+	}
+
+	for _, relation := range model.Relations() {
+		var endpoints []string
+		for _, ep := range relation.Endpoints() {
+			// Peer relations are established automatically when the
+			// application is deployed, and relations with remote
+			// applications cannot be expressed in a bundle.
+			if ep.Role() == string(charm.RolePeer) {
+				continue
+			}
+			if _, ok := data.Applications[ep.ApplicationName()]; !ok {
+				endpoints = nil
+				break
+			}
+			endpoints = append(endpoints, ep.ApplicationName()+":"+ep.Name())
+		}
+		if len(endpoints) == 2 {
+			data.Relations = append(data.Relations, endpoints)
+		}
+	}
+	return data, nil
+}
+
+// unitPlacement returns the bundle placement directive for a unit
+// assigned to the machine with the given id. Units in containers are
+// placed with a "<container-type>:<parent>" directive, so that the
+// container is recreated on deploy.
+func unitPlacement(machineId string) string {
+	if !names.IsContainerMachine(machineId) {
+		return machineId
+	}
+	return fmt.Sprintf("%s:%s", state.ContainerTypeFromId(machineId), state.ParentId(machineId))
+}
+
+// endpointBindings returns the endpoint bindings that are explicitly
+// bound to a space; bindings to the default space are omitted.
+func endpointBindings(bindings map[string]string) map[string]string {
+	result := make(map[string]string)
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		result[endpoint] = space
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// storageDirectives converts the application storage constraints into
+// the "pool,count,size" form understood by bundles.
+func storageDirectives(cons map[string]description.StorageConstraint) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for name, sc := range cons {
+		var fields []string
+		if sc.Pool() != "" {
+			fields = append(fields, sc.Pool())
+		}
+		fields = append(fields, fmt.Sprint(sc.Count()))
+		if sc.Size() != 0 {
+			fields = append(fields, fmt.Sprintf("%dM", sc.Size()))
+		}
+		result[name] = strings.Join(fields, ",")
+	}
+	return result
+}
+
+// constraintsString returns the string representation of the given
+// exported constraints, as accepted by constraints.Parse.
+func constraintsString(cons description.Constraints) string {
+	if cons == nil {
+		return ""
+	}
+	var result constraints.Value
+	if arch := cons.Architecture(); arch != "" {
+		result.Arch = &arch
+	}
+	if container := instance.ContainerType(cons.Container()); container != "" {
+		result.Container = &container
+	}
+	if cores := cons.CpuCores(); cores != 0 {
+		result.CpuCores = &cores
+	}
+	if power := cons.CpuPower(); power != 0 {
+		result.CpuPower = &power
+	}
+	if inst := cons.InstanceType(); inst != "" {
+		result.InstanceType = &inst
+	}
+	if mem := cons.Memory(); mem != 0 {
+		result.Mem = &mem
+	}
+	if disk := cons.RootDisk(); disk != 0 {
+		result.RootDisk = &disk
+	}
+	if spaces := cons.Spaces(); len(spaces) > 0 {
+		result.Spaces = &spaces
+	}
+	if tags := cons.Tags(); len(tags) > 0 {
+		result.Tags = &tags
+	}
+	if virt := cons.VirtType(); virt != "" {
+		result.VirtType = &virt
+	}
+	return result.String()
+}
@@ -4,29 +4,38 @@
 package bundle_test
 
 import (
+	"strings"
+
+	"github.com/juju/description"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type bundleSuite struct {
 	coretesting.BaseSuite
-	facade bundle.Bundle
+	auth    *apiservertesting.FakeAuthorizer
+	facade  *bundle.APIv2
+	backend *mockBackend
 }
 
 var _ = gc.Suite(&bundleSuite{})
 
 func (s *bundleSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
-	auth := apiservertesting.FakeAuthorizer{
-		Tag: names.NewUserTag("who"),
+	s.auth = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
 	}
-	facade, err := bundle.NewBundle(auth)
+	s.backend = &mockBackend{}
+	facade, err := bundle.NewBundleAPI(s.backend, s.auth, names.NewModelTag("some-uuid"))
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
@@ -198,3 +207,262 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 		}
 	}
 }
+
+func (s *bundleSuite) TestExportBundleFailNoApplication(c *gc.C) {
+	s.backend.model = description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region",
+	})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, `applications in model "some-uuid" not found`)
+	c.Assert(result, gc.Equals, params.StringResult{})
+	s.backend.CheckCallNames(c, "ExportPartial")
+}
+
+func (s *bundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("nobody")
+	s.auth.AdminTag = names.NewUserTag("admin")
+
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *bundleSuite) TestExportBundleModelWithSettingsRelations(c *gc.C) {
+	s.backend.model = description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name":           "awesome",
+			"uuid":           "some-uuid",
+			"default-series": "xenial",
+		},
+		CloudRegion: "some-region",
+	})
+
+	wordpress := s.backend.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		CharmURL: "cs:wordpress-5",
+		Series:   "xenial",
+		Exposed:  true,
+		CharmConfig: map[string]interface{}{
+			"blog-title": "my blog",
+		},
+		EndpointBindings: map[string]string{
+			"url": "public",
+			"db":  "",
+		},
+	})
+	wordpress.SetConstraints(description.ConstraintsArgs{Memory: 4096})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/1"),
+		Machine: names.NewMachineTag("1/lxd/0"),
+	})
+
+	mysql := s.backend.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		CharmURL: "cs:trusty/mysql-57",
+		Series:   "trusty",
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"data": {Pool: "ebs", Size: 10240, Count: 1},
+		},
+	})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Machine: names.NewMachineTag("2"),
+	})
+
+	s.backend.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "xenial",
+	})
+	machine1 := s.backend.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("1"),
+		Series: "xenial",
+	})
+	machine1.AddContainer(description.MachineArgs{
+		Id:     names.NewMachineTag("1/lxd/0"),
+		Series: "xenial",
+	})
+	machine2 := s.backend.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("2"),
+		Series: "trusty",
+	})
+	machine2.SetConstraints(description.ConstraintsArgs{CpuCores: 2})
+	// Machines without units are not part of the bundle.
+	s.backend.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("3"),
+		Series: "xenial",
+	})
+
+	rel := s.backend.model.AddRelation(description.RelationArgs{
+		Id:  42,
+		Key: "wordpress:db mysql:mysql",
+	})
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "wordpress",
+		Name:            "db",
+		Role:            "requirer",
+		Interface:       "mysql",
+		Scope:           "global",
+	})
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "mysql",
+		Role:            "provider",
+		Interface:       "mysql",
+		Scope:           "global",
+	})
+	peer := s.backend.model.AddRelation(description.RelationArgs{
+		Id:  43,
+		Key: "mysql:cluster",
+	})
+	peer.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "cluster",
+		Role:            "peer",
+		Interface:       "mysql-ha",
+		Scope:           "global",
+	})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Series: "xenial",
+		Applications: map[string]*charm.ApplicationSpec{
+			"wordpress": {
+				Charm:            "cs:wordpress-5",
+				NumUnits:         2,
+				To:               []string{"0", "lxd:1"},
+				Expose:           true,
+				Options:          map[string]interface{}{"blog-title": "my blog"},
+				Constraints:      "mem=4096M",
+				EndpointBindings: map[string]string{"url": "public"},
+			},
+			"mysql": {
+				Charm:    "cs:trusty/mysql-57",
+				Series:   "trusty",
+				NumUnits: 1,
+				To:       []string{"2"},
+				Storage:  map[string]string{"data": "ebs,1,10240M"},
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			"0": {},
+			"1": {},
+			"2": {Series: "trusty", Constraints: "cores=2"},
+		},
+		Relations: [][]string{
+			{"wordpress:db", "mysql:mysql"},
+		},
+	})
+	s.backend.CheckCallNames(c, "ExportPartial")
+}
+
+func (s *bundleSuite) TestExportBundleCAAS(c *gc.C) {
+	s.backend.model = description.NewModel(description.ModelArgs{
+		Type:  "caas",
+		Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region",
+	})
+	gitlab := s.backend.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("gitlab"),
+		CharmURL: "local:kubernetes/gitlab-1",
+		Series:   "kubernetes",
+	})
+	gitlab.AddUnit(description.UnitArgs{
+		Tag: names.NewUnitTag("gitlab/0"),
+	})
+	gitlab.AddUnit(description.UnitArgs{
+		Tag: names.NewUnitTag("gitlab/1"),
+	})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Not(jc.Contains), "to:")
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"gitlab": {
+				Charm:    "local:kubernetes/gitlab-1",
+				Series:   "kubernetes",
+				NumUnits: 2,
+			},
+		},
+	})
+}
+
+type exportBundleStateSuite struct {
+	statetesting.StateSuite
+	facade *bundle.APIv2
+}
+
+var _ = gc.Suite(&exportBundleStateSuite{})
+
+func (s *exportBundleStateSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	auth := &apiservertesting.FakeAuthorizer{
+		Tag:      s.Owner,
+		AdminTag: s.Owner,
+	}
+	facade, err := bundle.NewBundleAPI(s.State, auth, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *exportBundleStateSuite) TestExportBundleCharmConfig(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: ch,
+		CharmConfig: map[string]interface{}{
+			"title":       "my title",
+			"skill-level": 9,
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, gc.HasLen, 1)
+	spec := data.Applications[app.Name()]
+	c.Assert(spec, gc.NotNil)
+	c.Assert(spec.Charm, gc.Equals, ch.URL().String())
+	c.Assert(spec.NumUnits, gc.Equals, 1)
+	c.Assert(spec.Options, jc.DeepEquals, map[string]interface{}{
+		"title":       "my title",
+		"skill-level": 9,
+	})
+
+	// Deploying the charm with the exported options
+	// yields the same config as the application's.
+	redeployed := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "redeployed",
+		Charm:       ch,
+		CharmConfig: spec.Options,
+	})
+	expected, err := app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := redeployed.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, expected)
+}
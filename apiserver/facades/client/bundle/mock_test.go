@@ -0,0 +1,24 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	testing.Stub
+	model description.Model
+}
+
+func (m *mockBackend) ExportPartial(cfg state.ExportConfig) (description.Model, error) {
+	m.MethodCall(m, "ExportPartial", cfg)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.model, nil
+}
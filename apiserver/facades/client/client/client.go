@@ -109,6 +109,25 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
+// ClientV1 serves the client-specific API methods of version 1 of the
+// Client facade.
+type ClientV1 struct {
+	*Client
+}
+
+// NewFacadeV1 provides the signature required for facade registration
+// for version 1.
+func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
+	client, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV1{client}, nil
+}
+
+// ModelStatusHistory isn't on the v1 API.
+func (c *ClientV1) ModelStatusHistory(_, _ struct{}) {}
+
 // NewFacade provides the required signature for facade registration.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	st := ctx.State()
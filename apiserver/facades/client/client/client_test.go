@@ -677,6 +677,25 @@ func (s *clientRepoSuite) TearDownTest(c *gc.C) {
 	s.baseSuite.TearDownTest(c)
 }
 
+// checkModelDelta checks that the deltas include one for the model
+// being watched, and returns the other deltas.
+func (s *clientSuite) checkModelDelta(c *gc.C, deltas []multiwatcher.Delta) []multiwatcher.Delta {
+	var others []multiwatcher.Delta
+	var models []*multiwatcher.ModelInfo
+	for _, d := range deltas {
+		if info, ok := d.Entity.(*multiwatcher.ModelInfo); ok {
+			models = append(models, info)
+		} else {
+			others = append(others, d)
+		}
+	}
+	c.Assert(models, gc.HasLen, 1)
+	c.Check(models[0].ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Check(models[0].Name, gc.Equals, s.IAASModel.Name())
+	c.Check(models[0].Life, gc.Equals, multiwatcher.Life("alive"))
+	return others
+}
+
 func (s *clientSuite) TestClientWatchAllReadPermission(c *gc.C) {
 	loggo.GetLogger("juju.apiserver").SetLogLevel(loggo.TRACE)
 	// A very simple end-to-end test, because
@@ -701,6 +720,7 @@ func (s *clientSuite) TestClientWatchAllReadPermission(c *gc.C) {
 	}()
 	deltas, err := watcher.Next()
 	c.Assert(err, jc.ErrorIsNil)
+	deltas = s.checkModelDelta(c, deltas)
 	c.Assert(len(deltas), gc.Equals, 1)
 	d0, ok := deltas[0].Entity.(*multiwatcher.MachineInfo)
 	c.Assert(ok, jc.IsTrue)
@@ -766,6 +786,7 @@ func (s *clientSuite) TestClientWatchAllAdminPermission(c *gc.C) {
 	}()
 	deltas, err := watcher.Next()
 	c.Assert(err, jc.ErrorIsNil)
+	deltas = s.checkModelDelta(c, deltas)
 
 	c.Assert(len(deltas), gc.Equals, 2)
 	mIndex := 0
@@ -828,6 +849,29 @@ func (s *clientSuite) TestClientWatchAllAdminPermission(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientWatchAllAllWatcherV1OmitsModel(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var watchResult params.AllWatcherId
+	err = s.APIState.APICall("Client", s.APIState.BestFacadeVersion("Client"), "", "WatchAll", nil, &watchResult)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := s.APIState.APICall("AllWatcher", 1, watchResult.AllWatcherId, "Stop", nil, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	// Version 1 of the AllWatcher facade predates the model
+	// deltas, so clients using it must not be sent them.
+	var result params.AllWatcherNextResults
+	err = s.APIState.APICall("AllWatcher", 1, watchResult.AllWatcherId, "Next", nil, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Deltas, gc.HasLen, 1)
+	machine, ok := result.Deltas[0].Entity.(*multiwatcher.MachineInfo)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(machine.Id, gc.Equals, m.Id())
+}
+
 func (s *clientSuite) TestClientSetModelConstraints(c *gc.C) {
 	// Set constraints for the model.
 	cons, err := constraints.Parse("mem=4096", "cores=2")
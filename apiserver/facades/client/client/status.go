@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -163,6 +164,47 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// ModelStatusHistory returns a page of the status history entries from
+// across the model that match the query, oldest first.
+func (c *Client) ModelStatusHistory(args params.StatusHistoryQuery) (params.StatusHistoryEntries, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.StatusHistoryEntries{}, err
+	}
+	query := state.StatusHistoryQuery{
+		Application: args.Application,
+		FromDate:    args.FromDate,
+		ToDate:      args.ToDate,
+		Size:        args.Size,
+		Exclude:     set.NewStrings(args.Exclude...),
+		After:       args.After,
+		Limit:       args.Limit,
+	}
+	for _, kind := range args.Kinds {
+		query.Kinds = append(query.Kinds, status.HistoryKind(kind))
+	}
+	page, err := c.api.stateAccessor.QueryStatusHistory(query)
+	if err != nil {
+		return params.StatusHistoryEntries{}, errors.Trace(err)
+	}
+	result := params.StatusHistoryEntries{
+		Entries: make([]params.StatusHistoryEntry, len(page.Entries)),
+		Next:    page.Next,
+	}
+	for i, entry := range page.Entries {
+		result.Entries[i] = params.StatusHistoryEntry{
+			Tag: entry.Entity.String(),
+			Status: params.DetailedStatus{
+				Status: entry.Status.String(),
+				Info:   entry.Message,
+				Data:   entry.Data,
+				Since:  entry.Since,
+				Kind:   entry.Kind.String(),
+			},
+		}
+	}
+	return result, nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -209,6 +251,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			return noStatus, errors.Annotate(err, " could not fetch leaders")
 		}
 	}
+	if context.branches, err = fetchUnitBranches(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch branches")
+	}
 
 	logger.Debugf("Applications: %v", context.applications)
 	logger.Debugf("Remote applications: %v", context.consumerRemoteApplications)
@@ -403,6 +448,9 @@ type statusContext struct {
 	units         map[string]map[string]*state.Unit
 	latestCharms  map[charm.URL]*state.Charm
 	leaders       map[string]string
+
+	// branches: unit name -> name of the branch the unit tracks
+	branches map[string]string
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -624,6 +672,25 @@ func fetchOffers(st Backend, applications map[string]*state.Application) (map[st
 	return offersMap, nil
 }
 
+// fetchUnitBranches returns a map from unit name to the name of the
+// branch of staged charm config changes that the unit tracks. Units
+// not tracking a branch are absent from the map.
+func fetchUnitBranches(st Backend) (map[string]string, error) {
+	branches, err := st.Branches()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string)
+	for _, b := range branches {
+		for _, units := range b.AssignedUnits() {
+			for _, unit := range units {
+				result[unit] = b.Name()
+			}
+		}
+	}
+	return result, nil
+}
+
 // fetchRelations returns a map of all relations keyed by application name,
 // and another map keyed by id..
 //
@@ -705,6 +772,12 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine) (status params
 	status.AgentStatus = agentStatus
 
 	status.Series = machine.Series()
+	upgradeSeriesStatus, err := machine.UpgradeSeriesStatus()
+	if err != nil {
+		logger.Debugf("error fetching upgrade series status for machine %q: %v", machineID, err)
+	} else if upgradeSeriesStatus != model.UpgradeSeriesNotStarted {
+		status.UpgradeSeriesStatus = string(upgradeSeriesStatus)
+	}
 	status.Jobs = paramsJobsFromJobs(machine.Jobs())
 	status.WantsVote = machine.WantsVote()
 	status.HasVote = machine.HasVote()
@@ -959,11 +1032,11 @@ func (context *statusContext) processApplication(application *state.Application)
 			return params.ApplicationStatus{Err: common.ServerError(err)}
 		}
 		if specStr != "" {
-			spec, err := caas.ParseContainerSpec(specStr)
+			spec, err := caas.ParsePodSpec(specStr)
 			if err != nil {
 				return params.ApplicationStatus{Err: common.ServerError(err)}
 			}
-			processedStatus.WorkloadVersion = fmt.Sprintf("%v", spec.ImageName)
+			processedStatus.WorkloadVersion = fmt.Sprintf("%v", spec.Containers[0].ImageName)
 		}
 	}
 
@@ -1110,6 +1183,7 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	if leader := context.leaders[unit.ApplicationName()]; leader == unit.Name() {
 		result.Leader = true
 	}
+	result.Branch = context.branches[unit.Name()]
 	result.ProviderId = unit.ProviderId()
 	containerInfo := unit.ContainerInfo()
 	result.Address = containerInfo.Address
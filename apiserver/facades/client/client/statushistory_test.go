@@ -8,12 +8,14 @@ import (
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/client"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
@@ -219,10 +221,72 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestModelStatusHistory(c *gc.C) {
+	since := time.Unix(1000, 0)
+	s.st.modelHistory = state.StatusHistoryPage{
+		Entries: []state.StatusHistoryEntry{{
+			StatusInfo: status.StatusInfo{
+				Status:  status.Active,
+				Message: "ready",
+				Since:   &since,
+			},
+			Kind:   status.KindWorkload,
+			Entity: names.NewUnitTag("mysql/0"),
+		}},
+		Next: "1000:5b3e0cd1c3b4f2a1a0b1c2d3",
+	}
+	from := time.Unix(900, 0)
+	result, err := s.api.ModelStatusHistory(params.StatusHistoryQuery{
+		Kinds:       []string{"workload", "juju-unit"},
+		Application: "mysql",
+		FromDate:    &from,
+		Exclude:     []string{"running update-status hook"},
+		After:       "900:5b3e0cd1c3b4f2a1a0b1c2d2",
+		Limit:       10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StatusHistoryEntries{
+		Entries: []params.StatusHistoryEntry{{
+			Tag: "unit-mysql-0",
+			Status: params.DetailedStatus{
+				Status: "active",
+				Info:   "ready",
+				Since:  &since,
+				Kind:   "workload",
+			},
+		}},
+		Next: "1000:5b3e0cd1c3b4f2a1a0b1c2d3",
+	})
+	c.Assert(s.st.query, jc.DeepEquals, state.StatusHistoryQuery{
+		Kinds:       []status.HistoryKind{status.KindWorkload, status.KindUnitAgent},
+		Application: "mysql",
+		FromDate:    &from,
+		Exclude:     set.NewStrings("running update-status hook"),
+		After:       "900:5b3e0cd1c3b4f2a1a0b1c2d2",
+		Limit:       10,
+	})
+}
+
+func (s *statusHistoryTestSuite) TestModelStatusHistoryError(c *gc.C) {
+	s.st.queryErr = errors.NotValidf("status history kind %q", "relation")
+	_, err := s.api.ModelStatusHistory(params.StatusHistoryQuery{
+		Kinds: []string{"relation"},
+	})
+	c.Assert(err, gc.ErrorMatches, `status history kind "relation" not valid`)
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+	modelHistory state.StatusHistoryPage
+	query        state.StatusHistoryQuery
+	queryErr     error
+}
+
+func (m *mockState) QueryStatusHistory(query state.StatusHistoryQuery) (state.StatusHistoryPage, error) {
+	m.query = query
+	return m.modelHistory, m.queryErr
 }
 
 func (m *mockState) ModelUUID() string {
@@ -42,13 +42,23 @@ type ControllerAPI struct {
 	resources  facade.Resources
 }
 
+// ControllerAPIv5 provides the v5 Controller API. It lacks AuditLog.
+type ControllerAPIv5 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv4 provides the v4 Controller API. It lacks ConfigSet.
+type ControllerAPIv4 struct {
+	*ControllerAPIv5
+}
+
 // ControllerAPIv3 provides the v3 Controller API.
 type ControllerAPIv3 struct {
-	*ControllerAPI
+	*ControllerAPIv4
 }
 
-// NewControllerAPIv4 creates a new ControllerAPIv4.
-func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -62,6 +72,24 @@ func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
+// NewControllerAPIv4 creates a new ControllerAPIv4.
+func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
+	v5, err := NewControllerAPIv5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv4{v5}, nil
+}
+
 // NewControllerAPIv3 creates a new ControllerAPIv3.
 func NewControllerAPIv3(ctx facade.Context) (*ControllerAPIv3, error) {
 	v4, err := NewControllerAPIv4(ctx)
@@ -329,6 +357,72 @@ func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 	return errors.Trace(s.state.RemoveAllBlocksForController())
 }
 
+// ConfigSet changes the value of specified controller configuration
+// settings. Only some settings can be changed after bootstrap; see
+// controller.AllowedUpdateConfigAttributes. Agents pick up the new
+// values through the controller config watcher.
+func (s *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
+	if err := s.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args.Config) == 0 {
+		return nil
+	}
+	st := s.statePool.SystemState()
+	return errors.Trace(st.UpdateControllerConfig(args.Config, nil))
+}
+
+// ConfigSet isn't on the v4 API.
+func (s *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
+
+// AuditLog returns the requests matching the filter from the
+// controller's audit log, along with their conversations and any
+// errors returned in response. The audit log is shared by all the
+// controllers, so the records of every controller machine are
+// included.
+func (s *ControllerAPI) AuditLog(args params.AuditLogFilter) (params.AuditLogResults, error) {
+	var result params.AuditLogResults
+	if err := s.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	filter := state.AuditLogFilter{
+		Model:          args.Model,
+		Facade:         args.Facade,
+		Method:         args.Method,
+		ConversationID: args.ConversationID,
+		Limit:          args.Limit,
+	}
+	if args.User != "" {
+		if !names.IsValidUser(args.User) {
+			return result, errors.NotValidf("user name %q", args.User)
+		}
+		filter.User = names.NewUserTag(args.User)
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	records, err := s.statePool.SystemState().QueryAuditLog(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Records = make([]params.AuditLogRecord, len(records))
+	for i, rec := range records {
+		result.Records[i] = params.AuditLogRecord{
+			ControllerID: rec.ControllerID,
+			Conversation: rec.Conversation,
+			Request:      rec.Request,
+			Errors:       rec.Errors,
+		}
+	}
+	return result, nil
+}
+
+// AuditLog isn't on the v5 API.
+func (s *ControllerAPIv5) AuditLog(_, _ struct{}) {}
+
 // WatchAllModels starts watching events for all models in the
 // controller. The returned AllWatcherId should be used with Next on the
 // AllModelWatcher endpoint to receive deltas.
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -59,7 +60,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -76,7 +77,7 @@ func (s *controllerSuite) TestNewAPIRefusesNonClient(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: names.NewUnitTag("mysql/0"),
 	}
-	endPoint, err := controller.NewControllerAPIv4(
+	endPoint, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -256,7 +257,7 @@ func (s *controllerSuite) TestModelConfigFromNonController(c *gc.C) {
 		Tag:      s.Owner,
 		AdminTag: s.Owner,
 	}
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     st,
 			StatePool_: s.StatePool,
@@ -286,7 +287,7 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	defer st.Close()
 
 	authorizer := &apiservertesting.FakeAuthorizer{Tag: s.Owner}
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     st,
 			Resources_: common.NewResources(),
@@ -802,7 +803,7 @@ func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv4(
+	endpoint, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -900,3 +901,131 @@ func (s *controllerSuite) TestModelStatus(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
 }
+
+func (s *controllerSuite) TestConfigSet(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"audit-log-max-backups": 8,
+		"max-logs-age":          "48h",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	config, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.AuditLogMaxBackups(), gc.Equals, 8)
+	c.Assert(config.MaxLogsAge(), gc.Equals, 48*time.Hour)
+}
+
+func (s *controllerSuite) TestConfigSetRejectsImmutable(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"api-port": 1234,
+	}})
+	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
+}
+
+func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv6(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = endpoint.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"max-logs-age": "48h",
+	}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	log := state.NewAuditLog(s.State, "1")
+	err := log.AddConversation(auditlog.Conversation{
+		Who:            "user-bob",
+		What:           "juju deploy mysql",
+		When:           "2018-05-01T10:00:00Z",
+		ModelName:      "default",
+		ModelUUID:      "deadbeef",
+		ConversationID: "abc",
+		ConnectionID:   "1F",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(auditlog.Request{
+		ConversationID: "abc",
+		ConnectionID:   "1F",
+		RequestID:      3,
+		When:           "2018-05-01T10:00:01Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        6,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	from := time.Date(2018, 5, 1, 9, 0, 0, 0, time.UTC)
+	result, err := s.controller.AuditLog(params.AuditLogFilter{
+		User:   "bob",
+		Facade: "Application",
+		From:   &from,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogResults{
+		Records: []params.AuditLogRecord{{
+			ControllerID: "1",
+			Conversation: &auditlog.Conversation{
+				Who:            "user-bob",
+				What:           "juju deploy mysql",
+				When:           "2018-05-01T10:00:00Z",
+				ModelName:      "default",
+				ModelUUID:      "deadbeef",
+				ConversationID: "abc",
+				ConnectionID:   "1F",
+			},
+		}, {
+			ControllerID: "1",
+			Request: &auditlog.Request{
+				ConversationID: "abc",
+				ConnectionID:   "1F",
+				RequestID:      3,
+				When:           "2018-05-01T10:00:01Z",
+				Facade:         "Application",
+				Method:         "Deploy",
+				Version:        6,
+			},
+		}},
+	})
+
+	result, err = s.controller.AuditLog(params.AuditLogFilter{User: "mary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Records, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestAuditLogInvalidUser(c *gc.C) {
+	_, err := s.controller.AuditLog(params.AuditLogFilter{User: "not valid!"})
+	c.Assert(err, gc.ErrorMatches, `user name "not valid!" not valid`)
+}
+
+func (s *controllerSuite) TestAuditLogRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv6(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.AuditLog(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -57,6 +57,21 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	return &MachineManagerAPIV4{machineManagerAPI}, nil
 }
 
+// MachineManagerAPIV5 adds UpgradeSeriesPrepare, UpgradeSeriesComplete
+// and UpgradeSeriesAbort.
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+}
+
+// NewFacadeV5 creates a new server-side MachineManager API facade.
+func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV4, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV4}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
@@ -330,3 +345,106 @@ func (mm *MachineManagerAPIV4) updateOneMachineSeries(arg params.UpdateSeriesArg
 	}
 	return machine.UpdateMachineSeries(arg.Series, arg.Force)
 }
+
+// UpgradeSeriesPrepare starts a managed series upgrade of the given
+// machine(s). The units on each machine run their pre-series-upgrade
+// hooks, after which no other hooks are run until the upgrade is
+// completed.
+func (mm *MachineManagerAPIV5) UpgradeSeriesPrepare(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := mm.upgradeSeriesPrepareOne(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPIV5) upgradeSeriesPrepareOne(arg params.UpdateSeriesArg) error {
+	if arg.Series == "" {
+		return &params.Error{
+			Message: "series missing from args",
+			Code:    params.CodeBadRequest,
+		}
+	}
+	machineTag, err := names.ParseMachineTag(arg.Entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.CreateUpgradeSeriesLock(arg.Series, arg.Force)
+}
+
+// UpgradeSeriesComplete records that the operating system of the given
+// machine(s) has been upgraded, so that the units can run their
+// post-series-upgrade hooks and the upgrade can be completed.
+func (mm *MachineManagerAPIV5) UpgradeSeriesComplete(args params.Entities) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		err := mm.upgradeSeriesCompleteOne(arg.Tag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPIV5) upgradeSeriesCompleteOne(tag string) error {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.StartUpgradeSeriesCompletion()
+}
+
+// UpgradeSeriesAbort abandons the series upgrade of the given
+// machine(s), which must not yet have started completing it. The
+// machines keep their current series.
+func (mm *MachineManagerAPIV5) UpgradeSeriesAbort(args params.Entities) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		err := mm.upgradeSeriesAbortOne(arg.Tag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPIV5) upgradeSeriesAbortOne(tag string) error {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.AbortUpgradeSeries()
+}
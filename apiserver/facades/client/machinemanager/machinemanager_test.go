@@ -313,6 +313,124 @@ func (s *MachineManagerSuite) TestUpdateMachineSeriesPermissionDenied(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	s.setupUpdateMachineSeries(c)
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	results, err := apiV5.UpgradeSeriesPrepare(
+		params.UpdateSeriesArgs{
+			Args: []params.UpdateSeriesArg{
+				{
+					Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
+					Series: "xenial",
+				}, {
+					Entity: params.Entity{Tag: names.NewMachineTag("1").String()},
+					Series: "xenial",
+					Force:  true,
+				}, {
+					Entity: params.Entity{Tag: names.NewMachineTag("1").String()},
+				}, {
+					Entity: params.Entity{Tag: names.NewMachineTag("76").String()},
+					Series: "xenial",
+				},
+			}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{}, {},
+			{Error: &params.Error{Message: "series missing from args", Code: params.CodeBadRequest}},
+			{Error: &params.Error{Message: "machine 76 not found", Code: "not found"}},
+		}})
+
+	s.st.machines["0"].CheckCalls(c, []jtesting.StubCall{
+		{"CreateUpgradeSeriesLock", []interface{}{"xenial", false}},
+	})
+	s.st.machines["1"].CheckCalls(c, []jtesting.StubCall{
+		{"CreateUpgradeSeriesLock", []interface{}{"xenial", true}},
+	})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepareBlockedChanges(c *gc.C) {
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	s.st.blockMsg = "TestUpgradeSeriesPrepareBlockedChanges"
+	s.st.block = state.ChangeBlock
+	_, err := apiV5.UpgradeSeriesPrepare(
+		params.UpdateSeriesArgs{
+			Args: []params.UpdateSeriesArg{{
+				Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
+				Series: "xenial",
+			}},
+		},
+	)
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPreparePermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	_, err := apiV5.UpgradeSeriesPrepare(
+		params.UpdateSeriesArgs{
+			Args: []params.UpdateSeriesArg{{
+				Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
+				Series: "xenial",
+			}},
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
+	s.setupUpdateMachineSeries(c)
+	s.st.machines["1"].SetErrors(errors.New("not ready"))
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	results, err := apiV5.UpgradeSeriesComplete(params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewMachineTag("0").String()},
+			{Tag: names.NewMachineTag("1").String()},
+			{Tag: names.NewUnitTag("mysql/0").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "not ready"}},
+			{Error: &params.Error{Message: "\"unit-mysql-0\" is not a valid machine tag"}},
+		}})
+	s.st.machines["0"].CheckCallNames(c, "StartUpgradeSeriesCompletion")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesAbort(c *gc.C) {
+	s.setupUpdateMachineSeries(c)
+	s.st.machines["1"].SetErrors(errors.New("already completing"))
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	results, err := apiV5.UpgradeSeriesAbort(params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewMachineTag("0").String()},
+			{Tag: names.NewMachineTag("1").String()},
+			{Tag: names.NewUnitTag("mysql/0").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "already completing"}},
+			{Error: &params.Error{Message: "\"unit-mysql-0\" is not a valid machine tag"}},
+		}})
+	s.st.machines["0"].CheckCallNames(c, "AbortUpgradeSeries")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesAbortBlockedChanges(c *gc.C) {
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	s.st.blockMsg = "TestUpgradeSeriesAbortBlockedChanges"
+	s.st.block = state.ChangeBlock
+	_, err := apiV5.UpgradeSeriesAbort(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag("0").String()}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
 type mockState struct {
 	machinemanager.Backend
 	calls            int
@@ -450,6 +568,21 @@ func (m *mockMachine) UpdateMachineSeries(series string, force bool) error {
 	return m.NextErr()
 }
 
+func (m *mockMachine) CreateUpgradeSeriesLock(series string, force bool) error {
+	m.MethodCall(m, "CreateUpgradeSeriesLock", series, force)
+	return m.NextErr()
+}
+
+func (m *mockMachine) StartUpgradeSeriesCompletion() error {
+	m.MethodCall(m, "StartUpgradeSeriesCompletion")
+	return m.NextErr()
+}
+
+func (m *mockMachine) AbortUpgradeSeries() error {
+	m.MethodCall(m, "AbortUpgradeSeries")
+	return m.NextErr()
+}
+
 type mockUnit struct {
 	tag names.UnitTag
 }
@@ -47,6 +47,9 @@ type Machine interface {
 	Units() ([]Unit, error)
 	SetKeepInstance(keepInstance bool) error
 	UpdateMachineSeries(string, bool) error
+	CreateUpgradeSeriesLock(string, bool) error
+	StartUpgradeSeriesCompletion() error
+	AbortUpgradeSeries() error
 }
 
 type stateShim struct {
@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// modelgeneration facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ModelType() (state.ModelType, error)
+	AddBranch(name, userName string) error
+	Branch(name string) (Generation, error)
+	Branches() ([]Generation, error)
+	Application(name string) (Application, error)
+}
+
+// Generation defines the branch functionality required by the
+// modelgeneration facade. For details on the methods, see the methods
+// on state.Generation with the same names.
+type Generation interface {
+	Name() string
+	Created() time.Time
+	CreatedBy() string
+	AssignedUnits() map[string][]string
+	Config() map[string]charm.Settings
+	ApplicationConfig() map[string]application.ConfigAttributes
+	AssignUnit(unitName string) error
+	UpdateCharmConfig(appName string, changes charm.Settings) error
+	UpdateApplicationConfig(
+		appName string,
+		changes application.ConfigAttributes,
+		reset []string,
+		schema environschema.Fields,
+		defaults schema.Defaults,
+	) error
+	Commit(userName string) error
+	Abort(userName string) error
+}
+
+// Application defines the application functionality required by the
+// modelgeneration facade.
+type Application interface {
+	// UnitNames returns the names of the application's units.
+	UnitNames() ([]string, error)
+
+	// CharmOptions returns the config options of the application's
+	// charm.
+	CharmOptions() (*charm.Config, error)
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the modelgeneration facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return &stateShim{st}
+}
+
+func (s stateShim) ModelType() (state.ModelType, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return model.Type(), nil
+}
+
+func (s stateShim) Branch(name string) (Generation, error) {
+	gen, err := s.State.Branch(name)
+	if err != nil {
+		return nil, err
+	}
+	return gen, nil
+}
+
+func (s stateShim) Branches() ([]Generation, error) {
+	gens, err := s.State.Branches()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Generation, len(gens))
+	for i, gen := range gens {
+		result[i] = gen
+	}
+	return result, nil
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, err
+	}
+	return applicationShim{app}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) UnitNames() ([]string, error) {
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]string, len(units))
+	for i, unit := range units {
+		result[i] = unit.Name()
+	}
+	return result, nil
+}
+
+func (a applicationShim) CharmOptions() (*charm.Config, error) {
+	ch, _, err := a.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch.Config(), nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+
+	modelUUID    string
+	modelType    state.ModelType
+	branches     map[string]*mockGeneration
+	applications map[string]*mockApplication
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	return names.NewModelTag(m.modelUUID)
+}
+
+func (m *mockBackend) ModelType() (state.ModelType, error) {
+	m.MethodCall(m, "ModelType")
+	return m.modelType, m.NextErr()
+}
+
+func (m *mockBackend) AddBranch(name, userName string) error {
+	m.MethodCall(m, "AddBranch", name, userName)
+	return m.NextErr()
+}
+
+func (m *mockBackend) Branch(name string) (modelgeneration.Generation, error) {
+	m.MethodCall(m, "Branch", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	gen, ok := m.branches[name]
+	if !ok {
+		return nil, errors.NotFoundf("branch %q", name)
+	}
+	return gen, nil
+}
+
+func (m *mockBackend) Branches() ([]modelgeneration.Generation, error) {
+	m.MethodCall(m, "Branches")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	var result []modelgeneration.Generation
+	for _, gen := range m.branches {
+		result = append(result, gen)
+	}
+	return result, nil
+}
+
+func (m *mockBackend) Application(name string) (modelgeneration.Application, error) {
+	m.MethodCall(m, "Application", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	app, ok := m.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+type mockGeneration struct {
+	jtesting.Stub
+
+	name          string
+	created       time.Time
+	createdBy     string
+	assignedUnits map[string][]string
+	config        map[string]charm.Settings
+	appConfig     map[string]application.ConfigAttributes
+}
+
+func (g *mockGeneration) Name() string {
+	return g.name
+}
+
+func (g *mockGeneration) Created() time.Time {
+	return g.created
+}
+
+func (g *mockGeneration) CreatedBy() string {
+	return g.createdBy
+}
+
+func (g *mockGeneration) AssignedUnits() map[string][]string {
+	return g.assignedUnits
+}
+
+func (g *mockGeneration) Config() map[string]charm.Settings {
+	return g.config
+}
+
+func (g *mockGeneration) ApplicationConfig() map[string]application.ConfigAttributes {
+	return g.appConfig
+}
+
+func (g *mockGeneration) AssignUnit(unitName string) error {
+	g.MethodCall(g, "AssignUnit", unitName)
+	return g.NextErr()
+}
+
+func (g *mockGeneration) UpdateCharmConfig(appName string, changes charm.Settings) error {
+	g.MethodCall(g, "UpdateCharmConfig", appName, changes)
+	return g.NextErr()
+}
+
+func (g *mockGeneration) UpdateApplicationConfig(
+	appName string,
+	changes application.ConfigAttributes,
+	reset []string,
+	schema environschema.Fields,
+	defaults schema.Defaults,
+) error {
+	g.MethodCall(g, "UpdateApplicationConfig", appName, changes, reset)
+	return g.NextErr()
+}
+
+func (g *mockGeneration) Commit(userName string) error {
+	g.MethodCall(g, "Commit", userName)
+	return g.NextErr()
+}
+
+func (g *mockGeneration) Abort(userName string) error {
+	g.MethodCall(g, "Abort", userName)
+	return g.NextErr()
+}
+
+type mockApplication struct {
+	jtesting.Stub
+
+	unitNames []string
+	options   *charm.Config
+}
+
+func (a *mockApplication) UnitNames() ([]string, error) {
+	a.MethodCall(a, "UnitNames")
+	return a.unitNames, a.NextErr()
+}
+
+func (a *mockApplication) CharmOptions() (*charm.Config, error) {
+	a.MethodCall(a, "CharmOptions")
+	return a.options, a.NextErr()
+}
+
+type mockBlockChecker struct {
+	jtesting.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
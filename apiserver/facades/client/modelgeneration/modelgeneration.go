@@ -0,0 +1,285 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	applicationfacade "github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/permission"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.modelgeneration")
+
+// API provides the modelgeneration facade APIs for v1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(
+		NewStateBackend(ctx.State()),
+		ctx.Auth(),
+		common.NewBlockChecker(ctx.State()),
+	)
+}
+
+// NewAPI returns a new modelgeneration API facade.
+func NewAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	blockChecker BlockChecker,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      blockChecker,
+	}, nil
+}
+
+func (api *API) checkPermission(tag names.Tag, perm permission.Access) error {
+	allowed, err := api.authorizer.HasPermission(perm, tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) checkCanWrite() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
+}
+
+func (api *API) checkCanRead() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.ReadAccess)
+}
+
+// checkChange returns an error if the authenticated user may not
+// change the model's branches.
+func (api *API) checkChange() error {
+	if err := api.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.check.ChangeAllowed())
+}
+
+func (api *API) userName() string {
+	return api.authorizer.GetAuthTag().Id()
+}
+
+// AddBranch creates a new branch in the model, on which application
+// config and charm config changes can be staged.
+func (api *API) AddBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	if err := api.checkChange(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := api.backend.AddBranch(arg.BranchName, api.userName())
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// TrackBranch moves the specified units onto the branch, so that they
+// see the charm config staged on it. Specifying an application moves
+// all of its units.
+func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, error) {
+	if err := api.checkChange(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	gen, err := api.backend.Branch(arg.BranchName)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(arg.Entities))
+	for i, entity := range arg.Entities {
+		results[i].Error = common.ServerError(api.trackEntity(gen, entity.Tag))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) trackEntity(gen Generation, tagString string) error {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		return errors.Trace(gen.AssignUnit(tag.Id()))
+	case names.ApplicationTag:
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		unitNames, err := app.UnitNames()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, unitName := range unitNames {
+			if err := gen.AssignUnit(unitName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+	return errors.NotValidf("tag %q", tagString)
+}
+
+// SetBranchConfig stages changes to an application's config and charm
+// config on the branch. Settings in the application config schema are
+// staged as application config, and all others as charm config.
+func (api *API) SetBranchConfig(arg params.BranchConfigArg) (params.ErrorResult, error) {
+	if err := api.checkChange(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := api.setBranchConfig(arg)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+func (api *API) setBranchConfig(arg params.BranchConfigArg) error {
+	gen, err := api.backend.Branch(arg.BranchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(arg.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	options, err := app.CharmOptions()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelType, err := api.backend.ModelType()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	schema, defaults, err := applicationfacade.ApplicationConfigSchema(modelType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appConfigKeys := application.KnownConfigKeys(schema)
+
+	appConfig := make(application.ConfigAttributes)
+	charmConfig := make(map[string]string)
+	for name, value := range arg.Config {
+		if appConfigKeys.Contains(name) {
+			appConfig[name] = value
+		} else {
+			charmConfig[name] = value
+		}
+	}
+	changes, err := options.ParseSettingsStrings(charmConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if changes == nil {
+		changes = make(charm.Settings)
+	}
+	var appReset []string
+	for _, name := range arg.Reset {
+		if appConfigKeys.Contains(name) {
+			appReset = append(appReset, name)
+		} else {
+			changes[name] = nil
+		}
+	}
+
+	if len(appConfig) > 0 || len(appReset) > 0 {
+		if err := applicationfacade.CheckCharmConfigClash(options, appConfig); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("staging application config %v, reset %v for %q on branch %q",
+			appConfig, appReset, arg.Application, arg.BranchName)
+		if err := gen.UpdateApplicationConfig(arg.Application, appConfig, appReset, schema, defaults); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(changes) > 0 {
+		logger.Debugf("staging config %v for %q on branch %q", changes, arg.Application, arg.BranchName)
+		if err := gen.UpdateCharmConfig(arg.Application, changes); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CommitBranch applies the application config and charm config staged
+// on the branch to all units and removes the branch.
+func (api *API) CommitBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	if err := api.checkChange(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	gen, err := api.backend.Branch(arg.BranchName)
+	if err == nil {
+		err = gen.Commit(api.userName())
+	}
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// AbortBranch discards the application config and charm config staged
+// on the branch and removes it.
+func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	if err := api.checkChange(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	gen, err := api.backend.Branch(arg.BranchName)
+	if err == nil {
+		err = gen.Abort(api.userName())
+	}
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// BranchInfo returns details of the specified branches, or of all the
+// model's branches if none are specified.
+func (api *API) BranchInfo(args params.BranchInfoArgs) (params.BranchResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.BranchResults{}, errors.Trace(err)
+	}
+	var gens []Generation
+	if len(args.BranchNames) == 0 {
+		var err error
+		if gens, err = api.backend.Branches(); err != nil {
+			return params.BranchResults{Error: common.ServerError(err)}, nil
+		}
+	}
+	for _, name := range args.BranchNames {
+		gen, err := api.backend.Branch(name)
+		if err != nil {
+			return params.BranchResults{Error: common.ServerError(err)}, nil
+		}
+		gens = append(gens, gen)
+	}
+	result := params.BranchResults{Branches: make([]params.Branch, len(gens))}
+	for i, gen := range gens {
+		config := make(map[string]map[string]interface{})
+		for app, settings := range gen.Config() {
+			config[app] = settings
+		}
+		appConfig := make(map[string]map[string]interface{})
+		for app, attrs := range gen.ApplicationConfig() {
+			appConfig[app] = attrs
+		}
+		result.Branches[i] = params.Branch{
+			BranchName:        gen.Name(),
+			Created:           gen.Created().Unix(),
+			CreatedBy:         gen.CreatedBy(),
+			AssignedUnits:     gen.AssignedUnits(),
+			Config:            config,
+			ApplicationConfig: appConfig,
+		}
+	}
+	return result, nil
+}
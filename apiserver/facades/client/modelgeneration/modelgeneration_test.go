@@ -0,0 +1,255 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelGenerationSuite struct {
+	testing.IsolationSuite
+
+	backend      mockBackend
+	branch       *mockGeneration
+	application  *mockApplication
+	blockChecker mockBlockChecker
+	authorizer   apiservertesting.FakeAuthorizer
+	api          *modelgeneration.API
+}
+
+var _ = gc.Suite(&ModelGenerationSuite{})
+
+func (s *ModelGenerationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.branch = &mockGeneration{
+		name:      "new-config",
+		created:   time.Unix(1500000000, 0),
+		createdBy: "admin",
+		assignedUnits: map[string][]string{
+			"mysql": {"mysql/0"},
+		},
+		config: map[string]charm.Settings{
+			"mysql": {"title": "branch title"},
+		},
+		appConfig: map[string]application.ConfigAttributes{
+			"mysql": {"trust": true},
+		},
+	}
+	s.application = &mockApplication{
+		unitNames: []string{"mysql/0", "mysql/1"},
+		options: &charm.Config{Options: map[string]charm.Option{
+			"title":   {Type: "string", Default: "My Title"},
+			"workers": {Type: "int", Default: 1},
+		}},
+	}
+	s.backend = mockBackend{
+		modelUUID:    coretesting.ModelTag.Id(),
+		modelType:    state.ModelTypeIAAS,
+		branches:     map[string]*mockGeneration{"new-config": s.branch},
+		applications: map[string]*mockApplication{"mysql": s.application},
+	}
+	s.blockChecker = mockBlockChecker{}
+	s.setAPIUser(c, names.NewUserTag("admin"))
+}
+
+func (s *ModelGenerationSuite) setAPIUser(c *gc.C, user names.UserTag) {
+	s.authorizer.Tag = user
+	api, err := modelgeneration.NewAPI(&s.backend, s.authorizer, &s.blockChecker)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *ModelGenerationSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelgeneration.NewAPI(&s.backend, s.authorizer, &s.blockChecker)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ModelGenerationSuite) TestAddBranch(c *gc.C) {
+	result, err := s.api.AddBranch(params.BranchArg{BranchName: "other"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.backend.CheckCall(c, 1, "AddBranch", "other", "admin")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ModelGenerationSuite) TestAddBranchPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.AddBranch(params.BranchArg{BranchName: "other"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *ModelGenerationSuite) TestAddBranchBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.AddBranch(params.BranchArg{BranchName: "other"})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *ModelGenerationSuite) TestTrackBranch(c *gc.C) {
+	result, err := s.api.TrackBranch(params.BranchTrackArg{
+		BranchName: "new-config",
+		Entities: []params.Entity{
+			{Tag: "unit-mysql-2"},
+			{Tag: "application-mysql"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `tag "machine-0" not valid`)
+	s.branch.CheckCalls(c, []testing.StubCall{
+		{"AssignUnit", []interface{}{"mysql/2"}},
+		{"AssignUnit", []interface{}{"mysql/0"}},
+		{"AssignUnit", []interface{}{"mysql/1"}},
+	})
+}
+
+func (s *ModelGenerationSuite) TestTrackBranchNotFound(c *gc.C) {
+	_, err := s.api.TrackBranch(params.BranchTrackArg{
+		BranchName: "missing",
+		Entities:   []params.Entity{{Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `branch "missing" not found`)
+}
+
+func (s *ModelGenerationSuite) TestSetBranchConfig(c *gc.C) {
+	result, err := s.api.SetBranchConfig(params.BranchConfigArg{
+		BranchName:  "new-config",
+		Application: "mysql",
+		Config:      map[string]string{"workers": "4"},
+		Reset:       []string{"title"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.branch.CheckCall(c, 0, "UpdateCharmConfig", "mysql", charm.Settings{
+		"workers": int64(4),
+		"title":   nil,
+	})
+}
+
+func (s *ModelGenerationSuite) TestSetBranchConfigApplicationConfig(c *gc.C) {
+	result, err := s.api.SetBranchConfig(params.BranchConfigArg{
+		BranchName:  "new-config",
+		Application: "mysql",
+		Config:      map[string]string{"trust": "true", "workers": "4"},
+		Reset:       []string{"title"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.branch.CheckCalls(c, []testing.StubCall{
+		{"UpdateApplicationConfig", []interface{}{
+			"mysql", application.ConfigAttributes{"trust": "true"}, []string(nil),
+		}},
+		{"UpdateCharmConfig", []interface{}{
+			"mysql", charm.Settings{"workers": int64(4), "title": nil},
+		}},
+	})
+}
+
+func (s *ModelGenerationSuite) TestSetBranchConfigResetApplicationConfig(c *gc.C) {
+	result, err := s.api.SetBranchConfig(params.BranchConfigArg{
+		BranchName:  "new-config",
+		Application: "mysql",
+		Reset:       []string{"trust"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.branch.CheckCalls(c, []testing.StubCall{
+		{"UpdateApplicationConfig", []interface{}{
+			"mysql", application.ConfigAttributes{}, []string{"trust"},
+		}},
+	})
+}
+
+func (s *ModelGenerationSuite) TestSetBranchConfigTrustClash(c *gc.C) {
+	s.application.options.Options["trust"] = charm.Option{Type: "boolean"}
+	result, err := s.api.SetBranchConfig(params.BranchConfigArg{
+		BranchName:  "new-config",
+		Application: "mysql",
+		Config:      map[string]string{"trust": "true"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "cannot set trust: application config clashes with charm option of the same name")
+	s.branch.CheckNoCalls(c)
+}
+
+func (s *ModelGenerationSuite) TestSetBranchConfigInvalid(c *gc.C) {
+	result, err := s.api.SetBranchConfig(params.BranchConfigArg{
+		BranchName:  "new-config",
+		Application: "mysql",
+		Config:      map[string]string{"workers": "many"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `option "workers" expected int.*`)
+	s.branch.CheckNoCalls(c)
+}
+
+func (s *ModelGenerationSuite) TestCommitBranch(c *gc.C) {
+	result, err := s.api.CommitBranch(params.BranchArg{BranchName: "new-config"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.branch.CheckCall(c, 0, "Commit", "admin")
+}
+
+func (s *ModelGenerationSuite) TestCommitBranchNotFound(c *gc.C) {
+	result, err := s.api.CommitBranch(params.BranchArg{BranchName: "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ModelGenerationSuite) TestAbortBranch(c *gc.C) {
+	result, err := s.api.AbortBranch(params.BranchArg{BranchName: "new-config"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.branch.CheckCall(c, 0, "Abort", "admin")
+}
+
+func (s *ModelGenerationSuite) TestBranchInfo(c *gc.C) {
+	result, err := s.api.BranchInfo(params.BranchInfoArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BranchResults{
+		Branches: []params.Branch{{
+			BranchName: "new-config",
+			Created:    1500000000,
+			CreatedBy:  "admin",
+			AssignedUnits: map[string][]string{
+				"mysql": {"mysql/0"},
+			},
+			Config: map[string]map[string]interface{}{
+				"mysql": {"title": "branch title"},
+			},
+			ApplicationConfig: map[string]map[string]interface{}{
+				"mysql": {"trust": true},
+			},
+		}},
+	})
+}
+
+func (s *ModelGenerationSuite) TestBranchInfoNotFound(c *gc.C) {
+	result, err := s.api.BranchInfo(params.BranchInfoArgs{BranchNames: []string{"missing"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `branch "missing" not found`)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/environs"
@@ -284,6 +285,9 @@ func (m *ModelManagerAPI) newCAASModelConfig(
 		config.UUIDKey:         uuid.String(),
 		config.AgentVersionKey: jujuversion.Current.String(),
 	}
+	for k, v := range k8sprovider.NewModelConfigAttrs(uuid.String()) {
+		attrs[k] = v
+	}
 
 	cfg, err := config.New(config.UseDefaults, attrs)
 	if err != nil {
@@ -450,10 +450,11 @@ func (s *modelManagerSuite) TestCreateCAASModelArgs(c *gc.C) {
 	c.Assert(uuid, gc.Not(gc.Equals), s.caasSt.controllerModel.cfg.UUID())
 
 	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
-		"name":          "foo",
-		"type":          "CAAS",
-		"uuid":          uuid,
-		"agent-version": jujuversion.Current.String(),
+		"name":                 "foo",
+		"type":                 "CAAS",
+		"uuid":                 uuid,
+		"agent-version":        jujuversion.Current.String(),
+		"kubernetes-namespace": "juju-" + uuid,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
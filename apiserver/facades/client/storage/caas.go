@@ -0,0 +1,236 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// caasStorageAccess provides the storage information recorded
+// for the units of a CAAS model.
+//
+// Storage in a CAAS model is provisioned by Kubernetes along with
+// the pods that use it, as persistent volume claims created from
+// the application's volume claim templates. Its lifecycle is owned
+// by Kubernetes rather than Juju, so there are no storage instance,
+// volume or filesystem documents in state; only the filesystems each
+// unit's container reports. Storage can be listed and shown, but
+// detaching and removing it are reported as not supported for each
+// storage instance.
+type caasStorageAccess interface {
+	// ModelTag the tag of the model on which we are operating.
+	ModelTag() names.ModelTag
+
+	// UnitFilesystems returns the filesystems recorded for the
+	// container of each unit in the model.
+	UnitFilesystems() (map[names.UnitTag][]state.ContainerFilesystem, error)
+}
+
+// NewCAASAPIv4 returns a new storage v4 API facade for a CAAS model.
+func NewCAASAPIv4(
+	st caasStorageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv4, error) {
+	api, err := NewAPIv4(nil, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	api.caasStorage = st
+	return api, nil
+}
+
+// NewCAASAPIv3 returns a new storage v3 API facade for a CAAS model.
+func NewCAASAPIv3(
+	st caasStorageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv3, error) {
+	api, err := NewAPIv3(nil, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	api.caasStorage = st
+	return api, nil
+}
+
+// caasUnitFilesystem is a filesystem mounted in the container
+// of a unit in a CAAS model.
+type caasUnitFilesystem struct {
+	unit names.UnitTag
+	state.ContainerFilesystem
+}
+
+// caasFilesystems returns the filesystems of all units in the model,
+// ordered by unit and then by storage.
+func (api *APIv3) caasFilesystems() ([]caasUnitFilesystem, error) {
+	unitFilesystems, err := api.caasStorage.UnitFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []caasUnitFilesystem
+	for unit, filesystems := range unitFilesystems {
+		for _, f := range filesystems {
+			if f.StorageId == "" || f.Id == "" {
+				// Not yet assigned IDs by Juju.
+				continue
+			}
+			result = append(result, caasUnitFilesystem{unit, f})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].unit != result[j].unit {
+			return result[i].unit.Id() < result[j].unit.Id()
+		}
+		return result[i].StorageId < result[j].StorageId
+	})
+	return result, nil
+}
+
+func (api *APIv3) caasStorageDetails(entities params.Entities) (params.StorageDetailsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.StorageDetailsResults{}, errors.Trace(err)
+	}
+	filesystems, err := api.caasFilesystems()
+	if err != nil {
+		return params.StorageDetailsResults{}, errors.Trace(err)
+	}
+	byTag := make(map[names.StorageTag]caasUnitFilesystem)
+	for _, f := range filesystems {
+		byTag[f.StorageTag()] = f
+	}
+	results := make([]params.StorageDetailsResult, len(entities.Entities))
+	for i, entity := range entities.Entities {
+		storageTag, err := names.ParseStorageTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		f, ok := byTag[storageTag]
+		if !ok {
+			results[i].Error = common.ServerError(errors.NotFoundf(
+				"storage instance %q", storageTag.Id(),
+			))
+			continue
+		}
+		results[i].Result = createCAASStorageDetails(f)
+	}
+	return params.StorageDetailsResults{Results: results}, nil
+}
+
+// caasStorageNotSupported returns a result for each of the given
+// storage tags for an operation that cannot be performed on storage
+// in a CAAS model: storage that does not exist is reported as not
+// found, and storage that does is reported as not supported.
+func (api *APIv3) caasStorageNotSupported(operation string, tags []string) (params.ErrorResults, error) {
+	if err := api.checkWritePermission(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	filesystems, err := api.caasFilesystems()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	known := make(map[names.StorageTag]bool)
+	for _, f := range filesystems {
+		known[f.StorageTag()] = true
+	}
+	results := make([]params.ErrorResult, len(tags))
+	for i, tag := range tags {
+		storageTag, err := names.ParseStorageTag(tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !known[storageTag] {
+			results[i].Error = common.ServerError(errors.NotFoundf(
+				"storage instance %q", storageTag.Id(),
+			))
+			continue
+		}
+		results[i].Error = common.ServerError(errors.NotSupportedf(
+			"%s storage in a kubernetes model", operation,
+		))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *APIv3) listCAASStorageDetails() ([]params.StorageDetails, error) {
+	filesystems, err := api.caasFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]params.StorageDetails, len(filesystems))
+	for i, f := range filesystems {
+		results[i] = *createCAASStorageDetails(f)
+	}
+	return results, nil
+}
+
+func (api *APIv3) listCAASFilesystems(filter params.FilesystemFilter) ([]params.FilesystemDetails, error) {
+	if !filter.IsEmpty() {
+		// Filesystems in CAAS models are not attached to machines.
+		return nil, nil
+	}
+	filesystems, err := api.caasFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]params.FilesystemDetails, len(filesystems))
+	for i, f := range filesystems {
+		results[i] = params.FilesystemDetails{
+			FilesystemTag: f.FilesystemTag().String(),
+			Info: params.FilesystemInfo{
+				FilesystemId: f.FilesystemId,
+				Pool:         f.Pool,
+				Size:         f.Size,
+			},
+			Life:    params.Alive,
+			Status:  caasFilesystemStatus(f),
+			Storage: createCAASStorageDetails(f),
+		}
+	}
+	return results, nil
+}
+
+func createCAASStorageDetails(f caasUnitFilesystem) *params.StorageDetails {
+	storageTag := f.StorageTag().String()
+	unitTag := f.unit.String()
+	return &params.StorageDetails{
+		StorageTag: storageTag,
+		OwnerTag:   unitTag,
+		Kind:       params.StorageKindFilesystem,
+		Status:     caasFilesystemStatus(f),
+		Life:       params.Alive,
+		Persistent: true,
+		Attachments: map[string]params.StorageAttachmentDetails{
+			unitTag: {
+				StorageTag: storageTag,
+				UnitTag:    unitTag,
+				Location:   f.MountPoint,
+				Life:       params.Alive,
+			},
+		},
+	}
+}
+
+func caasFilesystemStatus(f caasUnitFilesystem) params.EntityStatus {
+	return params.EntityStatus{
+		Status: f.Status,
+		Info:   f.Message,
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type caasStorageSuite struct {
+	coretesting.BaseSuite
+
+	state *mockCAASState
+	api   *storage.APIv4
+}
+
+var _ = gc.Suite(&caasStorageSuite{})
+
+func (s *caasStorageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.state = &mockCAASState{
+		modelTag: coretesting.ModelTag,
+		unitFilesystems: map[names.UnitTag][]state.ContainerFilesystem{
+			names.NewUnitTag("mariadb/0"): {{
+				StorageId:    "database/0",
+				Id:           "0",
+				StorageName:  "database",
+				FilesystemId: "juju-database-0",
+				Pool:         "kubernetes",
+				Size:         1024,
+				MountPoint:   "/var/lib/mysql",
+				Status:       status.Attached,
+			}, {
+				// Not yet assigned IDs, so not reported.
+				StorageName:  "logs",
+				FilesystemId: "juju-logs-0",
+			}},
+		},
+	}
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin"), Controller: true}
+	api, err := storage.NewCAASAPIv4(
+		s.state, provider.StorageProviders(), &mockPoolManager{}, common.NewResources(), authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *caasStorageSuite) expectedStorageDetails() params.StorageDetails {
+	return params.StorageDetails{
+		StorageTag: "storage-database-0",
+		OwnerTag:   "unit-mariadb-0",
+		Kind:       params.StorageKindFilesystem,
+		Status:     params.EntityStatus{Status: status.Attached},
+		Life:       params.Alive,
+		Persistent: true,
+		Attachments: map[string]params.StorageAttachmentDetails{
+			"unit-mariadb-0": {
+				StorageTag: "storage-database-0",
+				UnitTag:    "unit-mariadb-0",
+				Location:   "/var/lib/mysql",
+				Life:       params.Alive,
+			},
+		},
+	}
+}
+
+func (s *caasStorageSuite) TestListStorageDetails(c *gc.C) {
+	found, err := s.api.ListStorageDetails(params.StorageFilters{
+		[]params.StorageFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Result, jc.DeepEquals, []params.StorageDetails{s.expectedStorageDetails()})
+}
+
+func (s *caasStorageSuite) TestStorageDetails(c *gc.C) {
+	found, err := s.api.StorageDetails(params.Entities{
+		[]params.Entity{{Tag: "storage-database-0"}, {Tag: "storage-logs-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 2)
+	expected := s.expectedStorageDetails()
+	c.Assert(found.Results[0].Result, jc.DeepEquals, &expected)
+	c.Assert(params.IsCodeNotFound(found.Results[1].Error), jc.IsTrue)
+}
+
+func (s *caasStorageSuite) TestListFilesystems(c *gc.C) {
+	found, err := s.api.ListFilesystems(params.FilesystemFilters{
+		[]params.FilesystemFilter{{}, {Machines: []string{"machine-0"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 2)
+	expectedStorage := s.expectedStorageDetails()
+	c.Assert(found.Results[0].Result, jc.DeepEquals, []params.FilesystemDetails{{
+		FilesystemTag: "filesystem-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "juju-database-0",
+			Pool:         "kubernetes",
+			Size:         1024,
+		},
+		Life:    params.Alive,
+		Status:  params.EntityStatus{Status: status.Attached},
+		Storage: &expectedStorage,
+	}})
+	c.Assert(found.Results[1].Result, gc.HasLen, 0)
+}
+
+func (s *caasStorageSuite) TestListVolumes(c *gc.C) {
+	found, err := s.api.ListVolumes(params.VolumeFilters{
+		[]params.VolumeFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 0)
+}
+
+func (s *caasStorageSuite) TestAddToUnitNotSupported(c *gc.C) {
+	_, err := s.api.AddToUnit(params.StoragesAddParams{
+		[]params.StorageAddParams{{UnitTag: "unit-mariadb-0", StorageName: "database"}},
+	})
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *caasStorageSuite) TestDetachNotSupported(c *gc.C) {
+	results, err := s.api.Detach(params.StorageAttachmentIds{
+		[]params.StorageAttachmentId{
+			{StorageTag: "storage-database-0", UnitTag: "unit-mariadb-0"},
+			{StorageTag: "storage-logs-0"},
+			{StorageTag: "volume-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "detaching storage in a kubernetes model not supported")
+	c.Assert(params.IsCodeNotSupported(results.Results[0].Error), jc.IsTrue)
+	c.Assert(params.IsCodeNotFound(results.Results[1].Error), jc.IsTrue)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+}
+
+func (s *caasStorageSuite) TestRemoveNotSupported(c *gc.C) {
+	results, err := s.api.Remove(params.RemoveStorage{
+		[]params.RemoveStorageInstance{
+			{Tag: "storage-database-0", DestroyAttachments: true, DestroyStorage: true},
+			{Tag: "storage-logs-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "removing storage in a kubernetes model not supported")
+	c.Assert(params.IsCodeNotSupported(results.Results[0].Error), jc.IsTrue)
+	c.Assert(params.IsCodeNotFound(results.Results[1].Error), jc.IsTrue)
+}
+
+func (s *caasStorageSuite) TestRemovePermissionDenied(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("someone")}
+	api, err := storage.NewCAASAPIv4(
+		s.state, provider.StorageProviders(), &mockPoolManager{}, common.NewResources(), authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Remove(params.RemoveStorage{
+		[]params.RemoveStorageInstance{{Tag: "storage-database-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -404,3 +404,16 @@ func (b mockBlock) Type() state.BlockType {
 func (b mockBlock) Message() string {
 	return b.msg
 }
+
+type mockCAASState struct {
+	modelTag        names.ModelTag
+	unitFilesystems map[names.UnitTag][]state.ContainerFilesystem
+}
+
+func (st *mockCAASState) ModelTag() names.ModelTag {
+	return st.modelTag
+}
+
+func (st *mockCAASState) UnitFilesystems() (map[names.UnitTag][]state.ContainerFilesystem, error) {
+	return st.unitFilesystems, nil
+}
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facade"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -25,6 +26,15 @@ func NewFacadeV4(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 ) (*APIv4, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() == state.ModelTypeCAAS {
+		registry := k8sprovider.StorageProviders()
+		pm := poolmanager.New(state.NewStateSettings(st), registry)
+		return NewCAASAPIv4(caasStateShim{st, model}, registry, pm, resources, authorizer)
+	}
 	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
 	if err != nil {
 		return nil, errors.Annotate(err, "getting environ")
@@ -45,6 +55,15 @@ func NewFacadeV3(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 ) (*APIv3, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() == state.ModelTypeCAAS {
+		registry := k8sprovider.StorageProviders()
+		pm := poolmanager.New(state.NewStateSettings(st), registry)
+		return NewCAASAPIv3(caasStateShim{st, model}, registry, pm, resources, authorizer)
+	}
 	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
 	if err != nil {
 		return nil, errors.Annotate(err, "getting environ")
@@ -199,3 +218,35 @@ func (s stateShim) ModelName() (string, error) {
 	}
 	return cfg.Name(), nil
 }
+
+type caasStateShim struct {
+	*state.State
+	model *state.Model
+}
+
+// ModelTag returns the tag of the model.
+func (s caasStateShim) ModelTag() names.ModelTag {
+	return s.model.ModelTag()
+}
+
+// UnitFilesystems returns the filesystems recorded for the
+// container of each unit in the model.
+func (s caasStateShim) UnitFilesystems() (map[names.UnitTag][]state.ContainerFilesystem, error) {
+	applications, err := s.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[names.UnitTag][]state.ContainerFilesystem)
+	for _, app := range applications {
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, u := range units {
+			if filesystems := u.ContainerInfo().Filesystems; len(filesystems) > 0 {
+				result[u.UnitTag()] = filesystems
+			}
+		}
+	}
+	return result, nil
+}
@@ -25,6 +25,7 @@ import (
 // APIv3 implements the storage v3 API.
 type APIv3 struct {
 	storage     storageAccess
+	caasStorage caasStorageAccess
 	registry    storage.ProviderRegistry
 	poolManager poolmanager.PoolManager
 	authorizer  facade.Authorizer
@@ -69,8 +70,15 @@ func NewAPIv3(
 	}, nil
 }
 
+func (api *APIv3) modelTag() names.ModelTag {
+	if api.caasStorage != nil {
+		return api.caasStorage.ModelTag()
+	}
+	return api.storage.ModelTag()
+}
+
 func (api *APIv3) checkCanRead() error {
-	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.storage.ModelTag())
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag())
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -81,7 +89,19 @@ func (api *APIv3) checkCanRead() error {
 }
 
 func (api *APIv3) checkCanWrite() error {
-	canWrite, err := api.authorizer.HasPermission(permission.WriteAccess, api.storage.ModelTag())
+	if err := api.checkWritePermission(); err != nil {
+		return errors.Trace(err)
+	}
+	if api.caasStorage != nil {
+		// Storage in a CAAS model is managed
+		// along with the application's pods.
+		return errors.NotSupportedf("changing storage in a kubernetes model")
+	}
+	return nil
+}
+
+func (api *APIv3) checkWritePermission() error {
+	canWrite, err := api.authorizer.HasPermission(permission.WriteAccess, api.modelTag())
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -95,6 +115,9 @@ func (api *APIv3) checkCanWrite() error {
 // storage identified by supplied tags. If specified storage cannot be
 // retrieved, individual error is returned instead of storage information.
 func (api *APIv3) StorageDetails(entities params.Entities) (params.StorageDetailsResults, error) {
+	if api.caasStorage != nil {
+		return api.caasStorageDetails(entities)
+	}
 	if err := api.checkCanWrite(); err != nil {
 		return params.StorageDetailsResults{}, errors.Trace(err)
 	}
@@ -146,6 +169,9 @@ func (api *APIv3) listStorageDetails(filter params.StorageFilter) ([]params.Stor
 		// this code.
 		return nil, errors.NotSupportedf("storage filters")
 	}
+	if api.caasStorage != nil {
+		return api.listCAASStorageDetails()
+	}
 	stateInstances, err := api.storage.AllStorageInstances()
 	if err != nil {
 		return nil, common.ServerError(err)
@@ -401,6 +427,10 @@ func (a *APIv3) ListVolumes(filters params.VolumeFilters) (params.VolumeDetailsL
 	results := params.VolumeDetailsListResults{
 		Results: make([]params.VolumeDetailsListResult, len(filters.Filters)),
 	}
+	if a.caasStorage != nil {
+		// There are no volumes in a CAAS model.
+		return results, nil
+	}
 	for i, filter := range filters.Filters {
 		volumes, volumeAttachments, err := filterVolumes(a.storage, filter)
 		if err != nil {
@@ -554,6 +584,15 @@ func (a *APIv3) ListFilesystems(filters params.FilesystemFilters) (params.Filesy
 	}
 
 	for i, filter := range filters.Filters {
+		if a.caasStorage != nil {
+			details, err := a.listCAASFilesystems(filter)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+			results.Results[i].Result = details
+			continue
+		}
 		filesystems, filesystemAttachments, err := filterFilesystems(a.storage, filter)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
@@ -793,6 +832,13 @@ func (a *APIv4) Remove(args params.RemoveStorage) (params.ErrorResults, error) {
 }
 
 func (a *APIv3) remove(args params.RemoveStorage) (params.ErrorResults, error) {
+	if a.caasStorage != nil {
+		tags := make([]string, len(args.Storage))
+		for i, arg := range args.Storage {
+			tags[i] = arg.Tag
+		}
+		return a.caasStorageNotSupported("removing", tags)
+	}
 	if err := a.checkCanWrite(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
@@ -824,6 +870,13 @@ func (a *APIv3) remove(args params.RemoveStorage) (params.ErrorResults, error) {
 // already Dying or Dead. Any associated, persistent storage will remain
 // alive.
 func (a *APIv3) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	if a.caasStorage != nil {
+		tags := make([]string, len(args.Ids))
+		for i, arg := range args.Ids {
+			tags[i] = arg.StorageTag
+		}
+		return a.caasStorageNotSupported("detaching", tags)
+	}
 	if err := a.checkCanWrite(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
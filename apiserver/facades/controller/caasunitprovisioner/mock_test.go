@@ -6,13 +6,16 @@ package caasunitprovisioner_test
 import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/controller/caasunitprovisioner"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 )
 
 type mockState struct {
@@ -21,6 +24,7 @@ type mockState struct {
 	applicationsWatcher *statetesting.MockStringsWatcher
 	model               mockModel
 	unit                mockUnit
+	modelConstraints    constraints.Value
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -59,6 +63,11 @@ func (st *mockState) Model() (caasunitprovisioner.Model, error) {
 	return &st.model, nil
 }
 
+func (st *mockState) ModelConstraints() (constraints.Value, error) {
+	st.MethodCall(st, "ModelConstraints")
+	return st.modelConstraints, st.NextErr()
+}
+
 type mockModel struct {
 	testing.Stub
 	containerSpecWatcher *statetesting.MockNotifyWatcher
@@ -84,10 +93,15 @@ type mockApplication struct {
 	testing.Stub
 	life         state.Life
 	unitsWatcher *statetesting.MockStringsWatcher
+	scaleWatcher *statetesting.MockNotifyWatcher
 
-	tag   names.Tag
-	units []caasunitprovisioner.Unit
-	ops   *state.UpdateUnitsOperation
+	tag                names.Tag
+	units              []caasunitprovisioner.Unit
+	ops                *state.UpdateUnitsOperation
+	storageConstraints map[string]state.StorageConstraints
+	charm              mockCharm
+	constraints        constraints.Value
+	scale              int
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -118,6 +132,34 @@ func (m *mockApplication) UpdateUnits(ops *state.UpdateUnitsOperation) error {
 	return nil
 }
 
+func (a *mockApplication) StorageConstraints() (map[string]state.StorageConstraints, error) {
+	a.MethodCall(a, "StorageConstraints")
+	return a.storageConstraints, a.NextErr()
+}
+
+func (a *mockApplication) Charm() (caasunitprovisioner.Charm, bool, error) {
+	a.MethodCall(a, "Charm")
+	if err := a.NextErr(); err != nil {
+		return nil, false, err
+	}
+	return &a.charm, false, nil
+}
+
+func (a *mockApplication) Constraints() (constraints.Value, error) {
+	a.MethodCall(a, "Constraints")
+	return a.constraints, a.NextErr()
+}
+
+func (a *mockApplication) GetScale() int {
+	a.MethodCall(a, "GetScale")
+	return a.scale
+}
+
+func (a *mockApplication) WatchScale() state.NotifyWatcher {
+	a.MethodCall(a, "WatchScale")
+	return a.scaleWatcher
+}
+
 var addOp = &state.AddUnitOperation{}
 
 func (m *mockApplication) AddOperation(props state.UnitUpdateProperties) *state.AddUnitOperation {
@@ -125,11 +167,20 @@ func (m *mockApplication) AddOperation(props state.UnitUpdateProperties) *state.
 	return addOp
 }
 
+type mockCharm struct {
+	meta charm.Meta
+}
+
+func (ch *mockCharm) Meta() *charm.Meta {
+	return &ch.meta
+}
+
 type mockUnit struct {
 	testing.Stub
-	name       string
-	life       state.Life
-	providerId string
+	name          string
+	life          state.Life
+	providerId    string
+	containerInfo state.ContainerInfo
 }
 
 func (*mockUnit) Tag() names.Tag {
@@ -153,6 +204,10 @@ func (m *mockUnit) AgentStatus() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Allocating}, nil
 }
 
+func (m *mockUnit) ContainerInfo() state.ContainerInfo {
+	return m.containerInfo
+}
+
 var updateOp = &state.UpdateUnitOperation{}
 
 func (m *mockUnit) UpdateOperation(props state.UnitUpdateProperties) *state.UpdateUnitOperation {
@@ -166,3 +221,32 @@ func (m *mockUnit) DestroyOperation() *state.DestroyUnitOperation {
 	m.MethodCall(m, "DestroyOperation")
 	return destroyOp
 }
+
+type mockStoragePoolManager struct {
+	testing.Stub
+	pools map[string]*storage.Config
+}
+
+func (m *mockStoragePoolManager) Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
+	return nil, errors.NotImplementedf("Create")
+}
+
+func (m *mockStoragePoolManager) Delete(name string) error {
+	return errors.NotImplementedf("Delete")
+}
+
+func (m *mockStoragePoolManager) Get(name string) (*storage.Config, error) {
+	m.MethodCall(m, "Get", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	pool, ok := m.pools[name]
+	if !ok {
+		return nil, errors.NotFoundf("pool %q", name)
+	}
+	return pool, nil
+}
+
+func (m *mockStoragePoolManager) List() ([]*storage.Config, error) {
+	return nil, errors.NotImplementedf("List")
+}
@@ -4,44 +4,95 @@
 package caasunitprovisioner
 
 import (
+	"path"
 	"reflect"
+	"sort"
+	"strconv"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller.caasunitprovisioner")
 
+// defaultStorageDir is the directory under which filesystems are
+// mounted in a pod if the charm does not specify a location.
+const defaultStorageDir = "/var/lib/juju/storage"
+
 type Facade struct {
 	*common.LifeGetter
-	resources facade.Resources
-	state     CAASUnitProvisionerState
+	resources               facade.Resources
+	state                   CAASUnitProvisionerState
+	storageProviderRegistry storage.ProviderRegistry
+	storagePoolManager      poolmanager.PoolManager
+}
+
+// FacadeV2 implements version 2 of the CAAS unit provisioner facade.
+type FacadeV2 struct {
+	*Facade
+}
+
+// FacadeV1 implements version 1 of the CAAS unit provisioner facade.
+type FacadeV1 struct {
+	*FacadeV2
 }
 
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
 	resources := ctx.Resources()
+	// TODO(caas) - get the registry from the provider
+	registry := k8sprovider.StorageProviders()
+	pm := poolmanager.New(state.NewStateSettings(ctx.State()), registry)
 	return NewFacade(
 		resources,
 		authorizer,
 		stateShim{ctx.State()},
+		registry,
+		pm,
 	)
 }
 
+// NewStateFacadeV2 provides the signature required for facade registration
+// of version 2 of the facade.
+func NewStateFacadeV2(ctx facade.Context) (*FacadeV2, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV2{f}, nil
+}
+
+// NewStateFacadeV1 provides the signature required for facade registration
+// of version 1 of the facade.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
 // NewFacade returns a new CAAS unit provisioner Facade facade.
 func NewFacade(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 	st CAASUnitProvisionerState,
+	storageProviderRegistry storage.ProviderRegistry,
+	storagePoolManager poolmanager.PoolManager,
 ) (*Facade, error) {
 	if !authorizer.AuthController() {
 		return nil, common.ErrPerm
@@ -53,11 +104,22 @@ func NewFacade(
 				common.AuthFuncForTagKind(names.UnitTagKind),
 			),
 		),
-		resources: resources,
-		state:     st,
+		resources:               resources,
+		state:                   st,
+		storageProviderRegistry: storageProviderRegistry,
+		storagePoolManager:      storagePoolManager,
 	}, nil
 }
 
+// ProvisioningInfo is not available on the v1 API.
+func (*FacadeV1) ProvisioningInfo(_, _ struct{}) {}
+
+// WatchApplicationsScale is not available on the v1 or v2 API.
+func (*FacadeV2) WatchApplicationsScale(_, _ struct{}) {}
+
+// ApplicationsScale is not available on the v1 or v2 API.
+func (*FacadeV2) ApplicationsScale(_, _ struct{}) {}
+
 // WatchApplications starts a StringsWatcher to watch CAAS applications
 // deployed to this model.
 func (f *Facade) WatchApplications() (params.StringsWatchResult, error) {
@@ -106,8 +168,70 @@ func (f *Facade) watchUnits(tagString string) (string, []string, error) {
 	return "", nil, watcher.EnsureErr(w)
 }
 
+// WatchApplicationsScale starts a NotifyWatcher to watch changes
+// to the desired scale of the specified applications.
+func (f *Facade) WatchApplicationsScale(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationScale(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationScale(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchScale()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// ApplicationsScale returns the desired scale of the
+// specified applications.
+func (f *Facade) ApplicationsScale(args params.Entities) (params.IntResults, error) {
+	results := params.IntResults{
+		Results: make([]params.IntResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		scale, err := f.applicationScale(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = scale
+	}
+	return results, nil
+}
+
+func (f *Facade) applicationScale(tagString string) (int, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return app.GetScale(), nil
+}
+
 // WatchContainerSpec starts a NotifyWatcher to watch changes to the
-// container spec for specified units in this model.
+// container spec for specified applications or units in this model.
 func (f *Facade) WatchContainerSpec(args params.Entities) (params.NotifyWatchResults, error) {
 	model, err := f.state.Model()
 	if err != nil {
@@ -128,7 +252,7 @@ func (f *Facade) WatchContainerSpec(args params.Entities) (params.NotifyWatchRes
 }
 
 func (f *Facade) watchContainerSpec(model Model, tagString string) (string, error) {
-	tag, err := names.ParseUnitTag(tagString)
+	tag, err := parseApplicationOrUnitTag(tagString)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
@@ -142,7 +266,8 @@ func (f *Facade) watchContainerSpec(model Model, tagString string) (string, erro
 	return "", watcher.EnsureErr(w)
 }
 
-// ContainerSpec returns the container spec for specified units in this model.
+// ContainerSpec returns the container spec for specified applications
+// or units in this model.
 func (f *Facade) ContainerSpec(args params.Entities) (params.StringResults, error) {
 	model, err := f.state.Model()
 	if err != nil {
@@ -163,13 +288,26 @@ func (f *Facade) ContainerSpec(args params.Entities) (params.StringResults, erro
 }
 
 func (f *Facade) containerSpec(model Model, tagString string) (string, error) {
-	tag, err := names.ParseUnitTag(tagString)
+	tag, err := parseApplicationOrUnitTag(tagString)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
 	return model.ContainerSpec(tag)
 }
 
+func parseApplicationOrUnitTag(tagString string) (names.Tag, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag.Kind() {
+	case names.ApplicationTagKind, names.UnitTagKind:
+		return tag, nil
+	default:
+		return nil, errors.Errorf("%q is not a valid application or unit tag", tagString)
+	}
+}
+
 // ApplicationsConfig returns the config for the specified applications.
 func (f *Facade) ApplicationsConfig(args params.Entities) (params.ApplicationGetConfigResults, error) {
 	results := params.ApplicationGetConfigResults{
@@ -195,6 +333,146 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	return app.ApplicationConfig()
 }
 
+// ProvisioningInfo returns the information needed to provision the
+// pods of the specified applications.
+func (f *Facade) ProvisioningInfo(args params.Entities) (params.KubernetesProvisioningInfoResults, error) {
+	results := params.KubernetesProvisioningInfoResults{
+		Results: make([]params.KubernetesProvisioningInfoResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		info, err := f.provisioningInfo(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = info
+	}
+	return results, nil
+}
+
+func (f *Facade) provisioningInfo(tagString string) (*params.KubernetesProvisioningInfo, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	filesystems, err := f.applicationFilesystemParams(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := f.applicationConstraints(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.KubernetesProvisioningInfo{
+		Filesystems: filesystems,
+		Constraints: cons,
+	}, nil
+}
+
+// applicationConstraints returns the application's constraints,
+// with any not set on the application taken from the model.
+func (f *Facade) applicationConstraints(app Application) (constraints.Value, error) {
+	appCons, err := app.Constraints()
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	modelCons, err := f.state.ModelConstraints()
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	cons, err := k8sprovider.ConstraintsValidator().Merge(modelCons, appCons)
+	return cons, errors.Trace(err)
+}
+
+// applicationFilesystemParams returns the parameters of the filesystems
+// to create for each of the application's pods, one for each instance
+// of charm storage required by the application's storage constraints.
+func (f *Facade) applicationFilesystemParams(app Application) ([]params.KubernetesFilesystemParams, error) {
+	storageConstraints, err := app.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(storageConstraints) == 0 {
+		return nil, nil
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Sort the storage names so the pods are always
+	// given their filesystems in the same order.
+	storageNames := make([]string, 0, len(storageConstraints))
+	for name := range storageConstraints {
+		storageNames = append(storageNames, name)
+	}
+	sort.Strings(storageNames)
+
+	var result []params.KubernetesFilesystemParams
+	for _, name := range storageNames {
+		cons := storageConstraints[name]
+		charmStorage, ok := ch.Meta().Storage[name]
+		if !ok {
+			return nil, errors.NotFoundf("charm storage %q", name)
+		}
+		providerType, attrs, err := f.poolConfig(cons.Pool)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting pool for %q storage", name)
+		}
+		for i := uint64(0); i < cons.Count; i++ {
+			result = append(result, params.KubernetesFilesystemParams{
+				StorageName: name,
+				Size:        cons.Size,
+				Provider:    string(providerType),
+				Attributes:  attrs,
+				Attachment: &params.KubernetesFilesystemAttachmentParams{
+					MountPoint: filesystemMountPoint(charmStorage, i),
+					ReadOnly:   charmStorage.ReadOnly,
+				},
+			})
+		}
+	}
+	return result, nil
+}
+
+// poolConfig returns the storage provider type and attributes of
+// the named storage pool.
+func (f *Facade) poolConfig(poolName string) (storage.ProviderType, map[string]interface{}, error) {
+	pool, err := f.storagePoolManager.Get(poolName)
+	if errors.IsNotFound(err) {
+		// If there's no pool called poolName, maybe a provider type
+		// has been specified directly.
+		providerType := storage.ProviderType(poolName)
+		if _, err1 := f.storageProviderRegistry.StorageProvider(providerType); err1 != nil {
+			// The name can't be resolved as a storage provider type,
+			// so return the original "pool not found" error.
+			return "", nil, errors.Trace(err)
+		}
+		return providerType, nil, nil
+	} else if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return pool.Provider(), pool.Attrs(), nil
+}
+
+// filesystemMountPoint returns the mount point to use in a pod for the
+// given instance of charm storage. For stores with potentially multiple
+// instances, the instance index is appended to the location.
+func filesystemMountPoint(meta charm.Storage, index uint64) string {
+	if meta.Location != "" && meta.CountMax == 1 {
+		return meta.Location
+	}
+	storageDir := meta.Location
+	if storageDir == "" {
+		storageDir = path.Join(defaultStorageDir, meta.Name)
+	}
+	return path.Join(storageDir, strconv.FormatUint(index, 10))
+}
+
 // UpdateApplicationsUnits updates the Juju data model to reflect the given
 // units of the specified application.
 func (a *Facade) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (params.ErrorResults, error) {
@@ -293,6 +571,30 @@ func (a *Facade) updateUnitsFromCloud(app Application, units []params.Applicatio
 		unitUpdate.Deletes = append(unitUpdate.Deletes, u.DestroyOperation())
 	}
 
+	storageConstraints, err := app.StorageConstraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitFilesystems := func(unitParams params.ApplicationUnitParams) []state.ContainerFilesystem {
+		if len(unitParams.FilesystemInfo) == 0 {
+			return nil
+		}
+		filesystems := make([]state.ContainerFilesystem, len(unitParams.FilesystemInfo))
+		for i, fs := range unitParams.FilesystemInfo {
+			filesystems[i] = state.ContainerFilesystem{
+				StorageName:  fs.StorageName,
+				FilesystemId: fs.FilesystemId,
+				Pool:         storageConstraints[fs.StorageName].Pool,
+				Size:         fs.Size,
+				MountPoint:   fs.MountPoint,
+				ReadOnly:     fs.ReadOnly,
+				Status:       status.Status(fs.Status),
+				Message:      fs.Info,
+			}
+		}
+		return filesystems
+	}
+
 	unitUpdateProperties := func(unitParams params.ApplicationUnitParams) state.UnitUpdateProperties {
 		return state.UnitUpdateProperties{
 			ProviderId: unitParams.Id,
@@ -303,6 +605,7 @@ func (a *Facade) updateUnitsFromCloud(app Application, units []params.Applicatio
 				Message: unitParams.Info,
 				Data:    unitParams.Data,
 			},
+			Filesystems: unitFilesystems(unitParams),
 		}
 	}
 
@@ -314,10 +617,10 @@ func (a *Facade) updateUnitsFromCloud(app Application, units []params.Applicatio
 		if string(existingStatus.Status) != params.Status ||
 			existingStatus.Message != params.Info ||
 			len(existingStatus.Data) != len(params.Data) ||
-			reflect.DeepEqual(existingStatus.Data, params.Data) {
+			!reflect.DeepEqual(existingStatus.Data, params.Data) {
 			return true, nil
 		}
-		return false, nil
+		return filesystemsChanged(u.ContainerInfo().Filesystems, unitFilesystems(params)), nil
 	}
 
 	// For existing units which have been updated, create the necessary update ops.
@@ -353,3 +656,20 @@ func (a *Facade) updateUnitsFromCloud(app Application, units []params.Applicatio
 	}
 	return app.UpdateUnits(&unitUpdate)
 }
+
+// filesystemsChanged reports whether the filesystems reported by the
+// provider differ from those recorded for a unit, ignoring the IDs
+// assigned by Juju.
+func filesystemsChanged(existing, reported []state.ContainerFilesystem) bool {
+	if len(existing) != len(reported) {
+		return true
+	}
+	for i, f := range existing {
+		f.StorageId = ""
+		f.Id = ""
+		if !reflect.DeepEqual(f, reported[i]) {
+			return true
+		}
+	}
+	return false
+}
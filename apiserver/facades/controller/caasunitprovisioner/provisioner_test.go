@@ -4,17 +4,22 @@
 package caasunitprovisioner_test
 
 import (
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/controller/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/workertest"
 )
@@ -28,10 +33,12 @@ type CAASProvisionerSuite struct {
 	applicationsChanges  chan []string
 	containerSpecChanges chan struct{}
 	unitsChanges         chan []string
+	scaleChanges         chan struct{}
 
-	resources  *common.Resources
-	authorizer *apiservertesting.FakeAuthorizer
-	facade     *caasunitprovisioner.Facade
+	resources          *common.Resources
+	authorizer         *apiservertesting.FakeAuthorizer
+	storagePoolManager *mockStoragePoolManager
+	facade             *caasunitprovisioner.Facade
 }
 
 func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
@@ -40,11 +47,14 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.applicationsChanges = make(chan []string, 1)
 	s.containerSpecChanges = make(chan struct{}, 1)
 	s.unitsChanges = make(chan []string, 1)
+	s.scaleChanges = make(chan struct{}, 1)
 	s.st = &mockState{
 		application: mockApplication{
 			tag:          names.NewApplicationTag("gitlab"),
 			life:         state.Alive,
 			unitsWatcher: statetesting.NewMockStringsWatcher(s.unitsChanges),
+			scaleWatcher: statetesting.NewMockNotifyWatcher(s.scaleChanges),
+			scale:        5,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		model: mockModel{
@@ -56,6 +66,7 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.unitsWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.scaleWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.containerSpecWatcher) })
 
 	s.resources = common.NewResources()
@@ -64,7 +75,17 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 		Controller: true,
 	}
 
-	facade, err := caasunitprovisioner.NewFacade(s.resources, s.authorizer, s.st)
+	fast, err := storage.NewConfig("fast", provider.K8s_ProviderType, map[string]interface{}{
+		provider.K8s_StorageClass: "ssd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.storagePoolManager = &mockStoragePoolManager{
+		pools: map[string]*storage.Config{"fast": fast},
+	}
+
+	facade, err := caasunitprovisioner.NewFacade(
+		s.resources, s.authorizer, s.st, provider.StorageProviders(), s.storagePoolManager,
+	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
@@ -73,7 +94,9 @@ func (s *CAASProvisionerSuite) TestPermission(c *gc.C) {
 	s.authorizer = &apiservertesting.FakeAuthorizer{
 		Tag: names.NewMachineTag("0"),
 	}
-	_, err := caasunitprovisioner.NewFacade(s.resources, s.authorizer, s.st)
+	_, err := caasunitprovisioner.NewFacade(
+		s.resources, s.authorizer, s.st, provider.StorageProviders(), s.storagePoolManager,
+	)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
@@ -96,19 +119,79 @@ func (s *CAASProvisionerSuite) TestWatchContainerSpec(c *gc.C) {
 	results, err := s.facade.WatchContainerSpec(params.Entities{
 		Entities: []params.Entity{
 			{Tag: "unit-gitlab-0"},
-			{Tag: "application-gitlab"},
+			{Tag: "machine-0"},
 		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 2)
 	c.Assert(results.Results[0].Error, gc.IsNil)
 	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
-		Message: `"application-gitlab" is not a valid unit tag`,
+		Message: `"machine-0" is not a valid application or unit tag`,
 	})
 
 	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
 	resource := s.resources.Get("1")
 	c.Assert(resource, gc.Equals, s.st.model.containerSpecWatcher)
+	s.st.model.CheckCall(c, 0, "WatchContainerSpec", names.NewUnitTag("gitlab/0"))
+}
+
+func (s *CAASProvisionerSuite) TestWatchContainerSpecApplication(c *gc.C) {
+	s.containerSpecChanges <- struct{}{}
+
+	results, err := s.facade.WatchContainerSpec(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	s.st.model.CheckCall(c, 0, "WatchContainerSpec", names.NewApplicationTag("gitlab"))
+}
+
+func (s *CAASProvisionerSuite) TestWatchApplicationsScale(c *gc.C) {
+	s.scaleChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsScale(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.scaleWatcher)
+}
+
+func (s *CAASProvisionerSuite) TestApplicationsScale(c *gc.C) {
+	results, err := s.facade.ApplicationsScale(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "application-mysql"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.IntResults{
+		Results: []params.IntResult{{
+			Result: 5,
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `application mysql not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
 }
 
 func (s *CAASProvisionerSuite) TestWatchUnits(c *gc.C) {
@@ -138,15 +221,18 @@ func (s *CAASProvisionerSuite) TestContainerSpec(c *gc.C) {
 		Entities: []params.Entity{
 			{Tag: "unit-gitlab-0"},
 			{Tag: "application-gitlab"},
+			{Tag: "machine-0"},
 		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, params.StringResults{
 		Results: []params.StringResult{{
 			Result: "spec(gitlab/0)",
+		}, {
+			Result: "spec(gitlab)",
 		}, {
 			Error: &params.Error{
-				Message: `"application-gitlab" is not a valid unit tag`,
+				Message: `"machine-0" is not a valid application or unit tag`,
 			},
 		}},
 	})
@@ -220,8 +306,8 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnits(c *gc.C) {
 			{&params.Error{Message: "application another not found", Code: "not found"}},
 		},
 	})
-	s.st.application.CheckCallNames(c, "AddOperation")
-	s.st.application.CheckCall(c, 0, "AddOperation", state.UnitUpdateProperties{
+	s.st.application.CheckCallNames(c, "StorageConstraints", "AddOperation")
+	s.st.application.CheckCall(c, 1, "AddOperation", state.UnitUpdateProperties{
 		ProviderId: "last-uuid",
 		Address:    "last-address", Ports: []string{"last-port"},
 		Status: &status.StatusInfo{Status: status.Running, Message: "last message"},
@@ -240,3 +326,127 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnits(c *gc.C) {
 	})
 	s.st.application.units[2].(*mockUnit).CheckCallNames(c, "Life", "DestroyOperation")
 }
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsFilesystems(c *gc.C) {
+	s.st.application.storageConstraints = map[string]state.StorageConstraints{
+		"data": {Pool: "fast", Size: 1024, Count: 1},
+	}
+	unchanged := state.ContainerFilesystem{
+		StorageId:    "data/0",
+		Id:           "0",
+		StorageName:  "data",
+		FilesystemId: "juju-data-0",
+		Pool:         "fast",
+		Size:         1024,
+		MountPoint:   "/srv/data",
+		Status:       status.Attached,
+	}
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", providerId: "uuid", life: state.Alive,
+			containerInfo: state.ContainerInfo{Filesystems: []state.ContainerFilesystem{unchanged}}},
+		&mockUnit{name: "gitlab/1", providerId: "uuid2", life: state.Alive},
+	}
+	filesystemInfo := []params.KubernetesFilesystemInfo{{
+		StorageName:  "data",
+		FilesystemId: "juju-data-0",
+		Size:         1024,
+		MountPoint:   "/srv/data",
+		Status:       "attached",
+	}}
+	units := []params.ApplicationUnitParams{
+		{Id: "uuid", Status: "allocating", FilesystemInfo: filesystemInfo},
+		{Id: "uuid2", Status: "allocating", FilesystemInfo: filesystemInfo},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+
+	// Only the unit whose filesystems have changed is updated.
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life")
+	s.st.application.units[1].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[1].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId: "uuid2",
+		Status:     &status.StatusInfo{Status: status.Allocating},
+		Filesystems: []state.ContainerFilesystem{{
+			StorageName:  "data",
+			FilesystemId: "juju-data-0",
+			Pool:         "fast",
+			Size:         1024,
+			MountPoint:   "/srv/data",
+			Status:       status.Attached,
+		}},
+	})
+}
+
+func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
+	s.st.application.storageConstraints = map[string]state.StorageConstraints{
+		"data":  {Pool: "fast", Size: 1024, Count: 1},
+		"cache": {Pool: "kubernetes", Size: 100, Count: 2},
+	}
+	s.st.application.constraints = constraints.MustParse("mem=1G tags=pool=gpu")
+	s.st.modelConstraints = constraints.MustParse("mem=512M cores=2")
+	s.st.application.charm.meta = charm.Meta{
+		Storage: map[string]charm.Storage{
+			"data": {
+				Name: "data", Type: charm.StorageFilesystem,
+				Location: "/srv/data", CountMin: 1, CountMax: 1,
+			},
+			"cache": {
+				Name: "cache", Type: charm.StorageFilesystem,
+				ReadOnly: true, CountMin: 1, CountMax: -1,
+			},
+		},
+	}
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.KubernetesProvisioningInfoResults{
+		Results: []params.KubernetesProvisioningInfoResult{{
+			Result: &params.KubernetesProvisioningInfo{
+				Filesystems: []params.KubernetesFilesystemParams{{
+					StorageName: "cache",
+					Size:        100,
+					Provider:    "kubernetes",
+					Attachment: &params.KubernetesFilesystemAttachmentParams{
+						MountPoint: "/var/lib/juju/storage/cache/0",
+						ReadOnly:   true,
+					},
+				}, {
+					StorageName: "cache",
+					Size:        100,
+					Provider:    "kubernetes",
+					Attachment: &params.KubernetesFilesystemAttachmentParams{
+						MountPoint: "/var/lib/juju/storage/cache/1",
+						ReadOnly:   true,
+					},
+				}, {
+					StorageName: "data",
+					Size:        1024,
+					Provider:    "kubernetes",
+					Attributes:  map[string]interface{}{"storage-class": "ssd"},
+					Attachment: &params.KubernetesFilesystemAttachmentParams{
+						MountPoint: "/srv/data",
+					},
+				}},
+				Constraints: constraints.MustParse("mem=1G cores=2 tags=pool=gpu"),
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.storagePoolManager.CheckCalls(c, []testing.StubCall{
+		{"Get", []interface{}{"cache"}},
+		{"Get", []interface{}{"data"}},
+	})
+}
@@ -5,8 +5,10 @@ package caasunitprovisioner
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -18,11 +20,12 @@ type CAASUnitProvisionerState interface {
 	Application(string) (Application, error)
 	FindEntity(names.Tag) (state.Entity, error)
 	Model() (Model, error)
+	ModelConstraints() (constraints.Value, error)
 	WatchApplications() state.StringsWatcher
 }
 
 // Model provides the subset of CAAS model state required
-// by the CAAS operator facade.
+// by the CAAS unit provisioner facade.
 type Model interface {
 	ContainerSpec(names.Tag) (string, error)
 	WatchContainerSpec(names.Tag) (state.NotifyWatcher, error)
@@ -36,6 +39,17 @@ type Application interface {
 	AllUnits() (units []Unit, err error)
 	AddOperation(state.UnitUpdateProperties) *state.AddUnitOperation
 	UpdateUnits(*state.UpdateUnitsOperation) error
+	StorageConstraints() (map[string]state.StorageConstraints, error)
+	Charm() (Charm, bool, error)
+	Constraints() (constraints.Value, error)
+	GetScale() int
+	WatchScale() state.NotifyWatcher
+}
+
+// Charm provides the subset of charm state required
+// by the CAAS unit provisioner facade.
+type Charm interface {
+	Meta() *charm.Meta
 }
 
 type stateShim struct {
@@ -62,6 +76,14 @@ type applicationShim struct {
 	*state.Application
 }
 
+func (a applicationShim) Charm() (Charm, bool, error) {
+	ch, force, err := a.Application.Charm()
+	if err != nil {
+		return nil, false, err
+	}
+	return ch, force, nil
+}
+
 func (a applicationShim) AllUnits() ([]Unit, error) {
 	all, err := a.Application.AllUnits()
 	if err != nil {
@@ -79,6 +101,7 @@ type Unit interface {
 	Life() state.Life
 	ProviderId() string
 	AgentStatus() (status.StatusInfo, error)
+	ContainerInfo() state.ContainerInfo
 	UpdateOperation(props state.UnitUpdateProperties) *state.UpdateUnitOperation
 	DestroyOperation() *state.DestroyUnitOperation
 }
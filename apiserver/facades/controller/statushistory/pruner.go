@@ -4,6 +4,8 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -33,10 +35,20 @@ func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API,
 
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// the history is smaller than p.MaxHistoryMB. Entries of the
+// kinds named in the model's status-history-retention config
+// are kept for the age configured for their kind instead.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthController() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	model, err := api.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB, cfg.StatusHistoryRetention())
 }
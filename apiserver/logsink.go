@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/logdb"
 )
@@ -174,6 +175,8 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Location: m.Location,
 		Level:    level,
 		Message:  m.Message,
+		Labels:   m.Labels,
+		Fields:   m.Fields,
 	}}), "logging to DB failed")
 
 	m.Entity = s.entity.String()
@@ -192,7 +195,7 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 
 // logToFile writes a single log message to the logsink log file.
 func logToFile(writer io.Writer, prefix string, m params.LogRecord) error {
-	_, err := writer.Write([]byte(strings.Join([]string{
+	parts := []string{
 		prefix,
 		m.Entity,
 		m.Time.In(time.UTC).Format("2006-01-02 15:04:05"),
@@ -200,6 +203,11 @@ func logToFile(writer io.Writer, prefix string, m params.LogRecord) error {
 		m.Module,
 		m.Location,
 		m.Message,
-	}, " ") + "\n"))
+	}
+	data := logfields.Data{Labels: m.Labels, Fields: m.Fields}
+	if !data.IsEmpty() {
+		parts = append(parts, data.String())
+	}
+	_, err := writer.Write([]byte(strings.Join(parts, " ") + "\n"))
 	return err
 }
@@ -21,6 +21,7 @@ import (
 type logStreamSource interface {
 	getStart(sink string) (time.Time, error)
 	newTailer(state.LogTailerParams) (state.LogTailer, error)
+	newAuditTailer(state.LogTailerParams) (state.LogTailer, error)
 }
 
 type messageWriter interface {
@@ -35,11 +36,14 @@ type logStreamEndpointHandler struct {
 
 func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 	newSource := func(req *http.Request) (logStreamSource, state.StatePoolReleaser, error) {
-		st, releaser, _, err := ctxt.stateForRequestAuthenticatedAgent(req)
+		st, releaser, entity, err := ctxt.stateForRequestAuthenticatedAgent(req)
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
-		return &logStreamState{st}, releaser, nil
+		return &logStreamState{
+			LogTailerState:  st,
+			controllerAgent: isMachineWithJob(entity, state.JobManageModel),
+		}, releaser, nil
 	}
 	return &logStreamEndpointHandler{
 		stopCh:    ctxt.stop(),
@@ -52,6 +56,7 @@ func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 // Args for the HTTP request are as follows:
 //   all -> string - one of [true, false], if true, include records from all models
 //   sink -> string - the name of the the log forwarding target
+//   audit -> string - one of [true, false], if true, stream the audit log records
 func (h *logStreamEndpointHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger.Infof("log stream request handler starting")
 	handler := func(conn *websocket.Conn) {
@@ -127,6 +132,13 @@ func (h *logStreamEndpointHandler) newTailer(source logStreamSource, cfg params.
 		StartTime:    start,
 		InitialLines: cfg.MaxLookbackRecords,
 	}
+	if cfg.Audit {
+		tailer, err := source.newAuditTailer(tailerArgs)
+		if err != nil {
+			return nil, errors.Annotate(err, "tailing audit log")
+		}
+		return tailer, nil
+	}
 	tailer, err := source.newTailer(tailerArgs)
 	if err != nil {
 		return nil, errors.Annotate(err, "tailing logs")
@@ -150,6 +162,10 @@ func (h *logStreamEndpointHandler) sendError(ws *websocket.Conn, req *http.Reque
 // logStreamState is an implementation of logStreamSource.
 type logStreamState struct {
 	state.LogTailerState
+
+	// controllerAgent is true if the logs are being streamed by a
+	// controller machine agent.
+	controllerAgent bool
 }
 
 func (st logStreamState) getStart(sink string) (time.Time, error) {
@@ -181,6 +197,17 @@ func (st logStreamState) newTailer(args state.LogTailerParams) (state.LogTailer,
 	return tailer, nil
 }
 
+func (st logStreamState) newAuditTailer(args state.LogTailerParams) (state.LogTailer, error) {
+	if !st.controllerAgent || !st.IsController() {
+		return nil, errors.NewUnauthorized(nil, "audit log records can only be streamed by controller agents")
+	}
+	tailer, err := state.NewAuditLogTailer(st, args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tailer, nil
+}
+
 type logStreamRequestHandler struct {
 	conn     messageWriter
 	req      *http.Request
@@ -237,6 +264,8 @@ func (h *logStreamRequestHandler) apiFromRecords(records []*state.LogRecord) par
 			Location:  rec.Location,
 			Level:     rec.Level.String(),
 			Message:   rec.Message,
+			Labels:    rec.Labels,
+			Fields:    rec.Fields,
 		}
 		result.Records[i] = apiRec
 	}
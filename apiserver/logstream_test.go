@@ -59,6 +59,55 @@ func (s *LogStreamIntSuite) TestParamConversion(c *gc.C) {
 	})
 }
 
+func (s *LogStreamIntSuite) TestParamAudit(c *gc.C) {
+	cfg := params.LogStreamConfig{
+		Sink:  "spam",
+		Audit: true,
+	}
+	req := s.newReq(c, cfg)
+
+	stub := &testing.Stub{}
+	source := &stubSource{stub: stub}
+	source.ReturnGetStart = 10
+	handler := logStreamEndpointHandler{
+		stopCh:    nil,
+		newSource: source.newSource,
+	}
+
+	_, err := handler.newLogStreamRequestHandler(nil, req, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCallNames(c, "newSource", "getStart", "newAuditTailer")
+	stub.CheckCall(c, 2, "newAuditTailer", state.LogTailerParams{
+		StartTime: time.Unix(10, 0),
+	})
+}
+
+func (s *LogStreamIntSuite) TestAuditTailerRequiresControllerAgent(c *gc.C) {
+	source := logStreamState{
+		LogTailerState:  &stubTailerState{isController: true},
+		controllerAgent: false,
+	}
+	_, err := source.newAuditTailer(state.LogTailerParams{})
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	source = logStreamState{
+		LogTailerState:  &stubTailerState{isController: false},
+		controllerAgent: true,
+	}
+	_, err = source.newAuditTailer(state.LogTailerParams{})
+	c.Assert(err, gc.ErrorMatches, "audit log records can only be streamed by controller agents")
+}
+
+type stubTailerState struct {
+	state.LogTailerState
+	isController bool
+}
+
+func (s *stubTailerState) IsController() bool {
+	return s.isController
+}
+
 type mockClock struct {
 	clock.Clock
 	now time.Time
@@ -285,6 +334,15 @@ func (s *stubSource) newTailer(args state.LogTailerParams) (state.LogTailer, err
 	return s.ReturnNewTailer, nil
 }
 
+func (s *stubSource) newAuditTailer(args state.LogTailerParams) (state.LogTailer, error) {
+	s.stub.AddCall("newAuditTailer", args)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnNewTailer, nil
+}
+
 type stubLogTailer struct {
 	state.LogTailer
 	stub *testing.Stub
@@ -95,6 +95,8 @@ func (s *migrationLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Location: m.Location,
 		Level:    level,
 		Message:  m.Message,
+		Labels:   m.Labels,
+		Fields:   m.Fields,
 	}})
 	if err == nil {
 		err = s.tracker.Track(m.Time)
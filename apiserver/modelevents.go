@@ -0,0 +1,161 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/schema"
+	"github.com/juju/errors"
+	"github.com/juju/utils/featureflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/state"
+)
+
+// modelEventsHandler streams typed model events, derived from the
+// model's multiwatcher, to users and machine agents.
+type modelEventsHandler struct {
+	ctxt    httpContext
+	streams *modelevents.Streams
+}
+
+func newModelEventsHandler(ctxt httpContext, streams *modelevents.Streams) *modelEventsHandler {
+	return &modelEventsHandler{
+		ctxt:    ctxt,
+		streams: streams,
+	}
+}
+
+// ServeHTTP will serve up connections as a websocket for the model
+// events API.
+//
+// Args for the HTTP request are as follows:
+//   cursor -> string - the cursor of the last event received; the stream
+//      - resumes with the following event. If not set, only events from
+//      - now on are sent.
+//   type -> []string - only send events of these types; "action-*"
+//      - selects all action events
+func (h *modelEventsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+
+		st, releaser, _, err := h.ctxt.stateForRequestAuthenticatedTag(req, names.MachineTagKind, names.UserTagKind)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		modelUUID := st.ModelUUID()
+		// The state is only needed for authentication; the stream
+		// holds its own for as long as it runs.
+		releaser()
+
+		var cfg params.ModelEventsConfig
+		query := req.URL.Query()
+		query.Del(":modeluuid")
+		if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+			h.sendError(conn, req, errors.Annotate(err, "decoding schema"))
+			return
+		}
+
+		sub, err := h.streams.Subscribe(modelUUID, cfg.Cursor)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		defer sub.Close()
+
+		// If we get to here, no more errors to report, so we report a nil
+		// error.  This way the first line of the connection is always a json
+		// formatted simple error.
+		h.sendError(conn, req, nil)
+		h.serveEvents(conn, sub, cfg.Types)
+	}
+	websocket.Serve(w, req, handler)
+}
+
+func (h *modelEventsHandler) serveEvents(conn *websocket.Conn, sub *modelevents.Subscription, types []string) {
+	// Nothing is expected from the client, but reading is needed to
+	// notice when it goes away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-h.ctxt.stop():
+			return
+		case <-closed:
+			logger.Tracef("model events handler stopped (client disconnected)")
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				logger.Debugf("model event stream stopped")
+				return
+			}
+			if e.Type != params.ModelEventResync && !eventTypeWanted(e.Type, types) {
+				continue
+			}
+			if err := conn.WriteJSON(e); err != nil {
+				if isBrokenPipe(err) {
+					logger.Tracef("model events handler stopped (client disconnected)")
+				} else {
+					logger.Errorf("model events handler error: %v", err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// eventTypeWanted reports whether events of the given type should be
+// sent to a client that asked for the given types.
+func eventTypeWanted(eventType string, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == eventType {
+			return true
+		}
+		if strings.HasSuffix(t, "*") && strings.HasPrefix(eventType, strings.TrimSuffix(t, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *modelEventsHandler) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	// There is no need to log the error for normal operators as there is nothing
+	// they can action. This is for developers.
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
+
+// newModelWatcher returns a multiwatcher for the model with the given
+// UUID for use by a model event stream.
+func (srv *Server) newModelWatcher(modelUUID string) (modelevents.AllWatcher, func(), error) {
+	st, releaser, err := srv.statePool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return st.Watch(state.WatchParams{}), func() { releaser() }, nil
+}
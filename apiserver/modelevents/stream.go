@@ -0,0 +1,482 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelevents turns the multiwatcher deltas for a model into a
+// stream of typed events, such as unit status changes and hook runs,
+// that clients can resume from a cursor after reconnecting.
+//
+// Each API server runs at most one stream per model, started when the
+// first client subscribes and stopped once it has had no subscribers
+// for a while. Events are kept in a fixed size buffer for resuming, and
+// published on the central hub for delivery to the current subscribers.
+package modelevents
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	pubsubevents "github.com/juju/juju/pubsub/modelevents"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.modelevents")
+
+const (
+	// DefaultBufferSize is the number of events each stream keeps for
+	// clients resuming from a cursor.
+	DefaultBufferSize = 1000
+
+	// DefaultIdleTimeout is how long a stream keeps running after its
+	// last subscriber has gone, so that the subscriber can reconnect
+	// and resume without missing events.
+	DefaultIdleTimeout = 10 * time.Minute
+)
+
+// AllWatcher is the subset of the state multiwatcher used by a stream.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// Hub is the subset of the central hub used to publish and receive
+// model events.
+type Hub interface {
+	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
+}
+
+// Config holds the dependencies and configuration for Streams.
+type Config struct {
+	// Hub is used to deliver events to subscribers.
+	Hub Hub
+
+	// Clock is used to timestamp events and to stop idle streams.
+	Clock clock.Clock
+
+	// NewWatcher returns a watcher of all the entities in the model
+	// with the given UUID, and a function to call once the watcher
+	// has been stopped.
+	NewWatcher func(modelUUID string) (AllWatcher, func(), error)
+
+	// BufferSize is the number of events kept for resuming.
+	BufferSize int
+
+	// IdleTimeout is how long a stream with no subscribers is kept
+	// running.
+	IdleTimeout time.Duration
+}
+
+// Validate checks that the config is usable.
+func (config Config) Validate() error {
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewWatcher == nil {
+		return errors.NotValidf("nil NewWatcher")
+	}
+	if config.BufferSize <= 0 {
+		return errors.NotValidf("non-positive BufferSize")
+	}
+	if config.IdleTimeout <= 0 {
+		return errors.NotValidf("non-positive IdleTimeout")
+	}
+	return nil
+}
+
+// Streams manages the event streams of the models served by an API
+// server.
+type Streams struct {
+	config Config
+
+	mu      sync.Mutex
+	streams map[string]*stream
+	stopped bool
+}
+
+// NewStreams returns a new Streams with the given config.
+func NewStreams(config Config) (*Streams, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Streams{
+		config:  config,
+		streams: make(map[string]*stream),
+	}, nil
+}
+
+// Subscribe returns a subscription to the events of the model with the
+// given UUID. If cursor is empty, only events from now on are delivered;
+// otherwise delivery starts with the event following the cursor. If the
+// events following the cursor are no longer available, the first event
+// delivered is a resync event.
+func (s *Streams) Subscribe(modelUUID, cursor string) (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return nil, errors.New("model event streams stopped")
+	}
+
+	st, ok := s.streams[modelUUID]
+	if ok && !st.alive() {
+		ok = false
+	}
+	if !ok {
+		var err error
+		st, err = s.startStream(modelUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s.streams[modelUUID] = st
+	}
+
+	sub, err := st.subscribe(cursor)
+	if err != nil {
+		if st.subscribers == 0 {
+			s.scheduleExpiry(st)
+		}
+		return nil, errors.Trace(err)
+	}
+	st.subscribers++
+	sub.release = func() { s.release(st) }
+	return sub, nil
+}
+
+// Stop stops all the streams and waits for them to finish.
+func (s *Streams) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	streams := s.streams
+	s.streams = make(map[string]*stream)
+	s.mu.Unlock()
+
+	for _, st := range streams {
+		st.tomb.Kill(nil)
+	}
+	for uuid, st := range streams {
+		if err := st.tomb.Wait(); err != nil {
+			logger.Warningf("event stream for model %s stopped with error: %v", uuid, err)
+		}
+	}
+}
+
+func (s *Streams) startStream(modelUUID string) (*stream, error) {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	watcher, release, err := s.config.NewWatcher(modelUUID)
+	if err != nil {
+		return nil, errors.Annotatef(err, "watching model %s", modelUUID)
+	}
+	st := &stream{
+		config:     s.config,
+		modelUUID:  modelUUID,
+		epoch:      uuid.String(),
+		watcher:    watcher,
+		translator: newTranslator(),
+		next:       1,
+	}
+	go func() {
+		defer st.tomb.Done()
+		defer release()
+		st.tomb.Kill(st.loop())
+	}()
+	go func() {
+		<-st.tomb.Dying()
+		if err := watcher.Stop(); err != nil {
+			logger.Debugf("stopping watcher for model %s: %v", modelUUID, err)
+		}
+	}()
+	return st, nil
+}
+
+func (s *Streams) release(st *stream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st.subscribers--
+	if st.subscribers == 0 {
+		s.scheduleExpiry(st)
+	}
+}
+
+// scheduleExpiry stops the stream once it has been idle for the idle
+// timeout. It must be called with s.mu held.
+func (s *Streams) scheduleExpiry(st *stream) {
+	st.idleGeneration++
+	generation := st.idleGeneration
+	s.config.Clock.AfterFunc(s.config.IdleTimeout, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if st.subscribers > 0 || st.idleGeneration != generation {
+			return
+		}
+		if s.streams[st.modelUUID] == st {
+			delete(s.streams, st.modelUUID)
+		}
+		st.tomb.Kill(nil)
+	})
+}
+
+// stream translates the deltas from a model's multiwatcher into events.
+type stream struct {
+	tomb       tomb.Tomb
+	config     Config
+	modelUUID  string
+	epoch      string
+	watcher    AllWatcher
+	translator *translator
+
+	// subscribers and idleGeneration are guarded by the mutex of the
+	// owning Streams.
+	subscribers    int
+	idleGeneration uint64
+
+	// mu guards the fields below it.
+	mu     sync.Mutex
+	buffer []pubsubevents.Event
+	next   uint64
+}
+
+func (st *stream) alive() bool {
+	select {
+	case <-st.tomb.Dying():
+		return false
+	default:
+		return true
+	}
+}
+
+func (st *stream) loop() error {
+	// The first set of deltas describes the model as it is now, which
+	// is the baseline for the events rather than a change.
+	deltas, err := st.watcher.Next()
+	if err != nil {
+		return st.watcherError(err)
+	}
+	st.translator.seed(deltas)
+	for {
+		deltas, err := st.watcher.Next()
+		if err != nil {
+			return st.watcherError(err)
+		}
+		for _, e := range st.translator.translate(deltas) {
+			st.publish(e)
+		}
+	}
+}
+
+func (st *stream) watcherError(err error) error {
+	select {
+	case <-st.tomb.Dying():
+		return tomb.ErrDying
+	default:
+		return errors.Annotate(err, "watching model")
+	}
+}
+
+// publish gives the event the next position in the stream, records it
+// for resuming and publishes it to the current subscribers.
+func (st *stream) publish(e event) {
+	st.mu.Lock()
+	msg := pubsubevents.Event{
+		Epoch:     st.epoch,
+		Sequence:  st.next,
+		Type:      e.Type,
+		Entity:    e.Entity,
+		Time:      st.config.Clock.Now().UTC().Format(time.RFC3339Nano),
+		Data:      e.Data,
+		LocalOnly: true,
+	}
+	st.next++
+	st.buffer = append(st.buffer, msg)
+	if excess := len(st.buffer) - st.config.BufferSize; excess > 0 {
+		st.buffer = st.buffer[excess:]
+	}
+	st.mu.Unlock()
+
+	if _, err := st.config.Hub.Publish(pubsubevents.Topic(st.modelUUID), msg); err != nil {
+		logger.Errorf("publishing %s event for %s: %v", msg.Type, msg.Entity, err)
+	}
+}
+
+// since returns the buffered events following the cursor, and the
+// sequence of the last event included. If the cursor cannot be
+// resumed from, resync is true and no events are returned.
+func (st *stream) since(cursor string) (_ []pubsubevents.Event, last uint64, resync bool, _ error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	last = st.next - 1
+	if cursor == "" {
+		return nil, last, false, nil
+	}
+	epoch, seq, err := parseCursor(cursor)
+	if err != nil {
+		return nil, 0, false, errors.Trace(err)
+	}
+	if epoch != st.epoch {
+		// The cursor is from a stream that has since stopped,
+		// possibly on another controller.
+		return nil, last, true, nil
+	}
+	if seq > last {
+		return nil, 0, false, errors.NotValidf("cursor %q", cursor)
+	}
+	oldest := st.next - uint64(len(st.buffer))
+	if seq+1 < oldest {
+		return nil, last, true, nil
+	}
+	backlog := make([]pubsubevents.Event, last-seq)
+	copy(backlog, st.buffer[seq+1-oldest:])
+	return backlog, last, false, nil
+}
+
+func (st *stream) subscribe(cursor string) (*Subscription, error) {
+	sub := &Subscription{
+		stream: st,
+		live:   make(chan pubsubevents.Event),
+		out:    make(chan params.ModelEvent),
+		done:   make(chan struct{}),
+	}
+	// Subscribe before reading the buffer so that no event can fall
+	// between the two; any overlap is dropped by sequence.
+	unsub, err := st.config.Hub.Subscribe(pubsubevents.Topic(st.modelUUID), sub.onEvent)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backlog, last, resync, err := st.since(cursor)
+	if err != nil {
+		unsub()
+		return nil, errors.Trace(err)
+	}
+	sub.unsub = unsub
+	go sub.loop(backlog, last, resync)
+	return sub, nil
+}
+
+// Subscription delivers the events of a model's stream.
+type Subscription struct {
+	stream  *stream
+	live    chan pubsubevents.Event
+	out     chan params.ModelEvent
+	done    chan struct{}
+	unsub   func()
+	release func()
+	once    sync.Once
+}
+
+// Events returns the channel on which events are delivered. The channel
+// is closed if the stream stops, in which case the client should
+// reconnect.
+func (sub *Subscription) Events() <-chan params.ModelEvent {
+	return sub.out
+}
+
+// Close stops delivery of events.
+func (sub *Subscription) Close() {
+	sub.once.Do(func() {
+		close(sub.done)
+		sub.unsub()
+		if sub.release != nil {
+			sub.release()
+		}
+	})
+}
+
+func (sub *Subscription) onEvent(_ string, e pubsubevents.Event, err error) {
+	if err != nil {
+		logger.Errorf("unexpected model event: %v", err)
+		return
+	}
+	select {
+	case sub.live <- e:
+	case <-sub.done:
+	}
+}
+
+func (sub *Subscription) loop(backlog []pubsubevents.Event, last uint64, resync bool) {
+	defer close(sub.out)
+	st := sub.stream
+	if resync {
+		e := params.ModelEvent{
+			Cursor: formatCursor(st.epoch, last),
+			Type:   params.ModelEventResync,
+			Time:   st.config.Clock.Now().UTC(),
+		}
+		if !sub.send(e) {
+			return
+		}
+	}
+	for _, e := range backlog {
+		if !sub.send(toParams(e)) {
+			return
+		}
+	}
+	for {
+		select {
+		case <-sub.done:
+			return
+		case <-st.tomb.Dying():
+			return
+		case e := <-sub.live:
+			if e.Epoch != st.epoch || e.Sequence <= last {
+				continue
+			}
+			last = e.Sequence
+			if !sub.send(toParams(e)) {
+				return
+			}
+		}
+	}
+}
+
+func (sub *Subscription) send(e params.ModelEvent) bool {
+	select {
+	case sub.out <- e:
+		return true
+	case <-sub.done:
+		return false
+	}
+}
+
+func toParams(e pubsubevents.Event) params.ModelEvent {
+	t, err := time.Parse(time.RFC3339Nano, e.Time)
+	if err != nil {
+		logger.Warningf("invalid time %q on %s event: %v", e.Time, e.Type, err)
+	}
+	return params.ModelEvent{
+		Cursor: formatCursor(e.Epoch, e.Sequence),
+		Type:   e.Type,
+		Entity: e.Entity,
+		Time:   t,
+		Data:   e.Data,
+	}
+}
+
+func formatCursor(epoch string, seq uint64) string {
+	return fmt.Sprintf("%s:%d", epoch, seq)
+}
+
+func parseCursor(cursor string) (string, uint64, error) {
+	i := strings.LastIndex(cursor, ":")
+	if i <= 0 {
+		return "", 0, errors.NotValidf("cursor %q", cursor)
+	}
+	seq, err := strconv.ParseUint(cursor[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, errors.NotValidf("cursor %q", cursor)
+	}
+	return cursor[:i], seq, nil
+}
@@ -0,0 +1,418 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type streamSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	watchers []*fakeWatcher
+	config   modelevents.Config
+}
+
+var _ = gc.Suite(&streamSuite{})
+
+func (s *streamSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC))
+	s.watchers = nil
+	s.config = modelevents.Config{
+		Hub:   pubsub.NewStructuredHub(nil),
+		Clock: s.clock,
+		NewWatcher: func(modelUUID string) (modelevents.AllWatcher, func(), error) {
+			c.Check(modelUUID, gc.Equals, coretesting.ModelTag.Id())
+			w := &fakeWatcher{
+				deltas:  make(chan []multiwatcher.Delta),
+				stopped: make(chan struct{}),
+			}
+			s.watchers = append(s.watchers, w)
+			return w, func() {}, nil
+		},
+		BufferSize:  modelevents.DefaultBufferSize,
+		IdleTimeout: time.Minute,
+	}
+}
+
+func (s *streamSuite) newStreams(c *gc.C) *modelevents.Streams {
+	streams, err := modelevents.NewStreams(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { streams.Stop() })
+	return streams
+}
+
+func (s *streamSuite) subscribe(c *gc.C, streams *modelevents.Streams, cursor string) *modelevents.Subscription {
+	sub, err := streams.Subscribe(coretesting.ModelTag.Id(), cursor)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { sub.Close() })
+	return sub
+}
+
+func (s *streamSuite) send(c *gc.C, deltas ...multiwatcher.Delta) {
+	c.Assert(s.watchers, gc.Not(gc.HasLen), 0)
+	w := s.watchers[len(s.watchers)-1]
+	select {
+	case w.deltas <- deltas:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher not read")
+	}
+}
+
+func (s *streamSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*modelevents.Config)
+		err    string
+	}{{
+		func(cfg *modelevents.Config) { cfg.Hub = nil },
+		"nil Hub not valid",
+	}, {
+		func(cfg *modelevents.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *modelevents.Config) { cfg.NewWatcher = nil },
+		"nil NewWatcher not valid",
+	}, {
+		func(cfg *modelevents.Config) { cfg.BufferSize = 0 },
+		"non-positive BufferSize not valid",
+	}, {
+		func(cfg *modelevents.Config) { cfg.IdleTimeout = 0 },
+		"non-positive IdleTimeout not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.change(&config)
+		_, err := modelevents.NewStreams(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *streamSuite) TestUnitStatusAndHooks(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+
+	s.send(c, unitDelta(status.Idle, "", status.Active, "ready"))
+	s.send(c, unitDelta(status.Executing, "running config-changed hook", status.Active, "ready"))
+	s.send(c, unitDelta(status.Idle, "", status.Maintenance, "restarting"))
+
+	assertEvent(c, sub, params.ModelEventUnitStatus, "unit-mysql-0", map[string]string{
+		"workload-status":          "active",
+		"workload-message":         "ready",
+		"agent-status":             "executing",
+		"previous-workload-status": "active",
+		"previous-agent-status":    "idle",
+	})
+	assertEvent(c, sub, params.ModelEventHookStarted, "unit-mysql-0", map[string]string{
+		"hook": "config-changed",
+	})
+	assertEvent(c, sub, params.ModelEventUnitStatus, "unit-mysql-0", map[string]string{
+		"workload-status":          "maintenance",
+		"workload-message":         "restarting",
+		"agent-status":             "idle",
+		"previous-workload-status": "active",
+		"previous-agent-status":    "executing",
+	})
+	assertEvent(c, sub, params.ModelEventHookFinished, "unit-mysql-0", map[string]string{
+		"hook":         "config-changed",
+		"agent-status": "idle",
+	})
+	assertNoEvent(c, sub)
+}
+
+func (s *streamSuite) TestMachineProvisioning(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+
+	s.send(c)
+	s.send(c, machineDelta("", status.Pending, ""))
+	s.send(c, machineDelta("i-1", status.Running, ""))
+
+	assertEvent(c, sub, params.ModelEventMachineAdded, "machine-0", map[string]string{
+		"series": "bionic",
+	})
+	assertEvent(c, sub, params.ModelEventMachineProvisioned, "machine-0", map[string]string{
+		"instance-id": "i-1",
+	})
+	assertEvent(c, sub, params.ModelEventMachineStatus, "machine-0", map[string]string{
+		"agent-status":             "",
+		"instance-status":          "running",
+		"previous-agent-status":    "",
+		"previous-instance-status": "pending",
+	})
+	assertNoEvent(c, sub)
+}
+
+func (s *streamSuite) TestMachineProvisioningFailed(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+
+	s.send(c, machineDelta("", status.Pending, ""))
+	s.send(c, multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:             "0",
+		InstanceStatus: multiwatcher.StatusInfo{Current: status.ProvisioningError, Message: "no capacity"},
+	}})
+	assertEvent(c, sub, params.ModelEventMachineProvisioningFailed, "machine-0", map[string]string{
+		"message": "no capacity",
+	})
+	assertEvent(c, sub, params.ModelEventMachineStatus, "machine-0", nil)
+
+	// Retrying and failing again is not another failure to provision.
+	s.send(c, multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:             "0",
+		InstanceStatus: multiwatcher.StatusInfo{Current: status.ProvisioningError, Message: "still no capacity"},
+	}})
+	assertNoEvent(c, sub)
+}
+
+func (s *streamSuite) TestActionsRelationsAndConfig(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+
+	const actionId = "11234567-89ab-cdef-0123-456789abcdef"
+	relation := &multiwatcher.RelationInfo{
+		Key: "wordpress:db mysql:server",
+		Endpoints: []multiwatcher.Endpoint{
+			{ApplicationName: "wordpress"},
+			{ApplicationName: "mysql"},
+		},
+	}
+	s.send(c,
+		multiwatcher.Delta{Entity: &multiwatcher.ModelInfo{
+			ModelUUID: coretesting.ModelTag.Id(),
+			Config:    map[string]interface{}{"logging-config": "<root>=INFO"},
+		}},
+		multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{
+			Name:   "mysql",
+			Config: map[string]interface{}{"dataset-size": "80%"},
+		}},
+	)
+	s.send(c,
+		multiwatcher.Delta{Entity: relation},
+		multiwatcher.Delta{Entity: &multiwatcher.ActionInfo{
+			Id: actionId, Name: "backup", Receiver: "mysql/0", Status: "pending",
+		}},
+		multiwatcher.Delta{Entity: &multiwatcher.ActionInfo{
+			Id: actionId, Name: "backup", Receiver: "mysql/0", Status: "completed", Message: "done",
+		}},
+		multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{
+			Name:   "mysql",
+			Config: map[string]interface{}{"dataset-size": "50%", "tuning-level": "fast"},
+		}},
+		multiwatcher.Delta{Entity: &multiwatcher.ModelInfo{
+			ModelUUID: coretesting.ModelTag.Id(),
+		}},
+		multiwatcher.Delta{Entity: relation, Removed: true},
+	)
+
+	assertEvent(c, sub, params.ModelEventRelationJoined, "relation-wordpress.db#mysql.server", map[string]string{
+		"key":          "wordpress:db mysql:server",
+		"applications": "mysql,wordpress",
+	})
+	assertEvent(c, sub, "action-pending", "action-"+actionId, map[string]string{
+		"name":     "backup",
+		"receiver": "mysql/0",
+	})
+	assertEvent(c, sub, "action-completed", "action-"+actionId, map[string]string{
+		"name":     "backup",
+		"receiver": "mysql/0",
+		"message":  "done",
+	})
+	assertEvent(c, sub, params.ModelEventConfigChanged, "application-mysql", map[string]string{
+		"keys": "dataset-size,tuning-level",
+	})
+	assertEvent(c, sub, params.ModelEventConfigChanged, coretesting.ModelTag.String(), map[string]string{
+		"keys": "logging-config",
+	})
+	assertEvent(c, sub, params.ModelEventRelationDeparted, "relation-wordpress.db#mysql.server", map[string]string{
+		"key":          "wordpress:db mysql:server",
+		"applications": "mysql,wordpress",
+	})
+	assertNoEvent(c, sub)
+}
+
+func (s *streamSuite) TestResumeFromCursor(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+
+	s.send(c)
+	s.send(c, machineDelta("", status.Pending, ""))
+	s.send(c, machineDelta("i-1", status.Pending, ""))
+	first := assertEvent(c, sub, params.ModelEventMachineAdded, "machine-0", nil)
+	assertEvent(c, sub, params.ModelEventMachineProvisioned, "machine-0", nil)
+	sub.Close()
+
+	// Events published while disconnected are delivered on resuming,
+	// followed by new ones.
+	s.send(c, machineDelta("i-1", status.Running, ""))
+	sub = s.subscribe(c, streams, first.Cursor)
+	second := assertEvent(c, sub, params.ModelEventMachineProvisioned, "machine-0", nil)
+	assertEvent(c, sub, params.ModelEventMachineStatus, "machine-0", nil)
+	s.send(c, machineDelta("i-1", status.Running, status.Error))
+	assertEvent(c, sub, params.ModelEventMachineStatus, "machine-0", nil)
+	assertNoEvent(c, sub)
+
+	c.Assert(second.Cursor, gc.Not(gc.Equals), first.Cursor)
+	c.Assert(s.watchers, gc.HasLen, 1)
+}
+
+func (s *streamSuite) TestResyncWhenCursorExpired(c *gc.C) {
+	s.config.BufferSize = 1
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+
+	s.send(c)
+	s.send(c, machineDelta("", status.Pending, ""))
+	first := assertEvent(c, sub, params.ModelEventMachineAdded, "machine-0", nil)
+	s.send(c, machineDelta("i-1", status.Pending, ""))
+	s.send(c, machineDelta("i-1", status.Running, ""))
+	assertEvent(c, sub, params.ModelEventMachineProvisioned, "machine-0", nil)
+	last := assertEvent(c, sub, params.ModelEventMachineStatus, "machine-0", nil)
+
+	resumed := s.subscribe(c, streams, first.Cursor)
+	resync := assertEvent(c, resumed, params.ModelEventResync, "", nil)
+	c.Assert(resync.Cursor, gc.Equals, last.Cursor)
+	c.Assert(resync.Time, gc.Equals, s.clock.Now())
+	assertNoEvent(c, resumed)
+}
+
+func (s *streamSuite) TestResyncForUnknownEpoch(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "gone:42")
+	assertEvent(c, sub, params.ModelEventResync, "", nil)
+}
+
+func (s *streamSuite) TestInvalidCursor(c *gc.C) {
+	streams := s.newStreams(c)
+	for _, cursor := range []string{"bad", ":1", "epoch:x"} {
+		_, err := streams.Subscribe(coretesting.ModelTag.Id(), cursor)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *streamSuite) TestIdleStreamStopped(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+	s.send(c)
+	sub.Close()
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.watchers[0].stopped:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher not stopped")
+	}
+
+	// A new subscription starts a new stream.
+	sub = s.subscribe(c, streams, "")
+	c.Assert(s.watchers, gc.HasLen, 2)
+	s.send(c)
+	s.send(c, machineDelta("", status.Pending, ""))
+	assertEvent(c, sub, params.ModelEventMachineAdded, "machine-0", nil)
+}
+
+func (s *streamSuite) TestReconnectBeforeIdleTimeout(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+	s.send(c)
+	sub.Close()
+
+	sub = s.subscribe(c, streams, "")
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.send(c, machineDelta("", status.Pending, ""))
+	assertEvent(c, sub, params.ModelEventMachineAdded, "machine-0", nil)
+	c.Assert(s.watchers, gc.HasLen, 1)
+}
+
+func (s *streamSuite) TestStop(c *gc.C) {
+	streams := s.newStreams(c)
+	sub := s.subscribe(c, streams, "")
+	streams.Stop()
+
+	select {
+	case _, ok := <-sub.Events():
+		c.Assert(ok, jc.IsFalse)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("subscription not stopped")
+	}
+	_, err := streams.Subscribe(coretesting.ModelTag.Id(), "")
+	c.Assert(err, gc.ErrorMatches, "model event streams stopped")
+}
+
+func assertEvent(c *gc.C, sub *modelevents.Subscription, eventType, entity string, data map[string]string) params.ModelEvent {
+	select {
+	case e, ok := <-sub.Events():
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(e.Type, gc.Equals, eventType)
+		c.Assert(e.Entity, gc.Equals, entity)
+		if data != nil {
+			c.Assert(e.Data, jc.DeepEquals, data)
+		}
+		return e
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("no %s event", eventType)
+	}
+	panic("unreachable")
+}
+
+func assertNoEvent(c *gc.C, sub *modelevents.Subscription) {
+	select {
+	case e := <-sub.Events():
+		c.Fatalf("unexpected event %#v", e)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func unitDelta(agent status.Status, agentMessage string, workload status.Status, workloadMessage string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           "mysql/0",
+		Application:    "mysql",
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent, Message: agentMessage},
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: workloadMessage},
+	}}
+}
+
+func machineDelta(instanceId string, instance, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:             "0",
+		InstanceId:     instanceId,
+		Series:         "bionic",
+		InstanceStatus: multiwatcher.StatusInfo{Current: instance},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+type fakeWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+}
+
+func (w *fakeWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas := <-w.deltas:
+		return deltas, nil
+	case <-w.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (w *fakeWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
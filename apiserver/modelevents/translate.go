@@ -0,0 +1,304 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+// event is a typed event derived from the multiwatcher deltas, before
+// it has been given a position in a stream.
+type event struct {
+	Type   string
+	Entity string
+	Data   map[string]string
+}
+
+// translator remembers the last seen info for each entity of interest
+// so that changes in the multiwatcher deltas can be turned into typed
+// events.
+type translator struct {
+	model        *multiwatcher.ModelInfo
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+	actions      map[string]*multiwatcher.ActionInfo
+	relations    map[string]*multiwatcher.RelationInfo
+}
+
+func newTranslator() *translator {
+	return &translator{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+		actions:      make(map[string]*multiwatcher.ActionInfo),
+		relations:    make(map[string]*multiwatcher.RelationInfo),
+	}
+}
+
+// seed records the initial state of the model, as returned by the first
+// call to the multiwatcher's Next, without producing any events.
+func (t *translator) seed(deltas []multiwatcher.Delta) {
+	t.translate(deltas)
+}
+
+// translate records the changes in the deltas and returns the events
+// they represent, in the order the deltas were received.
+func (t *translator) translate(deltas []multiwatcher.Delta) []event {
+	var events []event
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if !delta.Removed {
+				events = append(events, t.modelChanged(info)...)
+			}
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(t.applications, info.Name)
+				continue
+			}
+			events = append(events, t.applicationChanged(info)...)
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(t.units, info.Name)
+				continue
+			}
+			events = append(events, t.unitChanged(info)...)
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(t.machines, info.Id)
+				continue
+			}
+			events = append(events, t.machineChanged(info)...)
+		case *multiwatcher.ActionInfo:
+			if delta.Removed {
+				delete(t.actions, info.Id)
+				continue
+			}
+			events = append(events, t.actionChanged(info)...)
+		case *multiwatcher.RelationInfo:
+			events = append(events, t.relationChanged(info, delta.Removed)...)
+		}
+	}
+	return events
+}
+
+func (t *translator) modelChanged(info *multiwatcher.ModelInfo) []event {
+	prev := t.model
+	t.model = info
+	if prev == nil {
+		return nil
+	}
+	return configChanged(names.NewModelTag(info.ModelUUID), prev.Config, info.Config)
+}
+
+func (t *translator) applicationChanged(info *multiwatcher.ApplicationInfo) []event {
+	prev, ok := t.applications[info.Name]
+	t.applications[info.Name] = info
+	if !ok {
+		return nil
+	}
+	return configChanged(names.NewApplicationTag(info.Name), prev.Config, info.Config)
+}
+
+func (t *translator) unitChanged(info *multiwatcher.UnitInfo) []event {
+	prev := t.units[info.Name]
+	t.units[info.Name] = info
+	tag := names.NewUnitTag(info.Name).String()
+
+	var events []event
+	var prevWorkload, prevAgent multiwatcher.StatusInfo
+	if prev != nil {
+		prevWorkload, prevAgent = prev.WorkloadStatus, prev.AgentStatus
+	}
+	if prev == nil ||
+		prevWorkload.Current != info.WorkloadStatus.Current ||
+		prevWorkload.Message != info.WorkloadStatus.Message ||
+		prevAgent.Current != info.AgentStatus.Current {
+		data := map[string]string{
+			"workload-status":  string(info.WorkloadStatus.Current),
+			"workload-message": info.WorkloadStatus.Message,
+			"agent-status":     string(info.AgentStatus.Current),
+		}
+		if prev != nil {
+			data["previous-workload-status"] = string(prevWorkload.Current)
+			data["previous-agent-status"] = string(prevAgent.Current)
+		}
+		events = append(events, event{
+			Type:   params.ModelEventUnitStatus,
+			Entity: tag,
+			Data:   data,
+		})
+	}
+
+	// The same hook may run twice in a row, in which case only the
+	// time the agent status was set tells the runs apart.
+	prevHook, hook := runningHook(prevAgent), runningHook(info.AgentStatus)
+	rerun := prevHook == hook && !sameTime(prevAgent.Since, info.AgentStatus.Since)
+	if prevHook != "" && (prevHook != hook || rerun) {
+		events = append(events, event{
+			Type:   params.ModelEventHookFinished,
+			Entity: tag,
+			Data: map[string]string{
+				"hook":         prevHook,
+				"agent-status": string(info.AgentStatus.Current),
+			},
+		})
+	}
+	if hook != "" && (hook != prevHook || rerun) {
+		events = append(events, event{
+			Type:   params.ModelEventHookStarted,
+			Entity: tag,
+			Data:   map[string]string{"hook": hook},
+		})
+	}
+	return events
+}
+
+// runningHook returns the name of the hook the unit agent is running,
+// or "" if it is not running a hook.
+func runningHook(agent multiwatcher.StatusInfo) string {
+	if agent.Current != status.Executing {
+		return ""
+	}
+	if !strings.HasPrefix(agent.Message, "running ") || !strings.HasSuffix(agent.Message, " hook") {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(agent.Message, "running "), " hook")
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func (t *translator) machineChanged(info *multiwatcher.MachineInfo) []event {
+	prev := t.machines[info.Id]
+	t.machines[info.Id] = info
+	tag := names.NewMachineTag(info.Id).String()
+
+	if prev == nil {
+		return []event{{
+			Type:   params.ModelEventMachineAdded,
+			Entity: tag,
+			Data:   map[string]string{"series": info.Series},
+		}}
+	}
+
+	var events []event
+	if prev.InstanceId == "" && info.InstanceId != "" {
+		events = append(events, event{
+			Type:   params.ModelEventMachineProvisioned,
+			Entity: tag,
+			Data:   map[string]string{"instance-id": info.InstanceId},
+		})
+	}
+	if prev.InstanceStatus.Current != status.ProvisioningError && info.InstanceStatus.Current == status.ProvisioningError {
+		events = append(events, event{
+			Type:   params.ModelEventMachineProvisioningFailed,
+			Entity: tag,
+			Data:   map[string]string{"message": info.InstanceStatus.Message},
+		})
+	}
+	if prev.AgentStatus.Current != info.AgentStatus.Current ||
+		prev.InstanceStatus.Current != info.InstanceStatus.Current {
+		events = append(events, event{
+			Type:   params.ModelEventMachineStatus,
+			Entity: tag,
+			Data: map[string]string{
+				"agent-status":             string(info.AgentStatus.Current),
+				"instance-status":          string(info.InstanceStatus.Current),
+				"previous-agent-status":    string(prev.AgentStatus.Current),
+				"previous-instance-status": string(prev.InstanceStatus.Current),
+			},
+		})
+	}
+	return events
+}
+
+func (t *translator) actionChanged(info *multiwatcher.ActionInfo) []event {
+	prev := t.actions[info.Id]
+	t.actions[info.Id] = info
+	if prev != nil && prev.Status == info.Status {
+		return nil
+	}
+	data := map[string]string{
+		"name":     info.Name,
+		"receiver": info.Receiver,
+	}
+	if info.Message != "" {
+		data["message"] = info.Message
+	}
+	return []event{{
+		Type:   params.ModelEventActionPrefix + info.Status,
+		Entity: names.NewActionTag(info.Id).String(),
+		Data:   data,
+	}}
+}
+
+func (t *translator) relationChanged(info *multiwatcher.RelationInfo, removed bool) []event {
+	_, known := t.relations[info.Key]
+	eventType := params.ModelEventRelationJoined
+	if removed {
+		delete(t.relations, info.Key)
+		eventType = params.ModelEventRelationDeparted
+	} else {
+		t.relations[info.Key] = info
+	}
+	if known != removed {
+		// Either an update to a relation we already know about or
+		// the removal of one we never saw; neither is interesting.
+		return nil
+	}
+	applications := make([]string, len(info.Endpoints))
+	for i, ep := range info.Endpoints {
+		applications[i] = ep.ApplicationName
+	}
+	sort.Strings(applications)
+	data := map[string]string{
+		"key":          info.Key,
+		"applications": strings.Join(applications, ","),
+	}
+	return []event{{
+		Type:   eventType,
+		Entity: names.NewRelationTag(info.Key).String(),
+		Data:   data,
+	}}
+}
+
+// configChanged returns a config-changed event for the entity if the
+// old and new config differ.
+func configChanged(tag names.Tag, old, new map[string]interface{}) []event {
+	var changed []string
+	for k, v := range new {
+		if ov, ok := old[k]; !ok || !reflect.DeepEqual(ov, v) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	sort.Strings(changed)
+	return []event{{
+		Type:   params.ModelEventConfigChanged,
+		Entity: tag.String(),
+		Data:   map[string]string{"keys": strings.Join(changed, ",")},
+	}}
+}
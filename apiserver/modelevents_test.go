@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket/websockettest"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelEventsSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&modelEventsSuite{})
+
+func (s *modelEventsSuite) TestNoAuth(c *gc.C) {
+	conn := s.dialWebsocketInternal(c, nil, nil)
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, "no credentials provided")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *modelEventsSuite) TestUnitLoginsRejected(c *gc.C) {
+	u, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	header := utils.BasicAuthHeader(u.Tag().String(), password)
+	conn := s.dialWebsocketInternal(c, nil, header)
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, "tag kind unit not valid")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *modelEventsSuite) TestInvalidCursor(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"cursor": {"bad"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `cursor "bad" not valid`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *modelEventsSuite) TestStreamAndResume(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	query := url.Values{"type": {params.ModelEventUnitStatus}}
+
+	conn := s.dialWebsocket(c, query)
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+	events := readModelEvents(conn)
+
+	// The stream only reports changes made after it has read the
+	// state of the model, so keep changing the unit until it does.
+	var first params.ModelEvent
+	for attempt := 0; first.Cursor == ""; attempt++ {
+		c.Assert(attempt < 100, jc.IsTrue, gc.Commentf("no unit status event"))
+		s.setWorkloadStatus(c, unit, fmt.Sprintf("attempt %d", attempt))
+		select {
+		case first = <-events:
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+	c.Assert(first.Type, gc.Equals, params.ModelEventUnitStatus)
+	c.Assert(first.Entity, gc.Equals, unit.Tag().String())
+	c.Assert(conn.Close(), jc.ErrorIsNil)
+	s.drain(c, events)
+
+	// Changes made while disconnected are sent on resuming from the
+	// last event seen.
+	s.setWorkloadStatus(c, unit, "while disconnected")
+	query.Set("cursor", first.Cursor)
+	conn = s.dialWebsocket(c, query)
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+	events = readModelEvents(conn)
+
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case e := <-events:
+			c.Assert(e.Type, gc.Equals, params.ModelEventUnitStatus)
+			c.Assert(e.Cursor, gc.Not(gc.Equals), first.Cursor)
+			if e.Data["workload-message"] == "while disconnected" {
+				return
+			}
+		case <-timeout:
+			c.Fatalf("no event for change while disconnected")
+		}
+	}
+}
+
+func (s *modelEventsSuite) setWorkloadStatus(c *gc.C, unit *state.Unit, message string) {
+	now := time.Now()
+	err := unit.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: message,
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+}
+
+func (s *modelEventsSuite) drain(c *gc.C, events <-chan params.ModelEvent) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			c.Fatalf("events not closed")
+		}
+	}
+}
+
+// readModelEvents reads events from the websocket until it is closed.
+func readModelEvents(conn *websocket.Conn) <-chan params.ModelEvent {
+	events := make(chan params.ModelEvent, 100)
+	go func() {
+		defer close(events)
+		for {
+			var e params.ModelEvent
+			if err := conn.ReadJSON(&e); err != nil {
+				return
+			}
+			events <- e
+		}
+	}()
+	return events
+}
+
+func (s *modelEventsSuite) dialWebsocket(c *gc.C, queryParams url.Values) *websocket.Conn {
+	header := utils.BasicAuthHeader(s.userTag.String(), s.password)
+	return s.dialWebsocketInternal(c, queryParams, header)
+}
+
+func (s *modelEventsSuite) dialWebsocketInternal(c *gc.C, queryParams url.Values, header http.Header) *websocket.Conn {
+	server := s.makeURL(c, "wss", "/events", queryParams).String()
+	return dialWebsocketFromURL(c, server, header)
+}
@@ -105,6 +105,71 @@ func (l *bufferedLog) flush() error {
 	return nil
 }
 
+// methodLog forwards only the requests to some methods, their
+// responses, and the conversation they're part of to its destination
+// audit log.
+type methodLog struct {
+	mu           sync.Mutex
+	dest         auditlog.AuditLog
+	methods      set.Strings
+	conversation *auditlog.Conversation
+	requestIDs   map[uint64]bool
+}
+
+// NewAuditLogMethodFilter returns an auditlog.AuditLog that will only
+// log requests to the methods passed in (as facade.method, e.g.
+// "Uniter.CloudSpec") and their responses to the underlying log. The
+// conversation is logged before the first such request, and not at
+// all if there are none.
+func NewAuditLogMethodFilter(log auditlog.AuditLog, methods set.Strings) auditlog.AuditLog {
+	return &methodLog{
+		dest:       log,
+		methods:    methods,
+		requestIDs: make(map[uint64]bool),
+	}
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (l *methodLog) AddConversation(c auditlog.Conversation) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.conversation = &c
+	return nil
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (l *methodLog) AddRequest(r auditlog.Request) error {
+	if !l.methods.Contains(fmt.Sprintf("%s.%s", r.Facade, r.Method)) {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conversation != nil {
+		if err := l.dest.AddConversation(*l.conversation); err != nil {
+			return errors.Trace(err)
+		}
+		l.conversation = nil
+	}
+	l.requestIDs[r.RequestID] = true
+	return errors.Trace(l.dest.AddRequest(r))
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (l *methodLog) AddResponse(r auditlog.ResponseErrors) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.requestIDs[r.RequestID] {
+		return nil
+	}
+	delete(l.requestIDs, r.RequestID)
+	return errors.Trace(l.dest.AddResponse(r))
+}
+
+// Close implements auditlog.AuditLog.
+func (l *methodLog) Close() error {
+	return errors.Trace(l.dest.Close())
+}
+
 // MakeInterestingRequestFilter takes a set of method names (as
 // facade.method, e.g. "Client.FullStatus") that aren't very
 // interesting from an auditing perspective, and returns a filter
@@ -75,6 +75,42 @@ func (s *auditFilterSuite) TestFiltersUninterestingConversations(c *gc.C) {
 	target.CheckCallNames(c, "AddRequest", "AddResponse")
 }
 
+func (s *auditFilterSuite) TestMethodFilter(c *gc.C) {
+	target := &apitesting.FakeAuditLog{}
+	log := observer.NewAuditLogMethodFilter(target, set.NewStrings("Uniter.CloudSpec"))
+
+	err := log.AddConversation(auditlog.Conversation{Who: "unit-mysql-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(auditlog.Request{Facade: "Uniter", Method: "Life", RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddResponse(auditlog.ResponseErrors{RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	// Nothing written out yet.
+	target.CheckCallNames(c)
+
+	err = log.AddRequest(auditlog.Request{Facade: "Uniter", Method: "CloudSpec", RequestID: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(auditlog.Request{Facade: "Uniter", Method: "Life", RequestID: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddResponse(auditlog.ResponseErrors{RequestID: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddResponse(auditlog.ResponseErrors{RequestID: 2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the conversation and the matching request and
+	// response are written, and other requests still aren't.
+	target.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse")
+	target.CheckCall(c, 0, "AddConversation", auditlog.Conversation{Who: "unit-mysql-0"})
+	target.CheckCall(c, 1, "AddRequest", auditlog.Request{Facade: "Uniter", Method: "CloudSpec", RequestID: 2})
+	target.CheckCall(c, 2, "AddResponse", auditlog.ResponseErrors{RequestID: 2})
+
+	// The conversation is only written once.
+	target.ResetCalls()
+	err = log.AddRequest(auditlog.Request{Facade: "Uniter", Method: "CloudSpec", RequestID: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	target.CheckCallNames(c, "AddRequest")
+}
+
 func (s *auditFilterSuite) TestMakeFilter(c *gc.C) {
 	f1 := observer.MakeInterestingRequestFilter(set.NewStrings("Battery.Kinzie", "Helplessness.Blues"))
 	c.Assert(f1(auditlog.Request{Facade: "Battery", Method: "Kinzie"}), jc.IsFalse)
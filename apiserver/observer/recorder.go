@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/rpc"
 )
 
@@ -72,6 +73,14 @@ func (cr *combinedRecorder) HandleRequest(hdr *rpc.Header, body interface{}) err
 	}))
 }
 
+// SpanContext implements rpc.SpanRecorder.
+func (cr *combinedRecorder) SpanContext() tracing.SpanContext {
+	if spanRecorder, ok := cr.observer.(rpc.SpanRecorder); ok {
+		return spanRecorder.SpanContext()
+	}
+	return tracing.SpanContext{}
+}
+
 // HandleReply implements rpc.Recorder.
 func (cr *combinedRecorder) HandleReply(req rpc.Request, replyHdr *rpc.Header, body interface{}) error {
 	cr.observer.ServerReply(req, replyHdr, body)
@@ -4,12 +4,14 @@ package observer
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 )
@@ -142,7 +144,8 @@ func (n *rpcObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
 	if n.logger.IsTraceEnabled() {
 		n.logRequestTrace(n.logger, hdr, body)
 	} else {
-		n.logger.Debugf("<- [%X] %s %s", n.id, n.tag, jsoncodec.DumpRequest(hdr, "'params redacted'"))
+		logfields.Logf(n.logger, loggo.DEBUG, requestFields(hdr),
+			"<- [%X] %s %s", n.id, n.tag, jsoncodec.DumpRequest(hdr, "'params redacted'"))
 	}
 }
 
@@ -159,7 +162,7 @@ func (n *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interfa
 	if n.logger.IsTraceEnabled() {
 		n.logReplyTrace(n.logger, hdr, body)
 	} else {
-		n.logger.Debugf(
+		logfields.Logf(n.logger, loggo.DEBUG, requestFields(hdr),
 			"-> [%X] %s %s %s %s[%q].%s",
 			n.id,
 			n.tag,
@@ -172,6 +175,14 @@ func (n *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interfa
 	}
 }
 
+// requestFields returns the log fields identifying the request with
+// the given header.
+func requestFields(hdr *rpc.Header) logfields.Data {
+	return logfields.Data{Fields: map[string]string{
+		logfields.RequestID: strconv.FormatUint(hdr.RequestId, 10),
+	}}
+}
+
 func (n *rpcObserver) logRequestTrace(logger loggo.Logger, hdr *rpc.Header, body interface{}) {
 	n.logTrace(logger, "<-", hdr, body)
 }
@@ -0,0 +1,7 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracingobserver provides an implementation
+// of apiserver/observer.ObserverFactory that records
+// a trace span for each API request.
+package tracingobserver
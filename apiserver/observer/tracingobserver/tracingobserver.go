@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracingobserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/rpc"
+)
+
+// Config contains the configuration for an Observer.
+type Config struct {
+	// Tracer is used to record a span for each request.
+	Tracer *tracing.Tracer
+}
+
+// Validate validates the observer factory configuration.
+func (cfg Config) Validate() error {
+	if cfg.Tracer == nil {
+		return errors.NotValidf("nil Tracer")
+	}
+	return nil
+}
+
+// NewObserverFactory returns a function that, when called, returns a new
+// Observer. Each API connection gets its own Observer, so that spans can
+// be tagged with the connection and the entity logged in on it.
+func NewObserverFactory(config Config) (observer.ObserverFactory, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	return func() observer.Observer {
+		return &Observer{tracer: config.Tracer}
+	}, nil
+}
+
+// Observer is an API server request observer that records a trace span
+// for each request made on a connection. If the client sent the context
+// of its own span with the request, the server's span is recorded as
+// its child.
+type Observer struct {
+	tracer *tracing.Tracer
+
+	mu           sync.Mutex
+	connectionID string
+	entity       string
+	modelUUID    string
+}
+
+// Login is part of the observer.Observer interface.
+func (o *Observer) Login(entity names.Tag, model names.ModelTag, _ bool, _ string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entity = entity.String()
+	o.modelUUID = model.Id()
+}
+
+// Join is part of the observer.Observer interface.
+func (o *Observer) Join(req *http.Request, connectionID uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// Use %X to match the connection IDs in the logs.
+	o.connectionID = fmt.Sprintf("%X", connectionID)
+}
+
+// Leave is part of the observer.Observer interface.
+func (*Observer) Leave() {}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *Observer) RPCObserver() rpc.Observer {
+	return &rpcObserver{conn: o}
+}
+
+type rpcObserver struct {
+	conn *Observer
+	span *tracing.Span
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	req := hdr.Request
+	parent := tracing.SpanContext{
+		TraceID: hdr.TraceID,
+		SpanID:  hdr.SpanID,
+	}
+	o.span = o.conn.tracer.StartSpan(req.Type+"."+req.Action, tracing.KindServer, parent)
+	o.span.SetTag("facade", req.Type)
+	o.span.SetTag("version", strconv.Itoa(req.Version))
+	o.span.SetTag("method", req.Action)
+	o.span.SetTag("request-id", strconv.FormatUint(hdr.RequestId, 10))
+
+	o.conn.mu.Lock()
+	defer o.conn.mu.Unlock()
+	o.span.SetTag("connection-id", o.conn.connectionID)
+	o.span.SetTag("entity", o.conn.entity)
+	o.span.SetTag("model-uuid", o.conn.modelUUID)
+}
+
+// SpanContext is part of the rpc.SpanRecorder interface.
+func (o *rpcObserver) SpanContext() tracing.SpanContext {
+	return o.span.Context()
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	var err error
+	if hdr.Error != "" {
+		err = errors.New(hdr.Error)
+	}
+	o.span.SetTag("error-code", hdr.ErrorCode)
+	o.span.Finish(err)
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracingobserver_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/observer/tracingobserver"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
+	"github.com/juju/juju/rpc"
+)
+
+type observerSuite struct {
+	testing.IsolationSuite
+	clock     *testing.Clock
+	collector *tracingtest.Collector
+	factory   observer.ObserverFactory
+}
+
+var _ = gc.Suite(&observerSuite{})
+
+func (s *observerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.collector = &tracingtest.Collector{}
+	tracer := tracing.NewTracer("machine-0", s.clock)
+	tracer.SetExporter(s.collector)
+
+	var err error
+	s.factory, err = tracingobserver.NewObserverFactory(tracingobserver.Config{
+		Tracer: tracer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *observerSuite) TestConfigValidate(c *gc.C) {
+	_, err := tracingobserver.NewObserverFactory(tracingobserver.Config{})
+	c.Assert(err, gc.ErrorMatches, "validating config: nil Tracer not valid")
+}
+
+func (s *observerSuite) TestRPCObserver(c *gc.C) {
+	o := s.factory()
+	o.Join(&http.Request{}, 31)
+	o.Login(names.NewUserTag("bob"), names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"), false, "")
+
+	req := rpc.Request{
+		Type:    "Client",
+		Version: 1,
+		Action:  "FullStatus",
+	}
+	rpcObserver := o.RPCObserver()
+	rpcObserver.ServerRequest(&rpc.Header{
+		RequestId: 3,
+		Request:   req,
+		TraceID:   "0123456789abcdef0123456789abcdef",
+		SpanID:    "0123456789abcdef",
+	}, nil)
+	s.clock.Advance(time.Second)
+	rpcObserver.ServerReply(req, &rpc.Header{
+		RequestId: 3,
+		Error:     "boom",
+		ErrorCode: "bad",
+	}, nil)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].TraceID, gc.Equals, "0123456789abcdef0123456789abcdef")
+	c.Check(spans[0].ParentID, gc.Equals, "0123456789abcdef")
+	c.Check(spans[0].Name, gc.Equals, "Client.FullStatus")
+	c.Check(spans[0].Kind, gc.Equals, tracing.KindServer)
+	c.Check(spans[0].Duration, gc.Equals, int64(time.Second/time.Microsecond))
+	c.Check(spans[0].Tags, jc.DeepEquals, map[string]string{
+		"facade":        "Client",
+		"version":       "1",
+		"method":        "FullStatus",
+		"request-id":    "3",
+		"connection-id": "1F",
+		"entity":        "user-bob",
+		"model-uuid":    "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"error":         "boom",
+		"error-code":    "bad",
+	})
+}
+
+func (s *observerSuite) TestRPCObserverNewTrace(c *gc.C) {
+	o := s.factory().RPCObserver()
+	req := rpc.Request{Type: "Admin", Version: 3, Action: "Login"}
+	o.ServerRequest(&rpc.Header{RequestId: 1, Request: req}, nil)
+	spanContext := o.(rpc.SpanRecorder).SpanContext()
+	o.ServerReply(req, &rpc.Header{RequestId: 1}, nil)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].TraceID, gc.Not(gc.Equals), "")
+	c.Check(spans[0].ParentID, gc.Equals, "")
+
+	// The span is the parent of those recorded while serving the request.
+	c.Check(spanContext, jc.DeepEquals, tracing.SpanContext{
+		TraceID: spans[0].TraceID,
+		SpanID:  spans[0].ID,
+	})
+	c.Check(spans[0].Tags, jc.DeepEquals, map[string]string{
+		"facade":     "Admin",
+		"version":    "3",
+		"method":     "Login",
+		"request-id": "1",
+	})
+}
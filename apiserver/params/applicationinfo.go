@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"github.com/juju/juju/constraints"
+)
+
+// ApplicationInfo holds the details of an application, as shown by
+// "juju show-application".
+type ApplicationInfo struct {
+	Tag              string            `json:"tag"`
+	Charm            string            `json:"charm,omitempty"`
+	Series           string            `json:"series,omitempty"`
+	Channel          string            `json:"channel,omitempty"`
+	Constraints      constraints.Value `json:"constraints,omitempty"`
+	Principal        bool              `json:"principal"`
+	Exposed          bool              `json:"exposed"`
+	Remote           bool              `json:"remote"`
+	EndpointBindings map[string]string `json:"endpoint-bindings,omitempty"`
+
+	// Subordinates holds the names of the subordinate applications
+	// related to a principal application.
+	Subordinates []string `json:"subordinates,omitempty"`
+
+	// Principals holds the names of the principal applications
+	// related to a subordinate application.
+	Principals []string `json:"principals,omitempty"`
+
+	// OfferURL and SourceModelTag are only set for remote
+	// applications consumed from an offer.
+	OfferURL       string `json:"offer-url,omitempty"`
+	SourceModelTag string `json:"source-model-tag,omitempty"`
+}
+
+// ApplicationInfoResult holds the details of an application, or an
+// error.
+type ApplicationInfoResult struct {
+	Result *ApplicationInfo `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// ApplicationInfoResults holds the results of an ApplicationsInfo call.
+type ApplicationInfoResults struct {
+	Results []ApplicationInfoResult `json:"results"`
+}
+
+// UnitInfo holds the details of a unit, as shown by "juju show-unit".
+type UnitInfo struct {
+	Tag             string       `json:"tag"`
+	Life            string       `json:"life"`
+	Charm           string       `json:"charm,omitempty"`
+	WorkloadVersion string       `json:"workload-version,omitempty"`
+	WorkloadStatus  EntityStatus `json:"workload-status"`
+	AgentStatus     EntityStatus `json:"agent-status"`
+	Leader          bool         `json:"leader"`
+	Machine         string       `json:"machine,omitempty"`
+	PublicAddress   string       `json:"public-address,omitempty"`
+	PrivateAddress  string       `json:"private-address,omitempty"`
+	OpenedPorts     []string     `json:"opened-ports,omitempty"`
+	Principal       string       `json:"principal,omitempty"`
+
+	// RelationData holds the settings of the unit, and of the
+	// related units, for each relation the unit is in scope of.
+	RelationData []EndpointRelationData `json:"relation-data,omitempty"`
+}
+
+// EndpointRelationData holds the settings of a unit and of the units
+// related to it in a single relation.
+type EndpointRelationData struct {
+	RelationId      int                               `json:"relation-id"`
+	Endpoint        string                            `json:"endpoint"`
+	RelatedEndpoint string                            `json:"related-endpoint"`
+	UnitData        map[string]interface{}            `json:"unit-data,omitempty"`
+	RelatedUnits    map[string]map[string]interface{} `json:"related-units,omitempty"`
+}
+
+// UnitInfoResult holds the details of a unit, or an error.
+type UnitInfoResult struct {
+	Result *UnitInfo `json:"result,omitempty"`
+	Error  *Error    `json:"error,omitempty"`
+}
+
+// UnitInfoResults holds the results of a UnitsInfo call.
+type UnitInfoResults struct {
+	Results []UnitInfoResult `json:"results"`
+}
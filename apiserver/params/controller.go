@@ -3,6 +3,12 @@
 
 package params
 
+import (
+	"time"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -41,6 +47,56 @@ type RemoveBlocksArgs struct {
 	All bool `json:"all"`
 }
 
+// ControllerConfigSet holds the controller config attributes to
+// change in a call to ConfigSet.
+type ControllerConfigSet struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// AuditLogFilter holds the criteria for the requests to return from
+// the controller's audit log. Unset fields match all requests.
+type AuditLogFilter struct {
+	// User is the name of the user who made the requests.
+	User string `json:"user,omitempty"`
+
+	// Model is the name or UUID of the model on which the requests
+	// were made.
+	Model string `json:"model,omitempty"`
+
+	// Facade and Method identify the API calls made.
+	Facade string `json:"facade,omitempty"`
+	Method string `json:"method,omitempty"`
+
+	// ConversationID identifies the conversation of which the
+	// requests are part.
+	ConversationID string `json:"conversation-id,omitempty"`
+
+	// From and To bound the time range in which the requests were
+	// made.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Limit, if positive, restricts the requests to the most recent
+	// ones matching the filter.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogRecord holds a record from the controller's audit log, and
+// the ID of the controller machine that recorded it. Only one of
+// Conversation, Request and Errors is set.
+type AuditLogRecord struct {
+	ControllerID string                   `json:"controller-id"`
+	Conversation *auditlog.Conversation   `json:"conversation,omitempty"`
+	Request      *auditlog.Request        `json:"request,omitempty"`
+	Errors       *auditlog.ResponseErrors `json:"errors,omitempty"`
+}
+
+// AuditLogResults holds the records returned from the controller's
+// audit log.
+type AuditLogResults struct {
+	Records []AuditLogRecord `json:"records"`
+}
+
 // ModelStatus holds information about the status of a juju model.
 type ModelStatus struct {
 	ModelTag           string                `json:"model-tag"`
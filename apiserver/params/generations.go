@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// BranchArg identifies a branch of staged config changes.
+type BranchArg struct {
+	// BranchName is the name of the branch.
+	BranchName string `json:"branch"`
+}
+
+// BranchTrackArg holds the parameters for moving units onto a branch.
+type BranchTrackArg struct {
+	// BranchName is the name of the branch.
+	BranchName string `json:"branch"`
+
+	// Entities holds the tags of the units, or applications whose
+	// units, should track the branch.
+	Entities []Entity `json:"entities"`
+}
+
+// BranchConfigArg holds the parameters for staging application config
+// and charm config changes for an application on a branch.
+type BranchConfigArg struct {
+	// BranchName is the name of the branch.
+	BranchName string `json:"branch"`
+
+	// Application is the name of the application.
+	Application string `json:"application"`
+
+	// Config holds the settings to stage, in string form. Settings
+	// in the application config schema are staged as application
+	// config, and all others as charm config.
+	Config map[string]string `json:"config,omitempty"`
+
+	// Reset holds the names of settings to restore to their
+	// defaults when the branch is committed.
+	Reset []string `json:"reset,omitempty"`
+}
+
+// BranchInfoArgs holds the parameters for retrieving branches.
+type BranchInfoArgs struct {
+	// BranchNames are the names of the branches to return. If empty,
+	// all branches in the model are returned.
+	BranchNames []string `json:"branches,omitempty"`
+}
+
+// BranchResults holds the results of retrieving branches.
+type BranchResults struct {
+	// Branches holds the branches found.
+	Branches []Branch `json:"branches"`
+
+	// Error holds the error retrieving the branches, if any.
+	Error *Error `json:"error,omitempty"`
+}
+
+// Branch describes a branch of staged config changes.
+type Branch struct {
+	// BranchName is the name of the branch.
+	BranchName string `json:"branch"`
+
+	// Created is the Unix time at which the branch was created.
+	Created int64 `json:"created"`
+
+	// CreatedBy is the name of the user that created the branch.
+	CreatedBy string `json:"created-by"`
+
+	// AssignedUnits holds the names of the units tracking the
+	// branch, keyed by application name.
+	AssignedUnits map[string][]string `json:"assigned-units"`
+
+	// Config holds the charm settings staged on the branch, keyed by
+	// application name. A nil value resets the setting to the charm
+	// default.
+	Config map[string]map[string]interface{} `json:"config"`
+
+	// ApplicationConfig holds the application config staged on the
+	// branch, keyed by application name. A nil value resets the
+	// attribute to its default.
+	ApplicationConfig map[string]map[string]interface{} `json:"application-config,omitempty"`
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// GoalStateStatus holds the status of a unit or application in a goal
+// state.
+type GoalStateStatus struct {
+	Status string     `json:"status"`
+	Since  *time.Time `json:"since"`
+}
+
+// UnitsGoalState holds the goal state status of units, keyed on unit
+// name.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState holds the expected units of an application and of the
+// applications related to it, keyed on endpoint name.
+type GoalState struct {
+	Units     UnitsGoalState            `json:"units"`
+	Relations map[string]UnitsGoalState `json:"relations"`
+}
+
+// GoalStateResult holds the goal state of a unit's application, or an
+// error.
+type GoalStateResult struct {
+	Result *GoalState `json:"result"`
+	Error  *Error     `json:"error"`
+}
+
+// GoalStateResults holds the results of GoalStates API calls.
+type GoalStateResults struct {
+	Results []GoalStateResult `json:"results"`
+}
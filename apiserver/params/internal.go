@@ -791,6 +791,9 @@ type LogMessage struct {
 	Module    string    `json:"mod"`
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
+
+	Labels []string          `json:"labels,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
@@ -24,6 +24,9 @@ type LogStreamRecord struct {
 	Location  string    `json:"lo"`
 	Level     string    `json:"lv"`
 	Message   string    `json:"msg"`
+
+	Labels []string          `json:"lab,omitempty"`
+	Fields map[string]string `json:"fld,omitempty"`
 }
 
 // LogStreamConfig holds all the information necessary to open a
@@ -47,4 +50,9 @@ type LogStreamConfig struct {
 
 	// MaxLookbackRecords is the maximum number of log records to stream from the past.
 	MaxLookbackRecords int `schema:"maxlookbackrecords" url:"maxlookbackrecords,omitempty"`
+
+	// Audit indicates that the records of the controller's audit log
+	// should be streamed instead of the model's log records. This is
+	// only allowed for controller agents in the controller model.
+	Audit bool `schema:"audit" url:"audit,omitempty"`
 }
@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// The model event types streamed by the /model/:modeluuid/events
+// endpoint.
+const (
+	// ModelEventResync is sent as the first event on a stream when the
+	// requested cursor can no longer be resumed from. Events have been
+	// missed and the client should refresh its view of the model.
+	ModelEventResync = "resync"
+
+	// ModelEventUnitStatus is sent when a unit's workload or agent
+	// status changes.
+	ModelEventUnitStatus = "unit-status"
+
+	// ModelEventHookStarted and ModelEventHookFinished are sent when a
+	// unit agent starts and finishes running a hook.
+	ModelEventHookStarted  = "hook-started"
+	ModelEventHookFinished = "hook-finished"
+
+	// ModelEventActionPrefix is prefixed to an action's status to form
+	// the type of the event sent as the action moves through its
+	// lifecycle, e.g. "action-pending", "action-running" and
+	// "action-completed".
+	ModelEventActionPrefix = "action-"
+
+	// ModelEventRelationJoined and ModelEventRelationDeparted are sent
+	// when a relation is added to or removed from the model.
+	ModelEventRelationJoined   = "relation-joined"
+	ModelEventRelationDeparted = "relation-departed"
+
+	// ModelEventMachineAdded, ModelEventMachineProvisioned and
+	// ModelEventMachineProvisioningFailed follow a machine from being
+	// added to the model to its instance being started.
+	ModelEventMachineAdded              = "machine-added"
+	ModelEventMachineProvisioned        = "machine-provisioned"
+	ModelEventMachineProvisioningFailed = "machine-provisioning-failed"
+
+	// ModelEventMachineStatus is sent when a machine's agent or
+	// instance status changes.
+	ModelEventMachineStatus = "machine-status"
+
+	// ModelEventConfigChanged is sent when the configuration of the
+	// model or an application changes.
+	ModelEventConfigChanged = "config-changed"
+)
+
+// ModelEventsConfig holds the parameters for a request to stream
+// model events. The url tags are used by go-querystring to encode the
+// request and the schema tags by gorilla/schema to decode it.
+type ModelEventsConfig struct {
+	// Cursor is the cursor of the last event the client received. If
+	// set, the stream resumes with the event after it. If empty, only
+	// events from the time of the request are streamed.
+	Cursor string `schema:"cursor" url:"cursor,omitempty"`
+
+	// Types restricts the stream to events of the given types. Action
+	// events may be selected with "action-*".
+	Types []string `schema:"type" url:"type,omitempty"`
+}
+
+// ModelEvent is a single event sent on a model events stream.
+type ModelEvent struct {
+	// Cursor identifies the position of the event in the stream.
+	// Clients pass the cursor of the last event they saw when
+	// reconnecting to carry on from where they left off.
+	Cursor string `json:"cursor"`
+
+	// Type is one of the ModelEvent* constants.
+	Type string `json:"type"`
+
+	// Entity is the tag of the entity the event relates to. It is
+	// empty for resync events.
+	Entity string `json:"entity,omitempty"`
+
+	// Time is when the change was observed by the controller.
+	Time time.Time `json:"time"`
+
+	// Data holds type specific details, such as the old and new
+	// status of a unit or the name of a hook.
+	Data map[string]string `json:"data,omitempty"`
+}
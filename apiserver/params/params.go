@@ -13,6 +13,7 @@ import (
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/ssh"
 	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/constraints"
@@ -266,6 +267,129 @@ type ApplicationDeploy struct {
 	Resources        map[string]string              `json:"resources,omitempty"`
 }
 
+// DeployDryRunArgs holds the arguments for a DeployDryRun call.
+type DeployDryRunArgs struct {
+	Applications []DeployDryRunArg `json:"applications"`
+}
+
+// DeployDryRunArg holds the arguments for a dry run of deploying an
+// application. Nothing is uploaded for a dry run, so the charm and
+// the resources are described instead; the pending resource IDs in
+// ApplicationDeploy are not used.
+type DeployDryRunArg struct {
+	ApplicationDeploy
+
+	// Charm describes the charm to deploy, if it has not been
+	// added to the model.
+	Charm *DryRunCharm `json:"charm,omitempty"`
+
+	// DryRunResources describes the resources which would be
+	// used, with a revision of -1 where it is not yet known.
+	DryRunResources []DryRunResource `json:"dry-run-resources,omitempty"`
+}
+
+// SetCharmDryRunArg holds the arguments for a dry run of setting
+// an application's charm. As with DeployDryRunArg, the charm and
+// resources are described rather than uploaded, and the resource
+// IDs in ApplicationSetCharm are not used.
+type SetCharmDryRunArg struct {
+	ApplicationSetCharm
+
+	// Charm describes the new charm, if it has not been
+	// added to the model.
+	Charm *DryRunCharm `json:"charm,omitempty"`
+
+	// DryRunResources describes the resources which would be
+	// updated, with a revision of -1 where it is not yet known.
+	DryRunResources []DryRunResource `json:"dry-run-resources,omitempty"`
+}
+
+// DryRunCharm describes a charm which has not been added to
+// the model, for use in dry runs.
+type DryRunCharm struct {
+	Meta   *charm.Meta   `json:"meta"`
+	Config *charm.Config `json:"config,omitempty"`
+}
+
+// DeployDryRunResults holds the results of a DeployDryRun call.
+type DeployDryRunResults struct {
+	Results []DeployDryRunResult `json:"results"`
+}
+
+// DeployDryRunResult describes what the controller would do to deploy
+// an application, after validating the deploy arguments.
+type DeployDryRunResult struct {
+	ApplicationName string `json:"application"`
+	CharmURL        string `json:"charm-url"`
+	Series          string `json:"series"`
+	NumUnits        int    `json:"num-units"`
+
+	// Placement holds the placement directive used for each unit,
+	// or an empty string where the unit would be assigned to a
+	// machine by the model's assignment policy.
+	Placement []string `json:"placement,omitempty"`
+
+	// Storage holds the storage constraints that would be recorded,
+	// with any defaults filled in.
+	Storage map[string]storage.Constraints `json:"storage,omitempty"`
+
+	// EndpointBindings holds the space that each endpoint would be
+	// bound to.
+	EndpointBindings map[string]string `json:"endpoint-bindings,omitempty"`
+
+	// Resources holds the resources the application would use.
+	Resources []DryRunResource `json:"resources,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// DryRunResource describes a resource revision that an application
+// would use.
+type DryRunResource struct {
+	Name   string `json:"name"`
+	Origin string `json:"origin"`
+
+	// Revision is the revision of the resource, or -1 if it is
+	// yet to be uploaded or is the latest in the charm store.
+	Revision int `json:"revision"`
+
+	// CurrentRevision is the revision currently used by the
+	// application, or -1 if the application has no such resource.
+	CurrentRevision int `json:"current-revision"`
+}
+
+// SetCharmDryRunResult describes what the controller would do to
+// upgrade an application's charm, after validating the arguments.
+type SetCharmDryRunResult struct {
+	ApplicationName string `json:"application"`
+	CurrentCharmURL string `json:"current-charm-url"`
+	CharmURL        string `json:"charm-url"`
+
+	// ConfigAdded and ConfigRemoved hold the names of the charm
+	// config options that the new charm adds and removes.
+	ConfigAdded   []string `json:"config-added,omitempty"`
+	ConfigRemoved []string `json:"config-removed,omitempty"`
+
+	// BrokenRelations holds the keys of the established relations
+	// that the new charm no longer supports.
+	BrokenRelations []string `json:"broken-relations,omitempty"`
+
+	// Resources holds the resources that would be updated.
+	Resources []DryRunResource `json:"resources,omitempty"`
+}
+
+// AddUnitsDryRunResult describes what the controller would do to add
+// units to an application, after validating the arguments.
+type AddUnitsDryRunResult struct {
+	ApplicationName string `json:"application"`
+	NumUnits        int    `json:"num-units"`
+
+	// Placement holds the placement directive used for each unit,
+	// or an empty string where the unit would be assigned to a
+	// machine by the model's assignment policy.
+	Placement []string `json:"placement,omitempty"`
+}
+
 // ApplicationUpdate holds the parameters for making the application Update call.
 type ApplicationUpdate struct {
 	ApplicationName string             `json:"application"`
@@ -405,6 +529,33 @@ type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
 }
 
+// ApplicationEndpointBindings holds the endpoint bindings to change for
+// an application. Bindings maps endpoint names to space names; the
+// empty endpoint name sets the application's default space.
+type ApplicationEndpointBindings struct {
+	ApplicationTag string            `json:"application-tag"`
+	Bindings       map[string]string `json:"bindings"`
+}
+
+// ApplicationEndpointBindingsArgs holds the parameters for the
+// UpdateEndpointBindings call.
+type ApplicationEndpointBindingsArgs struct {
+	Args []ApplicationEndpointBindings `json:"args"`
+}
+
+// ScaleApplicationParams holds the desired number of
+// units of an application in a CAAS model.
+type ScaleApplicationParams struct {
+	ApplicationTag string `json:"application-tag"`
+	Scale          int    `json:"scale"`
+}
+
+// ScaleApplicationsParams holds the parameters for the
+// ScaleApplications call.
+type ScaleApplicationsParams struct {
+	Applications []ScaleApplicationParams `json:"applications"`
+}
+
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
 type ApplicationMetricCredential struct {
 	ApplicationName   string `json:"application"`
@@ -488,12 +639,45 @@ type UpdateApplicationUnits struct {
 
 // ApplicationUnitParams holds unit parameters used to update a unit.
 type ApplicationUnitParams struct {
-	Id      string                 `json:"id"`
-	Address string                 `json:"address"`
-	Ports   []string               `json:"ports"`
-	Status  string                 `json:"status"`
-	Info    string                 `json:"info"`
-	Data    map[string]interface{} `json:"data"`
+	Id             string                     `json:"id"`
+	Address        string                     `json:"address"`
+	Ports          []string                   `json:"ports"`
+	Status         string                     `json:"status"`
+	Info           string                     `json:"info"`
+	Data           map[string]interface{}     `json:"data"`
+	FilesystemInfo []KubernetesFilesystemInfo `json:"filesystem-info,omitempty"`
+}
+
+// KubernetesFilesystemInfo describes a filesystem mounted
+// in the pod of a unit in a Kubernetes model.
+type KubernetesFilesystemInfo struct {
+	StorageName  string `json:"storagename"`
+	FilesystemId string `json:"filesystem-id"`
+	Size         uint64 `json:"size"`
+	MountPoint   string `json:"mount-point"`
+	ReadOnly     bool   `json:"read-only,omitempty"`
+	Status       string `json:"status"`
+	Info         string `json:"info,omitempty"`
+}
+
+// KubernetesProvisioningInfo holds the information needed
+// to provision the pods of an application in a Kubernetes model.
+type KubernetesProvisioningInfo struct {
+	Filesystems []KubernetesFilesystemParams `json:"filesystems,omitempty"`
+	Constraints constraints.Value            `json:"constraints"`
+}
+
+// KubernetesProvisioningInfoResult holds the provisioning info
+// for an application, or an error.
+type KubernetesProvisioningInfoResult struct {
+	Result *KubernetesProvisioningInfo `json:"result,omitempty"`
+	Error  *Error                      `json:"error,omitempty"`
+}
+
+// KubernetesProvisioningInfoResults holds a set of
+// KubernetesProvisioningInfoResults.
+type KubernetesProvisioningInfoResults struct {
+	Results []KubernetesProvisioningInfoResult `json:"results"`
 }
 
 // DestroyApplicationUnits holds parameters for the deprecated
@@ -977,6 +1161,9 @@ type LogRecord struct {
 	Level    string    `json:"v"`
 	Message  string    `json:"x"`
 	Entity   string    `json:"e,omitempty"`
+
+	Labels []string          `json:"b,omitempty"`
+	Fields map[string]string `json:"f,omitempty"`
 }
 
 // PubSubMessage is used to propagate pubsub messages from one api server to the
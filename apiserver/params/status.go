@@ -102,6 +102,10 @@ type MachineStatus struct {
 	Jobs      []multiwatcher.MachineJob `json:"jobs"`
 	HasVote   bool                      `json:"has-vote"`
 	WantsVote bool                      `json:"wants-vote"`
+
+	// UpgradeSeriesStatus holds the progress of the machine through a
+	// managed series upgrade, if one is in progress.
+	UpgradeSeriesStatus string `json:"upgrade-series-status,omitempty"`
 }
 
 // ApplicationStatus holds status info about an application.
@@ -164,6 +168,10 @@ type UnitStatus struct {
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
 
+	// Branch is the name of the branch of staged charm config
+	// changes tracked by the unit, if any.
+	Branch string `json:"branch,omitempty"`
+
 	// The following are for CAAS models.
 
 	ProviderId string `json:"provider-id,omitempty"`
@@ -244,6 +252,38 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// StatusHistoryQuery holds the parameters of a query for status
+// history entries from across a model.
+type StatusHistoryQuery struct {
+	Kinds       []string   `json:"kinds,omitempty"`
+	Application string     `json:"application,omitempty"`
+	FromDate    *time.Time `json:"from-date,omitempty"`
+	ToDate      *time.Time `json:"to-date,omitempty"`
+	Size        int        `json:"size,omitempty"`
+	Exclude     []string   `json:"exclude,omitempty"`
+
+	// After, if set, is the Next cursor of the previous page of
+	// entries. Limit, if positive, is the most entries to return;
+	// the controller returns no more than its own maximum.
+	After string `json:"after,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// StatusHistoryEntry holds a status history entry of the entity
+// with the given tag.
+type StatusHistoryEntry struct {
+	Tag    string         `json:"tag"`
+	Status DetailedStatus `json:"status"`
+}
+
+// StatusHistoryEntries holds a page of the result of a
+// StatusHistoryQuery. Next, if set, is the cursor to pass as the
+// query's After field to get the next page.
+type StatusHistoryEntries struct {
+	Entries []StatusHistoryEntry `json:"entries"`
+	Next    string               `json:"next,omitempty"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
@@ -367,6 +367,24 @@ type FilesystemParams struct {
 	Attachment    *FilesystemAttachmentParams `json:"attachment,omitempty"`
 }
 
+// KubernetesFilesystemParams holds the parameters for creating
+// a filesystem for the pods of a Kubernetes application.
+type KubernetesFilesystemParams struct {
+	StorageName string                                `json:"storagename"`
+	Size        uint64                                `json:"size"`
+	Provider    string                                `json:"provider"`
+	Attributes  map[string]interface{}                `json:"attributes,omitempty"`
+	Tags        map[string]string                     `json:"tags,omitempty"`
+	Attachment  *KubernetesFilesystemAttachmentParams `json:"attachment,omitempty"`
+}
+
+// KubernetesFilesystemAttachmentParams holds the parameters for
+// mounting a filesystem in the pods of a Kubernetes application.
+type KubernetesFilesystemAttachmentParams struct {
+	MountPoint string `json:"mount-point,omitempty"`
+	ReadOnly   bool   `json:"read-only,omitempty"`
+}
+
 // RemoveFilesystemParams holds the parameters for destroying or releasing
 // a filesystem.
 type RemoveFilesystemParams struct {
@@ -0,0 +1,56 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// UpgradeSeriesStatusResult holds the progress of a machine or unit
+// through a managed series upgrade.
+type UpgradeSeriesStatusResult struct {
+	// Status is the upgrade series status.
+	Status string `json:"status,omitempty"`
+
+	// Error holds the error retrieving the status, if any.
+	Error *Error `json:"error,omitempty"`
+}
+
+// UpgradeSeriesStatusResults holds the results of retrieving the
+// upgrade series status of several entities.
+type UpgradeSeriesStatusResults struct {
+	Results []UpgradeSeriesStatusResult `json:"results"`
+}
+
+// UpgradeSeriesStatusParam holds the upgrade series status to record
+// for an entity.
+type UpgradeSeriesStatusParam struct {
+	// Entity identifies the machine or unit.
+	Entity Entity `json:"entity"`
+
+	// Status is the upgrade series status to record.
+	Status string `json:"status"`
+}
+
+// UpgradeSeriesStatusParams holds the upgrade series statuses to
+// record for several entities.
+type UpgradeSeriesStatusParams struct {
+	Params []UpgradeSeriesStatusParam `json:"params"`
+}
+
+// UpgradeSeriesUnitStatus holds the progress of a unit through the
+// series upgrade of its machine.
+type UpgradeSeriesUnitStatus struct {
+	// Entity identifies the unit.
+	Entity Entity `json:"entity"`
+
+	// Status is the unit's upgrade series status.
+	Status string `json:"status"`
+}
+
+// UpgradeSeriesUnitStatusesResult holds the progress of each unit on a
+// machine through the machine's series upgrade.
+type UpgradeSeriesUnitStatusesResult struct {
+	// Statuses holds the status of each unit.
+	Statuses []UpgradeSeriesUnitStatus `json:"statuses"`
+
+	// Error holds the error retrieving the statuses, if any.
+	Error *Error `json:"error,omitempty"`
+}
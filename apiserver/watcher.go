@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
 )
 
 // NewAllWatcher returns a new API server endpoint for interacting
@@ -85,6 +86,45 @@ func (aw *SrvAllWatcher) Next() (params.AllWatcherNextResults, error) {
 	}, err
 }
 
+// NewAllWatcherV1 returns a new API server endpoint for interacting
+// with a watcher created by the WatchAll API call, for version 1 of the
+// AllWatcher facade.
+func NewAllWatcherV1(context facade.Context) (facade.Facade, error) {
+	w, err := NewAllWatcher(context)
+	if err != nil {
+		return nil, err
+	}
+	return &SrvAllWatcherV1{w.(*SrvAllWatcher)}, nil
+}
+
+// SrvAllWatcherV1 defines the API methods on a state.Multiwatcher for
+// version 1 of the AllWatcher facade, which predates reporting changes
+// to the model itself.
+type SrvAllWatcherV1 struct {
+	*SrvAllWatcher
+}
+
+// Next returns the next set of deltas, leaving out any for the model.
+// It blocks until there are other deltas to return.
+func (aw *SrvAllWatcherV1) Next() (params.AllWatcherNextResults, error) {
+	for {
+		result, err := aw.SrvAllWatcher.Next()
+		if err != nil {
+			return result, err
+		}
+		var deltas []multiwatcher.Delta
+		for _, delta := range result.Deltas {
+			if _, ok := delta.Entity.(*multiwatcher.ModelInfo); ok {
+				continue
+			}
+			deltas = append(deltas, delta)
+		}
+		if len(deltas) > 0 {
+			return params.AllWatcherNextResults{Deltas: deltas}, nil
+		}
+	}
+}
+
 // srvNotifyWatcher defines the API access to methods on a state.NotifyWatcher.
 // Each client has its own current set of watchers, stored in resources.
 type srvNotifyWatcher struct {
@@ -4,14 +4,18 @@
 package caas
 
 import (
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/watcher"
 )
 
-// NewContainerBrokerFunc returns a Container Broker.
-type NewContainerBrokerFunc func(environs.CloudSpec) (Broker, error)
+// NewContainerBrokerFunc returns a Container Broker for the model
+// with the given config, using the given cloud.
+type NewContainerBrokerFunc func(environs.OpenParams) (Broker, error)
 
 // Broker instances interact with the CAAS substrate.
 type Broker interface {
@@ -19,8 +23,8 @@ type Broker interface {
 	// a charm for the specified application.
 	EnsureOperator(appName, agentPath string, config *OperatorConfig) error
 
-	// EnsureService creates or updates a service for pods with the given spec.
-	EnsureService(appName string, spec *ContainerSpec, numUnits int, config application.ConfigAttributes) error
+	// EnsureService creates or updates a service for pods with the given params.
+	EnsureService(appName string, params *ServiceParams, numUnits int, config application.ConfigAttributes) error
 
 	// DeleteService deletes the specified service.
 	DeleteService(appName string) error
@@ -32,7 +36,7 @@ type Broker interface {
 	UnexposeService(appName string) error
 
 	// EnsureUnit creates or updates a pod with the given spec.
-	EnsureUnit(appName, unitName string, spec *ContainerSpec) error
+	EnsureUnit(appName, unitName string, spec *PodSpec) error
 
 	// WatchUnits returns a watcher which notifies when there
 	// are changes to units of the specified application.
@@ -40,14 +44,64 @@ type Broker interface {
 
 	// Units returns all units of the specified application.
 	Units(appName string) ([]Unit, error)
+
+	// Destroy removes everything the broker created for the model,
+	// including its namespace and the service accounts in it.
+	Destroy() error
+
+	// SetConfig updates the broker with the model's new config.
+	SetConfig(cfg *config.Config) error
+
+	// ProviderRegistry is an interface for obtaining storage providers.
+	storage.ProviderRegistry
+}
+
+// ServiceParams defines parameters used to create a service.
+type ServiceParams struct {
+	// PodSpec is the spec used to configure a pod.
+	PodSpec *PodSpec
+
+	// Filesystems is a set of parameters for filesystems that
+	// should be created and mounted in each pod.
+	Filesystems []storage.KubernetesFilesystemParams
+
+	// Constraints are the application's constraints, used to
+	// size the pods and select the nodes they are run on.
+	Constraints constraints.Value
 }
 
 // Unit represents information about the status of a "pod".
 type Unit struct {
-	Id      string
-	Address string
-	Ports   []string
-	Status  status.StatusInfo
+	Id             string
+	Address        string
+	Ports          []string
+	Status         status.StatusInfo
+	FilesystemInfo []FilesystemInfo
+}
+
+// FilesystemInfo represents information about a filesystem
+// mounted by a unit.
+type FilesystemInfo struct {
+	// StorageName is the name of the charm storage
+	// that the filesystem was created for.
+	StorageName string
+
+	// FilesystemId is a unique provider-supplied ID for the
+	// filesystem.
+	FilesystemId string
+
+	// Size is the size of the filesystem, in MiB.
+	Size uint64
+
+	// MountPoint is the path at which the filesystem
+	// is mounted in the unit's pod.
+	MountPoint string
+
+	// ReadOnly indicates that the filesystem is mounted read-only.
+	ReadOnly bool
+
+	// Status is the status of the filesystem.
+	Status status.StatusInfo
 }
 
 // OperatorConfig is the config to use when creating an operator.
@@ -5,12 +5,61 @@ package caas
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	// LegacyPodSpecVersion is the version of specs which describe a
+	// single container, without a version field. They are converted
+	// into a pod spec with one container when parsed.
+	LegacyPodSpecVersion = 0
+
+	// CurrentPodSpecVersion is the version of the pod spec schema
+	// described by PodSpec.
+	CurrentPodSpecVersion = 1
+)
+
+// PodSpec defines the data values used to configure
+// a pod on the CAAS substrate.
+type PodSpec struct {
+	// Version is the version of the pod spec schema.
+	Version int `yaml:"version"`
+
+	// Containers are the workload containers run in the pod.
+	Containers []ContainerSpec `yaml:"containers"`
+
+	// InitContainers are run to completion, in order,
+	// before the workload containers are started.
+	InitContainers []ContainerSpec `yaml:"init-containers,omitempty"`
+
+	// ServiceAccount, if specified, causes the pods to be run with a
+	// service account granted the given permissions. Permissions are
+	// only ever granted within the model's own namespace, and never
+	// over the resources which limit it.
+	ServiceAccount *ServiceAccountSpec `yaml:"service-account,omitempty"`
+}
+
+// ServiceAccountSpec defines the permissions a charm's
+// workload needs on the CAAS substrate.
+type ServiceAccountSpec struct {
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+// PolicyRule grants the listed verbs on the listed resources.
+// An empty set of API groups means the core API group; an
+// empty set of resource names means all resources of the type.
+type PolicyRule struct {
+	APIGroups     []string `yaml:"api-groups,omitempty"`
+	Resources     []string `yaml:"resources"`
+	ResourceNames []string `yaml:"resource-names,omitempty"`
+	Verbs         []string `yaml:"verbs"`
+}
+
 // ContainerPort defines the attributes used to configure
 // an open port for the container.
 type ContainerPort struct {
+	Name          string `yaml:"name,omitempty"`
 	ContainerPort int    `yaml:"container-port"`
 	Protocol      string `yaml:"protocol"`
 }
@@ -18,23 +67,330 @@ type ContainerPort struct {
 // ContainerSpec defines the data values used to configure
 // a container on the CAAS substrate.
 type ContainerSpec struct {
+	Name       string          `yaml:"name"`
+	ImageName  string          `yaml:"image-name"`
+	Command    []string        `yaml:"command,omitempty"`
+	Args       []string        `yaml:"args,omitempty"`
+	WorkingDir string          `yaml:"working-dir,omitempty"`
+	Ports      []ContainerPort `yaml:"ports,omitempty"`
+
+	// Config holds environment variables to set in the container.
+	Config map[string]string `yaml:"config,omitempty"`
+
+	// SecretConfig holds environment variables to set in the
+	// container from keys of existing secrets.
+	SecretConfig []SecretConfig `yaml:"secret-config,omitempty"`
+
+	// Files holds sets of files to be mounted in the container.
+	Files []FileSet `yaml:"files,omitempty"`
+
+	LivenessProbe  *Probe                `yaml:"liveness-probe,omitempty"`
+	ReadinessProbe *Probe                `yaml:"readiness-probe,omitempty"`
+	Resources      *ResourceRequirements `yaml:"resources,omitempty"`
+}
+
+// SecretConfig defines an environment variable whose
+// value is taken from a key of a secret.
+type SecretConfig struct {
+	Name       string `yaml:"name"`
+	SecretName string `yaml:"secret-name"`
+	Key        string `yaml:"key"`
+}
+
+// FileSet defines a set of files, keyed by name,
+// to be mounted in a directory of a container.
+type FileSet struct {
 	Name      string            `yaml:"name"`
-	ImageName string            `yaml:"image-name"`
-	Ports     []ContainerPort   `yaml:"ports,omitempty"`
-	Config    map[string]string `yaml:"config,omitempty"`
+	MountPath string            `yaml:"mount-path"`
+	Files     map[string]string `yaml:"files"`
+}
+
+// Probe defines a check run against a container to
+// determine whether it is alive or ready. Exactly one
+// of Exec, HTTPGet or TCPSocket must be specified.
+type Probe struct {
+	Exec      *ExecAction      `yaml:"exec,omitempty"`
+	HTTPGet   *HTTPGetAction   `yaml:"http-get,omitempty"`
+	TCPSocket *TCPSocketAction `yaml:"tcp-socket,omitempty"`
+
+	InitialDelaySeconds int `yaml:"initial-delay-seconds,omitempty"`
+	TimeoutSeconds      int `yaml:"timeout-seconds,omitempty"`
+	PeriodSeconds       int `yaml:"period-seconds,omitempty"`
+	SuccessThreshold    int `yaml:"success-threshold,omitempty"`
+	FailureThreshold    int `yaml:"failure-threshold,omitempty"`
+}
+
+// ExecAction runs a command in the container; the probe
+// succeeds if the command exits with status 0.
+type ExecAction struct {
+	Command []string `yaml:"command"`
+}
+
+// HTTPGetAction performs an HTTP GET request against the
+// container; the probe succeeds on any 2xx or 3xx response.
+type HTTPGetAction struct {
+	Path   string `yaml:"path,omitempty"`
+	Port   int    `yaml:"port"`
+	Scheme string `yaml:"scheme,omitempty"`
+}
+
+// TCPSocketAction opens a TCP connection to the container;
+// the probe succeeds if the connection is established.
+type TCPSocketAction struct {
+	Port int `yaml:"port"`
+}
+
+// ResourceRequirements defines the compute resources a container
+// requests, and the limits it may not exceed. Resources are keyed
+// by name, "cpu" or "memory", with values such as "500m" or "1Gi".
+type ResourceRequirements struct {
+	Requests map[string]string `yaml:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits,omitempty"`
 }
 
-// ParseContainerSpec parses a YAML string into a ContainerSpec struct.
-func ParseContainerSpec(in string) (*ContainerSpec, error) {
-	var spec ContainerSpec
-	if err := yaml.Unmarshal([]byte(in), &spec); err != nil {
+const (
+	// ResourceCPU is the name of the CPU resource, in cores.
+	ResourceCPU = "cpu"
+
+	// ResourceMemory is the name of the memory resource, in bytes.
+	ResourceMemory = "memory"
+)
+
+// ParsePodSpec parses a YAML string into a PodSpec struct, and
+// validates it. Specs without a version are parsed as a single
+// container spec, for compatibility with the original format.
+func ParsePodSpec(in string) (*PodSpec, error) {
+	var versioned struct {
+		Version int `yaml:"version"`
+	}
+	if err := yaml.Unmarshal([]byte(in), &versioned); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var spec PodSpec
+	switch versioned.Version {
+	case LegacyPodSpecVersion:
+		var container ContainerSpec
+		if err := yaml.Unmarshal([]byte(in), &container); err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec = PodSpec{
+			Version:    CurrentPodSpecVersion,
+			Containers: []ContainerSpec{container},
+		}
+	case CurrentPodSpecVersion:
+		if err := yaml.UnmarshalStrict([]byte(in), &spec); err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotSupportedf("pod spec version %d", versioned.Version)
+	}
+	if err := spec.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	return &spec, nil
+}
+
+// Validate returns an error if the spec is not valid.
+func (spec *PodSpec) Validate() error {
+	if len(spec.Containers) == 0 {
+		return errors.New("spec has no containers")
+	}
+	containerNames := make(map[string]bool)
+	validate := func(c ContainerSpec) error {
+		if err := c.Validate(); err != nil {
+			if c.Name == "" {
+				return errors.Trace(err)
+			}
+			return errors.Annotatef(err, "container %q", c.Name)
+		}
+		if containerNames[c.Name] {
+			return errors.Errorf("duplicate container name %q", c.Name)
+		}
+		containerNames[c.Name] = true
+		return nil
+	}
+	for _, c := range spec.InitContainers {
+		if err := validate(c); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, c := range spec.Containers {
+		if err := validate(c); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return errors.Annotate(err, "service account")
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the spec is not valid.
+func (spec *ServiceAccountSpec) Validate() error {
+	if len(spec.Rules) == 0 {
+		return errors.New("no rules specified")
+	}
+	for i, rule := range spec.Rules {
+		if len(rule.Resources) == 0 {
+			return errors.Errorf("rule %d has no resources", i)
+		}
+		if len(rule.Verbs) == 0 {
+			return errors.Errorf("rule %d has no verbs", i)
+		}
+		for _, values := range [][]string{rule.APIGroups, rule.Resources, rule.ResourceNames, rule.Verbs} {
+			for _, value := range values {
+				if value == "*" {
+					return errors.Errorf("rule %d uses a wildcard", i)
+				}
+			}
+		}
+		for _, resource := range rule.Resources {
+			if reservedResources.Contains(resource) {
+				return errors.Errorf("rule %d grants access to %s", i, resource)
+			}
+		}
+	}
+	return nil
+}
+
+// reservedResources are the resources which enforce the limits of
+// the model's namespace, and so cannot be granted to a workload.
+var reservedResources = set.NewStrings(
+	"resourcequotas",
+	"limitranges",
+	"roles",
+	"rolebindings",
+	"clusterroles",
+	"clusterrolebindings",
+	"namespaces",
+)
+
+// Validate returns an error if the spec is not valid.
+func (spec *ContainerSpec) Validate() error {
 	if spec.Name == "" {
-		return nil, errors.New("spec name is missing")
+		return errors.New("spec name is missing")
 	}
 	if spec.ImageName == "" {
-		return nil, errors.New("spec image name is missing")
+		return errors.New("spec image name is missing")
 	}
-	return &spec, nil
+	for _, p := range spec.Ports {
+		if err := validatePort(p.ContainerPort); err != nil {
+			return errors.Trace(err)
+		}
+		switch p.Protocol {
+		case "", "TCP", "UDP":
+		default:
+			return errors.NotValidf("protocol %q for port %d", p.Protocol, p.ContainerPort)
+		}
+	}
+	for _, s := range spec.SecretConfig {
+		if s.Name == "" || s.SecretName == "" || s.Key == "" {
+			return errors.New("secret config requires name, secret-name and key")
+		}
+		if _, ok := spec.Config[s.Name]; ok {
+			return errors.Errorf("config %q is also set from a secret", s.Name)
+		}
+	}
+	fileSetNames := make(map[string]bool)
+	for _, f := range spec.Files {
+		if f.Name == "" {
+			return errors.New("file set name is missing")
+		}
+		if fileSetNames[f.Name] {
+			return errors.Errorf("duplicate file set name %q", f.Name)
+		}
+		fileSetNames[f.Name] = true
+		if f.MountPath == "" {
+			return errors.Errorf("file set %q mount path is missing", f.Name)
+		}
+		if len(f.Files) == 0 {
+			return errors.Errorf("file set %q has no files", f.Name)
+		}
+	}
+	if spec.LivenessProbe != nil {
+		if err := spec.LivenessProbe.Validate(); err != nil {
+			return errors.Annotate(err, "liveness probe")
+		}
+	}
+	if spec.ReadinessProbe != nil {
+		if err := spec.ReadinessProbe.Validate(); err != nil {
+			return errors.Annotate(err, "readiness probe")
+		}
+	}
+	if spec.Resources != nil {
+		if err := spec.Resources.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the probe is not valid.
+func (p *Probe) Validate() error {
+	var actions int
+	if p.Exec != nil {
+		actions++
+		if len(p.Exec.Command) == 0 {
+			return errors.New("exec command is missing")
+		}
+	}
+	if p.HTTPGet != nil {
+		actions++
+		if err := validatePort(p.HTTPGet.Port); err != nil {
+			return errors.Trace(err)
+		}
+		switch p.HTTPGet.Scheme {
+		case "", "HTTP", "HTTPS":
+		default:
+			return errors.NotValidf("scheme %q", p.HTTPGet.Scheme)
+		}
+	}
+	if p.TCPSocket != nil {
+		actions++
+		if err := validatePort(p.TCPSocket.Port); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if actions != 1 {
+		return errors.New("exactly one of exec, http-get or tcp-socket must be specified")
+	}
+	for name, value := range map[string]int{
+		"initial-delay-seconds": p.InitialDelaySeconds,
+		"timeout-seconds":       p.TimeoutSeconds,
+		"period-seconds":        p.PeriodSeconds,
+		"success-threshold":     p.SuccessThreshold,
+		"failure-threshold":     p.FailureThreshold,
+	} {
+		if value < 0 {
+			return errors.NotValidf("negative %s", name)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the resource requirements are not
+// valid. The resource quantities are validated by the provider.
+func (r *ResourceRequirements) Validate() error {
+	for _, resources := range []map[string]string{r.Requests, r.Limits} {
+		for name, value := range resources {
+			switch name {
+			case ResourceCPU, ResourceMemory:
+			default:
+				return errors.NotValidf("resource %q", name)
+			}
+			if value == "" {
+				return errors.Errorf("resource %q quantity is missing", name)
+			}
+		}
+	}
+	return nil
+}
+
+func validatePort(port int) error {
+	if port <= 0 || port > 65535 {
+		return errors.NotValidf("port %d", port)
+	}
+	return nil
 }
@@ -31,29 +31,319 @@ config:
   foo: bar
 `[1:]
 
-	spec, err := caas.ParseContainerSpec(specStr)
+	spec, err := caas.ParsePodSpec(specStr)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(spec, jc.DeepEquals, &caas.ContainerSpec{
-		Name:      "gitlab",
-		ImageName: "gitlab/latest",
-		Ports: []caas.ContainerPort{
-			{ContainerPort: 80, Protocol: "TCP"},
-			{ContainerPort: 443},
-		},
-		Config: map[string]string{
-			"attr": "foo=bar; fred=blogs",
-			"foo":  "bar",
+	c.Assert(spec, jc.DeepEquals, &caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+			Ports: []caas.ContainerPort{
+				{ContainerPort: 80, Protocol: "TCP"},
+				{ContainerPort: 443},
+			},
+			Config: map[string]string{
+				"attr": "foo=bar; fred=blogs",
+				"foo":  "bar",
+			},
+		}},
+	})
+}
+
+func (s *ContainersSuite) TestParsePodSpec(c *gc.C) {
+
+	specStr := `
+version: 1
+init-containers:
+- name: gitlab-init
+  image-name: gitlab-init/latest
+  command: ["/bin/sh", "-c", "echo init"]
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  ports:
+  - name: http
+    container-port: 80
+    protocol: TCP
+  config:
+    foo: bar
+  secret-config:
+  - name: DB_PASSWORD
+    secret-name: gitlab-db
+    key: password
+  files:
+  - name: configuration
+    mount-path: /etc/gitlab
+    files:
+      gitlab.rb: |
+        external_url 'http://gitlab'
+  liveness-probe:
+    http-get:
+      path: /ping
+      port: 80
+    initial-delay-seconds: 10
+  readiness-probe:
+    tcp-socket:
+      port: 80
+  resources:
+    requests:
+      cpu: 500m
+      memory: 1Gi
+    limits:
+      memory: 2Gi
+- name: sidecar
+  image-name: sidecar/latest
+  liveness-probe:
+    exec:
+      command: ["healthy"]
+service-account:
+  rules:
+  - resources: ["pods"]
+    verbs: ["get", "list", "watch"]
+  - api-groups: ["extensions"]
+    resources: ["deployments"]
+    resource-names: ["gitlab-runner"]
+    verbs: ["get", "update"]
+`[1:]
+
+	spec, err := caas.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, &caas.PodSpec{
+		Version: 1,
+		InitContainers: []caas.ContainerSpec{{
+			Name:      "gitlab-init",
+			ImageName: "gitlab-init/latest",
+			Command:   []string{"/bin/sh", "-c", "echo init"},
+		}},
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+			Ports: []caas.ContainerPort{
+				{Name: "http", ContainerPort: 80, Protocol: "TCP"},
+			},
+			Config: map[string]string{"foo": "bar"},
+			SecretConfig: []caas.SecretConfig{
+				{Name: "DB_PASSWORD", SecretName: "gitlab-db", Key: "password"},
+			},
+			Files: []caas.FileSet{{
+				Name:      "configuration",
+				MountPath: "/etc/gitlab",
+				Files: map[string]string{
+					"gitlab.rb": "external_url 'http://gitlab'\n",
+				},
+			}},
+			LivenessProbe: &caas.Probe{
+				HTTPGet:             &caas.HTTPGetAction{Path: "/ping", Port: 80},
+				InitialDelaySeconds: 10,
+			},
+			ReadinessProbe: &caas.Probe{
+				TCPSocket: &caas.TCPSocketAction{Port: 80},
+			},
+			Resources: &caas.ResourceRequirements{
+				Requests: map[string]string{"cpu": "500m", "memory": "1Gi"},
+				Limits:   map[string]string{"memory": "2Gi"},
+			},
+		}, {
+			Name:      "sidecar",
+			ImageName: "sidecar/latest",
+			LivenessProbe: &caas.Probe{
+				Exec: &caas.ExecAction{Command: []string{"healthy"}},
+			},
+		}},
+		ServiceAccount: &caas.ServiceAccountSpec{
+			Rules: []caas.PolicyRule{{
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			}, {
+				APIGroups:     []string{"extensions"},
+				Resources:     []string{"deployments"},
+				ResourceNames: []string{"gitlab-runner"},
+				Verbs:         []string{"get", "update"},
+			}},
 		},
 	})
 }
 
+var invalidPodSpecTests = []struct {
+	spec string
+	err  string
+}{{
+	spec: "version: 2",
+	err:  "pod spec version 2 not supported",
+}, {
+	spec: "version: 1",
+	err:  "spec has no containers",
+}, {
+	spec: "version: 1\nunknown: field\ncontainers: []",
+	err:  "(?s).*field unknown not found.*",
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+- name: gitlab
+  image-name: gitlab/latest
+`[1:],
+	err: `duplicate container name "gitlab"`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  ports:
+  - container-port: 0
+`[1:],
+	err: `container "gitlab": port 0 not valid`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  liveness-probe:
+    exec:
+      command: ["healthy"]
+    tcp-socket:
+      port: 80
+`[1:],
+	err: `container "gitlab": liveness probe: exactly one of exec, http-get or tcp-socket must be specified`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  resources:
+    limits:
+      gpu: 1
+`[1:],
+	err: `container "gitlab": resource "gpu" not valid`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  files:
+  - name: configuration
+    mount-path: /etc/gitlab
+`[1:],
+	err: `container "gitlab": file set "configuration" has no files`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+service-account:
+  rules: []
+`[1:],
+	err: `service account: no rules specified`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+service-account:
+  rules:
+  - resources: ["pods"]
+`[1:],
+	err: `service account: rule 0 has no verbs`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+service-account:
+  rules:
+  - resources: ["pods"]
+    verbs: ["get"]
+  - resources: ["*"]
+    verbs: ["get"]
+`[1:],
+	err: `service account: rule 1 uses a wildcard`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+service-account:
+  rules:
+  - api-groups: ["*"]
+    resources: ["pods"]
+    verbs: ["get"]
+`[1:],
+	err: `service account: rule 0 uses a wildcard`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+service-account:
+  rules:
+  - resources: ["pods"]
+    verbs: ["*"]
+`[1:],
+	err: `service account: rule 0 uses a wildcard`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+service-account:
+  rules:
+  - resources: ["pods", "resourcequotas"]
+    verbs: ["get", "update"]
+`[1:],
+	err: `service account: rule 0 grants access to resourcequotas`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+service-account:
+  rules:
+  - api-groups: ["rbac.authorization.k8s.io"]
+    resources: ["rolebindings"]
+    verbs: ["create"]
+`[1:],
+	err: `service account: rule 0 grants access to rolebindings`,
+}, {
+	spec: `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  secret-config:
+  - name: DB_PASSWORD
+    key: password
+`[1:],
+	err: `container "gitlab": secret config requires name, secret-name and key`,
+}}
+
+func (s *ContainersSuite) TestParseInvalidPodSpec(c *gc.C) {
+	for i, t := range invalidPodSpecTests {
+		c.Logf("test %d: %s", i, t.spec)
+		_, err := caas.ParsePodSpec(t.spec)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
 func (s *ContainersSuite) TestParseMissingName(c *gc.C) {
 
 	specStr := `
 image-name: gitlab/latest
 `[1:]
 
-	_, err := caas.ParseContainerSpec(specStr)
+	_, err := caas.ParsePodSpec(specStr)
 	c.Assert(err, gc.ErrorMatches, "spec name is missing")
 }
 
@@ -63,6 +353,6 @@ func (s *ContainersSuite) TestParseMissingImage(c *gc.C) {
 name: gitlab
 `[1:]
 
-	_, err := caas.ParseContainerSpec(specStr)
-	c.Assert(err, gc.ErrorMatches, "spec image name is missing")
+	_, err := caas.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab": spec image name is missing`)
 }
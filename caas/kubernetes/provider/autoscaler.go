@@ -0,0 +1,120 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	k8serrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+// autoscalerParams holds the horizontal pod autoscaler settings taken
+// from an application's config.
+type autoscalerParams struct {
+	// MaxReplicas is the most pods the autoscaler may run. Zero means
+	// autoscaling is disabled.
+	MaxReplicas int
+
+	// TargetCPUPercentage is the average CPU utilisation the
+	// autoscaler aims for.
+	TargetCPUPercentage int
+}
+
+// enabled reports whether autoscaling has been requested.
+func (p autoscalerParams) enabled() bool {
+	return p.MaxReplicas > 0
+}
+
+// autoscalerConfig reads the autoscaler settings from the application
+// config, and checks that they are usable with the given scale.
+func autoscalerConfig(config application.ConfigAttributes, numUnits int) (autoscalerParams, error) {
+	p := autoscalerParams{
+		MaxReplicas:         config.GetInt(autoscalerMaxReplicasKey, 0),
+		TargetCPUPercentage: config.GetInt(autoscalerTargetCPUKey, defaultAutoscalerTargetCPU),
+	}
+	if p.MaxReplicas < 0 {
+		return autoscalerParams{}, errors.NotValidf("%s %d", autoscalerMaxReplicasKey, p.MaxReplicas)
+	}
+	if !p.enabled() {
+		return p, nil
+	}
+	if p.MaxReplicas < numUnits {
+		return autoscalerParams{}, errors.Errorf(
+			"%s %d is less than the application scale %d", autoscalerMaxReplicasKey, p.MaxReplicas, numUnits,
+		)
+	}
+	if p.TargetCPUPercentage <= 0 {
+		return autoscalerParams{}, errors.NotValidf("%s %d", autoscalerTargetCPUKey, p.TargetCPUPercentage)
+	}
+	return p, nil
+}
+
+// autoscalerSpec returns the horizontal pod autoscaler for the
+// application's deployment. The application scale is used as the
+// minimum number of pods, so that Juju and the autoscaler agree on
+// the floor and the autoscaler alone decides how far above it to go.
+func autoscalerSpec(appName string, numUnits int, p autoscalerParams) *autoscaling.HorizontalPodAutoscaler {
+	minReplicas := int32(numUnits)
+	targetCPU := int32(p.TargetCPUPercentage)
+	return &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: map[string]string{labelApplication: appName}},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				Kind:       "Deployment",
+				Name:       deploymentName(appName),
+				APIVersion: "extensions/v1beta1",
+			},
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    int32(p.MaxReplicas),
+			TargetCPUUtilizationPercentage: &targetCPU,
+		},
+	}
+}
+
+// autoscaledReplicas returns the number of pods the application's
+// deployment should be given. While an autoscaler is in charge, it
+// may have scaled the deployment up beyond the application scale;
+// that choice is kept rather than fought over.
+func (k *kubernetesClient) autoscaledReplicas(appName string, numUnits int) int32 {
+	replicas := int32(numUnits)
+	existing, err := k.ExtensionsV1beta1().Deployments(k.namespace).Get(deploymentName(appName))
+	if err != nil {
+		return replicas
+	}
+	if existing.Spec.Replicas != nil && *existing.Spec.Replicas > replicas {
+		replicas = *existing.Spec.Replicas
+	}
+	return replicas
+}
+
+// ensureAutoscaler creates or updates the given autoscaler, and
+// reports whether it was created.
+func (k *kubernetesClient) ensureAutoscaler(spec *autoscaling.HorizontalPodAutoscaler) (created bool, err error) {
+	autoscalers := k.AutoscalingV1().HorizontalPodAutoscalers(k.namespace)
+	existing, err := autoscalers.Get(spec.Name)
+	if err == nil {
+		spec.ObjectMeta.ResourceVersion = existing.ObjectMeta.ResourceVersion
+	}
+	_, err = autoscalers.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = autoscalers.Create(spec)
+		created = err == nil
+	}
+	return created, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteAutoscaler(appName string) error {
+	orphanDependents := false
+	autoscalers := k.AutoscalingV1().HorizontalPodAutoscalers(k.namespace)
+	err := autoscalers.Delete(deploymentName(appName), &v1.DeleteOptions{OrphanDependents: &orphanDependents})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
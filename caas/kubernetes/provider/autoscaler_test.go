@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/client-go/pkg/api/v1"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/testing"
+)
+
+type AutoscalerSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&AutoscalerSuite{})
+
+func (s *AutoscalerSuite) TestAutoscalerConfigDisabled(c *gc.C) {
+	p, err := autoscalerConfig(application.ConfigAttributes{}, 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.enabled(), jc.IsFalse)
+}
+
+func (s *AutoscalerSuite) TestAutoscalerConfig(c *gc.C) {
+	p, err := autoscalerConfig(application.ConfigAttributes{
+		autoscalerMaxReplicasKey: 10,
+		autoscalerTargetCPUKey:   50,
+	}, 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.enabled(), jc.IsTrue)
+	c.Assert(p, jc.DeepEquals, autoscalerParams{MaxReplicas: 10, TargetCPUPercentage: 50})
+}
+
+func (s *AutoscalerSuite) TestAutoscalerConfigDefaultTargetCPU(c *gc.C) {
+	p, err := autoscalerConfig(application.ConfigAttributes{
+		autoscalerMaxReplicasKey: 10,
+	}, 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.TargetCPUPercentage, gc.Equals, defaultAutoscalerTargetCPU)
+}
+
+func (s *AutoscalerSuite) TestAutoscalerConfigErrors(c *gc.C) {
+	for i, test := range []struct {
+		config application.ConfigAttributes
+		err    string
+	}{{
+		config: application.ConfigAttributes{autoscalerMaxReplicasKey: -1},
+		err:    "kubernetes-autoscaler-max-replicas -1 not valid",
+	}, {
+		config: application.ConfigAttributes{autoscalerMaxReplicasKey: 2},
+		err:    "kubernetes-autoscaler-max-replicas 2 is less than the application scale 3",
+	}, {
+		config: application.ConfigAttributes{
+			autoscalerMaxReplicasKey: 10,
+			autoscalerTargetCPUKey:   0,
+		},
+		err: "kubernetes-autoscaler-target-cpu-percentage 0 not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := autoscalerConfig(test.config, 3)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AutoscalerSuite) TestAutoscalerSpec(c *gc.C) {
+	spec := autoscalerSpec("gitlab", 3, autoscalerParams{MaxReplicas: 10, TargetCPUPercentage: 50})
+	minReplicas := int32(3)
+	targetCPU := int32(50)
+	c.Assert(spec, jc.DeepEquals, &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-gitlab",
+			Labels: map[string]string{labelApplication: "gitlab"}},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				Kind:       "Deployment",
+				Name:       "juju-gitlab",
+				APIVersion: "extensions/v1beta1",
+			},
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    10,
+			TargetCPUUtilizationPercentage: &targetCPU,
+		},
+	})
+}
@@ -15,6 +15,7 @@ const (
 	defaultIngressSSLRedirect    = false
 	defaultIngressSSLPassthrough = false
 	defaultIngressAllowHTTPKey   = false
+	defaultAutoscalerTargetCPU   = 80
 
 	serviceTypeConfigKey               = "kubernetes-service-type"
 	serviceExternalIPsConfigKey        = "kubernetes-service-external-ips"
@@ -27,6 +28,9 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	autoscalerMaxReplicasKey = "kubernetes-autoscaler-max-replicas"
+	autoscalerTargetCPUKey   = "kubernetes-autoscaler-target-cpu-percentage"
 )
 
 var configFields = environschema.Fields{
@@ -80,6 +84,16 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	autoscalerMaxReplicasKey: {
+		Description: "the maximum number of pods a horizontal pod autoscaler may run; 0 disables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalerTargetCPUKey: {
+		Description: "the average CPU utilisation, as a percentage of requested CPU, the autoscaler aims for",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -88,6 +102,7 @@ var schemaDefaults = schema.Defaults{
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+	autoscalerTargetCPUKey:   defaultAutoscalerTargetCPU,
 }
 
 // ConfigSchema returns the configuration schema for
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+
+	"github.com/juju/juju/constraints"
+)
+
+const (
+	// archNodeLabel is the node label holding the
+	// architecture of the node, in Kubernetes' terms.
+	archNodeLabel = "kubernetes.io/arch"
+
+	// affinityAnnotation is the annotation used to specify the
+	// affinity of a pod; the version of the Kubernetes API we use
+	// does not have an affinity field in the pod spec.
+	affinityAnnotation = "scheduler.alpha.kubernetes.io/affinity"
+)
+
+// unsupportedConstraints are the constraints which
+// have no meaning for pods scheduled by Kubernetes.
+var unsupportedConstraints = []string{
+	constraints.Container,
+	constraints.CpuPower,
+	constraints.InstanceType,
+	constraints.RootDisk,
+	constraints.Spaces,
+	constraints.VirtType,
+}
+
+// kubernetesArches maps Juju architecture names
+// to those used by Kubernetes.
+var kubernetesArches = map[string]string{
+	arch.AMD64:   "amd64",
+	arch.I386:    "386",
+	arch.ARM:     "arm",
+	arch.ARM64:   "arm64",
+	arch.PPC64EL: "ppc64le",
+	arch.S390X:   "s390x",
+}
+
+// ConstraintsValidator returns a constraints.Validator used to
+// validate and merge the constraints of applications deployed
+// to Kubernetes models.
+func ConstraintsValidator() constraints.Validator {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	arches := make([]string, 0, len(kubernetesArches))
+	for a := range kubernetesArches {
+		arches = append(arches, a)
+	}
+	sort.Strings(arches)
+	validator.RegisterVocabulary(constraints.Arch, arches)
+	return validator
+}
+
+// applyConstraints updates the unit spec so that the pods are given
+// the resources, and are scheduled onto nodes, satisfying the given
+// constraints.
+//
+// The mem and cores constraints become both the requests and limits
+// of the first container's memory and cpu, overriding any set in the
+// pod spec, so that the pod is given the resources of a machine which
+// satisfies the constraints. The arch constraint selects nodes by their
+// architecture label, and tags select nodes by label: a "key=value" tag
+// requires the label to have that value, a "key" tag requires the label
+// to be present, and tags prefixed with "^" exclude matching nodes.
+func (u *unitSpec) applyConstraints(cons constraints.Value) error {
+	if len(u.Pod.Containers) > 0 {
+		resources := make(v1.ResourceList)
+		if cons.HasMem() {
+			resources[v1.ResourceMemory] = resource.MustParse(fmt.Sprintf("%dMi", *cons.Mem))
+		}
+		if cons.HasCpuCores() {
+			resources[v1.ResourceCPU] = resource.MustParse(fmt.Sprint(*cons.CpuCores))
+		}
+		container := &u.Pod.Containers[0]
+		for name, quantity := range resources {
+			if container.Resources.Requests == nil {
+				container.Resources.Requests = make(v1.ResourceList)
+			}
+			if container.Resources.Limits == nil {
+				container.Resources.Limits = make(v1.ResourceList)
+			}
+			container.Resources.Requests[name] = quantity
+			container.Resources.Limits[name] = quantity
+		}
+	}
+
+	nodeSelector := make(map[string]string)
+	if cons.HasArch() {
+		kubernetesArch, ok := kubernetesArches[*cons.Arch]
+		if !ok {
+			return errors.NotSupportedf("architecture %q", *cons.Arch)
+		}
+		nodeSelector[archNodeLabel] = kubernetesArch
+	}
+	var requirements []v1.NodeSelectorRequirement
+	if cons.Tags != nil {
+		for _, tag := range *cons.Tags {
+			exclude := strings.HasPrefix(tag, "^")
+			key, value := tag, ""
+			if exclude {
+				key = key[1:]
+			}
+			hasValue := strings.Contains(key, "=")
+			if hasValue {
+				parts := strings.SplitN(key, "=", 2)
+				key, value = parts[0], parts[1]
+			}
+			if key == "" {
+				return errors.NotValidf("tag %q", tag)
+			}
+			switch {
+			case !exclude && hasValue:
+				if existing, ok := nodeSelector[key]; ok && existing != value {
+					return errors.Errorf("conflicting values %q and %q for node label %q", existing, value, key)
+				}
+				nodeSelector[key] = value
+			case !exclude:
+				requirements = append(requirements, v1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: v1.NodeSelectorOpExists,
+				})
+			case hasValue:
+				requirements = append(requirements, v1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: v1.NodeSelectorOpNotIn,
+					Values:   []string{value},
+				})
+			default:
+				requirements = append(requirements, v1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: v1.NodeSelectorOpDoesNotExist,
+				})
+			}
+		}
+	}
+	if len(nodeSelector) > 0 {
+		u.Pod.NodeSelector = nodeSelector
+	}
+	if len(requirements) > 0 {
+		affinity := v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{{
+						MatchExpressions: requirements,
+					}},
+				},
+			},
+		}
+		data, err := json.Marshal(affinity)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if u.Annotations == nil {
+			u.Annotations = make(map[string]string)
+		}
+		u.Annotations[affinityAnnotation] = string(data)
+	}
+	return nil
+}
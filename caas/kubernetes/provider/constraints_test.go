@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type ConstraintsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ConstraintsSuite{})
+
+func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
+	validator := ConstraintsValidator()
+	cons := constraints.MustParse("mem=1G cores=2 tags=foo root-disk=10G instance-type=large")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"root-disk", "instance-type"})
+}
+
+func (s *ConstraintsSuite) TestConstraintsValidatorArch(c *gc.C) {
+	validator := ConstraintsValidator()
+	_, err := validator.Validate(constraints.MustParse("arch=ppc64el"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("arch=mips"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: arch=mips\nvalid values are: .*`)
+}
+
+func (s *ConstraintsSuite) TestMakeUnitSpecConstraints(c *gc.C) {
+	spec := &caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+			Resources: &caas.ResourceRequirements{
+				Requests: map[string]string{"memory": "256Mi"},
+				Limits:   map[string]string{"cpu": "500m"},
+			},
+		}, {
+			Name:      "sidecar",
+			ImageName: "sidecar/latest",
+		}},
+	}
+	cons := constraints.MustParse("mem=1G cores=2 arch=arm64 tags=pool=gpu,ssd,^zone=a,^spot")
+	unitSpec, err := makeUnitSpec("gitlab", spec, cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(unitSpec.Pod.Containers, gc.HasLen, 2)
+	c.Assert(unitSpec.Pod.Containers[0].Resources, jc.DeepEquals, v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceMemory: resource.MustParse("1024Mi"),
+			v1.ResourceCPU:    resource.MustParse("2"),
+		},
+		Limits: v1.ResourceList{
+			v1.ResourceMemory: resource.MustParse("1024Mi"),
+			v1.ResourceCPU:    resource.MustParse("2"),
+		},
+	})
+	c.Assert(unitSpec.Pod.Containers[1].Resources, jc.DeepEquals, v1.ResourceRequirements{})
+	c.Assert(unitSpec.Pod.NodeSelector, jc.DeepEquals, map[string]string{
+		"kubernetes.io/arch": "arm64",
+		"pool":               "gpu",
+	})
+	c.Assert(unitSpec.Annotations[affinityAnnotation], jc.JSONEquals, v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{
+						{Key: "ssd", Operator: v1.NodeSelectorOpExists},
+						{Key: "zone", Operator: v1.NodeSelectorOpNotIn, Values: []string{"a"}},
+						{Key: "spot", Operator: v1.NodeSelectorOpDoesNotExist},
+					},
+				}},
+			},
+		},
+	})
+}
+
+func (s *ConstraintsSuite) TestMakeUnitSpecNoConstraints(c *gc.C) {
+	spec := &caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+		}},
+	}
+	unitSpec, err := makeUnitSpec("gitlab", spec, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitSpec.Pod.Containers[0].Resources, jc.DeepEquals, v1.ResourceRequirements{})
+	c.Assert(unitSpec.Pod.NodeSelector, gc.IsNil)
+	c.Assert(unitSpec.Annotations, gc.IsNil)
+}
+
+func (s *ConstraintsSuite) TestMakeUnitSpecConflictingTags(c *gc.C) {
+	spec := &caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+		}},
+	}
+	cons := constraints.MustParse("tags=pool=gpu,pool=cpu")
+	_, err := makeUnitSpec("gitlab", spec, cons)
+	c.Assert(err, gc.ErrorMatches, `applying constraints: conflicting values "gpu" and "cpu" for node label "pool"`)
+}
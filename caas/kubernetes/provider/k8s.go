@@ -4,11 +4,10 @@
 package provider
 
 import (
-	"bytes"
 	"fmt"
 	"strconv"
 	"strings"
-	"text/template"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -20,25 +19,24 @@ import (
 	k8serrors "k8s.io/client-go/pkg/api/errors"
 	"k8s.io/client-go/pkg/api/unversioned"
 	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 	"k8s.io/client-go/pkg/util/intstr"
-	"k8s.io/client-go/pkg/util/yaml"
 	"k8s.io/client-go/rest"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/watcher"
 )
 
 var logger = loggo.GetLogger("juju.kubernetes.provider")
 
 const (
-	// TODO(caas) should be using a juju specific namespace
-	namespace = "default"
-
 	labelApplication = "juju-application"
 	labelUnit        = "juju-unit"
 )
@@ -47,11 +45,41 @@ const (
 
 type kubernetesClient struct {
 	*kubernetes.Clientset
+
+	// namespace is the namespace holding all of the
+	// model's resources. It is named after the model's
+	// UUID, see namespaceName, except for models created
+	// before each was given its own, see legacyNamespace.
+	namespace string
+
+	// modelUUID is the UUID of the model, used to
+	// identify the namespace as belonging to it.
+	modelUUID string
+
+	// lock protects quota, which changes along
+	// with the model config.
+	lock sync.Mutex
+
+	// quota is the hard resource quota of the namespace.
+	quota v1.ResourceList
 }
 
-// NewK8sProvider returns a kubernetes client for the specified cloud.
-func NewK8sProvider(cloudSpec environs.CloudSpec) (caas.Broker, error) {
-	config, err := newK8sConfig(cloudSpec)
+// NewK8sProvider returns a kubernetes client for the model with the
+// specified config, running in the specified cloud. Each model is
+// given its own namespace when it is created.
+func NewK8sProvider(args environs.OpenParams) (caas.Broker, error) {
+	if args.Config == nil {
+		return nil, errors.NotValidf("nil model config")
+	}
+	cfg, err := ModelConfigValidator().Validate(args.Config, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	quota, err := namespaceQuota(cfg.UnknownAttrs())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, err := newK8sConfig(args.Cloud)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -59,7 +87,12 @@ func NewK8sProvider(cloudSpec environs.CloudSpec) (caas.Broker, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &kubernetesClient{client}, nil
+	return &kubernetesClient{
+		Clientset: client,
+		namespace: modelNamespace(cfg.UnknownAttrs()),
+		modelUUID: cfg.UUID(),
+		quota:     quota,
+	}, nil
 }
 
 func newK8sConfig(cloudSpec environs.CloudSpec) (*rest.Config, error) {
@@ -90,6 +123,13 @@ func newK8sConfig(cloudSpec environs.CloudSpec) (*rest.Config, error) {
 func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caas.OperatorConfig) error {
 	logger.Debugf("creating/updating %s operator", appName)
 
+	if err := k.ensureNamespace(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.ensureServiceAccount(operatorServiceAccount(appName)); err != nil {
+		return errors.Annotate(err, "creating or updating operator service account")
+	}
+
 	// TODO(caas) use secrets for storing agent password?
 	if err := k.ensureConfigMap(operatorConfigMap(appName, config)); err != nil {
 		return errors.Annotate(err, "creating or updating ConfigMap")
@@ -108,22 +148,48 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteService(appName); err != nil {
 		return errors.Trace(err)
 	}
-	return errors.Trace(k.deleteDeployment(appName))
+	if err := k.deleteAutoscaler(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteStatefulSet(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteDeployment(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteConfigMaps(appName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteServiceAccount(workloadServiceAccountName(appName)))
 }
 
-// EnsureService creates or updates a service for pods with the given spec.
+// EnsureService creates or updates a service for pods with the given params.
+// Applications with storage are run as a StatefulSet so that each pod
+// keeps its persistent volumes when it is rescheduled; all others are
+// run as a Deployment. If the application config asks for autoscaling,
+// a horizontal pod autoscaler is added which may run more than numUnits
+// pods.
 func (k *kubernetesClient) EnsureService(
-	appName string, spec *caas.ContainerSpec, numUnits int, config application.ConfigAttributes,
+	appName string, params *caas.ServiceParams, numUnits int, config application.ConfigAttributes,
 ) (err error) {
 	logger.Debugf("creating/updating application %s", appName)
 
 	if numUnits <= 0 {
 		return errors.Errorf("number of units must be > 0")
 	}
-	if spec == nil {
+	if params == nil || params.PodSpec == nil {
 		return errors.Errorf("missing container spec")
 	}
+	autoscaler, err := autoscalerConfig(config, numUnits)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if autoscaler.enabled() && len(params.Filesystems) > 0 {
+		return errors.NotSupportedf("autoscaling applications with storage")
+	}
 
+	// Only resources created by this call are cleaned up on error;
+	// those which already existed belong to a running application.
 	var cleanups []func()
 	defer func() {
 		if err == nil {
@@ -134,15 +200,51 @@ func (k *kubernetesClient) EnsureService(
 		}
 	}()
 
-	unitSpec, err := makeUnitSpec(spec)
+	unitSpec, err := makeUnitSpec(appName, params.PodSpec, params.Constraints)
 	if err != nil {
 		return errors.Annotatef(err, "parsing unit spec for %s", appName)
 	}
+	if err := k.ensureNamespace(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.ensureWorkloadServiceAccount(appName, unitSpec); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.ensureConfigMaps(appName, unitSpec); err != nil {
+		return errors.Trace(err)
+	}
 	numPods := int32(numUnits)
-	if err := k.configureDeployment(appName, unitSpec, &numPods); err != nil {
-		return errors.Annotate(err, "creating or updating deployment controller")
+	if len(params.Filesystems) > 0 {
+		created, err := k.configureStatefulSet(appName, unitSpec, params.Filesystems, &numPods)
+		if err != nil {
+			return errors.Annotate(err, "creating or updating stateful set")
+		}
+		if created {
+			cleanups = append(cleanups, func() { k.deleteStatefulSet(appName) })
+		}
+	} else {
+		if autoscaler.enabled() {
+			numPods = k.autoscaledReplicas(appName, numUnits)
+		}
+		created, err := k.configureDeployment(appName, unitSpec, &numPods)
+		if err != nil {
+			return errors.Annotate(err, "creating or updating deployment controller")
+		}
+		if created {
+			cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
+		}
+	}
+	if autoscaler.enabled() {
+		created, err := k.ensureAutoscaler(autoscalerSpec(appName, numUnits, autoscaler))
+		if err != nil {
+			return errors.Annotate(err, "creating or updating autoscaler")
+		}
+		if created {
+			cleanups = append(cleanups, func() { k.deleteAutoscaler(appName) })
+		}
+	} else if err := k.deleteAutoscaler(appName); err != nil {
+		return errors.Trace(err)
 	}
-	cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 
 	var ports []v1.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
@@ -159,7 +261,9 @@ func (k *kubernetesClient) EnsureService(
 	return nil
 }
 
-func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpec, replicas *int32) error {
+// configureDeployment creates or updates the application's deployment,
+// and reports whether it was created.
+func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpec, replicas *int32) (bool, error) {
 	logger.Debugf("creating/updating deployment for %s", appName)
 
 	namePrefix := resourceNamePrefix(appName)
@@ -176,6 +280,7 @@ func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpe
 				ObjectMeta: v1.ObjectMeta{
 					GenerateName: namePrefix,
 					Labels:       map[string]string{labelApplication: appName},
+					Annotations:  unitSpec.Annotations,
 				},
 				Spec: unitSpec.Pod,
 			},
@@ -184,18 +289,19 @@ func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpe
 	return k.ensureDeployment(deployment)
 }
 
-func (k *kubernetesClient) ensureDeployment(spec *v1beta1.Deployment) error {
-	deployments := k.ExtensionsV1beta1().Deployments(namespace)
-	_, err := deployments.Update(spec)
+func (k *kubernetesClient) ensureDeployment(spec *v1beta1.Deployment) (created bool, err error) {
+	deployments := k.ExtensionsV1beta1().Deployments(k.namespace)
+	_, err = deployments.Update(spec)
 	if k8serrors.IsNotFound(err) {
 		_, err = deployments.Create(spec)
+		created = err == nil
 	}
-	return errors.Trace(err)
+	return created, errors.Trace(err)
 }
 
 func (k *kubernetesClient) deleteDeployment(appName string) error {
 	orphanDependents := false
-	deployments := k.ExtensionsV1beta1().Deployments(namespace)
+	deployments := k.ExtensionsV1beta1().Deployments(k.namespace)
 	err := deployments.Delete(deploymentName(appName), &v1.DeleteOptions{OrphanDependents: &orphanDependents})
 	if k8serrors.IsNotFound(err) {
 		return nil
@@ -203,6 +309,70 @@ func (k *kubernetesClient) deleteDeployment(appName string) error {
 	return errors.Trace(err)
 }
 
+// configureStatefulSet creates or updates the application's stateful
+// set, and reports whether it was created.
+func (k *kubernetesClient) configureStatefulSet(
+	appName string, unitSpec *unitSpec, filesystems []storage.KubernetesFilesystemParams, replicas *int32,
+) (bool, error) {
+	logger.Debugf("creating/updating stateful set for %s", appName)
+
+	claims, mounts, err := k.filesystemClaims(appName, filesystems)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	podSpec := unitSpec.Pod
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, mounts...)
+	}
+
+	namePrefix := resourceNamePrefix(appName)
+	statefulSet := &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: map[string]string{labelApplication: appName}},
+		Spec: apps.StatefulSetSpec{
+			Replicas: replicas,
+			Selector: &unversioned.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: namePrefix,
+					Labels:       map[string]string{labelApplication: appName},
+					Annotations:  unitSpec.Annotations,
+				},
+				Spec: podSpec,
+			},
+			VolumeClaimTemplates: claims,
+			ServiceName:          deploymentName(appName),
+		},
+	}
+	return k.ensureStatefulSet(statefulSet)
+}
+
+func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet) (created bool, err error) {
+	statefulSets := k.AppsV1beta1().StatefulSets(k.namespace)
+	_, err = statefulSets.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = statefulSets.Create(spec)
+		created = err == nil
+	}
+	return created, errors.Trace(err)
+}
+
+// deleteStatefulSet deletes the application's stateful set. The
+// persistent volume claims made for it are left alone, as they
+// hold the application's data.
+func (k *kubernetesClient) deleteStatefulSet(appName string) error {
+	orphanDependents := false
+	statefulSets := k.AppsV1beta1().StatefulSets(k.namespace)
+	err := statefulSets.Delete(deploymentName(appName), &v1.DeleteOptions{OrphanDependents: &orphanDependents})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func (k *kubernetesClient) configureService(appName string, containerPorts []v1.ContainerPort, config application.ConfigAttributes) error {
 	logger.Debugf("creating/updating service for %s", appName)
 
@@ -242,7 +412,7 @@ func (k *kubernetesClient) configureService(appName string, containerPorts []v1.
 }
 
 func (k *kubernetesClient) ensureService(spec *v1.Service) error {
-	services := k.CoreV1().Services(namespace)
+	services := k.CoreV1().Services(k.namespace)
 	// Set any immutable fields if the service already exists.
 	existing, err := services.Get(spec.Name)
 	if err == nil {
@@ -258,7 +428,7 @@ func (k *kubernetesClient) ensureService(spec *v1.Service) error {
 
 func (k *kubernetesClient) deleteService(appName string) error {
 	orphanDependents := false
-	services := k.CoreV1().Services(namespace)
+	services := k.CoreV1().Services(k.namespace)
 	err := services.Delete(deploymentName(appName), &v1.DeleteOptions{OrphanDependents: &orphanDependents})
 	if k8serrors.IsNotFound(err) {
 		return nil
@@ -286,7 +456,7 @@ func (k *kubernetesClient) ExposeService(appName string, config application.Conf
 		httpPath = "/" + httpPath
 	}
 
-	svc, err := k.CoreV1().Services(namespace).Get(deploymentName(appName))
+	svc, err := k.CoreV1().Services(k.namespace).Get(deploymentName(appName))
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -328,7 +498,7 @@ func (k *kubernetesClient) UnexposeService(appName string) error {
 }
 
 func (k *kubernetesClient) ensureIngress(spec *v1beta1.Ingress) error {
-	ingress := k.ExtensionsV1beta1().Ingresses(namespace)
+	ingress := k.ExtensionsV1beta1().Ingresses(k.namespace)
 	_, err := ingress.Update(spec)
 	if k8serrors.IsNotFound(err) {
 		_, err = ingress.Create(spec)
@@ -338,7 +508,7 @@ func (k *kubernetesClient) ensureIngress(spec *v1beta1.Ingress) error {
 
 func (k *kubernetesClient) deleteIngress(appName string) error {
 	orphanDependents := false
-	ingress := k.ExtensionsV1beta1().Ingresses(namespace)
+	ingress := k.ExtensionsV1beta1().Ingresses(k.namespace)
 	err := ingress.Delete(deploymentName(appName), &v1.DeleteOptions{OrphanDependents: &orphanDependents})
 	if k8serrors.IsNotFound(err) {
 		return nil
@@ -353,7 +523,7 @@ func applicationSelector(appName string) string {
 // WatchUnits returns a watcher which notifies when there
 // are changes to units of the specified application.
 func (k *kubernetesClient) WatchUnits(appName string) (watcher.NotifyWatcher, error) {
-	pods := k.CoreV1().Pods(namespace)
+	pods := k.CoreV1().Pods(k.namespace)
 	w, err := pods.Watch(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
 		Watch:         true,
@@ -366,7 +536,7 @@ func (k *kubernetesClient) WatchUnits(appName string) (watcher.NotifyWatcher, er
 
 // Units returns all units of the specified application.
 func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
-	pods := k.CoreV1().Pods(namespace)
+	pods := k.CoreV1().Pods(k.namespace)
 	podsList, err := pods.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
 	})
@@ -386,6 +556,13 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 		if dying {
 			continue
 		}
+		filesystems, err := k.podFilesystems(&p)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting filesystems for pod %q", p.Name)
+		}
+		for i := range filesystems {
+			filesystems[i].Status.Since = &now
+		}
 		result = append(result, caas.Unit{
 			Id:      string(p.UID),
 			Address: p.Status.PodIP,
@@ -395,6 +572,7 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 				Message: p.Status.Message,
 				Since:   &now,
 			},
+			FilesystemInfo: filesystems,
 		})
 	}
 	return result, nil
@@ -412,12 +590,18 @@ func (k *kubernetesClient) jujuStatus(podPhase v1.PodPhase) status.Status {
 }
 
 // EnsureUnit creates or updates a unit pod with the given unit name and spec.
-func (k *kubernetesClient) EnsureUnit(appName, unitName string, spec *caas.ContainerSpec) error {
+func (k *kubernetesClient) EnsureUnit(appName, unitName string, spec *caas.PodSpec) error {
 	logger.Debugf("creating/updating unit %s", unitName)
-	unitSpec, err := makeUnitSpec(spec)
+	unitSpec, err := makeUnitSpec(appName, spec, constraints.Value{})
 	if err != nil {
 		return errors.Annotatef(err, "parsing spec for %s", unitName)
 	}
+	if err := k.ensureWorkloadServiceAccount(appName, unitSpec); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.ensureConfigMaps(appName, unitSpec); err != nil {
+		return errors.Trace(err)
+	}
 	podName := unitPodName(unitName)
 	if err := k.deletePod(podName); err != nil {
 		return errors.Trace(err)
@@ -427,14 +611,15 @@ func (k *kubernetesClient) EnsureUnit(appName, unitName string, spec *caas.Conta
 			Name: podName,
 			Labels: map[string]string{
 				labelApplication: appName,
-				labelUnit:        unitName}},
+				labelUnit:        unitName},
+			Annotations: unitSpec.Annotations},
 		Spec: unitSpec.Pod,
 	}
 	return k.createPod(pod)
 }
 
 func (k *kubernetesClient) ensureConfigMap(configMap *v1.ConfigMap) error {
-	configMaps := k.CoreV1().ConfigMaps(namespace)
+	configMaps := k.CoreV1().ConfigMaps(k.namespace)
 	_, err := configMaps.Update(configMap)
 	if k8serrors.IsNotFound(err) {
 		_, err = configMaps.Create(configMap)
@@ -443,14 +628,14 @@ func (k *kubernetesClient) ensureConfigMap(configMap *v1.ConfigMap) error {
 }
 
 func (k *kubernetesClient) createPod(spec *v1.Pod) error {
-	pods := k.CoreV1().Pods(namespace)
+	pods := k.CoreV1().Pods(k.namespace)
 	_, err := pods.Create(spec)
 	return errors.Trace(err)
 }
 
 func (k *kubernetesClient) deletePod(podName string) error {
 	orphanDependents := false
-	pods := k.CoreV1().Pods(namespace)
+	pods := k.CoreV1().Pods(k.namespace)
 	err := pods.Delete(podName, &v1.DeleteOptions{
 		OrphanDependents: &orphanDependents,
 	})
@@ -498,6 +683,7 @@ func operatorPod(appName, agentPath string) *v1.Pod {
 	return &v1.Pod{
 		ObjectMeta: v1.ObjectMeta{Name: podName},
 		Spec: v1.PodSpec{
+			ServiceAccountName: operatorServiceAccountName(appName),
 			Containers: []v1.Container{{
 				Name:            "juju-operator",
 				ImagePullPolicy: v1.PullIfNotPresent,
@@ -543,47 +729,6 @@ func operatorConfigMap(appName string, config *caas.OperatorConfig) *v1.ConfigMa
 	}
 }
 
-type unitSpec struct {
-	Pod v1.PodSpec `json:"pod"`
-}
-
-var defaultPodTemplate = `
-pod:
-  containers:
-  - name: {{.Name}}
-    image: {{.ImageName}}
-    {{if .Ports}}
-    ports:
-    {{- range .Ports }}
-        - containerPort: {{.ContainerPort}}
-          {{if .Protocol}}protocol: {{.Protocol}}{{end}}
-    {{- end}}
-    {{end}}
-    {{if .Config}}
-    env:
-    {{- range $k, $v := .Config }}
-        - name: {{$k}}
-          value: {{$v}}
-    {{- end}}
-    {{end}}
-`[1:]
-
-func makeUnitSpec(containerSpec *caas.ContainerSpec) (*unitSpec, error) {
-	tmpl := template.Must(template.New("").Parse(defaultPodTemplate))
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, containerSpec); err != nil {
-		return nil, errors.Trace(err)
-	}
-	unitSpecString := buf.String()
-
-	var unitSpec unitSpec
-	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(unitSpecString), len(unitSpecString))
-	if err := decoder.Decode(&unitSpec); err != nil {
-		return nil, errors.Trace(err)
-	}
-	return &unitSpec, nil
-}
-
 func operatorPodName(appName string) string {
 	return "juju-operator-" + appName
 }
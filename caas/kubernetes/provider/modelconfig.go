@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// modelConfigSchema holds the model config attributes
+// specific to Kubernetes models.
+var modelConfigSchema = environschema.Fields{
+	namespaceKey: {
+		Description: "the namespace holding the model's resources, set when the model is created",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+		Immutable:   true,
+	},
+	namespaceMaxPodsKey: {
+		Description: "the most pods the model's namespace may run",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	namespaceMaxCPUKey: {
+		Description: "the most CPU the pods in the model's namespace may request, e.g. 4 or 500m",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	namespaceMaxMemoryKey: {
+		Description: "the most memory the pods in the model's namespace may request, e.g. 8Gi",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	namespaceMaxStorageKey: {
+		Description: "the most storage the volume claims in the model's namespace may request, e.g. 100Gi",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+}
+
+var modelConfigFields = func() schema.Fields {
+	fs, _, err := modelConfigSchema.ValidationSchema()
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}()
+
+// The namespace is only given a quota for the attributes which are
+// set, so none have defaults. Models created before the namespace was
+// recorded have none, and use the legacy namespace.
+var modelConfigDefaults = schema.Defaults{
+	namespaceKey:           schema.Omit,
+	namespaceMaxPodsKey:    schema.Omit,
+	namespaceMaxCPUKey:     schema.Omit,
+	namespaceMaxMemoryKey:  schema.Omit,
+	namespaceMaxStorageKey: schema.Omit,
+}
+
+// modelConfig implements config.ConfigSchemaSource
+// and config.Validator for Kubernetes models.
+type modelConfig struct{}
+
+// ModelConfigValidator returns a config.Validator used to
+// validate the config of Kubernetes models.
+func ModelConfigValidator() config.Validator {
+	return modelConfig{}
+}
+
+// ModelConfigSchemaSource returns the source of the schema and
+// defaults of the model config attributes specific to Kubernetes
+// models.
+func ModelConfigSchemaSource() config.ConfigSchemaSource {
+	return modelConfig{}
+}
+
+// ConfigSchema is part of the config.ConfigSchemaSource interface.
+func (modelConfig) ConfigSchema() schema.Fields {
+	return modelConfigFields
+}
+
+// ConfigDefaults is part of the config.ConfigSchemaSource interface.
+func (modelConfig) ConfigDefaults() schema.Defaults {
+	return modelConfigDefaults
+}
+
+// Validate is part of the config.Validator interface.
+func (modelConfig) Validate(cfg, old *config.Config) (*config.Config, error) {
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, errors.Trace(err)
+	}
+	validated, err := cfg.ValidateUnknownAttrs(modelConfigFields, modelConfigDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if old != nil {
+		oldNamespace, _ := old.UnknownAttrs()[namespaceKey].(string)
+		newNamespace, _ := validated[namespaceKey].(string)
+		if oldNamespace != newNamespace {
+			return nil, errors.NotValidf("changing %s from %q to %q", namespaceKey, oldNamespace, newNamespace)
+		}
+	}
+	quota, err := namespaceQuota(validated)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(quota) > 0 && modelNamespace(validated) == legacyNamespace {
+		return nil, errors.NotSupportedf("setting a quota on the shared %q namespace", legacyNamespace)
+	}
+	return cfg.Apply(validated)
+}
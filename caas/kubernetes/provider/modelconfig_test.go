@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+type ModelConfigSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ModelConfigSuite{})
+
+func (s *ModelConfigSuite) newConfig(c *gc.C, attrs testing.Attrs) *config.Config {
+	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(attrs))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *ModelConfigSuite) TestValidate(c *gc.C) {
+	cfg := s.newConfig(c, testing.Attrs{
+		"kubernetes-namespace":             "juju-deadbeef",
+		"kubernetes-namespace-max-pods":    10,
+		"kubernetes-namespace-max-cpu":     "500m",
+		"kubernetes-namespace-max-memory":  "8Gi",
+		"kubernetes-namespace-max-storage": "100Gi",
+	})
+	validated, err := ModelConfigValidator().Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validated.UnknownAttrs(), jc.DeepEquals, map[string]interface{}{
+		"kubernetes-namespace":             "juju-deadbeef",
+		"kubernetes-namespace-max-pods":    10,
+		"kubernetes-namespace-max-cpu":     "500m",
+		"kubernetes-namespace-max-memory":  "8Gi",
+		"kubernetes-namespace-max-storage": "100Gi",
+	})
+}
+
+func (s *ModelConfigSuite) TestValidateNoQuota(c *gc.C) {
+	cfg := s.newConfig(c, nil)
+	validated, err := ModelConfigValidator().Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validated.UnknownAttrs(), gc.HasLen, 0)
+}
+
+func (s *ModelConfigSuite) TestValidateErrors(c *gc.C) {
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"kubernetes-namespace-max-pods": "lots"},
+		err:   `kubernetes-namespace-max-pods: expected int, got string\("lots"\)`,
+	}, {
+		attrs: testing.Attrs{"kubernetes-namespace-max-pods": -1},
+		err:   "negative kubernetes-namespace-max-pods -1 not valid",
+	}, {
+		attrs: testing.Attrs{"kubernetes-namespace-max-memory": "lots"},
+		err:   "parsing kubernetes-namespace-max-memory: .*",
+	}, {
+		attrs: testing.Attrs{"kubernetes-namespace-max-cpu": "-1"},
+		err:   "negative kubernetes-namespace-max-cpu -1 not valid",
+	}} {
+		c.Logf("test %d", i)
+		attrs := testing.Attrs{"kubernetes-namespace": "juju-deadbeef"}.Merge(test.attrs)
+		_, err := ModelConfigValidator().Validate(s.newConfig(c, attrs), nil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ModelConfigSuite) TestValidateNamespaceImmutable(c *gc.C) {
+	old := s.newConfig(c, testing.Attrs{"kubernetes-namespace": "juju-deadbeef"})
+	for i, attrs := range []testing.Attrs{
+		{"kubernetes-namespace": "juju-cafebabe"},
+		{},
+	} {
+		c.Logf("test %d", i)
+		_, err := ModelConfigValidator().Validate(s.newConfig(c, attrs), old)
+		c.Check(err, gc.ErrorMatches, `changing kubernetes-namespace from "juju-deadbeef" to ".*" not valid`)
+	}
+
+	// A model without a namespace keeps using the legacy one.
+	legacy := s.newConfig(c, nil)
+	_, err := ModelConfigValidator().Validate(s.newConfig(c, nil), legacy)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ModelConfigValidator().Validate(old, legacy)
+	c.Assert(err, gc.ErrorMatches, `changing kubernetes-namespace from "" to "juju-deadbeef" not valid`)
+}
+
+func (s *ModelConfigSuite) TestValidateLegacyNamespaceQuota(c *gc.C) {
+	cfg := s.newConfig(c, testing.Attrs{"kubernetes-namespace-max-pods": 10})
+	_, err := ModelConfigValidator().Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `setting a quota on the shared "default" namespace not supported`)
+}
+
+func (s *ModelConfigSuite) TestConfigSchema(c *gc.C) {
+	fields := ModelConfigSchemaSource().ConfigSchema()
+	for _, key := range []string{
+		"kubernetes-namespace",
+		"kubernetes-namespace-max-pods",
+		"kubernetes-namespace-max-cpu",
+		"kubernetes-namespace-max-memory",
+		"kubernetes-namespace-max-storage",
+	} {
+		c.Check(fields[key], gc.NotNil, gc.Commentf("%s", key))
+	}
+}
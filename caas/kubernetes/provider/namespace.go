@@ -0,0 +1,277 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	k8serrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+const (
+	// labelModel is the label identifying the
+	// model which owns a namespace.
+	labelModel = "juju-model-uuid"
+
+	// namespaceKey is the model config attribute holding the name
+	// of the model's namespace. It is set when the model is created
+	// and cannot be changed.
+	namespaceKey = "kubernetes-namespace"
+
+	// legacyNamespace is the namespace used by models created before
+	// each model was given its own, which have no namespaceKey. It is
+	// shared with other models and Kubernetes itself, so it is never
+	// created, given a quota or deleted by the model.
+	legacyNamespace = "default"
+
+	quotaName      = "juju-quota"
+	limitRangeName = "juju-limits"
+
+	// These model config attributes set the hard quota of the
+	// model's namespace. CPU, memory and storage are given as
+	// Kubernetes quantities, e.g. "4", "500m", "8Gi".
+	namespaceMaxPodsKey    = "kubernetes-namespace-max-pods"
+	namespaceMaxCPUKey     = "kubernetes-namespace-max-cpu"
+	namespaceMaxMemoryKey  = "kubernetes-namespace-max-memory"
+	namespaceMaxStorageKey = "kubernetes-namespace-max-storage"
+)
+
+// Once a namespace has a CPU or memory quota, Kubernetes rejects
+// containers which do not request those resources. Containers
+// without requests, such as operators, are given these defaults.
+var defaultContainerRequests = v1.ResourceList{
+	v1.ResourceCPU:    resource.MustParse("100m"),
+	v1.ResourceMemory: resource.MustParse("128Mi"),
+}
+
+var namespaceQuotaResources = map[string]v1.ResourceName{
+	namespaceMaxPodsKey:    v1.ResourcePods,
+	namespaceMaxCPUKey:     v1.ResourceRequestsCPU,
+	namespaceMaxMemoryKey:  v1.ResourceRequestsMemory,
+	namespaceMaxStorageKey: v1.ResourceRequestsStorage,
+}
+
+// namespaceName returns the name of the namespace holding the
+// resources of the model with the given UUID. The model's name
+// cannot be used: it is only unique to its owner on one controller,
+// and may clash with namespaces Kubernetes itself uses.
+func namespaceName(modelUUID string) string {
+	return "juju-" + modelUUID
+}
+
+// modelNamespace returns the name of the namespace holding the
+// resources of the model with the given config attributes.
+func modelNamespace(attrs map[string]interface{}) string {
+	if name, _ := attrs[namespaceKey].(string); name != "" {
+		return name
+	}
+	return legacyNamespace
+}
+
+// NewModelConfigAttrs returns the model config attributes which
+// give a new Kubernetes model with the given UUID its own namespace.
+func NewModelConfigAttrs(modelUUID string) map[string]interface{} {
+	return map[string]interface{}{
+		namespaceKey: namespaceName(modelUUID),
+	}
+}
+
+// namespaceQuota returns the hard resource quota
+// set by the given model config attributes.
+func namespaceQuota(attrs map[string]interface{}) (v1.ResourceList, error) {
+	quota := make(v1.ResourceList)
+	for key, name := range namespaceQuotaResources {
+		value, ok := attrs[key]
+		if !ok {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(fmt.Sprint(value))
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing %s", key)
+		}
+		if quantity.Sign() < 0 {
+			return nil, errors.NotValidf("negative %s %v", key, value)
+		}
+		quota[name] = quantity
+	}
+	return quota, nil
+}
+
+// namespaceLimitRange returns the limit range giving containers
+// default requests for the resources limited by the quota, or
+// nil if there are none.
+func namespaceLimitRange(quota v1.ResourceList) *v1.LimitRange {
+	defaults := make(v1.ResourceList)
+	for quotaResource, name := range map[v1.ResourceName]v1.ResourceName{
+		v1.ResourceRequestsCPU:    v1.ResourceCPU,
+		v1.ResourceRequestsMemory: v1.ResourceMemory,
+	} {
+		if _, ok := quota[quotaResource]; ok {
+			defaults[name] = defaultContainerRequests[name]
+		}
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+	return &v1.LimitRange{
+		ObjectMeta: v1.ObjectMeta{Name: limitRangeName},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{{
+				Type:           v1.LimitTypeContainer,
+				DefaultRequest: defaults,
+			}},
+		},
+	}
+}
+
+// checkNamespaceOwner returns an error if the namespace
+// does not belong to the model with the given UUID.
+func checkNamespaceOwner(ns *v1.Namespace, modelUUID string) error {
+	if ns.Labels[labelModel] != modelUUID {
+		return errors.Errorf("namespace %q is not owned by this model", ns.Name)
+	}
+	return nil
+}
+
+// ensureNamespace creates the model's namespace if it does not
+// already exist, and applies the model's quota to it. Namespaces
+// which were not created for the model are never used, except for
+// the legacy namespace, which is used as it is.
+func (k *kubernetesClient) ensureNamespace() error {
+	if k.namespace == legacyNamespace {
+		return nil
+	}
+	namespaces := k.CoreV1().Namespaces()
+	ns, err := namespaces.Get(k.namespace)
+	if k8serrors.IsNotFound(err) {
+		ns, err = namespaces.Create(&v1.Namespace{
+			ObjectMeta: v1.ObjectMeta{
+				Name:   k.namespace,
+				Labels: map[string]string{labelModel: k.modelUUID},
+			},
+		})
+		if k8serrors.IsAlreadyExists(err) {
+			// Created concurrently; check that it is ours.
+			ns, err = namespaces.Get(k.namespace)
+		}
+	}
+	if err != nil {
+		return errors.Annotatef(err, "creating namespace %q", k.namespace)
+	}
+	if err := checkNamespaceOwner(ns, k.modelUUID); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.ensureQuota())
+}
+
+// ensureQuota creates, updates or removes the namespace's
+// resource quota and limit range to match the model's quota.
+func (k *kubernetesClient) ensureQuota() error {
+	k.lock.Lock()
+	hard := k.quota
+	k.lock.Unlock()
+
+	quotas := k.CoreV1().ResourceQuotas(k.namespace)
+	if len(hard) == 0 {
+		err := quotas.Delete(quotaName, nil)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotate(err, "deleting resource quota")
+		}
+	} else {
+		quota := &v1.ResourceQuota{
+			ObjectMeta: v1.ObjectMeta{Name: quotaName},
+			Spec:       v1.ResourceQuotaSpec{Hard: hard},
+		}
+		_, err := quotas.Update(quota)
+		if k8serrors.IsNotFound(err) {
+			_, err = quotas.Create(quota)
+		}
+		if err != nil {
+			return errors.Annotate(err, "creating or updating resource quota")
+		}
+	}
+
+	limitRanges := k.CoreV1().LimitRanges(k.namespace)
+	limitRange := namespaceLimitRange(hard)
+	if limitRange == nil {
+		err := limitRanges.Delete(limitRangeName, nil)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotate(err, "deleting limit range")
+		}
+		return nil
+	}
+	_, err := limitRanges.Update(limitRange)
+	if k8serrors.IsNotFound(err) {
+		_, err = limitRanges.Create(limitRange)
+	}
+	return errors.Annotate(err, "creating or updating limit range")
+}
+
+// SetConfig is part of the caas.Broker interface. Changes to the
+// namespace quota are applied straight away if the namespace exists;
+// otherwise they are applied when it is created.
+func (k *kubernetesClient) SetConfig(cfg *config.Config) error {
+	cfg, err := ModelConfigValidator().Validate(cfg, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	quota, err := namespaceQuota(cfg.UnknownAttrs())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	k.lock.Lock()
+	k.quota = quota
+	k.lock.Unlock()
+
+	if k.namespace == legacyNamespace {
+		return nil
+	}
+	ns, err := k.CoreV1().Namespaces().Get(k.namespace)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkNamespaceOwner(ns, k.modelUUID); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.ensureQuota())
+}
+
+// Destroy is part of the caas.Broker interface. Deleting the model's
+// namespace deletes everything in it, including the service accounts,
+// roles and quota created for the model.
+func (k *kubernetesClient) Destroy() error {
+	if k.namespace == legacyNamespace {
+		// The legacy namespace is shared, so it is left as it is,
+		// along with the model's resources in it.
+		logger.Warningf("not deleting shared namespace %q", k.namespace)
+		return nil
+	}
+	logger.Debugf("deleting namespace %s", k.namespace)
+
+	namespaces := k.CoreV1().Namespaces()
+	ns, err := namespaces.Get(k.namespace)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkNamespaceOwner(ns, k.modelUUID); err != nil {
+		// The model never used the namespace, so there
+		// is nothing of the model's to clean up.
+		logger.Warningf("not deleting namespace: %v", err)
+		return nil
+	}
+	err = namespaces.Delete(k.namespace, nil)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type NamespaceSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&NamespaceSuite{})
+
+func (s *NamespaceSuite) TestNamespaceName(c *gc.C) {
+	c.Assert(namespaceName("deadbeef-0bad-400d-8000-4b1d0d06f00d"), gc.Equals, "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *NamespaceSuite) TestModelNamespace(c *gc.C) {
+	attrs := NewModelConfigAttrs("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Assert(attrs, jc.DeepEquals, map[string]interface{}{
+		"kubernetes-namespace": "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+	c.Assert(modelNamespace(attrs), gc.Equals, "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d")
+
+	// Models created before each was given its own
+	// namespace use the default namespace.
+	c.Assert(modelNamespace(map[string]interface{}{}), gc.Equals, "default")
+}
+
+func (s *NamespaceSuite) TestNamespaceQuota(c *gc.C) {
+	quota, err := namespaceQuota(map[string]interface{}{
+		"kubernetes-namespace-max-pods":    10,
+		"kubernetes-namespace-max-cpu":     "4",
+		"kubernetes-namespace-max-memory":  "8Gi",
+		"kubernetes-namespace-max-storage": "100Gi",
+		"unrelated":                        "value",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, jc.DeepEquals, v1.ResourceList{
+		v1.ResourcePods:            resource.MustParse("10"),
+		v1.ResourceRequestsCPU:     resource.MustParse("4"),
+		v1.ResourceRequestsMemory:  resource.MustParse("8Gi"),
+		v1.ResourceRequestsStorage: resource.MustParse("100Gi"),
+	})
+}
+
+func (s *NamespaceSuite) TestNamespaceQuotaNone(c *gc.C) {
+	quota, err := namespaceQuota(map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.HasLen, 0)
+	c.Assert(namespaceLimitRange(quota), gc.IsNil)
+}
+
+func (s *NamespaceSuite) TestNamespaceQuotaInvalid(c *gc.C) {
+	_, err := namespaceQuota(map[string]interface{}{
+		"kubernetes-namespace-max-memory": "lots",
+	})
+	c.Assert(err, gc.ErrorMatches, "parsing kubernetes-namespace-max-memory: .*")
+}
+
+func (s *NamespaceSuite) TestNamespaceQuotaNegative(c *gc.C) {
+	_, err := namespaceQuota(map[string]interface{}{
+		"kubernetes-namespace-max-pods": -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "negative kubernetes-namespace-max-pods -1 not valid")
+}
+
+func (s *NamespaceSuite) TestNamespaceLimitRange(c *gc.C) {
+	limitRange := namespaceLimitRange(v1.ResourceList{
+		v1.ResourcePods:        resource.MustParse("10"),
+		v1.ResourceRequestsCPU: resource.MustParse("4"),
+	})
+	c.Assert(limitRange, jc.DeepEquals, &v1.LimitRange{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-limits"},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{{
+				Type: v1.LimitTypeContainer,
+				DefaultRequest: v1.ResourceList{
+					v1.ResourceCPU: resource.MustParse("100m"),
+				},
+			}},
+		},
+	})
+}
+
+func (s *NamespaceSuite) TestNamespaceLimitRangePodsOnly(c *gc.C) {
+	limitRange := namespaceLimitRange(v1.ResourceList{
+		v1.ResourcePods: resource.MustParse("10"),
+	})
+	c.Assert(limitRange, gc.IsNil)
+}
+
+func (s *NamespaceSuite) TestCheckNamespaceOwner(c *gc.C) {
+	ns := &v1.Namespace{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "gitlab",
+			Labels: map[string]string{"juju-model-uuid": "deadbeef"},
+		},
+	}
+	c.Assert(checkNamespaceOwner(ns, "deadbeef"), jc.ErrorIsNil)
+	c.Assert(checkNamespaceOwner(ns, "cafebabe"), gc.ErrorMatches, `namespace "gitlab" is not owned by this model`)
+
+	ns.Labels = nil
+	c.Assert(checkNamespaceOwner(ns, "deadbeef"), gc.ErrorMatches, `namespace "gitlab" is not owned by this model`)
+}
@@ -0,0 +1,267 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	k8serrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/constraints"
+)
+
+// initContainersAnnotation is the annotation used to specify the init
+// containers of a pod; the version of the Kubernetes API we use does
+// not serialise the pod spec's init containers field.
+const initContainersAnnotation = "pod.beta.kubernetes.io/init-containers"
+
+// unitSpec holds the Kubernetes resources needed
+// to run a pod for a Juju pod spec.
+type unitSpec struct {
+	// Pod is the spec of the pod.
+	Pod v1.PodSpec
+
+	// Annotations are to be set on the pod.
+	Annotations map[string]string
+
+	// ConfigMaps hold the files mounted in the pod's
+	// containers, and must exist before the pod is created.
+	ConfigMaps []*v1.ConfigMap
+
+	// ServiceAccount, if set, is the service account the pod
+	// runs as, and must exist before the pod is created.
+	ServiceAccount *serviceAccount
+}
+
+// makeUnitSpec translates a Juju pod spec and constraints into the
+// Kubernetes resources needed to run the specified application's pods.
+func makeUnitSpec(appName string, spec *caas.PodSpec, cons constraints.Value) (*unitSpec, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unitSpec unitSpec
+	var initContainers []v1.Container
+	for _, c := range spec.InitContainers {
+		container, err := unitSpec.makeContainer(appName, c)
+		if err != nil {
+			return nil, errors.Annotatef(err, "init container %q", c.Name)
+		}
+		initContainers = append(initContainers, container)
+	}
+	for _, c := range spec.Containers {
+		container, err := unitSpec.makeContainer(appName, c)
+		if err != nil {
+			return nil, errors.Annotatef(err, "container %q", c.Name)
+		}
+		unitSpec.Pod.Containers = append(unitSpec.Pod.Containers, container)
+	}
+	if len(initContainers) > 0 {
+		data, err := json.Marshal(initContainers)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitSpec.Annotations = map[string]string{
+			initContainersAnnotation: string(data),
+		}
+	}
+	if spec.ServiceAccount != nil {
+		unitSpec.ServiceAccount = workloadServiceAccount(appName, spec.ServiceAccount)
+		unitSpec.Pod.ServiceAccountName = unitSpec.ServiceAccount.Name
+	}
+	if err := unitSpec.applyConstraints(cons); err != nil {
+		return nil, errors.Annotate(err, "applying constraints")
+	}
+	return &unitSpec, nil
+}
+
+// makeContainer returns the Kubernetes container for the given spec,
+// adding the volumes and config maps it needs to the unit spec.
+func (u *unitSpec) makeContainer(appName string, spec caas.ContainerSpec) (v1.Container, error) {
+	container := v1.Container{
+		Name:       spec.Name,
+		Image:      spec.ImageName,
+		Command:    spec.Command,
+		Args:       spec.Args,
+		WorkingDir: spec.WorkingDir,
+	}
+	for _, p := range spec.Ports {
+		container.Ports = append(container.Ports, v1.ContainerPort{
+			Name:          p.Name,
+			ContainerPort: int32(p.ContainerPort),
+			Protocol:      v1.Protocol(p.Protocol),
+		})
+	}
+
+	// Sort the environment so that the pod spec
+	// does not change unless the config does.
+	envNames := make([]string, 0, len(spec.Config))
+	for name := range spec.Config {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		container.Env = append(container.Env, v1.EnvVar{
+			Name:  name,
+			Value: spec.Config[name],
+		})
+	}
+	for _, s := range spec.SecretConfig {
+		container.Env = append(container.Env, v1.EnvVar{
+			Name: s.Name,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: s.SecretName},
+					Key:                  s.Key,
+				},
+			},
+		})
+	}
+
+	for _, f := range spec.Files {
+		configMapName := fmt.Sprintf("%s-%s-%s", deploymentName(appName), spec.Name, f.Name)
+		volumeName := fmt.Sprintf("%s-%s", spec.Name, f.Name)
+		u.ConfigMaps = append(u.ConfigMaps, &v1.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{
+				Name:   configMapName,
+				Labels: map[string]string{labelApplication: appName},
+			},
+			Data: f.Files,
+		})
+		u.Pod.Volumes = append(u.Pod.Volumes, v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: configMapName},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: f.MountPath,
+		})
+	}
+
+	var err error
+	if container.LivenessProbe, err = makeProbe(spec.LivenessProbe); err != nil {
+		return v1.Container{}, errors.Annotate(err, "liveness probe")
+	}
+	if container.ReadinessProbe, err = makeProbe(spec.ReadinessProbe); err != nil {
+		return v1.Container{}, errors.Annotate(err, "readiness probe")
+	}
+	if spec.Resources != nil {
+		if container.Resources.Requests, err = makeResourceList(spec.Resources.Requests); err != nil {
+			return v1.Container{}, errors.Annotate(err, "resource requests")
+		}
+		if container.Resources.Limits, err = makeResourceList(spec.Resources.Limits); err != nil {
+			return v1.Container{}, errors.Annotate(err, "resource limits")
+		}
+	}
+	return container, nil
+}
+
+func makeProbe(probe *caas.Probe) (*v1.Probe, error) {
+	if probe == nil {
+		return nil, nil
+	}
+	result := &v1.Probe{
+		InitialDelaySeconds: int32(probe.InitialDelaySeconds),
+		TimeoutSeconds:      int32(probe.TimeoutSeconds),
+		PeriodSeconds:       int32(probe.PeriodSeconds),
+		SuccessThreshold:    int32(probe.SuccessThreshold),
+		FailureThreshold:    int32(probe.FailureThreshold),
+	}
+	switch {
+	case probe.Exec != nil:
+		result.Exec = &v1.ExecAction{Command: probe.Exec.Command}
+	case probe.HTTPGet != nil:
+		result.HTTPGet = &v1.HTTPGetAction{
+			Path:   probe.HTTPGet.Path,
+			Port:   intstr.FromInt(probe.HTTPGet.Port),
+			Scheme: v1.URIScheme(probe.HTTPGet.Scheme),
+		}
+	case probe.TCPSocket != nil:
+		result.TCPSocket = &v1.TCPSocketAction{
+			Port: intstr.FromInt(probe.TCPSocket.Port),
+		}
+	default:
+		return nil, errors.New("no probe action specified")
+	}
+	return result, nil
+}
+
+func makeResourceList(resources map[string]string) (v1.ResourceList, error) {
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	result := make(v1.ResourceList)
+	for name, value := range resources {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing %s quantity %q", name, value)
+		}
+		result[v1.ResourceName(name)] = quantity
+	}
+	return result, nil
+}
+
+// ensureConfigMaps creates or updates the config maps holding the
+// files mounted in the application's containers, and deletes those
+// the spec no longer mounts.
+func (k *kubernetesClient) ensureConfigMaps(appName string, unitSpec *unitSpec) error {
+	for _, configMap := range unitSpec.ConfigMaps {
+		if err := k.ensureConfigMap(configMap); err != nil {
+			return errors.Annotatef(err, "creating or updating config map %q", configMap.Name)
+		}
+	}
+	configMaps := k.CoreV1().ConfigMaps(k.namespace)
+	existing, err := configMaps.List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return errors.Annotate(err, "listing config maps")
+	}
+	for _, name := range staleConfigMaps(existing.Items, unitSpec.ConfigMaps) {
+		err := configMaps.Delete(name, nil)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting config map %q", name)
+		}
+	}
+	return nil
+}
+
+// staleConfigMaps returns the names of the existing
+// config maps which are not in the wanted ones.
+func staleConfigMaps(existing []v1.ConfigMap, wanted []*v1.ConfigMap) []string {
+	wantedNames := set.NewStrings()
+	for _, configMap := range wanted {
+		wantedNames.Add(configMap.Name)
+	}
+	var stale []string
+	for _, configMap := range existing {
+		if !wantedNames.Contains(configMap.Name) {
+			stale = append(stale, configMap.Name)
+		}
+	}
+	return stale
+}
+
+// deleteConfigMaps deletes the config maps holding
+// the files mounted in the application's containers.
+func (k *kubernetesClient) deleteConfigMaps(appName string) error {
+	err := k.CoreV1().ConfigMaps(k.namespace).DeleteCollection(nil, v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type PodSpecSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&PodSpecSuite{})
+
+func (s *PodSpecSuite) TestMakeUnitSpec(c *gc.C) {
+	spec, err := caas.ParsePodSpec(`
+version: 1
+init-containers:
+- name: gitlab-init
+  image-name: gitlab-init/latest
+  command: ["init"]
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  args: ["--debug"]
+  ports:
+  - container-port: 80
+    protocol: TCP
+  config:
+    foo: bar
+    bar: baz
+  secret-config:
+  - name: DB_PASSWORD
+    secret-name: gitlab-db
+    key: password
+  files:
+  - name: configuration
+    mount-path: /etc/gitlab
+    files:
+      gitlab.rb: external_url 'http://gitlab'
+  liveness-probe:
+    http-get:
+      path: /ping
+      port: 80
+    period-seconds: 5
+  resources:
+    requests:
+      memory: 1Gi
+    limits:
+      cpu: 500m
+`[1:])
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitSpec, err := makeUnitSpec("gitlab", spec, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitSpec.Pod, jc.DeepEquals, v1.PodSpec{
+		Containers: []v1.Container{{
+			Name:  "gitlab",
+			Image: "gitlab/latest",
+			Args:  []string{"--debug"},
+			Ports: []v1.ContainerPort{{ContainerPort: 80, Protocol: v1.ProtocolTCP}},
+			Env: []v1.EnvVar{
+				{Name: "bar", Value: "baz"},
+				{Name: "foo", Value: "bar"},
+				{Name: "DB_PASSWORD", ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "gitlab-db"},
+						Key:                  "password",
+					},
+				}},
+			},
+			VolumeMounts: []v1.VolumeMount{{
+				Name:      "gitlab-configuration",
+				MountPath: "/etc/gitlab",
+			}},
+			LivenessProbe: &v1.Probe{
+				Handler: v1.Handler{
+					HTTPGet: &v1.HTTPGetAction{Path: "/ping", Port: intstr.FromInt(80)},
+				},
+				PeriodSeconds: 5,
+			},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+			},
+		}},
+		Volumes: []v1.Volume{{
+			Name: "gitlab-configuration",
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: "juju-gitlab-gitlab-configuration"},
+				},
+			},
+		}},
+	})
+	c.Assert(unitSpec.ConfigMaps, jc.DeepEquals, []*v1.ConfigMap{{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-gitlab-gitlab-configuration",
+			Labels: map[string]string{labelApplication: "gitlab"},
+		},
+		Data: map[string]string{"gitlab.rb": "external_url 'http://gitlab'"},
+	}})
+	c.Assert(unitSpec.Annotations[initContainersAnnotation], jc.JSONEquals, []v1.Container{{
+		Name:    "gitlab-init",
+		Image:   "gitlab-init/latest",
+		Command: []string{"init"},
+	}})
+}
+
+func (s *PodSpecSuite) TestMakeUnitSpecInvalidQuantity(c *gc.C) {
+	spec := &caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+			Resources: &caas.ResourceRequirements{
+				Limits: map[string]string{"memory": "lots"},
+			},
+		}},
+	}
+	_, err := makeUnitSpec("gitlab", spec, constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, `container "gitlab": resource limits: parsing memory quantity "lots": .*`)
+}
+
+func (s *PodSpecSuite) TestStaleConfigMaps(c *gc.C) {
+	existing := []v1.ConfigMap{
+		{ObjectMeta: v1.ObjectMeta{Name: "juju-gitlab-gitlab-configuration"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "juju-gitlab-gitlab-old"}},
+	}
+	wanted := []*v1.ConfigMap{
+		{ObjectMeta: v1.ObjectMeta{Name: "juju-gitlab-gitlab-configuration"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "juju-gitlab-gitlab-new"}},
+	}
+	c.Assert(staleConfigMaps(existing, wanted), jc.DeepEquals, []string{"juju-gitlab-gitlab-old"})
+	c.Assert(staleConfigMaps(existing, nil), jc.DeepEquals, []string{
+		"juju-gitlab-gitlab-configuration", "juju-gitlab-gitlab-old",
+	})
+	c.Assert(staleConfigMaps(nil, wanted), gc.HasLen, 0)
+}
@@ -0,0 +1,149 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	k8serrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1alpha1"
+
+	"github.com/juju/juju/caas"
+)
+
+// operatorRules are the permissions granted to an application's
+// operator. The operator talks to the Juju controller rather than
+// to Kubernetes, so it need only observe the model's pods and
+// services.
+var operatorRules = []rbac.PolicyRule{{
+	APIGroups: []string{""},
+	Resources: []string{"pods", "services"},
+	Verbs:     []string{"get", "list", "watch"},
+}}
+
+// serviceAccount describes a service account, and the permissions it
+// is granted within the model's namespace by a role of the same name.
+type serviceAccount struct {
+	Name        string
+	Application string
+	Rules       []rbac.PolicyRule
+}
+
+// operatorServiceAccount returns the service account
+// the specified application's operator runs as.
+func operatorServiceAccount(appName string) *serviceAccount {
+	return &serviceAccount{
+		Name:        operatorServiceAccountName(appName),
+		Application: appName,
+		Rules:       operatorRules,
+	}
+}
+
+// workloadServiceAccount returns the service account the specified
+// application's pods run as, granted the rules requested by the charm.
+func workloadServiceAccount(appName string, spec *caas.ServiceAccountSpec) *serviceAccount {
+	rules := make([]rbac.PolicyRule, len(spec.Rules))
+	for i, r := range spec.Rules {
+		apiGroups := r.APIGroups
+		if len(apiGroups) == 0 {
+			// The core API group is named by the empty string.
+			apiGroups = []string{""}
+		}
+		rules[i] = rbac.PolicyRule{
+			APIGroups:     apiGroups,
+			Resources:     r.Resources,
+			ResourceNames: r.ResourceNames,
+			Verbs:         r.Verbs,
+		}
+	}
+	return &serviceAccount{
+		Name:        workloadServiceAccountName(appName),
+		Application: appName,
+		Rules:       rules,
+	}
+}
+
+// ensureWorkloadServiceAccount creates or updates the service account
+// the application's pods run as, or removes it if the pod spec does
+// not ask for one.
+func (k *kubernetesClient) ensureWorkloadServiceAccount(appName string, unitSpec *unitSpec) error {
+	if unitSpec.ServiceAccount == nil {
+		return errors.Trace(k.deleteServiceAccount(workloadServiceAccountName(appName)))
+	}
+	if err := k.ensureServiceAccount(unitSpec.ServiceAccount); err != nil {
+		return errors.Annotate(err, "creating or updating service account")
+	}
+	return nil
+}
+
+// ensureServiceAccount creates the service account if it does not
+// exist, and creates or updates the role and role binding which
+// grant it its permissions.
+func (k *kubernetesClient) ensureServiceAccount(sa *serviceAccount) error {
+	meta := v1.ObjectMeta{
+		Name:   sa.Name,
+		Labels: map[string]string{labelApplication: sa.Application},
+	}
+	// The service account has nothing to update, and updating it
+	// would drop the token secret Kubernetes has added to it.
+	_, err := k.CoreV1().ServiceAccounts(k.namespace).Create(&v1.ServiceAccount{ObjectMeta: meta})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Trace(err)
+	}
+
+	roles := k.RbacV1alpha1().Roles(k.namespace)
+	role := &rbac.Role{ObjectMeta: meta, Rules: sa.Rules}
+	_, err = roles.Update(role)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(role)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	roleBindings := k.RbacV1alpha1().RoleBindings(k.namespace)
+	roleBinding := &rbac.RoleBinding{
+		ObjectMeta: meta,
+		Subjects: []rbac.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      sa.Name,
+			Namespace: k.namespace,
+		}},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     sa.Name,
+		},
+	}
+	_, err = roleBindings.Update(roleBinding)
+	if k8serrors.IsNotFound(err) {
+		_, err = roleBindings.Create(roleBinding)
+	}
+	return errors.Trace(err)
+}
+
+// deleteServiceAccount deletes the named service account,
+// along with its role and role binding.
+func (k *kubernetesClient) deleteServiceAccount(name string) error {
+	orphanDependents := false
+	opts := &v1.DeleteOptions{OrphanDependents: &orphanDependents}
+	for _, remove := range []func(string, *v1.DeleteOptions) error{
+		k.RbacV1alpha1().RoleBindings(k.namespace).Delete,
+		k.RbacV1alpha1().Roles(k.namespace).Delete,
+		k.CoreV1().ServiceAccounts(k.namespace).Delete,
+	} {
+		if err := remove(name, opts); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func operatorServiceAccountName(appName string) string {
+	return operatorPodName(appName)
+}
+
+func workloadServiceAccountName(appName string) string {
+	return deploymentName(appName)
+}
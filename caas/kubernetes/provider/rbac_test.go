@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1alpha1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type RBACSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&RBACSuite{})
+
+func (s *RBACSuite) TestOperatorServiceAccount(c *gc.C) {
+	sa := operatorServiceAccount("gitlab")
+	c.Assert(sa, jc.DeepEquals, &serviceAccount{
+		Name:        "juju-operator-gitlab",
+		Application: "gitlab",
+		Rules: []rbac.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods", "services"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	})
+}
+
+func (s *RBACSuite) TestMakeUnitSpecServiceAccount(c *gc.C) {
+	spec := &caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+		}},
+		ServiceAccount: &caas.ServiceAccountSpec{
+			Rules: []caas.PolicyRule{{
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "update"},
+			}, {
+				APIGroups:     []string{"extensions"},
+				Resources:     []string{"deployments"},
+				ResourceNames: []string{"gitlab-runner"},
+				Verbs:         []string{"get"},
+			}},
+		},
+	}
+	unitSpec, err := makeUnitSpec("gitlab", spec, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitSpec.Pod.ServiceAccountName, gc.Equals, "juju-gitlab")
+	c.Assert(unitSpec.ServiceAccount, jc.DeepEquals, &serviceAccount{
+		Name:        "juju-gitlab",
+		Application: "gitlab",
+		Rules: []rbac.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "update"},
+		}, {
+			APIGroups:     []string{"extensions"},
+			Resources:     []string{"deployments"},
+			ResourceNames: []string{"gitlab-runner"},
+			Verbs:         []string{"get"},
+		}},
+	})
+}
+
+func (s *RBACSuite) TestMakeUnitSpecNoServiceAccount(c *gc.C) {
+	spec := &caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+		}},
+	}
+	unitSpec, err := makeUnitSpec("gitlab", spec, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitSpec.Pod.ServiceAccountName, gc.Equals, "")
+	c.Assert(unitSpec.ServiceAccount, gc.IsNil)
+}
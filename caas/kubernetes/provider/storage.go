@@ -0,0 +1,320 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	k8serrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+	k8sstorage "k8s.io/client-go/pkg/apis/storage/v1beta1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// K8s_ProviderType defines a Kubernetes storage provider type.
+	K8s_ProviderType = storage.ProviderType("kubernetes")
+
+	// K8s_StorageClass is the pool attribute naming the Kubernetes
+	// storage class used for persistent volume claims. If it is not
+	// set, the cluster's default storage class is used.
+	K8s_StorageClass = "storage-class"
+
+	// K8s_StorageProvisioner is the pool attribute naming the volume
+	// plugin, e.g. "kubernetes.io/aws-ebs", used to create the storage
+	// class if it does not already exist.
+	K8s_StorageProvisioner = "storage-provisioner"
+
+	// K8s_StorageParametersPrefix prefixes pool attributes which are
+	// passed on as parameters to the storage class provisioner.
+	K8s_StorageParametersPrefix = "parameters."
+
+	// storageClassAnnotation is the annotation used to request a
+	// storage class for a persistent volume claim.
+	storageClassAnnotation = "volume.beta.kubernetes.io/storage-class"
+
+	labelStorage = "juju-storage"
+)
+
+// StorageProviders returns a registry containing the Kubernetes
+// storage provider. The provider does not need a connection to the
+// cluster; the volumes it describes are created by the broker along
+// with the pods that use them.
+func StorageProviders() storage.ProviderRegistry {
+	return storage.StaticProviderRegistry{
+		Providers: map[storage.ProviderType]storage.Provider{
+			K8s_ProviderType: &storageProvider{},
+		},
+	}
+}
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (k *kubernetesClient) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return StorageProviders().StorageProviderTypes()
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (k *kubernetesClient) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	return StorageProviders().StorageProvider(t)
+}
+
+// storageProvider describes filesystems backed by persistent volume
+// claims in a Kubernetes cluster.
+type storageProvider struct{}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+var storageConfigFields = schema.Fields{
+	K8s_StorageClass:       schema.String(),
+	K8s_StorageProvisioner: schema.String(),
+}
+
+var storageConfigChecker = schema.FieldMap(
+	storageConfigFields,
+	schema.Defaults{
+		K8s_StorageClass:       "",
+		K8s_StorageProvisioner: "",
+	},
+)
+
+type storageConfig struct {
+	storageClass       string
+	storageProvisioner string
+	parameters         map[string]string
+}
+
+func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
+	out, err := storageConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating storage config")
+	}
+	coerced := out.(map[string]interface{})
+	storageConfig := &storageConfig{
+		storageClass:       coerced[K8s_StorageClass].(string),
+		storageProvisioner: coerced[K8s_StorageProvisioner].(string),
+	}
+	if storageConfig.storageProvisioner != "" && storageConfig.storageClass == "" {
+		return nil, errors.New("storage-class must be specified if storage-provisioner is specified")
+	}
+	for k, v := range attrs {
+		if !strings.HasPrefix(k, K8s_StorageParametersPrefix) {
+			continue
+		}
+		if storageConfig.storageProvisioner == "" {
+			return nil, errors.Errorf("%q specified, but storage-provisioner is not", k)
+		}
+		value, ok := v.(string)
+		if !ok {
+			return nil, errors.Errorf("storage parameter %q: expected string, got %T", k, v)
+		}
+		if storageConfig.parameters == nil {
+			storageConfig.parameters = make(map[string]string)
+		}
+		storageConfig.parameters[strings.TrimPrefix(k, K8s_StorageParametersPrefix)] = value
+	}
+	return storageConfig, nil
+}
+
+// ValidateConfig is defined on the storage.Provider interface.
+func (*storageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newStorageConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// Supports is defined on the storage.Provider interface.
+func (*storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the storage.Provider interface.
+func (*storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the storage.Provider interface.
+func (*storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the storage.Provider interface.
+func (*storageProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the storage.Provider interface.
+func (*storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// VolumeSource is defined on the storage.Provider interface.
+func (*storageProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the storage.Provider interface.
+//
+// Filesystems are not created by a storage provisioner; the broker
+// asks the cluster for them when it creates the application's pods.
+func (*storageProvider) FilesystemSource(*storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystem source")
+}
+
+// ensureStorageClass creates the storage class described by the
+// given config if it does not already exist, and returns its name.
+// An empty name means the cluster's default storage class is used.
+func (k *kubernetesClient) ensureStorageClass(cfg *storageConfig) (string, error) {
+	if cfg.storageProvisioner == "" {
+		return cfg.storageClass, nil
+	}
+	storageClasses := k.StorageV1beta1().StorageClasses()
+	_, err := storageClasses.Get(cfg.storageClass)
+	if err == nil {
+		return cfg.storageClass, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	logger.Debugf("creating storage class %s", cfg.storageClass)
+	_, err = storageClasses.Create(&k8sstorage.StorageClass{
+		ObjectMeta: v1.ObjectMeta{
+			Name: cfg.storageClass,
+		},
+		Provisioner: cfg.storageProvisioner,
+		Parameters:  cfg.parameters,
+	})
+	if k8serrors.IsAlreadyExists(err) {
+		err = nil
+	}
+	return cfg.storageClass, errors.Trace(err)
+}
+
+// filesystemClaims returns the persistent volume claim templates and
+// volume mounts for the given filesystems, creating any storage
+// classes they need.
+func (k *kubernetesClient) filesystemClaims(
+	appName string, filesystems []storage.KubernetesFilesystemParams,
+) ([]v1.PersistentVolumeClaim, []v1.VolumeMount, error) {
+	var (
+		claims []v1.PersistentVolumeClaim
+		mounts []v1.VolumeMount
+	)
+	counts := make(map[string]int)
+	for _, fs := range filesystems {
+		if fs.Provider != K8s_ProviderType {
+			return nil, nil, errors.NotValidf("storage provider type %q", fs.Provider)
+		}
+		if fs.Attachment == nil || fs.Attachment.Path == "" {
+			return nil, nil, errors.Errorf("mount point for %q storage not specified", fs.StorageName)
+		}
+		cfg, err := newStorageConfig(fs.Attributes)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "storage %q", fs.StorageName)
+		}
+		storageClass, err := k.ensureStorageClass(cfg)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "creating storage class for %q storage", fs.StorageName)
+		}
+
+		claimName := fmt.Sprintf("juju-%s-%d", fs.StorageName, counts[fs.StorageName])
+		counts[fs.StorageName]++
+		claim := v1.PersistentVolumeClaim{
+			ObjectMeta: v1.ObjectMeta{
+				Name: claimName,
+				Labels: map[string]string{
+					labelApplication: appName,
+					labelStorage:     fs.StorageName,
+				},
+			},
+			Spec: v1.PersistentVolumeClaimSpec{
+				AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dMi", fs.Size)),
+					},
+				},
+			},
+		}
+		if storageClass != "" {
+			claim.Annotations = map[string]string{storageClassAnnotation: storageClass}
+		}
+		claims = append(claims, claim)
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      claimName,
+			MountPath: fs.Attachment.Path,
+			ReadOnly:  fs.Attachment.ReadOnly,
+		})
+	}
+	return claims, mounts, nil
+}
+
+// podFilesystems returns information about the filesystems backed by
+// Juju persistent volume claims which are mounted in the given pod.
+func (k *kubernetesClient) podFilesystems(pod *v1.Pod) ([]caas.FilesystemInfo, error) {
+	mounts := make(map[string]v1.VolumeMount)
+	for _, c := range pod.Spec.Containers {
+		for _, m := range c.VolumeMounts {
+			if _, ok := mounts[m.Name]; !ok {
+				mounts[m.Name] = m
+			}
+		}
+	}
+
+	var result []caas.FilesystemInfo
+	claims := k.CoreV1().PersistentVolumeClaims(k.namespace)
+	for _, vol := range pod.Spec.Volumes {
+		if vol.PersistentVolumeClaim == nil {
+			continue
+		}
+		mount, ok := mounts[vol.Name]
+		if !ok {
+			continue
+		}
+		pvc, err := claims.Get(vol.PersistentVolumeClaim.ClaimName)
+		if k8serrors.IsNotFound(err) {
+			// The claim has not been created yet.
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageName := pvc.Labels[labelStorage]
+		if storageName == "" {
+			// Not a claim made for Juju storage.
+			continue
+		}
+		quantity, ok := pvc.Status.Capacity[v1.ResourceStorage]
+		if !ok {
+			quantity = pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		}
+		result = append(result, caas.FilesystemInfo{
+			StorageName:  storageName,
+			FilesystemId: pvc.Name,
+			Size:         uint64(quantity.Value() / (1024 * 1024)),
+			MountPoint:   mount.MountPath,
+			ReadOnly:     mount.ReadOnly,
+			Status: status.StatusInfo{
+				Status: claimStatus(pvc.Status.Phase),
+			},
+		})
+	}
+	return result, nil
+}
+
+func claimStatus(phase v1.PersistentVolumeClaimPhase) status.Status {
+	switch phase {
+	case v1.ClaimBound:
+		return status.Attached
+	case v1.ClaimLost:
+		return status.Error
+	default:
+		return status.Pending
+	}
+}
@@ -68,6 +68,9 @@ Add a unit of mariadb to LXD container number 3 on machine 24:
 Add a unit of mariadb to LXD container on a new machine:
     juju add-unit mariadb --to lxd
 
+Show where two units of mysql would be placed, without adding them:
+    juju add-unit mysql -n 2 --to 3 --dry-run
+
 See also: 
     remove-unit`[1:]
 
@@ -139,6 +142,7 @@ type addUnitCommand struct {
 	modelcmd.ModelCommandBase
 	UnitCommandBase
 	ApplicationName string
+	DryRun          bool
 	api             serviceAddUnitAPI
 }
 
@@ -154,6 +158,7 @@ func (c *addUnitCommand) Info() *cmd.Info {
 func (c *addUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.UnitCommandBase.SetFlags(f)
 	f.IntVar(&c.NumUnits, "n", 1, "Number of units to add")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show where the units would be added")
 }
 
 func (c *addUnitCommand) Init(args []string) error {
@@ -176,6 +181,7 @@ type serviceAddUnitAPI interface {
 	Close() error
 	ModelUUID() string
 	AddUnits(application.AddUnitsParams) ([]string, error)
+	AddUnitsDryRun(application.AddUnitsParams) (params.AddUnitsDryRunResult, error)
 }
 
 func (c *addUnitCommand) getAPI() (serviceAddUnitAPI, error) {
@@ -203,6 +209,9 @@ func (c *addUnitCommand) Run(ctx *cmd.Context) error {
 		// Application API version 5 and onwards.
 		return errors.New("this juju controller does not support --attach-storage")
 	}
+	if c.DryRun && apiclient.BestAPIVersion() < 7 {
+		return errors.New("this juju controller does not support --dry-run")
+	}
 
 	for i, p := range c.Placement {
 		if p.Scope == "model-uuid" {
@@ -210,12 +219,21 @@ func (c *addUnitCommand) Run(ctx *cmd.Context) error {
 		}
 		c.Placement[i] = p
 	}
-	_, err = apiclient.AddUnits(application.AddUnitsParams{
+	args := application.AddUnitsParams{
 		ApplicationName: c.ApplicationName,
 		NumUnits:        c.NumUnits,
 		Placement:       c.Placement,
 		AttachStorage:   c.AttachStorage,
-	})
+	}
+	if c.DryRun {
+		result, err := apiclient.AddUnitsDryRun(args)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		writeAddUnitsDryRun(ctx.Stdout, result)
+		return nil
+	}
+	_, err = apiclient.AddUnits(args)
 	if params.IsCodeUnauthorized(err) {
 		common.PermissionsMessage(ctx.Stderr, "add a unit")
 	}
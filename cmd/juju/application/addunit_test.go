@@ -32,6 +32,7 @@ type fakeServiceAddUnitAPI struct {
 	placement      []*instance.Placement
 	attachStorage  []string
 	bestAPIVersion int
+	dryRunResult   params.AddUnitsDryRunResult
 	err            error
 }
 
@@ -61,6 +62,17 @@ func (f *fakeServiceAddUnitAPI) AddUnits(args apiapplication.AddUnitsParams) ([]
 	return nil, nil
 }
 
+func (f *fakeServiceAddUnitAPI) AddUnitsDryRun(args apiapplication.AddUnitsParams) (params.AddUnitsDryRunResult, error) {
+	if f.err != nil {
+		return params.AddUnitsDryRunResult{}, f.err
+	}
+	if args.ApplicationName != f.application {
+		return params.AddUnitsDryRunResult{}, errors.NotFoundf("application %q", args.ApplicationName)
+	}
+	f.placement = args.Placement
+	return f.dryRunResult, nil
+}
+
 func (f *fakeServiceAddUnitAPI) ModelGet() (map[string]interface{}, error) {
 	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
 		"type": f.envType,
@@ -161,6 +173,36 @@ func (s *AddUnitSuite) TestAddUnitAttachStorageNotSupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --attach-storage")
 }
 
+func (s *AddUnitSuite) TestAddUnitDryRun(c *gc.C) {
+	s.fake.bestAPIVersion = 7
+	s.fake.dryRunResult = params.AddUnitsDryRunResult{
+		ApplicationName: "some-application-name",
+		NumUnits:        3,
+		Placement:       []string{"3", "lxd:1", ""},
+	}
+	ctx, err := cmdtesting.RunCommand(c, application.NewAddUnitCommandForTest(s.fake),
+		"some-application-name", "-n", "3", "--to", "3,lxd:1", "--dry-run",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.numUnits, gc.Equals, 1)
+	c.Assert(s.fake.placement, jc.DeepEquals, []*instance.Placement{
+		{"#", "3"},
+		{"lxd", "1"},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Changes to add units to application some-application-name:
+- add unit 1/3 to machine 3
+- add unit 2/3 to lxd:1
+- add unit 3/3 to a new machine
+`[1:])
+}
+
+func (s *AddUnitSuite) TestAddUnitDryRunNotSupported(c *gc.C) {
+	err := s.runAddUnit(c, "some-application-name", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --dry-run")
+	c.Assert(s.fake.numUnits, gc.Equals, 1)
+}
+
 func (s *AddUnitSuite) TestBlockAddUnit(c *gc.C) {
 	// Block operation
 	s.fake.err = common.OperationBlockedError("TestBlockAddUnit")
@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces the endpoints of a deployed application are bound to.`[1:]
+
+var usageBindDetails = `
+Endpoints are rebound using the same syntax as "juju deploy --bind": a lone
+space name changes the default space of the application, used by endpoints
+without an explicit binding, and <endpoint>=<space> binds a single endpoint.
+Endpoints that are not mentioned keep their current binding.
+
+Every machine hosting a unit of the application must already have an address
+in each of the new spaces, otherwise the command fails and no binding is
+changed.
+
+Once the bindings are changed, a config-changed hook is run on each unit of
+the application so that charms can use network-get to read the addresses of
+their endpoints in the new spaces.
+
+Examples:
+    juju bind mysql db-space
+    juju bind mysql db=db-space monitoring=admin-space
+    juju bind mysql public-space db=db-space
+
+See also:
+    deploy
+    show-application
+    spaces`[1:]
+
+// NewBindCommand returns a command which changes the endpoint bindings
+// of an application.
+func NewBindCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&bindCommand{})
+}
+
+// bindAPI defines the application facade methods used by the bind
+// command.
+type bindAPI interface {
+	Close() error
+	UpdateEndpointBindings(application string, bindings map[string]string) error
+}
+
+// bindCommand changes the endpoint bindings of an application.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	api bindAPI
+
+	applicationName string
+	bindings        map[string]string
+}
+
+// Info implements cmd.Command.
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application name> [<default-space>] [<endpoint>=<space> ...]",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *bindCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	bindings, err := parseBindings(args[1:])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.bindings = bindings
+	return nil
+}
+
+// parseBindings parses bindings of the form <space> or <endpoint>=<space>.
+// A lone space name sets the default space, keyed by the empty endpoint.
+func parseBindings(args []string) (map[string]string, error) {
+	bindings := make(map[string]string)
+	for _, arg := range args {
+		var endpoint, space string
+		parts := strings.Split(arg, "=")
+		switch len(parts) {
+		case 1:
+			space = parts[0]
+		case 2:
+			if parts[0] == "" {
+				return nil, errors.Errorf("binding %q has no endpoint name", arg)
+			}
+			endpoint, space = parts[0], parts[1]
+		default:
+			return nil, errors.Errorf("binding %q must be in the form <endpoint>=<space>", arg)
+		}
+		if !names.IsValidSpace(space) {
+			return nil, errors.Errorf("invalid space name %q", space)
+		}
+		if _, ok := bindings[endpoint]; ok {
+			if endpoint == "" {
+				return nil, errors.New("default space specified more than once")
+			}
+			return nil, errors.Errorf("endpoint %q bound more than once", endpoint)
+		}
+		bindings[endpoint] = space
+	}
+	return bindings, nil
+}
+
+func (c *bindCommand) getAPI() (bindAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *bindCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.UpdateEndpointBindings(c.applicationName, c.bindings)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
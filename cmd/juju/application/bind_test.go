@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type bindSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockBindAPI
+}
+
+var _ = gc.Suite(&bindSuite{})
+
+func (s *bindSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockBindAPI{Stub: &testing.Stub{}}
+}
+
+func (s *bindSuite) runBind(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewBindCommandForTest(s.mockAPI), args...)
+}
+
+func (s *bindSuite) TestBind(c *gc.C) {
+	_, err := s.runBind(c, "mysql", "public", "db=internal", "monitoring=admin")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "UpdateEndpointBindings", "Close")
+	s.mockAPI.CheckCall(c, 0, "UpdateEndpointBindings", "mysql", map[string]string{
+		"":           "public",
+		"db":         "internal",
+		"monitoring": "admin",
+	})
+}
+
+func (s *bindSuite) TestBindError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`machine "0" hosting unit "mysql/0" has no address in space(s) internal`))
+	_, err := s.runBind(c, "mysql", "db=internal")
+	c.Assert(err, gc.ErrorMatches, `machine "0" hosting unit "mysql/0" has no address in space\(s\) internal`)
+}
+
+func (s *bindSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql/0", "db=internal"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no bindings specified",
+	}, {
+		args: []string{"mysql", "=internal"},
+		err:  `binding "=internal" has no endpoint name`,
+	}, {
+		args: []string{"mysql", "db=internal=admin"},
+		err:  `binding "db=internal=admin" must be in the form <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "db=-bad"},
+		err:  `invalid space name "-bad"`,
+	}, {
+		args: []string{"mysql", "db=internal", "db=admin"},
+		err:  `endpoint "db" bound more than once`,
+	}, {
+		args: []string{"mysql", "internal", "admin"},
+		err:  "default space specified more than once",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runBind(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+type mockBindAPI struct {
+	*testing.Stub
+}
+
+func (m *mockBindAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockBindAPI) UpdateEndpointBindings(application string, bindings map[string]string) error {
+	m.MethodCall(m, "UpdateEndpointBindings", application, bindings)
+	return m.NextErr()
+}
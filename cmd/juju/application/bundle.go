@@ -71,8 +71,40 @@ func deployBundle(
 	bundleMachines map[string]string,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 
+	if err := composeAndVerifyBundle(ctx, bundleDir, data, bundleOverlayFile); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// TODO: move bundle parsing and checking into the handler.
+	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage)
+	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.resolveCharmsAndEndpoints(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.getChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.handleChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return h.macaroons, nil
+
+}
+
+// composeAndVerifyBundle applies the overlays and processes the includes
+// of the given bundle data, and then verifies the resulting bundle. The
+// bundleDir is the directory containing a local bundle, or empty for
+// bundles coming from the charm store.
+func composeAndVerifyBundle(
+	ctx *cmd.Context,
+	bundleDir string,
+	data *charm.BundleData,
+	bundleOverlayFile []string,
+) error {
 	if err := processBundleOverlay(data, bundleOverlayFile...); err != nil {
-		return nil, err
+		return err
 	}
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -86,13 +118,13 @@ func deployBundle(
 	if bundleDir == "" {
 		// Process includes in the bundle data.
 		if err := processBundleIncludes(ctx.Dir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
+			return errors.Annotate(err, "unable to process includes")
 		}
 		verifyError = data.Verify(verifyConstraints, verifyStorage)
 	} else {
 		// Process includes in the bundle data.
 		if err := processBundleIncludes(bundleDir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
+			return errors.Annotate(err, "unable to process includes")
 		}
 		verifyError = data.VerifyLocal(bundleDir, verifyConstraints, verifyStorage)
 	}
@@ -102,27 +134,11 @@ func deployBundle(
 			for i, err := range verr.Errors {
 				errs[i] = err.Error()
 			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+			return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
 		}
-		return nil, errors.Trace(verifyError)
+		return errors.Trace(verifyError)
 	}
-
-	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage)
-	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.resolveCharmsAndEndpoints(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.getChanges(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.handleChanges(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	return h.macaroons, nil
-
+	return nil
 }
 
 // bundleHandler provides helpers and the state required to deploy a bundle.
@@ -267,16 +283,34 @@ func (h *bundleHandler) makeModel(
 //     and if they do, resolve the implicitness in order to compare
 //     with relations in the model.
 func (h *bundleHandler) resolveCharmsAndEndpoints() error {
+	return errors.Trace(resolveBundleCharms(h.ctx, h.data, h.model, h.modelConfig, h.api))
+}
 
-	deployedApps := set.NewStrings()
+// charmResolver resolves charm URLs against the charm store.
+type charmResolver interface {
+	Resolve(*config.Config, *charm.URL) (*charm.URL, csparams.Channel, []string, error)
+}
 
-	for _, name := range h.applications.SortedValues() {
-		spec := h.data.Applications[name]
-		app := h.model.GetApplication(name)
-		if app != nil {
-			deployedApps.Add(name)
+// resolveBundleCharms replaces the charm URLs in the bundle data with
+// fully resolved charm URLs, so that they can be compared with the
+// charms used in the model. See resolveCharmsAndEndpoints for details.
+func resolveBundleCharms(
+	ctx *cmd.Context,
+	data *charm.BundleData,
+	model *bundlechanges.Model,
+	modelConfig *config.Config,
+	resolver charmResolver,
+) error {
+	applications := set.NewStrings()
+	for name := range data.Applications {
+		applications.Add(name)
+	}
 
-			if h.isLocalCharm(spec.Charm) {
+	for _, name := range applications.SortedValues() {
+		spec := data.Applications[name]
+		app := model.GetApplication(name)
+		if app != nil {
+			if isLocalCharm(spec.Charm) {
 				logger.Debugf("%s exists in model uses a local charm, replacing with %q", name, app.Charm)
 				// Replace with charm from model
 				spec.Charm = app.Charm
@@ -288,16 +322,16 @@ func (h *bundleHandler) resolveCharmsAndEndpoints() error {
 			}
 		}
 
-		if h.isLocalCharm(spec.Charm) {
+		if isLocalCharm(spec.Charm) {
 			continue
 		}
 
-		h.ctx.Infof("Resolving charm: %s", spec.Charm)
+		ctx.Infof("Resolving charm: %s", spec.Charm)
 		ch, err := charm.ParseURL(spec.Charm)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		url, _, _, err := h.api.Resolve(h.modelConfig, ch)
+		url, _, _, err := resolver.Resolve(modelConfig, ch)
 		if err != nil {
 			return errors.Annotatef(err, "cannot resolve URL %q", spec.Charm)
 		}
@@ -385,6 +419,12 @@ func (h *bundleHandler) handleChanges() error {
 }
 
 func (h *bundleHandler) isLocalCharm(name string) bool {
+	return isLocalCharm(name)
+}
+
+// isLocalCharm reports whether the given charm name refers to a charm
+// on the local filesystem.
+func isLocalCharm(name string) bool {
 	return strings.HasPrefix(name, ".") || filepath.IsAbs(name)
 }
 
@@ -1292,9 +1332,17 @@ func removeRelations(data [][]string, appName string) [][]string {
 	return result
 }
 
+// modelExtractor provides the API methods required to build a
+// bundlechanges.Model from the model status.
+type modelExtractor interface {
+	GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error)
+	GetConfig(appNames ...string) ([]map[string]interface{}, error)
+	GetConstraints(appNames ...string) ([]constraints.Value, error)
+}
+
 func buildModelRepresentation(
 	status *params.FullStatus,
-	apiRoot DeployAPI,
+	apiRoot modelExtractor,
 	useExistingMachines bool,
 	bundleMachines map[string]string,
 ) (*bundlechanges.Model, error) {
@@ -0,0 +1,383 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+)
+
+// diffSide identifies which side of the comparison is missing
+// something.
+type diffSide string
+
+const (
+	diffSideBundle diffSide = "bundle"
+	diffSideModel  diffSide = "model"
+)
+
+// bundleDiff describes the differences between a bundle and a model.
+// The YAML representation of this type is the output of the
+// diff-bundle command.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Series       *stringDiff                 `yaml:"series,omitempty" json:"series,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// Empty returns whether the bundle and the model are equivalent.
+func (d *bundleDiff) Empty() bool {
+	return len(d.Applications) == 0 &&
+		len(d.Machines) == 0 &&
+		d.Series == nil &&
+		d.Relations == nil
+}
+
+// applicationDiff describes the differences between an application in
+// the bundle and the same application in the model.
+type applicationDiff struct {
+	Missing     diffSide              `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *stringDiff           `yaml:"charm,omitempty" json:"charm,omitempty"`
+	NumUnits    *intDiff              `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Expose      *boolDiff             `yaml:"expose,omitempty" json:"expose,omitempty"`
+	Options     map[string]optionDiff `yaml:"options,omitempty" json:"options,omitempty"`
+	Annotations map[string]stringDiff `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+	Constraints *stringDiff           `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Charm == nil &&
+		d.NumUnits == nil &&
+		d.Expose == nil &&
+		len(d.Options) == 0 &&
+		len(d.Annotations) == 0 &&
+		d.Constraints == nil
+}
+
+// machineDiff describes the differences between a machine in the bundle
+// and the machine it is mapped to in the model.
+type machineDiff struct {
+	Missing     diffSide              `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Annotations map[string]stringDiff `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// relationsDiff holds the relations that only exist on one side.
+type relationsDiff struct {
+	BundleExtra [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelExtra  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+// stringDiff records a string value that differs between the bundle
+// and the model.
+type stringDiff struct {
+	Bundle string `yaml:"bundle" json:"bundle"`
+	Model  string `yaml:"model" json:"model"`
+}
+
+// intDiff records an integer value that differs between the bundle and
+// the model.
+type intDiff struct {
+	Bundle int `yaml:"bundle" json:"bundle"`
+	Model  int `yaml:"model" json:"model"`
+}
+
+// boolDiff records a boolean value that differs between the bundle and
+// the model.
+type boolDiff struct {
+	Bundle bool `yaml:"bundle" json:"bundle"`
+	Model  bool `yaml:"model" json:"model"`
+}
+
+// optionDiff records a charm config value that differs between the
+// bundle and the model.
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+// buildBundleDiff compares the bundle data against the model. Bundle
+// machines are compared with the model machines they are mapped to by
+// the model's MachineMap; unmapped bundle machines are compared with
+// the model machine with the same id.
+func buildBundleDiff(data *charm.BundleData, model *bundlechanges.Model, modelSeries string) *bundleDiff {
+	result := &bundleDiff{
+		Applications: diffApplications(data, model),
+		Machines:     diffMachines(data, model),
+		Relations:    diffRelations(data, model),
+	}
+	if data.Series != "" && data.Series != modelSeries {
+		result.Series = &stringDiff{Bundle: data.Series, Model: modelSeries}
+	}
+	return result
+}
+
+func diffApplications(data *charm.BundleData, model *bundlechanges.Model) map[string]*applicationDiff {
+	names := set.NewStrings()
+	for name := range data.Applications {
+		names.Add(name)
+	}
+	for name := range model.Applications {
+		names.Add(name)
+	}
+
+	result := make(map[string]*applicationDiff)
+	for _, name := range names.SortedValues() {
+		spec, inBundle := data.Applications[name]
+		app, inModel := model.Applications[name]
+		var diff *applicationDiff
+		switch {
+		case !inBundle:
+			diff = &applicationDiff{Missing: diffSideBundle}
+		case !inModel:
+			diff = &applicationDiff{Missing: diffSideModel}
+		default:
+			diff = diffApplication(spec, app, model.ConstraintsEqual)
+		}
+		if !diff.empty() {
+			result[name] = diff
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func diffApplication(
+	spec *charm.ApplicationSpec,
+	app *bundlechanges.Application,
+	constraintsEqual func(a, b string) bool,
+) *applicationDiff {
+	result := &applicationDiff{
+		Options:     diffOptions(spec.Options, app.Options),
+		Annotations: diffAnnotations(spec.Annotations, app.Annotations),
+	}
+	if spec.Charm != app.Charm {
+		result.Charm = &stringDiff{Bundle: spec.Charm, Model: app.Charm}
+	}
+	if spec.NumUnits != len(app.Units) {
+		result.NumUnits = &intDiff{Bundle: spec.NumUnits, Model: len(app.Units)}
+	}
+	if spec.Expose != app.Exposed {
+		result.Expose = &boolDiff{Bundle: spec.Expose, Model: app.Exposed}
+	}
+	equal := constraintsEqual
+	if equal == nil {
+		equal = func(a, b string) bool { return a == b }
+	}
+	if !equal(spec.Constraints, app.Constraints) {
+		result.Constraints = &stringDiff{Bundle: spec.Constraints, Model: app.Constraints}
+	}
+	return result
+}
+
+func diffOptions(bundle, model map[string]interface{}) map[string]optionDiff {
+	keys := set.NewStrings()
+	for key := range bundle {
+		keys.Add(key)
+	}
+	for key := range model {
+		keys.Add(key)
+	}
+	result := make(map[string]optionDiff)
+	for _, key := range keys.Values() {
+		bundleValue, modelValue := bundle[key], model[key]
+		if !reflect.DeepEqual(normaliseOption(bundleValue), normaliseOption(modelValue)) {
+			result[key] = optionDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// normaliseOption returns the given option value with any numeric
+// type converted to float64. Options read from the model arrive
+// through the API as JSON numbers, while those in the bundle are
+// parsed from YAML as ints, so they must be converted to compare.
+func normaliseOption(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+func diffAnnotations(bundle, model map[string]string) map[string]stringDiff {
+	keys := set.NewStrings()
+	for key := range bundle {
+		keys.Add(key)
+	}
+	for key := range model {
+		keys.Add(key)
+	}
+	result := make(map[string]stringDiff)
+	for _, key := range keys.Values() {
+		if bundle[key] != model[key] {
+			result[key] = stringDiff{Bundle: bundle[key], Model: model[key]}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func diffMachines(data *charm.BundleData, model *bundlechanges.Model) map[string]*machineDiff {
+	result := make(map[string]*machineDiff)
+	mapped := set.NewStrings()
+	for bundleID, spec := range data.Machines {
+		modelID := bundleID
+		if id, found := model.MachineMap[bundleID]; found {
+			modelID = id
+		}
+		machine, found := model.Machines[modelID]
+		if !found {
+			result[bundleID] = &machineDiff{Missing: diffSideModel}
+			continue
+		}
+		mapped.Add(modelID)
+		var annotations map[string]string
+		if spec != nil {
+			annotations = spec.Annotations
+		}
+		if diff := diffAnnotations(annotations, machine.Annotations); diff != nil {
+			result[bundleID] = &machineDiff{Annotations: diff}
+		}
+	}
+	for id := range model.Machines {
+		// Containers are created as part of unit placement, so they
+		// are not expected to appear in the bundle machines.
+		if names.IsContainerMachine(id) || mapped.Contains(id) {
+			continue
+		}
+		result[id] = &machineDiff{Missing: diffSideBundle}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// relationEndpoint is an application endpoint in a relation. The
+// endpoint name may be empty for bundle relations that rely on the
+// endpoint being inferred.
+type relationEndpoint struct {
+	application string
+	name        string
+}
+
+func parseRelationEndpoint(value string) relationEndpoint {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) == 1 {
+		return relationEndpoint{application: parts[0]}
+	}
+	return relationEndpoint{application: parts[0], name: parts[1]}
+}
+
+func (e relationEndpoint) matches(other relationEndpoint) bool {
+	if e.application != other.application {
+		return false
+	}
+	return e.name == "" || other.name == "" || e.name == other.name
+}
+
+func (e relationEndpoint) String() string {
+	if e.name == "" {
+		return e.application
+	}
+	return e.application + ":" + e.name
+}
+
+func relationsMatch(a, b [2]relationEndpoint) bool {
+	return (a[0].matches(b[0]) && a[1].matches(b[1])) ||
+		(a[0].matches(b[1]) && a[1].matches(b[0]))
+}
+
+func diffRelations(data *charm.BundleData, model *bundlechanges.Model) *relationsDiff {
+	var bundleRelations [][2]relationEndpoint
+	for _, relation := range data.Relations {
+		if len(relation) != 2 {
+			// Verified bundles only contain pairs.
+			continue
+		}
+		bundleRelations = append(bundleRelations, [2]relationEndpoint{
+			parseRelationEndpoint(relation[0]),
+			parseRelationEndpoint(relation[1]),
+		})
+	}
+	var modelRelations [][2]relationEndpoint
+	for _, relation := range model.Relations {
+		modelRelations = append(modelRelations, [2]relationEndpoint{
+			{application: relation.App1, name: relation.Endpoint1},
+			{application: relation.App2, name: relation.Endpoint2},
+		})
+	}
+
+	result := &relationsDiff{
+		BundleExtra: relationsOnlyIn(bundleRelations, modelRelations),
+		ModelExtra:  relationsOnlyIn(modelRelations, bundleRelations),
+	}
+	if len(result.BundleExtra) == 0 && len(result.ModelExtra) == 0 {
+		return nil
+	}
+	return result
+}
+
+// relationsOnlyIn returns the relations in the first slice that have
+// no match in the second, in a stable order.
+func relationsOnlyIn(relations, others [][2]relationEndpoint) [][]string {
+	var result [][]string
+	for _, relation := range relations {
+		found := false
+		for _, other := range others {
+			if relationsMatch(relation, other) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		endpoints := []string{relation[0].String(), relation[1].String()}
+		sort.Strings(endpoints)
+		result = append(result, endpoints)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i][0] != result[j][0] {
+			return result[i][0] < result[j][0]
+		}
+		return result[i][1] < result[j][1]
+	})
+	return result
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/utils/keyvalues"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/modelgeneration"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -33,6 +34,12 @@ Output includes the name of the charm used to deploy the application and a
 listing of the application-specific configuration settings.
 See ` + "`juju status`" + ` for application names.
 
+When --branch is specified, application config and charm settings that
+are set or reset are staged on the named branch rather than applied to
+the application. Only units tracking the branch see the staged charm
+settings; staged application config takes effect when the branch is
+committed.
+
 Examples:
     juju config apache2
     juju config --format=json apache2
@@ -41,10 +48,12 @@ Examples:
     juju config apache2 --file path/to/config.yaml
     juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
     juju config apache2 --model mymodel --file /home/ubuntu/mysql.yaml
+    juju config mysql --branch new-config dataset-size=60%
 
 See also:
     deploy
     status
+    add-branch
 `
 )
 
@@ -65,12 +74,14 @@ type attributes map[string]string
 
 // configCommand get, sets, and resets configuration values of an application' charm.
 type configCommand struct {
-	api applicationAPI
+	api       applicationAPI
+	branchAPI branchConfigAPI
 	modelcmd.ModelCommandBase
 	out cmd.Output
 
 	action          func(applicationAPI, *cmd.Context) error // get, set, or reset action set in  Init
 	applicationName string
+	branchName      string
 	configFile      cmd.FileVar
 	keys            []string
 	reset           []string // Holds the keys to be reset until parsed.
@@ -94,6 +105,13 @@ type applicationAPI interface {
 	UnsetApplicationConfig(application string, options []string) error
 }
 
+// branchConfigAPI is an interface to allow passing in a fake
+// implementation of the branch staging API under test.
+type branchConfigAPI interface {
+	Close() error
+	SetBranchConfig(branchName, application string, config map[string]string, reset []string) error
+}
+
 // Info is part of the cmd.Command interface.
 func (c *configCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -110,6 +128,7 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.configFile, "file", "path to yaml-formatted application config")
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.StringVar(&c.branchName, "branch", "", "Stage the changes on the named branch")
 }
 
 // getAPI either uses the fake API set at test time or that is nil, gets a real
@@ -126,6 +145,19 @@ func (c *configCommand) getAPI() (applicationAPI, error) {
 	return client, nil
 }
 
+// getBranchAPI either uses the fake API set at test time or gets a
+// real API for staging changes on a branch.
+func (c *configCommand) getBranchAPI() (branchConfigAPI, error) {
+	if c.branchAPI != nil {
+		return c.branchAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelgeneration.NewClient(root), nil
+}
+
 // Init is part of the cmd.Command interface.
 func (c *configCommand) Init(args []string) error {
 	if len(args) == 0 || len(strings.Split(args[0], "=")) > 1 {
@@ -143,14 +175,25 @@ func (c *configCommand) Init(args []string) error {
 	c.applicationName = args[0]
 	args = args[1:]
 
+	var err error
 	switch len(args) {
 	case 0:
-		return c.handleZeroArgs()
+		err = c.handleZeroArgs()
 	case 1:
-		return c.handleOneArg(args)
+		err = c.handleOneArg(args)
 	default:
-		return c.handleArgs(args)
+		err = c.handleArgs(args)
+	}
+	if err != nil || c.branchName == "" {
+		return err
+	}
+	if c.useFile {
+		return errors.New("cannot specify --file and --branch simultaneously")
+	}
+	if len(c.values) == 0 && len(c.resetKeys) == 0 {
+		return errors.New("--branch can only be used when setting or resetting values")
 	}
+	return nil
 }
 
 // handleZeroArgs handles the case where there are no positional args.
@@ -247,6 +290,9 @@ func (c *configCommand) parseSet(args []string) error {
 
 // Run implements the cmd.Command interface.
 func (c *configCommand) Run(ctx *cmd.Context) error {
+	if c.branchName != "" {
+		return c.stageBranchConfig(ctx)
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return errors.Trace(err)
@@ -316,6 +362,22 @@ func (c *configCommand) setConfig(client applicationAPI, ctx *cmd.Context) error
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
+// stageBranchConfig is the run action when setting or resetting
+// attribute values on a branch.
+func (c *configCommand) stageBranchConfig(ctx *cmd.Context) error {
+	settings, err := c.validateValues(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getBranchAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	err = client.SetBranchConfig(c.branchName, c.applicationName, settings, c.resetKeys)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
 // setConfigFromFile sets the application configuration from settings passed
 // in a YAML file.
 func (c *configCommand) setConfigFromFile(client applicationAPI, ctx *cmd.Context) error {
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -371,6 +372,57 @@ func (s *configCommandSuite) TestBlockSetConfig(c *gc.C) {
 	c.Check(c.GetTestLog(), gc.Matches, "(.|\n)*TestBlockSetConfig(.|\n)*")
 }
 
+func (s *configCommandSuite) TestSetConfigOnBranch(c *gc.C) {
+	branchAPI := &fakeBranchConfigAPI{}
+	cmd := application.NewConfigCommandWithBranchForTest(s.fake, branchAPI)
+	cmd.SetClientStore(application.NewMockStore())
+	_, err := cmdtesting.RunCommandInDir(c, cmd, []string{
+		"dummy-application",
+		"--branch", "new-config",
+		"--reset", "outlook",
+		"username=hello",
+	}, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	branchAPI.CheckCall(c, 0, "SetBranchConfig",
+		"new-config", "dummy-application", map[string]string{"username": "hello"}, []string{"outlook"})
+
+	// The application's settings are untouched.
+	c.Assert(s.fake.charmValues, jc.DeepEquals, s.defaultCharmValues)
+}
+
+func (s *configCommandSuite) TestBranchInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"dummy-application", "--branch", "new-config"},
+		err:  "--branch can only be used when setting or resetting values",
+	}, {
+		args: []string{"dummy-application", "--branch", "new-config", "title"},
+		err:  "--branch can only be used when setting or resetting values",
+	}, {
+		args: []string{"dummy-application", "--branch", "new-config", "--file", "testconfig.yaml"},
+		err:  "cannot specify --file and --branch simultaneously",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(application.NewConfigCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type fakeBranchConfigAPI struct {
+	testing.Stub
+}
+
+func (f *fakeBranchConfigAPI) Close() error {
+	return nil
+}
+
+func (f *fakeBranchConfigAPI) SetBranchConfig(branchName, application string, config map[string]string, reset []string) error {
+	f.MethodCall(f, "SetBranchConfig", branchName, application, config, reset)
+	return f.NextErr()
+}
+
 // assertSetSuccess sets configuration options and checks the expected settings.
 func (s *configCommandSuite) assertSetSuccess(
 	c *gc.C, dir string, args []string,
@@ -34,7 +34,9 @@ import (
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
 )
@@ -89,12 +91,16 @@ type DeployAPI interface {
 	// ApplicationClient
 	CharmInfo(string) (*apicharms.CharmInfo, error)
 	Deploy(application.DeployArgs) error
+	DeployDryRun(application.DeployDryRunArgs) (apiparams.DeployDryRunResult, error)
 	Status(patterns []string) (*apiparams.FullStatus, error)
 
 	Resolve(*config.Config, *charm.URL) (*charm.URL, params.Channel, []string, error)
 
 	GetBundle(*charm.URL) (charm.Bundle, error)
 
+	// Get downloads a charm from the charm store.
+	Get(*charm.URL) (charm.Charm, error)
+
 	WatchAll() (*api.AllWatcher, error)
 }
 
@@ -148,6 +154,21 @@ type deployAPIAdapter struct {
 	*annotationsClient
 }
 
+// newDeployAPIAdapter returns a deployAPIAdapter that uses the given
+// API connection and charm store client.
+func newDeployAPIAdapter(apiRoot api.Connection, cstoreClient *csclient.Client) *deployAPIAdapter {
+	return &deployAPIAdapter{
+		Connection:        apiRoot,
+		apiClient:         &apiClient{Client: apiRoot.Client()},
+		charmsClient:      &charmsClient{Client: apicharms.NewClient(apiRoot)},
+		applicationClient: &applicationClient{Client: application.NewClient(apiRoot)},
+		modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
+		charmstoreClient:  &charmstoreClient{Client: cstoreClient},
+		annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
+		charmRepoClient:   &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
+	}
+}
+
 func (a *deployAPIAdapter) Client() *api.Client {
 	return a.apiClient.Client
 }
@@ -157,14 +178,25 @@ func (a *deployAPIAdapter) ModelUUID() (string, bool) {
 }
 
 func (a *deployAPIAdapter) Deploy(args application.DeployArgs) error {
-	for i, p := range args.Placement {
+	a.resolvePlacementScopes(args.Placement)
+	return errors.Trace(a.applicationClient.Deploy(args))
+}
+
+func (a *deployAPIAdapter) DeployDryRun(args application.DeployDryRunArgs) (apiparams.DeployDryRunResult, error) {
+	a.resolvePlacementScopes(args.Placement)
+	result, err := a.applicationClient.DeployDryRun(args)
+	return result, errors.Trace(err)
+}
+
+// resolvePlacementScopes replaces the "model-uuid" placeholder scope
+// with the UUID of the model.
+func (a *deployAPIAdapter) resolvePlacementScopes(placement []*instance.Placement) {
+	for i, p := range placement {
 		if p.Scope == "model-uuid" {
 			p.Scope = a.applicationClient.ModelUUID()
 		}
-		args.Placement[i] = p
+		placement[i] = p
 	}
-
-	return errors.Trace(a.applicationClient.Deploy(args))
 }
 
 func (a *deployAPIAdapter) Resolve(cfg *config.Config, url *charm.URL) (
@@ -206,16 +238,7 @@ func NewDeployCommandForTest(newAPIRoot func() (DeployAPI, error), steps []Deplo
 			}
 			cstoreClient := newCharmStoreClient(bakeryClient).WithChannel(deployCmd.Channel)
 
-			return &deployAPIAdapter{
-				Connection:        apiRoot,
-				apiClient:         &apiClient{Client: apiRoot.Client()},
-				charmsClient:      &charmsClient{Client: apicharms.NewClient(apiRoot)},
-				applicationClient: &applicationClient{Client: application.NewClient(apiRoot)},
-				modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
-				charmstoreClient:  &charmstoreClient{Client: cstoreClient},
-				annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
-				charmRepoClient:   &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
-			}, nil
+			return newDeployAPIAdapter(apiRoot, cstoreClient), nil
 		}
 	}
 	return modelcmd.Wrap(deployCmd)
@@ -243,16 +266,7 @@ func NewDeployCommand() modelcmd.ModelCommand {
 		}
 		cstoreClient := newCharmStoreClient(bakeryClient).WithChannel(deployCmd.Channel)
 
-		return &deployAPIAdapter{
-			Connection:        apiRoot,
-			apiClient:         &apiClient{Client: apiRoot.Client()},
-			charmsClient:      &charmsClient{Client: apicharms.NewClient(apiRoot)},
-			applicationClient: &applicationClient{Client: application.NewClient(apiRoot)},
-			modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
-			charmstoreClient:  &charmstoreClient{Client: cstoreClient},
-			annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
-			charmRepoClient:   &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
-		}, nil
+		return newDeployAPIAdapter(apiRoot, cstoreClient), nil
 	}
 
 	return modelcmd.Wrap(deployCmd)
@@ -281,10 +295,14 @@ type DeployCommand struct {
 	// running an unsupported series.
 	Force bool
 
-	// DryRun is used to specify that the bundle shouldn't actually be
-	// deployed but just output the changes.
+	// DryRun is used to specify that the charm or bundle shouldn't
+	// actually be deployed but just output the changes.
 	DryRun bool
 
+	// Trust signifies that the charm should be deployed with access to
+	// the model's cloud credential.
+	Trust bool
+
 	ApplicationName string
 	ConfigOptions   common.ConfigFlag
 	ConstraintsStr  string
@@ -454,6 +472,17 @@ for the bundle machine 3.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+The --trust option grants the application access to the cloud credential of
+the model, which its charm can read with the credential-get hook tool. Trust
+can be granted or removed later with "juju trust".
+
+The --dry-run option shows what the deploy would do without changing the
+model. For a charm, the controller checks the charm URL and series, the
+placement directives, the storage pools and the resources, and reports the
+units, storage, endpoint bindings and resource revisions that the application
+would have. Neither the charm nor any resources are uploaded to the
+controller.
+
 
 Examples:
     juju deploy mysql               (deploy to a new machine)
@@ -481,12 +510,19 @@ Examples:
     (deploy 2 units to machines that are in the 'dmz' space but not of
     the 'cmd' or the 'database' spaces)
 
+    juju deploy mysql -n 2 --to 3 --dry-run
+    (show what deploying 2 units, one on machine 3, would do)
+
+    juju deploy aws-integrator --trust
+    (deploy aws-integrator with access to the model's cloud credential)
+
 See also:
     add-unit
     config
     set-constraints
     get-constraints
     spaces
+    trust
 `
 
 // DeployStep is an action that needs to be taken during charm deployment.
@@ -527,11 +563,10 @@ var (
 	// whether we are deploying a charm or a bundle.
 	charmOnlyFlags = []string{
 		"bind", "config", "constraints", "force", "n", "num-units",
-		"series", "to", "resource", "attach-storage",
+		"series", "to", "resource", "attach-storage", "trust",
 	}
-	// TODO(thumper): support dry-run for apps as well as bundles.
 	bundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "map-machines",
 	}
 )
 
@@ -547,8 +582,9 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
-	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the deploy would do")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed to a machine running an unsupported series")
+	f.BoolVar(&c.Trust, "trust", false, "Allows charm to run hooks that require access credentials")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
@@ -703,16 +739,44 @@ func (c *DeployCommand) deployBundle(
 	return nil
 }
 
+// checkDryRunSupported returns an error if --dry-run was requested
+// but the controller cannot do a dry run of a charm deployment. It is
+// checked before anything is added to the model.
+func (c *DeployCommand) checkDryRunSupported(apiRoot DeployAPI) error {
+	if c.DryRun && apiRoot.BestFacadeVersion("Application") < 7 {
+		// Dry runs of charm deployments are only supported from
+		// Application API version 7 and onwards.
+		return errors.New("this juju controller does not support --dry-run when deploying a charm")
+	}
+	return nil
+}
+
+// deployCharm deploys the charm with the given ID. For dry runs,
+// dryRunCharm holds the charm if it has not been added to the model.
 func (c *DeployCommand) deployCharm(
 	id charmstore.CharmID,
 	csMac *macaroon.Macaroon,
 	series string,
 	ctx *cmd.Context,
 	apiRoot DeployAPI,
+	dryRunCharm charm.Charm,
 ) (rErr error) {
-	charmInfo, err := apiRoot.CharmInfo(id.URL.String())
-	if err != nil {
-		return err
+	var charmInfo *apicharms.CharmInfo
+	if dryRunCharm != nil {
+		charmInfo = &apicharms.CharmInfo{
+			Revision: dryRunCharm.Revision(),
+			URL:      id.URL.String(),
+			Config:   dryRunCharm.Config(),
+			Meta:     dryRunCharm.Meta(),
+			Actions:  dryRunCharm.Actions(),
+			Metrics:  dryRunCharm.Metrics(),
+		}
+	} else {
+		var err error
+		charmInfo, err = apiRoot.CharmInfo(id.URL.String())
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(c.AttachStorage) > 0 && apiRoot.BestFacadeVersion("Application") < 5 {
@@ -767,6 +831,13 @@ func (c *DeployCommand) deployCharm(
 		appConfig[k] = v.(string)
 	}
 
+	if c.Trust {
+		if apiRoot.BestFacadeVersion("Application") < 7 {
+			return errors.New("this juju controller does not support --trust")
+		}
+		appConfig[coreapplication.TrustConfigOptionName] = strconv.FormatBool(c.Trust)
+	}
+
 	// Application facade V5 expects charm config to either all be in YAML
 	// or config map. If config map is specified, that overrides YAML.
 	// So we need to combine the two here to have only one.
@@ -811,7 +882,13 @@ func (c *DeployCommand) deployCharm(
 		CharmInfo:       charmInfo,
 	}
 
-	for _, step := range c.Steps {
+	// The deploy steps (such as registering metered charms) have
+	// effects outside the model, so they are skipped for dry runs.
+	steps := c.Steps
+	if c.DryRun {
+		steps = nil
+	}
+	for _, step := range steps {
 		err = step.RunPre(apiRoot, bakeryClient, ctx, deployInfo)
 		if err != nil {
 			return errors.Trace(err)
@@ -819,7 +896,7 @@ func (c *DeployCommand) deployCharm(
 	}
 
 	defer func() {
-		for _, step := range c.Steps {
+		for _, step := range steps {
 			err = errors.Trace(step.RunPost(apiRoot, bakeryClient, ctx, deployInfo, rErr))
 			if err != nil {
 				rErr = err
@@ -832,18 +909,6 @@ func (c *DeployCommand) deployCharm(
 			strings.Join(charmInfo.Meta.Terms, " "))
 	}
 
-	ids, err := resourceadapters.DeployResources(
-		applicationName,
-		id,
-		csMac,
-		c.Resources,
-		charmInfo.Meta.Resources,
-		apiRoot,
-	)
-	if err != nil {
-		return errors.Trace(err)
-	}
-
 	if len(appConfig) == 0 {
 		appConfig = nil
 	}
@@ -858,9 +923,39 @@ func (c *DeployCommand) deployCharm(
 		Placement:        c.Placement,
 		Storage:          c.Storage,
 		AttachStorage:    c.AttachStorage,
-		Resources:        ids,
 		EndpointBindings: c.Bindings,
 	}
+	if c.DryRun {
+		// Nothing is uploaded for a dry run: the controller
+		// is told which resources would be used instead.
+		resources, err := dryRunResources(c.Resources, charmInfo.Meta.Resources)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result, err := apiRoot.DeployDryRun(application.DeployDryRunArgs{
+			DeployArgs:      args,
+			Charm:           dryRunCharm,
+			DryRunResources: resources,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		writeDeployDryRun(ctx.Stdout, result)
+		return nil
+	}
+
+	ids, err := resourceadapters.DeployResources(
+		applicationName,
+		id,
+		csMac,
+		c.Resources,
+		charmInfo.Meta.Resources,
+		apiRoot,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args.Resources = ids
 	return errors.Trace(apiRoot.Deploy(args))
 }
 
@@ -978,6 +1073,9 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 		if err := c.validateCharmFlags(); err != nil {
 			return errors.Trace(err)
 		}
+		if err := c.checkDryRunSupported(api); err != nil {
+			return errors.Trace(err)
+		}
 		formattedCharmURL := userCharmURL.String()
 		ctx.Infof("Located charm %q.", formattedCharmURL)
 		ctx.Infof("Deploying charm %q.", formattedCharmURL)
@@ -987,6 +1085,7 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 			userCharmURL.Series,
 			ctx,
 			api,
+			nil, // the charm is already in the model.
 		))
 	}, nil
 }
@@ -1126,7 +1225,15 @@ func (c *DeployCommand) maybeReadLocalCharm(apiRoot DeployAPI) (deployFn, error)
 			return errors.Trace(err)
 		}
 
-		if curl, err = apiRoot.AddLocalCharm(curl, ch); err != nil {
+		if err := c.checkDryRunSupported(apiRoot); err != nil {
+			return errors.Trace(err)
+		}
+		var dryRunCharm charm.Charm
+		if c.DryRun {
+			// The charm is not uploaded for a dry run; the
+			// controller checks the URL it would be given.
+			dryRunCharm = ch
+		} else if curl, err = apiRoot.AddLocalCharm(curl, ch); err != nil {
 			return errors.Trace(err)
 		}
 
@@ -1142,6 +1249,7 @@ func (c *DeployCommand) maybeReadLocalCharm(apiRoot DeployAPI) (deployFn, error)
 			curl.Series,
 			ctx,
 			apiRoot,
+			dryRunCharm,
 		))
 	}, nil
 }
@@ -1240,13 +1348,28 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			return errors.Errorf("%v. Use --force to deploy the charm anyway.", err)
 		}
 
-		// Store the charm in the controller
-		curl, csMac, err := addCharmFromURL(apiRoot, storeCharmOrBundleURL, channel)
-		if err != nil {
-			if termErr, ok := errors.Cause(err).(*common.TermsRequiredError); ok {
-				return errors.Trace(termErr.UserErr())
+		if err := c.checkDryRunSupported(apiRoot); err != nil {
+			return errors.Trace(err)
+		}
+
+		curl := storeCharmOrBundleURL
+		var csMac *macaroon.Macaroon
+		var dryRunCharm charm.Charm
+		if c.DryRun {
+			// Fetch the charm for the dry run rather than
+			// storing it in the controller.
+			if dryRunCharm, err = apiRoot.Get(curl); err != nil {
+				return errors.Annotatef(err, "getting charm for URL %q", curl)
+			}
+		} else {
+			// Store the charm in the controller
+			curl, csMac, err = addCharmFromURL(apiRoot, storeCharmOrBundleURL, channel)
+			if err != nil {
+				if termErr, ok := errors.Cause(err).(*common.TermsRequiredError); ok {
+					return errors.Trace(termErr.UserErr())
+				}
+				return errors.Annotatef(err, "storing charm for URL %q", storeCharmOrBundleURL)
 			}
-			return errors.Annotatef(err, "storing charm for URL %q", storeCharmOrBundleURL)
 		}
 
 		formattedCharmURL := curl.String()
@@ -1262,6 +1385,7 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			series,
 			ctx,
 			apiRoot,
+			dryRunCharm,
 		))
 	}, nil
 }
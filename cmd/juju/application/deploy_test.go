@@ -48,6 +48,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -573,7 +574,7 @@ func (s *DeploySuite) TestDeployFlags(c *gc.C) {
 	c.Assert(command.flagSet, jc.DeepEquals, flagSet)
 	// Add to the slice below if a new flag is introduced which is valid for
 	// both charms and bundles.
-	charmAndBundleFlags := []string{"channel", "storage"}
+	charmAndBundleFlags := []string{"channel", "storage", "dry-run"}
 	var allFlags []string
 	flagSet.VisitAll(func(flag *gnuflag.Flag) {
 		allFlags = append(allFlags, flag.Name)
@@ -1454,6 +1455,140 @@ func (s *DeployUnitTestSuite) TestDeployApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *DeployUnitTestSuite) TestDeployWithTrust(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "dummy")
+	fakeAPI := s.fakeAPI()
+	fakeAPI.Call("BestFacadeVersion", "Application").Returns(7)
+
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	withLocalCharmDeployable(fakeAPI, dummyURL, charmDir)
+	withCharmDeployable(fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil,
+		map[string]string{"trust": "true"},
+	)
+
+	_, err := s.runDeploy(c, fakeAPI, dummyURL.String(), "--trust")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DeployUnitTestSuite) TestDeployWithTrustNotSupported(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "dummy")
+	fakeAPI := s.fakeAPI()
+	fakeAPI.Call("BestFacadeVersion", "Application").Returns(6)
+
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	withLocalCharmDeployable(fakeAPI, dummyURL, charmDir)
+	withCharmDeployable(fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil, nil)
+
+	_, err := s.runDeploy(c, fakeAPI, dummyURL.String(), "--trust")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --trust")
+}
+
+func (s *DeployUnitTestSuite) TestDeployDryRun(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "dummy")
+	fakeAPI := s.fakeAPI()
+	fakeAPI.Call("BestFacadeVersion", "Application").Returns(7)
+
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	withLocalCharmDeployable(fakeAPI, dummyURL, charmDir)
+	withCharmDeployable(fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil, nil)
+	fakeAPI.Call("DeployDryRun", application.DeployDryRunArgs{
+		DeployArgs: application.DeployArgs{
+			CharmID:         jjcharmstore.CharmID{URL: dummyURL},
+			ApplicationName: dummyURL.Name,
+			Series:          "trusty",
+			NumUnits:        1,
+		},
+	}).Returns(params.DeployDryRunResult{
+		ApplicationName: "dummy",
+		CharmURL:        dummyURL.String(),
+		Series:          "trusty",
+		NumUnits:        1,
+		Placement:       []string{""},
+		Storage: map[string]storage.Constraints{
+			"data": {Pool: "loop", Size: 1024, Count: 1},
+		},
+		EndpointBindings: map[string]string{"": "", "db": "db-space"},
+	}, error(nil))
+
+	ctx, err := s.runDeploy(c, fakeAPI, dummyURL.String(), "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Changes to deploy application dummy:
+- deploy application dummy on trusty using local:trusty/dummy-0
+- add unit 1/1 to a new machine
+- add storage data using pool "loop" (count 1, size 1024M)
+- bind endpoint db to space db-space
+`[1:])
+	for _, call := range fakeAPI.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "Deploy")
+	}
+}
+
+func (s *DeployUnitTestSuite) TestDeployDryRunNotSupported(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "dummy")
+	fakeAPI := s.fakeAPI()
+
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	withLocalCharmDeployable(fakeAPI, dummyURL, charmDir)
+	withCharmDeployable(fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil, nil)
+
+	_, err := s.runDeploy(c, fakeAPI, dummyURL.String(), "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --dry-run when deploying a charm")
+}
+
+func (s *DeployUnitTestSuite) TestDeployDryRunLocalCharmNotUploaded(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+	fakeAPI.Call("BestFacadeVersion", "Application").Returns(7)
+
+	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
+	withLocalCharmDeployable(fakeAPI, multiSeriesURL, charmDir)
+	withCharmDeployable(fakeAPI, multiSeriesURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil, nil)
+	fakeAPI.Call("DeployDryRun", application.DeployDryRunArgs{
+		DeployArgs: application.DeployArgs{
+			CharmID:         jjcharmstore.CharmID{URL: multiSeriesURL},
+			ApplicationName: multiSeriesURL.Name,
+			Series:          "trusty",
+			NumUnits:        1,
+		},
+		Charm: charmDir,
+	}).Returns(params.DeployDryRunResult{
+		ApplicationName: "multi-series",
+		CharmURL:        multiSeriesURL.String(),
+		Series:          "trusty",
+		NumUnits:        1,
+		Placement:       []string{""},
+	}, error(nil))
+
+	ctx, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Changes to deploy application multi-series:
+- deploy application multi-series on trusty using local:trusty/multi-series-1
+- add unit 1/1 to a new machine
+`[1:])
+	for _, call := range fakeAPI.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "AddLocalCharm")
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "CharmInfo")
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "Deploy")
+	}
+}
+
+func (s *DeployUnitTestSuite) TestDeployDryRunNotSupportedBeforeUpload(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+
+	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
+	withLocalCharmDeployable(fakeAPI, multiSeriesURL, charmDir)
+	withCharmDeployable(fakeAPI, multiSeriesURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil, nil)
+
+	_, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --dry-run when deploying a charm")
+	for _, call := range fakeAPI.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "AddLocalCharm")
+	}
+}
+
 func (s *DeployUnitTestSuite) TestDeployLocalWithBundleOverlay(c *gc.C) {
 	charmDir := s.makeCharmDir(c, "multi-series")
 	fakeAPI := s.fakeAPI()
@@ -1734,6 +1869,23 @@ func (f *fakeDeployAPI) Deploy(args application.DeployArgs) error {
 	return jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) DeployDryRun(args application.DeployDryRunArgs) (params.DeployDryRunResult, error) {
+	results := f.MethodCall(f, "DeployDryRun", args)
+	if len(results) != 2 {
+		return params.DeployDryRunResult{}, errors.Errorf("expected 2 results, got %d: %v", len(results), results)
+	}
+	return results[0].(params.DeployDryRunResult), jujutesting.TypeAssertError(results[1])
+}
+
+func (f *fakeDeployAPI) Get(url *charm.URL) (charm.Charm, error) {
+	results := f.MethodCall(f, "Get", url)
+	if len(results) != 2 {
+		return nil, errors.Errorf("expected 2 results, got %d: %v", len(results), results)
+	}
+	ch, _ := results[0].(charm.Charm)
+	return ch, jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
 	return nil, nil
 }
@@ -0,0 +1,218 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v2"
+	"gopkg.in/juju/charmrepo.v2/csclient/params"
+
+	apiparams "github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+)
+
+const diffBundleDoc = `
+Show what changes would need to be made to the current model to make it
+match the specified bundle. The bundle can be a local bundle file or
+directory, or the name of a bundle in the charm store.
+
+The output is YAML describing, for each application, machine and relation
+that differs, the value in the bundle and the value in the model side by
+side. Applications or machines that only exist on one side are reported
+as missing from the other.
+
+Bundle overlays can be specified with --overlay, and the mapping of bundle
+machines to existing model machines with --map-machines, in the same way
+as for "juju deploy".
+
+Examples:
+    juju diff-bundle localbundle.yaml
+    juju diff-bundle canonical-kubernetes
+    juju diff-bundle -m othermodel hadoop-spark
+    juju diff-bundle mongodb-cluster --channel beta
+    juju diff-bundle localbundle.yaml --overlay overlay.yaml
+    juju diff-bundle localbundle.yaml --map-machines 3=4
+
+See also:
+    deploy
+    export-bundle
+`
+
+// NewDiffBundleCommand returns a command to compare a bundle against
+// the selected model.
+func NewDiffBundleCommand() cmd.Command {
+	cmd := &diffBundleCommand{}
+	cmd.newAPIRootFn = func() (DiffBundleAPI, error) {
+		return cmd.newAPIRoot()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// DiffBundleAPI provides the API methods needed by the diff-bundle
+// command.
+type DiffBundleAPI interface {
+	Close() error
+	Status(patterns []string) (*apiparams.FullStatus, error)
+	GetAnnotations(tags []string) ([]apiparams.AnnotationsGetResult, error)
+	GetConfig(appNames ...string) ([]map[string]interface{}, error)
+	GetConstraints(appNames ...string) ([]constraints.Value, error)
+	ModelGet() (map[string]interface{}, error)
+	Resolve(*config.Config, *charm.URL) (*charm.URL, params.Channel, []string, error)
+	GetBundle(*charm.URL) (charm.Bundle, error)
+}
+
+// diffBundleCommand compares a bundle to a model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	bundle         string
+	bundleOverlays []string
+	channel        params.Channel
+	machineMap     string
+
+	useExistingMachines bool
+	bundleMachines      map[string]string
+
+	newAPIRootFn func() (DiffBundleAPI, error)
+}
+
+// Info is part of cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or name>",
+		Purpose: "Compare a bundle with a model and report any differences.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags is part of cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar((*string)(&c.channel), "channel", "", "Channel to use when getting the bundle from the charm store")
+	f.Var(cmd.NewAppendStringsValue(&c.bundleOverlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.StringVar(&c.machineMap, "map-machines", "", "Indicates how existing machines correspond to bundle machines")
+}
+
+// Init is part of cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no bundle specified")
+	}
+	c.bundle = args[0]
+	useExisting, mapping, err := parseMachineMap(c.machineMap)
+	if err != nil {
+		return errors.Annotate(err, "error in --map-machines")
+	}
+	c.useExistingMachines = useExisting
+	c.bundleMachines = mapping
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	apiRoot, err := c.newAPIRootFn()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiRoot.Close()
+
+	modelConfig, err := getModelConfig(apiRoot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	data, bundleDir, err := c.readBundle(ctx, apiRoot, modelConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := composeAndVerifyBundle(ctx, bundleDir, data, c.bundleOverlays); err != nil {
+		return errors.Trace(err)
+	}
+
+	status, err := apiRoot.Status(nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot get model status")
+	}
+	model, err := buildModelRepresentation(status, apiRoot, c.useExistingMachines, c.bundleMachines)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := resolveBundleCharms(ctx, data, model, modelConfig, apiRoot); err != nil {
+		return errors.Trace(err)
+	}
+
+	modelSeries, _ := modelConfig.DefaultSeries()
+	diff := buildBundleDiff(data, model, modelSeries)
+	return c.out.Write(ctx, diff)
+}
+
+// readBundle reads the bundle from the local filesystem, or from the
+// charm store if there is no local bundle with the given name. It
+// returns the bundle data and, for local bundles, the directory that
+// holds the bundle.
+func (c *diffBundleCommand) readBundle(
+	ctx *cmd.Context,
+	apiRoot DiffBundleAPI,
+	modelConfig *config.Config,
+) (*charm.BundleData, string, error) {
+	bundlePath := ctx.AbsPath(c.bundle)
+	if data, err := charmrepo.ReadBundleFile(bundlePath); err == nil {
+		return data, filepath.Dir(bundlePath), nil
+	}
+	if bundle, _, err := charmrepo.NewBundleAtPath(bundlePath); err == nil {
+		bundleDir := ""
+		if info, err := os.Stat(bundlePath); err == nil && info.IsDir() {
+			bundleDir = bundlePath
+		}
+		return bundle.Data(), bundleDir, nil
+	} else if !charmrepo.IsInvalidPathError(err) {
+		if _, statErr := os.Stat(bundlePath); statErr == nil {
+			return nil, "", errors.Annotatef(err, "cannot read bundle %q", c.bundle)
+		}
+	}
+
+	// Not a local bundle, so try the charm store.
+	bundleURL, err := charm.ParseURL(c.bundle)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	resolvedURL, _, _, err := apiRoot.Resolve(modelConfig, bundleURL)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if resolvedURL.Series != "bundle" {
+		return nil, "", errors.Errorf("%q is not a bundle", c.bundle)
+	}
+	bundle, err := apiRoot.GetBundle(resolvedURL)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	ctx.Infof("Located bundle %q", resolvedURL)
+	return bundle.Data(), "", nil
+}
+
+func (c *diffBundleCommand) newAPIRoot() (DiffBundleAPI, error) {
+	apiRoot, err := c.ModelCommandBase.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bakeryClient, err := c.BakeryClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cstoreClient := newCharmStoreClient(bakeryClient).WithChannel(c.channel)
+	return newDeployAPIAdapter(apiRoot, cstoreClient), nil
+}
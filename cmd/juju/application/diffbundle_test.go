@@ -0,0 +1,417 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v2/csclient/params"
+
+	apiparams "github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type diffSuite struct {
+	jujutesting.IsolationSuite
+	api   *mockDiffAPI
+	store *jujuclient.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&diffSuite{})
+
+func (s *diffSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockDiffAPI{
+		Stub: &jujutesting.Stub{},
+		status: &apiparams.FullStatus{
+			Applications: map[string]apiparams.ApplicationStatus{
+				"prometheus": {
+					Charm: "cs:xenial/prometheus-7",
+					Units: map[string]apiparams.UnitStatus{
+						"prometheus/0": {Machine: "0"},
+					},
+				},
+				"grafana": {
+					Charm:   "cs:xenial/grafana-19",
+					Exposed: true,
+					Units: map[string]apiparams.UnitStatus{
+						"grafana/0": {Machine: "1"},
+					},
+				},
+			},
+			Machines: map[string]apiparams.MachineStatus{
+				"0": {},
+				"1": {},
+			},
+			Relations: []apiparams.RelationStatus{{
+				Endpoints: []apiparams.EndpointStatus{
+					{ApplicationName: "grafana", Name: "grafana-source"},
+					{ApplicationName: "prometheus", Name: "grafana-source"},
+				},
+			}},
+		},
+		config: map[string][]map[string]interface{}{
+			"prometheus": {{
+				"web-listen-port": map[string]interface{}{
+					"source": "user",
+					// Config values come through the API as JSON.
+					"value": float64(9090),
+				},
+			}},
+			"grafana": {{}},
+		},
+		modelConfig: coretesting.FakeConfig().Merge(coretesting.Attrs{
+			"default-series": "xenial",
+		}),
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		coretesting.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+	s.dir = c.MkDir()
+}
+
+func (s *diffSuite) writeFile(c *gc.C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *diffSuite) runDiff(c *gc.C, args ...string) (string, error) {
+	diffCmd := &diffBundleCommand{
+		newAPIRootFn: func() (DiffBundleAPI, error) {
+			return s.api, nil
+		},
+	}
+	diffCmd.SetClientStore(s.store)
+	command := modelcmd.Wrap(diffCmd)
+	ctx := cmdtesting.Context(c)
+	ctx.Dir = s.dir
+	err := cmdtesting.InitCommand(command, args)
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	return cmdtesting.Stdout(ctx), err
+}
+
+func (s *diffSuite) TestInitNoBundle(c *gc.C) {
+	err := cmdtesting.InitCommand(&diffBundleCommand{}, []string{})
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *diffSuite) TestInitTooManyArgs(c *gc.C) {
+	err := cmdtesting.InitCommand(&diffBundleCommand{}, []string{"bundle.yaml", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *diffSuite) TestInitBadMachineMap(c *gc.C) {
+	err := cmdtesting.InitCommand(&diffBundleCommand{}, []string{"bundle.yaml", "--map-machines", "foo"})
+	c.Assert(err, gc.ErrorMatches, `error in --map-machines: expected "existing" or "<bundle-id>=<machine-id>", got "foo"`)
+}
+
+func (s *diffSuite) TestNoDifferences(c *gc.C) {
+	s.writeFile(c, "bundle.yaml", `
+applications:
+  prometheus:
+    charm: cs:xenial/prometheus-7
+    num_units: 1
+    to: ["0"]
+    options:
+      web-listen-port: 9090
+  grafana:
+    charm: cs:xenial/grafana-19
+    num_units: 1
+    expose: true
+    to: ["1"]
+machines:
+  "0": {}
+  "1": {}
+relations:
+  - ["prometheus:grafana-source", "grafana:grafana-source"]
+`)
+	out, err := s.runDiff(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+	s.api.CheckCallNames(c, "ModelGet", "Status", "GetAnnotations", "GetConfig", "GetConstraints", "Close")
+}
+
+func (s *diffSuite) TestDifferentOptionValue(c *gc.C) {
+	s.writeFile(c, "bundle.yaml", `
+applications:
+  prometheus:
+    charm: cs:xenial/prometheus-7
+    num_units: 1
+    to: ["0"]
+    options:
+      web-listen-port: 8080
+  grafana:
+    charm: cs:xenial/grafana-19
+    num_units: 1
+    expose: true
+    to: ["1"]
+machines:
+  "0": {}
+  "1": {}
+relations:
+  - ["prometheus:grafana-source", "grafana:grafana-source"]
+`)
+	out, err := s.runDiff(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  prometheus:
+    options:
+      web-listen-port:
+        bundle: 8080
+        model: 9090
+`[1:])
+}
+
+func (s *diffSuite) TestDifferences(c *gc.C) {
+	s.writeFile(c, "bundle.yaml", `
+series: bionic
+applications:
+  prometheus:
+    charm: cs:xenial/prometheus-7
+    num_units: 2
+    to: ["0", "2"]
+    constraints: mem=4G
+  telegraf:
+    charm: cs:xenial/telegraf-3
+machines:
+  "0": {}
+  "2": {}
+relations:
+  - ["prometheus", "telegraf"]
+`)
+	out, err := s.runDiff(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  grafana:
+    missing: bundle
+  prometheus:
+    num_units:
+      bundle: 2
+      model: 1
+    options:
+      web-listen-port:
+        bundle: null
+        model: 9090
+    constraints:
+      bundle: mem=4G
+      model: ""
+  telegraf:
+    missing: model
+machines:
+  "1":
+    missing: bundle
+  "2":
+    missing: model
+series:
+  bundle: bionic
+  model: xenial
+relations:
+  bundle-additions:
+  - - prometheus
+    - telegraf
+  model-additions:
+  - - grafana:grafana-source
+    - prometheus:grafana-source
+`[1:])
+}
+
+func (s *diffSuite) TestOverlayAndMachineMap(c *gc.C) {
+	s.writeFile(c, "bundle.yaml", `
+applications:
+  prometheus:
+    charm: cs:xenial/prometheus-7
+    num_units: 1
+    to: ["5"]
+    options:
+      web-listen-port: 9090
+  grafana:
+    charm: cs:xenial/grafana-19
+    num_units: 1
+    to: ["6"]
+machines:
+  "5": {}
+  "6": {}
+relations:
+  - ["prometheus:grafana-source", "grafana:grafana-source"]
+`)
+	s.writeFile(c, "overlay.yaml", `
+applications:
+  grafana:
+    expose: true
+`)
+	out, err := s.runDiff(c, "bundle.yaml", "--overlay", "overlay.yaml", "--map-machines", "5=0,6=1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+}
+
+func (s *diffSuite) TestResolvesCharms(c *gc.C) {
+	s.writeFile(c, "bundle.yaml", `
+applications:
+  prometheus:
+    charm: prometheus
+    num_units: 1
+    to: ["0"]
+    options:
+      web-listen-port: 9090
+  grafana:
+    charm: cs:xenial/grafana-19
+    num_units: 1
+    expose: true
+    to: ["1"]
+machines:
+  "0": {}
+  "1": {}
+relations:
+  - ["prometheus:grafana-source", "grafana:grafana-source"]
+`)
+	s.api.resolved = map[string]string{
+		"cs:prometheus": "cs:xenial/prometheus-8",
+	}
+	out, err := s.runDiff(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  prometheus:
+    charm:
+      bundle: cs:xenial/prometheus-8
+      model: cs:xenial/prometheus-7
+`[1:])
+}
+
+func (s *diffSuite) TestCharmStoreBundle(c *gc.C) {
+	s.api.resolved = map[string]string{
+		"cs:monitoring": "cs:bundle/monitoring-3",
+	}
+	s.api.bundle = &mockBundle{data: &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"grafana": {
+				Charm:    "cs:xenial/grafana-19",
+				NumUnits: 1,
+				Expose:   true,
+			},
+		},
+	}}
+	out, err := s.runDiff(c, "monitoring")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  prometheus:
+    missing: bundle
+machines:
+  "0":
+    missing: bundle
+  "1":
+    missing: bundle
+relations:
+  model-additions:
+  - - grafana:grafana-source
+    - prometheus:grafana-source
+`[1:])
+}
+
+func (s *diffSuite) TestNotABundle(c *gc.C) {
+	s.api.resolved = map[string]string{
+		"cs:grafana": "cs:xenial/grafana-19",
+	}
+	_, err := s.runDiff(c, "grafana")
+	c.Assert(err, gc.ErrorMatches, `"grafana" is not a bundle`)
+}
+
+type mockDiffAPI struct {
+	*jujutesting.Stub
+
+	status      *apiparams.FullStatus
+	config      map[string][]map[string]interface{}
+	modelConfig coretesting.Attrs
+	resolved    map[string]string
+	bundle      charm.Bundle
+}
+
+func (m *mockDiffAPI) Close() error {
+	m.AddCall("Close")
+	return m.NextErr()
+}
+
+func (m *mockDiffAPI) Status(patterns []string) (*apiparams.FullStatus, error) {
+	m.AddCall("Status", patterns)
+	return m.status, m.NextErr()
+}
+
+func (m *mockDiffAPI) GetAnnotations(tags []string) ([]apiparams.AnnotationsGetResult, error) {
+	m.AddCall("GetAnnotations", tags)
+	return nil, m.NextErr()
+}
+
+func (m *mockDiffAPI) GetConfig(appNames ...string) ([]map[string]interface{}, error) {
+	m.AddCall("GetConfig", appNames)
+	var result []map[string]interface{}
+	for _, name := range appNames {
+		result = append(result, m.config[name]...)
+	}
+	return result, m.NextErr()
+}
+
+func (m *mockDiffAPI) GetConstraints(appNames ...string) ([]constraints.Value, error) {
+	m.AddCall("GetConstraints", appNames)
+	return make([]constraints.Value, len(appNames)), m.NextErr()
+}
+
+func (m *mockDiffAPI) ModelGet() (map[string]interface{}, error) {
+	m.AddCall("ModelGet")
+	return m.modelConfig, m.NextErr()
+}
+
+func (m *mockDiffAPI) Resolve(cfg *config.Config, url *charm.URL) (*charm.URL, params.Channel, []string, error) {
+	m.AddCall("Resolve", url.String())
+	if err := m.NextErr(); err != nil {
+		return nil, "", nil, err
+	}
+	resolved, ok := m.resolved[url.String()]
+	if !ok {
+		return nil, "", nil, errors.NotFoundf("charm %q", url)
+	}
+	return charm.MustParseURL(resolved), params.StableChannel, nil, nil
+}
+
+func (m *mockDiffAPI) GetBundle(url *charm.URL) (charm.Bundle, error) {
+	m.AddCall("GetBundle", url.String())
+	return m.bundle, m.NextErr()
+}
+
+type mockBundle struct {
+	data *charm.BundleData
+}
+
+func (b *mockBundle) Data() *charm.BundleData {
+	return b.data
+}
+
+func (b *mockBundle) ReadMe() string {
+	return ""
+}
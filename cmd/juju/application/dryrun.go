@@ -0,0 +1,147 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/juju/errors"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// writeDeployDryRun writes a description of the changes that deploying
+// a charm would make, as reported by the controller.
+func writeDeployDryRun(w io.Writer, result params.DeployDryRunResult) {
+	fmt.Fprintf(w, "Changes to deploy application %s:\n", result.ApplicationName)
+	if result.Series != "" {
+		fmt.Fprintf(w, "- deploy application %s on %s using %s\n", result.ApplicationName, result.Series, result.CharmURL)
+	} else {
+		fmt.Fprintf(w, "- deploy application %s using %s\n", result.ApplicationName, result.CharmURL)
+	}
+	writeUnitPlacements(w, result.Placement)
+
+	storageNames := make([]string, 0, len(result.Storage))
+	for name := range result.Storage {
+		storageNames = append(storageNames, name)
+	}
+	sort.Strings(storageNames)
+	for _, name := range storageNames {
+		cons := result.Storage[name]
+		fmt.Fprintf(w, "- add storage %s using pool %q (count %d, size %dM)\n", name, cons.Pool, cons.Count, cons.Size)
+	}
+
+	endpoints := make([]string, 0, len(result.EndpointBindings))
+	for endpoint, space := range result.EndpointBindings {
+		if endpoint != "" && space != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(w, "- bind endpoint %s to space %s\n", endpoint, result.EndpointBindings[endpoint])
+	}
+	writeDryRunResources(w, result.Resources)
+}
+
+// writeSetCharmDryRun writes a description of the changes that
+// upgrading an application's charm would make, as reported by the
+// controller.
+func writeSetCharmDryRun(w io.Writer, result params.SetCharmDryRunResult) {
+	fmt.Fprintf(w, "Changes to upgrade application %s:\n", result.ApplicationName)
+	fmt.Fprintf(w, "- upgrade charm from %s to %s\n", result.CurrentCharmURL, result.CharmURL)
+	for _, name := range result.ConfigAdded {
+		fmt.Fprintf(w, "- add config option %s\n", name)
+	}
+	for _, name := range result.ConfigRemoved {
+		fmt.Fprintf(w, "- remove config option %s\n", name)
+	}
+	for _, key := range result.BrokenRelations {
+		fmt.Fprintf(w, "- would break relation %q\n", key)
+	}
+	writeDryRunResources(w, result.Resources)
+}
+
+// writeAddUnitsDryRun writes a description of where adding units to
+// an application would place them, as reported by the controller.
+func writeAddUnitsDryRun(w io.Writer, result params.AddUnitsDryRunResult) {
+	fmt.Fprintf(w, "Changes to add units to application %s:\n", result.ApplicationName)
+	writeUnitPlacements(w, result.Placement)
+}
+
+func writeUnitPlacements(w io.Writer, placement []string) {
+	for i, p := range placement {
+		var target string
+		switch {
+		case p == "":
+			target = "a new machine"
+		case names.IsValidMachine(p):
+			target = "machine " + p
+		default:
+			target = p
+		}
+		fmt.Fprintf(w, "- add unit %d/%d to %s\n", i+1, len(placement), target)
+	}
+}
+
+// dryRunResources describes the resources a dry run would use, given
+// the files and revisions specified with --resource, without uploading
+// anything. Resources which are not specified use the latest revision
+// in the charm store.
+func dryRunResources(
+	filesAndRevisions map[string]string,
+	metas map[string]charmresource.Meta,
+) ([]params.DryRunResource, error) {
+	for name := range filesAndRevisions {
+		if _, ok := metas[name]; !ok {
+			return nil, errors.Errorf("unrecognized resource %q", name)
+		}
+	}
+	var resources []params.DryRunResource
+	for name := range metas {
+		res := params.DryRunResource{
+			Name:     name,
+			Origin:   charmresource.OriginStore.String(),
+			Revision: -1,
+		}
+		if val, ok := filesAndRevisions[name]; ok {
+			if rev, err := strconv.Atoi(val); err == nil {
+				res.Revision = rev
+			} else {
+				if _, err := os.Stat(val); err != nil {
+					return nil, errors.Annotatef(err, "resource %q", name)
+				}
+				res.Origin = charmresource.OriginUpload.String()
+			}
+		}
+		resources = append(resources, res)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Name < resources[j].Name
+	})
+	return resources, nil
+}
+
+func writeDryRunResources(w io.Writer, resources []params.DryRunResource) {
+	for _, res := range resources {
+		switch {
+		case res.Origin == charmresource.OriginUpload.String():
+			fmt.Fprintf(w, "- upload resource %s", res.Name)
+		case res.Revision < 0:
+			fmt.Fprintf(w, "- use the latest revision of resource %s from %s", res.Name, res.Origin)
+		default:
+			fmt.Fprintf(w, "- use resource %s revision %d from %s", res.Name, res.Revision, res.Origin)
+		}
+		if res.CurrentRevision >= 0 {
+			fmt.Fprintf(w, " (currently revision %d)", res.CurrentRevision)
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -19,6 +19,7 @@ func NewUpgradeCharmCommandForTest(
 	apiOpen api.OpenFunc,
 	deployResources resourceadapters.DeployResourcesFunc,
 	resolveCharm ResolveCharmFunc,
+	getCharm GetCharmFunc,
 	newCharmAdder NewCharmAdderFunc,
 	newCharmClient func(api.Connection) CharmClient,
 	newCharmUpgradeClient func(api.Connection) CharmUpgradeClient,
@@ -28,6 +29,7 @@ func NewUpgradeCharmCommandForTest(
 	cmd := &upgradeCharmCommand{
 		DeployResources:       deployResources,
 		ResolveCharm:          resolveCharm,
+		GetCharm:              getCharm,
 		NewCharmAdder:         newCharmAdder,
 		NewCharmClient:        newCharmClient,
 		NewCharmUpgradeClient: newCharmUpgradeClient,
@@ -46,6 +48,45 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewConfigCommandWithBranchForTest returns a config command with the
+// application and branch APIs provided as specified.
+func NewConfigCommandWithBranchForTest(api applicationAPI, branchAPI branchConfigAPI) modelcmd.ModelCommand {
+	return modelcmd.Wrap(&configCommand{
+		api:       api,
+		branchAPI: branchAPI,
+	})
+}
+
+// NewTrustCommandForTest returns a trust command with the api provided
+// as specified.
+func NewTrustCommandForTest(api trustAPI) modelcmd.ModelCommand {
+	return modelcmd.Wrap(&trustCommand{api: api})
+}
+
+// NewBindCommandForTest returns a bind command with the api provided
+// as specified.
+func NewBindCommandForTest(api bindAPI) modelcmd.ModelCommand {
+	return modelcmd.Wrap(&bindCommand{api: api})
+}
+
+// NewScaleApplicationCommandForTest returns a scale-application command
+// with the api provided as specified.
+func NewScaleApplicationCommandForTest(api scaleApplicationAPI) modelcmd.ModelCommand {
+	return modelcmd.Wrap(&scaleApplicationCommand{api: api})
+}
+
+// NewShowApplicationCommandForTest returns a show-application command
+// with the api provided as specified.
+func NewShowApplicationCommandForTest(api ApplicationsInfoAPI) modelcmd.ModelCommand {
+	return modelcmd.Wrap(&showApplicationCommand{api: api})
+}
+
+// NewShowUnitCommandForTest returns a show-unit command with the api
+// provided as specified.
+func NewShowUnitCommandForTest(api UnitsInfoAPI) modelcmd.ModelCommand {
+	return modelcmd.Wrap(&showUnitCommand{api: api})
+}
+
 // NewAddRelationCommandForTest returns an AddRelationCommand with the api provided as specified.
 func NewAddRelationCommandForTest(addAPI applicationAddRelationAPI, consumeAPI applicationConsumeDetailsAPI) modelcmd.ModelCommand {
 	cmd := &addRelationCommand{addRelationAPI: addAPI, consumeDetailsAPI: consumeAPI}
@@ -0,0 +1,125 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageScaleApplicationSummary = `
+Sets the desired number of units of a Kubernetes application.`[1:]
+
+var usageScaleApplicationDetails = `
+Scaling an application records the number of pods it should be running.
+Kubernetes then starts or stops pods to match, and units are added to or
+removed from the model as the pods come and go. Scaling to 0 stops all
+of the pods while keeping the application deployed.
+
+If a horizontal pod autoscaler has been configured for the application
+using the kubernetes-autoscaler-max-replicas application config option,
+the scale sets the minimum number of pods the autoscaler will run.
+
+This command is only valid for applications in Kubernetes models.
+
+Examples:
+    juju scale-application mariadb 3
+    juju scale-application mariadb 0
+
+See also:
+    add-unit
+    remove-unit
+    config`[1:]
+
+// NewScaleApplicationCommand returns a command which sets the desired
+// scale of an application.
+func NewScaleApplicationCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&scaleApplicationCommand{})
+}
+
+// scaleApplicationAPI defines the application facade methods used by
+// the scale-application command.
+type scaleApplicationAPI interface {
+	Close() error
+	ScaleApplication(application string, scale int) error
+}
+
+// scaleApplicationCommand sets the desired scale of an application.
+type scaleApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	api scaleApplicationAPI
+
+	applicationName string
+	scale           int
+}
+
+// Info implements cmd.Command.
+func (c *scaleApplicationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "scale-application",
+		Args:    "<application name> <scale>",
+		Purpose: usageScaleApplicationSummary,
+		Doc:     usageScaleApplicationDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *scaleApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *scaleApplicationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no scale specified")
+	}
+	scale, err := strconv.Atoi(args[1])
+	if err != nil || scale < 0 {
+		return errors.Errorf("invalid scale %q: must be a non-negative integer", args[1])
+	}
+	c.scale = scale
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *scaleApplicationCommand) getAPI() (scaleApplicationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *scaleApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.ScaleApplication(c.applicationName, c.scale)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("%s scaled to %d", c.applicationName, c.scale)
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type scaleApplicationSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockScaleApplicationAPI
+}
+
+var _ = gc.Suite(&scaleApplicationSuite{})
+
+func (s *scaleApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockScaleApplicationAPI{Stub: &testing.Stub{}}
+}
+
+func (s *scaleApplicationSuite) runScaleApplication(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewScaleApplicationCommandForTest(s.mockAPI), args...)
+}
+
+func (s *scaleApplicationSuite) TestScaleApplication(c *gc.C) {
+	ctx, err := s.runScaleApplication(c, "gitlab", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "gitlab scaled to 3\n")
+	s.mockAPI.CheckCallNames(c, "ScaleApplication", "Close")
+	s.mockAPI.CheckCall(c, 0, "ScaleApplication", "gitlab", 3)
+}
+
+func (s *scaleApplicationSuite) TestScaleApplicationToZero(c *gc.C) {
+	_, err := s.runScaleApplication(c, "gitlab", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "ScaleApplication", "gitlab", 0)
+}
+
+func (s *scaleApplicationSuite) TestScaleApplicationError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("scaling applications in iaas models not supported"))
+	_, err := s.runScaleApplication(c, "mysql", "3")
+	c.Assert(err, gc.ErrorMatches, "scaling applications in iaas models not supported")
+}
+
+func (s *scaleApplicationSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"gitlab/0", "3"},
+		err:  `invalid application name "gitlab/0"`,
+	}, {
+		args: []string{"gitlab"},
+		err:  "no scale specified",
+	}, {
+		args: []string{"gitlab", "three"},
+		err:  `invalid scale "three": must be a non-negative integer`,
+	}, {
+		args: []string{"gitlab", "-1"},
+		err:  `invalid scale "-1": must be a non-negative integer`,
+	}, {
+		args: []string{"gitlab", "3", "4"},
+		err:  `unrecognized args: \["4"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runScaleApplication(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+type mockScaleApplicationAPI struct {
+	*testing.Stub
+}
+
+func (m *mockScaleApplicationAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockScaleApplicationAPI) ScaleApplication(application string, scale int) error {
+	m.MethodCall(m, "ScaleApplication", application, scale)
+	return m.NextErr()
+}
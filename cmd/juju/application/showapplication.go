@@ -0,0 +1,170 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showApplicationDoc = `
+The command takes deployed application names as an argument.
+
+For each application, the charm URL, series, channel, constraints and
+endpoint bindings are shown, along with whether the application is exposed
+and whether it is a principal or a subordinate. The subordinate applications
+of a principal, and the principal applications of a subordinate, are listed.
+For remote applications consumed from an offer, the offer URL and the model
+hosting the offer are shown instead.
+
+Examples:
+    juju show-application mysql
+    juju show-application mysql wordpress
+    juju show-application myapp --format json
+
+See also:
+    show-unit
+    status
+`
+
+// NewShowApplicationCommand returns a command that shows the details of
+// applications.
+func NewShowApplicationCommand() cmd.Command {
+	return modelcmd.Wrap(&showApplicationCommand{})
+}
+
+// ApplicationsInfoAPI defines the application facade methods used by
+// the show-application command.
+type ApplicationsInfoAPI interface {
+	Close() error
+	ApplicationsInfo([]names.ApplicationTag) ([]params.ApplicationInfoResult, error)
+}
+
+// showApplicationCommand shows the details of applications.
+type showApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ApplicationsInfoAPI
+
+	apps []string
+}
+
+// ApplicationInfo holds the output of show-application for a single
+// application.
+type ApplicationInfo struct {
+	Charm            string            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series           string            `yaml:"series,omitempty" json:"series,omitempty"`
+	Channel          string            `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Constraints      string            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Principal        bool              `yaml:"principal" json:"principal"`
+	Exposed          bool              `yaml:"exposed" json:"exposed"`
+	Remote           bool              `yaml:"remote" json:"remote"`
+	EndpointBindings map[string]string `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+	Subordinates     []string          `yaml:"subordinates,omitempty" json:"subordinates,omitempty"`
+	Principals       []string          `yaml:"principals,omitempty" json:"principals,omitempty"`
+	OfferURL         string            `yaml:"offer-url,omitempty" json:"offer-url,omitempty"`
+	SourceModel      string            `yaml:"source-model,omitempty" json:"source-model,omitempty"`
+}
+
+// Info implements cmd.Command.
+func (c *showApplicationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-application",
+		Args:    "<application name> [...]",
+		Purpose: "Displays information about an application.",
+		Doc:     showApplicationDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *showApplicationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("an application name must be supplied")
+	}
+	for _, name := range args {
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+	}
+	c.apps = args
+	return nil
+}
+
+func (c *showApplicationCommand) getAPI() (ApplicationsInfoAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *showApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	tags := make([]names.ApplicationTag, len(c.apps))
+	for i, name := range c.apps {
+		tags[i] = names.NewApplicationTag(name)
+	}
+	results, err := client.ApplicationsInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var errs params.ErrorResults
+	infos := make(map[string]ApplicationInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{Error: result.Error})
+			continue
+		}
+		infos[c.apps[i]] = formatApplicationInfo(*result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+	return c.out.Write(ctx, infos)
+}
+
+func formatApplicationInfo(info params.ApplicationInfo) ApplicationInfo {
+	out := ApplicationInfo{
+		Charm:            info.Charm,
+		Series:           info.Series,
+		Channel:          info.Channel,
+		Constraints:      info.Constraints.String(),
+		Principal:        info.Principal,
+		Exposed:          info.Exposed,
+		Remote:           info.Remote,
+		EndpointBindings: info.EndpointBindings,
+		Subordinates:     info.Subordinates,
+		Principals:       info.Principals,
+		OfferURL:         info.OfferURL,
+	}
+	if info.SourceModelTag != "" {
+		if tag, err := names.ParseModelTag(info.SourceModelTag); err == nil {
+			out.SourceModel = tag.Id()
+		}
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+type showApplicationSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockApplicationsInfoAPI
+}
+
+var _ = gc.Suite(&showApplicationSuite{})
+
+func (s *showApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockApplicationsInfoAPI{Stub: &testing.Stub{}}
+}
+
+func (s *showApplicationSuite) runShowApplication(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewShowApplicationCommandForTest(s.mockAPI), args...)
+}
+
+func (s *showApplicationSuite) TestShowApplication(c *gc.C) {
+	s.mockAPI.results = []params.ApplicationInfoResult{{
+		Result: &params.ApplicationInfo{
+			Tag:              "application-mysql",
+			Charm:            "cs:mysql-42",
+			Series:           "xenial",
+			Channel:          "stable",
+			Constraints:      constraints.MustParse("mem=4096M"),
+			Principal:        true,
+			EndpointBindings: map[string]string{"": "alpha", "db": "beta"},
+			Subordinates:     []string{"nrpe"},
+		},
+	}}
+	ctx, err := s.runShowApplication(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql:
+  charm: cs:mysql-42
+  series: xenial
+  channel: stable
+  constraints: mem=4096M
+  principal: true
+  exposed: false
+  remote: false
+  endpoint-bindings:
+    "": alpha
+    db: beta
+  subordinates:
+  - nrpe
+`[1:])
+	s.mockAPI.CheckCallNames(c, "ApplicationsInfo", "Close")
+	s.mockAPI.CheckCall(c, 0, "ApplicationsInfo", []names.ApplicationTag{names.NewApplicationTag("mysql")})
+}
+
+func (s *showApplicationSuite) TestShowApplicationRemoteJSON(c *gc.C) {
+	s.mockAPI.results = []params.ApplicationInfoResult{{
+		Result: &params.ApplicationInfo{
+			Tag:            "application-db2",
+			Remote:         true,
+			OfferURL:       "admin/other.db2",
+			SourceModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}}
+	ctx, err := s.runShowApplication(c, "db2", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"db2":{"principal":false,"exposed":false,"remote":true,`+
+		`"offer-url":"admin/other.db2","source-model":"deadbeef-0bad-400d-8000-4b1d0d06f00d"}}`+"\n")
+}
+
+func (s *showApplicationSuite) TestShowApplicationError(c *gc.C) {
+	s.mockAPI.results = []params.ApplicationInfoResult{{
+		Result: &params.ApplicationInfo{Tag: "application-mysql"},
+	}, {
+		Error: &params.Error{Message: `application "wordpress" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := s.runShowApplication(c, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+}
+
+func (s *showApplicationSuite) TestShowApplicationAPIError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runShowApplication(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *showApplicationSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runShowApplication(c)
+	c.Assert(err, gc.ErrorMatches, "an application name must be supplied")
+	_, err = s.runShowApplication(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+type mockApplicationsInfoAPI struct {
+	*testing.Stub
+	results []params.ApplicationInfoResult
+}
+
+func (m *mockApplicationsInfoAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockApplicationsInfoAPI) ApplicationsInfo(tags []names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+	m.MethodCall(m, "ApplicationsInfo", tags)
+	return m.results, m.NextErr()
+}
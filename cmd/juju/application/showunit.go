@@ -0,0 +1,205 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/status"
+)
+
+const showUnitDoc = `
+The command takes deployed unit names as an argument.
+
+For each unit, the workload and agent status, the machine the unit is on
+with its public and private addresses, the ports the unit has opened,
+whether the unit is the leader of its application, and the charm and
+workload versions are shown.
+
+The settings of the unit, and of each of its related units, are shown for
+every relation the unit is in.
+
+Examples:
+    juju show-unit mysql/0
+    juju show-unit mysql/0 wordpress/1
+    juju show-unit mysql/0 --format json
+
+See also:
+    show-application
+    status
+`
+
+// NewShowUnitCommand returns a command that shows the details of units.
+func NewShowUnitCommand() cmd.Command {
+	return modelcmd.Wrap(&showUnitCommand{})
+}
+
+// UnitsInfoAPI defines the application facade methods used by the
+// show-unit command.
+type UnitsInfoAPI interface {
+	Close() error
+	UnitsInfo([]names.UnitTag) ([]params.UnitInfoResult, error)
+}
+
+// showUnitCommand shows the details of units.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	api     UnitsInfoAPI
+	isoTime bool
+
+	units []string
+}
+
+// UnitInfo holds the output of show-unit for a single unit.
+type UnitInfo struct {
+	Charm           string             `yaml:"charm,omitempty" json:"charm,omitempty"`
+	WorkloadVersion string             `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	WorkloadStatus  UnitStatusInfo     `yaml:"workload-status" json:"workload-status"`
+	AgentStatus     UnitStatusInfo     `yaml:"juju-status" json:"juju-status"`
+	Life            string             `yaml:"life" json:"life"`
+	Leader          bool               `yaml:"leader" json:"leader"`
+	Machine         string             `yaml:"machine,omitempty" json:"machine,omitempty"`
+	PublicAddress   string             `yaml:"public-address,omitempty" json:"public-address,omitempty"`
+	PrivateAddress  string             `yaml:"private-address,omitempty" json:"private-address,omitempty"`
+	OpenedPorts     []string           `yaml:"opened-ports,omitempty" json:"opened-ports,omitempty"`
+	Principal       string             `yaml:"principal,omitempty" json:"principal,omitempty"`
+	RelationInfo    []UnitRelationInfo `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+}
+
+// UnitStatusInfo holds a workload or agent status of a unit.
+type UnitStatusInfo struct {
+	Current status.Status `yaml:"current,omitempty" json:"current,omitempty"`
+	Message string        `yaml:"message,omitempty" json:"message,omitempty"`
+	Since   string        `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+// UnitRelationInfo holds the settings of a unit, and of its related
+// units, in a single relation.
+type UnitRelationInfo struct {
+	RelationId      int                               `yaml:"relation-id" json:"relation-id"`
+	Endpoint        string                            `yaml:"endpoint" json:"endpoint"`
+	RelatedEndpoint string                            `yaml:"related-endpoint" json:"related-endpoint"`
+	Data            map[string]interface{}            `yaml:"data,omitempty" json:"data,omitempty"`
+	RelatedUnits    map[string]map[string]interface{} `yaml:"related-units,omitempty" json:"related-units,omitempty"`
+}
+
+// Info implements cmd.Command.
+func (c *showUnitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name> [...]",
+		Purpose: "Displays information about a unit.",
+		Doc:     showUnitDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("a unit name must be supplied")
+	}
+	for _, name := range args {
+		if !names.IsValidUnit(name) {
+			return errors.NotValidf("unit name %q", name)
+		}
+	}
+	c.units = args
+	return nil
+}
+
+func (c *showUnitCommand) getAPI() (UnitsInfoAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	tags := make([]names.UnitTag, len(c.units))
+	for i, name := range c.units {
+		tags[i] = names.NewUnitTag(name)
+	}
+	results, err := client.UnitsInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var errs params.ErrorResults
+	infos := make(map[string]UnitInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{Error: result.Error})
+			continue
+		}
+		infos[c.units[i]] = c.formatUnitInfo(*result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+	return c.out.Write(ctx, infos)
+}
+
+func (c *showUnitCommand) formatUnitInfo(info params.UnitInfo) UnitInfo {
+	out := UnitInfo{
+		Charm:           info.Charm,
+		WorkloadVersion: info.WorkloadVersion,
+		WorkloadStatus:  c.formatStatus(info.WorkloadStatus),
+		AgentStatus:     c.formatStatus(info.AgentStatus),
+		Life:            info.Life,
+		Leader:          info.Leader,
+		Machine:         info.Machine,
+		PublicAddress:   info.PublicAddress,
+		PrivateAddress:  info.PrivateAddress,
+		OpenedPorts:     info.OpenedPorts,
+		Principal:       info.Principal,
+	}
+	for _, data := range info.RelationData {
+		out.RelationInfo = append(out.RelationInfo, UnitRelationInfo{
+			RelationId:      data.RelationId,
+			Endpoint:        data.Endpoint,
+			RelatedEndpoint: data.RelatedEndpoint,
+			Data:            data.UnitData,
+			RelatedUnits:    data.RelatedUnits,
+		})
+	}
+	return out
+}
+
+func (c *showUnitCommand) formatStatus(s params.EntityStatus) UnitStatusInfo {
+	out := UnitStatusInfo{
+		Current: s.Status,
+		Message: s.Info,
+	}
+	if s.Since != nil {
+		out.Since = common.FormatTime(s.Since, c.isoTime)
+	}
+	return out
+}
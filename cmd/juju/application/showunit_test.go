@@ -0,0 +1,138 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+type showUnitSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockUnitsInfoAPI
+}
+
+var _ = gc.Suite(&showUnitSuite{})
+
+func (s *showUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockUnitsInfoAPI{Stub: &testing.Stub{}}
+}
+
+func (s *showUnitSuite) runShowUnit(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewShowUnitCommandForTest(s.mockAPI), args...)
+}
+
+func (s *showUnitSuite) TestShowUnit(c *gc.C) {
+	since := time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.mockAPI.results = []params.UnitInfoResult{{
+		Result: &params.UnitInfo{
+			Tag:             "unit-mysql-0",
+			Life:            "alive",
+			Charm:           "cs:mysql-42",
+			WorkloadVersion: "5.7",
+			WorkloadStatus:  params.EntityStatus{Status: status.Active, Info: "ready", Since: &since},
+			AgentStatus:     params.EntityStatus{Status: status.Idle, Since: &since},
+			Leader:          true,
+			Machine:         "1",
+			PublicAddress:   "10.0.0.1",
+			PrivateAddress:  "192.168.0.1",
+			OpenedPorts:     []string{"3306/tcp"},
+			RelationData: []params.EndpointRelationData{{
+				RelationId:      2,
+				Endpoint:        "db",
+				RelatedEndpoint: "server",
+				UnitData:        map[string]interface{}{"host": "10.0.0.1"},
+				RelatedUnits: map[string]map[string]interface{}{
+					"wordpress/0": {"user": "wp"},
+				},
+			}},
+		},
+	}}
+	ctx, err := s.runShowUnit(c, "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  charm: cs:mysql-42
+  workload-version: "5.7"
+  workload-status:
+    current: active
+    message: ready
+    since: 2018-07-01 10:00:00Z
+  juju-status:
+    current: idle
+    since: 2018-07-01 10:00:00Z
+  life: alive
+  leader: true
+  machine: "1"
+  public-address: 10.0.0.1
+  private-address: 192.168.0.1
+  opened-ports:
+  - 3306/tcp
+  relation-info:
+  - relation-id: 2
+    endpoint: db
+    related-endpoint: server
+    data:
+      host: 10.0.0.1
+    related-units:
+      wordpress/0:
+        user: wp
+`[1:])
+	s.mockAPI.CheckCallNames(c, "UnitsInfo", "Close")
+	s.mockAPI.CheckCall(c, 0, "UnitsInfo", []names.UnitTag{names.NewUnitTag("mysql/0")})
+}
+
+func (s *showUnitSuite) TestShowUnitJSON(c *gc.C) {
+	s.mockAPI.results = []params.UnitInfoResult{{
+		Result: &params.UnitInfo{
+			Tag:       "unit-nrpe-0",
+			Life:      "dying",
+			Principal: "mysql/0",
+		},
+	}}
+	ctx, err := s.runShowUnit(c, "nrpe/0", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"nrpe/0":{"workload-status":{},"juju-status":{},`+
+		`"life":"dying","leader":false,"principal":"mysql/0"}}`+"\n")
+}
+
+func (s *showUnitSuite) TestShowUnitError(c *gc.C) {
+	s.mockAPI.results = []params.UnitInfoResult{{
+		Error: &params.Error{Message: `unit "mysql/42" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := s.runShowUnit(c, "mysql/42")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/42" not found`)
+}
+
+func (s *showUnitSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runShowUnit(c)
+	c.Assert(err, gc.ErrorMatches, "a unit name must be supplied")
+	_, err = s.runShowUnit(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+type mockUnitsInfoAPI struct {
+	*testing.Stub
+	results []params.UnitInfoResult
+}
+
+func (m *mockUnitsInfoAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockUnitsInfoAPI) UnitsInfo(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+	m.MethodCall(m, "UnitsInfo", tags)
+	return m.results, m.NextErr()
+}
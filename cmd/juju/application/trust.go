@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	coreapplication "github.com/juju/juju/core/application"
+)
+
+var usageTrustSummary = `
+Sets the trust status of a deployed application.`[1:]
+
+var usageTrustDetails = `
+Trusting an application gives its units access to the cloud credential of the
+model. Charms of trusted applications can read the cloud specification and
+credential with the credential-get hook tool, and use them to drive the cloud
+API directly, for example to manage storage or load balancers.
+
+Trust can also be granted when deploying, with "juju deploy --trust".
+
+Examples:
+    juju trust media-wiki
+    juju trust media-wiki --remove
+
+See also:
+    config
+    deploy`[1:]
+
+// NewTrustCommand returns a command which sets the trust status of an
+// application.
+func NewTrustCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&trustCommand{})
+}
+
+// trustAPI defines the application facade methods used by the trust
+// command.
+type trustAPI interface {
+	Close() error
+	SetApplicationConfig(application string, config map[string]string) error
+}
+
+// trustCommand sets the trust status of an application.
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	api trustAPI
+
+	applicationName string
+	removeTrust     bool
+}
+
+// Info implements cmd.Command.
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.removeTrust, "remove", false, "Remove trusted access from a trusted application")
+}
+
+// Init implements cmd.Command.
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *trustCommand) getAPI() (trustAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *trustCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.SetApplicationConfig(c.applicationName, map[string]string{
+		coreapplication.TrustConfigOptionName: strconv.FormatBool(!c.removeTrust),
+	})
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
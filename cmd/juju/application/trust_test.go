@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type trustSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockTrustAPI
+}
+
+var _ = gc.Suite(&trustSuite{})
+
+func (s *trustSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockTrustAPI{Stub: &testing.Stub{}}
+}
+
+func (s *trustSuite) runTrust(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewTrustCommandForTest(s.mockAPI), args...)
+}
+
+func (s *trustSuite) TestTrust(c *gc.C) {
+	_, err := s.runTrust(c, "gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "SetApplicationConfig", "Close")
+	s.mockAPI.CheckCall(c, 0, "SetApplicationConfig", "gitlab", map[string]string{"trust": "true"})
+}
+
+func (s *trustSuite) TestTrustRemove(c *gc.C) {
+	_, err := s.runTrust(c, "gitlab", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetApplicationConfig", "gitlab", map[string]string{"trust": "false"})
+}
+
+func (s *trustSuite) TestTrustError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runTrust(c, "gitlab")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *trustSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runTrust(c)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+	_, err = s.runTrust(c, "gitlab/0")
+	c.Assert(err, gc.ErrorMatches, `invalid application name "gitlab/0"`)
+	_, err = s.runTrust(c, "gitlab", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+type mockTrustAPI struct {
+	*testing.Stub
+}
+
+func (m *mockTrustAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockTrustAPI) SetApplicationConfig(application string, config map[string]string) error {
+	m.MethodCall(m, "SetApplicationConfig", application, config)
+	return m.NextErr()
+}
@@ -39,6 +39,7 @@ func NewUpgradeCharmCommand() cmd.Command {
 	cmd := &upgradeCharmCommand{
 		DeployResources: resourceadapters.DeployResources,
 		ResolveCharm:    resolveCharm,
+		GetCharm:        (*charmrepo.CharmStore).Get,
 		NewCharmAdder:   newCharmAdder,
 		NewCharmClient: func(conn api.Connection) CharmClient {
 			return charms.NewClient(conn)
@@ -66,6 +67,7 @@ type CharmUpgradeClient interface {
 	GetCharmURL(string) (*charm.URL, error)
 	Get(string) (*params.ApplicationGetResults, error)
 	SetCharm(application.SetCharmConfig) error
+	SetCharmDryRun(application.SetCharmDryRunArgs) (params.SetCharmDryRunResult, error)
 }
 
 // CharmClient defines a subset of the charms facade, as required
@@ -82,6 +84,9 @@ type ResourceLister interface {
 
 // NewCharmAdderFunc is the type of a function used to construct
 // a new CharmAdder.
+// GetCharmFunc downloads a charm from the charm store.
+type GetCharmFunc func(*charmrepo.CharmStore, *charm.URL) (charm.Charm, error)
+
 type NewCharmAdderFunc func(
 	api.Connection,
 	*httpbakery.Client,
@@ -94,6 +99,7 @@ type upgradeCharmCommand struct {
 
 	DeployResources       resourceadapters.DeployResourcesFunc
 	ResolveCharm          ResolveCharmFunc
+	GetCharm              GetCharmFunc
 	NewCharmAdder         NewCharmAdderFunc
 	NewCharmClient        func(api.Connection) CharmClient
 	NewCharmUpgradeClient func(api.Connection) CharmUpgradeClient
@@ -106,6 +112,7 @@ type upgradeCharmCommand struct {
 	SwitchURL       string
 	CharmPath       string
 	Revision        int // defaults to -1 (latest)
+	DryRun          bool
 
 	// Resources is a map of resource name to filename to be uploaded on upgrade.
 	Resources map[string]string
@@ -194,6 +201,14 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+The --dry-run flag shows what the upgrade would change without upgrading the
+application: the config settings that the new charm adds or removes, any
+relations that the new charm would break, and the revisions of any resources
+being upgraded. Neither the new charm nor any resources are uploaded to the
+controller.
+
+  juju upgrade-charm foo --switch cs:bar --dry-run
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -216,6 +231,7 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the upgrade would do")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -266,6 +282,9 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 			return errors.New(action + " at upgrade-charm time is not supported by " + suffix)
 		}
 	}
+	if c.DryRun && apiRoot.BestFacadeVersion("Application") < 7 {
+		return errors.New("this juju controller does not support --dry-run")
+	}
 
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
@@ -306,27 +325,17 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 	deployedSeries := applicationInfo.Series
 
-	chID, csMac, err := c.addCharm(charmAdder, charmRepo, modelConfig, oldURL, newRef, deployedSeries)
+	chID, csMac, dryRunCharm, err := c.addCharm(charmAdder, charmRepo, modelConfig, oldURL, newRef, deployedSeries)
 	if err != nil {
 		if termErr, ok := errors.Cause(err).(*common.TermsRequiredError); ok {
 			return errors.Trace(termErr.UserErr())
 		}
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
-	ctx.Infof("Added charm %q to the model.", chID.URL)
-
-	// Next, upgrade resources.
-	charmsClient := c.NewCharmClient(apiRoot)
-	resourceLister, err := c.NewResourceLister(apiRoot)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	ids, err := c.upgradeResources(apiRoot, charmsClient, resourceLister, chID, csMac)
-	if err != nil {
-		return errors.Trace(err)
+	if !c.DryRun {
+		ctx.Infof("Added charm %q to the model.", chID.URL)
 	}
 
-	// Finally, upgrade the application.
 	var configYAML []byte
 	if c.Config.Path != "" {
 		configYAML, err = c.Config.Read(ctx)
@@ -340,12 +349,59 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ConfigSettingsYAML: string(configYAML),
 		ForceSeries:        c.ForceSeries,
 		ForceUnits:         c.ForceUnits,
-		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
+
+	charmsClient := c.NewCharmClient(apiRoot)
+	resourceLister, err := c.NewResourceLister(apiRoot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.DryRun {
+		resources, err := c.dryRunResources(resourceLister, dryRunCharm)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result, err := charmUpgradeClient.SetCharmDryRun(application.SetCharmDryRunArgs{
+			SetCharmConfig:  cfg,
+			Charm:           dryRunCharm,
+			DryRunResources: resources,
+		})
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		writeSetCharmDryRun(ctx.Stdout, result)
+		return nil
+	}
+
+	// Next, upgrade resources.
+	cfg.ResourceIDs, err = c.upgradeResources(apiRoot, charmsClient, resourceLister, chID, csMac)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Finally, upgrade the application.
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
 
+// dryRunResources describes the resources of the new charm which
+// upgradeResources would upload, without uploading anything.
+func (c *upgradeCharmCommand) dryRunResources(
+	resourceLister ResourceLister,
+	ch charm.Charm,
+) ([]params.DryRunResource, error) {
+	meta := ch.Meta().Resources
+	if len(meta) == 0 {
+		return dryRunResources(c.Resources, nil)
+	}
+	current, err := getResources(c.ApplicationName, resourceLister)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	filtered := filterResources(meta, current, c.Resources)
+	return dryRunResources(c.Resources, filtered)
+}
+
 // upgradeResources pushes metadata up to the server for each resource defined
 // in the new charm's metadata and returns a map of resource names to pending
 // IDs to include in the upgrage-charm call.
@@ -494,7 +550,7 @@ func (c *upgradeCharmCommand) getCharmStore(
 
 // addCharm interprets the new charmRef and adds the specified charm if
 // the new charm is different to what's already deployed as specified by
-// oldURL.
+// oldURL. For dry runs the charm is not added, but returned instead.
 func (c *upgradeCharmCommand) addCharm(
 	charmAdder CharmAdder,
 	charmRepo *charmrepo.CharmStore,
@@ -502,7 +558,7 @@ func (c *upgradeCharmCommand) addCharm(
 	oldURL *charm.URL,
 	charmRef string,
 	deployedSeries string,
-) (charmstore.CharmID, *macaroon.Macaroon, error) {
+) (charmstore.CharmID, *macaroon.Macaroon, charm.Charm, error) {
 	var id charmstore.CharmID
 	// Charm may have been supplied via a path reference. If so, build a
 	// local charm URL from the deployed series.
@@ -510,30 +566,34 @@ func (c *upgradeCharmCommand) addCharm(
 	if err == nil {
 		newName := ch.Meta().Name
 		if newName != oldURL.Name {
-			return id, nil, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newName)
+			return id, nil, nil, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newName)
+		}
+		if c.DryRun {
+			id.URL = newURL
+			return id, nil, ch, nil
 		}
 		addedURL, err := charmAdder.AddLocalCharm(newURL, ch)
 		id.URL = addedURL
-		return id, nil, err
+		return id, nil, nil, err
 	}
 	if _, ok := err.(*charmrepo.NotFoundError); ok {
-		return id, nil, errors.Errorf("no charm found at %q", charmRef)
+		return id, nil, nil, errors.Errorf("no charm found at %q", charmRef)
 	}
 	// If we get a "not exists" or invalid path error then we attempt to interpret
 	// the supplied charm reference as a URL below, otherwise we return the error.
 	if err != os.ErrNotExist && !charmrepo.IsInvalidPathError(err) {
-		return id, nil, err
+		return id, nil, nil, err
 	}
 
 	refURL, err := charm.ParseURL(charmRef)
 	if err != nil {
-		return id, nil, errors.Trace(err)
+		return id, nil, nil, errors.Trace(err)
 	}
 
 	// Charm has been supplied as a URL so we resolve and deploy using the store.
 	newURL, channel, supportedSeries, err := c.ResolveCharm(charmRepo.ResolveWithChannel, config, refURL)
 	if err != nil {
-		return id, nil, errors.Trace(err)
+		return id, nil, nil, errors.Trace(err)
 	}
 	id.Channel = channel
 	_, seriesSupportedErr := charm.SeriesForCharm(deployedSeries, supportedSeries)
@@ -542,7 +602,7 @@ func (c *upgradeCharmCommand) addCharm(
 		if len(supportedSeries) > 0 {
 			series = supportedSeries
 		}
-		return id, nil, errors.Errorf(
+		return id, nil, nil, errors.Errorf(
 			"cannot upgrade from single series %q charm to a charm supporting %q. Use --force-series to override.",
 			deployedSeries, series,
 		)
@@ -551,18 +611,26 @@ func (c *upgradeCharmCommand) addCharm(
 	// or Revision flags, discover the latest.
 	if *newURL == *oldURL {
 		if refURL.Revision != -1 {
-			return id, nil, errors.Errorf("already running specified charm %q", newURL)
+			return id, nil, nil, errors.Errorf("already running specified charm %q", newURL)
 		}
 		// No point in trying to upgrade a charm store charm when
 		// we just determined that's the latest revision
 		// available.
-		return id, nil, errors.Errorf("already running latest charm %q", newURL)
+		return id, nil, nil, errors.Errorf("already running latest charm %q", newURL)
 	}
 
+	if c.DryRun {
+		ch, err := c.GetCharm(charmRepo, newURL)
+		if err != nil {
+			return id, nil, nil, errors.Annotatef(err, "getting charm for URL %q", newURL)
+		}
+		id.URL = newURL
+		return id, nil, ch, nil
+	}
 	curl, csMac, err := addCharmFromURL(charmAdder, newURL, channel)
 	if err != nil {
-		return id, nil, errors.Trace(err)
+		return id, nil, nil, errors.Trace(err)
 	}
 	id.URL = curl
-	return id, csMac, nil
+	return id, csMac, nil, nil
 }
@@ -53,6 +53,7 @@ type UpgradeCharmSuite struct {
 
 	deployResources    resourceadapters.DeployResourcesFunc
 	resolveCharm       ResolveCharmFunc
+	getCharm           GetCharmFunc
 	resolvedCharmURL   *charm.URL
 	apiConnection      mockAPIConnection
 	charmAdder         mockCharmAdder
@@ -97,6 +98,14 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 		return s.resolvedCharmURL, csclientparams.StableChannel, []string{"quantal"}, nil
 	}
 
+	s.getCharm = func(charmRepo *charmrepo.CharmStore, url *charm.URL) (charm.Charm, error) {
+		s.AddCall("GetCharm", url)
+		if err := s.NextErr(); err != nil {
+			return nil, err
+		}
+		return testcharms.Repo.CharmDir("dummy"), nil
+	}
+
 	currentCharmURL := charm.MustParseURL("cs:quantal/foo-1")
 	latestCharmURL := charm.MustParseURL("cs:quantal/foo-2")
 	s.resolvedCharmURL = latestCharmURL
@@ -138,6 +147,7 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 		apiOpen,
 		s.deployResources,
 		s.resolveCharm,
+		s.getCharm,
 		func(conn api.Connection, bakeryClient *httpbakery.Client, channel csclientparams.Channel) CharmAdder {
 			s.AddCall("NewCharmAdder", conn, bakeryClient, channel)
 			s.PopNoErr()
@@ -184,6 +194,64 @@ func (s *UpgradeCharmSuite) TestStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *UpgradeCharmSuite) TestDryRun(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 7
+	s.charmUpgradeClient.dryRunResult = params.SetCharmDryRunResult{
+		ApplicationName: "foo",
+		CurrentCharmURL: "cs:quantal/foo-1",
+		CharmURL:        "cs:quantal/foo-2",
+		ConfigAdded:     []string{"new-option"},
+		ConfigRemoved:   []string{"old-option"},
+		BrokenRelations: []string{"foo:db mysql:db"},
+		Resources: []params.DryRunResource{{
+			Name:            "data",
+			Origin:          "store",
+			Revision:        3,
+			CurrentRevision: 2,
+		}},
+	}
+	ctx, err := s.runUpgradeCharm(c, "foo", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharmDryRun")
+	s.charmUpgradeClient.CheckCall(c, 2, "SetCharmDryRun", application.SetCharmDryRunArgs{
+		SetCharmConfig: application.SetCharmConfig{
+			ApplicationName: "foo",
+			CharmID: jujucharmstore.CharmID{
+				URL:     s.resolvedCharmURL,
+				Channel: csclientparams.StableChannel,
+			},
+		},
+		Charm: testcharms.Repo.CharmDir("dummy"),
+	})
+	var getCharmCalls []testing.StubCall
+	for _, call := range s.Calls() {
+		if call.FuncName == "GetCharm" {
+			getCharmCalls = append(getCharmCalls, call)
+		}
+	}
+	c.Assert(getCharmCalls, jc.DeepEquals, []testing.StubCall{{
+		FuncName: "GetCharm",
+		Args:     []interface{}{s.resolvedCharmURL},
+	}})
+	s.charmAdder.CheckNoCalls(c)
+	s.charmClient.CheckNoCalls(c)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Not(gc.Matches), "(?s).*Added charm.*")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Changes to upgrade application foo:
+- upgrade charm from cs:quantal/foo-1 to cs:quantal/foo-2
+- add config option new-option
+- remove config option old-option
+- would break relation "foo:db mysql:db"
+- use resource data revision 3 from store (currently revision 2)
+`[1:])
+}
+
+func (s *UpgradeCharmSuite) TestDryRunMinFacadeVersion(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --dry-run")
+	s.charmUpgradeClient.CheckNoCalls(c)
+}
+
 func (s *UpgradeCharmSuite) TestStorageConstraintsMinFacadeVersion(c *gc.C) {
 	s.apiConnection.bestFacadeVersion = 1
 	_, err := s.runUpgradeCharm(c, "foo", "--storage", "bar=baz")
@@ -742,7 +810,8 @@ func (m *mockCharmClient) CharmInfo(curl string) (*charms.CharmInfo, error) {
 type mockCharmUpgradeClient struct {
 	CharmUpgradeClient
 	testing.Stub
-	charmURL *charm.URL
+	charmURL     *charm.URL
+	dryRunResult params.SetCharmDryRunResult
 }
 
 func (m *mockCharmUpgradeClient) GetCharmURL(applicationName string) (*charm.URL, error) {
@@ -755,6 +824,11 @@ func (m *mockCharmUpgradeClient) SetCharm(cfg application.SetCharmConfig) error
 	return m.NextErr()
 }
 
+func (m *mockCharmUpgradeClient) SetCharmDryRun(args application.SetCharmDryRunArgs) (params.SetCharmDryRunResult, error) {
+	m.MethodCall(m, "SetCharmDryRun", args)
+	return m.dryRunResult, m.NextErr()
+}
+
 func (m *mockCharmUpgradeClient) Get(applicationName string) (*params.ApplicationGetResults, error) {
 	m.MethodCall(m, "Get", applicationName)
 	return &params.ApplicationGetResults{}, m.NextErr()
@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branch
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var abortHelpSummary = `
+Discards the configuration staged on a branch.`[1:]
+
+var abortHelpDetails = `
+Aborting a branch discards the configuration staged on it and
+removes the branch. Units that were tracking it revert to their
+application's current configuration.
+
+Examples:
+    juju abort new-config
+
+See also:
+    add-branch
+    commit`
+
+// NewAbortCommand returns a command to abort a branch.
+func NewAbortCommand() cmd.Command {
+	cmd := &abortCommand{}
+	cmd.newAPIFunc = func() (AbortAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelgeneration.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type abortCommand struct {
+	modelcmd.ModelCommandBase
+	branchName string
+
+	newAPIFunc func() (AbortAPI, error)
+}
+
+// AbortAPI defines the API methods that the abort command uses.
+type AbortAPI interface {
+	Close() error
+	AbortBranch(branchName string) error
+}
+
+// Info implements cmd.Command.
+func (c *abortCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "abort",
+		Args:    "<branch name>",
+		Purpose: abortHelpSummary,
+		Doc:     abortHelpDetails,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *abortCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no branch name specified")
+	}
+	c.branchName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *abortCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.AbortBranch(c.branchName); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Aborted branch %q", c.branchName)
+	return nil
+}
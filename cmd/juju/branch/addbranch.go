@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branch
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var addBranchHelpSummary = `
+Adds a branch for staging configuration changes.`[1:]
+
+var addBranchHelpDetails = `
+A branch holds application config and charm settings changes. Staged
+charm settings are seen only by the units tracking the branch, while
+staged application config takes effect when the branch is committed.
+Changes are staged on a branch with "juju config --branch", units are
+moved onto it with "juju track", and the changes are applied to all
+units with "juju commit" or discarded with "juju abort".
+
+Examples:
+    juju add-branch new-config
+
+See also:
+    track
+    show-branch
+    commit
+    abort
+    config`
+
+// NewAddBranchCommand returns a command to add a branch to a model.
+func NewAddBranchCommand() cmd.Command {
+	cmd := &addBranchCommand{}
+	cmd.newAPIFunc = func() (AddBranchAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelgeneration.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type addBranchCommand struct {
+	modelcmd.ModelCommandBase
+	branchName string
+
+	newAPIFunc func() (AddBranchAPI, error)
+}
+
+// AddBranchAPI defines the API methods that the add branch command uses.
+type AddBranchAPI interface {
+	Close() error
+	AddBranch(branchName string) error
+}
+
+// Info implements cmd.Command.
+func (c *addBranchCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-branch",
+		Args:    "<branch name>",
+		Purpose: addBranchHelpSummary,
+		Doc:     addBranchHelpDetails,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *addBranchCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no branch name specified")
+	}
+	c.branchName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *addBranchCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.AddBranch(c.branchName); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Added branch %q", c.branchName)
+	return nil
+}
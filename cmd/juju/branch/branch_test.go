@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branch_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/branch"
+	"github.com/juju/juju/testing"
+)
+
+type BranchSuite struct {
+	testing.BaseSuite
+
+	api *mockBranchAPI
+}
+
+var _ = gc.Suite(&BranchSuite{})
+
+func (s *BranchSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.api = &mockBranchAPI{}
+}
+
+func (s *BranchSuite) run(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *BranchSuite) TestAddBranch(c *gc.C) {
+	ctx, err := s.run(c, branch.NewAddBranchCommandForTest(s.api), "new-config")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added branch \"new-config\"\n")
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"AddBranch", []interface{}{"new-config"}},
+		{"Close", nil},
+	})
+}
+
+func (s *BranchSuite) TestAddBranchInit(c *gc.C) {
+	_, err := s.run(c, branch.NewAddBranchCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "no branch name specified")
+	_, err = s.run(c, branch.NewAddBranchCommandForTest(s.api), "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *BranchSuite) TestAddBranchBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestAddBranchBlocked"))
+	_, err := s.run(c, branch.NewAddBranchCommandForTest(s.api), "new-config")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+}
+
+func (s *BranchSuite) TestTrack(c *gc.C) {
+	_, err := s.run(c, branch.NewTrackCommandForTest(s.api), "new-config", "mysql/0", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "TrackBranch", "new-config", []string{"mysql/0", "wordpress"})
+}
+
+func (s *BranchSuite) TestTrackInit(c *gc.C) {
+	_, err := s.run(c, branch.NewTrackCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "no branch name specified")
+	_, err = s.run(c, branch.NewTrackCommandForTest(s.api), "new-config")
+	c.Assert(err, gc.ErrorMatches, "no units or applications specified")
+	_, err = s.run(c, branch.NewTrackCommandForTest(s.api), "new-config", "0")
+	c.Assert(err, gc.ErrorMatches, `unit or application name "0" not valid`)
+}
+
+func (s *BranchSuite) TestCommit(c *gc.C) {
+	ctx, err := s.run(c, branch.NewCommitCommandForTest(s.api), "new-config")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Committed branch \"new-config\"\n")
+	s.api.CheckCall(c, 0, "CommitBranch", "new-config")
+}
+
+func (s *BranchSuite) TestCommitError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, branch.NewCommitCommandForTest(s.api), "new-config")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *BranchSuite) TestAbort(c *gc.C) {
+	ctx, err := s.run(c, branch.NewAbortCommandForTest(s.api), "new-config")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Aborted branch \"new-config\"\n")
+	s.api.CheckCall(c, 0, "AbortBranch", "new-config")
+}
+
+func (s *BranchSuite) TestShowBranch(c *gc.C) {
+	s.api.branches = []params.Branch{{
+		BranchName:        "new-config",
+		Created:           1500000000,
+		CreatedBy:         "admin",
+		AssignedUnits:     map[string][]string{"mysql": {"mysql/0"}},
+		Config:            map[string]map[string]interface{}{"mysql": {"dataset-size": "60%"}},
+		ApplicationConfig: map[string]map[string]interface{}{"mysql": {"trust": true}},
+	}}
+	ctx, err := s.run(c, branch.NewShowBranchCommandForTest(s.api), "new-config", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+new-config:
+  created: 2017-07-14T02:40:00Z
+  created-by: admin
+  units:
+    mysql:
+    - mysql/0
+  config:
+    mysql:
+      dataset-size: 60%
+  application-config:
+    mysql:
+      trust: true
+`[1:])
+	s.api.CheckCall(c, 0, "BranchInfo", []string{"new-config"})
+}
+
+func (s *BranchSuite) TestShowBranchNone(c *gc.C) {
+	ctx, err := s.run(c, branch.NewShowBranchCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No branches to display.\n")
+}
+
+type mockBranchAPI struct {
+	jtesting.Stub
+
+	branches []params.Branch
+}
+
+func (m *mockBranchAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockBranchAPI) AddBranch(branchName string) error {
+	m.MethodCall(m, "AddBranch", branchName)
+	return m.NextErr()
+}
+
+func (m *mockBranchAPI) TrackBranch(branchName string, entities []string) error {
+	m.MethodCall(m, "TrackBranch", branchName, entities)
+	return m.NextErr()
+}
+
+func (m *mockBranchAPI) CommitBranch(branchName string) error {
+	m.MethodCall(m, "CommitBranch", branchName)
+	return m.NextErr()
+}
+
+func (m *mockBranchAPI) AbortBranch(branchName string) error {
+	m.MethodCall(m, "AbortBranch", branchName)
+	return m.NextErr()
+}
+
+func (m *mockBranchAPI) BranchInfo(branchNames ...string) ([]params.Branch, error) {
+	m.MethodCall(m, "BranchInfo", branchNames)
+	return m.branches, m.NextErr()
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branch
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var commitHelpSummary = `
+Applies the configuration staged on a branch to all units.`[1:]
+
+var commitHelpDetails = `
+Committing a branch applies the configuration staged on it to the
+applications concerned, so that every unit sees it, and removes the
+branch.
+
+Examples:
+    juju commit new-config
+
+See also:
+    add-branch
+    abort`
+
+// NewCommitCommand returns a command to commit a branch.
+func NewCommitCommand() cmd.Command {
+	cmd := &commitCommand{}
+	cmd.newAPIFunc = func() (CommitAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelgeneration.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type commitCommand struct {
+	modelcmd.ModelCommandBase
+	branchName string
+
+	newAPIFunc func() (CommitAPI, error)
+}
+
+// CommitAPI defines the API methods that the commit command uses.
+type CommitAPI interface {
+	Close() error
+	CommitBranch(branchName string) error
+}
+
+// Info implements cmd.Command.
+func (c *commitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "commit",
+		Args:    "<branch name>",
+		Purpose: commitHelpSummary,
+		Doc:     commitHelpDetails,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *commitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no branch name specified")
+	}
+	c.branchName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *commitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.CommitBranch(c.branchName); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Committed branch %q", c.branchName)
+	return nil
+}
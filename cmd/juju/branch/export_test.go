@@ -0,0 +1,50 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branch
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewAddBranchCommandForTest(api AddBranchAPI) cmd.Command {
+	return modelcmd.Wrap(&addBranchCommand{
+		newAPIFunc: func() (AddBranchAPI, error) {
+			return api, nil
+		},
+	})
+}
+
+func NewTrackCommandForTest(api TrackAPI) cmd.Command {
+	return modelcmd.Wrap(&trackCommand{
+		newAPIFunc: func() (TrackAPI, error) {
+			return api, nil
+		},
+	})
+}
+
+func NewShowBranchCommandForTest(api ShowBranchAPI) cmd.Command {
+	return modelcmd.Wrap(&showBranchCommand{
+		newAPIFunc: func() (ShowBranchAPI, error) {
+			return api, nil
+		},
+	})
+}
+
+func NewCommitCommandForTest(api CommitAPI) cmd.Command {
+	return modelcmd.Wrap(&commitCommand{
+		newAPIFunc: func() (CommitAPI, error) {
+			return api, nil
+		},
+	})
+}
+
+func NewAbortCommandForTest(api AbortAPI) cmd.Command {
+	return modelcmd.Wrap(&abortCommand{
+		newAPIFunc: func() (AbortAPI, error) {
+			return api, nil
+		},
+	})
+}
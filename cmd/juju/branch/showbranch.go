@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branch
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var showBranchHelpSummary = `
+Shows the configuration staged on branches and the units tracking them.`[1:]
+
+var showBranchHelpDetails = `
+By default, all branches in the model are shown.
+
+Examples:
+    juju show-branch
+    juju show-branch new-config --format json
+
+See also:
+    add-branch
+    track`
+
+// NewShowBranchCommand returns a command to show branches.
+func NewShowBranchCommand() cmd.Command {
+	cmd := &showBranchCommand{}
+	cmd.newAPIFunc = func() (ShowBranchAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelgeneration.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type showBranchCommand struct {
+	modelcmd.ModelCommandBase
+	out         cmd.Output
+	isoTime     bool
+	branchNames []string
+
+	newAPIFunc func() (ShowBranchAPI, error)
+}
+
+// ShowBranchAPI defines the API methods that the show branch command uses.
+type ShowBranchAPI interface {
+	Close() error
+	BranchInfo(branchNames ...string) ([]params.Branch, error)
+}
+
+// branchInfo holds the details of a branch for formatted output.
+type branchInfo struct {
+	Created           string                            `yaml:"created" json:"created"`
+	CreatedBy         string                            `yaml:"created-by" json:"created-by"`
+	Units             map[string][]string               `yaml:"units,omitempty" json:"units,omitempty"`
+	Config            map[string]map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	ApplicationConfig map[string]map[string]interface{} `yaml:"application-config,omitempty" json:"application-config,omitempty"`
+}
+
+// Info implements cmd.Command.
+func (c *showBranchCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-branch",
+		Args:    "[<branch name> ...]",
+		Purpose: showBranchHelpSummary,
+		Doc:     showBranchHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showBranchCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *showBranchCommand) Init(args []string) error {
+	c.branchNames = args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *showBranchCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	branches, err := client.BranchInfo(c.branchNames...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(branches) == 0 && c.out.Name() == "yaml" {
+		ctx.Infof("No branches to display.")
+		return nil
+	}
+	result := make(map[string]branchInfo, len(branches))
+	for _, b := range branches {
+		created := time.Unix(b.Created, 0)
+		result[b.BranchName] = branchInfo{
+			Created:           common.FormatTime(&created, c.isoTime),
+			CreatedBy:         b.CreatedBy,
+			Units:             b.AssignedUnits,
+			Config:            b.Config,
+			ApplicationConfig: b.ApplicationConfig,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
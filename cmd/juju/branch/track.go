@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branch
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var trackHelpSummary = `
+Moves units onto a branch.`[1:]
+
+var trackHelpDetails = `
+Units tracking a branch see the charm configuration staged on it, in
+place of their application's current configuration, until the branch is
+committed or aborted. Specifying an application moves all of its units
+onto the branch. A unit may only track one branch at a time.
+
+Examples:
+    juju track new-config mysql/0 mysql/1
+    juju track new-config wordpress
+
+See also:
+    add-branch
+    show-branch`
+
+// NewTrackCommand returns a command to move units onto a branch.
+func NewTrackCommand() cmd.Command {
+	cmd := &trackCommand{}
+	cmd.newAPIFunc = func() (TrackAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelgeneration.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type trackCommand struct {
+	modelcmd.ModelCommandBase
+	branchName string
+	entities   []string
+
+	newAPIFunc func() (TrackAPI, error)
+}
+
+// TrackAPI defines the API methods that the track command uses.
+type TrackAPI interface {
+	Close() error
+	TrackBranch(branchName string, entities []string) error
+}
+
+// Info implements cmd.Command.
+func (c *trackCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "track",
+		Args:    "<branch name> <unit name>|<application name> ...",
+		Purpose: trackHelpSummary,
+		Doc:     trackHelpDetails,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *trackCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no branch name specified")
+	case 1:
+		return errors.New("no units or applications specified")
+	}
+	c.branchName = args[0]
+	for _, arg := range args[1:] {
+		if !names.IsValidUnit(arg) && !names.IsValidApplication(arg) {
+			return errors.NotValidf("unit or application name %q", arg)
+		}
+	}
+	c.entities = args[1:]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *trackCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.TrackBranch(c.branchName, c.entities)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
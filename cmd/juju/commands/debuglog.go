@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -15,11 +16,13 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 	"github.com/juju/loggo/loggocolor"
+	"github.com/juju/utils/clock"
 	"github.com/mattn/go-isatty"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/logfields"
 )
 
 // defaultLineCount is the default number of lines to
@@ -42,12 +45,34 @@ The "entity" is the source of the message: a machine or unit. The names for
 machines and units can be seen in the output of `[1:] + "`juju status`" + `.
 
 The '--include' and '--exclude' options filter by entity. The entity can be
-a machine, unit, or application.
+a machine, unit, or application, and may contain '*' wildcards, such as
+"mysql/*", "0/lxd/*" or "mysql*".
 
 The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+Log messages may carry structured labels and name=value fields, such as
+the unit, hook and relation that a charm's juju-log call was made from.
+The '--label' option shows only messages with the given label, and the
+'--field' option shows only messages with the given field value. Any
+labels and fields are shown after each message.
+
+The '--since' and '--until' options show only messages logged within a
+time window. Each accepts either a time in RFC3339 format, or a duration
+such as "2h" meaning that long ago. With '--since', all messages since
+that time are shown, as with '--replay'; with '--until', new messages are
+not waited for.
+
+The '--grep' option shows only messages containing the given text. The
+text is matched literally; it is not a regular expression.
+
+All of the filtering is done by the controller, so only matching messages
+are sent to the client.
+
+With '--format json', each message is written as a single line of JSON,
+suitable for processing with tools such as jq.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
@@ -55,6 +80,9 @@ The filtering options combine as follows:
 * All --exclude-module options are logically ORed together.
 * The combined --include, --exclude, --include-module and --exclude-module
   selections are logically ANDed to form the complete filter.
+* All --label and --field options must match for a message to be shown.
+* The --since, --until and --grep options further restrict the messages
+  shown.
 
 Examples:
 
@@ -88,6 +116,22 @@ machine-3 or machine-4, and then stop:
         --exclude machine-3 \
         --exclude machine-4 
 
+Show everything logged by the config-changed hook of unit mysql/0:
+
+    juju debug-log --replay --field unit=mysql/0 --field hook=config-changed
+
+Show the errors logged by any mysql unit between 10:00 and 11:00 UTC on
+the 1st of May 2018 that mention a timeout, as JSON:
+
+    juju debug-log --include mysql --level ERROR \
+        --since 2018-05-01T10:00:00Z --until 2018-05-01T11:00:00Z \
+        --grep timeout --format json
+
+Show the messages logged by units in containers on machine 0 in the last
+two hours:
+
+    juju debug-log --include '0/lxd/*' --since 2h --no-tail
+
 To see all WARNING and ERROR messages and then continue showing any
 new WARNING and ERROR messages as they are logged:
 
@@ -110,7 +154,7 @@ func newDebugLogCommand() cmd.Command {
 }
 
 func newDebugLogCommandTZ(tz *time.Location) cmd.Command {
-	return modelcmd.Wrap(&debugLogCommand{tz: tz})
+	return modelcmd.Wrap(&debugLogCommand{tz: tz, clock: clock.WallClock})
 }
 
 type debugLogCommand struct {
@@ -118,6 +162,10 @@ type debugLogCommand struct {
 
 	level  string
 	params common.DebugLogParams
+	fields []string
+	since  string
+	until  string
+	output string
 
 	utc      bool
 	location bool
@@ -130,6 +178,7 @@ type debugLogCommand struct {
 
 	format string
 	tz     *time.Location
+	clock  clock.Clock
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -140,6 +189,11 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabels), "label", "Only show log messages with this label")
+	f.Var(cmd.NewAppendStringsValue(&c.fields), "field", "Only show log messages with this field value, given as name=value")
+	f.StringVar(&c.params.MessageContains, "grep", "", "Only show log messages containing this text")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -157,6 +211,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
+	f.StringVar(&c.output, "format", "text", "Output format, one of [text, json]")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -168,9 +223,47 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	data := logfields.Data{Labels: c.params.IncludeLabels}
+	if err := data.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, field := range c.fields {
+		name, value, err := logfields.ParseField(field)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.params.IncludeFields == nil {
+			c.params.IncludeFields = make(map[string]string)
+		}
+		c.params.IncludeFields[name] = value
+	}
+	if c.since != "" {
+		since, err := c.parseTime(c.since)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := c.parseTime(c.until)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until")
+		}
+		if until.Before(c.params.StartTime) {
+			return errors.New("--until must not be before --since")
+		}
+		c.params.EndTime = until
+	}
+	if c.output != "text" && c.output != "json" {
+		return errors.Errorf("format value %q is not one of %q, %q", c.output, "text", "json")
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if c.tail && c.until != "" {
+		return errors.NotValidf("setting --tail and --until")
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -187,6 +280,19 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseTime parses a time given either in RFC3339 format or as a
+// duration before now.
+func (c *debugLogCommand) parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("expected RFC3339 time or duration, got %q", value)
+	}
+	return c.clock.Now().Add(-d), nil
+}
+
 func (c *debugLogCommand) processEntities(entities []string) []string {
 	if entities == nil {
 		return nil
@@ -212,6 +318,8 @@ func (c *debugLogCommand) processEntities(entities []string) []string {
 			} else if names.IsValidApplication(entity) {
 				// Assume that the entity refers to an application.
 				entity = names.UnitTagKind + "-" + entity + "-*"
+			} else if strings.Contains(entity, "*") {
+				entity = wildcardEntityTag(entity)
 			}
 		}
 		result[i] = entity
@@ -219,6 +327,21 @@ func (c *debugLogCommand) processEntities(entities []string) []string {
 	return result
 }
 
+// wildcardEntityTag converts a machine, unit or application name
+// containing '*' wildcards, such as "0/lxd/*", "mysql/*" or "mysql*",
+// into the equivalent tag pattern.
+func wildcardEntityTag(entity string) string {
+	pattern := strings.Replace(entity, "/", "-", -1)
+	switch {
+	case entity[0] >= '0' && entity[0] <= '9':
+		return names.MachineTagKind + "-" + pattern
+	case strings.Contains(entity, "/"):
+		return names.UnitTagKind + "-" + pattern
+	default:
+		return names.UnitTagKind + "-" + pattern + "-*"
+	}
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params common.DebugLogParams) (<-chan common.LogMessage, error)
 	Close() error
@@ -240,7 +363,7 @@ func isTerminal(f interface{}) bool {
 func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if c.tail {
 		c.params.NoTail = false
-	} else if c.notail {
+	} else if c.notail || c.until != "" {
 		c.params.NoTail = true
 	} else {
 		// Set the default tail option to true if the caller is
@@ -261,17 +384,49 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if c.color {
 		writer.SetColorCapable(true)
 	}
+	encoder := json.NewEncoder(ctx.Stdout)
 	for {
 		msg, ok := <-messages
 		if !ok {
 			break
 		}
+		if c.output == "json" {
+			if err := encoder.Encode(c.jsonLogRecord(msg)); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
 		c.writeLogRecord(writer, msg)
 	}
 
 	return nil
 }
 
+// jsonLogRecord is the JSON output of a log message.
+type jsonLogRecord struct {
+	Entity    string            `json:"entity"`
+	Timestamp time.Time         `json:"timestamp"`
+	Severity  string            `json:"severity"`
+	Module    string            `json:"module"`
+	Location  string            `json:"location"`
+	Message   string            `json:"message"`
+	Labels    []string          `json:"labels,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+func (c *debugLogCommand) jsonLogRecord(r common.LogMessage) jsonLogRecord {
+	return jsonLogRecord{
+		Entity:    r.Entity,
+		Timestamp: r.Timestamp.In(c.tz),
+		Severity:  r.Severity,
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+		Labels:    r.Labels,
+		Fields:    r.Fields,
+	}
+}
+
 var SeverityColor = map[string]*ansiterm.Context{
 	"TRACE":   ansiterm.Foreground(ansiterm.Default),
 	"DEBUG":   ansiterm.Foreground(ansiterm.Green),
@@ -292,5 +447,12 @@ func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage
 	if c.location {
 		loggocolor.LocationColor.Fprintf(w, "%s ", r.Location)
 	}
-	fmt.Fprintln(w, r.Message)
+	data := logfields.Data{Labels: r.Labels, Fields: r.Fields}
+	if data.IsEmpty() {
+		fmt.Fprintln(w, r.Message)
+		return
+	}
+	fmt.Fprintf(w, "%s ", r.Message)
+	loggocolor.LocationColor.Fprintf(w, "%s", data.String())
+	fmt.Fprintln(w)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -23,6 +24,7 @@ type DebugLogSuite struct {
 var _ = gc.Suite(&DebugLogSuite{})
 
 func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
+	now := time.Date(2018, 5, 2, 12, 0, 0, 0, time.UTC)
 	for i, test := range []struct {
 		args     []string
 		expected common.DebugLogParams
@@ -88,6 +90,53 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--label", "upgrade", "--field", "hook=install", "--field", "unit=mysql/0"},
+			expected: common.DebugLogParams{
+				IncludeLabels: []string{"upgrade"},
+				IncludeFields: map[string]string{"hook": "install", "unit": "mysql/0"},
+				Backlog:       10,
+			},
+		}, {
+			args:     []string{"--field", "hook"},
+			errMatch: `expected name=value, got "hook"`,
+		}, {
+			args:     []string{"--label", "Upgrade"},
+			errMatch: `label "Upgrade" not valid`,
+		}, {
+			args: []string{"-i", "mysql/*", "-i", "0/lxd/*", "-x", "wordpress*"},
+			expected: common.DebugLogParams{
+				IncludeEntity: []string{"unit-mysql-*", "machine-0-lxd-*"},
+				ExcludeEntity: []string{"unit-wordpress*-*"},
+				Backlog:       10,
+			},
+		}, {
+			args: []string{"--since", "2018-05-01T10:00:00Z", "--until", "2h", "--grep", "timed out"},
+			expected: common.DebugLogParams{
+				MessageContains: "timed out",
+				Backlog:         10,
+				Replay:          true,
+				StartTime:       time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:         now.Add(-2 * time.Hour),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since: expected RFC3339 time or duration, got "yesterday"`,
+		}, {
+			args:     []string{"--since", "1h", "--until", "2h"},
+			errMatch: `--until must not be before --since`,
+		}, {
+			args:     []string{"--until", "1h", "--tail"},
+			errMatch: `setting --tail and --until not valid`,
+		}, {
+			args: []string{"--grep", "fail(ed"},
+			expected: common.DebugLogParams{
+				MessageContains: "fail(ed",
+				Backlog:         10,
+			},
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
@@ -106,7 +155,7 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 		},
 	} {
 		c.Logf("test %v", i)
-		command := &debugLogCommand{}
+		command := &debugLogCommand{clock: jujutesting.NewClock(now)}
 		err := cmdtesting.InitCommand(modelcmd.Wrap(command), test.args)
 		if test.errMatch == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -182,6 +231,71 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
 }
 
+func (s *DebugLogSuite) TestLogOutputFields(c *gc.C) {
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "INFO",
+				Module:    "unit.mysql/0.juju-log",
+				Location:  "juju-log.go:42",
+				Message:   "installing",
+				Labels:    []string{"upgrade"},
+				Fields:    map[string]string{"unit": "mysql/0", "hook": "install"},
+			},
+		}}, nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		"unit-mysql-0: 08:15:23 INFO unit.mysql/0.juju-log installing [upgrade] hook=install unit=mysql/0\n")
+}
+
+func (s *DebugLogSuite) TestUntilDoesNotTail(c *gc.C) {
+	fake := &fakeDebugLogAPI{}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	_, err := cmdtesting.RunCommand(c, newDebugLogCommand(), "--until", "2018-05-01T10:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.params, gc.DeepEquals, common.DebugLogParams{
+		Backlog: 10,
+		NoTail:  true,
+		EndTime: time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *DebugLogSuite) TestLogOutputJSON(c *gc.C) {
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				Entity:    "machine-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "INFO",
+				Module:    "test.module",
+				Location:  "somefile.go:123",
+				Message:   "this is the log output",
+			}, {
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 24, 0, time.UTC),
+				Severity:  "INFO",
+				Module:    "unit.mysql/0.juju-log",
+				Location:  "juju-log.go:42",
+				Message:   "installing",
+				Labels:    []string{"upgrade"},
+				Fields:    map[string]string{"unit": "mysql/0", "hook": "install"},
+			},
+		}}, nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(time.UTC), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+{"entity":"machine-0","timestamp":"2016-10-09T08:15:23.345Z","severity":"INFO","module":"test.module","location":"somefile.go:123","message":"this is the log output"}
+{"entity":"unit-mysql-0","timestamp":"2016-10-09T08:15:24Z","severity":"INFO","module":"unit.mysql/0.juju-log","location":"juju-log.go:42","message":"installing","labels":["upgrade"],"fields":{"hook":"install","unit":"mysql/0"}}
+`[1:])
+}
+
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
 	params common.DebugLogParams
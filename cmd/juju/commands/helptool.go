@@ -12,6 +12,8 @@ import (
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -48,6 +50,12 @@ func (dummyHookContext) OpenedPorts() []network.PortRange {
 func (dummyHookContext) ConfigSettings() (charm.Settings, error) {
 	return charm.NewConfig().DefaultSettings(), nil
 }
+func (dummyHookContext) GoalState() (*application.GoalState, error) {
+	return &application.GoalState{}, nil
+}
+func (dummyHookContext) CloudSpec() (*params.CloudSpec, error) {
+	return nil, errors.NotFoundf("CloudSpec")
+}
 func (dummyHookContext) HookRelation() (jujuc.ContextRelation, error) {
 	return nil, errors.NotFoundf("HookRelation")
 }
@@ -386,7 +386,9 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"abort",
 	"actions",
+	"add-branch",
 	"add-cloud",
 	"add-credential",
 	"add-machine",
@@ -403,8 +405,10 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"bootstrap",
 	"budget",
 	"cached-images",
@@ -414,6 +418,7 @@ var commandNames = []string{
 	"charm-resources",
 	"clouds",
 	"collect-metrics",
+	"commit",
 	"config",
 	"consume",
 	"controller-config",
@@ -428,6 +433,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -436,6 +442,8 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
+	"export-status-log",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -525,7 +533,9 @@ var commandNames = []string{
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
+	"show-application",
 	"show-backup",
+	"show-branch",
 	"show-cloud",
 	"show-controller",
 	"show-machine",
@@ -534,6 +544,7 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-user",
 	"show-wallet",
 	"sla",
@@ -548,6 +559,8 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"track",
+	"trust",
 	"unexpose",
 	"unregister",
 	"update-clouds",
@@ -556,9 +569,11 @@ var commandNames = []string{
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrade-series",
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
@@ -0,0 +1,302 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// defaultAuditLogLimit is the number of requests shown if no limit
+// is given.
+const defaultAuditLogLimit = 100
+
+// NewAuditLogCommand returns a command that queries the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+// auditLogCommand shows the requests recorded in the audit log of
+// every machine in the controller.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api   auditLogAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	user           string
+	model          string
+	facade         string
+	method         string
+	conversationID string
+	from           string
+	to             string
+	limit          int
+}
+
+const auditLogCommandHelpDoc = `
+Shows the API requests recorded in the controller's audit log, most
+recent last. Requests are recorded by every machine in the controller,
+and the results from all of them are merged; the machine that handled
+each request is shown. Each request is shown along with the
+conversation (the client command) it was part of, and any errors it
+returned. Auditing must be enabled with the "auditing-enabled"
+controller config setting for requests to be recorded.
+
+The --from and --to options accept either a time in RFC3339 format, or
+a duration such as "2h" meaning that long ago.
+
+By default, the most recent 100 matching requests are shown; use
+--limit=0 to show all of them.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --model default
+    juju audit-log --facade Application --method Deploy --from 24h
+    juju audit-log --conversation 2fca03cb62a3bd79 --format yaml
+
+See also:
+    controller-config
+`
+
+// Info implements cmd.Command.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Shows the API requests recorded in the controller's audit log.",
+		Doc:     strings.TrimSpace(auditLogCommandHelpDoc),
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show requests made on the model with this name or UUID")
+	f.StringVar(&c.facade, "facade", "", "Only show requests made to this API facade")
+	f.StringVar(&c.method, "method", "", "Only show requests made to this API method")
+	f.StringVar(&c.conversationID, "conversation", "", "Only show requests made in this conversation")
+	f.StringVar(&c.from, "from", "", "Only show requests made at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show requests made at or before this time")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "Show at most this many requests")
+}
+
+// Init implements cmd.Command.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	if _, err := parseAuditTime(c.from, time.Time{}); err != nil {
+		return errors.Annotate(err, "invalid --from")
+	}
+	if _, err := parseAuditTime(c.to, time.Time{}); err != nil {
+		return errors.Annotate(err, "invalid --to")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseAuditTime parses a time given either in RFC3339 format or as a
+// duration before now.
+func parseAuditTime(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, errors.Errorf("expected RFC3339 time or duration, got %q", value)
+	}
+	t := now.Add(-d)
+	return &t, nil
+}
+
+type auditLogAPI interface {
+	Close() error
+	AuditLog(params.AuditLogFilter) ([]params.AuditLogRecord, error)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	now := c.clock.Now()
+	filter := params.AuditLogFilter{
+		User:           c.user,
+		Model:          c.model,
+		Facade:         c.facade,
+		Method:         c.method,
+		ConversationID: c.conversationID,
+		Limit:          c.limit,
+	}
+	// The times have already been validated.
+	filter.From, _ = parseAuditTime(c.from, now)
+	filter.To, _ = parseAuditTime(c.to, now)
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	records, err := client.AuditLog(filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(records) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No matching requests in the audit log.")
+		return nil
+	}
+	entries := make([]auditLogEntry, len(records))
+	for i, rec := range records {
+		entries[i] = newAuditLogEntry(rec)
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// auditLogEntry is the formatted output of a record from the audit
+// log: a conversation, a request or the errors returned in response.
+type auditLogEntry struct {
+	Kind           string          `yaml:"kind" json:"kind"`
+	When           string          `yaml:"when" json:"when"`
+	Controller     string          `yaml:"controller" json:"controller"`
+	ConversationID string          `yaml:"conversation-id" json:"conversation-id"`
+	ConnectionID   string          `yaml:"connection-id" json:"connection-id"`
+	Who            string          `yaml:"who,omitempty" json:"who,omitempty"`
+	What           string          `yaml:"what,omitempty" json:"what,omitempty"`
+	ModelName      string          `yaml:"model-name,omitempty" json:"model-name,omitempty"`
+	ModelUUID      string          `yaml:"model-uuid,omitempty" json:"model-uuid,omitempty"`
+	RequestID      uint64          `yaml:"request-id,omitempty" json:"request-id,omitempty"`
+	Facade         string          `yaml:"facade,omitempty" json:"facade,omitempty"`
+	Method         string          `yaml:"method,omitempty" json:"method,omitempty"`
+	Version        int             `yaml:"version,omitempty" json:"version,omitempty"`
+	Args           string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Errors         []auditLogError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+type auditLogError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+func newAuditLogEntry(rec params.AuditLogRecord) auditLogEntry {
+	entry := auditLogEntry{Controller: rec.ControllerID}
+	switch {
+	case rec.Conversation != nil:
+		entry.Kind = "conversation"
+		entry.When = rec.Conversation.When
+		entry.ConversationID = rec.Conversation.ConversationID
+		entry.ConnectionID = rec.Conversation.ConnectionID
+		entry.Who = rec.Conversation.Who
+		entry.What = rec.Conversation.What
+		entry.ModelName = rec.Conversation.ModelName
+		entry.ModelUUID = rec.Conversation.ModelUUID
+	case rec.Request != nil:
+		entry.Kind = "request"
+		entry.When = rec.Request.When
+		entry.ConversationID = rec.Request.ConversationID
+		entry.ConnectionID = rec.Request.ConnectionID
+		entry.RequestID = rec.Request.RequestID
+		entry.Facade = rec.Request.Facade
+		entry.Method = rec.Request.Method
+		entry.Version = rec.Request.Version
+		entry.Args = rec.Request.Args
+	case rec.Errors != nil:
+		entry.Kind = "errors"
+		entry.When = rec.Errors.When
+		entry.ConversationID = rec.Errors.ConversationID
+		entry.ConnectionID = rec.Errors.ConnectionID
+		entry.RequestID = rec.Errors.RequestID
+		for _, e := range rec.Errors.Errors {
+			if e == nil {
+				continue
+			}
+			entry.Errors = append(entry.Errors, auditLogError{Message: e.Message, Code: e.Code})
+		}
+	}
+	return entry
+}
+
+// formatAuditLogTabular writes a line for each request, showing the
+// user and model from its conversation and any errors it returned.
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	type requestKey struct {
+		conversationID string
+		requestID      uint64
+	}
+	conversations := make(map[string]auditLogEntry)
+	errs := make(map[requestKey][]string)
+	for _, entry := range entries {
+		switch entry.Kind {
+		case "conversation":
+			conversations[entry.ConversationID] = entry
+		case "errors":
+			key := requestKey{entry.ConversationID, entry.RequestID}
+			for _, e := range entry.Errors {
+				errs[key] = append(errs[key], e.Message)
+			}
+		}
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Controller", "User", "Model", "Conversation", "Request", "Errors")
+	for _, entry := range entries {
+		if entry.Kind != "request" {
+			continue
+		}
+		conversation := conversations[entry.ConversationID]
+		user := conversation.Who
+		if tag, err := names.ParseUserTag(user); err == nil {
+			user = tag.Id()
+		}
+		w.Println(
+			entry.When,
+			entry.Controller,
+			user,
+			conversation.ModelName,
+			entry.ConversationID,
+			entry.Facade+"."+entry.Method,
+			strings.Join(errs[requestKey{entry.ConversationID, entry.RequestID}], "; "),
+		)
+	}
+	return tw.Flush()
+}
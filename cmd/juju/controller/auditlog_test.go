@@ -0,0 +1,214 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeAuditLogAPI{
+		records: []params.AuditLogRecord{{
+			ControllerID: "0",
+			Conversation: &auditlog.Conversation{
+				Who:            "user-bob",
+				What:           "juju deploy mysql",
+				When:           "2018-05-01T10:00:00Z",
+				ModelName:      "default",
+				ModelUUID:      "deadbeef",
+				ConversationID: "abc",
+				ConnectionID:   "1F",
+			},
+		}, {
+			ControllerID: "0",
+			Request: &auditlog.Request{
+				ConversationID: "abc",
+				ConnectionID:   "1F",
+				RequestID:      3,
+				When:           "2018-05-01T10:00:01Z",
+				Facade:         "Application",
+				Method:         "Deploy",
+				Version:        6,
+			},
+		}, {
+			ControllerID: "0",
+			Errors: &auditlog.ResponseErrors{
+				ConversationID: "abc",
+				ConnectionID:   "1F",
+				RequestID:      3,
+				When:           "2018-05-01T10:00:02Z",
+				Errors:         []*auditlog.Error{{Message: "boom", Code: "bad"}},
+			},
+		}, {
+			ControllerID: "2",
+			Request: &auditlog.Request{
+				ConversationID: "abc",
+				ConnectionID:   "1F",
+				RequestID:      4,
+				When:           "2018-05-01T10:00:03Z",
+				Facade:         "Client",
+				Method:         "FullStatus",
+				Version:        1,
+			},
+		}},
+	}
+	s.clock = testing.NewClock(time.Date(2018, 5, 2, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--user", "not valid!"},
+		err:  `user name "not valid!" not valid`,
+	}, {
+		args: []string{"--limit=-1"},
+		err:  `--limit must not be negative`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: expected RFC3339 time or duration, got "yesterday"`,
+	}, {
+		args: []string{"--to", "2018-05-01"},
+		err:  `invalid --to: expected RFC3339 time or duration, got "2018-05-01"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "default",
+		"--facade", "Application",
+		"--method", "Deploy",
+		"--conversation", "abc",
+		"--from", "24h",
+		"--to", "2018-05-02T10:00:00Z",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	from := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 5, 2, 10, 0, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"AuditLog", []interface{}{params.AuditLogFilter{
+			User:           "bob",
+			Model:          "default",
+			Facade:         "Application",
+			Method:         "Deploy",
+			ConversationID: "abc",
+			From:           &from,
+			To:             &to,
+			Limit:          5,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestDefaultLimit(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AuditLog", params.AuditLogFilter{Limit: 100})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Controller  User  Model    Conversation  Request             Errors
+2018-05-01T10:00:01Z  0           bob   default  abc           Application.Deploy  boom
+2018-05-01T10:00:03Z  2           bob   default  abc           Client.FullStatus   
+`[1:])
+}
+
+func (s *AuditLogSuite) TestNoRecords(c *gc.C) {
+	s.api.records = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No matching requests in the audit log.\n")
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	s.api.records = s.api.records[1:3]
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- kind: request
+  when: 2018-05-01T10:00:01Z
+  controller: "0"
+  conversation-id: abc
+  connection-id: 1F
+  request-id: 3
+  facade: Application
+  method: Deploy
+  version: 6
+- kind: errors
+  when: 2018-05-01T10:00:02Z
+  controller: "0"
+  conversation-id: abc
+  connection-id: 1F
+  request-id: 3
+  errors:
+  - message: boom
+    code: bad
+`[1:])
+}
+
+func (s *AuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("this controller version doesn't support querying the audit log"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support querying the audit log")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	records []params.AuditLogRecord
+}
+
+func (f *fakeAuditLogAPI) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogRecord, error) {
+	f.MethodCall(f, "AuditLog", filter)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.records, nil
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -0,0 +1,229 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/controller"
+)
+
+// NewConfigCommand returns a command that displays or sets
+// controller configuration.
+func NewConfigCommand() cmd.Command {
+	return modelcmd.WrapController(&configCommand{})
+}
+
+// configCommand is able to output either the entire controller config
+// or the requested value in a format of the user's choosing, or to
+// change the values of some of the settings.
+type configCommand struct {
+	modelcmd.ControllerCommandBase
+	api controllerAPI
+	out cmd.Output
+
+	key        string
+	configFile common.ConfigFlag
+	setOptions common.ConfigFlag
+}
+
+const configCommandHelpDoc = `
+By default, all configuration (keys and values) for the controller are
+displayed if a key is not specified. Supplying one key name returns
+only the value for that key.
+
+Supplying key=value will set the supplied key to the supplied value;
+this can be repeated for multiple keys. A yaml file of key values can
+also be supplied with --file. Only the following settings can be
+changed after bootstrap:
+
+%s
+
+Changes are picked up by the controller agents without restarting them.
+
+The available keys and values can be found here:
+  https://jujucharms.com/docs/stable/controllers-config
+
+Examples:
+
+    juju controller-config
+    juju controller-config api-port
+    juju controller-config -c mycontroller
+    juju controller-config auditing-enabled=true audit-log-max-backups=5
+    juju controller-config auditing-enabled=true --file=path/to/cfg.yaml
+
+See also:
+    controllers
+    model-config
+    show-cloud
+`
+
+// Info implements cmd.Command.
+func (c *configCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "controller-config",
+		Args:    "[<attribute key>[=<value>] ...]",
+		Purpose: "Displays or sets configuration settings for a controller.",
+		Doc:     strings.TrimSpace(fmt.Sprintf(configCommandHelpDoc, updatableKeys())),
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatConfigTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+	f.Var(&c.configFile, "file", "Path to yaml-formatted configuration file")
+}
+
+// Init implements cmd.Command.
+func (c *configCommand) Init(args []string) error {
+	var keys []string
+	for _, arg := range args {
+		if strings.Contains(arg, "=") {
+			if err := c.setOptions.Set(arg); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		keys = append(keys, arg)
+	}
+	setting := len(keys) < len(args) || c.configFile.String() != ""
+	switch {
+	case setting && len(keys) > 0:
+		return errors.New("can only retrieve a single value, or set values")
+	case len(keys) > 1:
+		return errors.Errorf("unrecognized args: %q", keys[1:])
+	case len(keys) == 1:
+		c.key = keys[0]
+	}
+	return nil
+}
+
+// updatableKeys returns the indented list of settings that can be
+// changed after bootstrap, for the help text.
+func updatableKeys() string {
+	keys := controller.AllowedUpdateConfigAttributes.SortedValues()
+	return "    " + strings.Join(keys, "\n    ")
+}
+
+type controllerAPI interface {
+	Close() error
+	ControllerConfig() (controller.Config, error)
+	ConfigSet(map[string]interface{}) error
+}
+
+func (c *configCommand) getAPI() (controllerAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *configCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.setOptions.String() != "" || c.configFile.String() != "" {
+		return c.setConfig(ctx, client)
+	}
+	return c.getConfig(ctx, client)
+}
+
+func (c *configCommand) setConfig(ctx *cmd.Context, client controllerAPI) error {
+	attrs, err := c.configFile.ReadAttrs(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	values, err := c.setOptions.ReadConfigPairs(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key, value := range values {
+		attrs[key] = value
+	}
+	return errors.Trace(client.ConfigSet(attrs))
+}
+
+func (c *configCommand) getConfig(ctx *cmd.Context, client controllerAPI) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attrs, err := client.ControllerConfig()
+	if err != nil {
+		return err
+	}
+
+	if c.key != "" {
+		if value, found := attrs[c.key]; found {
+			if c.out.Name() == "tabular" {
+				// The user has not specified that they want
+				// YAML or JSON formatting, so we print out
+				// the value unadorned.
+				return c.out.WriteFormatter(ctx, cmd.FormatSmart, value)
+			}
+			return c.out.Write(ctx, value)
+		}
+		return errors.Errorf("key %q not found in %q controller.", c.key, controllerName)
+	}
+	// If key is empty, write out the whole lot.
+	return c.out.Write(ctx, attrs)
+}
+
+func formatConfigTabular(writer io.Writer, value interface{}) error {
+	controllerConfig, ok := value.(controller.Config)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", controllerConfig, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	valueNames := make(set.Strings)
+	for name := range controllerConfig {
+		valueNames.Add(name)
+	}
+	w.Println("Attribute", "Value")
+
+	for _, name := range valueNames.SortedValues() {
+		value := controllerConfig[name]
+
+		var out bytes.Buffer
+		err := cmd.FormatYaml(&out, value)
+		if err != nil {
+			return errors.Annotatef(err, "formatting value for %q", name)
+		}
+		// Some attribute values have a newline appended
+		// which makes the output messy.
+		valString := strings.TrimSuffix(out.String(), "\n")
+		w.Println(name, valString)
+	}
+
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	jujucontroller "github.com/juju/juju/controller"
+)
+
+type ConfigSuite struct {
+	baseControllerSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+}
+
+func (s *ConfigSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewConfigCommandForTest(&fakeControllerAPI{}, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *ConfigSuite) TestInit(c *gc.C) {
+	// zero or one args is fine.
+	err := cmdtesting.InitCommand(controller.NewConfigCommandForTest(&fakeControllerAPI{}, s.store), nil)
+	c.Check(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(controller.NewConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one"})
+	c.Check(err, jc.ErrorIsNil)
+	// More than one is not allowed.
+	err = cmdtesting.InitCommand(controller.NewConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one", "two"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *ConfigSuite) TestSingleValue(c *gc.C) {
+	context, err := s.run(c, "ca-cert")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(cmdtesting.Stdout(context))
+	c.Assert(output, gc.Equals, "multi\nline")
+}
+
+func (s *ConfigSuite) TestSingleValueJSON(c *gc.C) {
+	context, err := s.run(c, "--format=json", "controller-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(cmdtesting.Stdout(context))
+	c.Assert(output, gc.Equals, `"uuid"`)
+}
+
+func (s *ConfigSuite) TestAllValues(c *gc.C) {
+	context, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(cmdtesting.Stdout(context))
+	expected := `
+Attribute  Value
+api-port   1234
+ca-cert    |-
+  multi
+  line
+controller-uuid  uuid`[1:]
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigSuite) TestAllValuesJSON(c *gc.C) {
+	context, err := s.run(c, "--format=json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(cmdtesting.Stdout(context))
+	expected := `{"api-port":1234,"ca-cert":"multi\nline","controller-uuid":"uuid"}`
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigSuite) TestError(c *gc.C) {
+	command := controller.NewConfigCommandForTest(&fakeControllerAPI{err: errors.New("error")}, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "error")
+}
+
+func (s *ConfigSuite) TestSetValues(c *gc.C) {
+	api := &fakeControllerAPI{}
+	command := controller.NewConfigCommandForTest(api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "auditing-enabled=true", "audit-log-max-backups=5")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.values, jc.DeepEquals, map[string]interface{}{
+		"auditing-enabled":      true,
+		"audit-log-max-backups": 5,
+	})
+}
+
+func (s *ConfigSuite) TestSetValuesFromFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "config.yaml")
+	err := ioutil.WriteFile(path, []byte("max-logs-age: 48h\nmax-logs-size: 2G\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := &fakeControllerAPI{}
+	command := controller.NewConfigCommandForTest(api, s.store)
+	_, err = cmdtesting.RunCommand(c, command, "--file", path, "max-logs-size=3G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.values, jc.DeepEquals, map[string]interface{}{
+		"max-logs-age":  "48h",
+		"max-logs-size": "3G",
+	})
+}
+
+func (s *ConfigSuite) TestSetError(c *gc.C) {
+	api := &fakeControllerAPI{err: errors.New(`can't change "api-port" after bootstrap`)}
+	command := controller.NewConfigCommandForTest(api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "api-port=1234")
+	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
+}
+
+func (s *ConfigSuite) TestInitGetAndSet(c *gc.C) {
+	command := controller.NewConfigCommandForTest(&fakeControllerAPI{}, s.store)
+	err := cmdtesting.InitCommand(command, []string{"api-port", "max-logs-age=48h"})
+	c.Assert(err, gc.ErrorMatches, "can only retrieve a single value, or set values")
+	command = controller.NewConfigCommandForTest(&fakeControllerAPI{}, s.store)
+	err = cmdtesting.InitCommand(command, []string{"--file", "config.yaml", "api-port"})
+	c.Assert(err, gc.ErrorMatches, "can only retrieve a single value, or set values")
+}
+
+type fakeControllerAPI struct {
+	err    error
+	values map[string]interface{}
+}
+
+func (f *fakeControllerAPI) Close() error {
+	return nil
+}
+
+func (f *fakeControllerAPI) ControllerConfig() (jujucontroller.Config, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return map[string]interface{}{
+		"controller-uuid": "uuid",
+		"api-port":        1234,
+		"ca-cert":         "multi\nline",
+	}, nil
+}
+
+func (f *fakeControllerAPI) ConfigSet(values map[string]interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.values = values
+	return nil
+}
@@ -57,3 +57,9 @@ func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewUpgradeSeriesCommandForTest returns an upgrade-series command with
+// the api provided as specified.
+func NewUpgradeSeriesCommandForTest(api UpgradeMachineSeriesAPI) cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{upgradeMachineSeriesClient: api})
+}
@@ -0,0 +1,212 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// The upgrade-series sub-commands.
+const (
+	prepareCommand  = "prepare"
+	completeCommand = "complete"
+	abortCommand    = "abort"
+)
+
+// NewUpgradeSeriesCommand returns a command which manages the upgrade of
+// a machine's operating system series.
+func NewUpgradeSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{})
+}
+
+// UpgradeMachineSeriesAPI defines the machinemanager facade methods
+// required by the upgrade-series command.
+type UpgradeMachineSeriesAPI interface {
+	Close() error
+	UpgradeSeriesPrepare(machineName, series string, force bool) error
+	UpgradeSeriesComplete(machineName string) error
+	UpgradeSeriesAbort(machineName string) error
+}
+
+// upgradeSeriesCommand is responsible for managed series upgrades of
+// machines.
+type upgradeSeriesCommand struct {
+	modelcmd.ModelCommandBase
+
+	upgradeMachineSeriesClient UpgradeMachineSeriesAPI
+
+	subCommand    string
+	machineNumber string
+	series        string
+	force         bool
+	yes           bool
+}
+
+var upgradeSeriesDoc = `
+Upgrading the operating system series of a machine is done in two steps,
+around the actual upgrade of the operating system (for example with
+do-release-upgrade).
+
+"juju upgrade-series prepare" runs the pre-series-upgrade hook of every unit
+on the machine. Once the hooks have completed, no other hooks are run on the
+machine, and the agents' service files are rewritten for the init system of
+the new series. The machine is then ready for its operating system to be
+upgraded and rebooted.
+
+"juju upgrade-series complete" runs the post-series-upgrade hook of every
+unit on the machine, after which the machine and its units record the new
+series and normal hook execution resumes.
+
+"juju upgrade-series abort" abandons the upgrade before its completion has
+started, for example after a pre-series-upgrade hook has failed. The units
+return to normal operation on the current series. Aborting is only safe
+before the operating system itself has been upgraded.
+
+The progress of the upgrade is shown by "juju status".
+
+The upgrade is disallowed unless the --force flag is used if the requested
+series is not explicitly supported by the charms of all units on the machine.
+
+Examples:
+    juju upgrade-series prepare 1 bionic
+    juju upgrade-series prepare 1 bionic --yes
+    juju upgrade-series complete 1
+    juju upgrade-series abort 1
+
+See also:
+    machines
+    status
+    update-series
+`
+
+const upgradeSeriesConfirmationMsg = `
+WARNING: This command will mark machine %q as being upgraded to series %q.
+The upgrade can be aborted until it is completed, but not once the
+operating system of the machine has been upgraded.
+Units running on the machine will not run any hooks other than the
+pre-series-upgrade hook until the upgrade is completed.
+
+Continue [y/N]?`
+
+// Info implements cmd.Command.
+func (c *upgradeSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upgrade-series",
+		Args:    "[prepare|complete|abort] <machine> [series]",
+		Purpose: "Upgrade the operating system series of a machine.",
+		Doc:     upgradeSeriesDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *upgradeSeriesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Upgrade even if the series is not supported by the charms on the machine")
+	f.BoolVar(&c.yes, "y", false, "Agree to prepare the machine without being prompted")
+	f.BoolVar(&c.yes, "yes", false, "")
+}
+
+// Init implements cmd.Command.
+func (c *upgradeSeriesCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("no upgrade-series sub-command specified")
+	}
+	c.subCommand = args[0]
+	args = args[1:]
+	switch c.subCommand {
+	case prepareCommand:
+		if len(args) != 2 {
+			return errors.Errorf("wrong number of arguments for %s, expected <machine> <series>", prepareCommand)
+		}
+		c.series = strings.ToLower(args[1])
+	case completeCommand, abortCommand:
+		if len(args) != 1 {
+			return errors.Errorf("wrong number of arguments for %s, expected <machine>", c.subCommand)
+		}
+		if c.force {
+			return errors.Errorf("--force is only valid with %s", prepareCommand)
+		}
+	default:
+		return errors.Errorf("%q is not a valid upgrade-series sub-command; use %q, %q or %q",
+			c.subCommand, prepareCommand, completeCommand, abortCommand)
+	}
+	if !names.IsValidMachine(args[0]) {
+		return errors.Errorf("invalid machine id %q", args[0])
+	}
+	c.machineNumber = args[0]
+	return nil
+}
+
+func (c *upgradeSeriesCommand) getAPI() (UpgradeMachineSeriesAPI, error) {
+	if c.upgradeMachineSeriesClient != nil {
+		return c.upgradeMachineSeriesClient, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *upgradeSeriesCommand) Run(ctx *cmd.Context) error {
+	if c.subCommand == prepareCommand && !c.yes {
+		if err := c.confirm(ctx); err != nil {
+			return err
+		}
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	switch c.subCommand {
+	case prepareCommand:
+		err = client.UpgradeSeriesPrepare(c.machineNumber, c.series, c.force)
+		if err == nil {
+			ctx.Infof("machine %s is being prepared for upgrade to series %q; "+
+				"run \"juju status\" to follow its progress", c.machineNumber, c.series)
+		}
+	case completeCommand:
+		err = client.UpgradeSeriesComplete(c.machineNumber)
+		if err == nil {
+			ctx.Infof("completing series upgrade of machine %s", c.machineNumber)
+		}
+	case abortCommand:
+		err = client.UpgradeSeriesAbort(c.machineNumber)
+		if err == nil {
+			ctx.Infof("aborted series upgrade of machine %s", c.machineNumber)
+		}
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+func (c *upgradeSeriesCommand) confirm(ctx *cmd.Context) error {
+	fmt.Fprintf(ctx.Stdout, upgradeSeriesConfirmationMsg, c.machineNumber, c.series)
+
+	scanner := bufio.NewScanner(ctx.Stdin)
+	scanner.Scan()
+	err := scanner.Err()
+	if err != nil && err != io.EOF {
+		return errors.Annotate(err, "upgrade series aborted")
+	}
+	answer := strings.ToLower(scanner.Text())
+	if answer != "y" && answer != "yes" {
+		return errors.New("upgrade series aborted")
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeUpgradeSeriesAPI
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeUpgradeSeriesAPI{}
+}
+
+func (s *UpgradeSeriesSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no upgrade-series sub-command specified",
+	}, {
+		args: []string{"start", "1"},
+		err:  `"start" is not a valid upgrade-series sub-command; use "prepare", "complete" or "abort"`,
+	}, {
+		args: []string{"prepare", "1"},
+		err:  "wrong number of arguments for prepare, expected <machine> <series>",
+	}, {
+		args: []string{"complete", "1", "bionic"},
+		err:  "wrong number of arguments for complete, expected <machine>",
+	}, {
+		args: []string{"complete", "1", "--force"},
+		err:  "--force is only valid with prepare",
+	}, {
+		args: []string{"abort"},
+		err:  "wrong number of arguments for abort, expected <machine>",
+	}, {
+		args: []string{"abort", "1", "--force"},
+		err:  "--force is only valid with prepare",
+	}, {
+		args: []string{"prepare", "mysql/0", "bionic"},
+		err:  `invalid machine id "mysql/0"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(machine.NewUpgradeSeriesCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpgradeSeriesSuite) TestPrepare(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewUpgradeSeriesCommandForTest(s.api),
+		"prepare", "1", "Bionic", "--yes", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"UpgradeSeriesPrepare", []interface{}{"1", "bionic", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareConfirmed(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("y\n")
+	cmd := machine.NewUpgradeSeriesCommandForTest(s.api)
+	err := cmdtesting.InitCommand(cmd, []string{"prepare", "1", "bionic"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmd.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `machine "1" as being upgraded to series "bionic"`)
+	s.api.CheckCallNames(c, "UpgradeSeriesPrepare", "Close")
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareAborted(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("n\n")
+	cmd := machine.NewUpgradeSeriesCommandForTest(s.api)
+	err := cmdtesting.InitCommand(cmd, []string{"prepare", "1", "bionic"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmd.Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "upgrade series aborted")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *UpgradeSeriesSuite) TestComplete(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewUpgradeSeriesCommandForTest(s.api),
+		"complete", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"UpgradeSeriesComplete", []interface{}{"1"}},
+		{"Close", nil},
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestAbort(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewUpgradeSeriesCommandForTest(s.api),
+		"abort", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "aborted series upgrade of machine 1\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"UpgradeSeriesAbort", []interface{}{"1"}},
+		{"Close", nil},
+	})
+}
+
+type fakeUpgradeSeriesAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeUpgradeSeriesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesPrepare(machineName, series string, force bool) error {
+	f.MethodCall(f, "UpgradeSeriesPrepare", machineName, series, force)
+	return f.NextErr()
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesComplete(machineName string) error {
+	f.MethodCall(f, "UpgradeSeriesComplete", machineName)
+	return f.NextErr()
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesAbort(machineName string) error {
+	f.MethodCall(f, "UpgradeSeriesAbort", machineName)
+	return f.NextErr()
+}
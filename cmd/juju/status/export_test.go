@@ -8,3 +8,7 @@ import "github.com/juju/cmd"
 func NewTestStatusHistoryCommand(api HistoryAPI) cmd.Command {
 	return &statusHistoryCommand{api: api}
 }
+
+func NewTestExportStatusHistoryCommand(api ExportHistoryAPI) cmd.Command {
+	return &exportStatusHistoryCommand{api: api}
+}
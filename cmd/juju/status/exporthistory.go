@@ -0,0 +1,252 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/status"
+)
+
+var exportStatusHistoryDoc = fmt.Sprintf(`
+Exports the status history of the units and machines of a model, oldest
+first, as CSV by default. Unlike show-status-log, which shows the history
+of one entity, the entries of every entity are included, or those of all
+the units of an application when --application is given.
+
+The entries may be restricted to some kinds with --type, which takes a
+comma-separated list of:
+%v
+and to a time range with --from-date and --to-date, which take dates in
+the format YYYY-MM-DD or RFC3339 times.
+
+Examples:
+    juju export-status-log > history.csv
+    juju export-status-log --application mysql --type workload --format json
+    juju export-status-log --from-date 2018-10-01 --to-date 2018-10-02 -o history.csv
+
+See also:
+    show-status-log
+`, supportedHistoryKindDescs())
+
+// NewExportStatusHistoryCommand returns a command that exports the
+// status history of the entities of a model.
+func NewExportStatusHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&exportStatusHistoryCommand{})
+}
+
+// ExportHistoryAPI is the API surface for the export-status-log command.
+type ExportHistoryAPI interface {
+	ModelStatusHistory(params.StatusHistoryQuery) (params.StatusHistoryEntries, error)
+	Close() error
+}
+
+type exportStatusHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	api                  ExportHistoryAPI
+	out                  cmd.Output
+	application          string
+	kinds                string
+	fromDate             string
+	toDate               string
+	size                 int
+	isoTime              bool
+	includeStatusUpdates bool
+
+	query params.StatusHistoryQuery
+}
+
+func (c *exportStatusHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-status-log",
+		Purpose: "Exports the status history of the entities of a model.",
+		Doc:     exportStatusHistoryDoc,
+	}
+}
+
+func (c *exportStatusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.application, "application", "", "Only export the history of the units of this application")
+	f.StringVar(&c.kinds, "type", "", fmt.Sprintf("Comma-separated types of statuses to export [%v]", supportedHistoryKindTypes()))
+	f.StringVar(&c.fromDate, "from-date", "", "Only export entries from this date or time on")
+	f.StringVar(&c.toDate, "to-date", "", "Only export entries from before this date or time")
+	f.IntVar(&c.size, "n", 0, "Only export the last N entries")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format (tabular format only)")
+	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Include update status hook messages in the exported logs")
+	c.out.AddFlags(f, "csv", map[string]cmd.Formatter{
+		"csv":     formatHistoryCSV,
+		"json":    cmd.FormatJson,
+		"yaml":    cmd.FormatYaml,
+		"tabular": c.formatTabular,
+	})
+}
+
+func (c *exportStatusHistoryCommand) Init(args []string) error {
+	if c.application != "" && !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	if c.size < 0 {
+		return errors.New("-n must not be negative")
+	}
+	query := params.StatusHistoryQuery{
+		Application: c.application,
+		Size:        c.size,
+	}
+	if c.kinds != "" {
+		for _, kind := range strings.Split(c.kinds, ",") {
+			kind = strings.TrimSpace(kind)
+			if !status.HistoryKind(kind).Valid() {
+				return errors.Errorf("unexpected status type %q", kind)
+			}
+			query.Kinds = append(query.Kinds, kind)
+		}
+	}
+	var err error
+	if query.FromDate, err = parseHistoryDate(c.fromDate); err != nil {
+		return errors.Annotate(err, "parsing from date")
+	}
+	if query.ToDate, err = parseHistoryDate(c.toDate); err != nil {
+		return errors.Annotate(err, "parsing to date")
+	}
+	if query.FromDate != nil && query.ToDate != nil && !query.ToDate.After(*query.FromDate) {
+		return errors.New("to date must be after from date")
+	}
+	if !c.includeStatusUpdates {
+		query.Exclude = []string{runningHookMSG}
+	}
+	c.query = query
+	return cmd.CheckEmpty(args)
+}
+
+// parseHistoryDate parses a date given as YYYY-MM-DD or as an RFC3339
+// time, returning nil if it is empty.
+func parseHistoryDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, errors.Errorf("expected YYYY-MM-DD or RFC3339 time, got %q", value)
+		}
+	}
+	return &t, nil
+}
+
+func (c *exportStatusHistoryCommand) getAPI() (ExportHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+func (c *exportStatusHistoryCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+
+	// The controller returns the entries a page at a time.
+	var exported []exportedStatus
+	query := c.query
+	for {
+		page, err := apiclient.ModelStatusHistory(query)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, entry := range page.Entries {
+			tag, err := names.ParseTag(entry.Tag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			var since time.Time
+			if entry.Status.Since != nil {
+				since = entry.Status.Since.UTC()
+			}
+			exported = append(exported, exportedStatus{
+				Time:    since,
+				Entity:  tag.Id(),
+				Kind:    entry.Status.Kind,
+				Status:  entry.Status.Status,
+				Message: entry.Status.Info,
+				Data:    entry.Status.Data,
+			})
+		}
+		if page.Next == "" {
+			break
+		}
+		query.After = page.Next
+	}
+	if exported == nil {
+		exported = []exportedStatus{}
+	}
+	return c.out.Write(ctx, exported)
+}
+
+// exportedStatus is a status history entry as exported by
+// export-status-log.
+type exportedStatus struct {
+	Time    time.Time              `json:"time" yaml:"time"`
+	Entity  string                 `json:"entity" yaml:"entity"`
+	Kind    string                 `json:"kind" yaml:"kind"`
+	Status  string                 `json:"status" yaml:"status"`
+	Message string                 `json:"message,omitempty" yaml:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+func formatHistoryCSV(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]exportedStatus)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	w := csv.NewWriter(writer)
+	if err := w.Write([]string{"time", "entity", "kind", "status", "message"}); err != nil {
+		return errors.Trace(err)
+	}
+	for _, entry := range entries {
+		record := []string{
+			entry.Time.Format(time.RFC3339Nano),
+			entry.Entity,
+			entry.Kind,
+			entry.Status,
+			entry.Message,
+		}
+		if err := w.Write(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.Flush()
+	return errors.Trace(w.Error())
+}
+
+func (c *exportStatusHistoryCommand) formatTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]exportedStatus)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Time", "Entity", "Type", "Status", "Message")
+	for _, entry := range entries {
+		w.Print(common.FormatTime(&entry.Time, c.isoTime), entry.Entity, entry.Kind)
+		w.PrintStatus(status.Status(entry.Status))
+		w.Println(entry.Message)
+	}
+	return tw.Flush()
+}
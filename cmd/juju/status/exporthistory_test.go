@@ -0,0 +1,166 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type ExportStatusHistorySuite struct {
+	testing.IsolationSuite
+	api *fakeExportHistoryAPI
+}
+
+var _ = gc.Suite(&ExportStatusHistorySuite{})
+
+func (s *ExportStatusHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	t0 := time.Date(2018, 10, 1, 12, 34, 56, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	s.api = &fakeExportHistoryAPI{
+		entries: []params.StatusHistoryEntry{{
+			Tag: "unit-mysql-0",
+			Status: params.DetailedStatus{
+				Kind:   "workload",
+				Status: "maintenance",
+				Info:   "installing, please wait",
+				Since:  &t0,
+			},
+		}, {
+			Tag: "machine-0",
+			Status: params.DetailedStatus{
+				Kind:   "juju-machine",
+				Status: "started",
+				Since:  &t1,
+			},
+		}},
+	}
+}
+
+func (s *ExportStatusHistorySuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, statuscmd.NewTestExportStatusHistoryCommand(s.api), args...)
+}
+
+func (s *ExportStatusHistorySuite) TestCSV(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,entity,kind,status,message\n"+
+		"2018-10-01T12:34:56Z,mysql/0,workload,maintenance,\"installing, please wait\"\n"+
+		"2018-10-01T12:35:56Z,0,juju-machine,started,\n")
+	c.Check(s.api.query, jc.DeepEquals, params.StatusHistoryQuery{
+		Exclude: []string{"running update-status hook"},
+	})
+}
+
+func (s *ExportStatusHistorySuite) TestJSON(c *gc.C) {
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `[`+
+		`{"time":"2018-10-01T12:34:56Z","entity":"mysql/0","kind":"workload","status":"maintenance","message":"installing, please wait"},`+
+		`{"time":"2018-10-01T12:35:56Z","entity":"0","kind":"juju-machine","status":"started"}`+
+		"]\n")
+}
+
+func (s *ExportStatusHistorySuite) TestPages(c *gc.C) {
+	s.api.pageSize = 1
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,entity,kind,status,message\n"+
+		"2018-10-01T12:34:56Z,mysql/0,workload,maintenance,\"installing, please wait\"\n"+
+		"2018-10-01T12:35:56Z,0,juju-machine,started,\n")
+	exclude := []string{"running update-status hook"}
+	c.Check(s.api.queries, jc.DeepEquals, []params.StatusHistoryQuery{
+		{Exclude: exclude},
+		{Exclude: exclude, After: "1"},
+	})
+}
+
+func (s *ExportStatusHistorySuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "[]\n")
+}
+
+func (s *ExportStatusHistorySuite) TestQuery(c *gc.C) {
+	_, err := s.run(c,
+		"--application", "mysql",
+		"--type", "workload, juju-unit",
+		"--from-date", "2018-10-01",
+		"--to-date", "2018-10-01T18:00:00Z",
+		"-n", "100",
+		"--include-status-updates",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 10, 1, 18, 0, 0, 0, time.UTC)
+	c.Check(s.api.query, jc.DeepEquals, params.StatusHistoryQuery{
+		Kinds:       []string{"workload", "juju-unit"},
+		Application: "mysql",
+		FromDate:    &from,
+		ToDate:      &to,
+		Size:        100,
+	})
+}
+
+func (s *ExportStatusHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"--application", "mysql/0"}, `application name "mysql/0" not valid`},
+		{[]string{"--type", "workload,relation"}, `unexpected status type "relation"`},
+		{[]string{"--from-date", "yesterday"}, `parsing from date: expected YYYY-MM-DD or RFC3339 time, got "yesterday"`},
+		{[]string{"--from-date", "2018-10-02", "--to-date", "2018-10-01"}, `to date must be after from date`},
+		{[]string{"-n", "-1"}, `-n must not be negative`},
+		{[]string{"mysql/0"}, `unrecognized args: \["mysql/0"\]`},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type fakeExportHistoryAPI struct {
+	entries  []params.StatusHistoryEntry
+	pageSize int
+	query    params.StatusHistoryQuery
+	queries  []params.StatusHistoryQuery
+}
+
+func (*fakeExportHistoryAPI) Close() error {
+	return nil
+}
+
+func (f *fakeExportHistoryAPI) ModelStatusHistory(query params.StatusHistoryQuery) (params.StatusHistoryEntries, error) {
+	f.query = query
+	f.queries = append(f.queries, query)
+	start := 0
+	if query.After != "" {
+		var err error
+		if start, err = strconv.Atoi(query.After); err != nil {
+			return params.StatusHistoryEntries{}, err
+		}
+	}
+	var result params.StatusHistoryEntries
+	end := len(f.entries)
+	if f.pageSize > 0 && start+f.pageSize < end {
+		end = start + f.pageSize
+		result.Next = strconv.Itoa(end)
+	}
+	result.Entries = f.entries[start:end]
+	return result, nil
+}
@@ -67,6 +67,7 @@ type machineStatus struct {
 	Constraints       string                      `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Hardware          string                      `json:"hardware,omitempty" yaml:"hardware,omitempty"`
 	HAStatus          string                      `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
+	UpgradeSeries     string                      `json:"upgrade-series-status,omitempty" yaml:"upgrade-series-status,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -190,6 +191,7 @@ type unitStatus struct {
 	MeterStatus        *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 
 	Leader        bool                  `json:"leader,omitempty" yaml:"leader,omitempty"`
+	Branch        string                `json:"branch,omitempty" yaml:"branch,omitempty"`
 	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
 	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
@@ -134,6 +134,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Containers:        make(map[string]machineStatus),
 		Constraints:       machine.Constraints,
 		Hardware:          machine.Hardware,
+		UpgradeSeries:     machine.UpgradeSeriesStatus,
 	}
 
 	for k, d := range machine.NetworkInterfaces {
@@ -338,6 +339,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Charm:              info.unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
 		Leader:             info.unit.Leader,
+		Branch:             info.unit.Branch,
 	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
@@ -105,6 +105,7 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 	}
 
 	units := make(map[string]unitStatus)
+	branching := false
 	outputHeaders("App", "Version", "Status", "Scale", "Charm", "Store", "Rev", "OS", "Notes")
 	tw.SetColumnAlignRight(3)
 	tw.SetColumnAlignRight(6)
@@ -146,6 +147,9 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 			if u.MeterStatus != nil {
 				metering = true
 			}
+			if u.Branch != "" {
+				branching = true
+			}
 		}
 	}
 
@@ -192,6 +196,15 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		recurseUnits(u, indentationLevel, pUnit)
 	}
 
+	if branching {
+		outputHeaders("Unit", "Branch")
+		for _, name := range utils.SortStringsNaturally(stringKeysFromMap(units)) {
+			if u := units[name]; u.Branch != "" {
+				p(name, u.Branch)
+			}
+		}
+	}
+
 	if metering {
 		outputHeaders("Entity", "Meter status", "Message")
 		if fs.Model.MeterStatus != nil {
@@ -333,9 +346,18 @@ func printMachine(w output.Wrapper, m machineStatus) {
 	if hw.AvailabilityZone != nil {
 		az = *hw.AvailabilityZone
 	}
+	message := m.MachineStatus.Message
+	if m.UpgradeSeries != "" {
+		upgradeMessage := "series upgrade " + m.UpgradeSeries
+		if message != "" {
+			message = fmt.Sprintf("%s (%s)", message, upgradeMessage)
+		} else {
+			message = upgradeMessage
+		}
+	}
 	w.Print(m.Id)
 	w.PrintStatus(m.JujuStatus.Current)
-	w.Println(m.DNSName, m.InstanceId, m.Series, az, m.MachineStatus.Message)
+	w.Println(m.DNSName, m.InstanceId, m.Series, az, message)
 	for _, name := range utils.SortStringsNaturally(stringKeysFromMap(m.Containers)) {
 		printMachine(w, m.Containers[name])
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
@@ -23,6 +24,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	WatchAll() (*api.AllWatcher, error)
 	Close() error
 }
 
@@ -37,6 +39,7 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    bool
 	api      statusAPI
 
 	color bool
@@ -55,6 +58,12 @@ machines, applications, and units will also be displayed. If a subordinate unit
 is matched, then its principal unit will be displayed. If a principal unit is
 matched, then all of its subordinates will be displayed.
 
+With --watch, the status is shown again whenever the model changes, until
+the command is interrupted. Changes are streamed from the controller rather
+than by fetching the whole status each time, and the lines that have changed
+since the previous status are marked with a '*'. Filter patterns may be used
+with --watch, but only the tabular format is supported.
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -77,6 +86,8 @@ Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --watch
+    juju show-status --watch mysql
 
 See also:
     machines
@@ -99,6 +110,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.watch, "watch", false, "Show the status again whenever the model changes")
 
 	defaultFormat := "tabular"
 
@@ -115,6 +127,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch && c.out.Name() != "tabular" {
+		return errors.Errorf("--watch only supports the tabular format, not %q", c.out.Name())
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -156,6 +171,9 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.watch {
+		return c.runWatch(ctx, apiclient, status, controllerName)
+	}
 	formatter := newStatusFormatter(status, controllerName, c.isoTime)
 	formatted, err := formatter.format()
 	if err != nil {
@@ -4284,6 +4284,65 @@ func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 		"Machine  State  DNS  Inst id  Series  AZ  Message\n")
 }
 
+func (s *StatusSuite) TestFormatTabularBranches(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						Branch: "new-config",
+					},
+					"foo/1": {},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, ""+
+		"Model  Controller  Cloud/Region  Version\n"+
+		"                                 \n"+
+		"\n"+
+		"App  Version  Status  Scale  Charm  Store  Rev  OS  Notes\n"+
+		"foo                     0/2                  0      \n"+
+		"\n"+
+		"Unit   Workload  Agent  Machine  Public address  Ports  Message\n"+
+		"foo/0                                                   \n"+
+		"foo/1                                                   \n"+
+		"\n"+
+		"Unit   Branch\n"+
+		"foo/0  new-config  \n"+
+		"\n"+
+		"Machine  State  DNS  Inst id  Series  AZ  Message\n")
+}
+
+func (s *StatusSuite) TestFormatUpgradeSeries(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				AgentStatus:         params.DetailedStatus{Status: "started"},
+				InstanceId:          "i-0",
+				Series:              "xenial",
+				Id:                  "0",
+				UpgradeSeriesStatus: "prepare started",
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(formatted.Machines["0"].UpgradeSeries, gc.Equals, "prepare started")
+
+	out := &bytes.Buffer{}
+	err = FormatTabular(out, false, formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), jc.Contains, "series upgrade prepare started")
+}
+
 //
 // Filtering Feature
 //
@@ -4695,6 +4754,11 @@ var statusInitTests = []struct {
 	}, {
 		envVar: "foo",
 		err:    "invalid JUJU_STATUS_ISO_TIME env var, expected true|false.*",
+	}, {
+		args: []string{"--watch"},
+	}, {
+		args: []string{"--watch", "--format", "yaml"},
+		err:  `--watch only supports the tabular format, not "yaml"`,
 	},
 }
 
@@ -0,0 +1,552 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/mattn/go-isatty"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+	corelife "github.com/juju/juju/core/life"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// allWatcher is the part of api.AllWatcher used by status --watch.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// statusModel is a local copy of the status of a model, which is kept
+// up to date by applying the deltas from an AllWatcher, so that
+// status --watch doesn't have to fetch the full status of the model
+// for every change.
+//
+// The deltas don't carry everything the full status does, so changes
+// that can't be reproduced exactly, such as new relations or new
+// subordinate units, require the full status to be fetched again.
+type statusModel struct {
+	status   *params.FullStatus
+	patterns []string
+}
+
+// apply updates the model with the given deltas. It returns false if
+// any of the deltas couldn't be applied, in which case the full status
+// must be fetched again.
+func (m *statusModel) apply(deltas []multiwatcher.Delta) bool {
+	ok := true
+	for _, delta := range deltas {
+		if !m.applyDelta(delta) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (m *statusModel) applyDelta(delta multiwatcher.Delta) bool {
+	switch info := delta.Entity.(type) {
+	case *multiwatcher.ModelInfo:
+		if !delta.Removed {
+			updateDetailedStatus(&m.status.Model.ModelStatus, info.Status, info.Life)
+		}
+		return true
+	case *multiwatcher.MachineInfo:
+		if delta.Removed {
+			return m.removeMachine(info.Id)
+		}
+		return m.updateMachine(info)
+	case *multiwatcher.ApplicationInfo:
+		if delta.Removed {
+			delete(m.status.Applications, info.Name)
+			return true
+		}
+		return m.updateApplication(info)
+	case *multiwatcher.UnitInfo:
+		if delta.Removed {
+			m.removeUnit(info.Application, info.Name)
+			return true
+		}
+		return m.updateUnit(info)
+	case *multiwatcher.RemoteApplicationInfo:
+		remote, found := m.status.RemoteApplications[info.Name]
+		if !found {
+			// A new remote application brings relations with it.
+			return delta.Removed || !m.matches(info.Name)
+		}
+		if delta.Removed {
+			delete(m.status.RemoteApplications, info.Name)
+			return true
+		}
+		remote.Life = lifeString(info.Life)
+		updateDetailedStatus(&remote.Status, info.Status, "")
+		m.status.RemoteApplications[info.Name] = remote
+		return true
+	case *multiwatcher.RelationInfo:
+		// Relations change the applications at both ends, and
+		// which applications are subordinate to which, so the
+		// full status is fetched again whenever one comes or goes.
+		found := false
+		for _, rel := range m.status.Relations {
+			if rel.Key == info.Key {
+				found = true
+				break
+			}
+		}
+		if delta.Removed {
+			return !found
+		}
+		return found || !m.relationMatches(info)
+	}
+	// Nothing else is shown by status.
+	return true
+}
+
+// matches reports whether an entity with the given names would be
+// included in the status, given the filter patterns.
+func (m *statusModel) matches(names ...string) bool {
+	if len(m.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range m.patterns {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *statusModel) relationMatches(info *multiwatcher.RelationInfo) bool {
+	for _, ep := range info.Endpoints {
+		if _, ok := m.status.Applications[ep.ApplicationName]; ok {
+			return true
+		}
+		if m.matches(ep.ApplicationName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *statusModel) updateApplication(info *multiwatcher.ApplicationInfo) bool {
+	app, found := m.status.Applications[info.Name]
+	if !found {
+		if len(m.patterns) > 0 {
+			// The filters include related entities too, which
+			// only the controller can work out.
+			return !m.matches(info.Name)
+		}
+		if curl, err := charm.ParseURL(info.CharmURL); err == nil {
+			app.Series = curl.Series
+		}
+	}
+	app.Charm = info.CharmURL
+	app.Exposed = info.Exposed
+	app.Life = lifeString(info.Life)
+	app.WorkloadVersion = info.WorkloadVersion
+	updateDetailedStatus(&app.Status, info.Status, "")
+	if m.status.Applications == nil {
+		m.status.Applications = make(map[string]params.ApplicationStatus)
+	}
+	m.status.Applications[info.Name] = app
+	return true
+}
+
+func (m *statusModel) updateUnit(info *multiwatcher.UnitInfo) bool {
+	app, found := m.status.Applications[info.Application]
+	if !found {
+		return !m.matches(info.Application, info.Name)
+	}
+	if info.Subordinate {
+		return m.updateSubordinate(info)
+	}
+	unit, found := app.Units[info.Name]
+	if !found && len(m.patterns) > 0 {
+		// Whether the new unit is shown depends on how the
+		// application came to match the filters.
+		return false
+	}
+	fillUnitStatus(&unit, info, app.Charm)
+	unit.Machine = info.MachineId
+	if app.Units == nil {
+		app.Units = make(map[string]params.UnitStatus)
+	}
+	app.Units[info.Name] = unit
+	m.status.Applications[info.Application] = app
+	return true
+}
+
+// updateSubordinate updates a subordinate unit, which is shown beneath
+// its principal. The delta doesn't identify the principal, so it
+// returns false if the subordinate isn't already known.
+func (m *statusModel) updateSubordinate(info *multiwatcher.UnitInfo) bool {
+	subApp := m.status.Applications[info.Application]
+	for appName, app := range m.status.Applications {
+		for unitName, unit := range app.Units {
+			sub, found := unit.Subordinates[info.Name]
+			if !found {
+				continue
+			}
+			fillUnitStatus(&sub, info, subApp.Charm)
+			unit.Subordinates[info.Name] = sub
+			app.Units[unitName] = unit
+			m.status.Applications[appName] = app
+			return true
+		}
+	}
+	return false
+}
+
+func fillUnitStatus(unit *params.UnitStatus, info *multiwatcher.UnitInfo, appCharm string) {
+	updateDetailedStatus(&unit.WorkloadStatus, info.WorkloadStatus, "")
+	updateDetailedStatus(&unit.AgentStatus, info.AgentStatus, "")
+	unit.PublicAddress = info.PublicAddress
+	unit.Charm = ""
+	if info.CharmURL != "" && info.CharmURL != appCharm {
+		unit.Charm = info.CharmURL
+	}
+	unit.OpenedPorts = nil
+	for _, r := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, network.PortRange{
+			FromPort: r.FromPort,
+			ToPort:   r.ToPort,
+			Protocol: r.Protocol,
+		}.String())
+	}
+}
+
+func (m *statusModel) removeUnit(appName, unitName string) {
+	if app, ok := m.status.Applications[appName]; ok {
+		delete(app.Units, unitName)
+	}
+	for _, app := range m.status.Applications {
+		for _, unit := range app.Units {
+			delete(unit.Subordinates, unitName)
+		}
+	}
+}
+
+func (m *statusModel) updateMachine(info *multiwatcher.MachineInfo) bool {
+	machines := m.machinesFor(info.Id)
+	if machines == nil {
+		// The machine's host isn't shown.
+		return !m.matches(info.Id)
+	}
+	machine, found := machines[info.Id]
+	if !found && len(m.patterns) > 0 {
+		return !m.matches(info.Id)
+	}
+	machine.Id = info.Id
+	machine.InstanceId = instance.Id(info.InstanceId)
+	machine.Series = info.Series
+	updateDetailedStatus(&machine.AgentStatus, info.AgentStatus, info.Life)
+	updateDetailedStatus(&machine.InstanceStatus, info.InstanceStatus, "")
+	machine.DNSName, machine.IPAddresses = machineAddresses(info.Addresses)
+	machine.Hardware = ""
+	if info.HardwareCharacteristics != nil {
+		machine.Hardware = info.HardwareCharacteristics.String()
+	}
+	machine.Jobs = info.Jobs
+	machine.HasVote = info.HasVote
+	machine.WantsVote = info.WantsVote
+	m.setMachine(info.Id, machine)
+	return true
+}
+
+// machineAddresses returns the address status shows for a machine,
+// preferring a public address, and all the addresses that can be
+// reached from outside the machine.
+func machineAddresses(addrs []multiwatcher.Address) (dnsName string, ipAddresses []string) {
+	for _, addr := range addrs {
+		switch network.Scope(addr.Scope) {
+		case network.ScopeMachineLocal, network.ScopeLinkLocal:
+			continue
+		case network.ScopePublic:
+			if dnsName == "" || !isPublic(addrs, dnsName) {
+				dnsName = addr.Value
+			}
+		default:
+			if dnsName == "" {
+				dnsName = addr.Value
+			}
+		}
+		ipAddresses = append(ipAddresses, addr.Value)
+	}
+	return dnsName, ipAddresses
+}
+
+// isPublic reports whether value is a public address.
+func isPublic(addrs []multiwatcher.Address, value string) bool {
+	for _, addr := range addrs {
+		if addr.Value == value {
+			return network.Scope(addr.Scope) == network.ScopePublic
+		}
+	}
+	return false
+}
+
+func (m *statusModel) removeMachine(id string) bool {
+	if machines := m.machinesFor(id); machines != nil {
+		delete(machines, id)
+	}
+	return true
+}
+
+// machinesFor returns the map that holds the machine with the given
+// id: the model's machines for a top level machine, or the containers
+// of its host for a container. It returns nil if the host isn't known.
+func (m *statusModel) machinesFor(id string) map[string]params.MachineStatus {
+	parent := parentMachineId(id)
+	if parent == "" {
+		if m.status.Machines == nil {
+			m.status.Machines = make(map[string]params.MachineStatus)
+		}
+		return m.status.Machines
+	}
+	machines := m.machinesFor(parent)
+	if machines == nil {
+		return nil
+	}
+	host, ok := machines[parent]
+	if !ok {
+		return nil
+	}
+	if host.Containers == nil {
+		host.Containers = make(map[string]params.MachineStatus)
+		machines[parent] = host
+	}
+	return host.Containers
+}
+
+func (m *statusModel) setMachine(id string, machine params.MachineStatus) {
+	if machines := m.machinesFor(id); machines != nil {
+		machines[id] = machine
+	}
+}
+
+// parentMachineId returns the id of the machine hosting the container
+// with the given id, or "" if id isn't a container.
+func parentMachineId(id string) string {
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], "/")
+}
+
+func updateDetailedStatus(out *params.DetailedStatus, in multiwatcher.StatusInfo, life multiwatcher.Life) {
+	out.Status = string(in.Current)
+	out.Info = in.Message
+	out.Data = in.Data
+	out.Since = in.Since
+	out.Err = in.Err
+	if in.Version != "" {
+		out.Version = in.Version
+	}
+	if life != "" {
+		out.Life = lifeString(life)
+	}
+}
+
+// lifeString returns the life as shown by status, which omits "alive"
+// as the usual case.
+func lifeString(life multiwatcher.Life) string {
+	if life == multiwatcher.Life(corelife.Alive) {
+		return ""
+	}
+	return string(life)
+}
+
+// frameWriter writes successive renderings of the status, marking the
+// lines that have changed since the previous one.
+type frameWriter struct {
+	writer   *ansiterm.Writer
+	clear    bool
+	previous []string
+	frames   int
+}
+
+// changedHighlight is used to mark the lines that have changed since
+// the previous frame.
+var changedHighlight = output.WarningHighlight
+
+// write writes the frame if it differs from the previous one.
+func (w *frameWriter) write(frame string) {
+	lines := strings.Split(strings.TrimRight(frame, "\n"), "\n")
+	if w.frames > 0 && equalLines(lines, w.previous) {
+		return
+	}
+	// Lines are compared by content rather than position, so that
+	// a new row doesn't mark everything below it as changed.
+	seen := make(map[string]int)
+	for _, line := range w.previous {
+		seen[line]++
+	}
+	if w.clear {
+		// Move to the top left and clear the screen.
+		fmt.Fprint(w.writer, "\x1b[H\x1b[2J")
+	} else if w.frames > 0 {
+		fmt.Fprintln(w.writer)
+	}
+	for _, line := range lines {
+		switch {
+		case strings.TrimSpace(line) == "":
+			fmt.Fprintln(w.writer)
+			continue
+		case w.frames > 0 && seen[line] == 0:
+			changedHighlight.Fprint(w.writer, "* ")
+		default:
+			fmt.Fprint(w.writer, "  ")
+			seen[line]--
+		}
+		fmt.Fprintln(w.writer, line)
+	}
+	w.previous = lines
+	w.frames++
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isTerminal(f interface{}) bool {
+	f_, ok := f.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f_.Fd())
+}
+
+// renderTabular returns the tabular rendering of the status.
+func (c *statusCommand) renderTabular(status *params.FullStatus, controllerName string, color bool) (string, error) {
+	formatter := newStatusFormatter(status, controllerName, c.isoTime)
+	formatted, err := formatter.format()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	if err := FormatTabular(&buf, color, formatted); err != nil {
+		return "", errors.Trace(err)
+	}
+	return buf.String(), nil
+}
+
+type deltasResult struct {
+	deltas []multiwatcher.Delta
+	err    error
+}
+
+// runWatch renders the status, and then renders it again whenever the
+// model changes, until the command is interrupted.
+func (c *statusCommand) runWatch(ctx *cmd.Context, client statusAPI, status *params.FullStatus, controllerName string) error {
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "watching model")
+	}
+	defer watcher.Stop()
+	return c.watchLoop(ctx, client, watcher, status, controllerName)
+}
+
+func (c *statusCommand) watchLoop(
+	ctx *cmd.Context,
+	client statusAPI,
+	watcher allWatcher,
+	status *params.FullStatus,
+	controllerName string,
+) error {
+	terminal := isTerminal(ctx.Stdout)
+	color := c.color || terminal
+	frames := &frameWriter{
+		writer: ansiterm.NewWriter(ctx.Stdout),
+		clear:  terminal,
+	}
+	if c.color {
+		frames.writer.SetColorCapable(true)
+	}
+	model := &statusModel{status: status, patterns: c.patterns}
+	render := func() error {
+		frame, err := c.renderTabular(model.status, controllerName, color)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		frames.write(frame)
+		return nil
+	}
+	if err := render(); err != nil {
+		return errors.Trace(err)
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	done := make(chan struct{})
+	defer close(done)
+	results := make(chan deltasResult)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case results <- deltasResult{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case result := <-results:
+			if params.IsCodeStopped(result.err) {
+				return nil
+			}
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			if !model.apply(result.deltas) {
+				logger.Debugf("fetching full status")
+				status, err := client.Status(c.patterns)
+				if status == nil {
+					if err == nil {
+						err = errors.New("unable to obtain the current status")
+					}
+					return errors.Annotate(err, "fetching status")
+				}
+				if err != nil {
+					logger.Warningf("fetching status: %v", err)
+				}
+				model.status = status
+			}
+			if err := render(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+type WatchSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func watchTestStatus() *params.FullStatus {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:     "default",
+			Type:     "iaas",
+			CloudTag: "cloud-dummy",
+			Version:  "2.4.0",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:             "0",
+				Series:         "xenial",
+				InstanceId:     "id-0",
+				DNSName:        "10.0.0.1",
+				AgentStatus:    params.DetailedStatus{Status: "started"},
+				InstanceStatus: params.DetailedStatus{Status: "running"},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:  "cs:xenial/mysql-1",
+				Series: "xenial",
+				Status: params.DetailedStatus{Status: "waiting"},
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						PublicAddress:  "10.0.0.1",
+						WorkloadStatus: params.DetailedStatus{Status: "waiting", Info: "installing"},
+						AgentStatus:    params.DetailedStatus{Status: "executing"},
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {
+								WorkloadStatus: params.DetailedStatus{Status: "active"},
+								AgentStatus:    params.DetailedStatus{Status: "idle"},
+							},
+						},
+					},
+				},
+			},
+			"logging": {
+				Charm:         "cs:xenial/logging-1",
+				Series:        "xenial",
+				SubordinateTo: []string{"mysql"},
+			},
+		},
+		Relations: []params.RelationStatus{{
+			Id:  0,
+			Key: "logging:info mysql:juju-info",
+		}},
+	}
+}
+
+func unitDelta(name string, workload status.Status, message string) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           name,
+			Application:    strings.Split(name, "/")[0],
+			CharmURL:       "cs:xenial/mysql-1",
+			MachineId:      "0",
+			PublicAddress:  "10.0.0.1",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: message},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+			PortRanges:     []multiwatcher.PortRange{{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}},
+		},
+	}
+}
+
+func (s *WatchSuite) TestApplyUnitChange(c *gc.C) {
+	model := &statusModel{status: watchTestStatus()}
+	ok := model.apply([]multiwatcher.Delta{unitDelta("mysql/0", status.Active, "ready")})
+	c.Assert(ok, jc.IsTrue)
+
+	unit := model.status.Applications["mysql"].Units["mysql/0"]
+	c.Check(unit.WorkloadStatus.Status, gc.Equals, "active")
+	c.Check(unit.WorkloadStatus.Info, gc.Equals, "ready")
+	c.Check(unit.AgentStatus.Status, gc.Equals, "idle")
+	c.Check(unit.OpenedPorts, jc.DeepEquals, []string{"3306/tcp"})
+	c.Check(unit.Charm, gc.Equals, "")
+	// What the delta doesn't know about is kept.
+	c.Check(unit.Subordinates, gc.HasLen, 1)
+}
+
+func (s *WatchSuite) TestApplyNewAndRemovedUnits(c *gc.C) {
+	model := &statusModel{status: watchTestStatus()}
+	removed := unitDelta("mysql/0", status.Active, "")
+	removed.Removed = true
+	ok := model.apply([]multiwatcher.Delta{
+		unitDelta("mysql/1", status.Maintenance, "installing"),
+		removed,
+	})
+	c.Assert(ok, jc.IsTrue)
+
+	units := model.status.Applications["mysql"].Units
+	c.Assert(units, gc.HasLen, 1)
+	c.Check(units["mysql/1"].WorkloadStatus.Status, gc.Equals, "maintenance")
+	c.Check(units["mysql/1"].Machine, gc.Equals, "0")
+}
+
+func (s *WatchSuite) TestApplySubordinate(c *gc.C) {
+	model := &statusModel{status: watchTestStatus()}
+	delta := unitDelta("logging/0", status.Blocked, "needs config")
+	delta.Entity.(*multiwatcher.UnitInfo).Subordinate = true
+	delta.Entity.(*multiwatcher.UnitInfo).CharmURL = "cs:xenial/logging-1"
+	c.Assert(model.apply([]multiwatcher.Delta{delta}), jc.IsTrue)
+
+	sub := model.status.Applications["mysql"].Units["mysql/0"].Subordinates["logging/0"]
+	c.Check(sub.WorkloadStatus.Status, gc.Equals, "blocked")
+	c.Check(sub.Charm, gc.Equals, "")
+
+	// A new subordinate's principal isn't known.
+	delta = unitDelta("logging/1", status.Active, "")
+	delta.Entity.(*multiwatcher.UnitInfo).Subordinate = true
+	c.Assert(model.apply([]multiwatcher.Delta{delta}), jc.IsFalse)
+}
+
+func (s *WatchSuite) TestApplyMachines(c *gc.C) {
+	model := &statusModel{status: watchTestStatus()}
+	ok := model.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:             "0/lxd/0",
+			InstanceId:     "juju-0-lxd-0",
+			Series:         "xenial",
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Pending},
+			InstanceStatus: multiwatcher.StatusInfo{Current: status.Provisioning},
+			Life:           "alive",
+			Addresses: []multiwatcher.Address{
+				{Value: "127.0.0.1", Scope: "local-machine"},
+				{Value: "10.0.0.2", Scope: "local-cloud"},
+				{Value: "54.0.0.2", Scope: "public"},
+			},
+		},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	container := model.status.Machines["0"].Containers["0/lxd/0"]
+	c.Check(container.InstanceId, gc.Equals, instance.Id("juju-0-lxd-0"))
+	c.Check(container.AgentStatus.Status, gc.Equals, "pending")
+	c.Check(container.AgentStatus.Life, gc.Equals, "")
+	c.Check(container.DNSName, gc.Equals, "54.0.0.2")
+	c.Check(container.IPAddresses, jc.DeepEquals, []string{"10.0.0.2", "54.0.0.2"})
+
+	// A container whose host isn't known needs the full status.
+	c.Assert(model.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{Id: "1/lxd/0"},
+	}}), jc.IsFalse)
+
+	ok = model.apply([]multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{Id: "0/lxd/0"},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	c.Check(model.status.Machines["0"].Containers, gc.HasLen, 0)
+}
+
+func (s *WatchSuite) TestApplyRelations(c *gc.C) {
+	model := &statusModel{status: watchTestStatus()}
+	existing := &multiwatcher.RelationInfo{Key: "logging:info mysql:juju-info"}
+	c.Check(model.apply([]multiwatcher.Delta{{Entity: existing}}), jc.IsTrue)
+
+	added := &multiwatcher.RelationInfo{
+		Key: "mysql:db wordpress:db",
+		Endpoints: []multiwatcher.Endpoint{
+			{ApplicationName: "mysql"},
+			{ApplicationName: "wordpress"},
+		},
+	}
+	c.Check(model.apply([]multiwatcher.Delta{{Entity: added}}), jc.IsFalse)
+	c.Check(model.apply([]multiwatcher.Delta{{Entity: existing, Removed: true}}), jc.IsFalse)
+}
+
+func (s *WatchSuite) TestApplyFiltered(c *gc.C) {
+	model := &statusModel{status: watchTestStatus(), patterns: []string{"mysql*"}}
+
+	// Entities that don't match the filters are ignored.
+	ok := model.apply([]multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}},
+		unitDelta("wordpress/0", status.Active, ""),
+	})
+	c.Assert(ok, jc.IsTrue)
+	c.Check(model.status.Applications, gc.HasLen, 2)
+
+	// New entities that do match need the full status.
+	c.Check(model.apply([]multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql-slave"}},
+	}), jc.IsFalse)
+	c.Check(model.apply([]multiwatcher.Delta{
+		unitDelta("mysql/1", status.Active, ""),
+	}), jc.IsFalse)
+}
+
+func (s *WatchSuite) TestFrameWriter(c *gc.C) {
+	var buf bytes.Buffer
+	w := &frameWriter{writer: ansiterm.NewWriter(&buf)}
+	w.write("Unit     Workload\nmysql/0  waiting\nmysql/1  waiting\n")
+	w.write("Unit     Workload\nmysql/0  waiting\nmysql/1  waiting\n")
+	w.write("Unit     Workload\nmysql/0  active\nmysql/1  waiting\nmysql/2  waiting\n")
+	c.Assert(buf.String(), gc.Equals, `
+  Unit     Workload
+  mysql/0  waiting
+  mysql/1  waiting
+
+  Unit     Workload
+* mysql/0  active
+  mysql/1  waiting
+* mysql/2  waiting
+`[1:])
+}
+
+type fakeStatusAPI struct {
+	testing.Stub
+	status *params.FullStatus
+}
+
+func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *fakeStatusAPI) WatchAll() (*api.AllWatcher, error) {
+	f.MethodCall(f, "WatchAll")
+	return nil, errors.NotImplementedf("WatchAll")
+}
+
+func (f *fakeStatusAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+type fakeAllWatcher struct {
+	deltas chan []multiwatcher.Delta
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	deltas, ok := <-w.deltas
+	if !ok {
+		return nil, &params.Error{Code: params.CodeStopped, Message: "watcher was stopped"}
+	}
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	return nil
+}
+
+func (s *WatchSuite) TestWatchLoop(c *gc.C) {
+	watcher := &fakeAllWatcher{deltas: make(chan []multiwatcher.Delta, 3)}
+	watcher.deltas <- []multiwatcher.Delta{unitDelta("mysql/0", status.Active, "ready")}
+	// A new relation means the full status is fetched again.
+	refreshed := watchTestStatus()
+	refreshed.Applications["mysql"].Units["mysql/0"] = params.UnitStatus{
+		Machine:        "0",
+		WorkloadStatus: params.DetailedStatus{Status: "active", Info: "related"},
+		AgentStatus:    params.DetailedStatus{Status: "idle"},
+	}
+	watcher.deltas <- []multiwatcher.Delta{{Entity: &multiwatcher.RelationInfo{Key: "mysql:db wordpress:db"}}}
+	close(watcher.deltas)
+	client := &fakeStatusAPI{status: refreshed}
+
+	command := &statusCommand{}
+	ctx := cmdtesting.Context(c)
+	err := command.watchLoop(ctx, client, watcher, watchTestStatus(), "kontroll")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, []testing.StubCall{{
+		FuncName: "Status",
+		Args:     []interface{}{[]string(nil)},
+	}})
+
+	frames := strings.Split(cmdtesting.Stdout(ctx), "\n\n  Model")
+	c.Assert(frames, gc.HasLen, 3)
+	c.Check(frames[0], gc.Matches, `(?s).*\n  mysql/0\s+waiting\s+executing\s+0\s+10\.0\.0\.1\s+installing\n.*`)
+	c.Check(frames[1], gc.Matches, `(?s).*\n\* mysql/0\s+active\s+idle\s+0\s+10\.0\.0\.1\s+3306/tcp\s+ready\n.*`)
+	c.Check(frames[2], gc.Matches, `(?s).*\n\* mysql/0\s+active\s+idle\s+0\s+related\n.*`)
+}
@@ -36,6 +36,9 @@ Detaches storage from units. Specify one or more unit/application storage IDs,
 as output by "juju storage". The storage will remain in the model until it is
 removed by an operator.
 
+Storage in a Kubernetes model is managed by Kubernetes along with
+the application's pods, and cannot be detached.
+
 Examples:
     juju detach-storage pgdata/0
 `
@@ -40,6 +40,9 @@ By default, remove-storage will fail if the storage
 is attached to any units. To override this behaviour,
 you can use "juju remove-storage --force".
 
+Storage in a Kubernetes model is managed by Kubernetes along with
+the application's pods, and cannot be removed.
+
 Examples:
     # Remove the detached storage pgdata/0.
     juju remove-storage pgdata/0
@@ -0,0 +1,20 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewWaitForCommandForTest(api WaitForAPI, clock clock.Clock) cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{
+		newAPIFunc: func() (WaitForAPI, error) {
+			return api, nil
+		},
+		clock: clock,
+	})
+}
@@ -0,0 +1,557 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+// valueType is the type of the value of a query expression.
+type valueType string
+
+const (
+	typeString valueType = "string"
+	typeInt    valueType = "int"
+	typeBool   valueType = "bool"
+)
+
+// schema describes the fields and collections that a query may refer
+// to in the scope of one kind of entity.
+type schema struct {
+	fields map[string]valueType
+	// collections maps the name of each collection to the kind of
+	// the entities in it.
+	collections map[string]string
+}
+
+var schemas = map[string]schema{
+	"model": {
+		fields: map[string]valueType{
+			"name":    typeString,
+			"life":    typeString,
+			"status":  typeString,
+			"message": typeString,
+		},
+		collections: map[string]string{
+			"applications": "application",
+			"units":        "unit",
+			"machines":     "machine",
+		},
+	},
+	"application": {
+		fields: map[string]valueType{
+			"name":    typeString,
+			"life":    typeString,
+			"status":  typeString,
+			"message": typeString,
+			"charm":   typeString,
+			"exposed": typeBool,
+		},
+		collections: map[string]string{
+			"units": "unit",
+		},
+	},
+	"unit": {
+		fields: map[string]valueType{
+			"name":             typeString,
+			"application":      typeString,
+			"machine":          typeString,
+			"workload-status":  typeString,
+			"workload-message": typeString,
+			"agent-status":     typeString,
+			"agent-message":    typeString,
+			"public-address":   typeString,
+			"subordinate":      typeBool,
+		},
+	},
+	"machine": {
+		fields: map[string]valueType{
+			"id":              typeString,
+			"life":            typeString,
+			"status":          typeString,
+			"message":         typeString,
+			"instance-status": typeString,
+			"instance-id":     typeString,
+			"series":          typeString,
+		},
+		collections: map[string]string{
+			"units": "unit",
+		},
+	},
+}
+
+// scope gives a query access to the fields and collections of the
+// entity it is evaluated against.
+type scope interface {
+	// field returns the value of the named field, which is a string,
+	// an int or a bool as described by the entity's schema.
+	field(name string) interface{}
+
+	// collection returns the scopes of the entities in the named
+	// collection.
+	collection(name string) []scope
+}
+
+// query is a parsed wait-for query.
+type query struct {
+	source string
+	expr   expr
+	// literals holds the string literals in the query.
+	literals map[string]bool
+}
+
+// parseQuery parses the source of a query to be evaluated against
+// entities of the given kind, checking that the fields it refers to
+// exist and that the result is a boolean.
+func parseQuery(source, kind string) (*query, error) {
+	p := &parser{
+		lexer:    lexer{input: source},
+		literals: make(map[string]bool),
+	}
+	if err := p.next(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	e, err := p.parseOr(schemas[kind])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if p.token.kind != tokenEOF {
+		return nil, p.errorf("unexpected %s", p.token)
+	}
+	if e.typ() != typeBool {
+		return nil, errors.Errorf("query must be a boolean expression, not %s", e.typ())
+	}
+	return &query{
+		source:   source,
+		expr:     e,
+		literals: p.literals,
+	}, nil
+}
+
+// eval reports whether the query is satisfied by the given scope.
+func (q *query) eval(s scope) bool {
+	return q.expr.eval(s).(bool)
+}
+
+// mentions reports whether the query contains the given string literal.
+func (q *query) mentions(literal string) bool {
+	return q.literals[literal]
+}
+
+// expr is a node of a parsed query. The parser checks the types of
+// the operands of each node, so evaluation cannot fail.
+type expr interface {
+	typ() valueType
+	eval(s scope) interface{}
+}
+
+type literal struct {
+	value interface{}
+	t     valueType
+}
+
+func (e literal) typ() valueType         { return e.t }
+func (e literal) eval(scope) interface{} { return e.value }
+
+type fieldRef struct {
+	name string
+	t    valueType
+}
+
+func (e fieldRef) typ() valueType           { return e.t }
+func (e fieldRef) eval(s scope) interface{} { return s.field(e.name) }
+
+type not struct {
+	x expr
+}
+
+func (e not) typ() valueType           { return typeBool }
+func (e not) eval(s scope) interface{} { return !e.x.eval(s).(bool) }
+
+type binary struct {
+	op   string
+	x, y expr
+}
+
+func (e binary) typ() valueType { return typeBool }
+
+func (e binary) eval(s scope) interface{} {
+	switch e.op {
+	case "&&":
+		return e.x.eval(s).(bool) && e.y.eval(s).(bool)
+	case "||":
+		return e.x.eval(s).(bool) || e.y.eval(s).(bool)
+	case "==":
+		return e.x.eval(s) == e.y.eval(s)
+	case "!=":
+		return e.x.eval(s) != e.y.eval(s)
+	}
+	x, y := e.x.eval(s), e.y.eval(s)
+	if e.x.typ() == typeString {
+		return compare(strings.Compare(x.(string), y.(string)), e.op)
+	}
+	xi, yi := x.(int), y.(int)
+	switch {
+	case xi < yi:
+		return compare(-1, e.op)
+	case xi > yi:
+		return compare(1, e.op)
+	}
+	return compare(0, e.op)
+}
+
+// compare returns the result of an ordering operator given the
+// comparison of its operands.
+func compare(cmp int, op string) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	panic(fmt.Sprintf("unknown operator %q", op))
+}
+
+// call is a call of one of the collection functions: all, any or
+// count. The body is evaluated in the scope of each entity of the
+// collection; count without a body counts all of them.
+type call struct {
+	fn         string
+	collection string
+	body       expr
+}
+
+func (e call) typ() valueType {
+	if e.fn == "count" {
+		return typeInt
+	}
+	return typeBool
+}
+
+func (e call) eval(s scope) interface{} {
+	items := s.collection(e.collection)
+	switch e.fn {
+	case "all":
+		for _, item := range items {
+			if !e.body.eval(item).(bool) {
+				return false
+			}
+		}
+		return true
+	case "any":
+		for _, item := range items {
+			if e.body.eval(item).(bool) {
+				return true
+			}
+		}
+		return false
+	}
+	if e.body == nil {
+		return len(items)
+	}
+	n := 0
+	for _, item := range items {
+		if e.body.eval(item).(bool) {
+			n++
+		}
+	}
+	return n
+}
+
+// parser is a recursive descent parser for queries. In order of
+// increasing precedence, the operators are ||, &&, the comparisons
+// and !.
+type parser struct {
+	lexer    lexer
+	token    token
+	literals map[string]bool
+}
+
+func (p *parser) next() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.token = t
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("at position %d: %s", p.token.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) isOp(ops ...string) bool {
+	if p.token.kind != tokenOp {
+		return false
+	}
+	for _, op := range ops {
+		if p.token.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if p.token.kind != kind || p.token.text != text {
+		return p.errorf("expected %q, found %s", text, p.token)
+	}
+	return p.next()
+}
+
+func (p *parser) parseOr(s schema) (expr, error) {
+	return p.parseLogical(s, "||", p.parseAnd)
+}
+
+func (p *parser) parseAnd(s schema) (expr, error) {
+	return p.parseLogical(s, "&&", p.parseComparison)
+}
+
+func (p *parser) parseLogical(s schema, op string, operand func(schema) (expr, error)) (expr, error) {
+	x, err := operand(s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for p.isOp(op) {
+		pos := p.token.pos
+		if err := p.next(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		y, err := operand(s)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if x.typ() != typeBool || y.typ() != typeBool {
+			return nil, errors.Errorf("at position %d: %s needs boolean operands, not %s and %s", pos+1, op, x.typ(), y.typ())
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseComparison(s schema) (expr, error) {
+	x, err := p.parseUnary(s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !p.isOp("==", "!=", "<", "<=", ">", ">=") {
+		return x, nil
+	}
+	op, pos := p.token.text, p.token.pos
+	if err := p.next(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	y, err := p.parseUnary(s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if x.typ() != y.typ() {
+		return nil, errors.Errorf("at position %d: cannot compare %s with %s", pos+1, x.typ(), y.typ())
+	}
+	if op != "==" && op != "!=" && x.typ() == typeBool {
+		return nil, errors.Errorf("at position %d: %s cannot compare booleans", pos+1, op)
+	}
+	return binary{op: op, x: x, y: y}, nil
+}
+
+func (p *parser) parseUnary(s schema) (expr, error) {
+	if !p.isOp("!") {
+		return p.parsePrimary(s)
+	}
+	pos := p.token.pos
+	if err := p.next(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	x, err := p.parseUnary(s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if x.typ() != typeBool {
+		return nil, errors.Errorf("at position %d: ! needs a boolean operand, not %s", pos+1, x.typ())
+	}
+	return not{x}, nil
+}
+
+func (p *parser) parsePrimary(s schema) (expr, error) {
+	t := p.token
+	switch t.kind {
+	case tokenString:
+		p.literals[t.text] = true
+		return literal{value: t.text, t: typeString}, p.next()
+	case tokenInt:
+		n, err := strconv.Atoi(t.text)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", t.text)
+		}
+		return literal{value: n, t: typeInt}, p.next()
+	case tokenOp:
+		if t.text != "(" {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		x, err := p.parseOr(s)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return x, p.expect(tokenOp, ")")
+	case tokenIdent:
+		if err := p.next(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if p.isOp("(") {
+			return p.parseCall(s, t)
+		}
+		switch t.text {
+		case "true":
+			return literal{value: true, t: typeBool}, nil
+		case "false":
+			return literal{value: false, t: typeBool}, nil
+		}
+		fieldType, ok := s.fields[t.text]
+		if !ok {
+			if _, ok := s.collections[t.text]; ok {
+				return nil, errors.Errorf("at position %d: collection %q can only be used with all, any or count", t.pos+1, t.text)
+			}
+			return nil, errors.Errorf("at position %d: unknown field %q", t.pos+1, t.text)
+		}
+		return fieldRef{name: t.text, t: fieldType}, nil
+	}
+	return nil, p.errorf("unexpected %s", t)
+}
+
+// parseCall parses the arguments of a call of the function named by
+// the given token, with the parser positioned on the opening
+// parenthesis.
+func (p *parser) parseCall(s schema, fn token) (expr, error) {
+	switch fn.text {
+	case "all", "any", "count":
+	default:
+		return nil, errors.Errorf("at position %d: unknown function %q", fn.pos+1, fn.text)
+	}
+	if err := p.next(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if p.token.kind != tokenIdent {
+		return nil, p.errorf("expected collection, found %s", p.token)
+	}
+	collection := p.token.text
+	kind, ok := s.collections[collection]
+	if !ok {
+		return nil, p.errorf("unknown collection %q", collection)
+	}
+	if err := p.next(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := call{fn: fn.text, collection: collection}
+	if fn.text == "count" && p.isOp(")") {
+		return c, p.next()
+	}
+	if err := p.expect(tokenOp, ","); err != nil {
+		return nil, errors.Trace(err)
+	}
+	pos := p.token.pos
+	body, err := p.parseOr(schemas[kind])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if body.typ() != typeBool {
+		return nil, errors.Errorf("at position %d: %s needs a boolean expression, not %s", pos+1, fn.text, body.typ())
+	}
+	c.body = body
+	return c, p.expect(tokenOp, ")")
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenInt
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lexer splits a query into tokens.
+type lexer struct {
+	input string
+	pos   int
+}
+
+// operators holds the operators and punctuation of the query
+// language, with the longer ones first so that they take precedence.
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", ",",
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos == len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+	c := l.input[l.pos]
+	switch {
+	case c == '"' || c == '\'':
+		end := strings.IndexByte(l.input[start+1:], c)
+		if end < 0 {
+			return token{}, errors.Errorf("at position %d: unterminated string", start+1)
+		}
+		l.pos = start + 1 + end + 1
+		return token{kind: tokenString, text: l.input[start+1 : l.pos-1], pos: start}, nil
+	case isDigit(c):
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenInt, text: l.input[start:l.pos], pos: start}, nil
+	case isLetter(c):
+		for l.pos < len(l.input) && (isLetter(l.input[l.pos]) || isDigit(l.input[l.pos]) || l.input[l.pos] == '-' || l.input[l.pos] == '_') {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.input[start:l.pos], pos: start}, nil
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(l.input[start:], op) {
+			l.pos += len(op)
+			return token{kind: tokenOp, text: op, pos: start}, nil
+		}
+	}
+	return token{}, errors.Errorf("at position %d: unexpected character %q", start+1, c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+type QuerySuite struct{}
+
+var _ = gc.Suite(&QuerySuite{})
+
+func (s *QuerySuite) store() *store {
+	st := newStore()
+	// The model's own fields are covered against a real
+	// AllWatcher by the wait-for feature tests.
+	st.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.ApplicationInfo{
+			Name:     "mysql",
+			Life:     "alive",
+			CharmURL: "cs:mysql-42",
+			Status:   multiwatcher.StatusInfo{Current: status.Active},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/1",
+			Application:    "mysql",
+			MachineId:      "1",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Maintenance},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Executing},
+		},
+	}, {
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "0",
+			Life:        "alive",
+			Series:      "bionic",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Started},
+		},
+	}})
+	return st
+}
+
+var evalTests = []struct {
+	kind   string
+	query  string
+	expect bool
+}{
+	{"model", `count(units) == 2`, true},
+	{"model", `count(applications) >= 1 && count(machines) < 1`, false},
+	{"model", `any(units, workload-status == "maintenance")`, true},
+	{"model", `all(units, workload-status == "active")`, false},
+	{"model", `count(units, agent-status == "idle") == 1`, true},
+	{"model", `all(machines, count(units) == 1)`, true},
+	{"application", `charm == "cs:mysql-42" && !exposed`, true},
+	{"application", `all(units, application == name)`, false},
+	{"unit", `workload-status == "active" && workload-message == "ready"`, true},
+	{"unit", `!(machine == "0") || subordinate`, false},
+	{"unit", `name < "mysql/1"`, true},
+	{"machine", `id == "0" && series == "bionic" && status == "started"`, true},
+	{"machine", `count(units, name == "mysql/0") == 1`, true},
+	{"model", `true || false && false`, true},
+	{"model", `(true || false) && false`, false},
+}
+
+func (s *QuerySuite) TestEval(c *gc.C) {
+	st := s.store()
+	targets := map[string]scope{
+		"model":       modelScope{st},
+		"application": applicationScope{st, st.applications["mysql"]},
+		"unit":        unitScope{st.units["mysql/0"]},
+		"machine":     machineScope{st, st.machines["0"]},
+	}
+	for i, test := range evalTests {
+		c.Logf("test %d: %s %s", i, test.kind, test.query)
+		q, err := parseQuery(test.query, test.kind)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(q.eval(targets[test.kind]), gc.Equals, test.expect)
+	}
+}
+
+var parseErrorTests = []struct {
+	kind  string
+	query string
+	err   string
+}{
+	{"unit", ``, `at position 1: unexpected end of query`},
+	{"unit", `name`, `query must be a boolean expression, not string`},
+	{"unit", `life == "alive"`, `at position 1: unknown field "life"`},
+	{"unit", `count(units) > 0`, `at position 7: unknown collection "units"`},
+	{"model", `units == 1`, `at position 1: collection "units" can only be used with all, any or count`},
+	{"model", `every(units, true)`, `at position 1: unknown function "every"`},
+	{"model", `all(units)`, `at position 10: expected ",", found "\)"`},
+	{"model", `all(units, name)`, `at position 12: all needs a boolean expression, not string`},
+	{"model", `count(units) == "3"`, `at position 14: cannot compare int with string`},
+	{"model", `true < false`, `at position 6: < cannot compare booleans`},
+	{"model", `name && true`, `at position 6: && needs boolean operands, not string and bool`},
+	{"model", `!name`, `at position 1: ! needs a boolean operand, not string`},
+	{"model", `name == "default`, `at position 9: unterminated string`},
+	{"model", `name == default`, `at position 9: unknown field "default"`},
+	{"model", `name = "default"`, `at position 6: unexpected character '='`},
+	{"model", `(true`, `at position 6: expected "\)", found end of query`},
+	{"model", `true true`, `at position 6: unexpected "true"`},
+}
+
+func (s *QuerySuite) TestParseErrors(c *gc.C) {
+	for i, test := range parseErrorTests {
+		c.Logf("test %d: %s %s", i, test.kind, test.query)
+		_, err := parseQuery(test.query, test.kind)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *QuerySuite) TestMentions(c *gc.C) {
+	q, err := parseQuery(`workload-status == "error" || agent-status == 'idle'`, "unit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(q.mentions("error"), jc.IsTrue)
+	c.Check(q.mentions("idle"), jc.IsTrue)
+	c.Check(q.mentions("active"), jc.IsFalse)
+}
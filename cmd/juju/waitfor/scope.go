@@ -0,0 +1,228 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"sort"
+
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// store holds the entities of a model as reported by the deltas of an
+// AllWatcher.
+type store struct {
+	model        *multiwatcher.ModelInfo
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+}
+
+func newStore() *store {
+	return &store{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+	}
+}
+
+// apply updates the store with the given deltas.
+func (s *store) apply(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if delta.Removed {
+				s.model = nil
+			} else {
+				s.model = info
+			}
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(s.applications, info.Name)
+			} else {
+				s.applications[info.Name] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(s.units, info.Name)
+			} else {
+				s.units[info.Name] = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(s.machines, info.Id)
+			} else {
+				s.machines[info.Id] = info
+			}
+		}
+	}
+}
+
+// unitsWhere returns the units for which the given function returns
+// true, ordered by name.
+func (s *store) unitsWhere(f func(*multiwatcher.UnitInfo) bool) []*multiwatcher.UnitInfo {
+	var units []*multiwatcher.UnitInfo
+	for _, unit := range s.units {
+		if f(unit) {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Name < units[j].Name
+	})
+	return units
+}
+
+func unitScopes(units []*multiwatcher.UnitInfo) []scope {
+	scopes := make([]scope, len(units))
+	for i, unit := range units {
+		scopes[i] = unitScope{unit}
+	}
+	return scopes
+}
+
+type modelScope struct {
+	store *store
+}
+
+func (m modelScope) field(name string) interface{} {
+	info := m.store.model
+	switch name {
+	case "name":
+		return info.Name
+	case "life":
+		return string(info.Life)
+	case "status":
+		return info.Status.Current.String()
+	case "message":
+		return info.Status.Message
+	}
+	return nil
+}
+
+func (m modelScope) collection(name string) []scope {
+	var scopes []scope
+	switch name {
+	case "applications":
+		names := make([]string, 0, len(m.store.applications))
+		for name := range m.store.applications {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			scopes = append(scopes, applicationScope{m.store, m.store.applications[name]})
+		}
+	case "units":
+		scopes = unitScopes(m.store.unitsWhere(func(*multiwatcher.UnitInfo) bool {
+			return true
+		}))
+	case "machines":
+		ids := make([]string, 0, len(m.store.machines))
+		for id := range m.store.machines {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			scopes = append(scopes, machineScope{m.store, m.store.machines[id]})
+		}
+	}
+	return scopes
+}
+
+type applicationScope struct {
+	store *store
+	info  *multiwatcher.ApplicationInfo
+}
+
+func (a applicationScope) field(name string) interface{} {
+	switch name {
+	case "name":
+		return a.info.Name
+	case "life":
+		return string(a.info.Life)
+	case "status":
+		return a.info.Status.Current.String()
+	case "message":
+		return a.info.Status.Message
+	case "charm":
+		return a.info.CharmURL
+	case "exposed":
+		return a.info.Exposed
+	}
+	return nil
+}
+
+func (a applicationScope) collection(name string) []scope {
+	if name != "units" {
+		return nil
+	}
+	return unitScopes(a.store.unitsWhere(func(unit *multiwatcher.UnitInfo) bool {
+		return unit.Application == a.info.Name
+	}))
+}
+
+type unitScope struct {
+	info *multiwatcher.UnitInfo
+}
+
+func (u unitScope) field(name string) interface{} {
+	switch name {
+	case "name":
+		return u.info.Name
+	case "application":
+		return u.info.Application
+	case "machine":
+		return u.info.MachineId
+	case "workload-status":
+		return u.info.WorkloadStatus.Current.String()
+	case "workload-message":
+		return u.info.WorkloadStatus.Message
+	case "agent-status":
+		return u.info.AgentStatus.Current.String()
+	case "agent-message":
+		return u.info.AgentStatus.Message
+	case "public-address":
+		return u.info.PublicAddress
+	case "subordinate":
+		return u.info.Subordinate
+	}
+	return nil
+}
+
+func (u unitScope) collection(string) []scope {
+	return nil
+}
+
+type machineScope struct {
+	store *store
+	info  *multiwatcher.MachineInfo
+}
+
+func (m machineScope) field(name string) interface{} {
+	switch name {
+	case "id":
+		return m.info.Id
+	case "life":
+		return string(m.info.Life)
+	case "status":
+		return m.info.AgentStatus.Current.String()
+	case "message":
+		return m.info.AgentStatus.Message
+	case "instance-status":
+		return m.info.InstanceStatus.Current.String()
+	case "instance-id":
+		return m.info.InstanceId
+	case "series":
+		return m.info.Series
+	}
+	return nil
+}
+
+func (m machineScope) collection(name string) []scope {
+	if name != "units" {
+		return nil
+	}
+	return unitScopes(m.store.unitsWhere(func(unit *multiwatcher.UnitInfo) bool {
+		return unit.MachineId == m.info.Id
+	}))
+}
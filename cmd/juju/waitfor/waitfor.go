@@ -0,0 +1,368 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+var waitForHelpSummary = `
+Waits for a model, application, unit or machine to reach a condition.`[1:]
+
+var waitForHelpDetails = `
+Blocks until the query given with --query is satisfied by the named
+entity, as reported by the model's status. The query is evaluated each
+time the model changes, and the command exits successfully as soon as
+it is true.
+
+The command fails if the timeout expires first, if the entity is
+removed after it has been seen, or if a relevant unit or machine goes
+into an error state and the query doesn't itself mention "error". An
+entity that doesn't exist yet is waited for.
+
+Queries compare fields with ==, !=, <, <=, > and >=, and combine the
+comparisons with &&, || and !. Strings are quoted with either double or
+single quotes. The functions all(collection, query) and
+any(collection, query) evaluate a query against the entities of a
+collection, and count(collection[, query]) counts them.
+
+The fields of a model are name, life, status and message, and its
+collections are applications, units and machines.
+
+The fields of an application are name, life, status, message, charm
+and exposed, and its collection is units.
+
+The fields of a unit are name, application, machine, workload-status,
+workload-message, agent-status, agent-message, public-address and
+subordinate.
+
+The fields of a machine are id, life, status, message, instance-status,
+instance-id and series, and its collection is units.
+
+Without --query, the command waits for all the units of a model or
+application, or the unit itself, to be active and idle, or for the
+machine to be started.
+
+Examples:
+    juju wait-for model default
+    juju wait-for application mysql --query 'count(units) >= 3'
+    juju wait-for unit mysql/0 --query 'workload-status == "active"'
+    juju wait-for machine 0 --query 'status == "started"' --timeout 5m
+    juju wait-for model default --query 'any(units, workload-status == "error")'
+
+See also:
+    status
+    show-status-log`
+
+// defaultQueries holds the query used for each kind of entity when
+// none is given.
+var defaultQueries = map[string]string{
+	"model":       `all(units, workload-status == "active" && agent-status == "idle")`,
+	"application": `count(units) > 0 && all(units, workload-status == "active" && agent-status == "idle")`,
+	"unit":        `workload-status == "active" && agent-status == "idle"`,
+	"machine":     `status == "started"`,
+}
+
+// NewWaitForCommand returns a command that waits for an entity in a
+// model to satisfy a query.
+func NewWaitForCommand() cmd.Command {
+	cmd := &waitForCommand{clock: clock.WallClock}
+	cmd.newAPIFunc = func() (WaitForAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return apiClient{client}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+	kind        string
+	name        string
+	queryString string
+	timeout     time.Duration
+
+	query *query
+	seen  bool
+
+	newAPIFunc func() (WaitForAPI, error)
+	clock      clock.Clock
+}
+
+// WaitForAPI defines the API methods that the wait-for command uses.
+type WaitForAPI interface {
+	Close() error
+	WatchAll() (AllWatcher, error)
+}
+
+// AllWatcher defines the methods of an api.AllWatcher that the
+// wait-for command uses.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// apiClient adapts an api.Client to the WaitForAPI interface.
+type apiClient struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitForAPI interface.
+func (c apiClient) WatchAll() (AllWatcher, error) {
+	watcher, err := c.Client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+// Info implements cmd.Command.
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "model|application|unit|machine <name>",
+		Purpose: waitForHelpSummary,
+		Doc:     waitForHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.queryString, "query", "", "The condition to wait for")
+	f.DurationVar(&c.timeout, "timeout", 10*time.Minute, "How long to wait before giving up")
+}
+
+// Init implements cmd.Command.
+func (c *waitForCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no entity kind specified")
+	case 1:
+		return errors.Errorf("no %s name specified", args[0])
+	}
+	c.kind, c.name = args[0], args[1]
+	switch c.kind {
+	case "model":
+		if err := c.SetModelName(c.name, false); err != nil {
+			return errors.Trace(err)
+		}
+	case "application":
+		if !names.IsValidApplication(c.name) {
+			return errors.NotValidf("application name %q", c.name)
+		}
+	case "unit":
+		if !names.IsValidUnit(c.name) {
+			return errors.NotValidf("unit name %q", c.name)
+		}
+	case "machine":
+		if !names.IsValidMachine(c.name) {
+			return errors.NotValidf("machine id %q", c.name)
+		}
+	default:
+		return errors.Errorf("entity kind %q not valid: expected model, application, unit or machine", c.kind)
+	}
+	if c.timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	queryString := c.queryString
+	if queryString == "" {
+		queryString = defaultQueries[c.kind]
+	}
+	query, err := parseQuery(queryString, c.kind)
+	if err != nil {
+		return errors.Annotate(err, "invalid query")
+	}
+	c.query = query
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "watching model")
+	}
+	defer watcher.Stop()
+	return c.waitLoop(ctx, watcher)
+}
+
+type deltasResult struct {
+	deltas []multiwatcher.Delta
+	err    error
+}
+
+func (c *waitForCommand) waitLoop(ctx *cmd.Context, watcher AllWatcher) error {
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	done := make(chan struct{})
+	defer close(done)
+	results := make(chan deltasResult)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case results <- deltasResult{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	timeout := c.clock.After(c.timeout)
+	store := newStore()
+	for {
+		select {
+		case <-interrupted:
+			return errors.Errorf("interrupted while waiting for %s", c.description())
+		case <-timeout:
+			return errors.Errorf("timed out after %v waiting for %s", c.timeout, c.description())
+		case result := <-results:
+			if params.IsCodeStopped(result.err) {
+				return errors.Errorf("model watcher stopped while waiting for %s", c.description())
+			}
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			store.apply(result.deltas)
+			satisfied, err := c.check(store)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if satisfied {
+				ctx.Infof("%s satisfies %s", c.description(), c.query.source)
+				return nil
+			}
+		}
+	}
+}
+
+// check reports whether the target entity satisfies the query. It
+// returns an error if the query can no longer be satisfied.
+func (c *waitForCommand) check(store *store) (bool, error) {
+	target := c.target(store)
+	if target == nil {
+		if c.seen {
+			return false, errors.Errorf("%s was removed", c.description())
+		}
+		return false, nil
+	}
+	c.seen = true
+	if c.query.eval(target) {
+		return true, nil
+	}
+	if c.query.mentions(status.Error.String()) {
+		return false, nil
+	}
+	return false, errors.Trace(c.checkErrors(store))
+}
+
+// target returns the scope of the entity being waited for, or nil if
+// it doesn't exist.
+func (c *waitForCommand) target(store *store) scope {
+	switch c.kind {
+	case "model":
+		if store.model != nil {
+			return modelScope{store}
+		}
+	case "application":
+		if info, ok := store.applications[c.name]; ok {
+			return applicationScope{store, info}
+		}
+	case "unit":
+		if info, ok := store.units[c.name]; ok {
+			return unitScope{info}
+		}
+	case "machine":
+		if info, ok := store.machines[c.name]; ok {
+			return machineScope{store, info}
+		}
+	}
+	return nil
+}
+
+// checkErrors returns an error if a unit or machine relevant to the
+// target entity is in an error state, which would stop it from
+// reaching the condition waited for without intervention.
+func (c *waitForCommand) checkErrors(store *store) error {
+	var machines []*multiwatcher.MachineInfo
+	var units []*multiwatcher.UnitInfo
+	switch c.kind {
+	case "model":
+		for _, machine := range store.machines {
+			machines = append(machines, machine)
+		}
+		sort.Slice(machines, func(i, j int) bool {
+			return machines[i].Id < machines[j].Id
+		})
+		units = store.unitsWhere(func(*multiwatcher.UnitInfo) bool {
+			return true
+		})
+	case "application":
+		units = store.unitsWhere(func(unit *multiwatcher.UnitInfo) bool {
+			return unit.Application == c.name
+		})
+	case "unit":
+		units = []*multiwatcher.UnitInfo{store.units[c.name]}
+	case "machine":
+		machines = []*multiwatcher.MachineInfo{store.machines[c.name]}
+	}
+	for _, machine := range machines {
+		if machine.AgentStatus.Current == status.Error {
+			return inError("machine", machine.Id, machine.AgentStatus)
+		}
+		if machine.InstanceStatus.Current == status.ProvisioningError {
+			return inError("machine", machine.Id, machine.InstanceStatus)
+		}
+	}
+	for _, unit := range units {
+		if unit.WorkloadStatus.Current == status.Error {
+			return inError("unit", unit.Name, unit.WorkloadStatus)
+		}
+		if unit.AgentStatus.Current == status.Error {
+			return inError("unit", unit.Name, unit.AgentStatus)
+		}
+	}
+	return nil
+}
+
+func inError(kind, name string, info multiwatcher.StatusInfo) error {
+	message := fmt.Sprintf("%s %q is in error", kind, name)
+	if info.Message != "" {
+		message += ": " + info.Message
+	}
+	return errors.New(message)
+}
+
+func (c *waitForCommand) description() string {
+	return fmt.Sprintf("%s %q", c.kind, c.name)
+}
@@ -0,0 +1,208 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WaitForSuite struct {
+	coretesting.BaseSuite
+
+	clock   *jtesting.Clock
+	watcher *fakeAllWatcher
+	api     *fakeWaitForAPI
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = jtesting.NewClock(time.Now())
+	s.watcher = newFakeAllWatcher()
+	s.api = &fakeWaitForAPI{watcher: s.watcher}
+}
+
+func (s *WaitForSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, waitfor.NewWaitForCommandForTest(s.api, s.clock), args...)
+}
+
+func unit(name string, workload, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           name,
+			Application:    "mysql",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: "hook failed"},
+			AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+		},
+	}
+}
+
+func (s *WaitForSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no entity kind specified"},
+		{[]string{"unit"}, "no unit name specified"},
+		{[]string{"relation", "foo"}, `entity kind "relation" not valid: expected model, application, unit or machine`},
+		{[]string{"application", "mysql/0"}, `application name "mysql/0" not valid`},
+		{[]string{"unit", "mysql"}, `unit name "mysql" not valid`},
+		{[]string{"machine", "mysql"}, `machine id "mysql" not valid`},
+		{[]string{"unit", "mysql/0", "--timeout", "0s"}, "timeout must be positive"},
+		{[]string{"unit", "mysql/0", "--query", "count(units) > 0"}, `invalid query: at position 7: unknown collection "units"`},
+		{[]string{"unit", "mysql/0", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WaitForSuite) TestSatisfied(c *gc.C) {
+	s.watcher.send(unit("mysql/0", status.Maintenance, status.Executing))
+	s.watcher.send(unit("mysql/0", status.Active, status.Idle))
+
+	ctx, err := s.run(c, "unit", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		`unit "mysql/0" satisfies workload-status == "active" && agent-status == "idle"`+"\n")
+	c.Check(s.watcher.stopped(), jc.IsTrue)
+	s.api.CheckCallNames(c, "WatchAll", "Close")
+}
+
+func (s *WaitForSuite) TestWaitsForEntityToAppear(c *gc.C) {
+	s.watcher.send(unit("mysql/0", status.Active, status.Idle))
+	s.watcher.send(multiwatcher.Delta{
+		Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"},
+	})
+
+	_, err := s.run(c, "application", "wordpress", "--query", "count(units) == 0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitForSuite) TestRemoved(c *gc.C) {
+	s.watcher.send(unit("mysql/0", status.Maintenance, status.Executing))
+	removed := unit("mysql/0", status.Maintenance, status.Executing)
+	removed.Removed = true
+	s.watcher.send(removed)
+
+	_, err := s.run(c, "unit", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" was removed`)
+}
+
+func (s *WaitForSuite) TestUnitInError(c *gc.C) {
+	s.watcher.send(
+		multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		unit("mysql/0", status.Active, status.Idle),
+		unit("mysql/1", status.Error, status.Idle),
+	)
+
+	_, err := s.run(c, "application", "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/1" is in error: hook failed`)
+}
+
+func (s *WaitForSuite) TestQueryForError(c *gc.C) {
+	s.watcher.send(unit("mysql/0", status.Maintenance, status.Executing))
+	s.watcher.send(unit("mysql/0", status.Error, status.Idle))
+
+	_, err := s.run(c, "unit", "mysql/0", "--query", `workload-status == "error"`)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitForSuite) TestTimeout(c *gc.C) {
+	s.watcher.send(unit("mysql/0", status.Maintenance, status.Executing))
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.run(c, "unit", "mysql/0", "--timeout", "5m")
+		errc <- err
+	}()
+	err := s.clock.WaitAdvance(5*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, `timed out after 5m0s waiting for unit "mysql/0"`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+}
+
+func (s *WaitForSuite) TestWatchAllError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "model", "default")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
+type fakeWaitForAPI struct {
+	jtesting.Stub
+	watcher *fakeAllWatcher
+}
+
+func (f *fakeWaitForAPI) WatchAll() (waitfor.AllWatcher, error) {
+	f.MethodCall(f, "WatchAll")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.watcher, nil
+}
+
+func (f *fakeWaitForAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+type fakeAllWatcher struct {
+	deltas   chan []multiwatcher.Delta
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newFakeAllWatcher() *fakeAllWatcher {
+	return &fakeAllWatcher{
+		deltas: make(chan []multiwatcher.Delta, 10),
+		stop:   make(chan struct{}),
+	}
+}
+
+func (w *fakeAllWatcher) send(deltas ...multiwatcher.Delta) {
+	w.deltas <- deltas
+}
+
+func (w *fakeAllWatcher) stopped() bool {
+	select {
+	case <-w.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas := <-w.deltas:
+		return deltas, nil
+	case <-w.stop:
+		return nil, &params.Error{Code: params.CodeStopped}
+	}
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	return nil
+}
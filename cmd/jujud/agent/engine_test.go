@@ -125,6 +125,7 @@ var (
 		"storage-provisioner",
 		"unconverted-api-workers",
 		"unit-agent-deployer",
+		"upgrade-series",
 	}
 )
 
@@ -51,6 +51,7 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
@@ -295,6 +296,7 @@ func NewMachineAgent(
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
 		preUpgradeSteps:             preUpgradeSteps,
+		tracer:                      tracing.NewTracer(names.NewMachineTag(machineId).String(), clock.WallClock),
 	}
 	if err := a.registerPrometheusCollectors(); err != nil {
 		return nil, errors.Trace(err)
@@ -356,6 +358,11 @@ type MachineAgent struct {
 	mongoDialCollector         *mongometrics.DialCollector
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
 
+	// tracer records spans for API requests, state transactions
+	// and provider calls; it only exports them when the apiserver
+	// worker has tracing enabled.
+	tracer *tracing.Tracer
+
 	// Only API servers have hubs. This is temporary until the apiserver and
 	// peergrouper have manifolds.
 	centralHub *pubsub.StructuredHub
@@ -545,6 +552,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			ValidateMigration:    a.validateMigration,
 			PrometheusRegisterer: a.prometheusRegistry,
 			CentralHub:           a.centralHub,
+			Tracer:               a.tracer,
 			PubSubReporter:       pubsubReporter,
 			UpdateLoggerConfig:   updateAgentConfLogging,
 			NewAgentStatusSetter: func(apiConn api.Connection) (upgradesteps.StatusSetter, error) {
@@ -795,6 +803,7 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, error) {
 		// to pass in the max-txn-log-size value.
 		InitDatabaseFunc:       state.InitDatabase,
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		Tracer:                 a.tracer,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -942,6 +951,7 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 			stateenvirons.GetNewEnvironFunc(environs.New),
 		),
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		Tracer:                 a.tracer,
 	})
 	return ctlr, nil
 }
@@ -964,6 +974,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 		agentConfig,
 		dialOpts,
 		a.mongoTxnCollector.AfterRunTransaction,
+		a.tracer,
 	)
 	if err != nil {
 		return nil, err
@@ -997,6 +1008,7 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 	manifoldsCfg := model.ManifoldsConfig{
 		Agent:                       modelAgent,
 		AgentConfigChanged:          a.configChangedVal,
+		IsControllerModel:           modelUUID == a.CurrentConfig().Model().Id(),
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
@@ -1006,6 +1018,7 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
 		NewMigrationMaster:          migrationmaster.NewWorker,
+		Tracer:                      a.tracer,
 	}
 	var manifolds dependency.Manifolds
 	if modelType == state.ModelTypeIAAS {
@@ -1069,6 +1082,7 @@ func openState(
 	agentConfig agent.Config,
 	dialOpts mongo.DialOpts,
 	runTransactionObserver state.RunTransactionObserverFunc,
+	tracer *tracing.Tracer,
 ) (_ *state.State, _ *state.Machine, err error) {
 	info, ok := agentConfig.MongoInfo()
 	if !ok {
@@ -1089,6 +1103,7 @@ func openState(
 			stateenvirons.GetNewEnvironFunc(environs.New),
 		),
 		RunTransactionObserver: runTransactionObserver,
+		Tracer:                 tracer,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"path/filepath"
 	"time"
 
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -21,7 +22,9 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
@@ -75,6 +78,11 @@ type ManifoldsConfig struct {
 	// is updated
 	AgentConfigChanged *voyeur.Value
 
+	// IsControllerModel is true if the manifolds administer the
+	// controller model, whose log forwarder also forwards the
+	// controller's audit log.
+	IsControllerModel bool
+
 	// Clock supplies timing services to any manifolds that need them.
 	// Only a few workers have been converted to use them fo far.
 	Clock clock.Clock
@@ -111,6 +119,10 @@ type ManifoldsConfig struct {
 	// NewMigrationMaster is called to create a new migrationmaster
 	// worker.
 	NewMigrationMaster func(migrationmaster.Config) (worker.Worker, error)
+
+	// Tracer records the provisioner's calls to the cloud provider,
+	// when the controller has tracing enabled.
+	Tracer *tracing.Tracer
 }
 
 // commonManifolds returns a set of interdependent dependency manifolds that will
@@ -120,6 +132,25 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 	agentConfig := config.Agent.CurrentConfig()
 	machineTag := agentConfig.Tag().(names.MachineTag)
 	modelTag := agentConfig.Model()
+	var auditSinks logforwarder.LogSinkRegistry
+	if config.IsControllerModel {
+		auditSinks = logforwarder.LogSinkRegistry{
+			logfwd.SinkTypeSyslog: {
+				Name:   "juju-audit-forward",
+				OpenFn: sinks.OpenSyslog,
+			},
+			logfwd.SinkTypeHTTP: {
+				Name:   "juju-audit-forward-http",
+				OpenFn: sinks.OpenHTTP,
+			},
+			logfwd.SinkTypeFile: {
+				Name: "juju-audit-forward-file",
+				OpenFn: sinks.NewFileOpener(filepath.Join(
+					agentConfig.LogDir(), "auditforward.log",
+				)),
+			},
+		}
+	}
 	result := dependency.Manifolds{
 
 		// The first group are foundational; the agent and clock
@@ -223,15 +254,6 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		// that it happens sometimes, even when we try to avoid
 		// it.
 
-		// The undertaker is currently the only ifNotAlive worker.
-		undertakerName: ifNotUpgrading(ifNotAlive(undertaker.Manifold(undertaker.ManifoldConfig{
-			APICallerName: apiCallerName,
-			EnvironName:   environTrackerName,
-
-			NewFacade: undertaker.NewFacade,
-			NewWorker: undertaker.NewWorker,
-		}))),
-
 		charmRevisionUpdaterName: ifNotMigrating(charmrevisionmanifold.Manifold(charmrevisionmanifold.ManifoldConfig{
 			APICallerName: apiCallerName,
 			ClockName:     clockName,
@@ -269,10 +291,23 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
-			Sinks: []logforwarder.LogSinkSpec{{
-				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
-			}},
+			Sinks: logforwarder.LogSinkRegistry{
+				logfwd.SinkTypeSyslog: {
+					Name:   "juju-log-forward",
+					OpenFn: sinks.OpenSyslog,
+				},
+				logfwd.SinkTypeHTTP: {
+					Name:   "juju-log-forward-http",
+					OpenFn: sinks.OpenHTTP,
+				},
+				logfwd.SinkTypeFile: {
+					Name: "juju-log-forward-file",
+					OpenFn: sinks.NewFileOpener(filepath.Join(
+						agentConfig.LogDir(), "logforward-"+modelTag.Id()+".log",
+					)),
+				},
+			},
+			AuditSinks: auditSinks,
 		})),
 		// The model upgrader runs on all controller agents, and
 		// unlocks the gate when the model is up-to-date. The
@@ -304,6 +339,15 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewEnvironFunc: config.NewEnvironFunc,
 		})),
 
+		// The undertaker is currently the only ifNotAlive worker.
+		undertakerName: ifNotUpgrading(ifNotAlive(undertaker.Manifold(undertaker.ManifoldConfig{
+			APICallerName:      apiCallerName,
+			CloudDestroyerName: environTrackerName,
+
+			NewFacade: undertaker.NewFacade,
+			NewWorker: undertaker.NewWorker,
+		}))),
+
 		// Everything else should be wrapped in ifResponsible,
 		// ifNotAlive, ifNotDead, or ifNotMigrating (which also
 		// implies NotDead), to ensure that only a single
@@ -332,6 +376,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:          agentName,
 			APICallerName:      apiCallerName,
 			EnvironName:        environTrackerName,
+			Tracer:             config.Tracer,
 			NewProvisionerFunc: provisioner.NewEnvironProvisioner,
 		})),
 		storageProvisionerName: ifNotMigrating(storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
@@ -399,6 +444,16 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:          apiCallerName,
 			NewContainerBrokerFunc: config.NewContainerBrokerFunc,
 		})),
+
+		// The undertaker removes the model's namespace, and
+		// everything in it, once the model is dead.
+		undertakerName: ifNotUpgrading(ifNotAlive(undertaker.Manifold(undertaker.ManifoldConfig{
+			APICallerName:      apiCallerName,
+			CloudDestroyerName: caasBrokerTrackerName,
+
+			NewFacade: undertaker.NewFacade,
+			NewWorker: undertaker.NewWorker,
+		}))),
 		caasFirewallerName: ifNotMigrating(caasfirewaller.Manifold(
 			caasfirewaller.ManifoldConfig{
 				APICallerName: apiCallerName,
@@ -144,6 +144,18 @@ func (s *ManifoldsSuite) TestStateCleanerIgnoresLifeFlags(c *gc.C) {
 	c.Check(inputs.Contains("not-dead-flag"), jc.IsFalse)
 }
 
+func (s *ManifoldsSuite) TestCAASUndertakerUsesBroker(c *gc.C) {
+	manifolds := model.CAASManifolds(model.ManifoldsConfig{
+		Agent: &mockAgent{},
+	})
+	manifold, found := manifolds["undertaker"]
+	c.Assert(found, jc.IsTrue)
+
+	inputs := set.NewStrings(manifold.Inputs...)
+	c.Check(inputs.Contains("caas-broker-tracker"), jc.IsTrue)
+	c.Check(inputs.Contains("environ-tracker"), jc.IsFalse)
+}
+
 func (s *ManifoldsSuite) TestClockWrapper(c *gc.C) {
 	expectClock := &fakeClock{}
 	manifolds := model.IAASManifolds(model.ManifoldsConfig{
@@ -20,6 +20,15 @@ import (
 	"github.com/juju/juju/cert"
 )
 
+const (
+	// TracingExporterNone disables tracing.
+	TracingExporterNone = "none"
+	// TracingExporterFile writes spans to a file in the log directory.
+	TracingExporterFile = "file"
+	// TracingExporterZipkin posts spans to a Zipkin-compatible collector.
+	TracingExporterZipkin = "zipkin"
+)
+
 const (
 	// MongoProfLow represents the most conservative mongo memory profile.
 	MongoProfLow = "low"
@@ -104,6 +113,17 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// TracingExporter sets where the controller sends the trace spans
+	// it records for API requests, transactions and provider calls:
+	// "none", "file" (trace.log in the log directory) or "zipkin"
+	// (the collector at TracingCollectorURL).
+	TracingExporter = "tracing-exporter"
+
+	// TracingCollectorURL is the URL of the Zipkin-compatible collector
+	// to which spans are posted when the tracing exporter is "zipkin",
+	// eg "http://zipkin.example.com:9411/api/v2/spans".
+	TracingCollectorURL = "tracing-collector-url"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -145,6 +165,10 @@ const (
 	// DefaultMaxTxnLogCollectionMB is the maximum size the txn log collection.
 	DefaultMaxTxnLogCollectionMB = 10 // 10 MB
 
+	// DefaultTracingExporter is the default for the TracingExporter
+	// setting (which is not to record spans).
+	DefaultTracingExporter = TracingExporterNone
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -179,8 +203,29 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		TracingExporter,
+		TracingCollectorURL,
 	}
 
+	// AllowedUpdateConfigAttributes contains all of the controller
+	// config attributes that are allowed to be updated after the
+	// controller has been created. The others are baked into agent
+	// configuration, cloud firewall rules or the database itself at
+	// bootstrap.
+	AllowedUpdateConfigAttributes = set.NewStrings(
+		AuditingEnabled,
+		AuditLogCaptureArgs,
+		AuditLogMaxSize,
+		AuditLogMaxBackups,
+		AuditLogExcludeMethods,
+		MaxLogsAge,
+		MaxLogsSize,
+		JujuHASpace,
+		JujuManagementSpace,
+		TracingExporter,
+		TracingCollectorURL,
+	)
+
 	// DefaultAuditLogExcludeMethods is the default list of methods to
 	// exclude from the audit log.
 	DefaultAuditLogExcludeMethods = []string{
@@ -372,6 +417,21 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// TracingExporter returns where trace spans are sent: one of
+// "none", "file" or "zipkin".
+func (c Config) TracingExporter() string {
+	if v, ok := c[TracingExporter]; ok {
+		return v.(string)
+	}
+	return DefaultTracingExporter
+}
+
+// TracingCollectorURL returns the URL of the collector to which trace
+// spans are posted when the tracing exporter is "zipkin".
+func (c Config) TracingCollectorURL() string {
+	return c.asString(TracingCollectorURL)
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -581,6 +641,35 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := validateTracingConfig(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func validateTracingConfig(c Config) error {
+	switch exporter := c.TracingExporter(); exporter {
+	case TracingExporterNone, TracingExporterFile:
+	case TracingExporterZipkin:
+		if c.TracingCollectorURL() == "" {
+			return errors.Errorf("invalid tracing config: %s must be set for the %q exporter", TracingCollectorURL, exporter)
+		}
+	default:
+		return errors.Errorf(
+			"invalid tracing exporter %q: should be one of %q, %q or %q",
+			exporter, TracingExporterNone, TracingExporterFile, TracingExporterZipkin,
+		)
+	}
+	if v := c.TracingCollectorURL(); v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid tracing collector URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid tracing collector URL %q: scheme must be http or https", v)
+		}
+	}
 	return nil
 }
 
@@ -626,6 +715,8 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxTxnLogSize:           schema.String(),
 	JujuHASpace:             schema.String(),
 	JujuManagementSpace:     schema.String(),
+	TracingExporter:         schema.String(),
+	TracingCollectorURL:     schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
@@ -646,4 +737,6 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
 	JujuHASpace:             schema.Omit,
 	JujuManagementSpace:     schema.Omit,
+	TracingExporter:         DefaultTracingExporter,
+	TracingCollectorURL:     schema.Omit,
 })
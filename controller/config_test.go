@@ -186,6 +186,35 @@ var validateTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names, got "Sharon Jones" at position 2`,
+}, {
+	about: "invalid tracing exporter",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.TracingExporter: "jaeger",
+	},
+	expectError: `invalid tracing exporter "jaeger": should be one of "none", "file" or "zipkin"`,
+}, {
+	about: "zipkin tracing exporter requires collector URL",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.TracingExporter: "zipkin",
+	},
+	expectError: `invalid tracing config: tracing-collector-url must be set for the "zipkin" exporter`,
+}, {
+	about: "invalid tracing collector URL",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.TracingExporter:     "zipkin",
+		controller.TracingCollectorURL: "zipkin.example.com:9411",
+	},
+	expectError: `invalid tracing collector URL "zipkin.example.com:9411": scheme must be http or https`,
+}, {
+	about: "zipkin tracing exporter OK",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.TracingExporter:     "zipkin",
+		controller.TracingCollectorURL: "http://zipkin.example.com:9411/api/v2/spans",
+	},
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -301,6 +330,27 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 	))
 }
 
+func (s *ConfigSuite) TestTracingConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingExporter(), gc.Equals, "none")
+	c.Assert(cfg.TracingCollectorURL(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestTracingConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"tracing-exporter":      "zipkin",
+			"tracing-collector-url": "https://zipkin.example.com/api/v2/spans",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingExporter(), gc.Equals, "zipkin")
+	c.Assert(cfg.TracingCollectorURL(), gc.Equals, "https://zipkin.example.com/api/v2/spans")
+}
+
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
 	_, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -10,6 +10,11 @@ import (
 	"gopkg.in/juju/environschema.v1"
 )
 
+// TrustConfigOptionName is the name of the application config option
+// which grants the application's units access to the model's cloud
+// credential.
+const TrustConfigOptionName = "trust"
+
 // ConfigAttributes is the config for an application.
 type ConfigAttributes map[string]interface{}
 
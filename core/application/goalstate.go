@@ -0,0 +1,32 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+)
+
+// GoalStateStatus holds the status of a unit as reported by the
+// goal-state hook tool.
+type GoalStateStatus struct {
+	Status string     `json:"status" yaml:"status"`
+	Since  *time.Time `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+// UnitsGoalState holds the goal state status of units, keyed on
+// unit name.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState describes the units that are expected to exist for an
+// application and for each of the applications related to it.
+type GoalState struct {
+	// Units holds the expected units of the unit's own application.
+	Units UnitsGoalState `json:"units" yaml:"units"`
+
+	// Relations holds the expected units of the related applications,
+	// keyed on the name of the local endpoint. Applications offered
+	// from other models have no visible units; they are reported
+	// by application name instead.
+	Relations map[string]UnitsGoalState `json:"relations" yaml:"relations"`
+}
@@ -238,6 +238,62 @@ func (a *auditLogFile) addRecord(r Record) error {
 	return errors.Trace(err)
 }
 
+type teeLog struct {
+	logs []AuditLog
+}
+
+// NewTee returns an audit entry sink which writes each entry to all
+// of the given sinks. An error writing to one sink doesn't stop the
+// entry being written to the others.
+func NewTee(logs ...AuditLog) AuditLog {
+	return &teeLog{logs: logs}
+}
+
+// AddConversation implements AuditLog.
+func (t *teeLog) AddConversation(c Conversation) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (t *teeLog) AddRequest(m Request) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddRequest(m)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (t *teeLog) AddResponse(m ResponseErrors) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddResponse(m)
+	})
+}
+
+// Close implements AuditLog.
+func (t *teeLog) Close() error {
+	return t.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+// each calls f for every sink, returning the first error.
+func (t *teeLog) each(f func(AuditLog) error) error {
+	var result error
+	for _, log := range t.logs {
+		err := f(log)
+		if err == nil {
+			continue
+		}
+		if result == nil {
+			result = errors.Trace(err)
+		} else {
+			logger.Errorf("audit log error: %v", err)
+		}
+	}
+	return result
+}
+
 func idString(id uint64) string {
 	return fmt.Sprintf("%X", id)
 }
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -137,6 +138,26 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestTee(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(nil, errors.New("kaboom"))
+	tee := auditlog.NewTee(&log1, &log2)
+
+	err := tee.AddConversation(auditlog.Conversation{ConversationID: "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = tee.AddRequest(auditlog.Request{ConversationID: "abc", RequestID: 1})
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+	err = tee.AddResponse(auditlog.ResponseErrors{ConversationID: "abc", RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = tee.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, log := range []*fakeLog{&log1, &log2} {
+		log.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse", "Close")
+		log.stub.CheckCall(c, 1, "AddRequest", auditlog.Request{ConversationID: "abc", RequestID: 1})
+	}
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
@@ -0,0 +1,182 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logfields supports attaching structured labels and key/value
+// fields to messages logged with loggo.
+//
+// Loggo entries only carry free-text messages, so the labels and
+// fields are kept out of the entry altogether: loggo writers see the
+// message exactly as it was formatted. While loggo passes an entry
+// logged with Logf to its writers, those that record structured data,
+// such as the agent's buffered log writer, retrieve the labels and
+// fields with DataFor, so that they can be stored and filtered on by
+// the controller.
+package logfields
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// Well-known field names.
+const (
+	Unit       = "unit"
+	Hook       = "hook"
+	Action     = "action"
+	ActionID   = "action-id"
+	RelationID = "relation-id"
+	ContextID  = "context-id"
+	RequestID  = "request-id"
+)
+
+// validName matches valid label and field names. Names are used as
+// part of database keys, so they are deliberately restricted.
+var validName = regexp.MustCompile(`^[a-z0-9]+([-_][a-z0-9]+)*$`)
+
+// Data holds the structured labels and key/value fields attached to a
+// log message.
+type Data struct {
+	Labels []string          `json:"labels,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// IsEmpty reports whether the data holds no labels or fields.
+func (d Data) IsEmpty() bool {
+	return len(d.Labels) == 0 && len(d.Fields) == 0
+}
+
+// Validate returns an error if any of the labels or field names are
+// not valid.
+func (d Data) Validate() error {
+	for _, label := range d.Labels {
+		if !validName.MatchString(label) {
+			return errors.NotValidf("label %q", label)
+		}
+	}
+	for name := range d.Fields {
+		if !validName.MatchString(name) {
+			return errors.NotValidf("field name %q", name)
+		}
+	}
+	return nil
+}
+
+// String returns the labels and fields in a human readable form, with
+// the fields sorted by name, for example "[upgrade] hook=install".
+func (d Data) String() string {
+	var parts []string
+	if len(d.Labels) > 0 {
+		parts = append(parts, "["+strings.Join(d.Labels, ",")+"]")
+	}
+	names := make([]string, 0, len(d.Fields))
+	for name := range d.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, name+"="+d.Fields[name])
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseField parses a field given as "name=value".
+func ParseField(s string) (name, value string, err error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return "", "", errors.Errorf("expected name=value, got %q", s)
+	}
+	name, value = parts[0], parts[1]
+	if !validName.MatchString(name) {
+		return "", "", errors.NotValidf("field name %q", name)
+	}
+	return name, value, nil
+}
+
+// entryKey identifies a log entry while it is being written. Only
+// a Logf call site can log an entry with a given location, so the
+// location, level and message are enough to tell its entries apart
+// from any others being written at the same time.
+type entryKey struct {
+	filename string
+	line     int
+	level    loggo.Level
+	message  string
+}
+
+// pending holds the data attached to the entries that Logf is in the
+// middle of writing.
+var (
+	pendingMu sync.Mutex
+	pending   = make(map[entryKey][]Data)
+)
+
+func addPending(key entryKey, data Data) {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	pending[key] = append(pending[key], data)
+}
+
+func removePending(key entryKey) {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	if datas := pending[key]; len(datas) > 1 {
+		pending[key] = datas[:len(datas)-1]
+	} else {
+		delete(pending, key)
+	}
+}
+
+// DataFor returns the labels and fields attached to the given entry
+// by Logf. It must be called by a loggo writer from its Write method,
+// while the entry is being written; entries not logged with Logf, or
+// logged without any data, have empty data.
+func DataFor(entry loggo.Entry) Data {
+	key := entryKey{
+		filename: entry.Filename,
+		line:     entry.Line,
+		level:    entry.Level,
+		message:  entry.Message,
+	}
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	datas := pending[key]
+	if len(datas) == 0 {
+		return Data{}
+	}
+	return datas[len(datas)-1]
+}
+
+// Logf logs a formatted message at the given level, with the given
+// labels and fields available to writers through DataFor.
+func Logf(logger loggo.Logger, level loggo.Level, data Data, format string, args ...interface{}) {
+	if !logger.IsLevelEnabled(level) {
+		return
+	}
+	message := fmt.Sprintf(format, args...)
+	if data.IsEmpty() {
+		logger.LogCallf(2, level, "%s", message)
+		return
+	}
+	// This is the location loggo records for the entry.
+	_, filename, line, ok := runtime.Caller(1)
+	if !ok {
+		filename = "???"
+		line = 0
+	}
+	key := entryKey{
+		filename: filename,
+		line:     line,
+		level:    level,
+		message:  message,
+	}
+	addPending(key, data)
+	defer removePending(key)
+	logger.LogCallf(2, level, "%s", message)
+}
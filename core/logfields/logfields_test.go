@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfields_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logfields"
+)
+
+type LogFieldsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LogFieldsSuite{})
+
+func (s *LogFieldsSuite) TestString(c *gc.C) {
+	data := logfields.Data{
+		Labels: []string{"upgrade", "slow"},
+		Fields: map[string]string{"unit": "mysql/0", "hook": "install"},
+	}
+	c.Assert(data.String(), gc.Equals, "[upgrade,slow] hook=install unit=mysql/0")
+	c.Assert(logfields.Data{}.String(), gc.Equals, "")
+}
+
+func (s *LogFieldsSuite) TestValidate(c *gc.C) {
+	c.Assert(logfields.Data{
+		Labels: []string{"ok", "also-ok"},
+		Fields: map[string]string{"relation-id": "db:1"},
+	}.Validate(), jc.ErrorIsNil)
+	c.Assert(logfields.Data{Labels: []string{"Bad"}}.Validate(), gc.ErrorMatches, `label "Bad" not valid`)
+	c.Assert(logfields.Data{Fields: map[string]string{"a.b": "c"}}.Validate(), gc.ErrorMatches, `field name "a.b" not valid`)
+}
+
+func (s *LogFieldsSuite) TestParseField(c *gc.C) {
+	name, value, err := logfields.ParseField("hook=config-changed")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "hook")
+	c.Assert(value, gc.Equals, "config-changed")
+
+	name, value, err = logfields.ParseField("note=a=b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "note")
+	c.Assert(value, gc.Equals, "a=b")
+
+	_, _, err = logfields.ParseField("hook")
+	c.Assert(err, gc.ErrorMatches, `expected name=value, got "hook"`)
+	_, _, err = logfields.ParseField("$bad=x")
+	c.Assert(err, gc.ErrorMatches, `field name "\$bad" not valid`)
+}
+
+// dataWriter is a loggo writer which records the
+// data attached to each entry written to it.
+type dataWriter struct {
+	entries []loggo.Entry
+	data    []logfields.Data
+}
+
+func (w *dataWriter) Write(entry loggo.Entry) {
+	w.entries = append(w.entries, entry)
+	w.data = append(w.data, logfields.DataFor(entry))
+}
+
+func (s *LogFieldsSuite) TestLogf(c *gc.C) {
+	var tw loggo.TestWriter
+	var dw dataWriter
+	context := loggo.NewContext(loggo.DEBUG)
+	c.Assert(context.AddWriter("test", &tw), jc.ErrorIsNil)
+	c.Assert(context.AddWriter("data", &dw), jc.ErrorIsNil)
+	logger := context.GetLogger("test")
+
+	data := logfields.Data{Fields: map[string]string{"hook": "install"}}
+	logfields.Logf(logger, loggo.INFO, data, "hello %s", "world")
+	logfields.Logf(logger, loggo.TRACE, data, "not logged")
+	logger.Infof("hello world")
+
+	// Plain writers see the message alone.
+	entries := tw.Log()
+	c.Assert(entries, gc.HasLen, 2)
+	c.Assert(entries[0].Level, gc.Equals, loggo.INFO)
+	c.Assert(entries[0].Message, gc.Equals, "hello world")
+	c.Assert(entries[0].Filename, gc.Matches, ".*logfields_test.go")
+
+	// Writers asking for the data get it for the entry
+	// logged with Logf, and only for that entry.
+	c.Assert(dw.entries, gc.HasLen, 2)
+	c.Assert(dw.entries[0].Message, gc.Equals, "hello world")
+	c.Assert(dw.data, jc.DeepEquals, []logfields.Data{data, {}})
+}
+
+func (s *LogFieldsSuite) TestDataForAfterLogf(c *gc.C) {
+	var tw loggo.TestWriter
+	context := loggo.NewContext(loggo.DEBUG)
+	c.Assert(context.AddWriter("test", &tw), jc.ErrorIsNil)
+	logger := context.GetLogger("test")
+
+	data := logfields.Data{Labels: []string{"upgrade"}}
+	logfields.Logf(logger, loggo.INFO, data, "hello")
+
+	// The data is only available while the entry is written.
+	entries := tw.Log()
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(logfields.DataFor(entries[0]).IsEmpty(), jc.IsTrue)
+}
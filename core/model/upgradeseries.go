@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/errors"
+)
+
+// UpgradeSeriesStatus describes the progress of a machine, or of a unit
+// on that machine, through a managed series upgrade.
+type UpgradeSeriesStatus string
+
+func (s UpgradeSeriesStatus) String() string {
+	return string(s)
+}
+
+const (
+	// UpgradeSeriesNotStarted indicates that no series upgrade is in
+	// progress.
+	UpgradeSeriesNotStarted UpgradeSeriesStatus = "not started"
+
+	// UpgradeSeriesPrepareStarted indicates that the upgrade has been
+	// requested, and the pre-series-upgrade hooks are being run.
+	UpgradeSeriesPrepareStarted UpgradeSeriesStatus = "prepare started"
+
+	// UpgradeSeriesPrepareCompleted indicates that the machine is ready
+	// for the operating system to be upgraded.
+	UpgradeSeriesPrepareCompleted UpgradeSeriesStatus = "prepare completed"
+
+	// UpgradeSeriesCompleteStarted indicates that the operating system
+	// has been upgraded, and the post-series-upgrade hooks are being run.
+	UpgradeSeriesCompleteStarted UpgradeSeriesStatus = "complete started"
+
+	// UpgradeSeriesCompleted indicates that the series upgrade has
+	// finished.
+	UpgradeSeriesCompleted UpgradeSeriesStatus = "completed"
+
+	// UpgradeSeriesError indicates that the series upgrade failed and
+	// requires intervention.
+	UpgradeSeriesError UpgradeSeriesStatus = "error"
+)
+
+// Validate returns an error if the status is not known.
+func (s UpgradeSeriesStatus) Validate() error {
+	switch s {
+	case UpgradeSeriesNotStarted,
+		UpgradeSeriesPrepareStarted,
+		UpgradeSeriesPrepareCompleted,
+		UpgradeSeriesCompleteStarted,
+		UpgradeSeriesCompleted,
+		UpgradeSeriesError:
+		return nil
+	}
+	return errors.NotValidf("upgrade series status %q", s)
+}
+
+// Blocking returns true if a unit in this status must not run any hooks
+// other than the series upgrade hooks.
+func (s UpgradeSeriesStatus) Blocking() bool {
+	switch s {
+	case UpgradeSeriesPrepareStarted,
+		UpgradeSeriesPrepareCompleted,
+		UpgradeSeriesCompleteStarted,
+		UpgradeSeriesError:
+		return true
+	}
+	return false
+}
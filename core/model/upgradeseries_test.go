@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (*UpgradeSeriesSuite) TestValidateValid(c *gc.C) {
+	for i, test := range []model.UpgradeSeriesStatus{
+		model.UpgradeSeriesNotStarted,
+		model.UpgradeSeriesPrepareStarted,
+		model.UpgradeSeriesPrepareCompleted,
+		model.UpgradeSeriesCompleteStarted,
+		model.UpgradeSeriesCompleted,
+		model.UpgradeSeriesError,
+	} {
+		c.Logf("test %d: %s", i, test)
+		c.Check(test.Validate(), jc.ErrorIsNil)
+	}
+}
+
+func (*UpgradeSeriesSuite) TestValidateInvalid(c *gc.C) {
+	for i, test := range []model.UpgradeSeriesStatus{
+		"", "bad", "Completed", " completed",
+	} {
+		c.Logf("test %d: %s", i, test)
+		err := test.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `upgrade series status ".*" not valid`)
+	}
+}
+
+func (*UpgradeSeriesSuite) TestBlocking(c *gc.C) {
+	for status, blocking := range map[model.UpgradeSeriesStatus]bool{
+		model.UpgradeSeriesNotStarted:       false,
+		model.UpgradeSeriesPrepareStarted:   true,
+		model.UpgradeSeriesPrepareCompleted: true,
+		model.UpgradeSeriesCompleteStarted:  true,
+		model.UpgradeSeriesCompleted:        false,
+		model.UpgradeSeriesError:            true,
+	} {
+		c.Check(status.Blocking(), gc.Equals, blocking, gc.Commentf("status %q", status))
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"gopkg.in/tomb.v1"
+)
+
+// fileExporter writes each span to a file as a line of JSON.
+type fileExporter struct {
+	mu     sync.Mutex
+	writer io.WriteCloser
+}
+
+// NewFileExporter returns an exporter that writes spans to the file
+// at the given path, one line of JSON per span. maxSize is the size
+// (in megabytes) the file may reach before it's rotated, and
+// maxBackups is the number of old compressed files to keep (or 0 to
+// keep all of them).
+func NewFileExporter(path string, maxSize, maxBackups int) (Exporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating trace file")
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &fileExporter{
+		writer: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			Compress:   true,
+		},
+	}, nil
+}
+
+// Export is part of the Exporter interface.
+func (e *fileExporter) Export(span SpanData) {
+	data, err := json.Marshal(span)
+	if err != nil {
+		logger.Errorf("encoding span: %v", err)
+		return
+	}
+	// Write the line in one call, in case lumberjack rotates the
+	// file between writes.
+	data = append(data, '\n')
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, err := e.writer.Write(data); err != nil {
+		logger.Errorf("writing span: %v", err)
+	}
+}
+
+// Close is part of the Exporter interface.
+func (e *fileExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return errors.Trace(e.writer.Close())
+}
+
+const (
+	// maxPendingSpans is the number of spans the HTTP exporter
+	// will hold before it starts dropping them.
+	maxPendingSpans = 1000
+
+	// maxBatchSize is the most spans the HTTP exporter will send
+	// in one request.
+	maxBatchSize = 100
+
+	// maxErrorBody is the most of a failed response's body that is
+	// logged.
+	maxErrorBody = 1024
+)
+
+// Doer exposes the underlying functionality needed by the HTTP
+// exporter.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// HTTPExporterConfig holds the configuration for an HTTP exporter.
+type HTTPExporterConfig struct {
+	// URL is the collector endpoint to which spans are posted,
+	// such as "http://zipkin.example.com:9411/api/v2/spans".
+	URL string
+
+	// Doer is used to send the requests.
+	Doer Doer
+
+	// Clock is used to time the batches.
+	Clock clock.Clock
+
+	// FlushInterval is the longest a span will wait before it's
+	// sent to the collector.
+	FlushInterval time.Duration
+}
+
+// Validate validates the HTTP exporter configuration.
+func (config HTTPExporterConfig) Validate() error {
+	if config.URL == "" {
+		return errors.NotValidf("empty URL")
+	}
+	if config.Doer == nil {
+		return errors.NotValidf("nil Doer")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	return nil
+}
+
+// httpExporter posts spans to a Zipkin-compatible collector, in
+// batches, from a background goroutine.
+type httpExporter struct {
+	tomb   tomb.Tomb
+	config HTTPExporterConfig
+	spans  chan SpanData
+}
+
+// NewHTTPExporter returns an exporter that posts spans to a Zipkin
+// compatible collector. Spans are sent at least every flush interval;
+// if the collector can't keep up, spans are dropped rather than
+// delaying the operations being traced.
+func NewHTTPExporter(config HTTPExporterConfig) (Exporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	e := &httpExporter{
+		config: config,
+		spans:  make(chan SpanData, maxPendingSpans),
+	}
+	go func() {
+		defer e.tomb.Done()
+		e.tomb.Kill(e.loop())
+	}()
+	return e, nil
+}
+
+// Export is part of the Exporter interface.
+func (e *httpExporter) Export(span SpanData) {
+	select {
+	case e.spans <- span:
+	default:
+		logger.Debugf("dropping span %s/%s: too many pending spans", span.TraceID, span.ID)
+	}
+}
+
+// Close is part of the Exporter interface.
+func (e *httpExporter) Close() error {
+	e.tomb.Kill(nil)
+	return errors.Trace(e.tomb.Wait())
+}
+
+func (e *httpExporter) loop() error {
+	var batch []SpanData
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.post(batch); err != nil {
+			logger.Warningf("dropping %d spans: %v", len(batch), err)
+		}
+		batch = nil
+	}
+	// drain adds any spans waiting in the channel to the batch.
+	drain := func() {
+		for {
+			select {
+			case span := <-e.spans:
+				batch = append(batch, span)
+				if len(batch) >= maxBatchSize {
+					flush()
+				}
+			default:
+				return
+			}
+		}
+	}
+	timer := e.config.Clock.NewTimer(e.config.FlushInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-e.tomb.Dying():
+			// Send whatever is left, so spans aren't lost when the
+			// exporter is replaced.
+			drain()
+			flush()
+			return tomb.ErrDying
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) >= maxBatchSize {
+				flush()
+			}
+		case <-timer.Chan():
+			drain()
+			flush()
+			timer.Reset(e.config.FlushInterval)
+		}
+	}
+}
+
+func (e *httpExporter) post(spans []SpanData) error {
+	body, err := json.Marshal(spans)
+	if err != nil {
+		return errors.Annotate(err, "encoding spans")
+	}
+	req, err := http.NewRequest("POST", e.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.config.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending spans")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return errors.Errorf("sending spans: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ExporterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ExporterSuite{})
+
+func makeSpan(id string) tracing.SpanData {
+	return tracing.SpanData{
+		TraceID:       "0123456789abcdef0123456789abcdef",
+		ID:            id,
+		Name:          "Client.FullStatus",
+		Kind:          tracing.KindServer,
+		Timestamp:     1525168800000000,
+		Duration:      1500,
+		LocalEndpoint: tracing.Endpoint{ServiceName: "machine-0"},
+	}
+}
+
+func (s *ExporterSuite) TestFileExporter(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "trace.log")
+	exporter, err := tracing.NewFileExporter(path, 10, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	exporter.Export(makeSpan("0000000000000001"))
+	exporter.Export(makeSpan("0000000000000002"))
+	err = exporter.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+{"traceId":"0123456789abcdef0123456789abcdef","id":"0000000000000001","name":"Client.FullStatus","kind":"SERVER","timestamp":1525168800000000,"duration":1500,"localEndpoint":{"serviceName":"machine-0"}}
+{"traceId":"0123456789abcdef0123456789abcdef","id":"0000000000000002","name":"Client.FullStatus","kind":"SERVER","timestamp":1525168800000000,"duration":1500,"localEndpoint":{"serviceName":"machine-0"}}
+`[1:])
+}
+
+func (s *ExporterSuite) TestHTTPExporterConfigValidate(c *gc.C) {
+	valid := tracing.HTTPExporterConfig{
+		URL:           "http://zipkin.example.com:9411/api/v2/spans",
+		Doer:          http.DefaultClient,
+		Clock:         testing.NewClock(time.Time{}),
+		FlushInterval: time.Second,
+	}
+	c.Assert(valid.Validate(), jc.ErrorIsNil)
+	for i, test := range []struct {
+		modify func(*tracing.HTTPExporterConfig)
+		err    string
+	}{{
+		modify: func(cfg *tracing.HTTPExporterConfig) { cfg.URL = "" },
+		err:    "empty URL not valid",
+	}, {
+		modify: func(cfg *tracing.HTTPExporterConfig) { cfg.Doer = nil },
+		err:    "nil Doer not valid",
+	}, {
+		modify: func(cfg *tracing.HTTPExporterConfig) { cfg.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		modify: func(cfg *tracing.HTTPExporterConfig) { cfg.FlushInterval = 0 },
+		err:    "non-positive FlushInterval not valid",
+	}} {
+		c.Logf("test %d", i)
+		cfg := valid
+		test.modify(&cfg)
+		_, err := tracing.NewHTTPExporter(cfg)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ExporterSuite) TestHTTPExporter(c *gc.C) {
+	bodies := make(chan []tracing.SpanData, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/api/v2/spans")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		var spans []tracing.SpanData
+		err := json.NewDecoder(req.Body).Decode(&spans)
+		c.Check(err, jc.ErrorIsNil)
+		bodies <- spans
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	clock := testing.NewClock(time.Time{})
+	exporter, err := tracing.NewHTTPExporter(tracing.HTTPExporterConfig{
+		URL:           server.URL + "/api/v2/spans",
+		Doer:          http.DefaultClient,
+		Clock:         clock,
+		FlushInterval: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer exporter.Close()
+
+	exporter.Export(makeSpan("0000000000000001"))
+	exporter.Export(makeSpan("0000000000000002"))
+
+	// The spans are sent together once the flush interval has passed.
+	err = clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case spans := <-bodies:
+		c.Assert(spans, jc.DeepEquals, []tracing.SpanData{
+			makeSpan("0000000000000001"),
+			makeSpan("0000000000000002"),
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for spans")
+	}
+}
+
+func (s *ExporterSuite) TestHTTPExporterFlushesOnClose(c *gc.C) {
+	var received []tracing.SpanData
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var spans []tracing.SpanData
+		err := json.NewDecoder(req.Body).Decode(&spans)
+		c.Check(err, jc.ErrorIsNil)
+		received = append(received, spans...)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	exporter, err := tracing.NewHTTPExporter(tracing.HTTPExporterConfig{
+		URL:           server.URL,
+		Doer:          http.DefaultClient,
+		Clock:         testing.NewClock(time.Time{}),
+		FlushInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	exporter.Export(makeSpan("0000000000000001"))
+	err = exporter.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, []tracing.SpanData{makeSpan("0000000000000001")})
+}
@@ -0,0 +1,238 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracing records spans describing the work done to handle a
+// request, so that a request can be followed from the client through
+// the API server and into the database and the cloud provider.
+//
+// Spans are written in the Zipkin v2 JSON format, either to a local
+// file or to a remote collector. The trace context of an API request
+// is carried in the RPC header; work that isn't started by a request,
+// such as provisioning, starts a trace of its own.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+)
+
+var logger = loggo.GetLogger("juju.core.tracing")
+
+// Kind describes the role a span plays in a remote call.
+type Kind string
+
+const (
+	// KindNone is used for spans that don't describe a remote call,
+	// such as state transactions.
+	KindNone Kind = ""
+
+	// KindClient is used for the client side of a remote call.
+	KindClient Kind = "CLIENT"
+
+	// KindServer is used for the server side of a remote call.
+	KindServer Kind = "SERVER"
+)
+
+// SpanContext identifies a span, and the trace it belongs to. It is
+// the part of a span that is propagated between processes.
+type SpanContext struct {
+	// TraceID identifies the trace, as 32 hex digits.
+	TraceID string
+
+	// SpanID identifies the span within the trace, as 16 hex digits.
+	SpanID string
+}
+
+// IsValid returns whether the context identifies a span.
+func (ctx SpanContext) IsValid() bool {
+	return ctx.TraceID != "" && ctx.SpanID != ""
+}
+
+// spanContextKey is the key under which a SpanContext
+// is stored in a context.Context.
+type spanContextKey struct{}
+
+// NewContext returns a copy of ctx that carries the span context, so
+// that work done further down the call stack can be recorded as part
+// of the same trace.
+func NewContext(ctx context.Context, spanContext SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, spanContext)
+}
+
+// FromContext returns the span context carried by ctx, or the zero
+// value if there is none.
+func FromContext(ctx context.Context) SpanContext {
+	spanContext, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return spanContext
+}
+
+// Endpoint describes the service that recorded a span.
+type Endpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+// SpanData holds a finished span, in the Zipkin v2 JSON format so
+// that it can be sent to any compatible collector.
+type SpanData struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Kind          Kind              `json:"kind,omitempty"`
+	Timestamp     int64             `json:"timestamp"` // microseconds since the epoch
+	Duration      int64             `json:"duration"`  // microseconds
+	LocalEndpoint Endpoint          `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// Exporter sends finished spans to somewhere they can be examined.
+// Export must not block for long; implementations that send spans
+// over the network should batch them in the background.
+type Exporter interface {
+	// Export records the finished span.
+	Export(SpanData)
+
+	// Close flushes any spans not yet sent and releases any
+	// resources held by the exporter.
+	Close() error
+}
+
+// Tracer starts spans on behalf of a service, and passes them to its
+// exporter when they're finished. A nil *Tracer, or one without an
+// exporter, starts nil spans; all the methods on a nil *Span do
+// nothing, so callers don't need to check whether tracing is enabled.
+type Tracer struct {
+	service string
+	clock   clock.Clock
+
+	mu       sync.Mutex
+	exporter Exporter
+}
+
+// NewTracer returns a tracer that records spans for the named
+// service. Spans aren't recorded until an exporter is set.
+func NewTracer(service string, clock clock.Clock) *Tracer {
+	return &Tracer{
+		service: service,
+		clock:   clock,
+	}
+}
+
+// SetExporter sets the exporter to which finished spans are passed;
+// a nil exporter disables tracing. Spans that have already been
+// started are still passed to the exporter they started with.
+func (t *Tracer) SetExporter(exporter Exporter) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.exporter = exporter
+}
+
+func (t *Tracer) getExporter() Exporter {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.exporter
+}
+
+// StartSpan starts a span with the given name. If parent is valid, the
+// span is part of the parent's trace; otherwise it starts a new trace.
+// StartSpan returns nil if the tracer has no exporter.
+func (t *Tracer) StartSpan(name string, kind Kind, parent SpanContext) *Span {
+	exporter := t.getExporter()
+	if exporter == nil {
+		return nil
+	}
+	start := t.clock.Now()
+	data := SpanData{
+		TraceID:       parent.TraceID,
+		ID:            newID(8),
+		ParentID:      parent.SpanID,
+		Name:          name,
+		Kind:          kind,
+		Timestamp:     start.UnixNano() / int64(time.Microsecond),
+		LocalEndpoint: Endpoint{ServiceName: t.service},
+	}
+	if !parent.IsValid() {
+		data.TraceID = newID(16)
+		data.ParentID = ""
+	}
+	return &Span{
+		clock:    t.clock,
+		exporter: exporter,
+		start:    start,
+		data:     data,
+	}
+}
+
+// Span records a single operation, such as an API request or a
+// database transaction. A span must only be used by one goroutine.
+type Span struct {
+	clock    clock.Clock
+	exporter Exporter
+	start    time.Time
+	data     SpanData
+	finished bool
+}
+
+// Context returns the context that identifies the span, or the zero
+// value if s is nil.
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return SpanContext{
+		TraceID: s.data.TraceID,
+		SpanID:  s.data.ID,
+	}
+}
+
+// SetTag records a key-value pair describing the span's operation.
+// Empty values aren't recorded.
+func (s *Span) SetTag(key, value string) {
+	if s == nil || value == "" {
+		return
+	}
+	if s.data.Tags == nil {
+		s.data.Tags = make(map[string]string)
+	}
+	s.data.Tags[key] = value
+}
+
+// Finish records the end of the span, and passes it to the exporter.
+// If err is not nil, it is recorded in the span's "error" tag. Only
+// the first call to Finish has any effect.
+func (s *Span) Finish(err error) {
+	if s == nil || s.finished {
+		return
+	}
+	s.finished = true
+	if err != nil {
+		s.SetTag("error", err.Error())
+	}
+	duration := s.clock.Now().Sub(s.start) / time.Microsecond
+	if duration < 1 {
+		// Zipkin treats a zero duration as unknown.
+		duration = 1
+	}
+	s.data.Duration = int64(duration)
+	s.exporter.Export(s.data)
+}
+
+// newID returns a random identifier of n bytes, in hex.
+func newID(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		// This should never happen; an ID that's not random is
+		// better than no span at all.
+		logger.Errorf("generating span ID: %v", err)
+	}
+	return hex.EncodeToString(buf)
+}
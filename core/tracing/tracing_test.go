@@ -0,0 +1,136 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
+)
+
+type TracerSuite struct {
+	testing.IsolationSuite
+	clock     *testing.Clock
+	collector *tracingtest.Collector
+	tracer    *tracing.Tracer
+}
+
+var _ = gc.Suite(&TracerSuite{})
+
+func (s *TracerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC))
+	s.collector = &tracingtest.Collector{}
+	s.tracer = tracing.NewTracer("machine-0", s.clock)
+	s.tracer.SetExporter(s.collector)
+}
+
+func (s *TracerSuite) TestRootSpan(c *gc.C) {
+	span := s.tracer.StartSpan("Client.FullStatus", tracing.KindServer, tracing.SpanContext{})
+	span.SetTag("facade", "Client")
+	span.SetTag("empty", "")
+	s.clock.Advance(1500 * time.Microsecond)
+	span.Finish(nil)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].TraceID, gc.Matches, "[0-9a-f]{32}")
+	c.Check(spans[0].ID, gc.Matches, "[0-9a-f]{16}")
+	c.Check(span.Context(), jc.DeepEquals, tracing.SpanContext{
+		TraceID: spans[0].TraceID,
+		SpanID:  spans[0].ID,
+	})
+	spans[0].TraceID = ""
+	spans[0].ID = ""
+	c.Check(spans[0], jc.DeepEquals, tracing.SpanData{
+		Name:          "Client.FullStatus",
+		Kind:          tracing.KindServer,
+		Timestamp:     s.clock.Now().Add(-1500*time.Microsecond).UnixNano() / 1000,
+		Duration:      1500,
+		LocalEndpoint: tracing.Endpoint{ServiceName: "machine-0"},
+		Tags:          map[string]string{"facade": "Client"},
+	})
+}
+
+func (s *TracerSuite) TestChildSpan(c *gc.C) {
+	parent := tracing.SpanContext{
+		TraceID: "0123456789abcdef0123456789abcdef",
+		SpanID:  "0123456789abcdef",
+	}
+	span := s.tracer.StartSpan("txn", tracing.KindNone, parent)
+	c.Check(span.Context().TraceID, gc.Equals, parent.TraceID)
+	c.Check(span.Context().SpanID, gc.Not(gc.Equals), parent.SpanID)
+	span.Finish(nil)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].ParentID, gc.Equals, parent.SpanID)
+	// Zipkin treats a zero duration as unknown.
+	c.Check(spans[0].Duration, gc.Equals, int64(1))
+}
+
+func (s *TracerSuite) TestInvalidParentStartsNewTrace(c *gc.C) {
+	span := s.tracer.StartSpan("txn", tracing.KindNone, tracing.SpanContext{SpanID: "0123456789abcdef"})
+	span.Finish(nil)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].TraceID, gc.Not(gc.Equals), "")
+	c.Check(spans[0].ParentID, gc.Equals, "")
+}
+
+func (s *TracerSuite) TestFinishError(c *gc.C) {
+	span := s.tracer.StartSpan("StartInstance", tracing.KindClient, tracing.SpanContext{})
+	span.Finish(errors.New("boom"))
+	span.Finish(errors.New("again"))
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].Tags, jc.DeepEquals, map[string]string{"error": "boom"})
+}
+
+func (s *TracerSuite) TestNoExporter(c *gc.C) {
+	s.tracer.SetExporter(nil)
+	span := s.tracer.StartSpan("txn", tracing.KindNone, tracing.SpanContext{})
+	c.Assert(span, gc.IsNil)
+
+	// A nil span can be used as normal.
+	c.Check(span.Context(), gc.Equals, tracing.SpanContext{})
+	c.Check(span.Context().IsValid(), jc.IsFalse)
+	span.SetTag("facade", "Client")
+	span.Finish(nil)
+	c.Check(s.collector.Spans(), gc.HasLen, 0)
+}
+
+func (s *TracerSuite) TestNilTracer(c *gc.C) {
+	var tracer *tracing.Tracer
+	span := tracer.StartSpan("txn", tracing.KindNone, tracing.SpanContext{})
+	c.Assert(span, gc.IsNil)
+	span.Finish(nil)
+}
+
+func (s *TracerSuite) TestSpanKeepsExporter(c *gc.C) {
+	span := s.tracer.StartSpan("txn", tracing.KindNone, tracing.SpanContext{})
+	other := &tracingtest.Collector{}
+	s.tracer.SetExporter(other)
+	span.Finish(nil)
+	c.Check(s.collector.Spans(), gc.HasLen, 1)
+	c.Check(other.Spans(), gc.HasLen, 0)
+}
+
+func (s *TracerSuite) TestContext(c *gc.C) {
+	ctx := context.Background()
+	c.Assert(tracing.FromContext(ctx), gc.Equals, tracing.SpanContext{})
+
+	span := s.tracer.StartSpan("Client.FullStatus", tracing.KindServer, tracing.SpanContext{})
+	ctx = tracing.NewContext(ctx, span.Context())
+	c.Assert(tracing.FromContext(ctx), gc.Equals, span.Context())
+}
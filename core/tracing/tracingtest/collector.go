@@ -0,0 +1,47 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracingtest
+
+import (
+	"sync"
+
+	"github.com/juju/juju/core/tracing"
+)
+
+// Collector is a tracing.Exporter that keeps the spans it's given in
+// memory, for tests to examine.
+type Collector struct {
+	mu     sync.Mutex
+	spans  []tracing.SpanData
+	closed bool
+}
+
+// Export is part of the tracing.Exporter interface.
+func (c *Collector) Export(span tracing.SpanData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spans = append(c.spans, span)
+}
+
+// Close is part of the tracing.Exporter interface.
+func (c *Collector) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// Spans returns the spans exported so far, oldest first.
+func (c *Collector) Spans() []tracing.SpanData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]tracing.SpanData(nil), c.spans...)
+}
+
+// Closed returns whether Close has been called.
+func (c *Collector) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
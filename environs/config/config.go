@@ -16,6 +16,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charmrepo.v2"
 	"gopkg.in/juju/environschema.v1"
@@ -25,8 +26,12 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -139,6 +144,34 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdSinks is a comma-separated list of the types of sink
+	// (syslog, http or file) to which logs are forwarded.
+	LogFwdSinks = "logforward-sinks"
+
+	// LogFwdHTTPURL sets the URL to which batches of log records are
+	// posted by the http log forwarding sink.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// http log collector's certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdHTTPToken sets the bearer token sent to the http log
+	// collector.
+	LogFwdHTTPToken = "logforward-http-token"
+
+	// LogFwdHTTPFormat sets the format (json or loki) in which log
+	// records are posted to the http log collector.
+	LogFwdHTTPFormat = "logforward-http-format"
+
+	// LogFwdFileMaxSize sets the size in megabytes at which the file
+	// log forwarding sink is rotated.
+	LogFwdFileMaxSize = "logforward-file-max-size"
+
+	// LogFwdFileMaxBackups sets the number of rotated files kept by
+	// the file log forwarding sink.
+	LogFwdFileMaxBackups = "logforward-file-max-backups"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -159,6 +192,11 @@ const (
 	// collection can grow to before it is pruned, eg "5M"
 	MaxStatusHistorySize = "max-status-history-size"
 
+	// StatusHistoryRetention overrides MaxStatusHistoryAge for some
+	// kinds of status history, as a comma-separated list of
+	// kind=age pairs, eg "workload=720h,juju-unit=6h"
+	StatusHistoryRetention = "status-history-retention"
+
 	// MaxActionResultsAge is the maximum age of actions to keep when pruning, eg
 	// "72h"
 	MaxActionResultsAge = "max-action-results-age"
@@ -517,9 +555,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
-		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+	if err := cfg.validateLogForwardSinks(); err != nil {
+		return errors.Trace(err)
+	}
+	if lfCfg, ok := cfg.LogForwardConfig(); ok {
+		for _, sinkType := range cfg.LogFwdSinks() {
+			sinkCfg, ok := lfCfg.Sinks[sinkType]
+			if !ok {
+				continue
+			}
+			if err := sinkCfg.Validate(); err != nil {
+				return errors.Annotatef(err, "invalid %s forwarding config", sinkType)
+			}
 		}
 	}
 
@@ -544,6 +591,10 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if _, err := cfg.statusHistoryRetention(); err != nil {
+		return errors.Annotate(err, "invalid status history retention in model configuration")
+	}
+
 	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max action age in model configuration")
@@ -873,6 +924,141 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdSinks returns the types of sink to which logs are forwarded.
+// If not set, logs are forwarded to syslog.
+func (c *Config) LogFwdSinks() []string {
+	value, _ := c.defined[LogFwdSinks].(string)
+	var sinkTypes []string
+	for _, sinkType := range strings.Split(value, ",") {
+		sinkType = strings.TrimSpace(sinkType)
+		if sinkType != "" {
+			sinkTypes = append(sinkTypes, sinkType)
+		}
+	}
+	if len(sinkTypes) == 0 {
+		return []string{logfwd.SinkTypeSyslog}
+	}
+	return sinkTypes
+}
+
+func (c *Config) validateLogForwardSinks() error {
+	seen := set.NewStrings()
+	for _, sinkType := range c.LogFwdSinks() {
+		if seen.Contains(sinkType) {
+			return errors.Errorf("duplicate log forwarding sink %q", sinkType)
+		}
+		seen.Add(sinkType)
+		switch sinkType {
+		case logfwd.SinkTypeSyslog, logfwd.SinkTypeHTTP, logfwd.SinkTypeFile:
+		default:
+			return errors.Errorf(
+				"unknown log forwarding sink %q, expected one of %s",
+				sinkType, strings.Join(logfwd.SinkTypes, ", "),
+			)
+		}
+	}
+	return nil
+}
+
+// LogFwdHTTP returns the http log forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	partial := false
+	var lfCfg httpjson.RawConfig
+
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPToken]; ok && s != "" {
+		partial = true
+		lfCfg.BearerToken = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPFormat]; ok && s != "" {
+		partial = true
+		lfCfg.Format = s.(string)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdFile returns the file log forwarding config.
+func (c *Config) LogFwdFile() (*logfile.RawConfig, bool) {
+	partial := false
+	var lfCfg logfile.RawConfig
+
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool)
+	}
+
+	if s, ok := c.defined[LogFwdFileMaxSize]; ok {
+		partial = true
+		lfCfg.MaxSize = s.(int)
+	}
+
+	if s, ok := c.defined[LogFwdFileMaxBackups]; ok {
+		partial = true
+		lfCfg.MaxBackups = s.(int)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogForwardConfig returns the log forwarding config for each of the
+// sinks to which logs are forwarded.
+func (c *Config) LogForwardConfig() (*logfwd.Config, bool) {
+	enabled, partial := c.defined[LogForwardEnabled].(bool)
+	lfCfg := &logfwd.Config{
+		Enabled: enabled,
+		Sinks:   make(map[string]logfwd.SinkConfig),
+	}
+	for _, sinkType := range c.LogFwdSinks() {
+		switch sinkType {
+		case logfwd.SinkTypeSyslog:
+			if sinkCfg, ok := c.LogFwdSyslog(); ok {
+				partial = true
+				lfCfg.Sinks[sinkType] = sinkCfg
+			}
+		case logfwd.SinkTypeHTTP:
+			if sinkCfg, ok := c.LogFwdHTTP(); ok {
+				partial = true
+				lfCfg.Sinks[sinkType] = sinkCfg
+			}
+		case logfwd.SinkTypeFile:
+			// The file sink needs no settings, so it is
+			// always available once selected.
+			sinkCfg, ok := c.LogFwdFile()
+			if !ok {
+				sinkCfg = &logfile.RawConfig{}
+			}
+			partial = true
+			lfCfg.Sinks[sinkType] = sinkCfg
+		}
+	}
+	if !partial {
+		return nil, false
+	}
+	return lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1084,6 +1270,46 @@ func (c *Config) MaxStatusHistorySizeMB() uint {
 	return uint(val)
 }
 
+// StatusHistoryRetention returns the maximum age of the status history
+// entries of each kind that is kept for a different period than
+// MaxStatusHistoryAge.
+func (c *Config) StatusHistoryRetention() map[status.HistoryKind]time.Duration {
+	// Value has already been validated.
+	retention, _ := c.statusHistoryRetention()
+	return retention
+}
+
+func (c *Config) statusHistoryRetention() (map[status.HistoryKind]time.Duration, error) {
+	v := c.asString(StatusHistoryRetention)
+	if v == "" {
+		return nil, nil
+	}
+	retention := make(map[status.HistoryKind]time.Duration)
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("expected kind=age, got %q", item)
+		}
+		kind := status.HistoryKind(strings.TrimSpace(parts[0]))
+		if !kind.Valid() {
+			return nil, errors.NotValidf("status history kind %q", kind)
+		}
+		if _, ok := retention[kind]; ok {
+			return nil, errors.Errorf("duplicate status history kind %q", kind)
+		}
+		age, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.Annotatef(err, "age for %q", kind)
+		}
+		if age <= 0 {
+			return nil, errors.Errorf("age for %q must be positive", kind)
+		}
+		retention[kind] = age
+	}
+	return retention, nil
+}
+
 func (c *Config) MaxActionResultsAge() time.Duration {
 	// Value has already been validated.
 	val, _ := time.ParseDuration(c.mustString(MaxActionResultsAge))
@@ -1216,6 +1442,13 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdSinks:            schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPToken:        schema.Omit,
+	LogFwdHTTPFormat:       schema.Omit,
+	LogFwdFileMaxSize:      schema.Omit,
+	LogFwdFileMaxBackups:   schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1255,6 +1488,7 @@ var alwaysOptional = schema.Defaults{
 	ContainerNetworkingMethod:    schema.Omit,
 	MaxStatusHistoryAge:          schema.Omit,
 	MaxStatusHistorySize:         schema.Omit,
+	StatusHistoryRetention:       schema.Omit,
 	MaxActionResultsAge:          schema.Omit,
 	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
@@ -1611,6 +1845,42 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdSinks: {
+		Description: `A comma-separated list of the sinks to which logs are forwarded: syslog, http or file (default syslog).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which batches of log records are posted by the http sink.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the http log collector's certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPToken: {
+		Description: `The bearer token sent to the http log collector.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPFormat: {
+		Description: `The format in which log records are posted to the http log collector (default json).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{httpjson.FormatJSON, httpjson.FormatLoki},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxSize: {
+		Description: `The size in megabytes at which the forwarded log file is rotated (default 100).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxBackups: {
+		Description: `The number of rotated forwarded log files that are kept (default 2).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1676,6 +1946,11 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	StatusHistoryRetention: {
+		Description: "The maximum age for status history entries of particular kinds, overriding max-status-history-age, as a comma-separated list of kind=age pairs, eg workload=720h,juju-unit=6h",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	MaxActionResultsAge: {
 		Description: "The maximum age for action entries before they are pruned, in human-readable time format",
 		Type:        environschema.Tstring,
@@ -23,6 +23,11 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
 
@@ -550,6 +555,65 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid http and file log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                        "my-type",
+			"name":                        "my-name",
+			"logforward-enabled":          true,
+			"logforward-sinks":            "http, file",
+			"logforward-http-url":         "https://logs.example.com/push",
+			"logforward-http-ca-cert":     testing.CACert,
+			"logforward-http-token":       "s3cret",
+			"logforward-http-format":      "loki",
+			"logforward-file-max-size":    10,
+			"logforward-file-max-backups": 3,
+		}),
+	}, {
+		about:       "Unknown log forwarding sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-sinks":   "syslog,kafka",
+		}),
+		err: `unknown log forwarding sink "kafka", expected one of syslog, http, file`,
+	}, {
+		about:       "Duplicate log forwarding sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-sinks": "file,file",
+		}),
+		err: `duplicate log forwarding sink "file"`,
+	}, {
+		about:       "Missing http log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-sinks":   "http",
+		}),
+		err: `invalid http forwarding config: empty URL not valid`,
+	}, {
+		about:       "Syslog host not needed when not forwarding to syslog",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-sinks":   "file",
+		}),
+	}, {
+		about:       "Unknown status history retention kind",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"status-history-retention": "workload=720h,relation=1h",
+		}),
+		err: `invalid status history retention in model configuration: status history kind "relation" not valid`,
+	}, {
+		about:       "Invalid status history retention age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"status-history-retention": "juju-unit=-1h",
+		}),
+		err: `invalid status history retention in model configuration: age for "juju-unit" must be positive`,
 	},
 }
 
@@ -1189,6 +1253,19 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestStatusHistoryRetention(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.StatusHistoryRetention(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"status-history-retention": "workload=720h, juju-unit=6h",
+	})
+	c.Assert(cfg.StatusHistoryRetention(), jc.DeepEquals, map[status.HistoryKind]time.Duration{
+		status.KindWorkload:  720 * time.Hour,
+		status.KindUnitAgent: 6 * time.Hour,
+	})
+}
+
 func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigDefault(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 5*time.Minute)
@@ -1208,6 +1285,59 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestLogForwardConfigNotSet(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LogFwdSinks(), jc.DeepEquals, []string{"syslog"})
+	_, ok := cfg.LogForwardConfig()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLogForwardConfigSyslog(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "localhost:1234",
+	})
+	lfCfg, ok := cfg.LogForwardConfig()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lfCfg, jc.DeepEquals, &logfwd.Config{
+		Enabled: true,
+		Sinks: map[string]logfwd.SinkConfig{
+			"syslog": &syslog.RawConfig{
+				Enabled: true,
+				Host:    "localhost:1234",
+			},
+		},
+	})
+}
+
+func (s *ConfigSuite) TestLogForwardConfigMultipleSinks(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":       true,
+		"logforward-sinks":         "http,file",
+		"syslog-host":              "localhost:1234",
+		"logforward-http-url":      "https://logs.example.com/push",
+		"logforward-http-token":    "s3cret",
+		"logforward-file-max-size": 10,
+	})
+	c.Assert(cfg.LogFwdSinks(), jc.DeepEquals, []string{"http", "file"})
+	lfCfg, ok := cfg.LogForwardConfig()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lfCfg, jc.DeepEquals, &logfwd.Config{
+		Enabled: true,
+		Sinks: map[string]logfwd.SinkConfig{
+			"http": &httpjson.RawConfig{
+				Enabled:     true,
+				URL:         "https://logs.example.com/push",
+				BearerToken: "s3cret",
+			},
+			"file": &logfile.RawConfig{
+				Enabled: true,
+				MaxSize: 10,
+			},
+		},
+	})
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
@@ -222,6 +222,13 @@ type ConfigGetter interface {
 	Config() *config.Config
 }
 
+// CloudDestroyer provides the API to clean up the cloud resources
+// used by a model. Both Environs and CAAS brokers implement it.
+type CloudDestroyer interface {
+	// Destroy destroys all of the cloud resources used by the model.
+	Destroy() error
+}
+
 // An Environ represents a Juju environment.
 //
 // Due to the limitations of some providers (for example ec2), the
@@ -238,6 +238,17 @@ func (s *cmdModelSuite) TestDumpModelDB(c *gc.C) {
 	c.Assert(modelMap["name"], gc.Equals, "controller")
 }
 
+func (s *cmdModelSuite) TestWaitForModel(c *gc.C) {
+	// The model's own fields come from the model's AllWatcher,
+	// so this would time out if the watcher didn't report them.
+	ctx := s.run(c, "wait-for", "model", "controller",
+		"--query", `name == "controller" && life == "alive" && status == "available"`,
+		"--timeout", "1m",
+	)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		`model "controller" satisfies name == "controller" && life == "alive" && status == "available"`+"\n")
+}
+
 func (s *cmdModelSuite) assertModelValue(c *gc.C, key string, expected interface{}) {
 	modelConfig, err := s.IAASModel.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+// These are the recognized types of log sink.
+const (
+	// SinkTypeSyslog forwards log records to a remote syslog host.
+	SinkTypeSyslog = "syslog"
+
+	// SinkTypeHTTP forwards batches of log records to a remote
+	// HTTP endpoint, encoded as JSON.
+	SinkTypeHTTP = "http"
+
+	// SinkTypeFile writes log records to a local rotating file.
+	SinkTypeFile = "file"
+)
+
+// SinkTypes holds all the recognized types of log sink.
+var SinkTypes = []string{
+	SinkTypeSyslog,
+	SinkTypeHTTP,
+	SinkTypeFile,
+}
+
+// SinkConfig is the configuration for forwarding log records to a
+// single type of log sink.
+type SinkConfig interface {
+	// Validate ensures that the config is currently valid.
+	Validate() error
+}
+
+// Config holds the log forwarding configuration of a model.
+type Config struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Sinks holds the config for each log sink to which log records
+	// are to be forwarded, keyed by sink type.
+	Sinks map[string]SinkConfig
+}
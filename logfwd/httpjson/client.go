@@ -0,0 +1,202 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// requestTimeout is how long we wait for a single batch of records to
+// be accepted by the remote endpoint.
+const requestTimeout = 30 * time.Second
+
+// maxErrorBody is the most of a failed response's body that is
+// included in the returned error.
+const maxErrorBody = 1024
+
+// Doer exposes the underlying functionality needed by Client.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client posts batches of log records to a remote HTTP endpoint.
+type Client struct {
+	cfg       RawConfig
+	doer      Doer
+	transport *http.Transport
+}
+
+// Open returns a new client that posts log records to the endpoint
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsCfg,
+	}
+	client := &Client{
+		cfg: cfg,
+		doer: &http.Client{
+			Transport: transport,
+			Timeout:   requestTimeout,
+		},
+		transport: transport,
+	}
+	return client, nil
+}
+
+// OpenForDoer returns a new client that posts log records to the
+// endpoint described by the config, using the supplied Doer.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		cfg:  cfg,
+		doer: doer,
+	}, nil
+}
+
+// Close releases any connections held by the client.
+func (client *Client) Close() error {
+	if client.transport != nil {
+		client.transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Send posts the records to the remote endpoint, in batches of at most
+// the configured batch size. The records are only considered sent if
+// every batch is accepted.
+func (client *Client) Send(records []logfwd.Record) error {
+	batchSize := client.cfg.batchSize()
+	for len(records) > 0 {
+		n := len(records)
+		if n > batchSize {
+			n = batchSize
+		}
+		if err := client.post(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client *Client) post(records []logfwd.Record) error {
+	var payload interface{}
+	switch client.cfg.format() {
+	case FormatLoki:
+		var err error
+		if payload, err = lokiPayload(records); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		payload = jsonPayload(records)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Annotate(err, "encoding log records")
+	}
+
+	req, err := http.NewRequest("POST", client.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client.cfg.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+client.cfg.BearerToken)
+	}
+	resp, err := client.doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending log records")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return errors.Errorf("sending log records: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// jsonBatch is the body posted for each batch of records in FormatJSON.
+type jsonBatch struct {
+	Records []logfwd.JSONRecord `json:"records"`
+}
+
+func jsonPayload(records []logfwd.Record) jsonBatch {
+	batch := jsonBatch{
+		Records: make([]logfwd.JSONRecord, len(records)),
+	}
+	for i, rec := range records {
+		batch.Records[i] = logfwd.NewJSONRecord(rec)
+	}
+	return batch
+}
+
+// lokiBatch is the body posted for each batch of records in FormatLoki.
+type lokiBatch struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+// lokiStream holds the records sharing the same set of labels. Each
+// value is a pair of the timestamp in nanoseconds and the log line.
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func lokiPayload(records []logfwd.Record) (lokiBatch, error) {
+	var batch lokiBatch
+	streams := make(map[string]*lokiStream)
+	for _, rec := range records {
+		labels := map[string]string{
+			"controller_uuid": rec.Origin.ControllerUUID,
+			"model_uuid":      rec.Origin.ModelUUID,
+			"level":           strings.ToLower(rec.Level.String()),
+		}
+		if rec.Origin.Name != "" {
+			labels["origin"] = rec.Origin.Name
+		}
+		key := fmt.Sprintf("%s/%s/%s/%s",
+			labels["controller_uuid"], labels["model_uuid"], labels["level"], labels["origin"])
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{Stream: labels}
+			streams[key] = stream
+			batch.Streams = append(batch.Streams, stream)
+		}
+
+		line, err := json.Marshal(logfwd.NewJSONRecord(rec))
+		if err != nil {
+			return batch, errors.Annotate(err, "encoding log record")
+		}
+		stream.Values = append(stream.Values, [2]string{
+			fmt.Sprint(rec.Timestamp.UnixNano()),
+			string(line),
+		})
+	}
+	return batch, nil
+}
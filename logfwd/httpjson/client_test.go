@@ -0,0 +1,226 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	requests []*http.Request
+	bodies   []string
+	status   int
+	server   *httptest.Server
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.status = http.StatusNoContent
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, string(body))
+		w.WriteHeader(s.status)
+		if s.status != http.StatusNoContent {
+			w.Write([]byte("go away\n"))
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) open(c *gc.C, cfg httpjson.RawConfig) *httpjson.Client {
+	cfg.Enabled = true
+	cfg.URL = s.server.URL + "/push"
+	client, err := httpjson.Open(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client
+}
+
+func (s *ClientSuite) TestSendJSON(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{})
+
+	err := client.Send(makeRecords(2))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	req := s.requests[0]
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.Path, gc.Equals, "/push")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Check(req.Header.Get("Authorization"), gc.Equals, "")
+
+	var body struct {
+		Records []logfwd.JSONRecord `json:"records"`
+	}
+	err = json.Unmarshal([]byte(s.bodies[0]), &body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(body.Records, gc.HasLen, 2)
+	c.Check(body.Records[0].ID, gc.Equals, int64(1))
+	c.Check(body.Records[0].Message, gc.Equals, "message 1")
+	c.Check(body.Records[0].Level, gc.Equals, "INFO")
+	c.Check(body.Records[1].ID, gc.Equals, int64(2))
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{BatchSize: 2})
+
+	err := client.Send(makeRecords(5))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.bodies, gc.HasLen, 3)
+	var sizes []int
+	for _, raw := range s.bodies {
+		var body struct {
+			Records []logfwd.JSONRecord `json:"records"`
+		}
+		err = json.Unmarshal([]byte(raw), &body)
+		c.Assert(err, jc.ErrorIsNil)
+		sizes = append(sizes, len(body.Records))
+	}
+	c.Check(sizes, jc.DeepEquals, []int{2, 2, 1})
+}
+
+func (s *ClientSuite) TestSendNoRecords(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{})
+
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.requests, gc.HasLen, 0)
+}
+
+func (s *ClientSuite) TestSendLoki(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{Format: httpjson.FormatLoki})
+
+	records := makeRecords(3)
+	records[1].Level = loggo.ERROR
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.bodies, gc.HasLen, 1)
+	var body struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	err = json.Unmarshal([]byte(s.bodies[0]), &body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(body.Streams, gc.HasLen, 2)
+	c.Check(body.Streams[0].Stream, jc.DeepEquals, map[string]string{
+		"controller_uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"level":           "info",
+		"origin":          "0",
+	})
+	c.Assert(body.Streams[0].Values, gc.HasLen, 2)
+	c.Check(body.Streams[0].Values[0][0], gc.Equals, "1525168801000000000")
+	var line logfwd.JSONRecord
+	err = json.Unmarshal([]byte(body.Streams[0].Values[1][1]), &line)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(line.Message, gc.Equals, "message 3")
+	c.Check(body.Streams[1].Stream["level"], gc.Equals, "error")
+	c.Check(body.Streams[1].Values, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendBearerToken(c *gc.C) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req)
+	}))
+	defer server.Close()
+
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled:     true,
+		URL:         server.URL,
+		BearerToken: "s3cret",
+	}, server.Client())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send(makeRecords(1))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Header.Get("Authorization"), gc.Equals, "Bearer s3cret")
+}
+
+func (s *ClientSuite) TestSendUnknownAuthority(c *gc.C) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req)
+	}))
+	defer server.Close()
+
+	client, err := httpjson.Open(httpjson.RawConfig{
+		Enabled: true,
+		URL:     server.URL,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	err = client.Send(makeRecords(1))
+	c.Assert(err, gc.ErrorMatches, `sending log records: .*certificate signed by unknown authority`)
+	c.Check(s.requests, gc.HasLen, 0)
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{})
+	s.status = http.StatusUnauthorized
+
+	err := client.Send(makeRecords(1))
+	c.Assert(err, gc.ErrorMatches, `sending log records: 401 Unauthorized: go away`)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.Open(httpjson.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func makeRecords(n int) []logfwd.Record {
+	records := make([]logfwd.Record, n)
+	for i := range records {
+		records[i] = logfwd.Record{
+			ID: int64(i + 1),
+			Origin: logfwd.Origin{
+				ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+				ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+				Hostname:       "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+				Type:           logfwd.OriginTypeMachine,
+				Name:           "0",
+				Software: logfwd.Software{
+					PrivateEnterpriseNumber: 28978,
+					Name:                    "jujud-machine-agent",
+					Version:                 version.MustParse("2.4.0"),
+				},
+			},
+			Timestamp: time.Unix(1525168800+int64(i+1), 0),
+			Level:     loggo.INFO,
+			Location: logfwd.SourceLocation{
+				Module:   "juju.worker",
+				Filename: "worker.go",
+				Line:     42,
+			},
+			Message: fmt.Sprintf("message %d", i+1),
+		}
+	}
+	return records
+}
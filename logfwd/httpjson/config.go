@@ -0,0 +1,138 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// These are the supported formats in which batches of log records
+// are posted.
+const (
+	// FormatJSON posts each batch as a JSON object holding a list
+	// of records.
+	FormatJSON = "json"
+
+	// FormatLoki posts each batch in the format accepted by the
+	// Loki push API, with each record JSON-encoded as a log line.
+	FormatLoki = "loki"
+)
+
+// DefaultBatchSize is the maximum number of log records posted in
+// a single request if no batch size is configured.
+const DefaultBatchSize = 100
+
+// RawConfig holds the raw configuration data for forwarding log
+// records to an HTTP endpoint.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the http or https URL to which batches of log records
+	// are posted.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If not
+	// set, the system's root CAs are used.
+	CACert string
+
+	// BearerToken, if set, is sent in the Authorization header of
+	// each request.
+	BearerToken string
+
+	// Format is the format in which batches of log records are
+	// posted. If not set, FormatJSON is used.
+	Format string
+
+	// BatchSize is the maximum number of log records posted in a
+	// single request. If not set, DefaultBatchSize is used.
+	BatchSize int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch cfg.Format {
+	case "", FormatJSON, FormatLoki:
+	default:
+		return errors.NotValidf("Format %q", cfg.Format)
+	}
+
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "URL")
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		// Don't send credentials over an unencrypted connection.
+		if cfg.BearerToken != "" {
+			return errors.NotValidf("bearer token with non-https URL %q", cfg.URL)
+		}
+	default:
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q with no host", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
+
+func (cfg RawConfig) format() string {
+	if cfg.Format == "" {
+		return FormatJSON
+	}
+	return cfg.Format
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
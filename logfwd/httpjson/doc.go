@@ -0,0 +1,8 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpjson package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP log collector. Log records are posted in
+// batches, encoded as JSON either as plain records or in the format
+// accepted by the Loki push API.
+package httpjson
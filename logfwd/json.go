@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
+// JSONRecord is the JSON representation of a log record, as forwarded
+// to log sinks that accept JSON.
+type JSONRecord struct {
+	ID             int64     `json:"id"`
+	Timestamp      time.Time `json:"timestamp"`
+	Level          string    `json:"level"`
+	Module         string    `json:"module,omitempty"`
+	Location       string    `json:"location,omitempty"`
+	Message        string    `json:"message"`
+	ControllerUUID string    `json:"controller-uuid"`
+	ModelUUID      string    `json:"model-uuid"`
+	Hostname       string    `json:"hostname,omitempty"`
+	OriginType     string    `json:"origin-type,omitempty"`
+	OriginName     string    `json:"origin-name,omitempty"`
+	Software       string    `json:"software,omitempty"`
+	Version        string    `json:"version,omitempty"`
+
+	Labels []string          `json:"labels,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// NewJSONRecord returns the JSON representation of the record.
+func NewJSONRecord(rec Record) JSONRecord {
+	out := JSONRecord{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		Module:         rec.Location.Module,
+		Message:        rec.Message,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginName:     rec.Origin.Name,
+		Software:       rec.Origin.Software.Name,
+		Labels:         rec.Labels,
+		Fields:         rec.Fields,
+	}
+	if rec.Location.Filename != "" {
+		out.Location = rec.Location.String()
+	}
+	if rec.Origin.Type != OriginTypeUnknown {
+		out.OriginType = rec.Origin.Type.String()
+	}
+	if rec.Origin.Software.Version != version.Zero {
+		out.Version = rec.Origin.Software.Version.String()
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type JSONRecordSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&JSONRecordSuite{})
+
+func (s *JSONRecordSuite) TestNewJSONRecord(c *gc.C) {
+	rec := validRecord
+	rec.ID = 10
+	rec.Timestamp = time.Date(2018, 5, 1, 10, 30, 0, 0, time.FixedZone("", 3600))
+	rec.Labels = []string{"upgrade"}
+	rec.Fields = map[string]string{"hook": "install"}
+
+	out := logfwd.NewJSONRecord(rec)
+
+	c.Check(out, jc.DeepEquals, logfwd.JSONRecord{
+		ID:             10,
+		Timestamp:      time.Date(2018, 5, 1, 9, 30, 0, 0, time.UTC),
+		Level:          "ERROR",
+		Module:         "spam",
+		Location:       "eggs.go:42",
+		Message:        "uh-oh",
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:       "spam.x.y.z.com",
+		OriginType:     "user",
+		OriginName:     "a-user",
+		Software:       "juju",
+		Version:        "2.0.1",
+		Labels:         []string{"upgrade"},
+		Fields:         map[string]string{"hook": "install"},
+	})
+}
+
+func (s *JSONRecordSuite) TestNewJSONRecordMinimal(c *gc.C) {
+	rec := logfwd.Record{
+		Level:   loggo.INFO,
+		Message: "hello",
+	}
+
+	out := logfwd.NewJSONRecord(rec)
+
+	c.Check(out, jc.DeepEquals, logfwd.JSONRecord{
+		Timestamp: time.Time{}.UTC(),
+		Level:     "INFO",
+		Message:   "hello",
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Client writes log records to a local file, rotating the file once
+// it reaches the configured size.
+type Client struct {
+	// Writer is the writer to which each record is written as a line
+	// of JSON.
+	Writer io.WriteCloser
+}
+
+// Open returns a new client that writes log records to the file at
+// the given path.
+func Open(path string, cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if path == "" {
+		return nil, errors.NotValidf("empty path")
+	}
+	if err := primeLogFile(path); err != nil {
+		return nil, errors.Annotate(err, "creating log file")
+	}
+	client := &Client{
+		Writer: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    cfg.maxSize(),
+			MaxBackups: cfg.maxBackups(),
+			Compress:   true,
+		},
+	}
+	return client, nil
+}
+
+// primeLogFile ensures the log file exists and is only readable by
+// its owner; lumberjack keeps the mode when rotating.
+func primeLogFile(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+// Close closes the underlying file.
+func (client Client) Close() error {
+	err := client.Writer.Close()
+	return errors.Trace(err)
+}
+
+// Send writes the records to the file, one line of JSON per record.
+func (client Client) Send(records []logfwd.Record) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		if err := enc.Encode(logfwd.NewJSONRecord(rec)); err != nil {
+			return errors.Annotate(err, "encoding log record")
+		}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	_, err := client.Writer.Write(buf.Bytes())
+	return errors.Annotate(err, "writing log records")
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/logfile"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "logforward.log")
+	client, err := logfile.Open(path, logfile.RawConfig{Enabled: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	err = client.Send([]logfwd.Record{
+		newRecord(1, "first"),
+		newRecord(2, "second"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Send([]logfwd.Record{newRecord(3, "third")})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	f, err := os.Open(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	var messages []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec logfwd.JSONRecord
+		err := json.Unmarshal(scanner.Bytes(), &rec)
+		c.Assert(err, jc.ErrorIsNil)
+		messages = append(messages, rec.Message)
+	}
+	c.Assert(scanner.Err(), jc.ErrorIsNil)
+	c.Check(messages, jc.DeepEquals, []string{"first", "second", "third"})
+}
+
+func (s *ClientSuite) TestOpenEmptyPath(c *gc.C) {
+	_, err := logfile.Open("", logfile.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `empty path not valid`)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "logforward.log")
+	_, err := logfile.Open(path, logfile.RawConfig{MaxSize: -1})
+	c.Assert(err, gc.ErrorMatches, `negative MaxSize not valid`)
+}
+
+func newRecord(id int64, msg string) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		},
+		Timestamp: time.Unix(1525168800+id, 0),
+		Level:     loggo.INFO,
+		Message:   msg,
+	}
+}
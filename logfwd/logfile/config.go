@@ -0,0 +1,58 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultMaxSize is the size in megabytes at which the log file
+	// is rotated if no maximum size is configured.
+	DefaultMaxSize = 100
+
+	// DefaultMaxBackups is the number of rotated log files that are
+	// kept if no maximum is configured.
+	DefaultMaxBackups = 2
+)
+
+// RawConfig holds the raw configuration data for forwarding log
+// records to a local file.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// MaxSize is the size in megabytes at which the log file is
+	// rotated. If not set, DefaultMaxSize is used.
+	MaxSize int
+
+	// MaxBackups is the number of rotated log files that are kept.
+	// If not set, DefaultMaxBackups is used.
+	MaxBackups int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.MaxSize < 0 {
+		return errors.NotValidf("negative MaxSize")
+	}
+	if cfg.MaxBackups < 0 {
+		return errors.NotValidf("negative MaxBackups")
+	}
+	return nil
+}
+
+func (cfg RawConfig) maxSize() int {
+	if cfg.MaxSize == 0 {
+		return DefaultMaxSize
+	}
+	return cfg.MaxSize
+}
+
+func (cfg RawConfig) maxBackups() int {
+	if cfg.MaxBackups == 0 {
+		return DefaultMaxBackups
+	}
+	return cfg.MaxBackups
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/logfile"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Enabled:    true,
+		MaxSize:    10,
+		MaxBackups: 5,
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg logfile.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeMaxSize(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Enabled: true,
+		MaxSize: -1,
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `negative MaxSize not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeMaxBackups(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Enabled:    true,
+		MaxBackups: -1,
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `negative MaxBackups not valid`)
+}
@@ -33,6 +33,15 @@ type Record struct {
 
 	// Message is the record's body. It may be empty.
 	Message string
+
+	// Labels are the structured labels attached to the record. They
+	// are optional.
+	Labels []string
+
+	// Fields are the structured key/value fields attached to the
+	// record, such as the unit and hook that created it. They are
+	// optional.
+	Fields map[string]string
 }
 
 // Validate ensures that the record is correct.
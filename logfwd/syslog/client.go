@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -158,6 +159,7 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 		},
 		Msg: rec.Message,
 	}
+	msg.StructuredData = append(msg.StructuredData, fieldsElements(rec)...)
 
 	switch rec.Level {
 	case loggo.ERROR:
@@ -177,3 +179,36 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	}
 	return msg, nil
 }
+
+// fieldsElements returns the structured data elements holding the
+// record's labels and fields, if it has any.
+func fieldsElements(rec logfwd.Record) rfc5424.StructuredData {
+	var elements rfc5424.StructuredData
+	pen := sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber)
+	if len(rec.Labels) > 0 {
+		labels := &sdelements.Private{Name: "labels", PEN: pen}
+		for _, label := range rec.Labels {
+			labels.Data = append(labels.Data, rfc5424.StructuredDataParam{
+				Name:  "label",
+				Value: rfc5424.StructuredDataParamValue(label),
+			})
+		}
+		elements = append(elements, labels)
+	}
+	if len(rec.Fields) > 0 {
+		names := make([]string, 0, len(rec.Fields))
+		for name := range rec.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := &sdelements.Private{Name: "fields", PEN: pen}
+		for _, name := range names {
+			fields.Data = append(fields.Data, rfc5424.StructuredDataParam{
+				Name:  rfc5424.StructuredDataName(name),
+				Value: rfc5424.StructuredDataParamValue(rec.Fields[name]),
+			})
+		}
+		elements = append(elements, fields)
+	}
+	return elements
+}
@@ -148,6 +148,52 @@ func (s *ClientSuite) TestSendLogFull(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestSendLogFields(c *gc.C) {
+	tag := names.NewUnitTag("mysql/0")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	rec := logfwd.Record{
+		Origin:    logfwd.OriginForUnitAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module:   "unit.mysql/0.juju-log",
+			Filename: "juju-log.go",
+			Line:     42,
+		},
+		Message: "installing",
+		Labels:  []string{"upgrade"},
+		Fields:  map[string]string{"unit": "mysql/0", "hook": "install"},
+	}
+	client := syslog.Client{Sender: s.sender}
+
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.stub.Calls()[0].Args[0].(rfc5424.Message)
+	c.Assert(msg.StructuredData, gc.HasLen, 5)
+	c.Check(msg.StructuredData[3], jc.DeepEquals, &sdelements.Private{
+		Name: "labels",
+		PEN:  28978,
+		Data: []rfc5424.StructuredDataParam{{
+			Name:  "label",
+			Value: "upgrade",
+		}},
+	})
+	c.Check(msg.StructuredData[4], jc.DeepEquals, &sdelements.Private{
+		Name: "fields",
+		PEN:  28978,
+		Data: []rfc5424.StructuredDataParam{{
+			Name:  "hook",
+			Value: "install",
+		}, {
+			Name:  "unit",
+			Value: "mysql/0",
+		}},
+	})
+}
+
 func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
 	tag := names.NewMachineTag("99")
 	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
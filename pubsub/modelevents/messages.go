@@ -0,0 +1,44 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents
+
+// TopicPrefix is prepended to a model's UUID to form the topic on which
+// the typed events for that model are published.
+const TopicPrefix = "model.events."
+
+// Topic returns the topic on which events for the model with the given
+// UUID are published.
+func Topic(modelUUID string) string {
+	return TopicPrefix + modelUUID
+}
+
+// Event is the data published on a model's events topic. Events are
+// derived locally on each API server from the model's multiwatcher, so
+// they are never forwarded between controllers.
+type Event struct {
+	// Epoch identifies the running stream that produced the event.
+	// Sequence numbers are only comparable within a single epoch.
+	Epoch string `yaml:"epoch"`
+
+	// Sequence increases by one for every event in an epoch.
+	Sequence uint64 `yaml:"sequence"`
+
+	// Type is one of the model event types defined in
+	// apiserver/params, such as "unit-status" or "hook-started".
+	Type string `yaml:"type"`
+
+	// Entity is the tag of the entity the event relates to.
+	Entity string `yaml:"entity"`
+
+	// Time is the time the event was observed, in RFC3339 format
+	// with nanoseconds.
+	Time string `yaml:"time"`
+
+	// Data holds type specific details of the event.
+	Data map[string]string `yaml:"data,omitempty"`
+
+	// LocalOnly stops the message being forwarded to other
+	// controllers.
+	LocalOnly bool `yaml:"local-only"`
+}
@@ -4,9 +4,12 @@
 package rpc
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/tracing"
 )
 
 var ErrShutdown = errors.New("connection is shut down")
@@ -22,6 +25,10 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// span records the call, if the connection is tracing its
+	// requests.
+	span *tracing.Span
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -61,11 +68,21 @@ func (conn *Conn) send(call *Call) {
 	conn.clientPending[reqId] = call
 	conn.mutex.Unlock()
 
+	call.span = conn.tracer.StartSpan(
+		call.Type+"."+call.Action, tracing.KindClient, tracing.SpanContext{},
+	)
+	call.span.SetTag("facade", call.Type)
+	call.span.SetTag("version", strconv.Itoa(call.Version))
+	call.span.SetTag("method", call.Action)
+	spanContext := call.span.Context()
+
 	// Encode and send the request.
 	hdr := &Header{
 		RequestId: reqId,
 		Request:   call.Request,
 		Version:   1,
+		TraceID:   spanContext.TraceID,
+		SpanID:    spanContext.SpanID,
 	}
 	params := call.Params
 	if params == nil {
@@ -122,6 +139,7 @@ func (conn *Conn) handleResponse(hdr *Header) error {
 }
 
 func (call *Call) done() {
+	call.span.Finish(call.Error)
 	select {
 	case call.Done <- call:
 		// ok
@@ -60,6 +60,8 @@ type inMsgV0 struct {
 	Error     string
 	ErrorCode string
 	Response  json.RawMessage
+	TraceId   string
+	SpanId    string
 }
 
 type inMsgV1 struct {
@@ -72,6 +74,8 @@ type inMsgV1 struct {
 	Error     string          `json:"error"`
 	ErrorCode string          `json:"error-code"`
 	Response  json.RawMessage `json:"response"`
+	TraceID   string          `json:"trace-id"`
+	SpanID    string          `json:"span-id"`
 }
 
 // outMsg holds an outgoing message.
@@ -85,6 +89,8 @@ type outMsgV0 struct {
 	Error     string      `json:",omitempty"`
 	ErrorCode string      `json:",omitempty"`
 	Response  interface{} `json:",omitempty"`
+	TraceId   string      `json:",omitempty"`
+	SpanId    string      `json:",omitempty"`
 }
 
 type outMsgV1 struct {
@@ -97,6 +103,8 @@ type outMsgV1 struct {
 	Error     string      `json:"error,omitempty"`
 	ErrorCode string      `json:"error-code,omitempty"`
 	Response  interface{} `json:"response,omitempty"`
+	TraceID   string      `json:"trace-id,omitempty"`
+	SpanID    string      `json:"span-id,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -140,6 +148,8 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.Version = version
+	hdr.TraceID = c.msg.TraceID
+	hdr.SpanID = c.msg.SpanID
 	return nil
 }
 
@@ -173,6 +183,8 @@ func (c *Codec) readV0Message(m json.RawMessage) (inMsgV1, int, error) {
 		Error:     msg.Error,
 		ErrorCode: msg.ErrorCode,
 		Response:  msg.Response,
+		TraceID:   msg.TraceId,
+		SpanID:    msg.SpanId,
 	}, 0, nil
 }
 
@@ -249,6 +261,8 @@ func newOutMsgV0(hdr *rpc.Header, body interface{}) outMsgV0 {
 		Request:   hdr.Request.Action,
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
+		TraceId:   hdr.TraceID,
+		SpanId:    hdr.SpanID,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
@@ -272,6 +286,8 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 		Request:   hdr.Request.Action,
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
+		TraceID:   hdr.TraceID,
+		SpanID:    hdr.SpanID,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
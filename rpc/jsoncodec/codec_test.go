@@ -74,6 +74,18 @@ func (*suite) TestRead(c *gc.C) {
 			},
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"RequestId": 5, "Type": "foo", "Request": "frob", "TraceId": "0123456789abcdef0123456789abcdef", "SpanId": "0123456789abcdef"}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			TraceID: "0123456789abcdef0123456789abcdef",
+			SpanID:  "0123456789abcdef",
+		},
+		expectBody: new(map[string]interface{}),
 	}, {
 		msg: `{"request-id": 1, "type": "foo", "id": "id", "request": "frob", "params": {"X": "param"}}`,
 		expectHdr: rpc.Header{
@@ -115,6 +127,19 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "type": "foo", "request": "frob", "trace-id": "0123456789abcdef0123456789abcdef", "span-id": "0123456789abcdef"}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			Version: 1,
+			TraceID: "0123456789abcdef0123456789abcdef",
+			SpanID:  "0123456789abcdef",
+		},
+		expectBody: new(map[string]interface{}),
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -196,6 +221,18 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"RequestId": 4, "Type": "foo", "Version": 2, "Request": "frob", "Params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			TraceID: "0123456789abcdef0123456789abcdef",
+			SpanID:  "0123456789abcdef",
+		},
+		body:   &value{X: "param"},
+		expect: `{"RequestId": 5, "Type": "foo", "Request": "frob", "Params": {"X": "param"}, "TraceId": "0123456789abcdef0123456789abcdef", "SpanId": "0123456789abcdef"}`,
 	}, {
 		hdr: &rpc.Header{
 			RequestId: 1,
@@ -236,6 +273,19 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			Version: 1,
+			TraceID: "0123456789abcdef0123456789abcdef",
+			SpanID:  "0123456789abcdef",
+		},
+		body:   &value{X: "param"},
+		expect: `{"request-id": 5, "type": "foo", "request": "frob", "params": {"X": "param"}, "trace-id": "0123456789abcdef0123456789abcdef", "span-id": "0123456789abcdef"}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
@@ -3,7 +3,11 @@
 
 package rpc
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/juju/juju/core/tracing"
+)
 
 // Observer can be implemented to find out about requests occurring in
 // an RPC conn, for example to print requests for logging
@@ -60,6 +64,19 @@ func (m *ObserverMultiplexer) ServerRequest(hdr *Header, body interface{}) {
 	mapConcurrent(func(n Observer) { n.ServerRequest(hdr, body) }, m.rpcObservers)
 }
 
+// SpanContext implements SpanRecorder. It returns the context of the
+// span recorded by the first of its observers that records one.
+func (m *ObserverMultiplexer) SpanContext() tracing.SpanContext {
+	for _, o := range m.rpcObservers {
+		if spanRecorder, ok := o.(SpanRecorder); ok {
+			if spanContext := spanRecorder.SpanContext(); spanContext.IsValid() {
+				return spanContext
+			}
+		}
+	}
+	return tracing.SpanContext{}
+}
+
 // mapConcurrent calls fn on all observers concurrently and then waits
 // for all calls to exit before returning.
 func mapConcurrent(fn func(Observer), requestNotifiers []Observer) {
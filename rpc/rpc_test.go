@@ -16,9 +16,12 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -767,6 +770,40 @@ func (*rpcSuite) TestErrorCode(c *gc.C) {
 	c.Assert(errors.Cause(err).(rpc.ErrorCoder).ErrorCode(), gc.Equals, "code")
 }
 
+func (*rpcSuite) TestClientTracing(c *gc.C) {
+	root := &Root{
+		errorInst: &ErrorMethods{&codedError{"message", "code"}},
+	}
+	client, _, srvDone, serverNotifier := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+	collector := &tracingtest.Collector{}
+	tracer := tracing.NewTracer("client", clock.WallClock)
+	tracer.SetExporter(collector)
+	client.SetTracer(tracer)
+
+	err := client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `message \(code\)`)
+
+	spans := collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].Name, gc.Equals, "ErrorMethods.Call")
+	c.Check(spans[0].Kind, gc.Equals, tracing.KindClient)
+	c.Check(spans[0].Tags, jc.DeepEquals, map[string]string{
+		"facade":  "ErrorMethods",
+		"version": "0",
+		"method":  "Call",
+		"error":   "message (code)",
+	})
+
+	// The span's context is sent to the server with the request.
+	serverNotifier.mu.Lock()
+	defer serverNotifier.mu.Unlock()
+	c.Assert(serverNotifier.serverRequests, gc.HasLen, 1)
+	hdr := serverNotifier.serverRequests[0].hdr
+	c.Check(hdr.TraceID, gc.Equals, spans[0].TraceID)
+	c.Check(hdr.SpanID, gc.Equals, spans[0].ID)
+}
+
 func (*rpcSuite) TestTransformErrors(c *gc.C) {
 	root := &Root{
 		errorInst: &ErrorMethods{&codedError{"message", "code"}},
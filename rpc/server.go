@@ -14,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/rpc/rpcreflect"
 )
 
@@ -67,6 +68,12 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// TraceID and SpanID identify the client's span for a request,
+	// if the client is tracing its requests, so that the server's
+	// span can be recorded as part of the same trace.
+	TraceID string
+	SpanID  string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -103,6 +110,18 @@ type Recorder interface {
 	HandleReply(req Request, replyHdr *Header, body interface{}) error
 }
 
+// SpanRecorder may be implemented by a Recorder or an Observer that
+// records a tracing span for each request. The span's context is
+// passed to the method serving the request in its context.Context (see
+// tracing.FromContext), so that the work done to serve the request can
+// be recorded as part of the same trace.
+type SpanRecorder interface {
+	// SpanContext returns the context of the span recorded for the
+	// request, or the zero value if there is none. It is called
+	// after HandleRequest or ServerRequest.
+	SpanContext() tracing.SpanContext
+}
+
 // Note that we use "client request" and "server request" to name
 // requests initiated locally and remotely respectively.
 
@@ -160,6 +179,9 @@ type Conn struct {
 	inputLoopError error
 
 	recorderFactory RecorderFactory
+
+	// tracer, if set, records a span for each client request.
+	tracer *tracing.Tracer
 }
 
 // NewConn creates a new connection that uses the given codec for
@@ -174,6 +196,13 @@ func NewConn(codec Codec, factory RecorderFactory) *Conn {
 	}
 }
 
+// SetTracer sets the tracer used to record a span for each client
+// request; the span's context is sent with the request. It must be
+// called before any requests are sent.
+func (conn *Conn) SetTracer(tracer *tracing.Tracer) {
+	conn.tracer = tracer
+}
+
 // Start starts the RPC connection running.  It must be called at
 // least once for any RPC connection (client or server side) It has no
 // effect if it has already been called.  By default, a connection
@@ -556,6 +585,9 @@ func (conn *Conn) runRequest(
 	// TODO(axw) provide a means for clients to cancel a request.
 	ctx, cancel := context.WithCancel(conn.context)
 	defer cancel()
+	if spanRecorder, ok := recorder.(SpanRecorder); ok {
+		ctx = tracing.NewContext(ctx, spanRecorder.SpanContext())
+	}
 
 	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
 	if err != nil {
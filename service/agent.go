@@ -8,9 +8,12 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/shell"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/service/systemd"
+	"github.com/juju/juju/service/upstart"
 )
 
 const (
@@ -83,3 +86,55 @@ func shutdownAfterConf(serviceName, desc string) common.Conf {
 		ExecStart:    "/sbin/shutdown -h now",
 	}
 }
+
+// AgentServiceName returns the name of the init system service that
+// runs the identified agent.
+func AgentServiceName(info AgentInfo) (string, error) {
+	if info.Kind == AgentKindUnit {
+		// Service names can be at most 64 characters long; limit
+		// them to 56 just to be safe.
+		tag, err := names.NewUnitTag(info.ID).ShortenedString(56)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return "jujud-" + tag, nil
+	}
+	return "jujud-" + info.name, nil
+}
+
+// WriteAgentServiceFiles writes the init system configuration of the
+// given agents for the init system used by the specified series. No
+// services are started or stopped; this is used to prepare a machine
+// for an upgrade of its series, before the init system that will run
+// the agents after the upgrade is itself running.
+func WriteAgentServiceFiles(agents []AgentInfo, series string) error {
+	initSystem, err := versionInitSystem(series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	renderer := &shell.BashRenderer{}
+	for _, info := range agents {
+		name, err := AgentServiceName(info)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		conf := AgentConf(info, renderer)
+		switch initSystem {
+		case InitSystemSystemd:
+			svc, err := systemd.NewService(name, conf, info.DataDir)
+			if err != nil {
+				return errors.Annotatef(err, "failed to wrap service %q", name)
+			}
+			err = svc.WriteService()
+		case InitSystemUpstart:
+			err = upstart.NewService(name, conf).Install()
+		default:
+			return errors.NotSupportedf("writing agent services for init system %q", initSystem)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "failed to write service %q", name)
+		}
+		logger.Infof("wrote %s service files for %q", initSystem, name)
+	}
+	return nil
+}
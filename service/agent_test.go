@@ -4,6 +4,7 @@
 package service_test
 
 import (
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -16,6 +17,8 @@ import (
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/service/systemd"
+	"github.com/juju/juju/service/upstart"
 )
 
 var (
@@ -237,3 +240,65 @@ func (*agentSuite) TestShutdownAfterConfMissingServiceName(c *gc.C) {
 
 	c.Check(err, gc.ErrorMatches, `.*missing "after" service name.*`)
 }
+
+func (*agentSuite) TestAgentServiceName(c *gc.C) {
+	name, err := service.AgentServiceName(service.NewMachineAgentInfo("0", "/var/lib/juju", "/var/log/juju"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "jujud-machine-0")
+
+	name, err = service.AgentServiceName(service.NewUnitAgentInfo("wordpress/1", "/var/lib/juju", "/var/log/juju"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "jujud-unit-wordpress-1")
+}
+
+func (s *agentSuite) TestWriteAgentServiceFilesSystemd(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("systemd services are not written on windows")
+	}
+	dataDir := c.MkDir()
+	linkDir := c.MkDir()
+	s.PatchValue(&systemd.LinkDir, linkDir)
+	agents := []service.AgentInfo{
+		service.NewMachineAgentInfo("0", dataDir, "/var/log/juju"),
+		service.NewUnitAgentInfo("wordpress/1", dataDir, "/var/log/juju"),
+	}
+
+	err := service.WriteAgentServiceFiles(agents, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, name := range []string{"jujud-machine-0", "jujud-unit-wordpress-1"} {
+		filename := filepath.Join(dataDir, "init", name, name+".service")
+		_, err := os.Stat(filename)
+		c.Check(err, jc.ErrorIsNil)
+		for _, link := range []string{
+			filepath.Join(linkDir, name+".service"),
+			filepath.Join(linkDir, "multi-user.target.wants", name+".service"),
+		} {
+			target, err := os.Readlink(link)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(target, gc.Equals, filename)
+		}
+	}
+}
+
+func (s *agentSuite) TestWriteAgentServiceFilesUpstart(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("upstart services are not written on windows")
+	}
+	initDir := c.MkDir()
+	s.PatchValue(&upstart.InitDir, initDir)
+	agents := []service.AgentInfo{
+		service.NewMachineAgentInfo("0", c.MkDir(), "/var/log/juju"),
+	}
+
+	err := service.WriteAgentServiceFiles(agents, "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = os.Stat(filepath.Join(initDir, "jujud-machine-0.conf"))
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (*agentSuite) TestWriteAgentServiceFilesUnsupported(c *gc.C) {
+	err := service.WriteAgentServiceFiles(nil, "win2012")
+	c.Assert(err, gc.ErrorMatches, `writing agent services for init system "windows" not supported`)
+}
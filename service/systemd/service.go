@@ -512,6 +512,41 @@ func (s *Service) writeConf() (string, error) {
 	return filename, nil
 }
 
+// LinkDir is the directory in which systemd looks for the unit files
+// of services that are enabled at boot.
+var LinkDir = "/etc/systemd/system"
+
+// WriteService writes the service's unit file, along with any exec-start
+// script, and links it into LinkDir so that the service is started at
+// boot. Unlike Install, it does not need systemd to be running, so it
+// can be used to prepare the host for an upgrade to a series that uses
+// systemd.
+func (s *Service) WriteService() error {
+	if s.NoConf() {
+		return s.errorf(nil, "missing conf")
+	}
+	filename, err := s.writeConf()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	wantsDir := path.Join(LinkDir, "multi-user.target.wants")
+	if err := mkdirAll(wantsDir); err != nil {
+		return s.errorf(err, "failed to create %q", wantsDir)
+	}
+	for _, link := range []string{
+		path.Join(LinkDir, s.UnitName),
+		path.Join(wantsDir, s.UnitName),
+	} {
+		if err := removeAll(link); err != nil {
+			return s.errorf(err, "failed to remove %q", link)
+		}
+		if err := symlink(filename, link); err != nil {
+			return s.errorf(err, "failed to link %q", link)
+		}
+	}
+	return nil
+}
+
 var mkdirAll = func(dirname string) error {
 	return os.MkdirAll(dirname, 0755)
 }
@@ -520,6 +555,8 @@ var createFile = func(filename string, data []byte, perm os.FileMode) error {
 	return ioutil.WriteFile(filename, data, perm)
 }
 
+var symlink = os.Symlink
+
 // InstallCommands implements Service.
 func (s *Service) InstallCommands() ([]string, error) {
 	if s.NoConf() {
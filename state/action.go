@@ -324,6 +324,15 @@ func (m *Model) AllActions() ([]Action, error) {
 	return results, nil
 }
 
+// PendingActionCount returns the number of Actions in the model that
+// are waiting to be run.
+func (st *State) PendingActionCount() (int, error) {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+	count, err := actions.Find(bson.D{{"status", ActionPending}}).Count()
+	return count, errors.Trace(err)
+}
+
 // ActionByTag returns an Action given an ActionTag.
 func (m *Model) ActionByTag(tag names.ActionTag) (Action, error) {
 	return m.Action(tag.Id())
@@ -17,6 +17,13 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used for the audit log defaults to 100MB. It's
+// tweaked in export_test.go to 1MB for the same reason as the txn log.
+var (
+	auditLogSize      = 100000000
+	auditLogSizeTests = 1000000
+)
+
 // allCollections should be the single source of truth for information about
 // any collection we use. It's broken up into 4 main sections:
 //
@@ -302,6 +309,10 @@ func allCollections() collectionSchema {
 		rebootC:      {},
 		sshHostKeysC: {},
 
+		// This collection holds the progress of managed series
+		// upgrades of machines.
+		upgradeSeriesLocksC: {},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
@@ -461,6 +472,14 @@ func allCollections() collectionSchema {
 		// for applications and units.
 		containerSpecsC: {},
 
+		// generationsC holds the branches of charm settings changes
+		// staged for a subset of a model's units.
+		generationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "name"},
+			}},
+		},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -468,6 +487,23 @@ func allCollections() collectionSchema {
 
 		// metrics; status-history; logs; ..?
 
+		// This collection holds the controller's audit log: the
+		// conversations, requests and response errors recorded by
+		// every controller's API server.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"conversation-id"},
+			}, {
+				Key: []string{"kind", "when", "_id"},
+			}},
+		},
+
 	}
 	return result
 }
@@ -483,6 +519,7 @@ const (
 	annotationsC             = "annotations"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
+	auditLogC                = "auditlog"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
@@ -547,6 +584,7 @@ const (
 	txnsC                    = "txns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
+	upgradeSeriesLocksC      = "upgradeSeriesLocks"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
@@ -562,4 +600,7 @@ const (
 	externalControllersC = "externalControllers"
 	relationNetworksC    = "relationNetworks"
 	firewallRulesC       = "firewallRules"
+
+	// Model generations
+	generationsC = "generations"
 )
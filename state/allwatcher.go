@@ -1214,6 +1214,7 @@ type backingEntityDoc interface {
 
 func newAllWatcherStateBacking(st *State, params WatchParams) Backing {
 	collectionNames := []string{
+		modelsC,
 		machinesC,
 		unitsC,
 		applicationsC,
@@ -1243,10 +1244,21 @@ func (b *allWatcherStateBacking) filterModel(docID interface{}) bool {
 	return err == nil
 }
 
+// filterModelDoc reports whether the models collection document
+// with the given id, which is the bare model UUID, is the backing's
+// own model.
+func (b *allWatcherStateBacking) filterModelDoc(docID interface{}) bool {
+	return docID.(string) == b.st.ModelUUID()
+}
+
 // Watch watches all the collections.
 func (b *allWatcherStateBacking) Watch(in chan<- watcher.Change) {
 	for _, c := range b.collectionByName {
-		b.watcher.WatchCollectionWithFilter(c.name, in, b.filterModel)
+		filter := b.filterModel
+		if c.name == modelsC {
+			filter = b.filterModelDoc
+		}
+		b.watcher.WatchCollectionWithFilter(c.name, in, filter)
 	}
 }
 
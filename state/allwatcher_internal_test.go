@@ -53,6 +53,31 @@ type allWatcherBaseSuite struct {
 	internalStateSuite
 }
 
+// modelInfo returns the entity info the backings report
+// for the state's model.
+func (s *allWatcherBaseSuite) modelInfo(c *gc.C, st *State) *multiwatcher.ModelInfo {
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := model.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	return &multiwatcher.ModelInfo{
+		ModelUUID:      model.UUID(),
+		Name:           model.Name(),
+		Life:           multiwatcher.Life("alive"),
+		Owner:          model.Owner().Id(),
+		ControllerUUID: model.ControllerUUID(),
+		Config:         cfg.AllAttrs(),
+		Status: multiwatcher.StatusInfo{
+			Current: status.Status,
+			Message: status.Message,
+			Data:    status.Data,
+			Since:   status.Since,
+		},
+	}
+}
+
 // setUpScenario adds some entities to the state so that
 // we can check that they all get pulled in by
 // all(Model)WatcherStateBacking.GetAll.
@@ -469,6 +494,8 @@ func (s *allWatcherStateSuite) TestGetAllMultiModelWithOffers(c *gc.C) {
 }
 
 func (s *allWatcherStateSuite) checkGetAll(c *gc.C, expectEntities entityInfoSlice, includeOffers bool) {
+	// The backing also reports its own model.
+	expectEntities = append(expectEntities, s.modelInfo(c, s.state))
 	b := newAllWatcherStateBacking(s.state, WatchParams{IncludeOffers: includeOffers})
 	all := newStore()
 	err := b.GetAll(all)
@@ -902,10 +929,13 @@ func (s *allWatcherStateSuite) TestStateWatcher(c *gc.C) {
 	tw := newTestAllWatcher(s.state, c)
 	defer tw.Stop()
 
-	// Expect to see events for the already created machines first.
-	deltas := tw.All(2)
+	// Expect to see events for the model and the
+	// already created machines first.
+	deltas := tw.All(3)
 	now := testing.ZeroTime()
 	checkDeltasEqual(c, deltas, []multiwatcher.Delta{{
+		Entity: s.modelInfo(c, s.state),
+	}, {
 		Entity: &multiwatcher.MachineInfo{
 			ModelUUID: s.state.ModelUUID(),
 			Id:        "0",
@@ -1261,6 +1291,31 @@ func (s *allWatcherStateSuite) TestStateWatcherTwoModels(c *gc.C) {
 				c.Assert(err, jc.ErrorIsNil)
 				return 1
 			},
+		}, {
+			about: "model",
+			triggerEvent: func(st *State) int {
+				ops := []txn.Op{{
+					C:      modelsC,
+					Id:     st.ModelUUID(),
+					Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+				}}
+				err := st.db().RunTransaction(ops)
+				c.Assert(err, jc.ErrorIsNil)
+				return 1
+			},
+		}, {
+			about: "model status",
+			triggerEvent: func(st *State) int {
+				model, err := st.Model()
+				c.Assert(err, jc.ErrorIsNil)
+
+				err = model.SetStatus(status.StatusInfo{
+					Status:  status.Busy,
+					Message: "migrating",
+				})
+				c.Assert(err, jc.ErrorIsNil)
+				return 1
+			},
 		}, {
 			about: "blocks",
 			triggerEvent: func(st *State) int {
@@ -1299,9 +1354,10 @@ func (s *allWatcherStateSuite) TestStateWatcherTwoModels(c *gc.C) {
 			w2 := newTestAllWatcher(otherState, c)
 			defer w2.Stop()
 
-			// The first set of deltas is empty, reflecting an empty model.
-			w1.AssertNoChange(c)
-			w2.AssertNoChange(c)
+			// The first set of deltas holds only the model itself,
+			// reflecting an empty model.
+			w1.AssertChanges(c, 1)
+			w2.AssertChanges(c, 1)
 			checkIsolationForModel(s.state, w1, w2)
 			checkIsolationForModel(otherState, w2, w1)
 		}()
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/status"
 )
 
@@ -53,6 +54,7 @@ type applicationDoc struct {
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
 	MinUnits             int        `bson:"minunits"`
+	DesiredScale         int        `bson:"scale"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
 	PasswordHash         string     `bson:"passwordhash"`
@@ -294,7 +296,9 @@ func (a *Application) destroyOps(destroyStorage, removeOffers bool) ([]txn.Op, e
 	} else {
 		notLastRefs = append(notLastRefs, bson.D{{"unitcount", 0}}...)
 	}
-	update := bson.D{{"$set", bson.D{{"life", Dying}}}}
+	// A dying application should have no pods running; the scale
+	// is only meaningful for applications in CAAS models.
+	update := bson.D{{"$set", bson.D{{"life", Dying}, {"scale", 0}}}}
 	if removeCount != 0 {
 		decref := bson.D{{"$inc", bson.D{{"relationcount", -removeCount}}}}
 		update = append(update, decref...)
@@ -956,20 +960,32 @@ type SetCharmConfig struct {
 	StorageConstraints map[string]StorageConstraints
 }
 
-// SetCharm changes the charm for the application.
-func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
+// ValidateSetCharm checks that the application's charm could be
+// changed with the supplied configuration, without changing the
+// application. Relations broken by the new charm are not checked.
+func (a *Application) ValidateSetCharm(cfg SetCharmConfig) (err error) {
 	defer errors.DeferredAnnotatef(
 		&err, "cannot upgrade application %q to charm %q", a, cfg.Charm,
 	)
+	_, _, err = a.validateSetCharmConfig(cfg)
+	return errors.Trace(err)
+}
+
+// validateSetCharmConfig performs the checks on cfg that do not depend
+// on the application's relations, and returns the validated charm
+// config settings. If the charm is forced onto the application's
+// series and supports a series with no known OS, the checks stop and
+// false is returned: SetCharm then leaves the application unchanged.
+func (a *Application) validateSetCharmConfig(cfg SetCharmConfig) (charm.Settings, bool, error) {
 	if cfg.Charm.Meta().Subordinate != a.doc.Subordinate {
-		return errors.Errorf("cannot change an application's subordinacy")
+		return nil, false, errors.Errorf("cannot change an application's subordinacy")
 	}
 	// For old style charms written for only one series, we still retain
 	// this check. Newer charms written for multi-series have a URL
 	// with series = "".
 	if cfg.Charm.URL().Series != "" {
 		if cfg.Charm.URL().Series != a.doc.Series {
-			return errors.Errorf("cannot change an application's series")
+			return nil, false, errors.Errorf("cannot change an application's series")
 		}
 	} else if !cfg.ForceSeries {
 		supported := false
@@ -984,7 +1000,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			if len(cfg.Charm.Meta().Series) > 0 {
 				supportedSeries = strings.Join(cfg.Charm.Meta().Series, ", ")
 			}
-			return errors.Errorf("only these series are supported: %v", supportedSeries)
+			return nil, false, errors.Errorf("only these series are supported: %v", supportedSeries)
 		}
 	} else {
 		// Even with forceSeries=true, we do not allow a charm to be used which is for
@@ -994,14 +1010,14 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		if err != nil {
 			// We don't expect an error here but there's not much we can
 			// do to recover.
-			return err
+			return nil, false, err
 		}
 		supportedOS := false
 		supportedSeries := cfg.Charm.Meta().Series
 		for _, chSeries := range supportedSeries {
 			charmSeriesOS, err := series.GetOSFromSeries(chSeries)
 			if err != nil {
-				return nil
+				return nil, false, nil
 			}
 			if currentOS == charmSeriesOS {
 				supportedOS = true
@@ -1009,13 +1025,27 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}
 		}
 		if !supportedOS && len(supportedSeries) > 0 {
-			return errors.Errorf("OS %q not supported by charm", currentOS)
+			return nil, false, errors.Errorf("OS %q not supported by charm", currentOS)
 		}
 	}
 
 	updatedSettings, err := cfg.Charm.Config().ValidateSettings(cfg.ConfigSettings)
 	if err != nil {
-		return errors.Annotate(err, "validating config settings")
+		return nil, false, errors.Annotate(err, "validating config settings")
+	}
+	return updatedSettings, true, nil
+}
+
+// SetCharm changes the charm for the application.
+func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
+	defer errors.DeferredAnnotatef(
+		&err, "cannot upgrade application %q to charm %q", a, cfg.Charm,
+	)
+	updatedSettings, ok, err := a.validateSetCharmConfig(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	} else if !ok {
+		return nil
 	}
 
 	var newCharmModifiedVersion int
@@ -1255,6 +1285,7 @@ func (a *Application) addUnitOps(
 		providerId:    args.ProviderId,
 		address:       args.Address,
 		ports:         args.Ports,
+		filesystems:   args.Filesystems,
 	})
 	if err != nil {
 		return names, ops, err
@@ -1272,9 +1303,10 @@ type applicationAddUnitOpsArgs struct {
 	attachStorage []names.StorageTag
 
 	// These attributes are relevant to CAAS models.
-	providerId string
-	address    string
-	ports      []string
+	providerId  string
+	address     string
+	ports       []string
+	filesystems []ContainerFilesystem
 }
 
 // addApplicationUnitOps is just like addUnitOps but explicitly takes a
@@ -1325,10 +1357,11 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		Principal:              args.principalName,
 		StorageAttachmentCount: numStorageAttachments,
 	}
-	if args.address != "" || args.ports != nil {
+	if args.address != "" || args.ports != nil || args.filesystems != nil {
 		udoc.ContainerInfo = ContainerInfo{
-			Address: args.address,
-			Ports:   args.ports,
+			Address:     args.address,
+			Ports:       args.ports,
+			Filesystems: args.filesystems,
 		}
 	}
 	now := a.st.clock().Now()
@@ -1570,15 +1603,27 @@ type AddUnitParams struct {
 
 	// Ports are the open ports on the container.
 	Ports []string
+
+	// Filesystems are the filesystems mounted in the container.
+	Filesystems []ContainerFilesystem
 }
 
-// AddUnit adds a new principal unit to the application.
+// AddUnit adds a new principal unit to the application. In CAAS models,
+// where each unit is backed by a pod, the application's scale is
+// increased by one in the same transaction.
 func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
 	name, ops, err := a.addUnitOps("", args, nil)
 	if err != nil {
 		return nil, err
 	}
+	model, err := a.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() == ModelTypeCAAS {
+		ops = append(ops, incScaleOp(a, 1))
+	}
 
 	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
 		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
@@ -2010,6 +2055,86 @@ func (a *Application) defaultEndpointBindings() (map[string]string, error) {
 	return DefaultEndpointBindingsForCharm(charm.Meta()), nil
 }
 
+// UpdateEndpointBindings merges the given endpoint bindings into the
+// existing ones of the application. Every machine hosting a unit of the
+// application must have an address in each space an endpoint is being
+// bound to.
+func (a *Application) UpdateEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update endpoint bindings for %q", a)
+	spaces := set.NewStrings()
+	for _, space := range bindings {
+		if space != environs.DefaultSpaceName {
+			spaces.Add(space)
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(a.st, a.globalKey(), bindings, ch.Meta())
+		if err == jujutxn.ErrNoOperations {
+			return nil, err
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := a.checkUnitMachinesInSpaces(spaces); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", a.doc.CharmURL},
+				{"unitcount", a.doc.UnitCount},
+			},
+		}, bindingsOp}, nil
+	}
+	return a.st.db().Run(buildTxn)
+}
+
+// checkUnitMachinesInSpaces returns an error if any machine hosting a
+// unit of the application has no address in one of the given spaces.
+func (a *Application) checkUnitMachinesInSpaces(spaces set.Strings) error {
+	if spaces.IsEmpty() {
+		return nil
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		machine, err := a.st.Machine(machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machineSpaces, err := machine.AllSpaces()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if missing := spaces.Difference(machineSpaces); !missing.IsEmpty() {
+			return errors.Errorf("machine %q hosting unit %q has no address in space(s) %s",
+				machineId, unit.Name(), strings.Join(missing.SortedValues(), ", "))
+		}
+	}
+	return nil
+}
+
 // MetricCredentials returns any metric credentials associated with this application.
 func (a *Application) MetricCredentials() []byte {
 	return a.doc.MetricCredentials
@@ -2260,10 +2385,11 @@ func (a *Application) PasswordValid(password string) bool {
 // UnitUpdateProperties holds information used to update
 // the state model for the unit.
 type UnitUpdateProperties struct {
-	ProviderId string
-	Address    string
-	Ports      []string
-	Status     *status.StatusInfo
+	ProviderId  string
+	Address     string
+	Ports       []string
+	Status      *status.StatusInfo
+	Filesystems []ContainerFilesystem
 }
 
 // UpdateUnits applies the given application unit update operations.
@@ -2345,10 +2471,15 @@ type AddUnitOperation struct {
 func (op *AddUnitOperation) Build(attempt int) ([]txn.Op, error) {
 	var ops []txn.Op
 
+	filesystems, err := assignContainerFilesystemIds(op.application.st, nil, op.props.Filesystems)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	addUnitArgs := AddUnitParams{
-		ProviderId: op.props.ProviderId,
-		Address:    op.props.Address,
-		Ports:      op.props.Ports,
+		ProviderId:  op.props.ProviderId,
+		Address:     op.props.Address,
+		Ports:       op.props.Ports,
+		Filesystems: filesystems,
 	}
 	name, addOps, err := op.application.addUnitOps("", addUnitArgs, nil)
 	if err != nil {
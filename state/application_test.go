@@ -200,6 +200,24 @@ func (s *ApplicationSuite) TestSetCharmPreconditions(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:otherseries/otherseries-mysql-1": cannot change an application's series`)
 }
 
+func (s *ApplicationSuite) TestValidateSetCharm(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingApplicationForSeries(c, s.State, "precise", "application", ch)
+
+	chDifferentSeries := state.AddTestingCharmMultiSeries(c, s.State, "multi-series2")
+	err := app.ValidateSetCharm(state.SetCharmConfig{Charm: chDifferentSeries})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "application" to charm "cs:multi-series2-8": only these series are supported: trusty, wily`)
+
+	err = app.ValidateSetCharm(state.SetCharmConfig{Charm: chDifferentSeries, ForceSeries: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The application is left alone.
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl, gc.DeepEquals, ch.URL())
+}
+
 func (s *ApplicationSuite) TestSetCharmUpdatesBindings(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, false)
 	c.Assert(err, jc.ErrorIsNil)
@@ -3025,6 +3043,86 @@ func (s *ApplicationSuite) TestEndpointBindingsWithExplictOverrides(c *gc.C) {
 	s.assertApplicationRemovedWithItsBindings(c, service)
 }
 
+func (s *ApplicationSuite) TestUpdateEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	app := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.UpdateEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{
+		"server":  "db",
+		"client":  "",
+		"cluster": "",
+	})
+}
+
+func (s *ApplicationSuite) TestUpdateEndpointBindingsUnknownSpace(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	app := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+
+	err := app.UpdateEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot update endpoint bindings for "yoursql": unknown space "db" not valid`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationSuite) TestUpdateEndpointBindingsMachineNotInSpace(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	app := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.UpdateEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot update endpoint bindings for "yoursql": `+
+		`machine "0" hosting unit "yoursql/0" has no address in space\(s\) db`)
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "")
+}
+
+func (s *ApplicationSuite) TestUpdateEndpointBindingsMachineInSpace(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	app := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "10.0.0.5/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.UpdateEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+}
+
 func (s *ApplicationSuite) TestSetCharmExtraBindingsUseDefaults(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
@@ -3405,6 +3503,68 @@ func (s *ApplicationSuite) TestUpdateCAASUnits(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *ApplicationSuite) TestUpdateCAASUnitsFilesystems(c *gc.C) {
+	s.SetFeatureFlags(feature.CAAS)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	defer st.Close()
+	f := factory.NewFactory(st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "storage-filesystem"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "storage-filesystem", Charm: ch})
+
+	filesystem := state.ContainerFilesystem{
+		StorageName:  "data",
+		FilesystemId: "juju-data-0-juju-storage-filesystem-0",
+		Pool:         "kubernetes",
+		Size:         1024,
+		MountPoint:   "/var/lib/juju/storage/data/0",
+		ReadOnly:     true,
+		Status:       status.Pending,
+	}
+	var updateUnits state.UpdateUnitsOperation
+	updateUnits.Adds = []*state.AddUnitOperation{app.AddOperation(state.UnitUpdateProperties{
+		ProviderId:  "unit-uuid",
+		Filesystems: []state.ContainerFilesystem{filesystem},
+	})}
+	err := app.UpdateUnits(&updateUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	filesystems := units[0].ContainerInfo().Filesystems
+	c.Assert(filesystems, gc.HasLen, 1)
+	storageId, id := filesystems[0].StorageId, filesystems[0].Id
+	c.Assert(storageId, gc.Matches, "data/[0-9]+")
+	c.Assert(id, gc.Matches, "[0-9]+")
+	filesystem.StorageId = storageId
+	filesystem.Id = id
+	c.Assert(filesystems[0], jc.DeepEquals, filesystem)
+
+	// Updating the filesystem keeps the IDs assigned by Juju.
+	filesystem.StorageId = ""
+	filesystem.Id = ""
+	filesystem.Status = status.Attached
+	updateUnits = state.UpdateUnitsOperation{}
+	updateUnits.Updates = []*state.UpdateUnitOperation{units[0].UpdateOperation(state.UnitUpdateProperties{
+		ProviderId:  "unit-uuid",
+		Filesystems: []state.ContainerFilesystem{filesystem},
+	})}
+	err = app.UpdateUnits(&updateUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = units[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	filesystems = units[0].ContainerInfo().Filesystems
+	c.Assert(filesystems, gc.HasLen, 1)
+	c.Assert(filesystems[0].StorageId, gc.Equals, storageId)
+	c.Assert(filesystems[0].Id, gc.Equals, id)
+	c.Assert(filesystems[0].Status, gc.Equals, status.Attached)
+	c.Assert(filesystems[0].StorageTag().Id(), gc.Equals, storageId)
+}
+
 func (s *ApplicationSuite) TestAddUnitWithProviderId(c *gc.C) {
 	u, err := s.mysql.AddUnit(state.AddUnitParams{ProviderId: "provider-id"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// GetScale returns the number of pods the application should be
+// running. It is only meaningful for applications in CAAS models.
+func (a *Application) GetScale() int {
+	return a.doc.DesiredScale
+}
+
+// SetScale sets the number of pods the application should be
+// running. It is only valid for applications in CAAS models.
+func (a *Application) SetScale(scale int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set scale for application %q", a)
+	if scale < 0 {
+		return errors.NotValidf("negative scale %d", scale)
+	}
+	if err := a.checkCAAS(); err != nil {
+		return errors.Trace(err)
+	}
+	app := &Application{st: a.st, doc: a.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errors.New("application is no longer alive")
+		}
+		if scale == app.doc.DesiredScale {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{setScaleOp(app, scale)}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.DesiredScale = scale
+	return nil
+}
+
+// setScaleOp returns the operation which sets the scale of the
+// application, asserting that it has not changed since it was read.
+func setScaleOp(app *Application, scale int) txn.Op {
+	return txn.Op{
+		C:  applicationsC,
+		Id: app.doc.DocID,
+		Assert: bson.D{
+			{"life", Alive},
+			{"scale", app.doc.DesiredScale},
+		},
+		Update: bson.D{{"$set", bson.D{{"scale", scale}}}},
+	}
+}
+
+// incScaleOp returns the operation which changes the scale of the
+// application by the given amount, asserting that the application is
+// alive and that its scale will not become negative.
+func incScaleOp(app *Application, change int) txn.Op {
+	assert := bson.D{{"life", Alive}}
+	if change < 0 {
+		assert = append(assert, bson.DocElem{"scale", bson.D{{"$gte", -change}}})
+	}
+	return txn.Op{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: assert,
+		Update: bson.D{{"$inc", bson.D{{"scale", change}}}},
+	}
+}
+
+func (a *Application) checkCAAS() error {
+	model, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Type() != ModelTypeCAAS {
+		return errors.NotSupportedf("scale for %s models", model.Type())
+	}
+	return nil
+}
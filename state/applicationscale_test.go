@@ -0,0 +1,222 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ApplicationScaleSuite struct {
+	ConnSuite
+	caasSt *state.State
+	app    *state.Application
+}
+
+var _ = gc.Suite(&ApplicationScaleSuite{})
+
+func (s *ApplicationScaleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.SetFeatureFlags(feature.CAAS)
+	s.caasSt = s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	s.AddCleanup(func(*gc.C) { s.caasSt.Close() })
+	f := factory.NewFactory(s.caasSt)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "wordpress"})
+	var err error
+	s.app, err = s.caasSt.AddApplication(state.AddApplicationArgs{
+		Name: "gitlab", Charm: ch, NumUnits: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationScaleSuite) TestInitialScale(c *gc.C) {
+	c.Assert(s.app.GetScale(), gc.Equals, 2)
+	err := s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 2)
+}
+
+func (s *ApplicationScaleSuite) TestSetScale(c *gc.C) {
+	err := s.app.SetScale(5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 5)
+
+	app, err := s.caasSt.Application(s.app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.GetScale(), gc.Equals, 5)
+}
+
+func (s *ApplicationScaleSuite) TestSetScaleNegative(c *gc.C) {
+	err := s.app.SetScale(-1)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "gitlab": negative scale -1 not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationScaleSuite) TestSetScaleIAAS(c *gc.C) {
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := app.SetScale(1)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "mysql": scale for iaas models not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ApplicationScaleSuite) TestSetScaleDying(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetScale(1)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "gitlab": application is no longer alive`)
+}
+
+func (s *ApplicationScaleSuite) TestSetScaleConcurrentChange(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.caasSt, func() {
+		app, err := s.caasSt.Application(s.app.Name())
+		c.Assert(err, jc.ErrorIsNil)
+		err = app.SetScale(3)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.app.SetScale(5)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 5)
+}
+
+func (s *ApplicationScaleSuite) TestAddUnitIncreasesScale(c *gc.C) {
+	_, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 3)
+}
+
+func (s *ApplicationScaleSuite) TestAddUnitIncreasesScaleConcurrentChange(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.caasSt, func() {
+		app, err := s.caasSt.Application(s.app.Name())
+		c.Assert(err, jc.ErrorIsNil)
+		err = app.SetScale(5)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 6)
+}
+
+func (s *ApplicationScaleSuite) TestDestroyUnitScaleDown(c *gc.C) {
+	units, err := s.app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	op := units[0].DestroyOperation()
+	op.ScaleDown = true
+	err = s.caasSt.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 1)
+
+	// Destroying the unit again leaves the scale alone.
+	op = units[0].DestroyOperation()
+	op.ScaleDown = true
+	err = s.caasSt.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 1)
+}
+
+func (s *ApplicationScaleSuite) TestDestroyUnitWithoutScaleDown(c *gc.C) {
+	units, err := s.app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.caasSt.ApplyOperation(units[0].DestroyOperation())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 2)
+}
+
+func (s *ApplicationScaleSuite) TestDestroyUnitScaleDownAtZero(c *gc.C) {
+	err := s.app.SetScale(0)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := s.app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	op := units[0].DestroyOperation()
+	op.ScaleDown = true
+	err = s.caasSt.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 0)
+}
+
+func (s *ApplicationScaleSuite) TestDestroyUnitScaleDownConcurrentChange(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.caasSt, func() {
+		app, err := s.caasSt.Application(s.app.Name())
+		c.Assert(err, jc.ErrorIsNil)
+		err = app.SetScale(0)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	units, err := s.app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	op := units[0].DestroyOperation()
+	op.ScaleDown = true
+	err = s.caasSt.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 0)
+}
+
+func (s *ApplicationScaleSuite) TestDestroySetsScaleToZero(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.Life(), gc.Equals, state.Dying)
+	c.Assert(s.app.GetScale(), gc.Equals, 0)
+}
+
+func (s *ApplicationScaleSuite) TestWatchScale(c *gc.C) {
+	w := s.app.WatchScale()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.caasSt, w)
+	wc.AssertOneChange()
+
+	// Change the scale, check one event.
+	err := s.app.SetScale(5)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes to other fields are ignored.
+	err = s.app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Make two changes, check one event.
+	err = s.app.SetScale(6)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetScale(4)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Destroying the application sets its scale to zero.
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
@@ -0,0 +1,534 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// AuditLogModule is the module of the log records emitted by an
+// audit log tailer.
+const AuditLogModule = "juju.audit"
+
+// These are the kinds of document held in the audit log collection,
+// one for each part of an auditlog.Record.
+const (
+	auditConversationKind = "conversation"
+	auditRequestKind      = "request"
+	auditErrorsKind       = "errors"
+)
+
+// auditTailRetryDelay is how long an audit log tailer waits before
+// querying the collection again once its tailable cursor has died.
+var auditTailRetryDelay = time.Second
+
+// auditLogDoc holds a conversation, request or response errors
+// recorded in the audit log. The fields used depend on the kind.
+type auditLogDoc struct {
+	Id             bson.ObjectId `bson:"_id"`
+	Kind           string        `bson:"kind"`
+	ControllerID   string        `bson:"controller-id"`
+	ConversationID string        `bson:"conversation-id"`
+	ConnectionID   string        `bson:"connection-id"`
+	When           time.Time     `bson:"when"`
+
+	// Conversation fields.
+	Who       string `bson:"who,omitempty"`
+	What      string `bson:"what,omitempty"`
+	ModelName string `bson:"model-name,omitempty"`
+	ModelUUID string `bson:"model-uuid,omitempty"`
+
+	// Request and response error fields.
+	RequestID int64              `bson:"request-id,omitempty"`
+	Facade    string             `bson:"facade,omitempty"`
+	Method    string             `bson:"method,omitempty"`
+	Version   int                `bson:"version,omitempty"`
+	Args      string             `bson:"args,omitempty"`
+	Errors    []auditLogErrorDoc `bson:"errors,omitempty"`
+}
+
+type auditLogErrorDoc struct {
+	Message string `bson:"message"`
+	Code    string `bson:"code"`
+}
+
+// record returns the audit log record held in the document.
+func (doc *auditLogDoc) record() auditlog.Record {
+	when := doc.When.UTC().Format(time.RFC3339)
+	switch doc.Kind {
+	case auditConversationKind:
+		return auditlog.Record{Conversation: &auditlog.Conversation{
+			Who:            doc.Who,
+			What:           doc.What,
+			When:           when,
+			ModelName:      doc.ModelName,
+			ModelUUID:      doc.ModelUUID,
+			ConversationID: doc.ConversationID,
+			ConnectionID:   doc.ConnectionID,
+		}}
+	case auditRequestKind:
+		return auditlog.Record{Request: &auditlog.Request{
+			ConversationID: doc.ConversationID,
+			ConnectionID:   doc.ConnectionID,
+			RequestID:      uint64(doc.RequestID),
+			When:           when,
+			Facade:         doc.Facade,
+			Method:         doc.Method,
+			Version:        doc.Version,
+			Args:           doc.Args,
+		}}
+	}
+	errs := make([]*auditlog.Error, len(doc.Errors))
+	for i, e := range doc.Errors {
+		errs[i] = &auditlog.Error{Message: e.Message, Code: e.Code}
+	}
+	return auditlog.Record{Errors: &auditlog.ResponseErrors{
+		ConversationID: doc.ConversationID,
+		ConnectionID:   doc.ConnectionID,
+		RequestID:      uint64(doc.RequestID),
+		When:           when,
+		Errors:         errs,
+	}}
+}
+
+// auditLogCollection writes audit log records to the controller's
+// capped audit log collection.
+type auditLogCollection struct {
+	st           *State
+	controllerID string
+}
+
+// NewAuditLog returns an audit log that writes records to the
+// controller's audit log collection, tagged with the ID of the
+// controller machine that handled the API connection. The collection
+// is shared by all the controllers, so it can be queried for the
+// records of the whole controller.
+func NewAuditLog(st *State, controllerID string) auditlog.AuditLog {
+	return &auditLogCollection{
+		st:           st,
+		controllerID: controllerID,
+	}
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (a *auditLogCollection) AddConversation(c auditlog.Conversation) error {
+	when, err := parseAuditTime(c.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.insert(&auditLogDoc{
+		Kind:           auditConversationKind,
+		ConversationID: c.ConversationID,
+		ConnectionID:   c.ConnectionID,
+		When:           when,
+		Who:            c.Who,
+		What:           c.What,
+		ModelName:      c.ModelName,
+		ModelUUID:      c.ModelUUID,
+	}))
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (a *auditLogCollection) AddRequest(r auditlog.Request) error {
+	when, err := parseAuditTime(r.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.insert(&auditLogDoc{
+		Kind:           auditRequestKind,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		When:           when,
+		RequestID:      int64(r.RequestID),
+		Facade:         r.Facade,
+		Method:         r.Method,
+		Version:        r.Version,
+		Args:           r.Args,
+	}))
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (a *auditLogCollection) AddResponse(r auditlog.ResponseErrors) error {
+	when, err := parseAuditTime(r.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	errs := make([]auditLogErrorDoc, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		if e == nil {
+			continue
+		}
+		errs = append(errs, auditLogErrorDoc{Message: e.Message, Code: e.Code})
+	}
+	return errors.Trace(a.insert(&auditLogDoc{
+		Kind:           auditErrorsKind,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		When:           when,
+		RequestID:      int64(r.RequestID),
+		Errors:         errs,
+	}))
+}
+
+// Close implements auditlog.AuditLog.
+func (a *auditLogCollection) Close() error {
+	return nil
+}
+
+func (a *auditLogCollection) insert(doc *auditLogDoc) error {
+	doc.Id = bson.NewObjectId()
+	doc.ControllerID = a.controllerID
+	coll, closer := a.st.db().GetRawCollection(auditLogC)
+	defer closer()
+	return errors.Trace(coll.Insert(doc))
+}
+
+func parseAuditTime(value string) (time.Time, error) {
+	when, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Annotatef(err, "invalid audit log time %q", value)
+	}
+	return when.UTC(), nil
+}
+
+// AuditLogRecord is a record from the controller's audit log, along
+// with the ID of the controller machine that recorded it.
+type AuditLogRecord struct {
+	ControllerID string
+	auditlog.Record
+}
+
+// AuditLogFilter specifies which requests to return from the
+// controller's audit log. Unset fields match all requests.
+type AuditLogFilter struct {
+	// User restricts the requests to those made by the user.
+	User names.UserTag
+
+	// Model restricts the requests to those made on the model with
+	// this name or UUID.
+	Model string
+
+	// Facade and Method restrict the requests to those made to the
+	// facade and method.
+	Facade string
+	Method string
+
+	// ConversationID restricts the requests to those made as part
+	// of the conversation.
+	ConversationID string
+
+	// From and To restrict the requests to those made in the time
+	// range, inclusively.
+	From time.Time
+	To   time.Time
+
+	// Limit, if positive, restricts the requests to the most recent
+	// ones matching the filter.
+	Limit int
+}
+
+// QueryAuditLog returns the requests matching the filter from the
+// controller's audit log, in the order in which they were made. Each
+// request is preceded by the conversation it is part of, if that has
+// not already been returned, and followed by any errors returned in
+// response.
+func (st *State) QueryAuditLog(filter AuditLogFilter) ([]AuditLogRecord, error) {
+	coll, closer := st.db().GetRawCollection(auditLogC)
+	defer closer()
+
+	requestSel := bson.D{{"kind", auditRequestKind}}
+	conversationSel := bson.D{{"kind", auditConversationKind}}
+	restrictConversations := false
+	if filter.User.Id() != "" {
+		conversationSel = append(conversationSel, bson.DocElem{"who", filter.User.String()})
+		restrictConversations = true
+	}
+	if filter.Model != "" {
+		conversationSel = append(conversationSel, bson.DocElem{"$or", []bson.D{
+			{{"model-uuid", filter.Model}},
+			{{"model-name", filter.Model}},
+		}})
+		restrictConversations = true
+	}
+	if restrictConversations {
+		if filter.ConversationID != "" {
+			conversationSel = append(conversationSel, bson.DocElem{"conversation-id", filter.ConversationID})
+		}
+		var ids []string
+		if err := coll.Find(conversationSel).Distinct("conversation-id", &ids); err != nil {
+			return nil, errors.Annotate(err, "querying audit log conversations")
+		}
+		if len(ids) == 0 {
+			return nil, nil
+		}
+		requestSel = append(requestSel, bson.DocElem{"conversation-id", bson.M{"$in": ids}})
+	} else if filter.ConversationID != "" {
+		requestSel = append(requestSel, bson.DocElem{"conversation-id", filter.ConversationID})
+	}
+	if filter.Facade != "" {
+		requestSel = append(requestSel, bson.DocElem{"facade", filter.Facade})
+	}
+	if filter.Method != "" {
+		requestSel = append(requestSel, bson.DocElem{"method", filter.Method})
+	}
+	when := bson.M{}
+	if !filter.From.IsZero() {
+		when["$gte"] = filter.From.UTC()
+	}
+	if !filter.To.IsZero() {
+		when["$lte"] = filter.To.UTC()
+	}
+	if len(when) > 0 {
+		requestSel = append(requestSel, bson.DocElem{"when", when})
+	}
+
+	var requests []auditLogDoc
+	if filter.Limit > 0 {
+		// Get the most recent requests, and put them back in the
+		// order they were made.
+		query := coll.Find(requestSel).Sort("-when", "-_id").Limit(filter.Limit)
+		if err := query.All(&requests); err != nil {
+			return nil, errors.Annotate(err, "querying audit log requests")
+		}
+		for i, j := 0, len(requests)-1; i < j; i, j = i+1, j-1 {
+			requests[i], requests[j] = requests[j], requests[i]
+		}
+	} else {
+		if err := coll.Find(requestSel).Sort("when", "_id").All(&requests); err != nil {
+			return nil, errors.Annotate(err, "querying audit log requests")
+		}
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	conversationIDs := make([]string, 0, len(requests))
+	for _, doc := range requests {
+		conversationIDs = append(conversationIDs, doc.ConversationID)
+	}
+	var others []auditLogDoc
+	err := coll.Find(bson.D{
+		{"kind", bson.M{"$in": []string{auditConversationKind, auditErrorsKind}}},
+		{"conversation-id", bson.M{"$in": conversationIDs}},
+	}).All(&others)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying audit log conversations")
+	}
+	conversations := make(map[string]*auditLogDoc)
+	responses := make(map[auditRequestKey][]*auditLogDoc)
+	for i := range others {
+		doc := &others[i]
+		if doc.Kind == auditConversationKind {
+			conversations[doc.ConversationID] = doc
+			continue
+		}
+		key := auditRequestKey{doc.ConversationID, doc.RequestID}
+		responses[key] = append(responses[key], doc)
+	}
+
+	var result []AuditLogRecord
+	add := func(doc *auditLogDoc) {
+		result = append(result, AuditLogRecord{
+			ControllerID: doc.ControllerID,
+			Record:       doc.record(),
+		})
+	}
+	for i := range requests {
+		doc := &requests[i]
+		if conversation, ok := conversations[doc.ConversationID]; ok {
+			add(conversation)
+			delete(conversations, doc.ConversationID)
+		}
+		add(doc)
+		for _, response := range responses[auditRequestKey{doc.ConversationID, doc.RequestID}] {
+			add(response)
+		}
+	}
+	return result, nil
+}
+
+// auditRequestKey identifies a request within the audit log.
+type auditRequestKey struct {
+	conversationID string
+	requestID      int64
+}
+
+// NewAuditLogTailer returns a LogTailer which emits the records of
+// the controller's audit log as log records for the controller model.
+// Each log record holds a JSON-encoded auditlog.Record as its message,
+// and the tag of the controller machine that recorded it as its
+// entity. Only the StartTime, InitialLines and NoTail parameters are
+// used.
+func NewAuditLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	if !st.IsController() {
+		return nil, errors.New("audit log records can only be tailed for the controller model")
+	}
+	session := st.MongoSession().Copy()
+	t := &auditLogTailer{
+		modelUUID: st.ModelUUID(),
+		coll:      session.DB(jujuDB).C(auditLogC).With(session),
+		params:    params,
+		logCh:     make(chan *LogRecord),
+	}
+	go func() {
+		err := t.loop()
+		t.tomb.Kill(errors.Cause(err))
+		close(t.logCh)
+		session.Close()
+		t.tomb.Done()
+	}()
+	return t, nil
+}
+
+type auditLogTailer struct {
+	tomb      tomb.Tomb
+	modelUUID string
+	coll      *mgo.Collection
+	params    LogTailerParams
+	logCh     chan *LogRecord
+
+	// lastWhen is the time of the last record sent, and lastIds
+	// holds the ids of the records sent with that time, so that
+	// they're not sent again when the collection is queried anew.
+	lastWhen time.Time
+	lastIds  *objectIdSet
+}
+
+// Logs implements the LogTailer interface.
+func (t *auditLogTailer) Logs() <-chan *LogRecord {
+	return t.logCh
+}
+
+// Dying implements the LogTailer interface.
+func (t *auditLogTailer) Dying() <-chan struct{} {
+	return t.tomb.Dying()
+}
+
+// Stop implements the LogTailer interface.
+func (t *auditLogTailer) Stop() error {
+	t.tomb.Kill(nil)
+	return t.tomb.Wait()
+}
+
+// Err implements the LogTailer interface.
+func (t *auditLogTailer) Err() error {
+	return t.tomb.Err()
+}
+
+func (t *auditLogTailer) loop() error {
+	// NOTE: don't trace or annotate the errors returned
+	// from this method as the error may be tomb.ErrDying, and
+	// the tomb code is sensitive about equality.
+	t.lastWhen = t.params.StartTime
+	t.lastIds = newObjectIdSet()
+
+	skip := 0
+	if t.params.InitialLines > 0 {
+		count, err := t.coll.Find(t.selector()).Count()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if count > t.params.InitialLines {
+			skip = count - t.params.InitialLines
+		}
+	}
+
+	for {
+		// The collection is capped, so natural order is the order
+		// in which the records were inserted.
+		query := t.coll.Find(t.selector()).Sort("$natural").Skip(skip)
+		skip = 0
+		if t.params.NoTail {
+			return t.process(query.Iter())
+		}
+		if err := t.process(query.Tail(auditTailRetryDelay)); err != nil {
+			return err
+		}
+		// The cursor died, either because there were no matching
+		// records or because it fell off the end of the collection.
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		case <-time.After(auditTailRetryDelay):
+		}
+	}
+}
+
+func (t *auditLogTailer) selector() bson.D {
+	if t.lastWhen.IsZero() {
+		return bson.D{}
+	}
+	return bson.D{{"when", bson.M{"$gte": t.lastWhen.UTC()}}}
+}
+
+// process sends the records from the iterator until it is exhausted
+// or, if it is a tailable cursor, until the cursor dies.
+func (t *auditLogTailer) process(iter *mgo.Iter) error {
+	defer iter.Close()
+	for {
+		for {
+			var doc auditLogDoc
+			if !iter.Next(&doc) {
+				break
+			}
+			if doc.When.Equal(t.lastWhen) && t.lastIds.Contains(doc.Id) {
+				continue
+			}
+			rec, err := auditDocToLogRecord(t.modelUUID, &doc)
+			if err != nil {
+				logger.Warningf("audit log deserialization failed (possible DB corruption), %v", err)
+				continue
+			}
+			select {
+			case <-t.tomb.Dying():
+				return tomb.ErrDying
+			case t.logCh <- rec:
+			}
+			if !doc.When.Equal(t.lastWhen) {
+				t.lastWhen = doc.When
+				t.lastIds = newObjectIdSet()
+			}
+			t.lastIds.Add(doc.Id)
+		}
+		if !iter.Timeout() {
+			return errors.Trace(iter.Close())
+		}
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		default:
+		}
+	}
+}
+
+func auditDocToLogRecord(modelUUID string, doc *auditLogDoc) (*LogRecord, error) {
+	if !names.IsValidMachine(doc.ControllerID) {
+		return nil, errors.NotValidf("controller ID %q", doc.ControllerID)
+	}
+	message, err := json.Marshal(doc.record())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &LogRecord{
+		ID:        doc.When.UnixNano(),
+		Time:      doc.When.UTC(),
+		ModelUUID: modelUUID,
+		Entity:    names.NewMachineTag(doc.ControllerID),
+		Version:   jujuversion.Current,
+		Level:     loggo.INFO,
+		Module:    AuditLogModule,
+		Message:   string(message),
+	}, nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type AuditLogSuite struct {
+	ConnWithWallClockSuite
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) addConversation(c *gc.C, log auditlog.AuditLog, id, who, model, when string) {
+	err := log.AddConversation(auditlog.Conversation{
+		Who:            who,
+		What:           "juju status",
+		When:           when,
+		ModelName:      model,
+		ModelUUID:      model + "-uuid",
+		ConversationID: id,
+		ConnectionID:   "C" + id,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) addRequest(c *gc.C, log auditlog.AuditLog, conversationID string, requestID uint64, facade, method, when string) {
+	err := log.AddRequest(auditlog.Request{
+		ConversationID: conversationID,
+		ConnectionID:   "C" + conversationID,
+		RequestID:      requestID,
+		When:           when,
+		Facade:         facade,
+		Method:         method,
+		Version:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) populate(c *gc.C) {
+	log0 := state.NewAuditLog(s.State, "0")
+	log1 := state.NewAuditLog(s.State, "1")
+
+	s.addConversation(c, log0, "abc", "user-bob", "default", "2018-05-01T10:00:00Z")
+	s.addRequest(c, log0, "abc", 1, "Client", "FullStatus", "2018-05-01T10:00:01Z")
+	err := log0.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "abc",
+		ConnectionID:   "Cabc",
+		RequestID:      1,
+		When:           "2018-05-01T10:00:02Z",
+		Errors:         []*auditlog.Error{nil, {Message: "boom", Code: "bad"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.addConversation(c, log1, "def", "user-mary", "other", "2018-05-01T11:00:00Z")
+	s.addRequest(c, log1, "def", 1, "Application", "Deploy", "2018-05-01T11:00:01Z")
+	s.addRequest(c, log1, "def", 2, "Client", "FullStatus", "2018-05-01T11:00:02Z")
+}
+
+func (s *AuditLogSuite) TestAddInvalidTime(c *gc.C) {
+	log := state.NewAuditLog(s.State, "0")
+	err := log.AddConversation(auditlog.Conversation{When: "yesterday"})
+	c.Assert(err, gc.ErrorMatches, `invalid audit log time "yesterday": .*`)
+}
+
+func (s *AuditLogSuite) TestQueryAll(c *gc.C) {
+	s.populate(c)
+
+	records, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []state.AuditLogRecord{{
+		ControllerID: "0",
+		Record: auditlog.Record{Conversation: &auditlog.Conversation{
+			Who:            "user-bob",
+			What:           "juju status",
+			When:           "2018-05-01T10:00:00Z",
+			ModelName:      "default",
+			ModelUUID:      "default-uuid",
+			ConversationID: "abc",
+			ConnectionID:   "Cabc",
+		}},
+	}, {
+		ControllerID: "0",
+		Record: auditlog.Record{Request: &auditlog.Request{
+			ConversationID: "abc",
+			ConnectionID:   "Cabc",
+			RequestID:      1,
+			When:           "2018-05-01T10:00:01Z",
+			Facade:         "Client",
+			Method:         "FullStatus",
+			Version:        1,
+		}},
+	}, {
+		ControllerID: "0",
+		Record: auditlog.Record{Errors: &auditlog.ResponseErrors{
+			ConversationID: "abc",
+			ConnectionID:   "Cabc",
+			RequestID:      1,
+			When:           "2018-05-01T10:00:02Z",
+			Errors:         []*auditlog.Error{{Message: "boom", Code: "bad"}},
+		}},
+	}, {
+		ControllerID: "1",
+		Record: auditlog.Record{Conversation: &auditlog.Conversation{
+			Who:            "user-mary",
+			What:           "juju status",
+			When:           "2018-05-01T11:00:00Z",
+			ModelName:      "other",
+			ModelUUID:      "other-uuid",
+			ConversationID: "def",
+			ConnectionID:   "Cdef",
+		}},
+	}, {
+		ControllerID: "1",
+		Record: auditlog.Record{Request: &auditlog.Request{
+			ConversationID: "def",
+			ConnectionID:   "Cdef",
+			RequestID:      1,
+			When:           "2018-05-01T11:00:01Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        1,
+		}},
+	}, {
+		ControllerID: "1",
+		Record: auditlog.Record{Request: &auditlog.Request{
+			ConversationID: "def",
+			ConnectionID:   "Cdef",
+			RequestID:      2,
+			When:           "2018-05-01T11:00:02Z",
+			Facade:         "Client",
+			Method:         "FullStatus",
+			Version:        1,
+		}},
+	}})
+}
+
+// requestSummary returns the conversation and request IDs of the
+// requests in the records, and checks that each request follows its
+// conversation.
+func requestSummary(c *gc.C, records []state.AuditLogRecord) []string {
+	var summary []string
+	seen := make(map[string]bool)
+	for _, rec := range records {
+		switch {
+		case rec.Conversation != nil:
+			seen[rec.Conversation.ConversationID] = true
+		case rec.Request != nil:
+			c.Check(seen[rec.Request.ConversationID], jc.IsTrue)
+			summary = append(summary, rec.Request.ConversationID+"/"+rec.Request.Method)
+		}
+	}
+	return summary
+}
+
+func (s *AuditLogSuite) TestQueryFilters(c *gc.C) {
+	s.populate(c)
+
+	from := time.Date(2018, 5, 1, 11, 0, 2, 0, time.UTC)
+	for i, test := range []struct {
+		about    string
+		filter   state.AuditLogFilter
+		expected []string
+	}{{
+		about:    "user",
+		filter:   state.AuditLogFilter{User: names.NewUserTag("bob")},
+		expected: []string{"abc/FullStatus"},
+	}, {
+		about:    "model name",
+		filter:   state.AuditLogFilter{Model: "other"},
+		expected: []string{"def/Deploy", "def/FullStatus"},
+	}, {
+		about:    "model uuid",
+		filter:   state.AuditLogFilter{Model: "default-uuid"},
+		expected: []string{"abc/FullStatus"},
+	}, {
+		about:    "facade and method",
+		filter:   state.AuditLogFilter{Facade: "Client", Method: "FullStatus"},
+		expected: []string{"abc/FullStatus", "def/FullStatus"},
+	}, {
+		about:    "conversation",
+		filter:   state.AuditLogFilter{ConversationID: "def"},
+		expected: []string{"def/Deploy", "def/FullStatus"},
+	}, {
+		about:    "conversation and user",
+		filter:   state.AuditLogFilter{ConversationID: "def", User: names.NewUserTag("bob")},
+		expected: nil,
+	}, {
+		about:    "time range",
+		filter:   state.AuditLogFilter{From: from.Add(-2 * time.Hour), To: from.Add(-time.Second)},
+		expected: []string{"abc/FullStatus", "def/Deploy"},
+	}, {
+		about:    "from",
+		filter:   state.AuditLogFilter{From: from},
+		expected: []string{"def/FullStatus"},
+	}, {
+		about:    "limit",
+		filter:   state.AuditLogFilter{Limit: 2},
+		expected: []string{"def/Deploy", "def/FullStatus"},
+	}, {
+		about:    "no matching user",
+		filter:   state.AuditLogFilter{User: names.NewUserTag("fred")},
+		expected: nil,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		records, err := s.State.QueryAuditLog(test.filter)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(requestSummary(c, records), jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *AuditLogSuite) TestTailer(c *gc.C) {
+	s.populate(c)
+
+	tailer, err := state.NewAuditLogTailer(s.State, state.LogTailerParams{
+		StartTime: time.Date(2018, 5, 1, 11, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	expectRecord := func(controllerID string, check func(auditlog.Record)) {
+		select {
+		case rec, ok := <-tailer.Logs():
+			c.Assert(ok, jc.IsTrue)
+			c.Check(rec.ModelUUID, gc.Equals, s.State.ModelUUID())
+			c.Check(rec.Entity, gc.Equals, names.NewMachineTag(controllerID))
+			c.Check(rec.Version, gc.Equals, jujuversion.Current)
+			c.Check(rec.Level, gc.Equals, loggo.INFO)
+			c.Check(rec.Module, gc.Equals, state.AuditLogModule)
+			var record auditlog.Record
+			err := json.Unmarshal([]byte(rec.Message), &record)
+			c.Assert(err, jc.ErrorIsNil)
+			check(record)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for audit record")
+		}
+	}
+	expectRecord("1", func(rec auditlog.Record) {
+		c.Assert(rec.Conversation, gc.NotNil)
+		c.Check(rec.Conversation.ConversationID, gc.Equals, "def")
+	})
+	expectRecord("1", func(rec auditlog.Record) {
+		c.Assert(rec.Request, gc.NotNil)
+		c.Check(rec.Request.Method, gc.Equals, "Deploy")
+	})
+	expectRecord("1", func(rec auditlog.Record) {
+		c.Assert(rec.Request, gc.NotNil)
+		c.Check(rec.Request.Method, gc.Equals, "FullStatus")
+	})
+
+	// Records added later are tailed.
+	log := state.NewAuditLog(s.State, "2")
+	s.addRequest(c, log, "def", 3, "Client", "ModelInfo", "2018-05-01T12:00:00Z")
+	expectRecord("2", func(rec auditlog.Record) {
+		c.Assert(rec.Request, gc.NotNil)
+		c.Check(rec.Request.Method, gc.Equals, "ModelInfo")
+	})
+}
+
+func (s *AuditLogSuite) TestTailerNotController(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	_, err := state.NewAuditLogTailer(st, state.LogTailerParams{})
+	c.Assert(err, gc.ErrorMatches, "audit log records can only be tailed for the controller model")
+}
@@ -604,6 +604,28 @@ func (st *State) Charm(curl *charm.URL) (*Charm, error) {
 	return newCharm(st, cdoc), nil
 }
 
+// UnstoredCharm returns a charm with the given URL, metadata and
+// config which has not been added to the model. It may be used to
+// validate changes which would use the charm, such as with
+// ValidateAddApplication, but not to make them.
+func (st *State) UnstoredCharm(curl *charm.URL, meta *charm.Meta, config *charm.Config) (*Charm, error) {
+	if meta == nil {
+		return nil, errors.NotValidf("charm %q without metadata", curl)
+	}
+	if err := meta.Check(); err != nil {
+		return nil, errors.Annotatef(err, "invalid metadata for charm %q", curl)
+	}
+	if config == nil {
+		config = charm.NewConfig()
+	}
+	return newCharm(st, &charmDoc{
+		URL:           curl,
+		Meta:          meta,
+		Config:        config,
+		PendingUpload: true,
+	}), nil
+}
+
 // LatestPlaceholderCharm returns the latest charm described by the
 // given URL but which is not yet deployed.
 func (st *State) LatestPlaceholderCharm(curl *charm.URL) (*Charm, error) {
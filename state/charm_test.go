@@ -111,6 +111,32 @@ func (s *CharmSuite) testCharm(c *gc.C) {
 		})
 }
 
+func (s *CharmSuite) TestUnstoredCharm(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/unstored-1")
+	meta := &charm.Meta{Name: "unstored", Summary: "s", Description: "d"}
+	ch, err := s.State.UnstoredCharm(curl, meta, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.URL(), gc.DeepEquals, curl)
+	c.Assert(ch.Meta(), gc.Equals, meta)
+	c.Assert(ch.Config(), jc.DeepEquals, charm.NewConfig())
+	c.Assert(ch.IsUploaded(), jc.IsFalse)
+
+	_, err = s.State.Charm(curl)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmSuite) TestUnstoredCharmInvalidMeta(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/unstored-1")
+	_, err := s.State.UnstoredCharm(curl, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `charm "cs:quantal/unstored-1" without metadata not valid`)
+
+	_, err = s.State.UnstoredCharm(curl, &charm.Meta{
+		Name:     "unstored",
+		Provides: map[string]charm.Relation{"juju-info": {Name: "juju-info", Interface: "juju-info"}},
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid metadata for charm "cs:quantal/unstored-1": .*`)
+}
+
 func (s *CharmSuite) TestRemovedCharmNotFound(c *gc.C) {
 	s.remove(c)
 	s.checkRemoved(c)
@@ -96,6 +96,15 @@ func (st *State) NeedsCleanup() (bool, error) {
 	return count > 0, nil
 }
 
+// CleanupCount returns the number of documents marked for removal that
+// have not yet been cleaned up.
+func (st *State) CleanupCount() (int, error) {
+	cleanups, closer := st.db().GetCollection(cleanupsC)
+	defer closer()
+	count, err := cleanups.Count()
+	return count, errors.Trace(err)
+}
+
 // Cleanup removes all documents that were previously marked for removal, if
 // any such exist. It should be called periodically by at least one element
 // of the system.
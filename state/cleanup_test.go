@@ -67,6 +67,22 @@ func (s *CleanupSuite) TestCleanupDyingApplicationUnits(c *gc.C) {
 	s.assertCleanupCount(c, 1)
 }
 
+func (s *CleanupSuite) TestCleanupCount(c *gc.C) {
+	count, err := s.State.CleanupCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err = mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err = s.State.CleanupCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, jc.GreaterThan, 0)
+}
+
 func (s *CleanupSuite) TestCleanupDyingApplicationCharm(c *gc.C) {
 	// Create a application and a charm.
 	ch := s.AddTestingCharm(c, "mysql")
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -40,6 +41,7 @@ func (cs *ConnSuite) SetUpTest(c *gc.C) {
 			return storage.ChainedProviderRegistry{
 				dummy.StorageProviders(),
 				provider.CommonStorageProviders(),
+				k8sprovider.StorageProviders(),
 			}, nil
 		},
 	}
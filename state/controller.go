@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
@@ -12,6 +13,7 @@ import (
 	mgo "gopkg.in/mgo.v2"
 
 	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
 )
 
 const (
@@ -41,6 +43,7 @@ type Controller struct {
 	policy                 Policy
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
+	tracer                 *tracing.Tracer
 }
 
 // Close the connection to the database.
@@ -60,6 +63,7 @@ func (ctlr *Controller) NewState(modelTag names.ModelTag) (*State, error) {
 		ctlr.newPolicy,
 		ctlr.clock,
 		ctlr.runTransactionObserver,
+		ctlr.tracer,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -84,3 +88,103 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// UpdateControllerConfig changes the controller configuration. The
+// attributes in updateAttrs are set and those in removeAttrs are
+// reverted to their defaults. Only the attributes in
+// controller.AllowedUpdateConfigAttributes may be changed after
+// bootstrap.
+func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	for key := range updateAttrs {
+		if err := checkUpdateControllerConfig(key); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, key := range removeAttrs {
+		if err := checkUpdateControllerConfig(key); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	settings, err := readSettings(st.db(), controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller config")
+	}
+	attrs := settings.Map()
+	for _, key := range removeAttrs {
+		delete(attrs, key)
+	}
+	for key, value := range updateAttrs {
+		attrs[key] = value
+	}
+	current := jujucontroller.Config(settings.Map())
+	caCert, _ := current.CACert()
+	// NewConfig coerces the values into the types the schema expects
+	// and fills in defaults, so the merged result is what gets stored.
+	newConfig, err := jujucontroller.NewConfig(current.ControllerUUID(), caCert, attrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key, space := range map[string]string{
+		jujucontroller.JujuHASpace:         newConfig.JujuHASpace(),
+		jujucontroller.JujuManagementSpace: newConfig.JujuManagementSpace(),
+	} {
+		if _, ok := updateAttrs[key]; !ok || space == "" {
+			continue
+		}
+		if err := st.checkSpaceIsAvailableToAllControllers(space); err != nil {
+			return errors.Annotatef(err, "invalid config %q=%q", key, space)
+		}
+	}
+
+	for _, key := range removeAttrs {
+		// Accessors expect attributes with defaults to be present.
+		if value, ok := newConfig[key]; ok {
+			settings.Set(key, value)
+		} else {
+			settings.Delete(key)
+		}
+	}
+	for key := range updateAttrs {
+		settings.Set(key, newConfig[key])
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
+
+func checkUpdateControllerConfig(name string) error {
+	if !jujucontroller.ControllerOnlyAttribute(name) {
+		return errors.Errorf("unknown controller config setting %q", name)
+	}
+	if !jujucontroller.AllowedUpdateConfigAttributes.Contains(name) {
+		return errors.Errorf("can't change %q after bootstrap", name)
+	}
+	return nil
+}
+
+// checkSpaceIsAvailableToAllControllers returns an error if any
+// controller machine has no address in the given space.
+func (st *State) checkSpaceIsAvailableToAllControllers(space string) error {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return errors.Annotate(err, "cannot get controller info")
+	}
+	var missing []string
+	for _, id := range info.MachineIds {
+		m, err := st.Machine(id)
+		if err != nil {
+			return errors.Annotate(err, "cannot get controller machine")
+		}
+		spaces, err := m.AllSpaces()
+		if err != nil {
+			return errors.Annotate(err, "cannot get spaces for controller machine")
+		}
+		if !spaces.Contains(space) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("machines with no addresses in this space: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
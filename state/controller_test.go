@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
@@ -35,6 +37,7 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.JujuHASpace,
 		controller.JujuManagementSpace,
 		controller.AuditLogExcludeMethods,
+		controller.TracingCollectorURL,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -65,3 +68,72 @@ func (s *ControllerSuite) TestPing(c *gc.C) {
 	gitjujutesting.MgoServer.Restart()
 	c.Assert(s.Controller.Ping(), gc.NotNil)
 }
+
+func (s *ControllerSuite) TestUpdateControllerConfig(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditLogMaxBackups:     "10",
+		controller.AuditLogExcludeMethods: []interface{}{"Client.FullStatus"},
+		controller.MaxLogsAge:             "96h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
+	c.Assert(cfg.AuditLogExcludeMethods(), jc.DeepEquals, set.NewStrings("Client.FullStatus"))
+	c.Assert(cfg.MaxLogsAge(), gc.Equals, 96*time.Hour)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigRemoveRevertsToDefault(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxLogsAge: "96h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.MaxLogsAge})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxLogsAge(), gc.Equals, controller.DefaultMaxLogsAgeDays*24*time.Hour)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigRejectsImmutable(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIPort: 1234,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
+	err = s.State.UpdateControllerConfig(nil, []string{controller.CACertKey})
+	c.Assert(err, gc.ErrorMatches, `can't change "ca-cert" after bootstrap`)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigRejectsUnknown(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"not-a-key": "value",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `unknown controller config setting "not-a-key"`)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigRejectsInvalidValue(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxLogsAge: "forever",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid logs prune interval in configuration: .*`)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxLogsAge(), gc.Equals, 72*time.Hour)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigSpaceNotAvailable(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("ha", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.JujuHASpace: "ha",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches,
+		`invalid config "juju-ha-space"="ha": machines with no addresses in this space: 0`)
+}
@@ -14,6 +14,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/mongo"
 )
@@ -235,6 +236,14 @@ type database struct {
 	// runTransactionObserver is passed on to txn.TransactionRunner, to be
 	// invoked after calls to Run and RunTransaction.
 	runTransactionObserver RunTransactionObserverFunc
+
+	// tracer, if non-nil, is used to record a span for each
+	// transaction run by TransactionRunner.
+	tracer *tracing.Tracer
+
+	// spanContext, if valid, is the parent of the
+	// spans recorded for transactions.
+	spanContext tracing.SpanContext
 }
 
 // RunTransactionObserverFunc is the type of a function to be called
@@ -244,14 +253,25 @@ type RunTransactionObserverFunc func(dbName, modelUUID string, ops []txn.Op, err
 func (db *database) copySession(modelUUID string) (*database, SessionCloser) {
 	session := db.raw.Session.Copy()
 	return &database{
-		raw:        db.raw.With(session),
-		schema:     db.schema,
-		modelUUID:  modelUUID,
-		runner:     db.runner,
-		ownSession: true,
+		raw:         db.raw.With(session),
+		schema:      db.schema,
+		modelUUID:   modelUUID,
+		runner:      db.runner,
+		ownSession:  true,
+		tracer:      db.tracer,
+		spanContext: db.spanContext,
 	}, session.Close
 }
 
+// withSpanContext returns a copy of the database sharing its session,
+// whose transaction spans are children of the given span.
+func (db *database) withSpanContext(spanContext tracing.SpanContext) *database {
+	dbCopy := *db
+	dbCopy.ownSession = false
+	dbCopy.spanContext = spanContext
+	return &dbCopy
+}
+
 // Copy is part of the Database interface.
 func (db *database) Copy() (Database, SessionCloser) {
 	return db.copySession(db.modelUUID)
@@ -339,6 +359,15 @@ func (db *database) TransactionRunner() (runner jujutxn.Runner, closer SessionCl
 		}
 		runner = jujutxn.NewRunner(params)
 	}
+	if db.tracer != nil {
+		runner = &tracingRunner{
+			rawRunner: runner,
+			tracer:    db.tracer,
+			parent:    db.spanContext,
+			dbName:    db.raw.Name,
+			modelUUID: db.modelUUID,
+		}
+	}
 	return &multiModelRunner{
 		rawRunner: runner,
 		modelUUID: db.modelUUID,
@@ -231,6 +231,7 @@ func addCharm(c *gc.C, st *State, series string, ch charm.Charm) *Charm {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -469,6 +470,22 @@ func MakeLogDoc(
 	}
 }
 
+func MakeLogDocWithFields(
+	entity names.Tag,
+	t time.Time,
+	module string,
+	location string,
+	level loggo.Level,
+	msg string,
+	labels []string,
+	fields map[string]string,
+) *logDoc {
+	doc := MakeLogDoc(entity, t, module, location, level, msg)
+	doc.Labels = labels
+	doc.Fields = fields
+	return doc
+}
+
 func SpaceDoc(s *Space) spaceDoc {
 	return s.doc
 }
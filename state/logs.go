@@ -9,6 +9,7 @@ package state
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/tomb.v1"
 
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/mongo"
 )
 
@@ -201,6 +203,11 @@ type logDoc struct {
 	Location string        `bson:"l"` // "filename:lineno"
 	Level    int           `bson:"v"`
 	Message  string        `bson:"x"`
+
+	// Labels and Fields hold any structured data attached to
+	// the record, e.g. ["upgrade"] and {"hook": "install"}.
+	Labels []string          `bson:"b,omitempty"`
+	Fields map[string]string `bson:"f,omitempty"`
 }
 
 type DbLogger struct {
@@ -248,6 +255,8 @@ func (logger *DbLogger) Log(records []LogRecord) error {
 			Location: r.Location,
 			Level:    int(r.Level),
 			Message:  r.Message,
+			Labels:   r.Labels,
+			Fields:   r.Fields,
 		})
 	}
 	_, err := bulk.Run()
@@ -258,7 +267,8 @@ func validateInputLogRecord(r LogRecord) error {
 	if r.Entity == nil {
 		return errors.NotValidf("missing Entity")
 	}
-	return nil
+	data := logfields.Data{Labels: r.Labels, Fields: r.Fields}
+	return errors.Trace(data.Validate())
 }
 
 // Close cleans up resources used by the DbLogger instance.
@@ -307,21 +317,32 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+
+	// structured fields
+	Labels []string
+	Fields map[string]string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
+//
+// If EndTime is set, only the records already in the logs collection
+// are returned; new records are not tailed.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
-	MinLevel      loggo.Level
-	InitialLines  int
-	NoTail        bool
-	IncludeEntity []string
-	ExcludeEntity []string
-	IncludeModule []string
-	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+	StartID         int64
+	StartTime       time.Time
+	EndTime         time.Time
+	MinLevel        loggo.Level
+	InitialLines    int
+	NoTail          bool
+	IncludeEntity   []string
+	ExcludeEntity   []string
+	IncludeModule   []string
+	ExcludeModule   []string
+	IncludeLabels   []string
+	IncludeFields   map[string]string
+	MessageContains string
+	Oplog           *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -420,7 +441,7 @@ func (t *logTailer) loop() error {
 		return err
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
 		return nil
 	}
 
@@ -619,8 +640,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeRange := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeRange["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeRange["$lte"] = params.EndTime.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeRange})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -641,6 +669,26 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeLabels) > 0 {
+		sel = append(sel, bson.DocElem{"b", bson.M{"$all": params.IncludeLabels}})
+	}
+	if len(params.IncludeFields) > 0 {
+		// Sort the field names so the selector is deterministic.
+		names := make([]string, 0, len(params.IncludeFields))
+		for name := range params.IncludeFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sel = append(sel, bson.DocElem{"f." + name, params.IncludeFields[name]})
+		}
+	}
+	if params.MessageContains != "" {
+		// The text is matched literally, so that it is not
+		// interpreted by mongo's regular expression engine.
+		pattern := regexp.QuoteMeta(params.MessageContains)
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: pattern}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -747,6 +795,8 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+		Labels:   doc.Labels,
+		Fields:   doc.Fields,
 	}
 	return rec, nil
 }
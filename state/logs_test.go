@@ -151,6 +151,8 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 		Location: "foo.go:99",
 		Level:    loggo.INFO,
 		Message:  "all is well",
+		Labels:   []string{"upgrade"},
+		Fields:   map[string]string{"hook": "install"},
 	}, {
 		Time:     t1,
 		Entity:   names.NewMachineTag("47"),
@@ -172,6 +174,8 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[0]["l"], gc.Equals, "foo.go:99")
 	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
 	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	c.Assert(docs[0]["b"], jc.DeepEquals, []interface{}{"upgrade"})
+	c.Assert(docs[0]["f"], jc.DeepEquals, bson.M{"hook": "install"})
 
 	c.Assert(docs[1]["t"], gc.Equals, t1.UnixNano())
 	c.Assert(docs[1]["n"], gc.Equals, "machine-47")
@@ -179,6 +183,22 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["b"], gc.IsNil)
+	c.Assert(docs[1]["f"], gc.IsNil)
+}
+
+func (s *LogsSuite) TestDbLoggerInvalidField(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+
+	err := logger.Log([]state.LogRecord{{
+		Time:    coretesting.ZeroTime(),
+		Entity:  names.NewMachineTag("45"),
+		Level:   loggo.INFO,
+		Message: "all is well",
+		Fields:  map[string]string{"$where": "1"},
+	}})
+	c.Assert(err, gc.ErrorMatches, `validating input log record: field name "\$where" not valid`)
 }
 
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
@@ -386,6 +406,41 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeWindowFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT, threshT.Add(5*time.Second), 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(6*time.Second), threshT.Add(10*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   threshT.Add(5 * time.Second),
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The tailer stops once the logs collection has been read.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -781,6 +836,65 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeFields(c *gc.C) {
+	install := logTemplate{Fields: map[string]string{"hook": "install", "unit": "mysql/0"}}
+	otherUnit := logTemplate{Fields: map[string]string{"hook": "install", "unit": "mysql/1"}}
+	configChanged := logTemplate{Fields: map[string]string{"hook": "config-changed", "unit": "mysql/0"}}
+	none := logTemplate{}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, install)
+		s.writeLogs(c, s.otherUUID, 1, otherUnit)
+		s.writeLogs(c, s.otherUUID, 1, configChanged)
+		s.writeLogs(c, s.otherUUID, 1, none)
+		s.writeLogs(c, s.otherUUID, 1, install)
+	}
+	params := state.LogTailerParams{
+		IncludeFields: map[string]string{"hook": "install", "unit": "mysql/0"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, install)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeLabels(c *gc.C) {
+	upgrade := logTemplate{Labels: []string{"upgrade", "slow"}}
+	slow := logTemplate{Labels: []string{"slow"}}
+	none := logTemplate{}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, slow)
+		s.writeLogs(c, s.otherUUID, 1, upgrade)
+		s.writeLogs(c, s.otherUUID, 1, none)
+	}
+	params := state.LogTailerParams{
+		IncludeLabels: []string{"upgrade"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, upgrade)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestMessageContains(c *gc.C) {
+	started := logTemplate{Message: "started mysql (pid 42) on port 3306"}
+	stopped := logTemplate{Message: "stopped mysql"}
+	other := logTemplate{Message: "started mysqld on port 3306"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, started)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, stopped)
+	}
+	params := state.LogTailerParams{
+		// The text contains regular expression metacharacters,
+		// which must be matched literally.
+		MessageContains: "mysql (pid",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, started)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -810,6 +924,8 @@ type logTemplate struct {
 	Location string
 	Level    loggo.Level
 	Message  string
+	Labels   []string
+	Fields   map[string]string
 }
 
 // emptyTag gives us an explicit way to specify an empty tag for the
@@ -891,13 +1007,15 @@ func (s *LogTailerSuite) normaliseLogTemplate(lt *logTemplate) {
 
 func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface{} {
 	s.normaliseLogTemplate(&lt)
-	return state.MakeLogDoc(
+	return state.MakeLogDocWithFields(
 		lt.Entity,
 		t,
 		lt.Module,
 		lt.Location,
 		lt.Level,
 		lt.Message,
+		lt.Labels,
+		lt.Fields,
 	)
 }
 
@@ -918,6 +1036,8 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Labels, jc.DeepEquals, lt.Labels)
+			c.Assert(log.Fields, jc.DeepEquals, lt.Fields)
 			count++
 			if count == expectedCount {
 				return
@@ -694,6 +694,7 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		Exposed:              application.doc.Exposed,
 		PasswordHash:         application.doc.PasswordHash,
 		MinUnits:             application.doc.MinUnits,
+		DesiredScale:         application.doc.DesiredScale,
 		EndpointBindings:     map[string]string(ctx.endpoingBindings[globalKey]),
 		ApplicationConfig:    applicationConfigDoc.Settings,
 		CharmConfig:          applicationCharmSettingsDoc.Settings,
@@ -133,6 +133,23 @@ func (s *MigrationBaseSuite) makeUnitWithStorage(c *gc.C) (*state.Application, *
 	return service, unit, storageTag
 }
 
+// makeCAASApplicationWithScale adds a CAAS model with an application
+// scaled to the given number of pods, but with no units.
+func (s *MigrationBaseSuite) makeCAASApplicationWithScale(c *gc.C, scale int) (*state.State, *state.Application) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	f := factory.NewFactory(st)
+	application := f.MakeApplication(c, &factory.ApplicationParams{
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	err := application.SetScale(scale)
+	c.Assert(err, jc.ErrorIsNil)
+	return st, application
+}
+
 type MigrationExportSuite struct {
 	MigrationBaseSuite
 }
@@ -435,6 +452,20 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 	s.checkStatusHistory(c, history[:addedHistoryCount], status.Active)
 }
 
+func (s *MigrationExportSuite) TestCAASApplicationScale(c *gc.C) {
+	s.SetFeatureFlags(feature.StrictMigration, feature.CAAS)
+	st, application := s.makeCAASApplicationWithScale(c, 3)
+
+	model, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Name(), gc.Equals, application.Name())
+	c.Assert(applications[0].Units(), gc.HasLen, 0)
+	c.Assert(applications[0].DesiredScale(), gc.Equals, 3)
+}
+
 func (s *MigrationExportSuite) TestMultipleApplications(c *gc.C) {
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "first"})
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "second"})
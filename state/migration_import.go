@@ -1015,6 +1015,7 @@ func (i *importer) makeApplicationDoc(a description.Application) (*applicationDo
 		PasswordHash:         a.PasswordHash(),
 		Life:                 Alive,
 		UnitCount:            len(a.Units()),
+		DesiredScale:         a.DesiredScale(),
 		RelationCount:        i.relationCount(a.Name()),
 		Exposed:              a.Exposed(),
 		MinUnits:             a.MinUnits(),
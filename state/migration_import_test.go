@@ -21,6 +21,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/permission"
@@ -557,6 +558,22 @@ func (s *MigrationImportSuite) TestCharmRevSequencesNotImported(c *gc.C) {
 	c.Check(nextVal, gc.Equals, 0)
 }
 
+func (s *MigrationImportSuite) TestCAASApplicationScale(c *gc.C) {
+	s.SetFeatureFlags(feature.CAAS)
+	st, application := s.makeCAASApplicationWithScale(c, 3)
+
+	out, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := utils.MustNewUUID().String()
+	_, newSt, err := s.State.Import(newModel(out, uuid, "new"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.GetScale(), gc.Equals, 3)
+}
+
 func (s *MigrationImportSuite) TestApplicationsSubordinatesAfter(c *gc.C) {
 	// Test for https://bugs.launchpad.net/juju/+bug/1650249
 	subordinate := s.Factory.MakeApplication(c, &factory.ApplicationParams{
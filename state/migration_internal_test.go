@@ -122,6 +122,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Transaction stuff.
 		"txns",
 		"txns.log",
+		// The audit log is controller global, not migrated.
+		auditLogC,
 
 		// We don't import any of the migration collections.
 		migrationsC,
@@ -198,6 +200,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// TODO(caas)
 		containerSpecsC,
+
+		// TODO(generations)
+		generationsC,
+
+		// TODO(upgradeseries)
+		upgradeSeriesLocksC,
 	)
 
 	envCollections := set.NewStrings()
@@ -377,6 +385,7 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"ForceCharm",
 		"Exposed",
 		"MinUnits",
+		"DesiredScale",
 		"MetricCredentials",
 		"PasswordHash",
 	)
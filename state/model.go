@@ -373,6 +373,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		st.newPolicy,
 		st.clock(),
 		st.runTransactionObserver,
+		st.tracer,
 	)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "could not create state for new model")
@@ -0,0 +1,577 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	jujutxn "github.com/juju/txn"
+
+	"github.com/juju/juju/core/application"
+)
+
+// Generation represents a named branch of pending application config
+// and charm settings changes in a model. Units tracking the branch see
+// the staged charm settings in place of the application's current
+// settings until the branch is either committed, which applies the
+// staged changes to the application, or aborted, which discards them.
+type Generation struct {
+	st  *State
+	doc generationDoc
+}
+
+type generationDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Name      string `bson:"name"`
+
+	// AssignedUnits holds the names of the units tracking the
+	// branch, keyed by application name.
+	AssignedUnits map[string][]string `bson:"assigned-units"`
+
+	// Config holds the staged charm settings, keyed by application
+	// name. A nil value resets the setting to the charm default when
+	// the branch is committed. Setting names are escaped for storage.
+	Config map[string]map[string]interface{} `bson:"charm-config"`
+
+	// AppConfig holds the staged application config, keyed by
+	// application name. A nil value resets the attribute to its
+	// default when the branch is committed. Attribute names are
+	// escaped for storage.
+	AppConfig map[string]map[string]interface{} `bson:"app-config"`
+
+	Created   int64  `bson:"created"`
+	CreatedBy string `bson:"created-by"`
+
+	TxnRevno int64 `bson:"txn-revno"`
+}
+
+// Name returns the name of the branch.
+func (g *Generation) Name() string {
+	return g.doc.Name
+}
+
+// AssignedUnits returns the names of the units tracking the branch,
+// keyed by application name.
+func (g *Generation) AssignedUnits() map[string][]string {
+	result := make(map[string][]string, len(g.doc.AssignedUnits))
+	for app, units := range g.doc.AssignedUnits {
+		result[app] = append([]string(nil), units...)
+	}
+	return result
+}
+
+// Config returns the charm settings staged on the branch, keyed by
+// application name. A nil value indicates that the setting will be
+// reset to the charm default.
+func (g *Generation) Config() map[string]charm.Settings {
+	result := make(map[string]charm.Settings, len(g.doc.Config))
+	for app := range g.doc.Config {
+		result[app] = g.appConfig(app)
+	}
+	return result
+}
+
+// appConfig returns the charm settings staged on the branch for the
+// named application, with setting names unescaped.
+func (g *Generation) appConfig(appName string) charm.Settings {
+	return charm.Settings(copyMap(g.doc.Config[appName], unescapeReplacer.Replace))
+}
+
+// ApplicationConfig returns the application config staged on the
+// branch, keyed by application name. A nil value indicates that the
+// attribute will be reset to its default.
+func (g *Generation) ApplicationConfig() map[string]application.ConfigAttributes {
+	result := make(map[string]application.ConfigAttributes, len(g.doc.AppConfig))
+	for app := range g.doc.AppConfig {
+		result[app] = g.appApplicationConfig(app)
+	}
+	return result
+}
+
+// appApplicationConfig returns the application config staged on the
+// branch for the named application, with attribute names unescaped.
+func (g *Generation) appApplicationConfig(appName string) application.ConfigAttributes {
+	return application.ConfigAttributes(copyMap(g.doc.AppConfig[appName], unescapeReplacer.Replace))
+}
+
+// Created returns the time at which the branch was created.
+func (g *Generation) Created() time.Time {
+	return time.Unix(g.doc.Created, 0).UTC()
+}
+
+// CreatedBy returns the name of the user that created the branch.
+func (g *Generation) CreatedBy() string {
+	return g.doc.CreatedBy
+}
+
+// Refresh refreshes the contents of the branch from the underlying
+// state.
+func (g *Generation) Refresh() error {
+	gen, err := g.st.Branch(g.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	g.doc = gen.doc
+	return nil
+}
+
+// AssignUnit indicates that the named unit should see the charm
+// settings staged on the branch. A unit may only track one branch.
+func (g *Generation) AssignUnit(unitName string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		unit, err := g.st.Unit(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if unit.Life() != Alive {
+			return nil, errors.Errorf("unit %q is not alive", unitName)
+		}
+		appName := unit.ApplicationName()
+		for _, name := range g.doc.AssignedUnits[appName] {
+			if name == unitName {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		current, err := g.st.unitBranch(appName, unitName)
+		if err == nil {
+			return nil, errors.Errorf("unit %q is already tracking branch %q", unitName, current.Name())
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     unit.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{{"$push", bson.D{{"assigned-units." + appName, unitName}}}},
+		}}, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot assign unit %q to branch %q", unitName, g.doc.Name)
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// UpdateCharmConfig stages changes to the named application's charm
+// settings on the branch. Values set to nil will reset the setting to
+// the charm default when the branch is committed; unknown and invalid
+// values will return an error.
+func (g *Generation) UpdateCharmConfig(appName string, changes charm.Settings) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		validated, err := ch.Config().ValidateSettings(changes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		config := g.appConfig(appName)
+		if config == nil {
+			config = make(charm.Settings)
+		}
+		for name, value := range validated {
+			config[name] = value
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"charm-config." + appName, copyMap(config, escapeReplacer.Replace)},
+			}}},
+		}}, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update charm config for %q on branch %q", appName, g.doc.Name)
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// UpdateApplicationConfig stages changes to the named application's
+// config on the branch. Attributes named in reset will be restored to
+// their defaults when the branch is committed. The application's
+// current config, with the changes already staged and these changes
+// applied, is validated against the supplied schema; unknown and
+// invalid values will return an error.
+func (g *Generation) UpdateApplicationConfig(
+	appName string,
+	changes application.ConfigAttributes,
+	reset []string,
+	schema environschema.Fields,
+	defaults schema.Defaults,
+) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, err := app.ApplicationConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		staged := g.appApplicationConfig(appName)
+		if staged == nil {
+			staged = make(application.ConfigAttributes)
+		}
+		for name, value := range changes {
+			staged[name] = value
+		}
+		for _, name := range reset {
+			staged[name] = nil
+		}
+		attrs := make(map[string]interface{})
+		for name, value := range current {
+			attrs[name] = value
+		}
+		for name, value := range staged {
+			if value == nil {
+				delete(attrs, name)
+			} else {
+				attrs[name] = value
+			}
+		}
+		newConfig, err := application.NewConfig(attrs, schema, defaults)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := newConfig.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Stage the coerced values, so that they are applied with
+		// the correct types when the branch is committed.
+		coerced := newConfig.Attributes()
+		for name, value := range staged {
+			if value != nil {
+				staged[name] = coerced[name]
+			}
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"app-config." + appName, copyMap(staged, escapeReplacer.Replace)},
+			}}},
+		}}, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update application config for %q on branch %q", appName, g.doc.Name)
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// Commit applies the application config and charm settings staged on
+// the branch to the applications concerned, so that they are seen by
+// all units, and removes the branch. Changes staged for applications
+// that have since been removed are discarded.
+func (g *Generation) Commit(userName string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := checkModelActive(g.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Remove: true,
+		}}
+		for _, appName := range g.applicationNames() {
+			app, err := g.st.Application(appName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			charmOps, err := g.commitCharmConfigOps(app)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			appOps, err := g.commitApplicationConfigOps(app)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, charmOps...)
+			ops = append(ops, appOps...)
+		}
+		return ops, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot commit branch %q", g.doc.Name)
+	}
+	logger.Infof("branch %q committed by %q", g.doc.Name, userName)
+	return nil
+}
+
+// commitCharmConfigOps returns the operations required to apply the
+// settings staged on the branch to the given application's charm
+// settings.
+func (g *Generation) commitCharmConfigOps(app *Application) ([]txn.Op, error) {
+	if len(g.doc.Config[app.Name()]) == 0 {
+		return nil, nil
+	}
+	node, err := readSettings(g.st.db(), settingsC, app.charmConfigKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	options := ch.Config().Options
+	for name, value := range g.appConfig(app.Name()) {
+		// The charm may have been upgraded since the setting
+		// was staged; settings it no longer knows are dropped.
+		if _, ok := options[name]; !ok {
+			continue
+		}
+		if value == nil {
+			node.Delete(name)
+		} else {
+			node.Set(name, value)
+		}
+	}
+	_, ops := node.settingsUpdateOps()
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	ops[0].Assert = bson.D{{"version", node.version}}
+	return append([]txn.Op{{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: bson.D{{"charmurl", app.doc.CharmURL}},
+	}}, ops...), nil
+}
+
+// commitApplicationConfigOps returns the operations required to apply
+// the application config staged on the branch to the given
+// application's config.
+func (g *Generation) commitApplicationConfigOps(app *Application) ([]txn.Op, error) {
+	staged := g.appApplicationConfig(app.Name())
+	if len(staged) == 0 {
+		return nil, nil
+	}
+	node, err := readSettings(g.st.db(), settingsC, app.applicationConfigKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, value := range staged {
+		if value == nil {
+			node.Delete(name)
+		} else {
+			node.Set(name, value)
+		}
+	}
+	_, ops := node.settingsUpdateOps()
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	ops[0].Assert = bson.D{{"version", node.version}}
+	return ops, nil
+}
+
+// Abort discards the changes staged on the branch and removes it.
+// Units tracking the branch revert to the application's current
+// settings.
+func (g *Generation) Abort(userName string) error {
+	ops := []txn.Op{{
+		C:      generationsC,
+		Id:     g.doc.DocId,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := g.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("branch %q", g.doc.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot abort branch %q", g.doc.Name)
+	}
+	logger.Infof("branch %q aborted by %q", g.doc.Name, userName)
+	return nil
+}
+
+// applicationNames returns the sorted names of the applications with
+// config or settings staged on the branch.
+func (g *Generation) applicationNames() []string {
+	names := set.NewStrings()
+	for app := range g.doc.Config {
+		names.Add(app)
+	}
+	for app := range g.doc.AppConfig {
+		names.Add(app)
+	}
+	return names.SortedValues()
+}
+
+// AddBranch creates a new branch with the given name, on which
+// application config and charm settings can be staged for a chosen set
+// of units.
+func (st *State) AddBranch(name, userName string) error {
+	if name == "" {
+		return errors.NotValidf("empty branch name")
+	}
+	doc := generationDoc{
+		DocId:         name,
+		Name:          name,
+		AssignedUnits: map[string][]string{},
+		Config:        map[string]map[string]interface{}{},
+		AppConfig:     map[string]map[string]interface{}{},
+		Created:       st.nowToTheSecond().Unix(),
+		CreatedBy:     userName,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := st.Branch(name); err == nil {
+			return nil, errors.AlreadyExistsf("branch %q", name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     name,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}, model.assertActiveOp()}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot add branch %q", name)
+	}
+	return nil
+}
+
+// Branch returns the branch with the given name.
+func (st *State) Branch(name string) (*Generation, error) {
+	coll, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	var doc generationDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("branch %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get branch %q", name)
+	}
+	return &Generation{st: st, doc: doc}, nil
+}
+
+// Branches returns all the branches in the model, sorted by name.
+func (st *State) Branches() ([]*Generation, error) {
+	coll, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	var docs []generationDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get branches")
+	}
+	result := make([]*Generation, len(docs))
+	for i, doc := range docs {
+		result[i] = &Generation{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// unitBranch returns the branch tracked by the named unit of the
+// named application.
+func (st *State) unitBranch(appName, unitName string) (*Generation, error) {
+	coll, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	var doc generationDoc
+	err := coll.Find(bson.D{{"assigned-units." + appName, unitName}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("branch for unit %q", unitName)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get branch for unit %q", unitName)
+	}
+	return &Generation{st: st, doc: doc}, nil
+}
+
+// Branch returns the name of the branch tracked by the unit, or an
+// empty string if the unit sees the application's current settings.
+func (u *Unit) Branch() (string, error) {
+	gen, err := u.st.unitBranch(u.doc.Application, u.doc.Name)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return gen.Name(), nil
+}
+
+// branchCharmSettings applies the charm settings staged on the branch
+// tracked by the unit, if any, to the supplied settings. Staged values
+// for options unknown to the unit's charm are ignored and nil values
+// are replaced by the option's default.
+func (u *Unit) branchCharmSettings(settings charm.Settings) (charm.Settings, error) {
+	gen, err := u.st.unitBranch(u.doc.Application, u.doc.Name)
+	if errors.IsNotFound(err) {
+		return settings, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	staged := gen.appConfig(u.doc.Application)
+	if len(staged) == 0 {
+		return settings, nil
+	}
+	ch, err := u.st.Charm(u.doc.CharmURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	options := ch.Config().Options
+	defaults := ch.Config().DefaultSettings()
+	for name, value := range staged {
+		if _, ok := options[name]; !ok {
+			continue
+		}
+		if value == nil {
+			value = defaults[name]
+		}
+		settings[name] = value
+	}
+	return settings, nil
+}
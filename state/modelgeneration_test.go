@@ -0,0 +1,277 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type GenerationSuite struct {
+	ConnSuite
+	charm       *state.Charm
+	application *state.Application
+	unit0       *state.Unit
+	unit1       *state.Unit
+}
+
+var _ = gc.Suite(&GenerationSuite{})
+
+func (s *GenerationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "wordpress")
+	s.application = s.AddTestingApplication(c, "wordpress", s.charm)
+	var err error
+	s.unit0, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit0.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit1, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit1.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *GenerationSuite) addBranch(c *gc.C, name string) *state.Generation {
+	err := s.State.AddBranch(name, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	gen, err := s.State.Branch(name)
+	c.Assert(err, jc.ErrorIsNil)
+	return gen
+}
+
+func (s *GenerationSuite) TestAddBranch(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	c.Assert(gen.Name(), gc.Equals, "new-config")
+	c.Assert(gen.CreatedBy(), gc.Equals, "admin")
+	c.Assert(gen.AssignedUnits(), gc.HasLen, 0)
+	c.Assert(gen.Config(), gc.HasLen, 0)
+}
+
+func (s *GenerationSuite) TestAddBranchAlreadyExists(c *gc.C) {
+	s.addBranch(c, "new-config")
+	err := s.State.AddBranch("new-config", "admin")
+	c.Assert(err, gc.ErrorMatches, `cannot add branch "new-config": branch "new-config" already exists`)
+}
+
+func (s *GenerationSuite) TestBranchNotFound(c *gc.C) {
+	_, err := s.State.Branch("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *GenerationSuite) TestBranches(c *gc.C) {
+	s.addBranch(c, "b")
+	s.addBranch(c, "a")
+	gens, err := s.State.Branches()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gens, gc.HasLen, 2)
+	c.Assert(gens[0].Name(), gc.Equals, "a")
+	c.Assert(gens[1].Name(), gc.Equals, "b")
+}
+
+func (s *GenerationSuite) TestAssignUnit(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.AssignUnit(s.unit0.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.AssignedUnits(), jc.DeepEquals, map[string][]string{
+		"wordpress": {"wordpress/0"},
+	})
+
+	// Assigning a second time is a no-op.
+	err = gen.AssignUnit(s.unit0.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.AssignedUnits()["wordpress"], gc.HasLen, 1)
+
+	branch, err := s.unit0.Branch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch, gc.Equals, "new-config")
+	branch, err = s.unit1.Branch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch, gc.Equals, "")
+}
+
+func (s *GenerationSuite) TestAssignUnitAlreadyTracking(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.AssignUnit(s.unit0.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.addBranch(c, "other-config")
+	err = other.AssignUnit(s.unit0.Name())
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/0" to branch "other-config": unit "wordpress/0" is already tracking branch "new-config"`)
+}
+
+func (s *GenerationSuite) TestAssignUnitNotFound(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.AssignUnit("wordpress/42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *GenerationSuite) TestUpdateCharmConfig(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "branch title"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.Config(), jc.DeepEquals, map[string]charm.Settings{
+		"wordpress": {"blog-title": "branch title"},
+	})
+}
+
+func (s *GenerationSuite) TestUpdateCharmConfigInvalid(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.UpdateCharmConfig("wordpress", charm.Settings{"no-such-option": "x"})
+	c.Assert(err, gc.ErrorMatches, `cannot update charm config for "wordpress" on branch "new-config": unknown option "no-such-option"`)
+}
+
+func (s *GenerationSuite) TestUpdateApplicationConfig(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.UpdateApplicationConfig("wordpress",
+		application.ConfigAttributes{"title": "branch title", "skill-level": "42"},
+		[]string{"outlook"}, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.ApplicationConfig(), jc.DeepEquals, map[string]application.ConfigAttributes{
+		"wordpress": {"title": "branch title", "skill-level": 42, "outlook": nil},
+	})
+
+	cfg, err := s.application.ApplicationConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["title"], gc.IsNil)
+}
+
+func (s *GenerationSuite) TestUpdateApplicationConfigInvalid(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.UpdateApplicationConfig("wordpress",
+		application.ConfigAttributes{"skill-level": "lots"},
+		nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update application config for "wordpress" on branch "new-config": .*skill-level.*`)
+	c.Assert(gen.ApplicationConfig(), gc.HasLen, 0)
+}
+
+func (s *GenerationSuite) TestConfigSettingsOnBranch(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "branch title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = gen.AssignUnit(s.unit0.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := s.unit0.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["blog-title"], gc.Equals, "branch title")
+
+	settings, err = s.unit1.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["blog-title"], gc.Equals, "My Title")
+}
+
+func (s *GenerationSuite) TestCommit(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "branch title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = gen.AssignUnit(s.unit0.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = gen.Commit("admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Branch("new-config")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	cfg, err := s.application.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["blog-title"], gc.Equals, "branch title")
+	settings, err := s.unit1.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["blog-title"], gc.Equals, "branch title")
+}
+
+func (s *GenerationSuite) TestCommitResetsToDefault(c *gc.C) {
+	err := s.application.UpdateCharmConfig(charm.Settings{"blog-title": "current title"})
+	c.Assert(err, jc.ErrorIsNil)
+	gen := s.addBranch(c, "new-config")
+	err = gen.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": nil})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = gen.Commit("admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.application.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["blog-title"], gc.Equals, "My Title")
+}
+
+func (s *GenerationSuite) TestCommitApplicationConfig(c *gc.C) {
+	schema := sampleApplicationConfigSchema()
+	err := s.application.UpdateApplicationConfig(
+		application.ConfigAttributes{"title": "current title", "outlook": "positive"},
+		nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	gen := s.addBranch(c, "new-config")
+	err = gen.UpdateApplicationConfig("wordpress",
+		application.ConfigAttributes{"title": "branch title", "skill-level": "42"},
+		[]string{"outlook"}, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = gen.Commit("admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.application.ApplicationConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, application.ConfigAttributes{
+		"title":       "branch title",
+		"skill-level": 42,
+	})
+}
+
+func (s *GenerationSuite) TestAbort(c *gc.C) {
+	gen := s.addBranch(c, "new-config")
+	err := gen.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "branch title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = gen.AssignUnit(s.unit0.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = gen.Abort("admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Branch("new-config")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	settings, err := s.unit0.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["blog-title"], gc.Equals, "My Title")
+
+	err = gen.Abort("admin")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *GenerationSuite) TestWatchConfigSettingsBranch(c *gc.C) {
+	w, err := s.unit0.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Staging config on a branch the unit does not track is not reported.
+	gen := s.addBranch(c, "new-config")
+	err = gen.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "branch title"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Tracking the branch is reported.
+	err = gen.AssignUnit(s.unit0.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Tracking by another unit is not.
+	err = gen.AssignUnit(s.unit1.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Aborting the branch is reported.
+	err = gen.Abort("admin")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/mongo"
 )
 
@@ -44,6 +45,10 @@ type OpenParams struct {
 	// or not.
 	RunTransactionObserver RunTransactionObserverFunc
 
+	// Tracer, if non-nil, is used to record a span for each
+	// mgo/txn transaction that is run.
+	Tracer *tracing.Tracer
+
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
@@ -90,6 +95,7 @@ func OpenController(args OpenParams) (*Controller, error) {
 		session:                session,
 		newPolicy:              args.NewPolicy,
 		runTransactionObserver: args.RunTransactionObserver,
+		tracer:                 args.Tracer,
 	}, nil
 }
 
@@ -111,6 +117,7 @@ func Open(args OpenParams) (*State, error) {
 		args.NewPolicy,
 		args.Clock,
 		args.RunTransactionObserver,
+		args.Tracer,
 	)
 	if err != nil {
 		session.Close()
@@ -139,8 +146,9 @@ func open(
 	newPolicy NewPolicyFunc,
 	clock clock.Clock,
 	runTransactionObserver RunTransactionObserverFunc,
+	tracer *tracing.Tracer,
 ) (*State, error) {
-	st, err := newState(controllerModelTag, controllerModelTag, session, newPolicy, clock, runTransactionObserver)
+	st, err := newState(controllerModelTag, controllerModelTag, session, newPolicy, clock, runTransactionObserver, tracer)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -168,6 +176,7 @@ func newState(
 	newPolicy NewPolicyFunc,
 	clock clock.Clock,
 	runTransactionObserver RunTransactionObserverFunc,
+	tracer *tracing.Tracer,
 ) (_ *State, err error) {
 
 	defer func() {
@@ -181,6 +190,7 @@ func newState(
 		schema:                 allCollections(),
 		modelUUID:              modelTag.Id(),
 		runTransactionObserver: runTransactionObserver,
+		tracer:                 tracer,
 	}
 
 	// Create State.
@@ -192,6 +202,7 @@ func newState(
 		database:               db,
 		newPolicy:              newPolicy,
 		runTransactionObserver: runTransactionObserver,
+		tracer:                 tracer,
 	}
 	if newPolicy != nil {
 		st.policy = newPolicy(st)
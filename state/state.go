@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/core/application"
 	coreglobalclock "github.com/juju/juju/core/globalclock"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
@@ -87,6 +88,7 @@ type State struct {
 	policy                 Policy
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
+	tracer                 *tracing.Tracer
 
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
@@ -534,6 +536,20 @@ func (st *State) db() Database {
 	return st.database
 }
 
+// WithSpanContext returns a State sharing st's connection, whose
+// transactions are traced as children of the given span. The
+// returned State must not be closed; it is valid for as long as
+// st is. If transactions are not being traced, st is returned.
+func (st *State) WithSpanContext(spanContext tracing.SpanContext) *State {
+	db, ok := st.database.(*database)
+	if !ok || st.tracer == nil || !spanContext.IsValid() {
+		return st
+	}
+	stCopy := *st
+	stCopy.database = db.withSpanContext(spanContext)
+	return &stCopy
+}
+
 // txnLogWatcher returns the TxnLogWatcher for the State. It is part
 // of the modelBackend interface.
 func (st *State) txnLogWatcher() watcher.BaseWatcher {
@@ -1022,47 +1038,12 @@ type AddApplicationArgs struct {
 func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add application %q", args.Name)
 
-	// Sanity checks.
-	if !names.IsValidApplication(args.Name) {
-		return nil, errors.Errorf("invalid name")
-	}
-	if args.Charm == nil {
-		return nil, errors.Errorf("charm is nil")
-	}
-	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
-		return nil, errors.Errorf("AttachStorage is non-empty but NumUnits is %d, must be 1", args.NumUnits)
-	}
-
-	if err := validateCharmVersion(args.Charm); err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
-		return nil, errors.Trace(err)
-	} else if exists {
-		return nil, errors.Errorf("application already exists")
-	}
-	if err := checkModelActive(st); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if args.Storage == nil {
-		args.Storage = make(map[string]StorageConstraints)
-	}
-
-	// Perform model specific arg processing.
 	model, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	switch model.Type() {
-	case ModelTypeIAAS:
-		if err := st.processIAASModelApplicationArgs(&args); err != nil {
-			return nil, errors.Trace(err)
-		}
-	case ModelTypeCAAS:
-		if err := st.processCAASModelApplicationArgs(&args); err != nil {
-			return nil, errors.Trace(err)
-		}
+	if err := st.processAddApplicationArgs(model.Type(), &args); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	applicationID := st.docID(args.Name)
@@ -1083,6 +1064,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		RelationCount: len(peers),
 		Life:          Alive,
 	}
+	if model.Type() == ModelTypeCAAS {
+		appDoc.DesiredScale = args.NumUnits
+	}
 
 	app := newApplication(st, appDoc)
 
@@ -1213,6 +1197,85 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	return nil, errors.Trace(err)
 }
 
+// ValidateAddApplication checks that an application could be added
+// with the supplied arguments, without changing the model. It returns
+// a copy of the arguments with any defaults, such as the series and
+// storage constraints, filled in as AddApplication would record them.
+func (st *State) ValidateAddApplication(args AddApplicationArgs) (_ AddApplicationArgs, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add application %q", args.Name)
+
+	model, err := st.Model()
+	if err != nil {
+		return AddApplicationArgs{}, errors.Trace(err)
+	}
+	// Storage defaults are filled in place, so work on a copy to
+	// leave the caller's constraints untouched.
+	storageCons := make(map[string]StorageConstraints, len(args.Storage))
+	for name, cons := range args.Storage {
+		storageCons[name] = cons
+	}
+	args.Storage = storageCons
+	if err := st.processAddApplicationArgs(model.Type(), &args); err != nil {
+		return AddApplicationArgs{}, errors.Trace(err)
+	}
+	bindings, _, err := mergeBindings(args.EndpointBindings, nil, args.Charm.Meta())
+	if err != nil {
+		return AddApplicationArgs{}, errors.Trace(err)
+	}
+	if err := validateEndpointBindingsForCharm(st, bindings, args.Charm.Meta()); err != nil {
+		return AddApplicationArgs{}, errors.Trace(err)
+	}
+	args.EndpointBindings = bindings
+	if err := args.ApplicationConfig.Validate(); err != nil {
+		return AddApplicationArgs{}, errors.Trace(err)
+	}
+	return args, nil
+}
+
+// processAddApplicationArgs performs the checks common to adding and
+// validating an application, and fills in defaults for the model type.
+func (st *State) processAddApplicationArgs(modelType ModelType, args *AddApplicationArgs) error {
+	// Sanity checks.
+	if !names.IsValidApplication(args.Name) {
+		return errors.Errorf("invalid name")
+	}
+	if args.Charm == nil {
+		return errors.Errorf("charm is nil")
+	}
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return errors.Errorf("AttachStorage is non-empty but NumUnits is %d, must be 1", args.NumUnits)
+	}
+
+	if err := validateCharmVersion(args.Charm); err != nil {
+		return errors.Trace(err)
+	}
+
+	if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
+		return errors.Trace(err)
+	} else if exists {
+		return errors.Errorf("application already exists")
+	}
+	if err := checkModelActive(st); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Storage == nil {
+		args.Storage = make(map[string]StorageConstraints)
+	}
+
+	// Perform model specific arg processing.
+	switch modelType {
+	case ModelTypeIAAS:
+		if err := st.processIAASModelApplicationArgs(args); err != nil {
+			return errors.Trace(err)
+		}
+	case ModelTypeCAAS:
+		if err := st.processCAASModelApplicationArgs(args); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func (st *State) processIAASModelApplicationArgs(args *AddApplicationArgs) error {
 	im, err := st.IAASModel()
 	if err != nil {
@@ -1363,6 +1426,10 @@ func (st *State) processCAASModelApplicationArgs(args *AddApplicationArgs) error
 		}
 	}
 
+	if err := addDefaultCAASStorageConstraints(st, args.Storage, args.Charm.Meta()); err != nil {
+		return errors.Trace(err)
+	}
+
 	// TODO(caas) restrict the series to CAAS series.
 	// TODO(caas) check that AddApplicationArgs doesn't
 	// contain IAAS-specific things.
@@ -29,6 +29,7 @@ import (
 	mgotxn "gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/agent"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
@@ -1575,6 +1576,105 @@ func (s *StateSuite) TestAddCAASApplication(c *gc.C) {
 	c.Assert(ch.URL(), gc.DeepEquals, ch.URL())
 }
 
+func (s *StateSuite) TestAddCAASApplicationStorageDefaults(c *gc.C) {
+	s.SetFeatureFlags(feature.CAAS)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	defer st.Close()
+	f := factory.NewFactory(st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "storage-filesystem"})
+
+	app, err := st.AddApplication(state.AddApplicationArgs{Name: "storage-filesystem", Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := app.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data": {Pool: "kubernetes", Size: 1024, Count: 1},
+	})
+}
+
+func (s *StateSuite) TestAddCAASApplicationBlockStorage(c *gc.C) {
+	s.SetFeatureFlags(feature.CAAS)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	defer st.Close()
+	f := factory.NewFactory(st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "storage-block"})
+
+	_, err := st.AddApplication(state.AddApplicationArgs{Name: "storage-block", Charm: ch})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block": charm "storage-block" store "data": block storage on CAAS models not supported`)
+}
+
+func (s *StateSuite) TestAddCAASApplicationStoragePool(c *gc.C) {
+	s.SetFeatureFlags(feature.CAAS)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	defer st.Close()
+	pm := poolmanager.New(state.NewStateSettings(st), k8sprovider.StorageProviders())
+	_, err := pm.Create("fast", k8sprovider.K8s_ProviderType, map[string]interface{}{"storage-class": "fast"})
+	c.Assert(err, jc.ErrorIsNil)
+	f := factory.NewFactory(st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "storage-filesystem"})
+
+	app, err := st.AddApplication(state.AddApplicationArgs{
+		Name: "storage-filesystem", Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "fast"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := app.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data": {Pool: "fast", Size: 1024, Count: 1},
+	})
+}
+
+func (s *StateSuite) TestAddCAASApplicationStoragePoolNotFound(c *gc.C) {
+	s.SetFeatureFlags(feature.CAAS)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	defer st.Close()
+	f := factory.NewFactory(st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "storage-filesystem"})
+
+	_, err := st.AddApplication(state.AddApplicationArgs{
+		Name: "storage-filesystem", Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "fast"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-filesystem": charm "storage-filesystem" store "data": pool "fast" not found`)
+}
+
+func (s *StateSuite) TestAddCAASApplicationStoragePoolNotKubernetes(c *gc.C) {
+	s.SetFeatureFlags(feature.CAAS)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name: "caas-model",
+		Type: state.ModelTypeCAAS, CloudRegion: "<none>",
+		StorageProviderRegistry: factory.NilStorageProviderRegistry{}})
+	defer st.Close()
+	f := factory.NewFactory(st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "storage-filesystem"})
+
+	_, err := st.AddApplication(state.AddApplicationArgs{
+		Name: "storage-filesystem", Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "rootfs"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-filesystem": charm "storage-filesystem" store "data": "rootfs" storage provider on CAAS models not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *StateSuite) TestAddApplicationWithNilCharmConfigValues(c *gc.C) {
 	ch := s.AddTestingCharm(c, "dummy")
 	insettings := charm.Settings{"tuning": nil}
@@ -1646,6 +1746,53 @@ func (s *StateSuite) TestAddApplicationSameLocalExists(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot add application "s0": application already exists`)
 }
 
+func (s *StateSuite) TestValidateAddApplication(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": {Count: 1},
+	}
+	args, err := s.State.ValidateAddApplication(state.AddApplicationArgs{
+		Name:    "storage-block",
+		Charm:   ch,
+		Storage: storage,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.Series, gc.Equals, "quantal")
+	c.Assert(args.Storage, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data":    {Pool: "loop", Count: 1, Size: 1024},
+		"allecto": {Pool: "loop", Count: 0, Size: 1024},
+	})
+	// The caller's constraints are left alone.
+	c.Assert(storage, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data": {Count: 1},
+	})
+
+	// Nothing was added to the model.
+	_, err = s.State.Application("storage-block")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StateSuite) TestValidateAddApplicationErrors(c *gc.C) {
+	ch := s.AddTestingCharm(c, "dummy")
+	s.AddTestingApplication(c, "s0", ch)
+	_, err := s.State.ValidateAddApplication(state.AddApplicationArgs{Name: "s0", Charm: ch})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "s0": application already exists`)
+
+	_, err = s.State.ValidateAddApplication(state.AddApplicationArgs{
+		Name:             "s1",
+		Charm:            ch,
+		EndpointBindings: map[string]string{"foo": "public"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "s1": .*`)
+
+	_, err = s.State.ValidateAddApplication(state.AddApplicationArgs{
+		Name:   "s1",
+		Charm:  ch,
+		Series: "win2012r2",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "s1": series "win2012r2" \(OS "Windows"\) not supported by charm, supported series are "quantal"`)
+}
+
 func (s *StateSuite) TestAddApplicationLocalAddedAfterInitial(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	// Check that a application with a name conflict cannot be added if
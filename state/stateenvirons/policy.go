@@ -6,6 +6,7 @@ package stateenvirons
 import (
 	"github.com/juju/errors"
 
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -53,9 +54,7 @@ func (p environStatePolicy) ConfigValidator() (config.Validator, error) {
 		return nil, errors.Trace(err)
 	}
 	if model.Type() != state.ModelTypeIAAS {
-		// TODO(caas) CAAS providers should also support
-		// config validation.
-		return nil, errors.NotImplementedf("ConfigValidator")
+		return k8sprovider.ModelConfigValidator(), nil
 	}
 	return environProvider(p.st)
 }
@@ -67,9 +66,7 @@ func (p environStatePolicy) ProviderConfigSchemaSource() (config.ConfigSchemaSou
 		return nil, errors.Trace(err)
 	}
 	if model.Type() != state.ModelTypeIAAS {
-		// TODO(caas) CAAS providers should also provide
-		// a config schema.
-		return nil, errors.NotImplementedf("ProviderConfigSchemaSource")
+		return k8sprovider.ModelConfigSchemaSource(), nil
 	}
 	provider, err := environProvider(p.st)
 	if err != nil {
@@ -88,9 +85,7 @@ func (p environStatePolicy) ConstraintsValidator() (constraints.Validator, error
 		return nil, errors.Trace(err)
 	}
 	if model.Type() != state.ModelTypeIAAS {
-		// TODO(caas) CAAS providers should also provide
-		// constraints validation.
-		return nil, errors.NotImplementedf("ConstraintsValidator")
+		return k8sprovider.ConstraintsValidator(), nil
 	}
 	env, err := p.getEnviron(p.st)
 	if err != nil {
@@ -126,8 +121,8 @@ func (p environStatePolicy) StorageProviderRegistry() (storage.ProviderRegistry,
 		return nil, errors.Trace(err)
 	}
 	if model.Type() != state.ModelTypeIAAS {
-		// Only IAAS models support storage.
-		return nil, errors.NotImplementedf("StorageProviderRegistry")
+		// TODO(caas) - get the registry from the broker.
+		return k8sprovider.StorageProviders(), nil
 	}
 	env, err := p.getEnviron(p.st)
 	if err != nil {
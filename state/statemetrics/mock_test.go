@@ -126,12 +126,57 @@ func (m *mockState) AllMachines() ([]statemetrics.Machine, error) {
 	return out, nil
 }
 
+func (m *mockState) AllUnits() ([]statemetrics.Unit, error) {
+	m.MethodCall(m, "AllUnits")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]statemetrics.Unit, len(m.model.units))
+	for i, unit := range m.model.units {
+		out[i] = unit
+	}
+	return out, nil
+}
+
+func (m *mockState) AllRelations() ([]statemetrics.Relation, error) {
+	m.MethodCall(m, "AllRelations")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]statemetrics.Relation, len(m.model.relations))
+	for i, relation := range m.model.relations {
+		out[i] = relation
+	}
+	return out, nil
+}
+
+func (m *mockState) PendingActionCount() (int, error) {
+	m.MethodCall(m, "PendingActionCount")
+	return m.model.pendingActions, m.NextErr()
+}
+
+func (m *mockState) ApplicationLeaders() (map[string]string, error) {
+	m.MethodCall(m, "ApplicationLeaders")
+	return m.model.leaders, m.NextErr()
+}
+
+func (m *mockState) CleanupCount() (int, error) {
+	m.MethodCall(m, "CleanupCount")
+	return m.model.cleanups, m.NextErr()
+}
+
 type mockModel struct {
 	testing.Stub
-	tag      names.ModelTag
-	life     state.Life
-	status   status.StatusInfo
-	machines []*mockMachine
+	tag            names.ModelTag
+	name           string
+	life           state.Life
+	status         status.StatusInfo
+	machines       []*mockMachine
+	units          []*mockUnit
+	relations      []*mockRelation
+	pendingActions int
+	leaders        map[string]string
+	cleanups       int
 }
 
 func (m *mockModel) Life() state.Life {
@@ -144,6 +189,11 @@ func (m *mockModel) ModelTag() names.ModelTag {
 	return m.tag
 }
 
+func (m *mockModel) Name() string {
+	m.MethodCall(m, "Name")
+	return m.name
+}
+
 func (m *mockModel) Status() (status.StatusInfo, error) {
 	m.MethodCall(m, "Status")
 	if err := m.NextErr(); err != nil {
@@ -202,3 +252,41 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	}
 	return m.agentStatus, nil
 }
+
+type mockUnit struct {
+	testing.Stub
+	agentStatus    status.StatusInfo
+	workloadStatus status.StatusInfo
+	life           state.Life
+}
+
+func (u *mockUnit) Life() state.Life {
+	u.MethodCall(u, "Life")
+	return u.life
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.agentStatus, nil
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	u.MethodCall(u, "Status")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.workloadStatus, nil
+}
+
+type mockRelation struct {
+	testing.Stub
+	life state.Life
+}
+
+func (r *mockRelation) Life() state.Life {
+	r.MethodCall(r, "Life")
+	return r.life
+}
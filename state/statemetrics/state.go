@@ -22,8 +22,13 @@ type StatePool interface {
 type State interface {
 	AllMachines() ([]Machine, error)
 	AllModelUUIDs() ([]string, error)
+	AllRelations() ([]Relation, error)
+	AllUnits() ([]Unit, error)
 	AllUsers() ([]User, error)
+	ApplicationLeaders() (map[string]string, error)
+	CleanupCount() (int, error)
 	ControllerTag() names.ControllerTag
+	PendingActionCount() (int, error)
 	UserAccess(names.UserTag, names.Tag) (permission.UserAccess, error)
 }
 
@@ -38,9 +43,22 @@ type Machine interface {
 type Model interface {
 	Life() state.Life
 	ModelTag() names.ModelTag
+	Name() string
 	Status() (status.StatusInfo, error)
 }
 
+// Unit represents a unit in a Juju model.
+type Unit interface {
+	AgentStatus() (status.StatusInfo, error)
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+}
+
+// Relation represents a relation in a Juju model.
+type Relation interface {
+	Life() state.Life
+}
+
 // User represents a user known to the Juju controller.
 type User interface {
 	IsDeleted() bool
@@ -109,3 +127,35 @@ func (s stateShim) AllUsers() ([]User, error) {
 	}
 	return out, nil
 }
+
+func (s stateShim) AllUnits() ([]Unit, error) {
+	applications, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var out []Unit
+	for _, app := range applications {
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, u := range units {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (s stateShim) AllRelations() ([]Relation, error) {
+	relations, err := s.State.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Relation, len(relations))
+	for i, r := range relations {
+		if r != nil {
+			out[i] = r
+		}
+	}
+	return out, nil
+}
@@ -20,6 +20,9 @@ const (
 	domainLabel           = "domain"
 	agentStatusLabel      = "agent_status"
 	machineStatusLabel    = "machine_status"
+	workloadStatusLabel   = "workload_status"
+	modelLabel            = "model"
+	modelUUIDLabel        = "model_uuid"
 )
 
 var (
@@ -29,6 +32,38 @@ var (
 		machineStatusLabel,
 	}
 
+	// modelMachineLabelNames are the label names for the per-model
+	// machine metric. The machines metric predates it and keeps its
+	// original labels, so that existing series are unchanged.
+	modelMachineLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		agentStatusLabel,
+		lifeLabel,
+		machineStatusLabel,
+	}
+
+	unitLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		agentStatusLabel,
+		lifeLabel,
+		workloadStatusLabel,
+	}
+
+	relationLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		lifeLabel,
+	}
+
+	// modelOnlyLabelNames are the label names for metrics that
+	// are only broken down by model.
+	modelOnlyLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+	}
+
 	modelLabelNames = []string{
 		lifeLabel,
 		statusLabel,
@@ -52,9 +87,15 @@ type Collector struct {
 	scrapeDuration prometheus.Gauge
 	scrapeErrors   prometheus.Gauge
 
-	models   *prometheus.GaugeVec
-	machines *prometheus.GaugeVec
-	users    *prometheus.GaugeVec
+	models         *prometheus.GaugeVec
+	machines       *prometheus.GaugeVec
+	modelMachines  *prometheus.GaugeVec
+	units          *prometheus.GaugeVec
+	relations      *prometheus.GaugeVec
+	pendingActions *prometheus.GaugeVec
+	leases         *prometheus.GaugeVec
+	cleanups       *prometheus.GaugeVec
+	users          *prometheus.GaugeVec
 }
 
 // New returns a new Collector.
@@ -92,6 +133,54 @@ func New(pool StatePool) *Collector {
 			},
 			machineLabelNames,
 		),
+		modelMachines: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_machines",
+				Help:      "Number of machines in each model.",
+			},
+			modelMachineLabelNames,
+		),
+		units: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "units",
+				Help:      "Number of units managed by the controller.",
+			},
+			unitLabelNames,
+		),
+		relations: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "relations",
+				Help:      "Number of relations managed by the controller.",
+			},
+			relationLabelNames,
+		),
+		pendingActions: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "pending_actions",
+				Help:      "Number of actions waiting to be run.",
+			},
+			modelOnlyLabelNames,
+		),
+		leases: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "leases",
+				Help:      "Number of application leadership leases held.",
+			},
+			modelOnlyLabelNames,
+		),
+		cleanups: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "cleanups",
+				Help:      "Number of cleanups waiting to be run.",
+			},
+			modelOnlyLabelNames,
+		),
 		users: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
@@ -106,7 +195,13 @@ func New(pool StatePool) *Collector {
 // Describe is part of the prometheus.Collector interface.
 func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.machines.Describe(ch)
+	c.modelMachines.Describe(ch)
 	c.models.Describe(ch)
+	c.units.Describe(ch)
+	c.relations.Describe(ch)
+	c.pendingActions.Describe(ch)
+	c.leases.Describe(ch)
+	c.cleanups.Describe(ch)
 	c.users.Describe(ch)
 
 	c.scrapeErrors.Describe(ch)
@@ -122,13 +217,25 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	defer c.scrapeErrors.Collect(ch)
 
 	c.machines.Reset()
+	c.modelMachines.Reset()
 	c.models.Reset()
+	c.units.Reset()
+	c.relations.Reset()
+	c.pendingActions.Reset()
+	c.leases.Reset()
+	c.cleanups.Reset()
 	c.users.Reset()
 
 	c.updateMetrics()
 
 	c.machines.Collect(ch)
+	c.modelMachines.Collect(ch)
 	c.models.Collect(ch)
+	c.units.Collect(ch)
+	c.relations.Collect(ch)
+	c.pendingActions.Collect(ch)
+	c.leases.Collect(ch)
+	c.cleanups.Collect(ch)
 	c.users.Collect(ch)
 }
 
@@ -210,6 +317,22 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 	}
 	defer releaseState()
 
+	modelLabels := prometheus.Labels{
+		modelLabel:     model.Name(),
+		modelUUIDLabel: modelTag.Id(),
+	}
+	c.updateMachineMetrics(st, modelLabels)
+	c.updateUnitMetrics(st, modelLabels)
+	c.updateRelationMetrics(st, modelLabels)
+	c.updateQueueMetrics(st, modelLabels)
+
+	c.models.With(prometheus.Labels{
+		lifeLabel:   model.Life().String(),
+		statusLabel: string(modelStatus.Status),
+	}).Inc()
+}
+
+func (c *Collector) updateMachineMetrics(st State, modelLabels prometheus.Labels) {
 	machines, err := st.AllMachines()
 	if err != nil {
 		c.scrapeErrors.Inc()
@@ -242,10 +365,92 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 			lifeLabel:          m.Life().String(),
 			machineStatusLabel: string(machineStatus.Status),
 		}).Inc()
+		c.modelMachines.With(withLabels(modelLabels, prometheus.Labels{
+			agentStatusLabel:   string(agentStatus.Status),
+			lifeLabel:          m.Life().String(),
+			machineStatusLabel: string(machineStatus.Status),
+		})).Inc()
 	}
+}
 
-	c.models.With(prometheus.Labels{
-		lifeLabel:   model.Life().String(),
-		statusLabel: string(modelStatus.Status),
-	}).Inc()
+func (c *Collector) updateUnitMetrics(st State, modelLabels prometheus.Labels) {
+	units, err := st.AllUnits()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting units: %v", err)
+		units = nil
+	}
+	for _, u := range units {
+		agentStatus, err := u.AgentStatus()
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit agent status: %v", err)
+			continue
+		}
+
+		workloadStatus, err := u.Status()
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit workload status: %v", err)
+			continue
+		}
+
+		c.units.With(withLabels(modelLabels, prometheus.Labels{
+			agentStatusLabel:    string(agentStatus.Status),
+			lifeLabel:           u.Life().String(),
+			workloadStatusLabel: string(workloadStatus.Status),
+		})).Inc()
+	}
+}
+
+func (c *Collector) updateRelationMetrics(st State, modelLabels prometheus.Labels) {
+	relations, err := st.AllRelations()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting relations: %v", err)
+		relations = nil
+	}
+	for _, r := range relations {
+		c.relations.With(withLabels(modelLabels, prometheus.Labels{
+			lifeLabel: r.Life().String(),
+		})).Inc()
+	}
+}
+
+// updateQueueMetrics updates the metrics for work that is waiting to
+// be done, or is held, in the model.
+func (c *Collector) updateQueueMetrics(st State, modelLabels prometheus.Labels) {
+	if count, err := st.PendingActionCount(); err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting pending actions: %v", err)
+	} else {
+		c.pendingActions.With(modelLabels).Set(float64(count))
+	}
+
+	if leaders, err := st.ApplicationLeaders(); err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting application leaders: %v", err)
+	} else {
+		c.leases.With(modelLabels).Set(float64(len(leaders)))
+	}
+
+	if count, err := st.CleanupCount(); err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting cleanups: %v", err)
+	} else {
+		c.cleanups.With(modelLabels).Set(float64(count))
+	}
+}
+
+// withLabels returns the union of the model labels and the given
+// labels.
+func withLabels(modelLabels, labels prometheus.Labels) prometheus.Labels {
+	for name, value := range modelLabels {
+		labels[name] = value
+	}
+	return labels
 }
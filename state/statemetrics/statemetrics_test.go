@@ -50,6 +50,7 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 	s.pool = &mockStatePool{
 		models: []*mockModel{{
 			tag:    names.NewModelTag("b266dff7-eee8-4297-b03a-4692796ec193"),
+			name:   "default",
 			life:   state.Alive,
 			status: status.StatusInfo{Status: status.Available},
 			machines: []*mockMachine{{
@@ -57,8 +58,26 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 				agentStatus:    status.StatusInfo{Status: status.Started},
 				instanceStatus: status.StatusInfo{Status: status.Running},
 			}},
+			units: []*mockUnit{{
+				life:           state.Alive,
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}, {
+				life:           state.Alive,
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Error},
+			}, {
+				life:           state.Alive,
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}},
+			relations:      []*mockRelation{{life: state.Alive}},
+			pendingActions: 3,
+			leaders:        map[string]string{"mysql": "mysql/0", "wordpress": "wordpress/1"},
+			cleanups:       0,
 		}, {
 			tag:    names.NewModelTag("1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			name:   "other",
 			life:   state.Dying,
 			status: status.StatusInfo{Status: status.Destroying},
 			machines: []*mockMachine{{
@@ -66,6 +85,8 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 				agentStatus:    status.StatusInfo{Status: status.Error},
 				instanceStatus: status.StatusInfo{Status: status.ProvisioningError},
 			}},
+			relations: []*mockRelation{{life: state.Dying}},
+			cleanups:  2,
 		}},
 	}
 	s.pool.system = &mockState{
@@ -87,7 +108,13 @@ func (s *collectorSuite) TestDescribe(c *gc.C) {
 	}
 	expect := []string{
 		`.*fqName: "juju_state_machines".*`,
+		`.*fqName: "juju_state_model_machines".*`,
 		`.*fqName: "juju_state_models".*`,
+		`.*fqName: "juju_state_units".*`,
+		`.*fqName: "juju_state_relations".*`,
+		`.*fqName: "juju_state_pending_actions".*`,
+		`.*fqName: "juju_state_leases".*`,
+		`.*fqName: "juju_state_cleanups".*`,
 		`.*fqName: "juju_state_users".*`,
 		`.*fqName: "juju_state_scrape_errors".*`,
 		`.*fqName: "juju_state_scrape_duration_seconds".*`,
@@ -169,6 +196,116 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 			},
 		},
 
+		// juju_state_model_machines
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "started"),
+				labelpair("life", "alive"),
+				labelpair("machine_status", "running"),
+				labelpair("model", "default"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "error"),
+				labelpair("life", "alive"),
+				labelpair("machine_status", "provisioning error"),
+				labelpair("model", "other"),
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
+		// juju_state_units
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "idle"),
+				labelpair("life", "alive"),
+				labelpair("model", "default"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("workload_status", "active"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "idle"),
+				labelpair("life", "alive"),
+				labelpair("model", "default"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("workload_status", "error"),
+			},
+		},
+
+		// juju_state_relations
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("life", "alive"),
+				labelpair("model", "default"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("life", "dying"),
+				labelpair("model", "other"),
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
+		// juju_state_pending_actions
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(3)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "default"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(0)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "other"),
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
+		// juju_state_leases
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "default"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(0)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "other"),
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
+		// juju_state_cleanups
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(0)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "default"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "other"),
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
 		// juju_state_models
 		{
 			Gauge: &dto.Gauge{Value: float64ptr(1)},
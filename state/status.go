@@ -6,12 +6,18 @@ package state
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -501,7 +507,357 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
-func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds)
-	return errors.Trace(err)
+// PruneStatusHistory removes status history entries until only the
+// ones newer than now - maxHistoryTime remain and the history is
+// smaller than maxHistoryMB. The entries of the kinds in retention are
+// kept for the age given for their kind instead of maxHistoryTime.
+func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int, retention map[status.HistoryKind]time.Duration) error {
+	if len(retention) == 0 {
+		err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds)
+		return errors.Trace(err)
+	}
+
+	// NOTE(axw) we require a raw collection to obtain the size of the
+	// collection. Take care to include model-uuid in queries where
+	// appropriate.
+	history, closer := st.db().GetRawCollection(statusesHistoryC)
+	defer closer()
+
+	kinds := make([]string, 0, len(retention))
+	for kind := range retention {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+	patterns := make([]string, len(kinds))
+	for i, kind := range kinds {
+		patterns[i] = historyKindKeyPattern(status.HistoryKind(kind), "")
+		p := collectionPruner{
+			st:       st,
+			coll:     history,
+			maxAge:   retention[status.HistoryKind(kind)],
+			ageField: "updated",
+			timeUnit: NanoSeconds,
+			filter:   bson.D{{globalKeyField, bson.RegEx{Pattern: patterns[i]}}},
+		}
+		if err := p.pruneByAge(); err != nil {
+			return errors.Annotatef(err, "pruning %s status history", kind)
+		}
+	}
+
+	// The remaining entries are pruned by the model-wide age, and
+	// all entries by size.
+	p := collectionPruner{
+		st:       st,
+		coll:     history,
+		maxAge:   maxHistoryTime,
+		maxSize:  maxHistoryMB,
+		ageField: "updated",
+		timeUnit: NanoSeconds,
+		filter: bson.D{{globalKeyField, bson.M{
+			"$not": bson.RegEx{Pattern: strings.Join(patterns, "|")},
+		}}},
+	}
+	if err := p.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.pruneByAge(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.pruneBySize())
+}
+
+// historyKindKeyPattern returns a regular expression matching the
+// global keys of the status history entries of the given kind. If an
+// application is given, unit entries are restricted to its units.
+func historyKindKeyPattern(kind status.HistoryKind, application string) string {
+	unit := `[^#]+`
+	if application != "" {
+		unit = regexp.QuoteMeta(application) + `/[0-9]+`
+	}
+	switch kind {
+	case status.KindUnit:
+		return `^u#` + unit + `(#charm)?$`
+	case status.KindUnitAgent:
+		return `^u#` + unit + `$`
+	case status.KindWorkload:
+		return `^u#` + unit + `#charm$`
+	case status.KindMachine:
+		return `^m#[^#/]+$`
+	case status.KindMachineInstance:
+		return `^m#[^#/]+#instance$`
+	case status.KindContainer:
+		return `^m#[^#]+/[^#]+$`
+	case status.KindContainerInstance:
+		return `^m#[^#]+/[^#]+#instance$`
+	}
+	return ""
+}
+
+// historyEntryOwner returns the kind of the status history entries with
+// the given global key, and the entity they belong to. It returns false
+// if the key isn't that of any kind of status history.
+func historyEntryOwner(globalKey string) (status.HistoryKind, names.Tag, bool) {
+	parts := strings.Split(globalKey, "#")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", nil, false
+	}
+	suffix := ""
+	if len(parts) == 3 {
+		suffix = parts[2]
+	}
+	switch {
+	case parts[0] == "u" && names.IsValidUnit(parts[1]):
+		tag := names.NewUnitTag(parts[1])
+		switch suffix {
+		case "":
+			return status.KindUnitAgent, tag, true
+		case "charm":
+			return status.KindWorkload, tag, true
+		}
+	case parts[0] == "m" && names.IsValidMachine(parts[1]):
+		tag := names.NewMachineTag(parts[1])
+		container := strings.Contains(parts[1], "/")
+		switch {
+		case suffix == "" && container:
+			return status.KindContainer, tag, true
+		case suffix == "":
+			return status.KindMachine, tag, true
+		case suffix == "instance" && container:
+			return status.KindContainerInstance, tag, true
+		case suffix == "instance":
+			return status.KindMachineInstance, tag, true
+		}
+	}
+	return "", nil, false
+}
+
+// StatusHistoryQuery holds the arguments used to select status history
+// entries from across a model.
+type StatusHistoryQuery struct {
+	// Kinds restricts the entries to those of the given kinds. Entries
+	// of all kinds are selected if it is empty.
+	Kinds []status.HistoryKind
+
+	// Application restricts the entries to those of the application's
+	// units. Only unit kinds may be selected with an application.
+	Application string
+
+	// FromDate, if set, excludes the entries recorded before it.
+	FromDate *time.Time
+
+	// ToDate, if set, excludes the entries recorded at or after it.
+	ToDate *time.Time
+
+	// Size, if positive, limits the result to the most recent
+	// entries.
+	Size int
+
+	// Exclude holds the status messages of entries to leave out.
+	Exclude set.Strings
+
+	// After, if set, is the cursor of the entry after which to
+	// start, as returned in the Next field of the previous page.
+	After string
+
+	// Limit is the most entries to return in one page. If it is not
+	// positive, or more than MaxStatusHistoryPageSize, then at most
+	// MaxStatusHistoryPageSize entries are returned.
+	Limit int
+}
+
+// MaxStatusHistoryPageSize is the most status history entries returned
+// in one page by QueryStatusHistory.
+const MaxStatusHistoryPageSize = 1000
+
+// StatusHistoryPage is a page of the status history entries
+// matching a StatusHistoryQuery.
+type StatusHistoryPage struct {
+	// Entries holds the entries of the page, oldest first.
+	Entries []StatusHistoryEntry
+
+	// Next, if set, is the cursor to pass in the query's After
+	// field to get the next page; it is empty on the last page.
+	Next string
+}
+
+// StatusHistoryEntry is a status history entry of an entity in a model.
+type StatusHistoryEntry struct {
+	status.StatusInfo
+
+	// Kind is the kind of the entry.
+	Kind status.HistoryKind
+
+	// Entity is the tag of the unit or machine the entry belongs to.
+	Entity names.Tag
+}
+
+// QueryStatusHistory returns a page of the status history entries from
+// across the model that match the query, oldest first.
+func (st *State) QueryStatusHistory(q StatusHistoryQuery) (StatusHistoryPage, error) {
+	if q.Application != "" && !names.IsValidApplication(q.Application) {
+		return StatusHistoryPage{}, errors.NotValidf("application name %q", q.Application)
+	}
+	if q.FromDate != nil && q.ToDate != nil && !q.ToDate.After(*q.FromDate) {
+		return StatusHistoryPage{}, errors.NotValidf("date range ending before it starts")
+	}
+	kinds := q.Kinds
+	if len(kinds) == 0 {
+		kinds = []status.HistoryKind{status.KindUnit}
+		if q.Application == "" {
+			kinds = append(kinds,
+				status.KindMachine, status.KindMachineInstance,
+				status.KindContainer, status.KindContainerInstance,
+			)
+		}
+	}
+	patterns := make([]string, len(kinds))
+	for i, kind := range kinds {
+		if !kind.Valid() {
+			return StatusHistoryPage{}, errors.NotValidf("status history kind %q", kind)
+		}
+		switch kind {
+		case status.KindUnit, status.KindUnitAgent, status.KindWorkload:
+		default:
+			if q.Application != "" {
+				return StatusHistoryPage{}, errors.NotValidf("%s status history for an application", kind)
+			}
+		}
+		patterns[i] = historyKindKeyPattern(kind, q.Application)
+	}
+
+	query := bson.D{{globalKeyField, bson.RegEx{Pattern: strings.Join(patterns, "|")}}}
+	updated := bson.M{}
+	if q.FromDate != nil {
+		updated["$gte"] = q.FromDate.UnixNano()
+	}
+	if q.ToDate != nil {
+		updated["$lt"] = q.ToDate.UnixNano()
+	}
+	if len(updated) > 0 {
+		query = append(query, bson.DocElem{Name: "updated", Value: updated})
+	}
+	if q.Exclude.Size() > 0 {
+		query = append(query, bson.DocElem{Name: "statusinfo", Value: bson.M{"$nin": q.Exclude.Values()}})
+	}
+
+	var bounds []bson.D
+	if q.After != "" {
+		after, err := parseStatusHistoryCursor(q.After)
+		if err != nil {
+			return StatusHistoryPage{}, errors.Trace(err)
+		}
+		bounds = append(bounds, after.afterQuery())
+	}
+	limit := q.Limit
+	if limit <= 0 || limit > MaxStatusHistoryPageSize {
+		limit = MaxStatusHistoryPageSize
+	}
+
+	history, closer := st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	if q.Size > 0 {
+		// Only the most recent entries are selected, so start
+		// from the oldest of them.
+		var oldest []historicalStatusPageDoc
+		err := history.Find(query).Sort("-updated", "-_id").Skip(q.Size - 1).Limit(1).All(&oldest)
+		if err != nil {
+			return StatusHistoryPage{}, errors.Annotate(err, "cannot get status history")
+		}
+		if len(oldest) > 0 {
+			bounds = append(bounds, oldest[0].cursor().fromQuery())
+		}
+	}
+	if len(bounds) > 0 {
+		query = append(query, bson.DocElem{Name: "$and", Value: bounds})
+	}
+
+	// One more entry than the limit is read to
+	// find out whether there is another page.
+	var docs []historicalStatusPageDoc
+	err := history.Find(query).Sort("updated", "_id").Limit(limit + 1).All(&docs)
+	if err != nil {
+		return StatusHistoryPage{}, errors.Annotate(err, "cannot get status history")
+	}
+	var page StatusHistoryPage
+	if len(docs) > limit {
+		docs = docs[:limit]
+		page.Next = docs[limit-1].cursor().String()
+	}
+
+	entries := make([]StatusHistoryEntry, 0, len(docs))
+	for _, doc := range docs {
+		kind, entity, ok := historyEntryOwner(doc.GlobalKey)
+		if !ok {
+			continue
+		}
+		entries = append(entries, StatusHistoryEntry{
+			StatusInfo: status.StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    utils.UnescapeKeys(doc.StatusData),
+				Since:   unixNanoToTime(doc.Updated),
+			},
+			Kind:   kind,
+			Entity: entity,
+		})
+	}
+	page.Entries = entries
+	return page, nil
+}
+
+// historicalStatusPageDoc is a status history document
+// read along with its ID, to be returned in a page.
+type historicalStatusPageDoc struct {
+	Id                  bson.ObjectId `bson:"_id"`
+	historicalStatusDoc `bson:",inline"`
+}
+
+func (doc historicalStatusPageDoc) cursor() statusHistoryCursor {
+	return statusHistoryCursor{updated: doc.Updated, id: doc.Id}
+}
+
+// statusHistoryCursor identifies the position of a status history
+// entry in the order in which entries are returned: by time, and then
+// by ID for those recorded at the same time.
+type statusHistoryCursor struct {
+	updated int64
+	id      bson.ObjectId
+}
+
+// parseStatusHistoryCursor parses a cursor as
+// formatted by statusHistoryCursor.String.
+func parseStatusHistoryCursor(value string) (statusHistoryCursor, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || !bson.IsObjectIdHex(parts[1]) {
+		return statusHistoryCursor{}, errors.NotValidf("status history cursor %q", value)
+	}
+	updated, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return statusHistoryCursor{}, errors.NotValidf("status history cursor %q", value)
+	}
+	return statusHistoryCursor{updated: updated, id: bson.ObjectIdHex(parts[1])}, nil
+}
+
+// String returns the cursor in the form
+// in which it is passed to clients.
+func (c statusHistoryCursor) String() string {
+	return fmt.Sprintf("%d:%s", c.updated, c.id.Hex())
+}
+
+// afterQuery returns a query matching the entries after the cursor.
+func (c statusHistoryCursor) afterQuery() bson.D {
+	return bson.D{{"$or", []bson.D{
+		{{"updated", bson.D{{"$gt", c.updated}}}},
+		{{"updated", c.updated}, {"_id", bson.D{{"$gt", c.id}}}},
+	}}}
+}
+
+// fromQuery returns a query matching the entry
+// at the cursor and the entries after it.
+func (c statusHistoryCursor) fromQuery() bson.D {
+	return bson.D{{"$or", []bson.D{
+		{{"updated", bson.D{{"$gt", c.updated}}}},
+		{{"updated", c.updated}, {"_id", bson.D{{"$gte", c.id}}}},
+	}}}
 }
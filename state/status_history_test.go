@@ -39,7 +39,7 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryBySize(c *gc.C) {
 	c.Logf("%d\n", len(history))
 	c.Assert(history, gc.HasLen, 20001)
 
-	err = state.PruneStatusHistory(s.State, 0, 1)
+	err = state.PruneStatusHistory(s.State, 0, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
@@ -69,7 +69,7 @@ func (s *StatusHistorySuite) TestPruneStatusBySizeOnlyForController(c *gc.C) {
 	c.Logf("%d\n", len(history))
 	c.Assert(history, gc.HasLen, 20001)
 
-	err = state.PruneStatusHistory(st, 0, 1)
+	err = state.PruneStatusHistory(st, 0, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
@@ -124,7 +124,7 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 		checkPrimedUnitStatus(c, statusInfo, 9-i, 24*time.Hour)
 	}
 
-	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
+	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = units[0].StatusHistory(status.StatusHistoryFilter{Size: 50})
@@ -172,6 +172,161 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryByKind(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	agent := unit.Agent()
+
+	primeUnitStatusHistory(c, unit, 10, 0)
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+	primeUnitAgentStatusHistory(c, agent, 10, 0, "")
+	primeUnitAgentStatusHistory(c, agent, 10, 24*time.Hour, "")
+
+	err := state.PruneStatusHistory(s.State, 48*time.Hour, 1024, map[status.HistoryKind]time.Duration{
+		status.KindUnitAgent: 10 * time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The workload history is kept for the model-wide age...
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 21)
+
+	// ...while the agent history is pruned by its own.
+	history, err = agent.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 11)
+	for i, statusInfo := range history[:10] {
+		checkPrimedUnitAgentStatus(c, statusInfo, 9-i, 0)
+	}
+}
+
+func (s *StatusHistorySuite) TestQueryStatusHistory(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit0, 3, 48*time.Hour)
+	primeUnitStatusHistory(c, unit1, 2, 0)
+
+	page, err := s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		Kinds:       []status.HistoryKind{status.KindWorkload},
+		Application: application.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(page.Next, gc.Equals, "")
+	entries := page.Entries
+	c.Assert(entries, gc.HasLen, 7)
+	for _, entry := range entries {
+		c.Check(entry.Kind, gc.Equals, status.KindWorkload)
+	}
+	for i, entry := range entries[:3] {
+		c.Check(entry.Entity, gc.Equals, unit0.Tag())
+		checkPrimedUnitStatus(c, entry.StatusInfo, i, 48*time.Hour)
+	}
+	checkInitialWorkloadStatus(c, entries[3].StatusInfo)
+	checkInitialWorkloadStatus(c, entries[4].StatusInfo)
+	for i, entry := range entries[5:] {
+		c.Check(entry.Entity, gc.Equals, unit1.Tag())
+		checkPrimedUnitStatus(c, entry.StatusInfo, i, 0)
+	}
+
+	// The most recent entries are returned, still oldest first.
+	page, err = s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		Application: application.Name(),
+		Size:        2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	entries = page.Entries
+	c.Assert(entries, gc.HasLen, 2)
+	checkPrimedUnitStatus(c, entries[0].StatusInfo, 0, 0)
+	checkPrimedUnitStatus(c, entries[1].StatusInfo, 1, 0)
+
+	// Entries of all kinds are selected by time range.
+	dayAgo := time.Now().Add(-24 * time.Hour)
+	page, err = s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		ToDate: &dayAgo,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	entries = page.Entries
+	c.Assert(entries, gc.HasLen, 3)
+	for i, entry := range entries {
+		c.Check(entry.Entity, gc.Equals, unit0.Tag())
+		checkPrimedUnitStatus(c, entry.StatusInfo, i, 48*time.Hour)
+	}
+}
+
+func (s *StatusHistorySuite) TestQueryStatusHistoryInvalid(c *gc.C) {
+	_, err := s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		Kinds:       []status.HistoryKind{status.KindMachine},
+		Application: "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, `juju-machine status history for an application not valid`)
+
+	_, err = s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		Kinds: []status.HistoryKind{"relation"},
+	})
+	c.Assert(err, gc.ErrorMatches, `status history kind "relation" not valid`)
+
+	now := time.Now()
+	_, err = s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		FromDate: &now,
+		ToDate:   &now,
+	})
+	c.Assert(err, gc.ErrorMatches, `date range ending before it starts not valid`)
+
+	_, err = s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		After: "yesterday",
+	})
+	c.Assert(err, gc.ErrorMatches, `status history cursor "yesterday" not valid`)
+}
+
+func (s *StatusHistorySuite) TestQueryStatusHistoryPages(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit, 5, 0)
+
+	query := state.StatusHistoryQuery{
+		Kinds:       []status.HistoryKind{status.KindWorkload},
+		Application: application.Name(),
+		Limit:       2,
+	}
+	all, err := s.State.QueryStatusHistory(state.StatusHistoryQuery{
+		Kinds:       query.Kinds,
+		Application: query.Application,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all.Entries, gc.HasLen, 6)
+
+	var entries []state.StatusHistoryEntry
+	var pages int
+	for {
+		page, err := s.State.QueryStatusHistory(query)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(len(page.Entries) <= 2, jc.IsTrue)
+		entries = append(entries, page.Entries...)
+		pages++
+		if page.Next == "" {
+			break
+		}
+		query.After = page.Next
+	}
+	c.Assert(pages, gc.Equals, 3)
+	c.Assert(entries, jc.DeepEquals, all.Entries)
+
+	// The most recent entries are paged through too.
+	query.After = ""
+	query.Size = 3
+	page, err := s.State.QueryStatusHistory(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(page.Entries, jc.DeepEquals, all.Entries[3:5])
+	c.Assert(page.Next, gc.Not(gc.Equals), "")
+	query.After = page.Next
+	page, err = s.State.QueryStatusHistory(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(page.Entries, jc.DeepEquals, all.Entries[5:])
+	c.Assert(page.Next, gc.Equals, "")
+}
+
 func (s *StatusHistorySuite) TestStatusHistoryFilterRunningUpdateStatusHook(c *gc.C) {
 
 	application := s.Factory.MakeApplication(c, nil)
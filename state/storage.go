@@ -1720,11 +1720,18 @@ func validateStoragePool(
 }
 
 func poolStorageProvider(im *IAASModel, poolName string) (storage.ProviderType, storage.Provider, error) {
-	registry, err := im.st.storageProviderRegistry()
+	return storagePoolProvider(im.st, poolName)
+}
+
+// storagePoolProvider returns the storage provider type and provider
+// for the named pool, or for the provider type of that name, using the
+// storage providers available to the model.
+func storagePoolProvider(st *State, poolName string) (storage.ProviderType, storage.Provider, error) {
+	registry, err := st.storageProviderRegistry()
 	if err != nil {
 		return "", nil, errors.Annotate(err, "getting storage provider registry")
 	}
-	poolManager := poolmanager.New(NewStateSettings(im.mb), registry)
+	poolManager := poolmanager.New(NewStateSettings(st), registry)
 	pool, err := poolManager.Get(poolName)
 	if errors.IsNotFound(err) {
 		// If there's no pool called poolName, maybe a provider type
@@ -1782,6 +1789,85 @@ func addDefaultStorageConstraints(im *IAASModel, allCons map[string]StorageConst
 	return nil
 }
 
+// defaultCAASStoragePool is the storage pool used for the storage
+// of applications in CAAS models if none is specified. It names the
+// Kubernetes storage provider, which uses the cluster's default
+// storage class.
+const defaultCAASStoragePool = "kubernetes"
+
+// caasStorageProviderType is the type of the Kubernetes storage
+// provider, which provides all storage in CAAS models.
+const caasStorageProviderType = storage.ProviderType("kubernetes")
+
+// addDefaultCAASStorageConstraints fills in missing storage constraints
+// for an application in a CAAS model, and checks that the charm storage
+// can be provided there. Only filesystem storage is supported, and the
+// storage pools must belong to the model's storage provider.
+func addDefaultCAASStorageConstraints(st *State, allCons map[string]StorageConstraints, charmMeta *charm.Meta) error {
+	for name := range allCons {
+		if _, ok := charmMeta.Storage[name]; !ok {
+			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
+		}
+	}
+	for name, charmStorage := range charmMeta.Storage {
+		cons := allCons[name]
+		if cons.Count == 0 {
+			cons.Count = uint64(charmStorage.CountMin)
+		}
+		if cons.Count == 0 {
+			continue
+		}
+		if charmStorage.Type != charm.StorageFilesystem {
+			return errors.NotSupportedf("charm %q store %q: %s storage on CAAS models", charmMeta.Name, name, charmStorage.Type)
+		}
+		if charmStorage.Shared {
+			return errors.Errorf(
+				"charm %q store %q: shared storage support not implemented",
+				charmMeta.Name, name,
+			)
+		}
+		if err := validateCharmStorageCount(charmStorage, cons.Count); err != nil {
+			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+		}
+		if cons.Pool == "" {
+			cons.Pool = defaultCAASStoragePool
+		}
+		if err := validateCAASStoragePool(st, cons.Pool); err != nil {
+			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+		}
+		if cons.Size == 0 {
+			if charmStorage.MinimumSize > 0 {
+				cons.Size = charmStorage.MinimumSize
+			} else {
+				cons.Size = 1024
+			}
+		}
+		if cons.Size < charmStorage.MinimumSize {
+			return errors.Errorf(
+				"charm %q store %q: minimum storage size is %s, %s specified",
+				charmMeta.Name, name,
+				humanize.Bytes(charmStorage.MinimumSize*humanize.MByte),
+				humanize.Bytes(cons.Size*humanize.MByte),
+			)
+		}
+		allCons[name] = cons
+	}
+	return nil
+}
+
+// validateCAASStoragePool checks that the named pool, or storage
+// provider type, exists and uses the Kubernetes storage provider.
+func validateCAASStoragePool(st *State, poolName string) error {
+	providerType, _, err := storagePoolProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if providerType != caasStorageProviderType {
+		return errors.NotSupportedf("%q storage provider on CAAS models", providerType)
+	}
+	return nil
+}
+
 // storageConstraintsWithDefaults returns a constraints
 // derived from cons, with any defaults filled in.
 func storageConstraintsWithDefaults(
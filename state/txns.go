@@ -5,6 +5,8 @@ package state
 
 import (
 	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +14,8 @@ import (
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/tracing"
 )
 
 func readTxnRevno(db Database, collectionName string, id interface{}) (int64, error) {
@@ -118,6 +122,80 @@ func (r *multiModelRunner) MaybePruneTransactions(opts jujutxn.PruneOptions) err
 	return r.rawRunner.MaybePruneTransactions(opts)
 }
 
+// tracingRunner wraps a jujutxn.Runner, recording a span for each
+// transaction that is run.
+type tracingRunner struct {
+	rawRunner jujutxn.Runner
+	tracer    *tracing.Tracer
+	parent    tracing.SpanContext
+	dbName    string
+	modelUUID string
+}
+
+// RunTransaction is part of the jujutxn.Runner interface.
+func (r *tracingRunner) RunTransaction(ops []txn.Op) error {
+	span := r.startSpan()
+	err := r.rawRunner.RunTransaction(ops)
+	r.finishSpan(span, ops, 1, err)
+	return err
+}
+
+// Run is part of the jujutxn.Runner interface.
+func (r *tracingRunner) Run(transactions jujutxn.TransactionSource) error {
+	span := r.startSpan()
+	var lastOps []txn.Op
+	var attempts int
+	err := r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+		attempts = attempt + 1
+		ops, err := transactions(attempt)
+		lastOps = ops
+		return ops, err
+	})
+	r.finishSpan(span, lastOps, attempts, err)
+	return err
+}
+
+// ResumeTransactions is part of the jujutxn.Runner interface.
+func (r *tracingRunner) ResumeTransactions() error {
+	return r.rawRunner.ResumeTransactions()
+}
+
+// MaybePruneTransactions is part of the jujutxn.Runner interface.
+func (r *tracingRunner) MaybePruneTransactions(opts jujutxn.PruneOptions) error {
+	return r.rawRunner.MaybePruneTransactions(opts)
+}
+
+func (r *tracingRunner) startSpan() *tracing.Span {
+	// Without a parent, each transaction starts a trace of its own.
+	span := r.tracer.StartSpan("txn", tracing.KindNone, r.parent)
+	span.SetTag("db", r.dbName)
+	span.SetTag("model-uuid", r.modelUUID)
+	return span
+}
+
+func (r *tracingRunner) finishSpan(span *tracing.Span, ops []txn.Op, attempts int, err error) {
+	if span == nil {
+		return
+	}
+	seen := make(map[string]bool)
+	var collections []string
+	for _, op := range ops {
+		if !seen[op.C] {
+			seen[op.C] = true
+			collections = append(collections, op.C)
+		}
+	}
+	sort.Strings(collections)
+	span.SetTag("collections", strings.Join(collections, ","))
+	span.SetTag("ops", strconv.Itoa(len(ops)))
+	span.SetTag("attempts", strconv.Itoa(attempts))
+	if err == jujutxn.ErrNoOperations {
+		// Nothing needed doing; that's not a failure.
+		err = nil
+	}
+	span.Finish(err)
+}
+
 // updateOps modifies the Insert and Update fields in a slice of
 // txn.Ops to ensure they are multi-model safe where
 // possible. The returned []txn.Op is a new copy of the input (with
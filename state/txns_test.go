@@ -8,10 +8,13 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
 	"github.com/juju/juju/testing"
 )
 
@@ -470,6 +473,108 @@ func (s *MultiModelRunnerSuite) TestMaybePruneTransactionsWithError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "boom")
 }
 
+type TracingRunnerSuite struct {
+	testing.BaseSuite
+	collector  *tracingtest.Collector
+	testRunner *recordingRunner
+	runner     jujutxn.Runner
+}
+
+var _ = gc.Suite(&TracingRunnerSuite{})
+
+func (s *TracingRunnerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.collector = &tracingtest.Collector{}
+	tracer := tracing.NewTracer("machine-0", clock.WallClock)
+	tracer.SetExporter(s.collector)
+	s.testRunner = &recordingRunner{}
+	s.runner = &tracingRunner{
+		rawRunner: s.testRunner,
+		tracer:    tracer,
+		dbName:    "juju",
+		modelUUID: modelUUID,
+	}
+}
+
+func (s *TracingRunnerSuite) TestRunTransaction(c *gc.C) {
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     "0",
+		Insert: bson.M{},
+	}, {
+		C:      "other",
+		Id:     "1",
+		Assert: txn.DocExists,
+	}, {
+		C:      machinesC,
+		Id:     "1",
+		Remove: true,
+	}}
+	err := s.runner.RunTransaction(ops)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.testRunner.seenOps, jc.DeepEquals, ops)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].Name, gc.Equals, "txn")
+	c.Check(spans[0].Tags, jc.DeepEquals, map[string]string{
+		"db":          "juju",
+		"model-uuid":  modelUUID,
+		"collections": "machines,other",
+		"ops":         "3",
+		"attempts":    "1",
+	})
+}
+
+func (s *TracingRunnerSuite) TestRun(c *gc.C) {
+	err := s.runner.Run(func(attempt int) ([]txn.Op, error) {
+		c.Check(attempt, gc.Equals, testTxnAttempt)
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].Tags, jc.DeepEquals, map[string]string{
+		"db":         "juju",
+		"model-uuid": modelUUID,
+		"ops":        "0",
+		"attempts":   "43",
+		"error":      "boom",
+	})
+}
+
+func (s *TracingRunnerSuite) TestRunNoOperations(c *gc.C) {
+	err := s.runner.Run(func(int) ([]txn.Op, error) {
+		return nil, jujutxn.ErrNoOperations
+	})
+	c.Assert(err, gc.Equals, jujutxn.ErrNoOperations)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].Tags["error"], gc.Equals, "")
+}
+
+func (s *TracingRunnerSuite) TestParentSpan(c *gc.C) {
+	tracer := tracing.NewTracer("machine-0", clock.WallClock)
+	tracer.SetExporter(s.collector)
+	parent := tracer.StartSpan("Application.Deploy", tracing.KindServer, tracing.SpanContext{})
+	runner := &tracingRunner{
+		rawRunner: s.testRunner,
+		tracer:    tracer,
+		parent:    parent.Context(),
+		dbName:    "juju",
+		modelUUID: modelUUID,
+	}
+	err := runner.RunTransaction([]txn.Op{{C: machinesC, Id: "0", Remove: true}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	spans := s.collector.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].TraceID, gc.Equals, parent.Context().TraceID)
+	c.Check(spans[0].ParentID, gc.Equals, parent.Context().SpanID)
+}
+
 // recordingRunner is fake transaction running that implements the
 // jujutxn.Runner interface. Instead of doing anything with a database
 // it simply records the transaction operations passed to it for later
@@ -79,8 +79,63 @@ type port struct {
 // ContainerInfo holds attributes about the containing
 // hosting a unit in a CAAS model.
 type ContainerInfo struct {
-	Address string   `bson:"address"`
-	Ports   []string `bson:"ports"`
+	Address     string                `bson:"address"`
+	Ports       []string              `bson:"ports"`
+	Filesystems []ContainerFilesystem `bson:"filesystems,omitempty"`
+}
+
+// ContainerFilesystem holds attributes about a filesystem mounted
+// in the container hosting a unit in a CAAS model.
+//
+// Such filesystems are provisioned by Kubernetes as persistent volume
+// claims along with the unit's pod, and outlive it. As Juju does not
+// manage their lifecycle, they are recorded here rather than as storage
+// instance and filesystem documents, and cannot be detached or removed
+// through Juju; the IDs are assigned so that they can still be listed
+// and shown like other storage.
+type ContainerFilesystem struct {
+	// StorageId is the ID of the storage instance
+	// assigned by Juju for the filesystem.
+	StorageId string `bson:"storage-id"`
+
+	// Id is the ID of the filesystem assigned by Juju.
+	Id string `bson:"id"`
+
+	// StorageName is the name of the charm storage
+	// that the filesystem was created for.
+	StorageName string `bson:"storage-name"`
+
+	// FilesystemId is the provider's ID for the filesystem.
+	FilesystemId string `bson:"filesystem-id"`
+
+	// Pool is the storage pool the filesystem was created from.
+	Pool string `bson:"pool"`
+
+	// Size is the size of the filesystem in MiB.
+	Size uint64 `bson:"size"`
+
+	// MountPoint is the path at which the filesystem is
+	// mounted in the container.
+	MountPoint string `bson:"mount-point"`
+
+	// ReadOnly indicates that the filesystem is mounted read-only.
+	ReadOnly bool `bson:"read-only"`
+
+	// Status and Message describe the state of the filesystem
+	// as last reported by the provider.
+	Status  status.Status `bson:"status"`
+	Message string        `bson:"message,omitempty"`
+}
+
+// StorageTag returns the tag of the storage instance
+// the filesystem was created for.
+func (f ContainerFilesystem) StorageTag() names.StorageTag {
+	return names.NewStorageTag(f.StorageId)
+}
+
+// FilesystemTag returns the tag of the filesystem.
+func (f ContainerFilesystem) FilesystemTag() names.FilesystemTag {
+	return names.NewFilesystemTag(f.Id)
 }
 
 // unitDoc represents the internal state of a unit in MongoDB.
@@ -141,12 +196,17 @@ func (u *Unit) Application() (*Application, error) {
 // ConfigSettings returns the complete set of service charm config settings
 // available to the unit. Unset values will be replaced with the default
 // value for the associated option, and may thus be nil when no default is
-// specified.
+// specified. If the unit is tracking a branch, the settings staged on the
+// branch take precedence over the application's current settings.
 func (u *Unit) ConfigSettings() (charm.Settings, error) {
 	if u.doc.CharmURL == nil {
 		return nil, fmt.Errorf("unit charm not set")
 	}
-	return charmSettingsWithDefaults(u.st, u.doc.CharmURL, applicationCharmConfigKey(u.doc.Application, u.doc.CharmURL))
+	settings, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, applicationCharmConfigKey(u.doc.Application, u.doc.CharmURL))
+	if err != nil {
+		return nil, err
+	}
+	return u.branchCharmSettings(settings)
 }
 
 // ApplicationName returns the application name.
@@ -338,9 +398,16 @@ func (op *UpdateUnitOperation) Build(attempt int) ([]txn.Op, error) {
 		return nil, errors.Errorf("unit %q has provider id %q which does not match %q",
 			op.unit.Name(), op.unit.ProviderId(), op.props.ProviderId)
 	}
+	filesystems, err := assignContainerFilesystemIds(
+		op.unit.st, op.unit.ContainerInfo().Filesystems, op.props.Filesystems,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	containerInfo := ContainerInfo{
-		Address: op.props.Address,
-		Ports:   op.props.Ports,
+		Address:     op.props.Address,
+		Ports:       op.props.Ports,
+		Filesystems: filesystems,
 	}
 	var updates bson.D
 	asserts := isAliveDoc
@@ -378,6 +445,43 @@ func (op *UpdateUnitOperation) Build(attempt int) ([]txn.Op, error) {
 	return ops, nil
 }
 
+// assignContainerFilesystemIds returns the given filesystems, as reported
+// by the provider, with Juju storage and filesystem IDs. Filesystems that
+// are already recorded against the unit keep their IDs.
+func assignContainerFilesystemIds(mb modelBackend, existing, filesystems []ContainerFilesystem) ([]ContainerFilesystem, error) {
+	if len(filesystems) == 0 {
+		return nil, nil
+	}
+	type key struct {
+		storageName  string
+		filesystemId string
+	}
+	existingByKey := make(map[key]ContainerFilesystem)
+	for _, f := range existing {
+		existingByKey[key{f.StorageName, f.FilesystemId}] = f
+	}
+	result := make([]ContainerFilesystem, len(filesystems))
+	for i, f := range filesystems {
+		if e, ok := existingByKey[key{f.StorageName, f.FilesystemId}]; ok {
+			f.StorageId = e.StorageId
+			f.Id = e.Id
+		} else {
+			storageId, err := newStorageInstanceId(mb, f.StorageName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			id, err := newFilesystemId(mb, "")
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			f.StorageId = storageId
+			f.Id = id
+		}
+		result[i] = f
+	}
+	return result, nil
+}
+
 // Done is part of the ModelOperation interface.
 func (op *UpdateUnitOperation) Done(err error) error {
 	if err != nil {
@@ -420,6 +524,12 @@ type DestroyUnitOperation struct {
 	// to the unit is destroyed. If this is false, then detachable
 	// storage will be detached and left in the model.
 	DestroyStorage bool
+
+	// ScaleDown controls whether or not the scale of the unit's
+	// application is reduced by one along with destroying the unit,
+	// so that the pod backing it is not replaced. It only applies
+	// to units in CAAS models.
+	ScaleDown bool
 }
 
 // Build is part of the ModelOperation interface.
@@ -436,6 +546,13 @@ func (op *DestroyUnitOperation) Build(attempt int) ([]txn.Op, error) {
 	case errAlreadyDying:
 		return nil, jujutxn.ErrNoOperations
 	case nil:
+		if op.ScaleDown {
+			scaleOps, err := op.unit.scaleDownOps()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, scaleOps...)
+		}
 		return ops, nil
 	default:
 		return nil, err
@@ -443,6 +560,26 @@ func (op *DestroyUnitOperation) Build(attempt int) ([]txn.Op, error) {
 	return nil, jujutxn.ErrNoOperations
 }
 
+// scaleDownOps returns the operations required to reduce the scale of
+// the unit's application by one. No operations are returned if the
+// application is not in a CAAS model, is no longer alive, or is already
+// scaled to zero.
+func (u *Unit) scaleDownOps() ([]txn.Op, error) {
+	app, err := u.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := app.checkCAAS(); errors.IsNotSupported(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app.doc.Life != Alive || app.doc.DesiredScale == 0 {
+		return nil, nil
+	}
+	return []txn.Op{incScaleOp(app, -1)}, nil
+}
+
 // Done is part of the ModelOperation interface.
 func (op *DestroyUnitOperation) Done(err error) error {
 	if err != nil {
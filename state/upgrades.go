@@ -1373,3 +1373,98 @@ func addRelationStatus(st *State) error {
 	})
 	return errors.Annotate(err, "adding relation status")
 }
+
+// AddScaleToCAASApplications sets the desired scale of applications in
+// CAAS models, which was previously implied by their number of units.
+func AddScaleToCAASApplications(st *State) error {
+	models, closer := st.db().GetRawCollection(modelsC)
+	defer closer()
+
+	var modelDocs []struct {
+		UUID string `bson:"_id"`
+	}
+	err := models.Find(bson.D{{"type", string(ModelTypeCAAS)}}).Select(bson.D{{"_id", 1}}).All(&modelDocs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(modelDocs) == 0 {
+		return nil
+	}
+	modelUUIDs := make([]string, len(modelDocs))
+	for i, doc := range modelDocs {
+		modelUUIDs[i] = doc.UUID
+	}
+
+	applications, closer := st.db().GetRawCollection(applicationsC)
+	defer closer()
+
+	var doc struct {
+		DocID     string `bson:"_id"`
+		UnitCount int    `bson:"unitcount"`
+	}
+	var ops []txn.Op
+	iter := applications.Find(bson.D{
+		{"model-uuid", bson.D{{"$in", modelUUIDs}}},
+		{"scale", bson.D{{"$exists", false}}},
+	}).Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"scale", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"scale", doc.UnitCount}}}},
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(st.runRawTransaction(ops), "adding scale to CAAS applications")
+}
+
+// CreateAuditLogCollection creates the capped audit log collection and
+// its indexes, which are otherwise only created when the controller is
+// bootstrapped. If an uncapped collection has already been created by
+// an insert, it is converted to a capped one.
+func CreateAuditLogCollection(st *State) error {
+	if err := ensureSchemaCollections(st, auditLogC); err != nil {
+		return errors.Trace(err)
+	}
+	db := st.MongoSession().DB(jujuDB)
+	var stats struct {
+		Capped bool `bson:"capped"`
+	}
+	if err := db.Run(bson.D{{"collStats", auditLogC}}, &stats); err != nil {
+		return errors.Annotate(err, "reading audit log collection stats")
+	}
+	if stats.Capped {
+		return nil
+	}
+	err := db.Run(bson.D{
+		{"convertToCapped", auditLogC},
+		{"size", auditLogSize},
+	}, nil)
+	if err != nil {
+		return errors.Annotate(err, "converting audit log collection to capped")
+	}
+	// Converting a collection to capped drops its indexes.
+	return errors.Trace(ensureSchemaCollections(st, auditLogC))
+}
+
+// AddGenerationsIndexes creates the indexes of the generations
+// collection, which are otherwise only created when the controller
+// is bootstrapped.
+func AddGenerationsIndexes(st *State) error {
+	return errors.Trace(ensureSchemaCollections(st, generationsC))
+}
+
+// ensureSchemaCollections creates the named collections and their
+// indexes as specified in the collection schema.
+func ensureSchemaCollections(st *State, names ...string) error {
+	all := allCollections()
+	schema := make(collectionSchema)
+	for _, name := range names {
+		schema[name] = all[name]
+	}
+	return errors.Trace(schema.Create(st.MongoSession().DB(jujuDB), nil))
+}
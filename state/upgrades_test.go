@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -1931,6 +1932,66 @@ func (s *upgradesSuite) TestMoveOldAuditLogNoRecords(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *upgradesSuite) TestCreateAuditLogCollection(c *gc.C) {
+	db := s.state.MongoSession().DB("juju")
+	auditLog := db.C(auditLogC)
+	err := auditLog.DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An insert into a missing collection creates an uncapped
+	// collection with no indexes.
+	err = auditLog.Insert(bson.M{"_id": "conversation", "conversation-id": "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertCapped := func() {
+		var stats struct {
+			Capped bool `bson:"capped"`
+		}
+		err := db.Run(bson.D{{"collStats", auditLogC}}, &stats)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(stats.Capped, jc.IsTrue)
+		indexes, err := auditLog.Indexes()
+		c.Assert(err, jc.ErrorIsNil)
+		keys := set.NewStrings()
+		for _, index := range indexes {
+			keys.Add(strings.Join(index.Key, ","))
+		}
+		c.Assert(keys.Contains("conversation-id"), jc.IsTrue)
+		c.Assert(keys.Contains("kind,when,_id"), jc.IsTrue)
+	}
+
+	err = CreateAuditLogCollection(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCapped()
+	count, err := auditLog.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	// Running the upgrade again is a no-op.
+	err = CreateAuditLogCollection(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCapped()
+}
+
+func (s *upgradesSuite) TestAddGenerationsIndexes(c *gc.C) {
+	generations := s.state.MongoSession().DB("juju").C(generationsC)
+	err := generations.DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = AddGenerationsIndexes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	indexes, err := generations.Indexes()
+	c.Assert(err, jc.ErrorIsNil)
+	var keys []string
+	for _, index := range indexes {
+		keys = append(keys, strings.Join(index.Key, ","))
+	}
+	c.Assert(keys, jc.SameContents, []string{"_id", "model-uuid,name"})
+
+	err = AddGenerationsIndexes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *upgradesSuite) TestMoveOldAuditLogRename(c *gc.C) {
 	auditLog, closer := s.state.db().GetRawCollection("audit.log")
 	defer closer()
@@ -2025,3 +2086,53 @@ func (s *upgradesSuite) TestAddRelationStatus(c *gc.C) {
 		expectUpgradedData{statuses, expectedStatuses},
 	)
 }
+
+func (s *upgradesSuite) TestAddScaleToCAASApplications(c *gc.C) {
+	models, closer := s.state.db().GetRawCollection(modelsC)
+	defer closer()
+	applications, closer := s.state.db().GetRawCollection(applicationsC)
+	defer closer()
+
+	iaasUUID := "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	caasUUID := "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+	err := models.Insert(
+		bson.M{"_id": iaasUUID, "type": "iaas"},
+		bson.M{"_id": caasUUID, "type": "caas"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = applications.Insert(bson.M{
+		"_id":        iaasUUID + ":mysql",
+		"model-uuid": iaasUUID,
+		"unitcount":  2,
+	}, bson.M{
+		"_id":        caasUUID + ":gitlab",
+		"model-uuid": caasUUID,
+		"unitcount":  3,
+	}, bson.M{
+		"_id":        caasUUID + ":mariadb",
+		"model-uuid": caasUUID,
+		"unitcount":  1,
+		"scale":      2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedApplications := []bson.M{{
+		"_id":        iaasUUID + ":mysql",
+		"model-uuid": iaasUUID,
+		"unitcount":  2,
+	}, {
+		"_id":        caasUUID + ":gitlab",
+		"model-uuid": caasUUID,
+		"unitcount":  3,
+		"scale":      3,
+	}, {
+		"_id":        caasUUID + ":mariadb",
+		"model-uuid": caasUUID,
+		"unitcount":  1,
+		"scale":      2,
+	}}
+	s.assertUpgradedData(c, AddScaleToCAASApplications,
+		expectUpgradedData{applications, expectedApplications},
+	)
+}
@@ -0,0 +1,368 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/model"
+)
+
+// upgradeSeriesLockDoc records the progress of a managed series upgrade
+// of a machine. The document exists for as long as the upgrade is in
+// progress.
+type upgradeSeriesLockDoc struct {
+	DocID         string                               `bson:"_id"`
+	Id            string                               `bson:"machineid"`
+	ModelUUID     string                               `bson:"model-uuid"`
+	FromSeries    string                               `bson:"from-series"`
+	ToSeries      string                               `bson:"to-series"`
+	MachineStatus model.UpgradeSeriesStatus            `bson:"machine-status"`
+	UnitStatuses  map[string]model.UpgradeSeriesStatus `bson:"unit-statuses"`
+}
+
+// CreateUpgradeSeriesLock locks the machine for a managed upgrade to
+// the specified series. Every unit on the machine, including
+// subordinates, takes part in the upgrade, so all of their charms must
+// support the new series unless force is true.
+func (m *Machine) CreateUpgradeSeriesLock(toSeries string, force bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := checkModelActive(m.st); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		locked, err := m.IsLockedForSeriesUpgrade()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if locked {
+			return nil, errors.AlreadyExistsf("upgrade series lock for machine %q", m.Id())
+		}
+		if m.Series() == toSeries {
+			return nil, errors.Errorf("machine %q is already running series %q", m.Id(), toSeries)
+		}
+
+		principals := m.Principals()
+		units, err := m.verifyUnitsSeries(principals, toSeries, force)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitStatuses := make(map[string]model.UpgradeSeriesStatus)
+		for _, unit := range units {
+			unitStatuses[unit.Name()] = model.UpgradeSeriesPrepareStarted
+		}
+		return []txn.Op{
+			assertModelActiveOp(m.st.ModelUUID()),
+			{
+				C:      machinesC,
+				Id:     m.doc.DocID,
+				Assert: bson.D{{"life", Alive}, {"principals", principals}},
+			}, {
+				C:      upgradeSeriesLocksC,
+				Id:     m.doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &upgradeSeriesLockDoc{
+					Id:            m.Id(),
+					FromSeries:    m.Series(),
+					ToSeries:      toSeries,
+					MachineStatus: model.UpgradeSeriesPrepareStarted,
+					UnitStatuses:  unitStatuses,
+				},
+			},
+		}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot start series upgrade for %q", m)
+}
+
+// RemoveUpgradeSeriesLock removes the machine's series upgrade lock, if
+// there is one.
+func (m *Machine) RemoveUpgradeSeriesLock() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		locked, err := m.IsLockedForSeriesUpgrade()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !locked {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{removeUpgradeSeriesLockOp(m.st, m.Id())}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot remove series upgrade lock for %q", m)
+}
+
+func removeUpgradeSeriesLockOp(st *State, machineId string) txn.Op {
+	return txn.Op{
+		C:      upgradeSeriesLocksC,
+		Id:     st.docID(machineId),
+		Remove: true,
+	}
+}
+
+// IsLockedForSeriesUpgrade returns true if the machine is undergoing a
+// managed series upgrade.
+func (m *Machine) IsLockedForSeriesUpgrade() (bool, error) {
+	locks, closer := m.st.db().GetCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	count, err := locks.FindId(m.doc.DocID).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+func (m *Machine) getUpgradeSeriesLock() (*upgradeSeriesLockDoc, error) {
+	locks, closer := m.st.db().GetCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	var lock upgradeSeriesLockDoc
+	err := locks.FindId(m.doc.DocID).One(&lock)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("upgrade series lock for machine %q", m.Id())
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get upgrade series lock for machine %q", m.Id())
+	}
+	return &lock, nil
+}
+
+// UpgradeSeriesTarget returns the series to which the machine is being
+// upgraded. A NotFound error is returned if the machine is not locked
+// for a series upgrade.
+func (m *Machine) UpgradeSeriesTarget() (string, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return lock.ToSeries, nil
+}
+
+// UpgradeSeriesStatus returns the progress of the machine through its
+// series upgrade. UpgradeSeriesNotStarted is returned if the machine is
+// not locked for a series upgrade.
+func (m *Machine) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if errors.IsNotFound(err) {
+		return model.UpgradeSeriesNotStarted, nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return lock.MachineStatus, nil
+}
+
+// UpgradeSeriesUnitStatuses returns the progress through the series
+// upgrade of each unit on the machine, keyed by unit name.
+func (m *Machine) UpgradeSeriesUnitStatuses() (map[string]model.UpgradeSeriesStatus, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return lock.UnitStatuses, nil
+}
+
+// SetUpgradeSeriesStatus records the progress of the machine through its
+// series upgrade.
+func (m *Machine) SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus) error {
+	if err := status.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		lock, err := m.getUpgradeSeriesLock()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if lock.MachineStatus == status {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"machine-status", lock.MachineStatus}},
+			Update: bson.D{{"$set", bson.D{{"machine-status", status}}}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot set series upgrade status for %q", m)
+}
+
+// SetUpgradeSeriesUnitStatus records the progress of the named unit
+// through the machine's series upgrade.
+func (m *Machine) SetUpgradeSeriesUnitStatus(unitName string, status model.UpgradeSeriesStatus) error {
+	if err := status.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		lock, err := m.getUpgradeSeriesLock()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, ok := lock.UnitStatuses[unitName]
+		if !ok {
+			return nil, errors.NotFoundf("unit %q in series upgrade of machine %q", unitName, m.Id())
+		}
+		if current == status {
+			return nil, jujutxn.ErrNoOperations
+		}
+		field := "unit-statuses." + unitName
+		return []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{field, current}},
+			Update: bson.D{{"$set", bson.D{{field, status}}}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot set series upgrade status for unit %q", unitName)
+}
+
+// StartUpgradeSeriesCompletion records that the operating system of the
+// machine has been upgraded, so the post-series-upgrade hooks can run.
+// It is an error to call this before the machine has been prepared.
+func (m *Machine) StartUpgradeSeriesCompletion() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		lock, err := m.getUpgradeSeriesLock()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if lock.MachineStatus == model.UpgradeSeriesCompleteStarted {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if lock.MachineStatus != model.UpgradeSeriesPrepareCompleted {
+			return nil, errors.Errorf("machine %q is not ready to complete its series upgrade (status %q)",
+				m.Id(), lock.MachineStatus)
+		}
+		set := bson.D{{"machine-status", model.UpgradeSeriesCompleteStarted}}
+		for unitName := range lock.UnitStatuses {
+			set = append(set, bson.DocElem{"unit-statuses." + unitName, model.UpgradeSeriesCompleteStarted})
+		}
+		return []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"machine-status", model.UpgradeSeriesPrepareCompleted}},
+			Update: bson.D{{"$set", set}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot complete series upgrade for %q", m)
+}
+
+// AbortUpgradeSeries removes the series upgrade lock without changing
+// the series of the machine, so that its units return to normal
+// operation. A series upgrade may only be aborted before the operating
+// system has been upgraded, or after it has failed.
+func (m *Machine) AbortUpgradeSeries() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		lock, err := m.getUpgradeSeriesLock()
+		if errors.IsNotFound(err) {
+			return nil, errors.Errorf("machine %q is not undergoing a series upgrade", m.Id())
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch lock.MachineStatus {
+		case model.UpgradeSeriesPrepareStarted,
+			model.UpgradeSeriesPrepareCompleted,
+			model.UpgradeSeriesError:
+		default:
+			return nil, errors.Errorf("machine %q has started completing its series upgrade (status %q)",
+				m.Id(), lock.MachineStatus)
+		}
+		op := removeUpgradeSeriesLockOp(m.st, m.Id())
+		op.Assert = bson.D{{"machine-status", lock.MachineStatus}}
+		return []txn.Op{op}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot abort series upgrade for %q", m)
+}
+
+// CompleteUpgradeSeries records the new series of the machine and its
+// units, and removes the series upgrade lock. It is an error to call
+// this before every unit has completed its post-series-upgrade hook.
+func (m *Machine) CompleteUpgradeSeries() error {
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for unitName, status := range lock.UnitStatuses {
+		if status != model.UpgradeSeriesCompleted {
+			return errors.Errorf("unit %q has not completed its series upgrade (status %q)", unitName, status)
+		}
+	}
+	// The units' charms were verified against the new series when the
+	// lock was created, so there is no need to check them again.
+	if err := m.UpdateMachineSeries(lock.ToSeries, true); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.RemoveUpgradeSeriesLock())
+}
+
+// WatchUpgradeSeriesNotifications returns a watcher that fires when the
+// machine's series upgrade lock is created, changed or removed.
+func (m *Machine) WatchUpgradeSeriesNotifications() NotifyWatcher {
+	return newEntityWatcher(m.st, upgradeSeriesLocksC, m.doc.DocID)
+}
+
+func (u *Unit) upgradeSeriesMachine() (*Machine, error) {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u.st.Machine(machineId)
+}
+
+// UpgradeSeriesStatus returns the progress of the unit through the
+// series upgrade of its machine. UpgradeSeriesNotStarted is returned if
+// no upgrade is in progress.
+func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
+	machine, err := u.upgradeSeriesMachine()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	statuses, err := machine.UpgradeSeriesUnitStatuses()
+	if errors.IsNotFound(err) {
+		return model.UpgradeSeriesNotStarted, nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	status, ok := statuses[u.Name()]
+	if !ok {
+		return model.UpgradeSeriesNotStarted, nil
+	}
+	return status, nil
+}
+
+// SetUpgradeSeriesStatus records the progress of the unit through the
+// series upgrade of its machine.
+func (u *Unit) SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus) error {
+	machine, err := u.upgradeSeriesMachine()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(machine.SetUpgradeSeriesUnitStatus(u.Name(), status))
+}
+
+// WatchUpgradeSeriesNotifications returns a watcher that fires when the
+// series upgrade lock of the unit's machine changes.
+func (u *Unit) WatchUpgradeSeriesNotifications() (NotifyWatcher, error) {
+	machine, err := u.upgradeSeriesMachine()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine.WatchUpgradeSeriesNotifications(), nil
+}
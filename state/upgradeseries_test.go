@@ -0,0 +1,259 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	ConnSuite
+
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	var err error
+	s.machine, err = s.State.AddMachine("precise", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingApplicationForSeries(c, s.State, "precise", "multi-series", ch)
+	s.unit, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLock(c *gc.C) {
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+
+	err = s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err = s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsTrue)
+
+	target, err := s.machine.UpgradeSeriesTarget()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.Equals, "trusty")
+
+	status, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, model.UpgradeSeriesPrepareStarted)
+
+	statuses, err := s.machine.UpgradeSeriesUnitStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses, jc.DeepEquals, map[string]model.UpgradeSeriesStatus{
+		"multi-series/0": model.UpgradeSeriesPrepareStarted,
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockAlreadyExists(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockSameSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("precise", false)
+	c.Assert(err, gc.ErrorMatches, `cannot start series upgrade for "0": machine "0" is already running series "precise"`)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockUnsupportedSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("xenial", false)
+	c.Assert(errors.Cause(err), jc.Satisfies, state.IsIncompatibleSeriesError)
+
+	err = s.machine.CreateUpgradeSeriesLock("xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveUpgradeSeriesLock(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+
+	// Removing a missing lock is not an error.
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestUpgradeSeriesStatusNotLocked(c *gc.C) {
+	status, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, model.UpgradeSeriesNotStarted)
+
+	status, err = s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, model.UpgradeSeriesNotStarted)
+
+	_, err = s.machine.UpgradeSeriesTarget()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUnitUpgradeSeriesStatus(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, model.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUnitUpgradeSeriesStatusInvalid(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.SetUpgradeSeriesStatus("bad")
+	c.Assert(err, gc.ErrorMatches, `upgrade series status "bad" not valid`)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUnitUpgradeSeriesStatusNotLocked(c *gc.C) {
+	err := s.unit.SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestStartUpgradeSeriesCompletionNotPrepared(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.StartUpgradeSeriesCompletion()
+	c.Assert(err, gc.ErrorMatches, `.*machine "0" is not ready to complete its series upgrade \(status "prepare started"\)`)
+}
+
+func (s *UpgradeSeriesSuite) TestCompleteUpgradeSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.StartUpgradeSeriesCompletion()
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, model.UpgradeSeriesCompleteStarted)
+
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `unit "multi-series/0" has not completed its series upgrade \(status "complete started"\)`)
+
+	err = s.unit.SetUpgradeSeriesStatus(model.UpgradeSeriesCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "trusty")
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Series(), gc.Equals, "trusty")
+}
+
+func (s *UpgradeSeriesSuite) TestAbortUpgradeSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetUpgradeSeriesStatus(model.UpgradeSeriesError)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesStatus(model.UpgradeSeriesError)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.AbortUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+	status, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, model.UpgradeSeriesNotStarted)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "precise")
+}
+
+func (s *UpgradeSeriesSuite) TestAbortUpgradeSeriesNotLocked(c *gc.C) {
+	err := s.machine.AbortUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot abort series upgrade for "0": machine "0" is not undergoing a series upgrade`)
+}
+
+func (s *UpgradeSeriesSuite) TestAbortUpgradeSeriesCompleteStarted(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.StartUpgradeSeriesCompletion()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.AbortUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `.*machine "0" has started completing its series upgrade \(status "complete started"\)`)
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsTrue)
+}
+
+func (s *UpgradeSeriesSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	w, err := s.unit.WatchUpgradeSeriesNotifications()
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.unit.SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveMachineRemovesLock(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UnassignFromMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+}
@@ -1611,7 +1611,119 @@ func (u *Unit) WatchConfigSettings() (NotifyWatcher, error) {
 		return nil, fmt.Errorf("unit charm not set")
 	}
 	settingsKey := applicationCharmConfigKey(u.doc.Application, u.doc.CharmURL)
-	return newEntityWatcher(u.st, settingsC, u.st.docID(settingsKey)), nil
+	return newUnitConfigSettingsWatcher(u, u.st.docID(settingsKey)), nil
+}
+
+// unitConfigSettingsWatcher notifies about changes to the charm config
+// settings seen by a unit; that is, changes to its application's
+// settings and to any settings staged on the branch the unit tracks.
+// It also notifies about changes to the application's endpoint
+// bindings, so that the unit's charm can re-read its addresses with
+// network-get.
+type unitConfigSettingsWatcher struct {
+	commonWatcher
+	unit        *Unit
+	settingsKey string
+	out         chan struct{}
+}
+
+var _ Watcher = (*unitConfigSettingsWatcher)(nil)
+
+func newUnitConfigSettingsWatcher(unit *Unit, settingsKey string) NotifyWatcher {
+	w := &unitConfigSettingsWatcher{
+		commonWatcher: newCommonWatcher(unit.st),
+		unit:          unit,
+		settingsKey:   settingsKey,
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the unitConfigSettingsWatcher.
+func (w *unitConfigSettingsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+// branchConfig returns the settings staged for the unit's application
+// on the branch the unit tracks, or nil if it tracks no branch.
+func (w *unitConfigSettingsWatcher) branchConfig() (map[string]interface{}, error) {
+	gen, err := w.unit.st.unitBranch(w.unit.doc.Application, w.unit.doc.Name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gen.appConfig(w.unit.doc.Application), nil
+}
+
+func (w *unitConfigSettingsWatcher) loop() error {
+	settingsColl, closer := w.db.GetCollection(settingsC)
+	txnRevno, err := getTxnRevno(settingsColl, w.settingsKey)
+	closer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settingsCh := make(chan watcher.Change)
+	w.watcher.Watch(settingsC, w.settingsKey, txnRevno, settingsCh)
+	defer w.watcher.Unwatch(settingsC, w.settingsKey, settingsCh)
+
+	bindingsKey := w.backend.docID(applicationGlobalKey(w.unit.doc.Application))
+	bindingsColl, closer := w.db.GetCollection(endpointBindingsC)
+	bindingsRevno, err := getTxnRevno(bindingsColl, bindingsKey)
+	closer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	bindingsCh := make(chan watcher.Change)
+	w.watcher.Watch(endpointBindingsC, bindingsKey, bindingsRevno, bindingsCh)
+	defer w.watcher.Unwatch(endpointBindingsC, bindingsKey, bindingsCh)
+
+	generationsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(generationsC, generationsCh, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(generationsC, generationsCh)
+
+	known, err := w.branchConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-settingsCh:
+			if _, ok := collect(ch, settingsCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case ch := <-bindingsCh:
+			if _, ok := collect(ch, bindingsCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case ch := <-generationsCh:
+			if _, ok := collect(ch, generationsCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			latest, err := w.branchConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !reflect.DeepEqual(latest, known) {
+				known = latest
+				out = w.out
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
 }
 
 // WatchMeterStatus returns a watcher observing changes that affect the meter status
@@ -1945,6 +2057,73 @@ func (w *machineAddressesWatcher) loop() error {
 	}
 }
 
+// applicationScaleWatcher notifies about changes to the desired
+// scale of an application.
+type applicationScaleWatcher struct {
+	commonWatcher
+	app *Application
+	out chan struct{}
+}
+
+var _ Watcher = (*applicationScaleWatcher)(nil)
+
+// WatchScale returns a new NotifyWatcher watching the application's
+// desired scale. Once the application is removed, its scale is
+// considered to be zero.
+func (a *Application) WatchScale() NotifyWatcher {
+	w := &applicationScaleWatcher{
+		commonWatcher: newCommonWatcher(a.st),
+		out:           make(chan struct{}),
+		app:           &Application{st: a.st, doc: a.doc}, // Copy so it may be freely refreshed
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *applicationScaleWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *applicationScaleWatcher) loop() error {
+	applications, closer := w.db.GetCollection(applicationsC)
+	revno, err := getTxnRevno(applications, w.app.doc.DocID)
+	closer()
+	if err != nil {
+		return err
+	}
+	applicationCh := make(chan watcher.Change)
+	w.watcher.Watch(applicationsC, w.app.doc.DocID, revno, applicationCh)
+	defer w.watcher.Unwatch(applicationsC, w.app.doc.DocID, applicationCh)
+	scale := w.app.GetScale()
+	out := w.out
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-applicationCh:
+			newScale := 0
+			if err := w.app.Refresh(); err == nil {
+				newScale = w.app.GetScale()
+			} else if !errors.IsNotFound(err) {
+				return err
+			}
+			if newScale != scale {
+				scale = newScale
+				out = w.out
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // WatchCleanups starts and returns a CleanupWatcher.
 func (st *State) WatchCleanups() NotifyWatcher {
 	return newNotifyCollWatcher(st, cleanupsC, isLocalID(st))
@@ -329,6 +329,45 @@ type FilesystemAttachmentParams struct {
 	Path string
 }
 
+// KubernetesFilesystemParams is a fully specified set of parameters for
+// filesystem creation in a Kubernetes cluster, derived from one or more
+// of user-specified storage constraints, a storage pool definition, and
+// charm storage metadata.
+type KubernetesFilesystemParams struct {
+	// StorageName is the name of the charm storage that the
+	// filesystem is created for.
+	StorageName string
+
+	// Size is the minimum size of the filesystem in MiB.
+	Size uint64
+
+	// The provider type for this filesystem.
+	Provider ProviderType
+
+	// Attributes is a set of provider-specific options for storage creation,
+	// as defined in a storage pool.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created filesystem, if the
+	// storage provider supports tags.
+	ResourceTags map[string]string
+
+	// Attachment identifies the mount point the filesystem should
+	// have in each pod of the application.
+	Attachment *KubernetesFilesystemAttachmentParams
+}
+
+// KubernetesFilesystemAttachmentParams is a set of parameters for mounting
+// a filesystem in the pods of a Kubernetes application.
+type KubernetesFilesystemAttachmentParams struct {
+	// Path is the path at which the filesystem is to be mounted
+	// in each pod.
+	Path string
+
+	// ReadOnly indicates that the filesystem should be mounted read-only.
+	ReadOnly bool
+}
+
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
 // for one volume. Volume and VolumeAttachment should only be used if Error is
 // nil.
@@ -35,6 +35,9 @@ type StateBackend interface {
 	MigrateLeasesToGlobalTime() error
 	MoveOldAuditLog() error
 	AddRelationStatus() error
+	AddScaleToCAASApplications() error
+	CreateAuditLogCollection() error
+	AddGenerationsIndexes() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -137,6 +140,18 @@ func (s stateBackend) AddRelationStatus() error {
 	return state.AddRelationStatus(s.st)
 }
 
+func (s stateBackend) AddScaleToCAASApplications() error {
+	return state.AddScaleToCAASApplications(s.st)
+}
+
+func (s stateBackend) CreateAuditLogCollection() error {
+	return state.CreateAuditLogCollection(s.st)
+}
+
+func (s stateBackend) AddGenerationsIndexes() error {
+	return state.AddGenerationsIndexes(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
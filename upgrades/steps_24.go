@@ -13,5 +13,26 @@ func stateStepsFor24() []Step {
 				return context.State().MoveOldAuditLog()
 			},
 		},
+		&upgradeStep{
+			description: "add scale to CAAS applications",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddScaleToCAASApplications()
+			},
+		},
+		&upgradeStep{
+			description: "create the capped audit log collection",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().CreateAuditLogCollection()
+			},
+		},
+		&upgradeStep{
+			description: "add indexes to the generations collection",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddGenerationsIndexes()
+			},
+		},
 	}
 }
@@ -25,3 +25,21 @@ func (s *steps24Suite) TestMoveOldAuditLog(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps24Suite) TestAddScaleToCAASApplications(c *gc.C) {
+	step := findStateStep(c, v24, "add scale to CAAS applications")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
+func (s *steps24Suite) TestCreateAuditLogCollection(c *gc.C) {
+	step := findStateStep(c, v24, "create the capped audit log collection")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
+func (s *steps24Suite) TestAddGenerationsIndexes(c *gc.C) {
+	step := findStateStep(c, v24, "add indexes to the generations collection")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -4,14 +4,33 @@
 package apiserver
 
 import (
+	"net/http"
+	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
+)
+
+const (
+	// traceLogMaxSizeMB and traceLogMaxBackups control the rotation
+	// of the trace file written by the "file" tracing exporter.
+	traceLogMaxSizeMB  = 300
+	traceLogMaxBackups = 5
+
+	// tracingFlushInterval is the longest a span waits before it's
+	// posted to the tracing collector.
+	tracingFlushInterval = 5 * time.Second
+
+	// tracingRequestTimeout is how long we wait for the tracing
+	// collector to accept a batch of spans.
+	tracingRequestTimeout = 30 * time.Second
 )
 
 func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
@@ -137,6 +156,38 @@ func getLogSinkConfig(cfg agent.Config) (apiserver.LogSinkConfig, error) {
 	return result, nil
 }
 
+// tracingConfig holds the controller settings that control where
+// trace spans are sent.
+type tracingConfig struct {
+	Exporter     string
+	CollectorURL string
+}
+
+func getTracingConfig(cfg controller.Config) tracingConfig {
+	return tracingConfig{
+		Exporter:     cfg.TracingExporter(),
+		CollectorURL: cfg.TracingCollectorURL(),
+	}
+}
+
+// newTracingExporter returns the exporter described by the tracing
+// config, or nil if tracing is disabled.
+func newTracingExporter(config tracingConfig, logDir string, clock clock.Clock) (tracing.Exporter, error) {
+	switch config.Exporter {
+	case controller.TracingExporterFile:
+		path := filepath.Join(logDir, "trace.log")
+		return tracing.NewFileExporter(path, traceLogMaxSizeMB, traceLogMaxBackups)
+	case controller.TracingExporterZipkin:
+		return tracing.NewHTTPExporter(tracing.HTTPExporterConfig{
+			URL:           config.CollectorURL,
+			Doer:          &http.Client{Timeout: tracingRequestTimeout},
+			Clock:         clock,
+			FlushInterval: tracingFlushInterval,
+		})
+	}
+	return nil, nil
+}
+
 func getAuditLogConfig(cfg controller.Config) apiserver.AuditLogConfig {
 	return apiserver.AuditLogConfig{
 		Enabled:        cfg.AuditingEnabled(),
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/gate"
@@ -32,6 +33,7 @@ type ManifoldConfig struct {
 	UpgradeGateName   string
 
 	PrometheusRegisterer              prometheus.Registerer
+	Tracer                            *tracing.Tracer
 	RegisterIntrospectionHTTPHandlers func(func(path string, _ http.Handler))
 	Hub                               *pubsub.StructuredHub
 
@@ -61,6 +63,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.PrometheusRegisterer == nil {
 		return errors.NotValidf("nil PrometheusRegisterer")
 	}
+	if config.Tracer == nil {
+		return errors.NotValidf("nil Tracer")
+	}
 	if config.RegisterIntrospectionHTTPHandlers == nil {
 		return errors.NotValidf("nil RegisterIntrospectionHTTPHandlers")
 	}
@@ -134,6 +139,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Clock:                             clock,
 		StatePool:                         statePool,
 		PrometheusRegisterer:              config.PrometheusRegisterer,
+		Tracer:                            config.Tracer,
 		RegisterIntrospectionHTTPHandlers: config.RegisterIntrospectionHTTPHandlers,
 		RestoreStatus:                     restoreStatus,
 		UpgradeComplete:                   upgradeLock.IsUnlocked,
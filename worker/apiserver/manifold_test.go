@@ -19,6 +19,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/apiserver"
 	"github.com/juju/juju/worker/dependency"
@@ -36,6 +37,7 @@ type ManifoldSuite struct {
 	clock                *testing.Clock
 	state                stubStateTracker
 	prometheusRegisterer stubPrometheusRegisterer
+	tracer               *tracing.Tracer
 	certWatcher          stubCertWatcher
 	hub                  pubsub.StructuredHub
 	upgradeGate          stubGateWaiter
@@ -52,6 +54,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.clock = testing.NewClock(time.Time{})
 	s.state = stubStateTracker{}
 	s.prometheusRegisterer = stubPrometheusRegisterer{}
+	s.tracer = tracing.NewTracer("machine-0", s.clock)
 	s.certWatcher = stubCertWatcher{}
 	s.upgradeGate = stubGateWaiter{}
 	s.stub.ResetCalls()
@@ -65,6 +68,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		StateName:                         "state",
 		UpgradeGateName:                   "upgrade",
 		PrometheusRegisterer:              &s.prometheusRegisterer,
+		Tracer:                            s.tracer,
 		RegisterIntrospectionHTTPHandlers: func(func(string, http.Handler)) {},
 		Hub:       &s.hub,
 		NewWorker: s.newWorker,
@@ -153,6 +157,7 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		Clock:                s.clock,
 		StatePool:            &s.state.pool,
 		PrometheusRegisterer: &s.prometheusRegisterer,
+		Tracer:               s.tracer,
 		Hub:                  &s.hub,
 	})
 }
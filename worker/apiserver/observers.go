@@ -12,7 +12,9 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/observer/metricobserver"
+	"github.com/juju/juju/apiserver/observer/tracingobserver"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
 )
 
 func newObserverFn(
@@ -20,6 +22,7 @@ func newObserverFn(
 	controllerConfig controller.Config,
 	clock clock.Clock,
 	prometheusRegisterer prometheus.Registerer,
+	tracer *tracing.Tracer,
 ) (observer.ObserverFactory, error) {
 
 	var observerFactories []observer.ObserverFactory
@@ -44,5 +47,14 @@ func newObserverFn(
 	}
 	observerFactories = append(observerFactories, metricObserver)
 
+	// Tracing observer.
+	tracingObserver, err := tracingobserver.NewObserverFactory(tracingobserver.Config{
+		Tracer: tracer,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating tracing observer factory")
+	}
+	observerFactories = append(observerFactories, tracingObserver)
+
 	return observer.ObserverFactoryMultiplexer(observerFactories...), nil
 }
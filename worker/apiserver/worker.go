@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"net"
 	"net/http"
+	"reflect"
 	"strconv"
 
 	"github.com/juju/errors"
@@ -19,7 +20,9 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
 )
 
 var logger = loggo.GetLogger("juju.worker.apiserver")
@@ -31,6 +34,7 @@ type Config struct {
 	Hub                               *pubsub.StructuredHub
 	StatePool                         *state.StatePool
 	PrometheusRegisterer              prometheus.Registerer
+	Tracer                            *tracing.Tracer
 	RegisterIntrospectionHTTPHandlers func(func(path string, _ http.Handler))
 	RestoreStatus                     func() state.RestoreStatus
 	UpgradeComplete                   func() bool
@@ -38,9 +42,18 @@ type Config struct {
 	NewServer                         NewServerFunc
 }
 
+// Server is the API server run by the worker.
+type Server interface {
+	worker.Worker
+
+	// UpdateAuditLogConfig replaces the audit logging configuration
+	// of the server.
+	UpdateAuditLogConfig(apiserver.AuditLogConfig, auditlog.AuditLog) error
+}
+
 // NewServerFunc is the type of function that will be used
 // by the worker to create a new API server.
-type NewServerFunc func(*state.StatePool, net.Listener, apiserver.ServerConfig) (worker.Worker, error)
+type NewServerFunc func(*state.StatePool, net.Listener, apiserver.ServerConfig) (Server, error)
 
 // Validate validates the API server configuration.
 func (config Config) Validate() error {
@@ -59,6 +72,9 @@ func (config Config) Validate() error {
 	if config.PrometheusRegisterer == nil {
 		return errors.NotValidf("nil PrometheusRegisterer")
 	}
+	if config.Tracer == nil {
+		return errors.NotValidf("nil Tracer")
+	}
 	if config.RegisterIntrospectionHTTPHandlers == nil {
 		return errors.NotValidf("nil RegisterIntrospectionHTTPHandlers")
 	}
@@ -110,6 +126,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		controllerConfig,
 		config.Clock,
 		config.PrometheusRegisterer,
+		config.Tracer,
 	)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
@@ -136,9 +153,10 @@ func NewWorker(config Config) (worker.Worker, error) {
 		PrometheusRegisterer:          config.PrometheusRegisterer,
 		AuditLogConfig:                auditConfig,
 	}
+	controllerID := config.AgentConfig.Tag().Id()
 	if auditConfig.Enabled {
-		serverConfig.AuditLog = auditlog.NewLogFile(
-			logDir, auditConfig.MaxSizeMB, auditConfig.MaxBackups)
+		serverConfig.AuditLog = newAuditLog(
+			config.StatePool.SystemState(), controllerID, logDir, auditConfig)
 	}
 
 	listener, err := net.Listen("tcp", listenAddr)
@@ -152,13 +170,165 @@ func NewWorker(config Config) (worker.Worker, error) {
 		}
 		return nil, errors.Trace(err)
 	}
-	return server, nil
+
+	w := &serverWorker{
+		server:       server,
+		st:           config.StatePool.SystemState(),
+		controllerID: controllerID,
+		logDir:       logDir,
+		auditConfig:  auditConfig,
+		auditLog:     serverConfig.AuditLog,
+		clock:        config.Clock,
+		tracer:       config.Tracer,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{server},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// serverWorker runs an API server, and passes changes to the
+// controller configuration on to it.
+type serverWorker struct {
+	catacomb     catacomb.Catacomb
+	server       Server
+	st           *state.State
+	controllerID string
+	logDir       string
+
+	auditConfig apiserver.AuditLogConfig
+	auditLog    auditlog.AuditLog
+
+	// The tracer is shared with the rest of the agent; the worker
+	// owns its exporter, which is set from the controller config.
+	clock         clock.Clock
+	tracer        *tracing.Tracer
+	tracingConfig tracingConfig
+	exporter      tracing.Exporter
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *serverWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *serverWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *serverWorker) loop() error {
+	configWatcher := w.st.WatchControllerConfig()
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	// Tracing is set up by the watcher's initial event.
+	defer w.stopTracing()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := w.st.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot fetch the controller config")
+			}
+			if err := w.updateAuditConfig(getAuditLogConfig(controllerConfig)); err != nil {
+				return errors.Trace(err)
+			}
+			w.updateTracingConfig(getTracingConfig(controllerConfig))
+		}
+	}
+}
+
+// updateAuditConfig passes a changed audit logging configuration on to
+// the server. The audit log file is only reopened if the settings that
+// control its rotation have changed; the log it replaces is closed.
+func (w *serverWorker) updateAuditConfig(config apiserver.AuditLogConfig) error {
+	if reflect.DeepEqual(config, w.auditConfig) {
+		return nil
+	}
+	auditLog := w.auditLog
+	switch {
+	case !config.Enabled:
+		auditLog = nil
+	case auditLog == nil ||
+		config.MaxSizeMB != w.auditConfig.MaxSizeMB ||
+		config.MaxBackups != w.auditConfig.MaxBackups:
+		auditLog = newAuditLog(w.st, w.controllerID, w.logDir, config)
+	}
+	if err := w.server.UpdateAuditLogConfig(config, auditLog); err != nil {
+		return errors.Annotate(err, "cannot update audit log config")
+	}
+	logger.Infof("audit log config updated: enabled %v, max size %dM, max backups %d",
+		config.Enabled, config.MaxSizeMB, config.MaxBackups)
+	w.auditConfig = config
+	if w.auditLog != nil && w.auditLog != auditLog {
+		// Conversations already in progress may still record to
+		// the old log, which reopens its file if they do.
+		if err := w.auditLog.Close(); err != nil {
+			logger.Warningf("closing previous audit log: %v", err)
+		}
+	}
+	w.auditLog = auditLog
+	return nil
+}
+
+// updateTracingConfig replaces the tracer's exporter when the tracing
+// settings have changed. Tracing is only diagnostic, so failing to
+// create the exporter disables tracing rather than stopping the server.
+func (w *serverWorker) updateTracingConfig(config tracingConfig) {
+	if config == w.tracingConfig {
+		return
+	}
+	exporter, err := newTracingExporter(config, w.logDir, w.clock)
+	if err != nil {
+		logger.Errorf("cannot create %q tracing exporter, tracing disabled: %v", config.Exporter, err)
+	}
+	w.tracer.SetExporter(exporter)
+	w.closeExporter()
+	w.exporter = exporter
+	w.tracingConfig = config
+	logger.Infof("tracing config updated: exporter %q", config.Exporter)
+}
+
+// stopTracing disables the tracer and closes its exporter.
+func (w *serverWorker) stopTracing() {
+	w.tracer.SetExporter(nil)
+	w.closeExporter()
+}
+
+func (w *serverWorker) closeExporter() {
+	if w.exporter == nil {
+		return
+	}
+	if err := w.exporter.Close(); err != nil {
+		logger.Warningf("closing tracing exporter: %v", err)
+	}
+	w.exporter = nil
+}
+
+// newAuditLog returns an audit log that records to both the audit log
+// file in the log directory and the controller's audit log collection,
+// which can be queried and forwarded.
+var newAuditLog = func(st *state.State, controllerID, logDir string, config apiserver.AuditLogConfig) auditlog.AuditLog {
+	return auditlog.NewTee(
+		auditlog.NewLogFile(logDir, config.MaxSizeMB, config.MaxBackups),
+		state.NewAuditLog(st, controllerID),
+	)
 }
 
 func newServerShim(
 	statePool *state.StatePool,
 	listener net.Listener,
 	config apiserver.ServerConfig,
-) (worker.Worker, error) {
+) (Server, error) {
 	return apiserver.NewServer(statePool, listener, config)
 }
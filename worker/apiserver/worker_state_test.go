@@ -4,7 +4,10 @@
 package apiserver_test
 
 import (
+	"io/ioutil"
 	"net"
+	"path/filepath"
+	"time"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -12,6 +15,8 @@ import (
 	gc "gopkg.in/check.v1"
 
 	coreapiserver "github.com/juju/juju/apiserver"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -124,3 +129,152 @@ func (s *WorkerStateSuite) TestStart(c *gc.C) {
 		AuditLogConfig:       auditLogConfig,
 	})
 }
+
+func (s *WorkerStateSuite) TestAuditConfigUpdated(c *gc.C) {
+	w, err := apiserver.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"audit-log-exclude-methods": []interface{}{"Other.Method"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	call := s.waitForAuditConfigUpdate(c, 1)
+	c.Assert(call.Args[0], jc.DeepEquals, coreapiserver.AuditLogConfig{
+		Enabled:        true,
+		CaptureAPIArgs: true,
+		MaxSizeMB:      200,
+		MaxBackups:     5,
+		ExcludeMethods: set.NewStrings("Other.Method"),
+	})
+	c.Assert(call.Args[1], gc.NotNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"auditing-enabled": false,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	call = s.waitForAuditConfigUpdate(c, 2)
+	c.Assert(call.Args[0].(coreapiserver.AuditLogConfig).Enabled, jc.IsFalse)
+	c.Assert(call.Args[1], gc.IsNil)
+}
+
+func (s *WorkerStateSuite) TestAuditConfigUpdateClosesReplacedLog(c *gc.C) {
+	var logs []*closeRecordingAuditLog
+	s.workerFixture.PatchValue(apiserver.NewAuditLog, func(*state.State, string, string, coreapiserver.AuditLogConfig) auditlog.AuditLog {
+		log := &closeRecordingAuditLog{closed: make(chan struct{})}
+		logs = append(logs, log)
+		return log
+	})
+	w, err := apiserver.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	c.Assert(logs, gc.HasLen, 1)
+
+	// Changing the rotation settings reopens the log.
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"audit-log-max-size": "100M",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	call := s.waitForAuditConfigUpdate(c, 1)
+	c.Assert(call.Args[1], gc.Equals, logs[1])
+	s.assertAuditLogClosed(c, logs[0])
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"auditing-enabled": false,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForAuditConfigUpdate(c, 2)
+	s.assertAuditLogClosed(c, logs[1])
+	c.Assert(logs, gc.HasLen, 2)
+}
+
+func (s *WorkerStateSuite) assertAuditLogClosed(c *gc.C, log *closeRecordingAuditLog) {
+	select {
+	case <-log.closed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for audit log to be closed")
+	}
+}
+
+// closeRecordingAuditLog is an audit log that discards its records
+// and signals when it is closed.
+type closeRecordingAuditLog struct {
+	auditlog.AuditLog
+	closed chan struct{}
+}
+
+func (l *closeRecordingAuditLog) Close() error {
+	close(l.closed)
+	return nil
+}
+
+func (s *WorkerStateSuite) TestTracingConfigUpdated(c *gc.C) {
+	w, err := apiserver.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// Tracing is disabled by default.
+	c.Assert(s.tracer.StartSpan("test", tracing.KindNone, tracing.SpanContext{}), gc.IsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"tracing-exporter": "file",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForTracing(c, true)
+	span := s.tracer.StartSpan("test", tracing.KindNone, tracing.SpanContext{})
+	span.Finish(nil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"tracing-exporter": "none",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForTracing(c, false)
+
+	// The span was written to the trace file when the file exporter
+	// was closed.
+	data, err := ioutil.ReadFile(filepath.Join(s.agentConfig.LogDir(), "trace.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.Contains, `"name":"test"`)
+}
+
+func (s *WorkerStateSuite) TestStopDisablesTracing(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"tracing-exporter": "file",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := apiserver.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForTracing(c, true)
+
+	workertest.CleanKill(c, w)
+	c.Assert(s.tracer.StartSpan("test", tracing.KindNone, tracing.SpanContext{}), gc.IsNil)
+}
+
+// waitForTracing waits for the tracer to be enabled or disabled.
+func (s *WorkerStateSuite) waitForTracing(c *gc.C, enabled bool) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		span := s.tracer.StartSpan("probe", tracing.KindNone, tracing.SpanContext{})
+		if (span != nil) == enabled {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for tracing enabled to be %v", enabled)
+}
+
+// waitForAuditConfigUpdate waits for the nth call to
+// UpdateAuditLogConfig and returns it.
+func (s *WorkerStateSuite) waitForAuditConfigUpdate(c *gc.C, n int) testing.StubCall {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		var calls []testing.StubCall
+		for _, call := range s.stub.Calls() {
+			if call.FuncName == "UpdateAuditLogConfig" {
+				calls = append(calls, call)
+			}
+		}
+		if len(calls) >= n {
+			return calls[n-1]
+		}
+	}
+	c.Fatalf("timed out waiting for audit log config update %d", n)
+	panic("unreachable")
+}
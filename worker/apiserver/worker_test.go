@@ -17,6 +17,8 @@ import (
 	"github.com/juju/juju/agent"
 	coreapiserver "github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/apiserver"
 	"github.com/juju/juju/worker/workertest"
@@ -28,6 +30,7 @@ type workerFixture struct {
 	clock                *testing.Clock
 	hub                  pubsub.StructuredHub
 	prometheusRegisterer stubPrometheusRegisterer
+	tracer               *tracing.Tracer
 	certWatcher          stubCertWatcher
 	config               apiserver.Config
 	stub                 testing.Stub
@@ -45,6 +48,7 @@ func (s *workerFixture) SetUpTest(c *gc.C) {
 	}
 	s.clock = testing.NewClock(time.Time{})
 	s.prometheusRegisterer = stubPrometheusRegisterer{}
+	s.tracer = tracing.NewTracer("machine-0", s.clock)
 	s.certWatcher = stubCertWatcher{}
 	s.stub.ResetCalls()
 
@@ -54,6 +58,7 @@ func (s *workerFixture) SetUpTest(c *gc.C) {
 		Hub:                               &s.hub,
 		StatePool:                         &state.StatePool{},
 		PrometheusRegisterer:              &s.prometheusRegisterer,
+		Tracer:                            s.tracer,
 		RegisterIntrospectionHTTPHandlers: func(func(string, http.Handler)) {},
 		UpgradeComplete:                   func() bool { return true },
 		RestoreStatus:                     func() state.RestoreStatus { return "" },
@@ -66,16 +71,29 @@ func (s *workerFixture) newServer(
 	statePool *state.StatePool,
 	listener net.Listener,
 	config coreapiserver.ServerConfig,
-) (worker.Worker, error) {
+) (apiserver.Server, error) {
 	s.stub.MethodCall(s, "NewServer", statePool, listener, config)
 	if err := s.stub.NextErr(); err != nil {
 		return nil, err
 	}
-	w := worker.NewRunner(worker.RunnerParams{})
+	w := &mockServer{
+		Runner: worker.NewRunner(worker.RunnerParams{}),
+		stub:   &s.stub,
+	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
 	return w, nil
 }
 
+type mockServer struct {
+	*worker.Runner
+	stub *testing.Stub
+}
+
+func (s *mockServer) UpdateAuditLogConfig(config coreapiserver.AuditLogConfig, log auditlog.AuditLog) error {
+	s.stub.MethodCall(s, "UpdateAuditLogConfig", config, log)
+	return s.stub.NextErr()
+}
+
 type WorkerValidationSuite struct {
 	workerFixture
 }
@@ -102,6 +120,9 @@ func (s *WorkerValidationSuite) TestValidateErrors(c *gc.C) {
 	}, {
 		func(cfg *apiserver.Config) { cfg.PrometheusRegisterer = nil },
 		"nil PrometheusRegisterer not valid",
+	}, {
+		func(cfg *apiserver.Config) { cfg.Tracer = nil },
+		"nil Tracer not valid",
 	}, {
 		func(cfg *apiserver.Config) { cfg.RegisterIntrospectionHTTPHandlers = nil },
 		"nil RegisterIntrospectionHTTPHandlers not valid",
@@ -9,6 +9,8 @@ import (
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -18,6 +20,8 @@ var logger = loggo.GetLogger("juju.worker.caas")
 // that allows clients to be informed of changes to the configuration.
 type ConfigObserver interface {
 	CloudSpec() (environs.CloudSpec, error)
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
 }
 
 // Config describes the dependencies of a Tracker.
@@ -61,7 +65,14 @@ func NewTracker(config Config) (*Tracker, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get cloud information")
 	}
-	broker, err := config.NewContainerBrokerFunc(cloudSpec)
+	modelConfig, err := config.Observer.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get model config")
+	}
+	broker, err := config.NewContainerBrokerFunc(environs.OpenParams{
+		Cloud:  cloudSpec,
+		Config: modelConfig,
+	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create caas broker")
 	}
@@ -87,12 +98,31 @@ func (t *Tracker) Broker() caas.Broker {
 }
 
 func (t *Tracker) loop() error {
-	// TODO(caas) - watch for config and credential changes
+	// TODO(caas) - watch for credential changes
+	configWatcher, err := t.config.Observer.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model config")
+	}
+	if err := t.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
 	for {
-		logger.Debugf("waiting for config and credential notifications")
+		logger.Debugf("waiting for config notifications")
 		select {
 		case <-t.catacomb.Dying():
 			return t.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watch closed")
+			}
+		}
+		logger.Debugf("reloading model config")
+		modelConfig, err := t.config.Observer.ModelConfig()
+		if err != nil {
+			return errors.Annotate(err, "cannot read model config")
+		}
+		if err = t.broker.SetConfig(modelConfig); err != nil {
+			return errors.Annotate(err, "cannot update caas broker config")
 		}
 	}
 }
@@ -4,6 +4,8 @@
 package caasbroker_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -86,17 +88,157 @@ func (s *TrackerSuite) TestCloudSpec(c *gc.C) {
 		Type:   "bar",
 		Region: "baz",
 	}
-	fix := &fixture{cloud: cloudSpec}
+	fix := &fixture{
+		cloud:         cloudSpec,
+		initialConfig: coretesting.Attrs{"name": "gitlab"},
+	}
 	fix.Run(c, func(context *runContext) {
 		tracker, err := caasbroker.NewTracker(caasbroker.Config{
 			Observer: context,
-			NewContainerBrokerFunc: func(spec environs.CloudSpec) (caas.Broker, error) {
-				c.Assert(spec, jc.DeepEquals, cloudSpec)
+			NewContainerBrokerFunc: func(args environs.OpenParams) (caas.Broker, error) {
+				c.Assert(args.Cloud, jc.DeepEquals, cloudSpec)
+				c.Assert(args.Config.Name(), gc.Equals, "gitlab")
 				return nil, errors.NotValidf("cloud spec")
 			},
 		})
 		c.Check(err, gc.ErrorMatches, `cannot create caas broker: cloud spec not valid`)
 		c.Check(tracker, gc.IsNil)
-		context.CheckCallNames(c, "CloudSpec")
+		context.CheckCallNames(c, "CloudSpec", "ModelConfig")
+	})
+}
+
+func (s *TrackerSuite) TestModelConfigFails(c *gc.C) {
+	fix := &fixture{
+		observerErrs: []error{
+			nil, errors.New("no config"),
+		},
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			Observer:               context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Check(err, gc.ErrorMatches, "cannot get model config: no config")
+		c.Check(tracker, gc.IsNil)
+		context.CheckCallNames(c, "CloudSpec", "ModelConfig")
+	})
+}
+
+func (s *TrackerSuite) TestWatchFails(c *gc.C) {
+	fix := &fixture{
+		observerErrs: []error{
+			nil, nil, errors.New("grrk splat"),
+		},
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			Observer:               context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot watch model config: grrk splat")
+		context.CheckCallNames(c, "CloudSpec", "ModelConfig", "WatchForModelConfigChanges")
+	})
+}
+
+func (s *TrackerSuite) TestWatchCloses(c *gc.C) {
+	fix := &fixture{}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			Observer:               context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.CloseModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "model config watch closed")
+		context.CheckCallNames(c, "CloudSpec", "ModelConfig", "WatchForModelConfigChanges")
+	})
+}
+
+func (s *TrackerSuite) TestWatchedModelConfigFails(c *gc.C) {
+	fix := &fixture{
+		observerErrs: []error{
+			nil, nil, nil, errors.New("blam ouch"),
+		},
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			Observer:               context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.SendModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot read model config: blam ouch")
+		context.CheckCallNames(c, "CloudSpec", "ModelConfig", "WatchForModelConfigChanges", "ModelConfig")
+	})
+}
+
+func (s *TrackerSuite) TestWatchedModelConfigIncompatible(c *gc.C) {
+	fix := &fixture{}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			Observer: context,
+			NewContainerBrokerFunc: func(environs.OpenParams) (caas.Broker, error) {
+				broker := &mockBroker{}
+				broker.SetErrors(errors.New("SetConfig is broken"))
+				return broker, nil
+			},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.SendModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot update caas broker config: SetConfig is broken")
+		context.CheckCallNames(c, "CloudSpec", "ModelConfig", "WatchForModelConfigChanges", "ModelConfig")
+	})
+}
+
+func (s *TrackerSuite) TestWatchedModelConfigUpdates(c *gc.C) {
+	fix := &fixture{
+		initialConfig: coretesting.Attrs{
+			"kubernetes-namespace-max-pods": 10,
+		},
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			Observer:               context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, tracker)
+
+		context.SetConfig(coretesting.Attrs{
+			"kubernetes-namespace-max-pods": 20,
+		})
+		broker := tracker.Broker().(*mockBroker)
+		c.Assert(broker.Config().UnknownAttrs()["kubernetes-namespace-max-pods"], gc.Equals, 10)
+
+		timeout := time.After(coretesting.LongWait)
+		attempt := time.After(0)
+		context.SendModelConfigNotify()
+		for {
+			select {
+			case <-attempt:
+				maxPods := broker.Config().UnknownAttrs()["kubernetes-namespace-max-pods"]
+				if maxPods == 10 {
+					attempt = time.After(coretesting.ShortWait)
+					continue
+				}
+				c.Check(maxPods, gc.Equals, 20)
+			case <-timeout:
+				c.Fatalf("timed out waiting for broker to be updated")
+			}
+			break
+		}
 	})
 }
@@ -8,30 +8,48 @@ import (
 
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/workertest"
 )
 
 type fixture struct {
-	watcherErr   error
-	observerErrs []error
-	cloud        environs.CloudSpec
+	watcherErr    error
+	observerErrs  []error
+	cloud         environs.CloudSpec
+	initialConfig map[string]interface{}
 }
 
 func (fix *fixture) Run(c *gc.C, test func(*runContext)) {
+	watcher := newNotifyWatcher(fix.watcherErr)
+	defer workertest.DirtyKill(c, watcher)
 	context := &runContext{
-		cloud: fix.cloud,
+		cloud:   fix.cloud,
+		config:  coretesting.FakeConfig().Merge(fix.initialConfig),
+		watcher: watcher,
 	}
 	context.stub.SetErrors(fix.observerErrs...)
 	test(context)
 }
 
 type runContext struct {
-	mu     sync.Mutex
-	stub   testing.Stub
-	cloud  environs.CloudSpec
-	config map[string]interface{}
+	mu      sync.Mutex
+	stub    testing.Stub
+	cloud   environs.CloudSpec
+	config  map[string]interface{}
+	watcher *notifyWatcher
+}
+
+// SetConfig updates the configuration returned by ModelConfig.
+func (context *runContext) SetConfig(extraAttrs coretesting.Attrs) {
+	context.mu.Lock()
+	defer context.mu.Unlock()
+	context.config = coretesting.FakeConfig().Merge(extraAttrs)
 }
 
 func (context *runContext) CloudSpec() (environs.CloudSpec, error) {
@@ -44,6 +62,38 @@ func (context *runContext) CloudSpec() (environs.CloudSpec, error) {
 	return context.cloud, nil
 }
 
+func (context *runContext) ModelConfig() (*config.Config, error) {
+	context.mu.Lock()
+	defer context.mu.Unlock()
+	context.stub.AddCall("ModelConfig")
+	if err := context.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.NoDefaults, context.config)
+}
+
+// SendModelConfigNotify sends a value on the channel used by
+// WatchForModelConfigChanges results.
+func (context *runContext) SendModelConfigNotify() {
+	context.watcher.changes <- struct{}{}
+}
+
+// CloseModelConfigNotify closes the channel used by
+// WatchForModelConfigChanges results.
+func (context *runContext) CloseModelConfigNotify() {
+	close(context.watcher.changes)
+}
+
+func (context *runContext) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	context.mu.Lock()
+	defer context.mu.Unlock()
+	context.stub.AddCall("WatchForModelConfigChanges")
+	if err := context.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return context.watcher, nil
+}
+
 func (context *runContext) CheckCallNames(c *gc.C, names ...string) {
 	context.mu.Lock()
 	defer context.mu.Unlock()
@@ -54,9 +104,46 @@ type mockBroker struct {
 	caas.Broker
 	testing.Stub
 	spec environs.CloudSpec
+	cfg  *config.Config
 	mu   sync.Mutex
 }
 
-func newMockBroker(spec environs.CloudSpec) (caas.Broker, error) {
-	return &mockBroker{spec: spec}, nil
+func newMockBroker(args environs.OpenParams) (caas.Broker, error) {
+	return &mockBroker{spec: args.Cloud, cfg: args.Config}, nil
+}
+
+func (b *mockBroker) Config() *config.Config {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg
+}
+
+func (b *mockBroker) SetConfig(cfg *config.Config) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.MethodCall(b, "SetConfig", cfg)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	b.cfg = cfg
+	return nil
+}
+
+// newNotifyWatcher returns a watcher.NotifyWatcher that will fail with the
+// supplied error when Kill()ed.
+func newNotifyWatcher(err error) *notifyWatcher {
+	return &notifyWatcher{
+		Worker:  workertest.NewErrorWorker(err),
+		changes: make(chan struct{}, 1000),
+	}
+}
+
+type notifyWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *notifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
 }
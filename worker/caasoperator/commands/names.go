@@ -25,6 +25,7 @@ var baseCommands = map[string]creator{
 	"relation-set":            nil,
 	"relation-get":            nil,
 	"container-spec-set":      NewContainerspecSetCommand,
+	"pod-spec-set":            NewPodSpecSetCommand,
 }
 
 func allEnabledCommands() map[string]creator {
@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/caas"
+)
+
+// PodSpecSetCommand implements the pod-spec-set command.
+type PodSpecSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	specFile cmd.FileVar
+}
+
+// NewPodSpecSetCommand makes a pod-spec-set command.
+func NewPodSpecSetCommand(ctx Context) (cmd.Command, error) {
+	return &PodSpecSetCommand{ctx: ctx}, nil
+}
+
+func (c *PodSpecSetCommand) Info() *cmd.Info {
+	doc := `
+Sets the spec of the pods run for the application's units.
+The spec is validated before it is set. A spec describes
+one or more containers, along with any init containers
+which are run before them. For each container, the spec
+may define ports, environment config (including config
+taken from secrets), files to mount, liveness and
+readiness probes, and compute resource requests and limits.
+`
+	return &cmd.Info{
+		Name:    "pod-spec-set",
+		Args:    "--file <pod spec file>",
+		Purpose: "set pod spec information",
+		Doc:     doc,
+	}
+}
+
+func (c *PodSpecSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.specFile.SetStdin()
+	c.specFile.Path = "-"
+	f.Var(&c.specFile, "file", "file containing pod spec")
+}
+
+func (c *PodSpecSetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *PodSpecSetCommand) Run(ctx *cmd.Context) error {
+	specData, err := c.specFile.Read(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(specData) == 0 {
+		return errors.New("no pod spec specified: pipe pod spec to command, or specify a file with --file")
+	}
+	if _, err := caas.ParsePodSpec(string(specData)); err != nil {
+		return errors.Annotate(err, "invalid pod spec")
+	}
+	return c.ctx.SetContainerSpec("", string(specData))
+}
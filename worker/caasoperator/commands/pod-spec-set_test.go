@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/caasoperator/commands"
+)
+
+type PodSpecSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&PodSpecSetSuite{})
+
+var podSpecYaml = `
+version: 1
+containers:
+- name: gitlab
+  image-name: gitlab/latest
+  liveness-probe:
+    tcp-socket:
+      port: 80
+`[1:]
+
+func (s *PodSpecSetSuite) TestInit(c *gc.C) {
+	hctx := s.newHookContext(c)
+	com, err := commands.NewCommand(hctx, "pod-spec-set")
+	c.Assert(err, jc.ErrorIsNil)
+	cmdtesting.TestInit(c, com, []string{"--file", "file", "extra"}, `unrecognized args: \["extra"\]`)
+}
+
+func (s *PodSpecSetSuite) TestPodSpecSetNoData(c *gc.C) {
+	hctx := s.newHookContext(c)
+	com, err := commands.NewCommand(hctx, "pod-spec-set")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Matches,
+		".*no pod spec specified: pipe pod spec to command, or specify a file with --file\n")
+	c.Assert(hctx.containerSpec, gc.Equals, "")
+}
+
+func (s *PodSpecSetSuite) TestPodSpecSetInvalid(c *gc.C) {
+	hctx := s.newHookContext(c)
+	com, err := commands.NewCommand(hctx, "pod-spec-set")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = bytes.NewBufferString("version: 1\ncontainers: []\n")
+
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR invalid pod spec: spec has no containers\n")
+	c.Assert(hctx.containerSpec, gc.Equals, "")
+}
+
+func (s *PodSpecSetSuite) TestPodSpecSet(c *gc.C) {
+	s.assertPodSpecSet(c, "specfile.yaml")
+}
+
+func (s *PodSpecSetSuite) TestPodSpecSetStdIn(c *gc.C) {
+	s.assertPodSpecSet(c, "-")
+}
+
+func (s *PodSpecSetSuite) assertPodSpecSet(c *gc.C, filename string) {
+	hctx := s.newHookContext(c)
+	com, err := commands.NewCommand(hctx, "pod-spec-set")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+
+	var args []string
+	if filename == "-" {
+		ctx.Stdin = bytes.NewBufferString(podSpecYaml)
+	} else {
+		filename = filepath.Join(c.MkDir(), filename)
+		args = append(args, "--file", filename)
+		err := ioutil.WriteFile(filename, []byte(podSpecYaml), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	code := cmd.Main(com, ctx, args)
+	c.Check(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Assert(hctx.containerSpec, gc.Equals, podSpecYaml)
+	c.Assert(hctx.containerSpecUnit, gc.Equals, "")
+}
@@ -143,7 +143,7 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	}
 	ps.Stdout = outWriter
 	ps.Stderr = outWriter
-	hookLogger := charmrunner.NewHookLogger(runner.getLogger(hookName), outReader)
+	hookLogger := charmrunner.NewHookLogger(runner.getLogger(hookName), outReader, nil)
 	go hookLogger.Run()
 	err = ps.Start()
 	outWriter.Close()
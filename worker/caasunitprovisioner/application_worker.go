@@ -5,12 +5,12 @@ package caasunitprovisioner
 
 import (
 	"github.com/juju/errors"
-	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -26,8 +26,6 @@ type applicationWorker struct {
 	applicationGetter   ApplicationGetter
 	unitGetter          UnitGetter
 	unitUpdater         UnitUpdater
-
-	aliveUnitsChan chan []string
 }
 
 func newApplicationWorker(
@@ -51,7 +49,6 @@ func newApplicationWorker(
 		applicationGetter:   applicationGetter,
 		unitGetter:          unitGetter,
 		unitUpdater:         unitUpdater,
-		aliveUnitsChan:      make(chan []string),
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -73,12 +70,6 @@ func (w *applicationWorker) Wait() error {
 }
 
 func (aw *applicationWorker) loop() error {
-	jujuUnitsWatcher, err := aw.unitGetter.WatchUnits(aw.application)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	aw.catacomb.Add(jujuUnitsWatcher)
-
 	brokerUnitsWatcher, err := aw.containerBroker.WatchUnits(aw.application)
 	if err != nil {
 		return errors.Annotatef(err, "failed to start unit watcher for %q", aw.application)
@@ -87,38 +78,47 @@ func (aw *applicationWorker) loop() error {
 		return errors.Trace(err)
 	}
 
-	var deploymentWorker worker.Worker
+	// For broker managed units, the deployment worker tells the
+	// broker how many pods to run; the units in the Juju model are
+	// then updated to match those reported by the broker. Otherwise,
+	// we start a worker to manage each unit in the Juju model.
+	var jujuUnitsChanges watcher.StringsChannel
 	if aw.brokerManagedUnits {
-		deploymentWorker, err = newDeploymentWorker(
+		deploymentWorker, err := newDeploymentWorker(
 			aw.application,
 			aw.serviceBroker,
 			aw.containerSpecGetter,
 			aw.applicationGetter,
-			aw.aliveUnitsChan)
+		)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := aw.catacomb.Add(deploymentWorker); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		jujuUnitsWatcher, err := aw.unitGetter.WatchUnits(aw.application)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		aw.catacomb.Add(deploymentWorker)
+		if err := aw.catacomb.Add(jujuUnitsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		jujuUnitsChanges = jujuUnitsWatcher.Changes()
 	}
 	unitWorkers := make(map[string]worker.Worker)
-	aliveUnits := make(set.Strings)
-	var aliveUnitsChan chan []string
 
 	for {
 		select {
 		case <-aw.catacomb.Dying():
 			return aw.catacomb.ErrDying()
-		case units, ok := <-jujuUnitsWatcher.Changes():
+		case units, ok := <-jujuUnitsChanges:
 			if !ok {
 				return errors.New("watcher closed channel")
 			}
-			if aw.brokerManagedUnits {
-				aliveUnitsChan = aw.aliveUnitsChan
-			}
 			for _, unitId := range units {
 				unitLife, err := aw.lifeGetter.Life(unitId)
 				if errors.IsNotFound(err) {
-					aliveUnits.Remove(unitId)
 					w, ok := unitWorkers[unitId]
 					if ok {
 						if err := worker.Stop(w); err != nil {
@@ -131,29 +131,18 @@ func (aw *applicationWorker) loop() error {
 				if err != nil {
 					return errors.Trace(err)
 				}
-
-				if unitLife == life.Dead {
-					aliveUnits.Remove(unitId)
-				} else {
-					aliveUnits.Add(unitId)
+				if _, ok := unitWorkers[unitId]; ok || unitLife == life.Dead {
+					// Already watching the unit. or we're
+					// not yet watching it and it's dead.
+					continue
 				}
-				if !aw.brokerManagedUnits {
-					// For Juju managed units, we start a worker to manage the unit.
-					if _, ok := unitWorkers[unitId]; ok || unitLife == life.Dead {
-						// Already watching the unit. or we're
-						// not yet watching it and it's dead.
-						continue
-					}
-					w, err := newUnitWorker(aw.application, unitId, aw.containerBroker, aw.containerSpecGetter)
-					if err != nil {
-						return errors.Trace(err)
-					}
-					unitWorkers[unitId] = w
-					aw.catacomb.Add(w)
+				w, err := newUnitWorker(aw.application, unitId, aw.containerBroker, aw.containerSpecGetter)
+				if err != nil {
+					return errors.Trace(err)
 				}
+				unitWorkers[unitId] = w
+				aw.catacomb.Add(w)
 			}
-		case aliveUnitsChan <- aliveUnits.Values():
-			aliveUnitsChan = nil
 		case _, ok := <-brokerUnitsWatcher.Changes():
 			logger.Debugf("units changed: %#v", ok)
 			if !ok {
@@ -177,6 +166,17 @@ func (aw *applicationWorker) loop() error {
 					Info:    u.Status.Message,
 					Data:    u.Status.Data,
 				}
+				for _, fs := range u.FilesystemInfo {
+					args.Units[i].FilesystemInfo = append(args.Units[i].FilesystemInfo, params.KubernetesFilesystemInfo{
+						StorageName:  fs.StorageName,
+						FilesystemId: fs.FilesystemId,
+						Size:         fs.Size,
+						MountPoint:   fs.MountPoint,
+						ReadOnly:     fs.ReadOnly,
+						Status:       fs.Status.Status.String(),
+						Info:         fs.Status.Message,
+					})
+				}
 			}
 			if err := aw.unitUpdater.UpdateUnits(args); err != nil {
 				return errors.Trace(err)
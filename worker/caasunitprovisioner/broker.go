@@ -10,12 +10,12 @@ import (
 )
 
 type ContainerBroker interface {
-	EnsureUnit(appName, unitName string, spec *caas.ContainerSpec) error
+	EnsureUnit(appName, unitName string, spec *caas.PodSpec) error
 	WatchUnits(appName string) (watcher.NotifyWatcher, error)
 	Units(appName string) ([]caas.Unit, error)
 }
 
 type ServiceBroker interface {
-	EnsureService(appName string, unitSpec *caas.ContainerSpec, numUnits int, config application.ConfigAttributes) error
+	EnsureService(appName string, params *caas.ServiceParams, numUnits int, config application.ConfigAttributes) error
 	DeleteService(appName string) error
 }
@@ -4,6 +4,7 @@
 package caasunitprovisioner
 
 import (
+	"github.com/juju/juju/api/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
@@ -28,11 +29,14 @@ type Client interface {
 type ApplicationGetter interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	ProvisioningInfo(string) (*caasunitprovisioner.ProvisioningInfo, error)
+	WatchApplicationScale(string) (watcher.NotifyWatcher, error)
+	ApplicationScale(string) (int, error)
 }
 
 // ContainerSpecGetter provides an interface for
-// watching and getting the container spec for a
-// unit.
+// watching and getting the container spec for an
+// application or unit.
 type ContainerSpecGetter interface {
 	ContainerSpec(entityName string) (string, error)
 	WatchContainerSpec(entityName string) (watcher.NotifyWatcher, error)
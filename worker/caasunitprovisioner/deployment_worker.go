@@ -20,8 +20,6 @@ type deploymentWorker struct {
 	broker              ServiceBroker
 	applicationGetter   ApplicationGetter
 	containerSpecGetter ContainerSpecGetter
-
-	aliveUnitsChan <-chan []string
 }
 
 func newDeploymentWorker(
@@ -29,14 +27,12 @@ func newDeploymentWorker(
 	broker ServiceBroker,
 	containerSpecGetter ContainerSpecGetter,
 	applicationGetter ApplicationGetter,
-	aliveUnitsChan <-chan []string,
 ) (worker.Worker, error) {
 	w := &deploymentWorker{
 		application:         application,
 		broker:              broker,
 		containerSpecGetter: containerSpecGetter,
 		applicationGetter:   applicationGetter,
-		aliveUnitsChan:      aliveUnitsChan,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -58,14 +54,21 @@ func (w *deploymentWorker) Wait() error {
 }
 
 func (w *deploymentWorker) loop() error {
+	scaleWatcher, err := w.applicationGetter.WatchApplicationScale(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(scaleWatcher); err != nil {
+		return errors.Trace(err)
+	}
 
 	var (
-		aliveUnits []string
-		cw         watcher.NotifyWatcher
-		specChan   watcher.NotifyChannel
+		scale    int
+		cw       watcher.NotifyWatcher
+		specChan watcher.NotifyChannel
 
-		currentAliveCount int
-		currentSpec       string
+		currentScale int
+		currentSpec  string
 	)
 
 	gotSpecNotify := false
@@ -73,14 +76,26 @@ func (w *deploymentWorker) loop() error {
 		select {
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
-		case aliveUnits = <-w.aliveUnitsChan:
-			if len(aliveUnits) > 0 && specChan == nil {
-				var err error
-				cw, err = w.containerSpecGetter.WatchContainerSpec(aliveUnits[0])
+		case _, ok := <-scaleWatcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			scale, err = w.applicationGetter.ApplicationScale(w.application)
+			if errors.IsNotFound(err) {
+				// The application has been removed,
+				// so it should have no pods.
+				scale = 0
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if scale > 0 && specChan == nil {
+				cw, err = w.containerSpecGetter.WatchContainerSpec(w.application)
 				if err != nil {
 					return errors.Trace(err)
 				}
-				w.catacomb.Add(cw)
+				if err := w.catacomb.Add(cw); err != nil {
+					return errors.Trace(err)
+				}
 				specChan = cw.Changes()
 			}
 		case _, ok := <-specChan:
@@ -89,51 +104,60 @@ func (w *deploymentWorker) loop() error {
 			}
 			gotSpecNotify = true
 		}
-		if len(aliveUnits) == 0 {
+		if scale == 0 {
 			if cw != nil {
 				worker.Stop(cw)
+				cw = nil
 				specChan = nil
+				gotSpecNotify = false
 			}
 			if err := w.broker.DeleteService(w.application); err != nil {
 				return errors.Trace(err)
 			}
+			currentScale = 0
+			currentSpec = ""
 			continue
 		}
 
-		// TODO(caas) - for now, we assume all units are homogeneous
-		// so we just need to get the first spec and use that one.
 		if !gotSpecNotify {
 			continue
 		}
-		specStr, err := w.containerSpecGetter.ContainerSpec(aliveUnits[0])
+		specStr, err := w.containerSpecGetter.ContainerSpec(w.application)
 		if errors.IsNotFound(err) {
-			// No container spec defined for a unit yet;
-			// wait for one to be set.
+			// No container spec defined for the application
+			// yet; wait for one to be set.
 			continue
 		} else if err != nil {
 			return errors.Trace(err)
 		}
 
-		numUnits := len(aliveUnits)
-		if numUnits == currentAliveCount && specStr == currentSpec {
+		if scale == currentScale && specStr == currentSpec {
 			continue
 		}
 
-		currentAliveCount = numUnits
-		currentSpec = specStr
-
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		spec, err := caas.ParseContainerSpec(specStr)
+		spec, err := caas.ParsePodSpec(specStr)
 		if err != nil {
 			return errors.Annotate(err, "cannot parse container spec")
 		}
-		err = w.broker.EnsureService(w.application, spec, numUnits, appConfig)
+		info, err := w.applicationGetter.ProvisioningInfo(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		serviceParams := &caas.ServiceParams{
+			PodSpec:     spec,
+			Filesystems: info.Filesystems,
+			Constraints: info.Constraints,
+		}
+		err = w.broker.EnsureService(w.application, serviceParams, scale, appConfig)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		logger.Debugf("created/updated deployment for %s for %d units", w.application, numUnits)
+		currentScale = scale
+		currentSpec = specStr
+		logger.Debugf("created/updated deployment for %s for %d units", w.application, scale)
 	}
 }
@@ -10,11 +10,14 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base"
+	apicaasunitprovisioner "github.com/juju/juju/api/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/watcher/watchertest"
@@ -38,8 +41,8 @@ type mockServiceBroker struct {
 	ensured chan<- struct{}
 }
 
-func (m *mockServiceBroker) EnsureService(appName string, unitSpec *caas.ContainerSpec, numUnits int, config application.ConfigAttributes) error {
-	m.MethodCall(m, "EnsureService", appName, unitSpec, numUnits, config)
+func (m *mockServiceBroker) EnsureService(appName string, params *caas.ServiceParams, numUnits int, config application.ConfigAttributes) error {
+	m.MethodCall(m, "EnsureService", appName, params, numUnits, config)
 	m.ensured <- struct{}{}
 	return m.NextErr()
 }
@@ -55,7 +58,7 @@ type mockContainerBroker struct {
 	unitsWatcher *watchertest.MockNotifyWatcher
 }
 
-func (m *mockContainerBroker) EnsureUnit(appName, unitName string, spec *caas.ContainerSpec) error {
+func (m *mockContainerBroker) EnsureUnit(appName, unitName string, spec *caas.PodSpec) error {
 	m.MethodCall(m, "EnsureUnit", appName, unitName, spec)
 	m.ensured <- struct{}{}
 	return m.NextErr()
@@ -73,13 +76,22 @@ func (m *mockContainerBroker) Units(appName string) ([]caas.Unit, error) {
 			Id:      "u1",
 			Address: "10.0.0.1",
 			Status:  status.StatusInfo{Status: status.Allocating},
+			FilesystemInfo: []caas.FilesystemInfo{{
+				StorageName:  "database",
+				FilesystemId: "juju-database-0",
+				Size:         100,
+				MountPoint:   "/path-to-here",
+				Status:       status.StatusInfo{Status: status.Attached},
+			}},
 		},
 	}, m.NextErr()
 }
 
 type mockApplicationGetter struct {
 	testing.Stub
-	watcher *watchertest.MockStringsWatcher
+	watcher      *watchertest.MockStringsWatcher
+	scaleWatcher *watchertest.MockNotifyWatcher
+	scale        int
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -95,6 +107,40 @@ func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.C
 	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
 }
 
+func (a *mockApplicationGetter) ProvisioningInfo(appName string) (*apicaasunitprovisioner.ProvisioningInfo, error) {
+	a.MethodCall(a, "ProvisioningInfo", appName)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return &apicaasunitprovisioner.ProvisioningInfo{
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "/path-to-here",
+			},
+		}},
+		Constraints: constraints.MustParse("mem=4G"),
+	}, nil
+}
+
+func (a *mockApplicationGetter) WatchApplicationScale(appName string) (watcher.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchApplicationScale", appName)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.scaleWatcher, nil
+}
+
+func (a *mockApplicationGetter) ApplicationScale(appName string) (int, error) {
+	a.MethodCall(a, "ApplicationScale", appName)
+	if err := a.NextErr(); err != nil {
+		return 0, err
+	}
+	return a.scale, nil
+}
+
 type mockContainerSpecGetter struct {
 	testing.Stub
 	spec          string
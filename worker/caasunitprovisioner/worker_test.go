@@ -15,8 +15,10 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher/watchertest"
 	"github.com/juju/juju/worker/caasunitprovisioner"
@@ -39,6 +41,7 @@ type WorkerSuite struct {
 	jujuUnitChanges      chan []string
 	caasUnitsChanges     chan struct{}
 	containerSpecChanges chan struct{}
+	scaleChanges         chan struct{}
 	serviceEnsured       chan struct{}
 	unitEnsured          chan struct{}
 	clock                *testing.Clock
@@ -59,18 +62,32 @@ config:
   foo: bar
 `[1:]
 
-	parsedSpec = caas.ContainerSpec{
-		Name:      "gitlab",
-		ImageName: "gitlab/latest",
-		Ports: []caas.ContainerPort{
-			{ContainerPort: 80, Protocol: "TCP"},
-			{ContainerPort: 443},
-		},
-		Config: map[string]string{
-			"attr": "foo=bar; fred=blogs",
-			"foo":  "bar",
-		},
+	parsedSpec = caas.PodSpec{
+		Version: caas.CurrentPodSpecVersion,
+		Containers: []caas.ContainerSpec{{
+			Name:      "gitlab",
+			ImageName: "gitlab/latest",
+			Ports: []caas.ContainerPort{
+				{ContainerPort: 80, Protocol: "TCP"},
+				{ContainerPort: 443},
+			},
+			Config: map[string]string{
+				"attr": "foo=bar; fred=blogs",
+				"foo":  "bar",
+			},
+		}},
 	}
+
+	filesystems = []storage.KubernetesFilesystemParams{{
+		StorageName: "database",
+		Size:        100,
+		Provider:    "kubernetes",
+		Attachment: &storage.KubernetesFilesystemAttachmentParams{
+			Path: "/path-to-here",
+		},
+	}}
+
+	cons = constraints.MustParse("mem=4G")
 )
 
 func (s *WorkerSuite) SetUpTest(c *gc.C) {
@@ -80,13 +97,16 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.jujuUnitChanges = make(chan []string)
 	s.caasUnitsChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{})
+	s.scaleChanges = make(chan struct{})
 	s.serviceEnsured = make(chan struct{})
 	s.unitEnsured = make(chan struct{})
 
 	s.applicationGetter = mockApplicationGetter{
-		watcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
+		watcher:      watchertest.NewMockStringsWatcher(s.applicationChanges),
+		scaleWatcher: watchertest.NewMockNotifyWatcher(s.scaleChanges),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.watcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.scaleWatcher) })
 
 	s.containerSpecGetter = mockContainerSpecGetter{
 		watcher: watchertest.NewMockNotifyWatcher(s.containerSpecChanges),
@@ -129,6 +149,15 @@ func (s *WorkerSuite) sendContainerSpecChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) sendScaleChange(c *gc.C, scale int) {
+	s.applicationGetter.scale = scale
+	select {
+	case s.scaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+}
+
 func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 	s.testValidateConfig(c, func(config *caasunitprovisioner.Config) {
 		config.ApplicationGetter = nil
@@ -185,10 +214,14 @@ func (s *WorkerSuite) setupNewUnitScenario(c *gc.C, brokerManaged bool, opChan c
 		c.Fatal("timed out sending applications change")
 	}
 
-	select {
-	case s.jujuUnitChanges <- []string{"gitlab/0"}:
-	case <-time.After(coretesting.LongWait):
-		c.Fatal("timed out sending units change")
+	if brokerManaged {
+		s.sendScaleChange(c, 1)
+	} else {
+		select {
+		case s.jujuUnitChanges <- []string{"gitlab/0"}:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out sending units change")
+		}
 	}
 
 	// We seed a "not found" error above to indicate that
@@ -233,26 +266,24 @@ func (s *WorkerSuite) TestNewBrokerManagedUnit(c *gc.C) {
 	w := s.setupNewUnitScenario(c, true, s.serviceEnsured)
 	defer workertest.CleanKill(c, w)
 
-	s.applicationGetter.CheckCallNames(c, "WatchApplications", "ApplicationConfig")
+	s.applicationGetter.CheckCallNames(c,
+		"WatchApplications", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig", "ProvisioningInfo")
+	s.applicationGetter.CheckCall(c, 1, "WatchApplicationScale", "gitlab")
+	s.applicationGetter.CheckCall(c, 2, "ApplicationScale", "gitlab")
 	s.containerSpecGetter.CheckCallNames(c, "WatchContainerSpec", "ContainerSpec", "ContainerSpec")
-	s.containerSpecGetter.CheckCall(c, 0, "WatchContainerSpec", "gitlab/0")
-	s.containerSpecGetter.CheckCall(c, 1, "ContainerSpec", "gitlab/0") // not found
-	s.containerSpecGetter.CheckCall(c, 2, "ContainerSpec", "gitlab/0")
-	s.lifeGetter.CheckCallNames(c, "Life", "Life")
+	s.containerSpecGetter.CheckCall(c, 0, "WatchContainerSpec", "gitlab")
+	s.containerSpecGetter.CheckCall(c, 1, "ContainerSpec", "gitlab") // not found
+	s.containerSpecGetter.CheckCall(c, 2, "ContainerSpec", "gitlab")
+	s.lifeGetter.CheckCallNames(c, "Life")
 	s.lifeGetter.CheckCall(c, 0, "Life", "gitlab")
-	s.lifeGetter.CheckCall(c, 1, "Life", "gitlab/0")
+	s.unitGetter.CheckNoCalls(c)
 	s.serviceBroker.CheckCallNames(c, "EnsureService")
 	s.serviceBroker.CheckCall(c, 0, "EnsureService",
-		"gitlab", &parsedSpec, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+		"gitlab", &caas.ServiceParams{PodSpec: &parsedSpec, Filesystems: filesystems, Constraints: cons}, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 
 	s.serviceBroker.ResetCalls()
-	// Add another unit.
-	select {
-	case s.jujuUnitChanges <- []string{"gitlab/1"}:
-	case <-time.After(coretesting.LongWait):
-		c.Fatal("timed out sending units change")
-	}
-
+	// Scale up.
+	s.sendScaleChange(c, 2)
 	select {
 	case <-s.serviceEnsured:
 	case <-time.After(coretesting.LongWait):
@@ -261,17 +292,11 @@ func (s *WorkerSuite) TestNewBrokerManagedUnit(c *gc.C) {
 
 	s.serviceBroker.CheckCallNames(c, "EnsureService")
 	s.serviceBroker.CheckCall(c, 0, "EnsureService",
-		"gitlab", &parsedSpec, 2, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+		"gitlab", &caas.ServiceParams{PodSpec: &parsedSpec, Filesystems: filesystems, Constraints: cons}, 2, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 
 	s.serviceBroker.ResetCalls()
-	// Delete a unit.
-	s.lifeGetter.setLife(life.Dead)
-	select {
-	case s.jujuUnitChanges <- []string{"gitlab/0"}:
-	case <-time.After(coretesting.LongWait):
-		c.Fatal("timed out sending units change")
-	}
-
+	// Scale down.
+	s.sendScaleChange(c, 1)
 	select {
 	case <-s.serviceEnsured:
 	case <-time.After(coretesting.LongWait):
@@ -280,7 +305,24 @@ func (s *WorkerSuite) TestNewBrokerManagedUnit(c *gc.C) {
 
 	s.serviceBroker.CheckCallNames(c, "EnsureService")
 	s.serviceBroker.CheckCall(c, 0, "EnsureService",
-		"gitlab", &parsedSpec, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+		"gitlab", &caas.ServiceParams{PodSpec: &parsedSpec, Filesystems: filesystems, Constraints: cons}, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
+func (s *WorkerSuite) TestNewBrokerManagedUnitSameScale(c *gc.C) {
+	w := s.setupNewUnitScenario(c, true, s.serviceEnsured)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+
+	// Same scale, nothing happens.
+	s.sendScaleChange(c, 1)
+	s.containerSpecGetter.assertSpecRetrieved(c)
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service/unit ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.serviceBroker.CheckNoCalls(c)
 }
 
 func (s *WorkerSuite) TestNewBrokerManagedUnitSpecChange(c *gc.C) {
@@ -304,9 +346,12 @@ name: gitlab
 image-name: gitlab/latest
 `[1:]
 
-		anotherParsedSpec = caas.ContainerSpec{
-			Name:      "gitlab",
-			ImageName: "gitlab/latest",
+		anotherParsedSpec = caas.PodSpec{
+			Version: caas.CurrentPodSpecVersion,
+			Containers: []caas.ContainerSpec{{
+				Name:      "gitlab",
+				ImageName: "gitlab/latest",
+			}},
 		}
 	)
 
@@ -322,21 +367,16 @@ image-name: gitlab/latest
 
 	s.serviceBroker.CheckCallNames(c, "EnsureService")
 	s.serviceBroker.CheckCall(c, 0, "EnsureService",
-		"gitlab", &anotherParsedSpec, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+		"gitlab", &caas.ServiceParams{PodSpec: &anotherParsedSpec, Filesystems: filesystems, Constraints: cons}, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
-func (s *WorkerSuite) TestNewBrokerManagedUnitAllRemoved(c *gc.C) {
+func (s *WorkerSuite) TestNewBrokerManagedUnitScaledToZero(c *gc.C) {
 	w := s.setupNewUnitScenario(c, true, s.serviceEnsured)
 	defer workertest.CleanKill(c, w)
 
 	s.serviceBroker.ResetCalls()
-	// Add another unit.
-	select {
-	case s.jujuUnitChanges <- []string{"gitlab/1"}:
-	case <-time.After(coretesting.LongWait):
-		c.Fatal("timed out sending units change")
-	}
-
+	// Scale up.
+	s.sendScaleChange(c, 2)
 	select {
 	case <-s.serviceEnsured:
 	case <-time.After(coretesting.LongWait):
@@ -344,13 +384,9 @@ func (s *WorkerSuite) TestNewBrokerManagedUnitAllRemoved(c *gc.C) {
 	}
 	s.serviceBroker.ResetCalls()
 
-	// Now the units die.
-	s.lifeGetter.setLife(life.Dead)
-	select {
-	case s.jujuUnitChanges <- []string{"gitlab/0", "gitlab/1"}:
-	case <-time.After(coretesting.LongWait):
-		c.Fatal("timed out sending units change")
-	}
+	// Now scale to zero.
+	s.sendScaleChange(c, 0)
+	workertest.CheckKilled(c, s.containerSpecGetter.watcher)
 
 	select {
 	case <-s.serviceEnsured:
@@ -358,6 +394,33 @@ func (s *WorkerSuite) TestNewBrokerManagedUnitAllRemoved(c *gc.C) {
 	case <-time.After(coretesting.ShortWait):
 	}
 
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.serviceBroker.Calls()) > 0 {
+			break
+		}
+	}
+	s.serviceBroker.CheckCallNames(c, "DeleteService")
+	s.serviceBroker.CheckCall(c, 0, "DeleteService", "gitlab")
+}
+
+func (s *WorkerSuite) TestNewBrokerManagedUnitApplicationRemoved(c *gc.C) {
+	w := s.setupNewUnitScenario(c, true, s.serviceEnsured)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+	s.applicationGetter.SetErrors(errors.NotFoundf("application"))
+	select {
+	case s.scaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	workertest.CheckKilled(c, s.containerSpecGetter.watcher)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.serviceBroker.Calls()) > 0 {
+			break
+		}
+	}
 	s.serviceBroker.CheckCallNames(c, "DeleteService")
 	s.serviceBroker.CheckCall(c, 0, "DeleteService", "gitlab")
 }
@@ -520,7 +583,15 @@ func (s *WorkerSuite) TestUnitsChange(c *gc.C) {
 		params.UpdateApplicationUnits{
 			ApplicationTag: names.NewApplicationTag("gitlab").String(),
 			Units: []params.ApplicationUnitParams{
-				{Id: "u1", Address: "10.0.0.1", Ports: []string(nil), Status: "allocating"},
+				{Id: "u1", Address: "10.0.0.1", Ports: []string(nil), Status: "allocating",
+					FilesystemInfo: []params.KubernetesFilesystemInfo{{
+						StorageName:  "database",
+						FilesystemId: "juju-database-0",
+						Size:         100,
+						MountPoint:   "/path-to-here",
+						Status:       "attached",
+					}},
+				},
 			},
 		},
 	})
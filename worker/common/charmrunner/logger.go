@@ -10,16 +10,20 @@ import (
 	"time"
 
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/logfields"
 )
 
 var logger = loggo.GetLogger("juju.worker.common.runner")
 
-// NewHookLogger creates a new hook logger.
-func NewHookLogger(logger loggo.Logger, outReader io.ReadCloser) *HookLogger {
+// NewHookLogger creates a new hook logger. The fields, which may be
+// nil, are attached to each line of output logged.
+func NewHookLogger(logger loggo.Logger, outReader io.ReadCloser, fields map[string]string) *HookLogger {
 	return &HookLogger{
 		r:      outReader,
 		done:   make(chan struct{}),
 		logger: logger,
+		fields: logfields.Data{Fields: fields},
 	}
 }
 
@@ -30,6 +34,7 @@ type HookLogger struct {
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+	fields  logfields.Data
 }
 
 // Run starts the hook logger.
@@ -50,7 +55,7 @@ func (l *HookLogger) Run() {
 			l.mu.Unlock()
 			return
 		}
-		l.logger.Debugf("%s", line)
+		logfields.Logf(l.logger, loggo.DEBUG, l.fields, "%s", line)
 		l.mu.Unlock()
 	}
 }
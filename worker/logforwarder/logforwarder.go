@@ -5,6 +5,7 @@ package logforwarder
 
 import (
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/juju/errors"
@@ -40,17 +41,15 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
-	catacomb  catacomb.Catacomb
-	args      OpenLogForwarderArgs
-	enabledCh chan bool
-	mu        sync.Mutex
-	enabled   bool
+	catacomb   catacomb.Catacomb
+	args       OpenLogForwarderArgs
+	enabledCh  chan bool
+	mu         sync.Mutex
+	enabled    bool
+	sinkConfig logfwd.SinkConfig
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -64,6 +63,10 @@ type OpenLogForwarderArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
+	// SinkType is the type of the log sink, which selects the sink's
+	// config from the model's log forward config.
+	SinkType string
+
 	// Name is the name given to the log sink.
 	Name string
 
@@ -74,15 +77,20 @@ type OpenLogForwarderArgs struct {
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
 	OpenLogStream LogStreamFn
+
+	// Audit indicates that the forwarder should send the controller's
+	// audit log records rather than the model's log records.
+	Audit bool
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
 
 	closeExisting := func() error {
 		lf.enabled = false
+		lf.sinkConfig = nil
 		// If we are already sending, close the current sender.
 		if currentSender != nil {
 			return currentSender.Close()
@@ -96,16 +104,26 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		logger.Infof("config change - log forwarding not enabled")
+	var sinkCfg logfwd.SinkConfig
+	if ok && cfg.Enabled {
+		sinkCfg = cfg.Sinks[lf.args.SinkType]
+	}
+	if sinkCfg == nil {
+		logger.Infof("config change - log forwarding to %s not enabled", lf.args.SinkType)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
 	// and bounce the worker; we'll just log the issue and wait for another
 	// config change to come through.
 	// We'll continue sending using the current sink.
-	if err := cfg.Validate(); err != nil {
-		logger.Errorf("invalid log forward config change: %v", err)
+	if err := sinkCfg.Validate(); err != nil {
+		logger.Errorf("invalid %s log forward config change: %v", lf.args.SinkType, err)
+		return currentSender, nil
+	}
+	// The config for other sinks, or other model config, may have
+	// changed; there's no need to reopen the sink if its own config
+	// has not.
+	if currentSender != nil && reflect.DeepEqual(sinkCfg, lf.sinkConfig) {
 		return currentSender, nil
 	}
 
@@ -115,13 +133,14 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 	sink, err := OpenTrackingSink(TrackingSinkArgs{
 		Name:     lf.args.Name,
-		Config:   cfg,
+		Config:   sinkCfg,
 		Caller:   lf.args.Caller,
 		OpenSink: lf.args.OpenSink,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	lf.sinkConfig = sinkCfg
 	lf.enabledCh <- true
 	return sink, nil
 }
@@ -145,7 +164,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.SinkType)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -193,7 +212,8 @@ func (lf *LogForwarder) loop() error {
 			// Lazily create log streamer if needed.
 			if stream == nil {
 				streamCfg := params.LogStreamConfig{
-					Sink: lf.args.Name,
+					Sink:  lf.args.Name,
+					Audit: lf.args.Audit,
 					// TODO(wallyworld) - this should be configurable via lf.args.LogForwardConfig
 					MaxLookbackRecords: 100,
 				}
@@ -217,6 +237,9 @@ func (lf *LogForwarder) loop() error {
 		}
 	}()
 
+	// pending holds records received while no sink was open, so that
+	// they are sent once a sink is opened rather than being lost.
+	var pending []logfwd.Record
 	var sender SendCloser
 	defer func() {
 		if sender != nil {
@@ -230,13 +253,20 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
 			}
+			if sender != nil && len(pending) > 0 {
+				if err := sender.Send(pending); err != nil {
+					return errors.Trace(err)
+				}
+				pending = nil
+			}
 		case rec := <-records:
 			if sender == nil {
+				pending = append(pending, rec...)
 				continue
 			}
 			if err := sender.Send(rec); err != nil {
@@ -69,8 +69,9 @@ func (s *LogForwarderSuite) newLogForwarderArgs(
 	sender *stubSender,
 ) logforwarder.OpenLogForwarderArgs {
 	api := &mockLogForwardConfig{
-		enabled: stream != nil,
-		host:    "10.0.0.1",
+		enabled:  stream != nil,
+		host:     "10.0.0.1",
+		sinkType: logfwd.SinkTypeSyslog,
 	}
 	return s.newLogForwarderArgsWithAPI(c, api, stream, sender)
 }
@@ -85,8 +86,10 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		SinkType:         logfwd.SinkTypeSyslog,
+		Name:             "juju-log-forward",
+		OpenSink: func(cfg logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -113,14 +116,38 @@ func (s *LogForwarderSuite) TestOne(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestAudit(c *gc.C) {
+	s.stream.addRecords(c, s.rec)
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.Name = "juju-audit-forward"
+	args.Audit = true
+	streamCfgs := make(chan params.LogStreamConfig, 1)
+	args.OpenLogStream = func(_ base.APICaller, cfg params.LogStreamConfig, _ string) (logforwarder.LogStream, error) {
+		streamCfgs <- cfg
+		return s.stream, nil
+	}
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+	c.Assert(<-streamCfgs, jc.DeepEquals, params.LogStreamConfig{
+		Sink:               "juju-audit-forward",
+		Audit:              true,
+		MaxLookbackRecords: 100,
+	})
+}
+
 func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	rec0 := s.rec
 	rec1 := s.rec
 	rec1.ID = 11
 
 	api := &mockLogForwardConfig{
-		enabled: true,
-		host:    "10.0.0.1",
+		enabled:  true,
+		host:     "10.0.0.1",
+		sinkType: logfwd.SinkTypeSyslog,
 	}
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -152,6 +179,94 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestOtherSinkConfigChange(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+
+	api := &mockLogForwardConfig{
+		enabled:  true,
+		host:     "10.0.0.1",
+		sinkType: logfwd.SinkTypeSyslog,
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0)
+	s.sender.waitForSend(c)
+
+	// A change to the config of another sink leaves this one open.
+	api.otherHost = "10.0.0.3"
+	api.changes <- struct{}{}
+
+	s.stream.addRecords(c, rec1)
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestSinkDeselected(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+
+	api := &mockLogForwardConfig{
+		enabled:  true,
+		host:     "10.0.0.1",
+		sinkType: logfwd.SinkTypeSyslog,
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0)
+	s.sender.waitForSend(c)
+
+	// Forward logs to another type of sink instead.
+	api.sinkType = logfwd.SinkTypeHTTP
+	api.changes <- struct{}{}
+	s.sender.waitForClose(c)
+
+	// Records read while the sink is deselected are held on to,
+	// and sent once it is selected again.
+	s.stream.addRecords(c, rec1)
+	api.sinkType = logfwd.SinkTypeSyslog
+	api.changes <- struct{}{}
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Close", nil},
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestNotSelected(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled:  true,
+		host:     "10.0.0.1",
+		sinkType: logfwd.SinkTypeHTTP,
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, lf)
+
+	// There should be no stream or sender activity when log
+	// forwarding to this sink's type is not selected.
+	s.stream.stub.CheckCallNames(c)
+	s.sender.stub.CheckCallNames(c)
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -207,11 +322,14 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 }
 
 type mockLogForwardConfig struct {
-	enabled bool
-	host    string
-	changes chan struct{}
+	enabled   bool
+	host      string
+	otherHost string
+	sinkType  string
+	changes   chan struct{}
 }
 
+
 type mockWatcher struct {
 	watcher.NotifyWatcher
 	changes chan struct{}
@@ -248,14 +366,26 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
-	}, true, nil
+func (c *mockLogForwardConfig) LogForwardConfig() (*logfwd.Config, bool, error) {
+	sinkCfg := func(host string) *syslog.RawConfig {
+		return &syslog.RawConfig{
+			Enabled:    c.enabled,
+			Host:       host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		}
+	}
+	cfg := &logfwd.Config{
+		Enabled: c.enabled,
+		Sinks: map[string]logfwd.SinkConfig{
+			c.sinkType: sinkCfg(c.host),
+		},
+	}
+	if c.otherHost != "" {
+		cfg.Sinks["other"] = sinkCfg(c.otherHost)
+	}
+	return cfg, true, nil
 }
 
 type stubStream struct {
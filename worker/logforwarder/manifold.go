@@ -20,9 +20,14 @@ type ManifoldConfig struct {
 	// These are the dependency resource names.
 	APICallerName string
 
-	// Sinks are the named functions that opens the underlying log sinks
-	// to which log records will be forwarded.
-	Sinks []LogSinkSpec
+	// Sinks are the log sinks, keyed by type, to which log records
+	// may be forwarded. The model config selects which are used.
+	Sinks LogSinkRegistry
+
+	// AuditSinks are the log sinks, keyed by type, to which the
+	// controller's audit log records may be forwarded. It is only set
+	// for the controller model.
+	AuditSinks LogSinkRegistry
 
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
@@ -71,6 +76,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				LogForwardConfig: agentFacade,
 				Caller:           apiCaller,
 				Sinks:            config.Sinks,
+				AuditSinks:       config.AuditSinks,
 				OpenLogStream:    openLogStream,
 				OpenLogForwarder: openForwarder,
 			})
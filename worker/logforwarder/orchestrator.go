@@ -4,13 +4,20 @@
 package logforwarder
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/catacomb"
 )
 
+// orchestrator runs a log forwarder for each type of log sink. Each
+// forwarder enables itself when the model config selects its sink type,
+// and tracks the records it has sent separately from the others.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -25,9 +32,14 @@ type OrchestratorArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Sinks are the named functions that open the underlying log sinks
-	// to which log records will be forwarded.
-	Sinks []LogSinkSpec
+	// Sinks are the log sinks, keyed by type, to which log records
+	// may be forwarded.
+	Sinks LogSinkRegistry
+
+	// AuditSinks are the log sinks, keyed by type, to which the
+	// controller's audit log records may be forwarded. It should only
+	// be set for the controller model.
+	AuditSinks LogSinkRegistry
 
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
@@ -38,21 +50,74 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
-	if len(args.Sinks) == 0 {
+	if len(args.Sinks) == 0 && len(args.AuditSinks) == 0 {
 		return nil, nil
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+
+	var forwarders []worker.Worker
+	openForwarders := func(sinks LogSinkRegistry, audit bool) error {
+		sinkTypes := make([]string, 0, len(sinks))
+		for sinkType := range sinks {
+			sinkTypes = append(sinkTypes, sinkType)
+		}
+		sort.Strings(sinkTypes)
+
+		for _, sinkType := range sinkTypes {
+			spec := sinks[sinkType]
+			lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+				ControllerUUID:   args.ControllerUUID,
+				LogForwardConfig: args.LogForwardConfig,
+				Caller:           args.Caller,
+				SinkType:         sinkType,
+				Name:             spec.Name,
+				OpenSink:         spec.OpenFn,
+				OpenLogStream:    args.OpenLogStream,
+				Audit:            audit,
+			})
+			if err != nil {
+				if audit {
+					return errors.Annotatef(err, "opening %s audit log forwarder", sinkType)
+				}
+				return errors.Annotatef(err, "opening %s log forwarder", sinkType)
+			}
+			forwarders = append(forwarders, lf)
+		}
+		return nil
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
+	err := openForwarders(args.Sinks, false)
+	if err == nil {
+		err = openForwarders(args.AuditSinks, true)
+	}
+	if err != nil {
+		for _, w := range forwarders {
+			worker.Stop(w)
+		}
+		return nil, errors.Trace(err)
+	}
+
+	o := &orchestrator{}
+	err = catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: forwarders,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	<-o.catacomb.Dying()
+	return o.catacomb.ErrDying()
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
@@ -4,7 +4,7 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/watcher"
 )
 
@@ -15,19 +15,28 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	LogForwardConfig() (*logfwd.Config, bool, error)
 }
 
+// LogSinkSpec describes a type of log sink to which log records may
+// be forwarded.
 type LogSinkSpec struct {
-	// Name is the name of the log sink.
+	// Name is the name of the log sink. The last record successfully
+	// forwarded to the sink is tracked under this name, so it must be
+	// unique and must not change between releases.
 	Name string
 
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
-// LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+// LogSinkRegistry holds the log sinks to which log records may be
+// forwarded, keyed by the sink type used to select them in model config.
+type LogSinkRegistry map[string]LogSinkSpec
+
+// LogSinkFn is a function that opens a log sink. The config will be
+// the one that the model config holds for the sink's type.
+type LogSinkFn func(cfg logfwd.SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
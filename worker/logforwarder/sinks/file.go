@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// NewFileOpener returns a function that opens a sink which writes the
+// log messages to be forwarded to a rotating file at the given path.
+// The path is not part of the model config, so that model users can't
+// choose where files are written on the controller.
+func NewFileOpener(path string) logforwarder.LogSinkFn {
+	return func(sinkCfg logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+		cfg, ok := sinkCfg.(*logfile.RawConfig)
+		if !ok {
+			return nil, errors.Errorf("expected file config, got %T", sinkCfg)
+		}
+		if !cfg.Enabled {
+			return nil, errors.New("log forwarding not enabled")
+		}
+		client, err := logfile.Open(path, *cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &logforwarder.LogSink{
+			SendCloser: client,
+		}, nil
+	}
+}
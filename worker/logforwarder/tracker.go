@@ -10,18 +10,19 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config logfwd.SinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Name is the name given to the log sink.
+	// Name is the name given to the log sink. The last record sent
+	// is tracked separately for each sink name, so that a sink picks
+	// up from where it left off regardless of what other sinks do.
 	Name string
 
 	// OpenSink is the function that opens the underlying log sink that
@@ -12,6 +12,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/deque"
+
+	"github.com/juju/juju/core/logfields"
 )
 
 // LogRecord represents a log message in an agent which is to be
@@ -23,6 +25,11 @@ type LogRecord struct {
 	Level    loggo.Level
 	Message  string
 
+	// Labels and Fields hold any structured data attached to the
+	// message with the logfields package.
+	Labels []string
+	Fields map[string]string
+
 	// Number of messages dropped after this one due to buffer limit.
 	DroppedAfter int
 }
@@ -147,12 +154,15 @@ func (w *BufferedLogWriter) loop() {
 
 // Write sends a new log message to the writer. This implements the loggo.Writer interface.
 func (w *BufferedLogWriter) Write(entry loggo.Entry) {
+	data := logfields.DataFor(entry)
 	w.in <- &LogRecord{
 		Time:     entry.Timestamp,
 		Module:   entry.Module,
 		Location: fmt.Sprintf("%s:%d", filepath.Base(entry.Filename), entry.Line),
 		Level:    entry.Level,
 		Message:  entry.Message,
+		Labels:   data.Labels,
+		Fields:   data.Fields,
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/logfields"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendertest"
@@ -77,6 +78,30 @@ func (s *bufferedLogWriterSuite) TestBuffering(c *gc.C) {
 	}
 }
 
+func (s *bufferedLogWriterSuite) TestFields(c *gc.C) {
+	context := loggo.NewContext(loggo.DEBUG)
+	c.Assert(context.AddWriter("buffered", s.writer), jc.ErrorIsNil)
+	logger := context.GetLogger("unit.mysql/0.juju-log")
+
+	logfields.Logf(logger, loggo.INFO, logfields.Data{
+		Labels: []string{"upgrade"},
+		Fields: map[string]string{"hook": "install"},
+	}, "installing")
+	logger.Infof("no fields")
+
+	rec := s.receiveOne(c)
+	c.Check(rec.Module, gc.Equals, "unit.mysql/0.juju-log")
+	c.Check(rec.Level, gc.Equals, loggo.INFO)
+	c.Check(rec.Message, gc.Equals, "installing")
+	c.Check(rec.Labels, jc.DeepEquals, []string{"upgrade"})
+	c.Check(rec.Fields, jc.DeepEquals, map[string]string{"hook": "install"})
+
+	rec = s.receiveOne(c)
+	c.Check(rec.Message, gc.Equals, "no fields")
+	c.Check(rec.Labels, gc.HasLen, 0)
+	c.Check(rec.Fields, gc.HasLen, 0)
+}
+
 func (s *bufferedLogWriterSuite) TestLimiting(c *gc.C) {
 	write := func(msgNum int) {
 		s.writer.Write(
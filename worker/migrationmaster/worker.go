@@ -587,6 +587,8 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 				Location: msg.Location,
 				Level:    msg.Severity,
 				Message:  msg.Message,
+				Labels:   msg.Labels,
+				Fields:   msg.Fields,
 			})
 			if err != nil {
 				return errors.Trace(err)
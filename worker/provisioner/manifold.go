@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
 	apiprovisioner "github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
 )
@@ -24,7 +25,11 @@ type ManifoldConfig struct {
 	APICallerName string
 	EnvironName   string
 
-	NewProvisionerFunc func(*apiprovisioner.State, agent.Config, environs.Environ) (Provisioner, error)
+	// Tracer, if non-nil, records the provisioner's calls to the
+	// environ.
+	Tracer *tracing.Tracer
+
+	NewProvisionerFunc func(*apiprovisioner.State, agent.Config, environs.Environ, *tracing.Tracer) (Provisioner, error)
 }
 
 // Manifold creates a manifold that runs an environemnt provisioner. See the
@@ -54,7 +59,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 			api := apiprovisioner.NewState(apiCaller)
 			agentConfig := agent.CurrentConfig()
-			w, err := config.NewProvisionerFunc(api, agentConfig, environ)
+			w, err := config.NewProvisionerFunc(api, agentConfig, environ, config.Tracer)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
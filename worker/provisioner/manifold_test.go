@@ -7,12 +7,14 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
 	apitesting "github.com/juju/juju/api/base/testing"
 	apiprovisioner "github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
 	dt "github.com/juju/juju/worker/dependency/testing"
@@ -21,24 +23,28 @@ import (
 
 type ManifoldSuite struct {
 	testing.IsolationSuite
-	stub testing.Stub
+	stub   testing.Stub
+	tracer *tracing.Tracer
 }
 
 var _ = gc.Suite(&ManifoldSuite{})
 
 func (s *ManifoldSuite) makeManifold() dependency.Manifold {
+	s.tracer = tracing.NewTracer("machine-0", clock.WallClock)
 	fakeNewProvFunc := func(
 		apiSt *apiprovisioner.State,
 		agentConf agent.Config,
 		environ environs.Environ,
+		tracer *tracing.Tracer,
 	) (provisioner.Provisioner, error) {
-		s.stub.AddCall("NewProvisionerFunc")
+		s.stub.AddCall("NewProvisionerFunc", tracer)
 		return struct{ provisioner.Provisioner }{}, nil
 	}
 	return provisioner.Manifold(provisioner.ManifoldConfig{
 		AgentName:          "agent",
 		APICallerName:      "api-caller",
 		EnvironName:        "environ",
+		Tracer:             s.tracer,
 		NewProvisionerFunc: fakeNewProvFunc,
 	})
 }
@@ -92,7 +98,10 @@ func (s *ManifoldSuite) TestStarts(c *gc.C) {
 	}))
 	c.Check(w, gc.NotNil)
 	c.Check(err, jc.ErrorIsNil)
-	s.stub.CheckCallNames(c, "NewProvisionerFunc")
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "NewProvisionerFunc",
+		Args:     []interface{}{s.tracer},
+	}})
 }
 
 type fakeAgent struct {
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/agent"
 	apiprovisioner "github.com/juju/juju/api/provisioner"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
@@ -65,6 +66,7 @@ type provisioner struct {
 	broker                  environs.InstanceBroker
 	distributionGroupFinder DistributionGroupFinder
 	toolsFinder             ToolsFinder
+	tracer                  *tracing.Tracer
 	catacomb                catacomb.Catacomb
 }
 
@@ -169,6 +171,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		auth,
 		modelCfg.ImageStream(),
 		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		p.tracer,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -178,14 +181,21 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 
 // NewEnvironProvisioner returns a new Provisioner for an environment.
 // When new machines are added to the state, it allocates instances
-// from the environment and allocates them to the new machines.
-func NewEnvironProvisioner(st *apiprovisioner.State, agentConfig agent.Config, environ environs.Environ) (Provisioner, error) {
+// from the environment and allocates them to the new machines. Calls
+// to the environment are recorded by the tracer, which may be nil.
+func NewEnvironProvisioner(
+	st *apiprovisioner.State,
+	agentConfig agent.Config,
+	environ environs.Environ,
+	tracer *tracing.Tracer,
+) (Provisioner, error) {
 	p := &environProvisioner{
 		provisioner: provisioner{
 			st:                      st,
 			agentConfig:             agentConfig,
 			toolsFinder:             getToolsFinder(st),
 			distributionGroupFinder: getDistributionGroupFinder(st),
+			tracer:                  tracer,
 		},
 		environ: environ,
 	}
@@ -6,6 +6,7 @@ package provisioner
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -81,6 +83,7 @@ func NewProvisionerTask(
 	auth authentication.AuthenticationProvider,
 	imageStream string,
 	retryStartInstanceStrategy RetryStrategy,
+	tracer *tracing.Tracer,
 ) (ProvisionerTask, error) {
 	machineChanges := machineWatcher.Changes()
 	workers := []worker.Worker{machineWatcher}
@@ -105,6 +108,7 @@ func NewProvisionerTask(
 		availabilityZoneMachines:   make([]*AvailabilityZoneMachine, 0),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
+		tracer:                     tracer,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &task.catacomb,
@@ -138,6 +142,7 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	tracer                     *tracing.Tracer
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
@@ -234,14 +239,25 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 		task.machines[machine.Tag().String()] = machine
 		pending = append(pending, machine)
 	}
-	return task.startMachines(pending)
+	if len(pending) == 0 {
+		return nil
+	}
+	span := task.startProcessSpan("RetryMachines", machineIds(pending))
+	err = task.startMachines(span.Context(), pending)
+	span.Finish(err)
+	return err
 }
 
-func (task *provisionerTask) processMachines(ids []string) error {
+func (task *provisionerTask) processMachines(ids []string) (err error) {
 	logger.Tracef("processMachines(%v)", ids)
+	span := task.startProcessSpan("ProcessMachines", ids)
+	defer func() {
+		span.Finish(err)
+	}()
+	parent := span.Context()
 
 	// Populate the tasks maps of current instances and machines.
-	if err := task.populateMachineMaps(ids); err != nil {
+	if err := task.populateMachineMaps(parent, ids); err != nil {
 		return err
 	}
 
@@ -288,7 +304,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 	// pending ones, because if we start an instance and then fail to
 	// set its InstanceId on the machine we don't want to start a new
 	// instance for the same machine ID.
-	if err := task.stopInstances(append(stopping, unknown...)); err != nil {
+	if err := task.stopInstances(parent, append(stopping, unknown...)); err != nil {
 		return err
 	}
 
@@ -303,10 +319,28 @@ func (task *provisionerTask) processMachines(ids []string) error {
 	}
 
 	// Any machines that require maintenance get pinged
-	task.maintainMachines(maintain)
+	task.maintainMachines(parent, maintain)
 
 	// Start an instance for the pending ones
-	return task.startMachines(pending)
+	return task.startMachines(parent, pending)
+}
+
+// startProcessSpan starts the span which the spans of the broker
+// calls made while processing the given machines are children of.
+// Provisioning isn't started by an API request, so each span starts
+// a trace of its own.
+func (task *provisionerTask) startProcessSpan(name string, ids []string) *tracing.Span {
+	span := task.tracer.StartSpan(name, tracing.KindNone, tracing.SpanContext{})
+	span.SetTag("machine-ids", strings.Join(ids, ","))
+	return span
+}
+
+func machineIds(machines []*apiprovisioner.Machine) []string {
+	ids := make([]string, len(machines))
+	for i, machine := range machines {
+		ids[i] = machine.Id()
+	}
+	return ids
 }
 
 func instanceIds(instances []instance.Instance) []string {
@@ -319,10 +353,10 @@ func instanceIds(instances []instance.Instance) []string {
 
 // populateMachineMaps updates task.instances. Also updates
 // task.machines map if a list of IDs is given.
-func (task *provisionerTask) populateMachineMaps(ids []string) error {
+func (task *provisionerTask) populateMachineMaps(parent tracing.SpanContext, ids []string) error {
 	task.instances = make(map[instance.Id]instance.Instance)
 
-	instances, err := task.broker.AllInstances()
+	instances, err := task.brokerAllInstances(parent)
 	if err != nil {
 		return errors.Annotate(err, "failed to get all instances from broker")
 	}
@@ -504,7 +538,7 @@ func (task *provisionerTask) instancesForDeadMachines(deadMachines []*apiprovisi
 	return instances
 }
 
-func (task *provisionerTask) stopInstances(instances []instance.Instance) error {
+func (task *provisionerTask) stopInstances(parent tracing.SpanContext, instances []instance.Instance) error {
 	// Although calling StopInstance with an empty slice should produce no change in the
 	// provider, environs like dummy do not consider this a noop.
 	if len(instances) == 0 {
@@ -518,12 +552,86 @@ func (task *provisionerTask) stopInstances(instances []instance.Instance) error
 	for i, inst := range instances {
 		ids[i] = inst.Id()
 	}
-	if err := task.broker.StopInstances(ids...); err != nil {
+	if err := task.brokerStopInstances(parent, ids...); err != nil {
 		return errors.Annotate(err, "broker failed to stop instances")
 	}
 	return nil
 }
 
+// brokerAllInstances calls AllInstances on the broker, recording a
+// span for the call.
+func (task *provisionerTask) brokerAllInstances(parent tracing.SpanContext) ([]instance.Instance, error) {
+	span := task.tracer.StartSpan("AllInstances", tracing.KindClient, parent)
+	instances, err := task.broker.AllInstances()
+	if err == nil {
+		span.SetTag("instances", strconv.Itoa(len(instances)))
+	}
+	span.Finish(err)
+	return instances, err
+}
+
+// brokerStartInstance calls StartInstance on the broker, recording a
+// span for the call.
+func (task *provisionerTask) brokerStartInstance(
+	parent tracing.SpanContext,
+	machine *apiprovisioner.Machine,
+	startInstanceParams environs.StartInstanceParams,
+) (*environs.StartInstanceResult, error) {
+	span := task.tracer.StartSpan("StartInstance", tracing.KindClient, parent)
+	span.SetTag("machine-id", machine.Id())
+	span.SetTag("availability-zone", startInstanceParams.AvailabilityZone)
+	result, err := task.broker.StartInstance(startInstanceParams)
+	if err == nil {
+		span.SetTag("instance-id", string(result.Instance.Id()))
+	}
+	span.Finish(err)
+	return result, err
+}
+
+// brokerStopInstances calls StopInstances on the broker, recording a
+// span for the call.
+func (task *provisionerTask) brokerStopInstances(parent tracing.SpanContext, ids ...instance.Id) error {
+	span := task.tracer.StartSpan("StopInstances", tracing.KindClient, parent)
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = string(id)
+	}
+	span.SetTag("instance-ids", strings.Join(idStrings, ","))
+	err := task.broker.StopInstances(ids...)
+	span.Finish(err)
+	return err
+}
+
+// brokerMaintainInstance calls MaintainInstance on the broker,
+// recording a span for the call.
+func (task *provisionerTask) brokerMaintainInstance(
+	parent tracing.SpanContext,
+	startInstanceParams environs.StartInstanceParams,
+) error {
+	span := task.tracer.StartSpan("MaintainInstance", tracing.KindClient, parent)
+	span.SetTag("machine-id", startInstanceParams.InstanceConfig.MachineId)
+	err := task.broker.MaintainInstance(startInstanceParams)
+	span.Finish(err)
+	return err
+}
+
+// brokerDeriveAvailabilityZones calls DeriveAvailabilityZones on the
+// zoned broker, recording a span for the call.
+func (task *provisionerTask) brokerDeriveAvailabilityZones(
+	parent tracing.SpanContext,
+	zonedEnv providercommon.ZonedEnviron,
+	startInstanceParams environs.StartInstanceParams,
+) ([]string, error) {
+	span := task.tracer.StartSpan("DeriveAvailabilityZones", tracing.KindClient, parent)
+	span.SetTag("machine-id", startInstanceParams.InstanceConfig.MachineId)
+	zones, err := zonedEnv.DeriveAvailabilityZones(startInstanceParams)
+	if err == nil {
+		span.SetTag("availability-zones", strings.Join(zones, ","))
+	}
+	span.Finish(err)
+	return zones, err
+}
+
 func (task *provisionerTask) constructInstanceConfig(
 	machine *apiprovisioner.Machine,
 	auth authentication.AuthenticationProvider,
@@ -700,13 +808,13 @@ func (task *provisionerTask) constructStartInstanceParams(
 	return startInstanceParams, nil
 }
 
-func (task *provisionerTask) maintainMachines(machines []*apiprovisioner.Machine) error {
+func (task *provisionerTask) maintainMachines(parent tracing.SpanContext, machines []*apiprovisioner.Machine) error {
 	for _, m := range machines {
 		logger.Infof("maintainMachines: %v", m)
 		startInstanceParams := environs.StartInstanceParams{}
 		startInstanceParams.InstanceConfig = &instancecfg.InstanceConfig{}
 		startInstanceParams.InstanceConfig.MachineId = m.Id()
-		if err := task.broker.MaintainInstance(startInstanceParams); err != nil {
+		if err := task.brokerMaintainInstance(parent, startInstanceParams); err != nil {
 			return errors.Annotatef(err, "cannot maintain machine %v", m)
 		}
 	}
@@ -873,7 +981,7 @@ func (b byPopulationThenNames) Swap(i, j int) {
 
 // startMachines starts a goroutine for each specified machine to
 // start it.  Errors from individual start machine attempts will be logged.
-func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) error {
+func (task *provisionerTask) startMachines(parent tracing.SpanContext, machines []*apiprovisioner.Machine) error {
 	if len(machines) == 0 {
 		return nil
 	}
@@ -903,7 +1011,7 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 		wg.Add(1)
 		go func(machine *apiprovisioner.Machine, dg []string, index int) {
 			defer wg.Done()
-			if err := task.startMachine(machine, dg); err != nil {
+			if err := task.startMachine(parent, machine, dg); err != nil {
 				task.removeMachineFromAZMap(machine)
 				errMachines[index] = err
 			}
@@ -987,12 +1095,16 @@ func (task *provisionerTask) setupToStartMachine(machine *apiprovisioner.Machine
 // populateExcludedMachines, translates the results of DeriveAvailabilityZones
 // into availabilityZoneMachines.ExcludedMachineIds for machines not to be used
 // in the given zone.
-func (task *provisionerTask) populateExcludedMachines(machineId string, startInstanceParams environs.StartInstanceParams) error {
+func (task *provisionerTask) populateExcludedMachines(
+	parent tracing.SpanContext,
+	machineId string,
+	startInstanceParams environs.StartInstanceParams,
+) error {
 	zonedEnv, ok := task.broker.(providercommon.ZonedEnviron)
 	if !ok {
 		return nil
 	}
-	derivedZones, err := zonedEnv.DeriveAvailabilityZones(startInstanceParams)
+	derivedZones, err := task.brokerDeriveAvailabilityZones(parent, zonedEnv, startInstanceParams)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -1011,9 +1123,19 @@ func (task *provisionerTask) populateExcludedMachines(machineId string, startIns
 }
 
 func (task *provisionerTask) startMachine(
+	parent tracing.SpanContext,
 	machine *apiprovisioner.Machine,
 	distributionGroupMachineIds []string,
-) error {
+) (err error) {
+	// Each machine's broker calls are grouped under a span of
+	// their own, as the machines are started concurrently.
+	span := task.tracer.StartSpan("StartMachine", tracing.KindNone, parent)
+	span.SetTag("machine-id", machine.Id())
+	defer func() {
+		span.Finish(err)
+	}()
+	parent = span.Context()
+
 	v, err := machine.ModelAgentVersion()
 	if err != nil {
 		return err
@@ -1026,7 +1148,7 @@ func (task *provisionerTask) startMachine(
 	// Figure out if the zones available to use for a new instance are
 	// restricted based on placement, and if so exclude those machines
 	// from being started in any other zone.
-	if err := task.populateExcludedMachines(machine.Id(), startInstanceParams); err != nil {
+	if err := task.populateExcludedMachines(parent, machine.Id(), startInstanceParams); err != nil {
 		return err
 	}
 
@@ -1054,7 +1176,7 @@ func (task *provisionerTask) startMachine(
 			logger.Infof("trying machine %s StartInstance in availability zone %s", machine, startInstanceParams.AvailabilityZone)
 		}
 
-		attemptResult, err := task.broker.StartInstance(startInstanceParams)
+		attemptResult, err := task.brokerStartInstance(parent, machine, startInstanceParams)
 		if err == nil {
 			result = attemptResult
 			break
@@ -1129,7 +1251,7 @@ func (task *provisionerTask) startMachine(
 		if err2 := task.setErrorStatus("cannot register instance for machine %v: %v", machine, err); err2 != nil {
 			logger.Errorf("%v", errors.Annotate(err2, "cannot set machine's status"))
 		}
-		if err2 := task.broker.StopInstances(result.Instance.Id()); err2 != nil {
+		if err2 := task.brokerStopInstances(parent, result.Instance.Id()); err2 != nil {
 			logger.Errorf("%v", errors.Annotate(err2, "after failing to set instance info"))
 		}
 		return errors.Annotate(err, "cannot set instance info")
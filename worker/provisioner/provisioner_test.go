@@ -13,6 +13,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/utils/arch"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
@@ -27,6 +28,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/filestorage"
@@ -61,6 +64,9 @@ type CommonProvisionerSuite struct {
 
 	st          api.Connection
 	provisioner *apiprovisioner.State
+
+	collector *tracingtest.Collector
+	tracer    *tracing.Tracer
 }
 
 func (s *CommonProvisionerSuite) assertProvisionerObservesConfigChanges(c *gc.C, p provisioner.Provisioner) {
@@ -140,6 +146,10 @@ func (s *CommonProvisionerSuite) SetUpTest(c *gc.C) {
 	dummy.Listen(op)
 	s.op = op
 
+	s.collector = &tracingtest.Collector{}
+	s.tracer = tracing.NewTracer("machine-0", clock.WallClock)
+	s.tracer.SetExporter(s.collector)
+
 	cfg, err := s.IAASModel.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
 	s.cfg = cfg
@@ -459,7 +469,7 @@ func (s *CommonProvisionerSuite) newEnvironProvisioner(c *gc.C) provisioner.Prov
 	machineTag := names.NewMachineTag("0")
 	agentConfig := s.AgentConfigForTag(c, machineTag)
 	apiState := apiprovisioner.NewState(s.st)
-	w, err := provisioner.NewEnvironProvisioner(apiState, agentConfig, s.Environ)
+	w, err := provisioner.NewEnvironProvisioner(apiState, agentConfig, s.Environ, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
 }
@@ -1354,6 +1364,7 @@ func (s *ProvisionerSuite) newProvisionerTaskWithRetryStrategy(
 		auth,
 		imagemetadata.ReleasedStream,
 		retryStrategy,
+		s.tracer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
@@ -1378,6 +1389,66 @@ func (s *ProvisionerSuite) TestHarvestNoneReapsNothing(c *gc.C) {
 	s.checkNoOperations(c)
 }
 
+func (s *ProvisionerSuite) TestProvisionerTaskTracesBrokerCalls(c *gc.C) {
+	task := s.newProvisionerTask(c, config.HarvestAll, s.Environ, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer workertest.CleanKill(c, task)
+
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	inst := s.checkStartInstance(c, m0)
+	c.Assert(m0.EnsureDead(), gc.IsNil)
+	s.checkStopInstances(c, inst)
+
+	// Spans are exported when they finish, so the spans of the broker
+	// calls are seen before those of their parents.
+	var startInstance, stopInstances, startMachine, startProcess, stopProcess *tracing.SpanData
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		byID := make(map[string]*tracing.SpanData)
+		for _, span := range s.collector.Spans() {
+			span := span
+			byID[span.ID] = &span
+			switch span.Name {
+			case "StartInstance":
+				startInstance = &span
+			case "StopInstances":
+				stopInstances = &span
+			}
+		}
+		if startInstance == nil || stopInstances == nil {
+			continue
+		}
+		startMachine = byID[startInstance.ParentID]
+		stopProcess = byID[stopInstances.ParentID]
+		if startMachine != nil && stopProcess != nil {
+			startProcess = byID[startMachine.ParentID]
+		}
+		if startProcess != nil {
+			break
+		}
+	}
+	c.Assert(startProcess, gc.NotNil)
+
+	c.Check(startInstance.Kind, gc.Equals, tracing.KindClient)
+	c.Check(startInstance.Tags, jc.DeepEquals, map[string]string{
+		"machine-id":  m0.Id(),
+		"instance-id": string(inst.Id()),
+	})
+	c.Check(startMachine.Name, gc.Equals, "StartMachine")
+	c.Check(startMachine.Tags, jc.DeepEquals, map[string]string{
+		"machine-id": m0.Id(),
+	})
+	c.Check(startProcess.Name, gc.Equals, "ProcessMachines")
+	c.Check(startProcess.ParentID, gc.Equals, "")
+	c.Check(startInstance.TraceID, gc.Equals, startProcess.TraceID)
+
+	c.Check(stopInstances.Tags, jc.DeepEquals, map[string]string{
+		"instance-ids": string(inst.Id()),
+	})
+	c.Check(stopProcess.Name, gc.Equals, "ProcessMachines")
+	c.Check(stopProcess.Tags["machine-ids"], gc.Equals, m0.Id())
+	c.Check(stopInstances.TraceID, gc.Equals, stopProcess.TraceID)
+}
+
 func (s *ProvisionerSuite) TestHarvestUnknownReapsOnlyUnknown(c *gc.C) {
 
 	task := s.newProvisionerTask(c,
@@ -15,8 +15,8 @@ import (
 // ManifoldConfig holds the names of the resources used by, and the
 // additional dependencies of, an undertaker worker.
 type ManifoldConfig struct {
-	APICallerName string
-	EnvironName   string
+	APICallerName      string
+	CloudDestroyerName string
 
 	NewFacade func(base.APICaller) (Facade, error)
 	NewWorker func(Config) (worker.Worker, error)
@@ -28,8 +28,8 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
 		return nil, errors.Trace(err)
 	}
-	var environ environs.Environ
-	if err := context.Get(config.EnvironName, &environ); err != nil {
+	var destroyer environs.CloudDestroyer
+	if err := context.Get(config.CloudDestroyerName, &destroyer); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -38,8 +38,8 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 	worker, err := config.NewWorker(Config{
-		Facade:  facade,
-		Environ: environ,
+		Facade:    facade,
+		Destroyer: destroyer,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -53,7 +53,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 	return dependency.Manifold{
 		Inputs: []string{
 			config.APICallerName,
-			config.EnvironName,
+			config.CloudDestroyerName,
 		},
 		Start: config.start,
 	}
@@ -105,8 +105,8 @@ func (fix fixture) run(c *gc.C, test func(worker.Worker)) *testing.Stub {
 	}
 	stub.SetErrors(fix.errors...)
 	w, err := undertaker.NewUndertaker(undertaker.Config{
-		Facade:  facade,
-		Environ: environ,
+		Facade:    facade,
+		Destroyer: environ,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	defer fix.cleanup(c, w)
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
@@ -62,10 +63,25 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 		return opc.u.relations.CommitHook(hi)
 	case hi.Kind.IsStorage():
 		return opc.u.storage.CommitHook(hi)
+	case hi.Kind == hook.PreSeriesUpgrade:
+		return opc.setUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted)
+	case hi.Kind == hook.PostSeriesUpgrade:
+		return opc.setUpgradeSeriesStatus(model.UpgradeSeriesCompleted)
 	}
 	return nil
 }
 
+// setUpgradeSeriesStatus records the progress of the unit through the
+// series upgrade of its machine, unless the upgrade has been aborted.
+func (opc *operationCallbacks) setUpgradeSeriesStatus(status model.UpgradeSeriesStatus) error {
+	err := opc.u.unit.SetUpgradeSeriesStatus(status)
+	if params.IsCodeNotFound(err) {
+		logger.Infof("series upgrade aborted; not setting status %q", status)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func notifyHook(hook string, ctx runner.Context, method func(string)) {
 	if r, err := ctx.HookRelation(); err == nil {
 		remote, _ := ctx.RemoteUnitName()
@@ -85,9 +101,17 @@ func (opc *operationCallbacks) NotifyHookCompleted(hook string, ctx runner.Conte
 }
 
 // NotifyHookFailed is part of the operation.Callbacks interface.
-func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context) {
+func (opc *operationCallbacks) NotifyHookFailed(hookName string, ctx runner.Context) {
 	if opc.u.observer != nil {
-		notifyHook(hook, ctx, opc.u.observer.HookFailed)
+		notifyHook(hookName, ctx, opc.u.observer.HookFailed)
+	}
+	switch hooks.Kind(hookName) {
+	case hook.PreSeriesUpgrade, hook.PostSeriesUpgrade:
+		// The machine's series upgrade cannot proceed until the
+		// hook is resolved, so make the failure visible.
+		if err := opc.setUpgradeSeriesStatus(model.UpgradeSeriesError); err != nil {
+			logger.Errorf("cannot set series upgrade status: %v", err)
+		}
 	}
 }
 
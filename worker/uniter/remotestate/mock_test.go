@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
@@ -204,6 +205,8 @@ type mockUnit struct {
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
 	relationsWatcher      *mockStringsWatcher
+	upgradeSeriesWatcher  *mockNotifyWatcher
+	upgradeSeriesStatus   model.UpgradeSeriesStatus
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -254,6 +257,14 @@ func (u *mockUnit) WatchRelations() (watcher.StringsWatcher, error) {
 	return u.relationsWatcher, nil
 }
 
+func (u *mockUnit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	return u.upgradeSeriesWatcher, nil
+}
+
+func (u *mockUnit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
+	return u.upgradeSeriesStatus, nil
+}
+
 type mockApplication struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
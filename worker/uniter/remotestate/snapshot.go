@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 )
 
 // Snapshot is a snapshot of the remote state of the unit.
@@ -71,6 +72,10 @@ type Snapshot struct {
 
 	// Series is the current series running on the unit
 	Series string
+
+	// UpgradeSeriesStatus is the progress of the unit through
+	// the series upgrade of its machine.
+	UpgradeSeriesStatus model.UpgradeSeriesStatus
 }
 
 type RelationSnapshot struct {
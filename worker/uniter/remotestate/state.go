@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/watcher"
 )
 
@@ -46,6 +47,12 @@ type Unit interface {
 	// WatchRelation returns a watcher that fires when relations
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
+	// WatchUpgradeSeriesNotifications returns a watcher that fires
+	// when the series upgrade of the unit's machine progresses.
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	// UpgradeSeriesStatus returns the progress of the unit through
+	// the series upgrade of its machine.
+	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
 }
 
 type Application interface {
@@ -268,6 +268,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenUpgradeSeriesChange bool
+	upgradeSeriesw, err := w.unit.WatchUpgradeSeriesNotifications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(upgradeSeriesw); err != nil {
+		return errors.Trace(err)
+	}
+	requiredEvents++
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -352,6 +362,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenConfigChange)
 
+		case _, ok := <-upgradeSeriesw.Changes():
+			logger.Debugf("got upgrade series change: ok=%t", ok)
+			if !ok {
+				return errors.New("upgrade series watcher closed")
+			}
+			if err := w.upgradeSeriesStatusChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenUpgradeSeriesChange)
+
 		case _, ok := <-addressesw.Changes():
 			logger.Debugf("got address change: ok=%t", ok)
 			if !ok {
@@ -547,6 +567,17 @@ func (w *RemoteStateWatcher) configChanged() error {
 	return nil
 }
 
+func (w *RemoteStateWatcher) upgradeSeriesStatusChanged() error {
+	status, err := w.unit.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.UpgradeSeriesStatus = status
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) addressesChanged() error {
 	w.mu.Lock()
 	w.current.ConfigVersion++
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -49,6 +50,8 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
 			relationsWatcher:      newMockStringsWatcher(),
+			upgradeSeriesWatcher:  newMockNotifyWatcher(),
+			upgradeSeriesStatus:   model.UpgradeSeriesNotStarted,
 		},
 		relations:                   make(map[names.RelationTag]*mockRelation),
 		storageAttachment:           make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -116,6 +119,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.application.applicationWatcher.changes <- struct{}{}
 	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.relationsWatcher.changes <- []string{}
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -130,6 +134,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.application.applicationWatcher.changes <- struct{}{}
 	st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.relationsWatcher.changes <- []string{}
+	st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	st.updateStatusIntervalWatcher.changes <- struct{}{}
 	l.claimTicket.ch <- struct{}{}
 }
@@ -151,6 +156,7 @@ func (s *WatcherSuite) TestSnapshot(c *gc.C) {
 		LeaderSettingsVersion: 1,
 		Leader:                true,
 		Series:                "",
+		UpgradeSeriesStatus:   model.UpgradeSeriesNotStarted,
 	})
 }
 
@@ -190,6 +196,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	s.st.unit.storageWatcher.changes <- []string{}
 	assertOneChange()
 
+	s.st.unit.upgradeSeriesStatus = model.UpgradeSeriesPrepareStarted
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, model.UpgradeSeriesPrepareStarted)
+
 	s.st.unit.application.forceUpgrade = true
 	s.st.unit.application.applicationWatcher.changes <- struct{}{}
 	assertOneChange()
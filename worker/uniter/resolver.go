@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/charm.v6/hooks"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -73,6 +74,15 @@ func (s *uniterResolver) NextOp(
 		s.retryHookTimerStarted = false
 	}
 
+	if localState.Kind == operation.Continue && remoteState.Life != params.Dying {
+		// A series upgrade of the unit's machine blocks all other
+		// operations until the unit has run the relevant hook.
+		op, err := s.nextOpUpgradeSeries(localState, remoteState, opFactory)
+		if errors.Cause(err) != resolver.ErrNoOperation || remoteState.UpgradeSeriesStatus.Blocking() {
+			return op, err
+		}
+	}
+
 	op, err := s.config.Leadership.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
@@ -203,6 +213,31 @@ func (s *uniterResolver) nextOpHookError(
 	}
 }
 
+// nextOpUpgradeSeries runs the pre-series-upgrade and post-series-upgrade
+// hooks as the unit's machine progresses through a series upgrade. While
+// the upgrade is in progress, no other hooks are run; ErrNoOperation is
+// returned once the unit has nothing further to do for the upgrade.
+func (s *uniterResolver) nextOpUpgradeSeries(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	switch remoteState.UpgradeSeriesStatus {
+	case model.UpgradeSeriesPrepareStarted:
+		if localState.UpgradeSeriesStatus != model.UpgradeSeriesPrepareCompleted {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PreSeriesUpgrade})
+		}
+	case model.UpgradeSeriesCompleteStarted:
+		if localState.UpgradeSeriesStatus != model.UpgradeSeriesCompleted {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PostSeriesUpgrade})
+		}
+	}
+	if remoteState.UpgradeSeriesStatus.Blocking() {
+		logger.Debugf("series upgrade in progress (status %q); not running other hooks", remoteState.UpgradeSeriesStatus)
+	}
+	return nil, resolver.ErrNoOperation
+}
+
 func charmModified(local resolver.LocalState, remote remotestate.Snapshot) bool {
 	if *local.CharmURL != *remote.CharmURL {
 		logger.Debugf("upgrade from %v to %v", local.CharmURL, remote.CharmURL)
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
@@ -98,4 +99,9 @@ type LocalState struct {
 	// Series is the current series running on the unit from remotestate.Snapshot
 	// for which a config-changed hook has been committed.
 	Series string
+
+	// UpgradeSeriesStatus is the progress of the unit through a
+	// series upgrade, as recorded by the committing of the
+	// pre-series-upgrade and post-series-upgrade hooks.
+	UpgradeSeriesStatus model.UpgradeSeriesStatus
 }
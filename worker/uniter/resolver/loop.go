@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6/hooks"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -58,6 +59,7 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 	for {
 		rf.RemoteState = cfg.Watcher.Snapshot()
 		rf.LocalState.State = cfg.Executor.State()
+		resetUpgradeSeriesStatus(rf.LocalState, rf.RemoteState)
 
 		op, err := cfg.Resolver.NextOp(*rf.LocalState, rf.RemoteState, rf)
 		for err == nil {
@@ -69,6 +71,7 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 			// changed between operations.
 			rf.RemoteState = cfg.Watcher.Snapshot()
 			rf.LocalState.State = cfg.Executor.State()
+			resetUpgradeSeriesStatus(rf.LocalState, rf.RemoteState)
 
 			err = updateCharmDir(rf.LocalState.State, cfg.CharmDirGuard, cfg.Abort)
 			if err != nil {
@@ -101,6 +104,16 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 	}
 }
 
+// resetUpgradeSeriesStatus forgets the unit's progress through a series
+// upgrade once the upgrade has finished or been aborted, so that the
+// series upgrade hooks run again for the next one.
+func resetUpgradeSeriesStatus(local *LocalState, remote remotestate.Snapshot) {
+	switch remote.UpgradeSeriesStatus {
+	case model.UpgradeSeriesNotStarted, "":
+		local.UpgradeSeriesStatus = ""
+	}
+}
+
 // updateCharmDir sets charm directory availability for sharing among
 // concurrent workers according to local operation state.
 func updateCharmDir(opState operation.State, guard fortress.Guard, abort fortress.Abort) error {
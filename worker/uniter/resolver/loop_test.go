@@ -11,6 +11,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -144,6 +145,45 @@ func (s *LoopSuite) TestInitialFinalLocalState(c *gc.C) {
 	c.Assert(lastLocal, jc.DeepEquals, local)
 }
 
+func (s *LoopSuite) TestUpgradeSeriesStatusReset(c *gc.C) {
+	for i, test := range []struct {
+		remote model.UpgradeSeriesStatus
+		local  model.UpgradeSeriesStatus
+	}{
+		{remote: model.UpgradeSeriesNotStarted, local: ""},
+		{remote: "", local: ""},
+		{remote: model.UpgradeSeriesPrepareStarted, local: model.UpgradeSeriesPrepareCompleted},
+	} {
+		c.Logf("test %d: remote status %q", i, test.remote)
+		var local resolver.LocalState
+		s.resolver = resolver.ResolverFunc(func(
+			l resolver.LocalState,
+			_ remotestate.Snapshot,
+			_ operation.Factory,
+		) (operation.Operation, error) {
+			local = l
+			return nil, resolver.ErrNoOperation
+		})
+		s.watcher.snapshot.UpgradeSeriesStatus = test.remote
+		abort := make(chan struct{})
+		close(abort)
+
+		err := resolver.Loop(resolver.LoopConfig{
+			Resolver:      s.resolver,
+			Factory:       s.opFactory,
+			Watcher:       s.watcher,
+			Executor:      s.executor,
+			Abort:         abort,
+			CharmDirGuard: &mockCharmDirGuard{},
+		}, &resolver.LocalState{
+			CharmURL:            s.charmURL,
+			UpgradeSeriesStatus: model.UpgradeSeriesPrepareCompleted,
+		})
+		c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+		c.Check(local.UpgradeSeriesStatus, gc.Equals, test.local)
+	}
+}
+
 func (s *LoopSuite) TestLoop(c *gc.C) {
 	var resolverCalls int
 	theOp := &mockOp{}
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -122,6 +123,14 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.PreSeriesUpgrade:
+		op = onCommitWrapper{op, func() {
+			s.LocalState.UpgradeSeriesStatus = model.UpgradeSeriesPrepareCompleted
+		}}
+	case hook.PostSeriesUpgrade:
+		op = onCommitWrapper{op, func() {
+			s.LocalState.UpgradeSeriesStatus = model.UpgradeSeriesCompleted
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
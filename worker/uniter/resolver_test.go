@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter"
 	uniteractions "github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/hook"
@@ -142,6 +143,64 @@ func (s *resolverSuite) TestSeriesChangedBlank(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run config-changed hook")
 }
 
+func (s *resolverSuite) startedLocalState() resolver.LocalState {
+	return resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+}
+
+func (s *resolverSuite) TestUpgradeSeriesPrepareStarted(c *gc.C) {
+	localState := s.startedLocalState()
+	s.remoteState.UpgradeSeriesStatus = model.UpgradeSeriesPrepareStarted
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run pre-series-upgrade hook")
+}
+
+func (s *resolverSuite) TestUpgradeSeriesBlocksOtherHooks(c *gc.C) {
+	localState := s.startedLocalState()
+	localState.UpgradeSeriesStatus = model.UpgradeSeriesPrepareCompleted
+	s.remoteState.Series = "trusty"
+	s.remoteState.UpdateStatusVersion = 1
+	for _, status := range []model.UpgradeSeriesStatus{
+		model.UpgradeSeriesPrepareStarted,
+		model.UpgradeSeriesPrepareCompleted,
+	} {
+		s.remoteState.UpgradeSeriesStatus = status
+		_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+		c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	}
+}
+
+func (s *resolverSuite) TestUpgradeSeriesCompleteStarted(c *gc.C) {
+	localState := s.startedLocalState()
+	localState.UpgradeSeriesStatus = model.UpgradeSeriesPrepareCompleted
+	s.remoteState.UpgradeSeriesStatus = model.UpgradeSeriesCompleteStarted
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run post-series-upgrade hook")
+
+	localState.UpgradeSeriesStatus = model.UpgradeSeriesCompleted
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestUpgradeSeriesCompletedResumesHooks(c *gc.C) {
+	localState := s.startedLocalState()
+	localState.UpgradeSeriesStatus = model.UpgradeSeriesCompleted
+	s.remoteState.UpgradeSeriesStatus = model.UpgradeSeriesCompleted
+	s.remoteState.Series = "trusty"
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
+
 func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c *gc.C) {
 	s.resolverConfig.ShouldRetryHooks = false
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -21,6 +21,8 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/version"
@@ -128,6 +130,9 @@ type HookContext struct {
 	// id identifies the context.
 	id string
 
+	// hookName is the name of the hook being run, if any.
+	hookName string
+
 	// actionData contains the values relevant to the run of an Action:
 	// its tag, its parameters, and its results.
 	actionData *ActionData
@@ -483,6 +488,25 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// GoalState returns the goal state for the current unit.
+func (ctx *HookContext) GoalState() (*application.GoalState, error) {
+	goalState, err := ctx.state.GoalState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &goalState, nil
+}
+
+// CloudSpec returns the cloud specification of the unit's model, if
+// the unit's application has been granted trust.
+func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
+	spec, err := ctx.state.CloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spec, nil
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -529,6 +553,25 @@ func (ctx *HookContext) UpdateActionResults(keys []string, value string) error {
 	return nil
 }
 
+// LogFields implements jujuc.ContextLogging.
+func (ctx *HookContext) LogFields() map[string]string {
+	fields := map[string]string{
+		logfields.Unit:      ctx.unitName,
+		logfields.ContextID: ctx.id,
+	}
+	if ctx.hookName != "" {
+		fields[logfields.Hook] = ctx.hookName
+	}
+	if ctx.actionData != nil {
+		fields[logfields.Action] = ctx.actionData.Name
+		fields[logfields.ActionID] = ctx.actionData.Tag.Id()
+	}
+	if relation, found := ctx.relations[ctx.relationId]; found {
+		fields[logfields.RelationID] = relation.FakeId()
+	}
+	return fields
+}
+
 func (ctx *HookContext) HookRelation() (jujuc.ContextRelation, error) {
 	return ctx.Relation(ctx.relationId)
 }
@@ -226,6 +226,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	ctx.hookName = hookName
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -174,6 +174,15 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotActionContext(c, ctx)
 	s.AssertRelationContext(c, ctx, 1, "")
 	s.AssertNotStorageContext(c, ctx)
+
+	relation, err := ctx.HookRelation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.LogFields(), jc.DeepEquals, map[string]string{
+		"unit":        "u/0",
+		"context-id":  ctx.Id(),
+		"hook":        relation.Name() + "-relation-broken",
+		"relation-id": relation.FakeId(),
+	})
 }
 
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
@@ -260,6 +269,13 @@ func (s *ContextFactorySuite) TestActionContext(c *gc.C) {
 	s.AssertActionContext(c, ctx)
 	s.AssertNotRelationContext(c, ctx)
 	s.AssertNotStorageContext(c, ctx)
+
+	c.Assert(ctx.LogFields(), jc.DeepEquals, map[string]string{
+		"unit":       "u/0",
+		"context-id": ctx.Id(),
+		"action":     "snapshot",
+		"action-id":  action.Id(),
+	})
 }
 
 func (s *ContextFactorySuite) TestCommandContext(c *gc.C) {
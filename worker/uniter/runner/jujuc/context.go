@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
@@ -40,6 +41,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextLogging
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -93,6 +95,12 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// GoalState returns the goal state for the current unit.
+	GoalState() (*application.GoalState, error)
+
+	// CloudSpec returns the unit's cloud specification.
+	CloudSpec() (*params.CloudSpec, error)
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -290,6 +298,15 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextLogging expresses the parts of a hook context related to
+// logging.
+type ContextLogging interface {
+	// LogFields returns the structured fields, such as the unit and
+	// the hook or action being run, to attach to messages logged by
+	// the hook.
+	LogFields() map[string]string
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
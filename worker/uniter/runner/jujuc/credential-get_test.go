@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CredentialGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CredentialGetSuite{})
+
+func (s *CredentialGetSuite) newHookContextWithCloudSpec(c *gc.C) *Context {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.CloudSpec = params.CloudSpec{
+		Type:             "openstack",
+		Name:             "brinstack",
+		Region:           "region-1",
+		Endpoint:         "https://keystone.example.com",
+		IdentityEndpoint: "https://keystone.example.com/v3",
+		Credential: &params.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "admin",
+				"password": "secret",
+			},
+		},
+	}
+	return hctx
+}
+
+var credentialGetTests = []struct {
+	args []string
+	out  string
+}{{
+	args: nil,
+	out: `
+type: openstack
+name: brinstack
+region: region-1
+endpoint: https://keystone.example.com
+identity-endpoint: https://keystone.example.com/v3
+credential:
+  auth-type: userpass
+  attrs:
+    password: secret
+    username: admin
+`[1:],
+}, {
+	args: []string{"--format", "json"},
+	out: `{"type":"openstack","name":"brinstack","region":"region-1","endpoint":"https://keystone.example.com",` +
+		`"identity-endpoint":"https://keystone.example.com/v3","credential":{"auth-type":"userpass",` +
+		`"attrs":{"password":"secret","username":"admin"}}}` + "\n",
+}}
+
+func (s *CredentialGetSuite) TestOutputFormat(c *gc.C) {
+	for i, t := range credentialGetTests {
+		c.Logf("test %d: %#v", i, t.args)
+		hctx := s.newHookContextWithCloudSpec(c)
+		com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *CredentialGetSuite) TestNotTrusted(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("permission denied"))
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot access cloud credentials: permission denied\n")
+}
+
+func (s *CredentialGetSuite) TestUnexpectedArgs(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"blah"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["blah"\]`)
+}
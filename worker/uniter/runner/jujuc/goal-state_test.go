@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type GoalStateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&GoalStateSuite{})
+
+func (s *GoalStateSuite) newHookContextWithGoalState(c *gc.C) *Context {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.GoalState = application.GoalState{
+		Units: application.UnitsGoalState{
+			"u/0": {Status: "active"},
+			"u/1": {Status: "waiting"},
+		},
+		Relations: map[string]application.UnitsGoalState{
+			"db": {
+				"mysql/0": {Status: "dying"},
+			},
+		},
+	}
+	return hctx
+}
+
+var goalStateTests = []struct {
+	args []string
+	out  string
+}{{
+	args: nil,
+	out: `
+units:
+  u/0:
+    status: active
+  u/1:
+    status: waiting
+relations:
+  db:
+    mysql/0:
+      status: dying
+`[1:],
+}, {
+	args: []string{"--format", "yaml"},
+	out: `
+units:
+  u/0:
+    status: active
+  u/1:
+    status: waiting
+relations:
+  db:
+    mysql/0:
+      status: dying
+`[1:],
+}, {
+	args: []string{"--format", "json"},
+	out:  `{"units":{"u/0":{"status":"active"},"u/1":{"status":"waiting"}},"relations":{"db":{"mysql/0":{"status":"dying"}}}}` + "\n",
+}}
+
+func (s *GoalStateSuite) TestOutputFormat(c *gc.C) {
+	for i, t := range goalStateTests {
+		c.Logf("test %d: %#v", i, t.args)
+		hctx := s.newHookContextWithGoalState(c)
+		com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *GoalStateSuite) TestUnexpectedArgs(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"blah"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["blah"\]`)
+}
+
+func (s *GoalStateSuite) TestGoalStateError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("boom"))
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR getting goal state: boom\n")
+}
+
+func (s *GoalStateSuite) TestHelp(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), jc.Contains, "Usage: goal-state [options]")
+	c.Check(bufferString(ctx.Stdout), jc.Contains, "print the status of the charm's peers and related units")
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/logfields"
 )
 
 // JujuLogCommand implements the juju-log command.
@@ -20,6 +22,9 @@ type JujuLogCommand struct {
 	Message    string
 	Debug      bool
 	Level      string
+	Labels     []string
+	Fields     map[string]string
+	fieldArgs  []string
 	formatFlag string // deprecated
 }
 
@@ -27,11 +32,20 @@ func NewJujuLogCommand(ctx Context) (cmd.Command, error) {
 	return &JujuLogCommand{ctx: ctx}, nil
 }
 
+const jujuLogDoc = `
+The message is recorded in the model's log, along with structured
+fields identifying the unit and the hook or action being run. Further
+labels and name=value fields may be attached to the message with the
+--label and --field options, so that it can be found with
+"juju debug-log --label" and "juju debug-log --field".
+`
+
 func (c *JujuLogCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "juju-log",
 		Args:    "<message>",
 		Purpose: "write a message to the juju log",
+		Doc:     strings.TrimSpace(jujuLogDoc),
 	}
 }
 
@@ -39,6 +53,8 @@ func (c *JujuLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Debug, "debug", false, "log at debug level")
 	f.StringVar(&c.Level, "l", "INFO", "Send log message at the given level")
 	f.StringVar(&c.Level, "log-level", "INFO", "")
+	f.Var(cmd.NewAppendStringsValue(&c.Labels), "label", "attach a label to the log message")
+	f.Var(cmd.NewAppendStringsValue(&c.fieldArgs), "field", "attach a name=value field to the log message")
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
 }
 
@@ -46,6 +62,19 @@ func (c *JujuLogCommand) Init(args []string) error {
 	if args == nil {
 		return errors.New("no message specified")
 	}
+	if err := (logfields.Data{Labels: c.Labels}).Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, arg := range c.fieldArgs {
+		name, value, err := logfields.ParseField(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.Fields == nil {
+			c.Fields = make(map[string]string)
+		}
+		c.Fields[name] = value
+	}
 	c.Message = strings.Join(args, " ")
 	return nil
 }
@@ -75,6 +104,18 @@ func (c *JujuLogCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	logger.Logf(logLevel, "%s%s", prefix, c.Message)
+	// The fields identifying the hook are set by the context, and
+	// take precedence over any given by the charm.
+	data := logfields.Data{
+		Labels: c.Labels,
+		Fields: map[string]string{logfields.Unit: c.ctx.UnitName()},
+	}
+	for name, value := range c.Fields {
+		data.Fields[name] = value
+	}
+	for name, value := range c.ctx.LogFields() {
+		data.Fields[name] = value
+	}
+	logfields.Logf(logger, logLevel, data, "%s%s", prefix, c.Message)
 	return nil
 }
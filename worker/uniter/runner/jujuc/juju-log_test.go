@@ -7,9 +7,11 @@ package jujuc_test
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -53,3 +55,65 @@ func (s *JujuLogSuite) TestLogDeprecation(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "--format flag deprecated for command \"juju-log\"")
 }
+
+func (s *JujuLogSuite) TestLogInitBadField(c *gc.C) {
+	com := s.newJujuLogCommand(c)
+	cmdtesting.TestInit(c, com, []string{"--field", "hook", "msg"}, `expected name=value, got "hook"`)
+
+	com = s.newJujuLogCommand(c)
+	cmdtesting.TestInit(c, com, []string{"--label", "Bad Label", "msg"}, `label "Bad Label" not valid`)
+}
+
+// dataWriter is a loggo writer which records the
+// data attached to each entry written to it.
+type dataWriter struct {
+	entries []loggo.Entry
+	data    []logfields.Data
+}
+
+func (w *dataWriter) Write(entry loggo.Entry) {
+	w.entries = append(w.entries, entry)
+	w.data = append(w.data, logfields.DataFor(entry))
+}
+
+func (s *JujuLogSuite) TestLogFields(c *gc.C) {
+	var dw dataWriter
+	c.Assert(loggo.RegisterWriter("juju-log-test", &dw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("juju-log-test")
+
+	ctx, info := s.newHookContext(1, "")
+	info.Logging.Fields = map[string]string{
+		logfields.Hook:       "peer1-relation-changed",
+		logfields.RelationID: "peer1:1",
+	}
+	com, err := jujuc.NewCommand(ctx, cmdString("juju-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, com,
+		"--label", "upgrade",
+		"--field", "step=migrate",
+		"--field", "hook=spoofed",
+		"hello", "world",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var found bool
+	for i, entry := range dw.entries {
+		if entry.Module != "unit.u/0.juju-log" {
+			continue
+		}
+		found = true
+		c.Check(entry.Level, gc.Equals, loggo.INFO)
+		// The message written is left as it was logged.
+		c.Check(entry.Message, gc.Equals, "peer1:1: hello world")
+		c.Check(dw.data[i], jc.DeepEquals, logfields.Data{
+			Labels: []string{"upgrade"},
+			Fields: map[string]string{
+				"unit":        "u/0",
+				"hook":        "peer1-relation-changed",
+				"relation-id": "peer1:1",
+				"step":        "migrate",
+			},
+		})
+	}
+	c.Assert(found, jc.IsTrue)
+}
@@ -0,0 +1,22 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+// Logging holds the values for the hook context.
+type Logging struct {
+	Fields map[string]string
+}
+
+// ContextLogging is a test double for jujuc.ContextLogging.
+type ContextLogging struct {
+	contextBase
+	info *Logging
+}
+
+// LogFields implements jujuc.ContextLogging.
+func (c *ContextLogging) LogFields() map[string]string {
+	c.stub.AddCall("LogFields")
+	c.stub.NextErr()
+	return c.info.Fields
+}
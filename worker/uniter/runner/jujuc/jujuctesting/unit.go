@@ -6,12 +6,17 @@ package jujuctesting
 import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 )
 
 // Unit holds the values for the hook context.
 type Unit struct {
 	Name           string
 	ConfigSettings charm.Settings
+	GoalState      application.GoalState
+	CloudSpec      params.CloudSpec
 }
 
 // ContextUnit is a test double for jujuc.ContextUnit.
@@ -37,3 +42,23 @@ func (c *ContextUnit) ConfigSettings() (charm.Settings, error) {
 
 	return c.info.ConfigSettings, nil
 }
+
+// GoalState implements jujuc.ContextUnit.
+func (c *ContextUnit) GoalState() (*application.GoalState, error) {
+	c.stub.AddCall("GoalState")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &c.info.GoalState, nil
+}
+
+// CloudSpec implements jujuc.ContextUnit.
+func (c *ContextUnit) CloudSpec() (*params.CloudSpec, error) {
+	c.stub.AddCall("CloudSpec")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &c.info.CloudSpec, nil
+}
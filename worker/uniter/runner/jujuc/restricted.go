@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/network"
 )
 
@@ -25,6 +26,16 @@ type RestrictedContext struct{}
 // ConfigSettings implements hooks.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// CloudSpec implements hooks.Context.
+func (*RestrictedContext) CloudSpec() (*params.CloudSpec, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GoalState implements hooks.Context.
+func (*RestrictedContext) GoalState() (*application.GoalState, error) {
+	return nil, ErrRestrictedContext
+}
+
 // UnitStatus implements hooks.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) {
 	return nil, ErrRestrictedContext
@@ -153,3 +164,8 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// LogFields implements hooks.Context.
+func (*RestrictedContext) LogFields() map[string]string {
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/component/all"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -1712,6 +1713,45 @@ func (s *UniterSuite) TestRebootDisabledOnHookError(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestUniterUpgradeSeriesHookError(c *gc.C) {
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"pre-series-upgrade hook fail and abort",
+			createCharm{
+				customize: func(c *gc.C, ctx *context, path string) {
+					ctx.writeHook(c, filepath.Join(path, "hooks", "pre-series-upgrade"), false)
+				},
+			},
+			serveCharm{},
+			createUniter{},
+			waitUnitAgent{status: status.Idle},
+			waitHooks(startupHooks(false)),
+			verifyCharm{},
+
+			custom{func(c *gc.C, ctx *context) {
+				err := upgradeSeriesMachine(c, ctx).CreateUpgradeSeriesLock("trusty", true)
+				c.Assert(err, jc.ErrorIsNil)
+			}},
+			waitUnitAgent{
+				statusGetter: unitStatusGetter,
+				status:       status.Error,
+				info:         `hook failed: "pre-series-upgrade"`,
+			},
+			waitHooks{"fail-pre-series-upgrade"},
+			waitUpgradeSeriesStatus{model.UpgradeSeriesError},
+
+			custom{func(c *gc.C, ctx *context) {
+				err := upgradeSeriesMachine(c, ctx).AbortUpgradeSeries()
+				c.Assert(err, jc.ErrorIsNil)
+			}},
+			resolveError{state.ResolvedNoHooks},
+			waitUnitAgent{status: status.Idle},
+			waitUpgradeSeriesStatus{model.UpgradeSeriesNotStarted},
+			verifyRunning{},
+		),
+	})
+}
+
 func (s *UniterSuite) TestJujuRunExecutionSerialized(c *gc.C) {
 	s.runUniterTests(c, []uniterTest{
 		ut(
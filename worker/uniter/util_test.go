@@ -35,6 +35,7 @@ import (
 	apiuniter "github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/core/leadership"
 	coreleadership "github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -715,6 +716,36 @@ func (s resolveError) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func upgradeSeriesMachine(c *gc.C, ctx *context) *state.Machine {
+	machineId, err := ctx.unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := ctx.st.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	return machine
+}
+
+type waitUpgradeSeriesStatus struct {
+	status model.UpgradeSeriesStatus
+}
+
+func (s waitUpgradeSeriesStatus) step(c *gc.C, ctx *context) {
+	timeout := time.After(worstCase)
+	for {
+		ctx.s.BackingState.StartSync()
+		status, err := ctx.unit.UpgradeSeriesStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		if status == s.status {
+			return
+		}
+		select {
+		case <-time.After(coretesting.ShortWait):
+			c.Logf("want series upgrade status %q, got %q; still waiting", s.status, status)
+		case <-timeout:
+			c.Fatalf("never reached series upgrade status %q", s.status)
+		}
+	}
+}
+
 type statusfunc func() (status.StatusInfo, error)
 
 type statusfuncGetter func(ctx *context) statusfunc
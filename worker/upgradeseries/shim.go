@@ -0,0 +1,25 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	apiupgradeseries "github.com/juju/juju/api/upgradeseries"
+)
+
+func NewFacade(apiCaller base.APICaller, tag names.MachineTag) (Facade, error) {
+	return apiupgradeseries.NewFacade(apiCaller, tag), nil
+}
+
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.upgradeseries")
+
+// Facade exposes the series upgrade functionality of the controller
+// to a Worker.
+type Facade interface {
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	MachineStatus() (model.UpgradeSeriesStatus, error)
+	SetMachineStatus(model.UpgradeSeriesStatus) error
+	TargetSeries() (string, error)
+	UnitStatuses() (map[string]model.UpgradeSeriesStatus, error)
+	FinishUpgradeSeries() error
+}
+
+// Config defines the parameters of the upgradeseries worker.
+type Config struct {
+	Facade    Facade
+	MachineId string
+	DataDir   string
+	LogDir    string
+
+	// WriteAgentServiceFiles writes the init system configuration of
+	// the given agents for the specified series.
+	WriteAgentServiceFiles func([]service.AgentInfo, string) error
+}
+
+// Validate returns an error if Config cannot drive an upgradeseries
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.DataDir == "" {
+		return errors.NotValidf("empty DataDir")
+	}
+	if config.LogDir == "" {
+		return errors.NotValidf("empty LogDir")
+	}
+	if config.WriteAgentServiceFiles == nil {
+		return errors.NotValidf("nil WriteAgentServiceFiles")
+	}
+	return nil
+}
+
+// New returns a worker that drives the machine side of a series
+// upgrade: once every unit on the machine has run its
+// pre-series-upgrade hook, the agents' service files are rewritten for
+// the target series; once every unit has run its post-series-upgrade
+// hook, the upgrade is finished.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &upgradeSeriesHandler{config: config},
+	})
+	return w, errors.Trace(err)
+}
+
+// upgradeSeriesHandler implements watcher.NotifyHandler.
+type upgradeSeriesHandler struct {
+	config Config
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *upgradeSeriesHandler) SetUp() (watcher.NotifyWatcher, error) {
+	w, err := h.config.Facade.WatchUpgradeSeriesNotifications()
+	return w, errors.Trace(err)
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *upgradeSeriesHandler) Handle(_ <-chan struct{}) error {
+	status, err := h.config.Facade.MachineStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch status {
+	case model.UpgradeSeriesPrepareStarted:
+		return errors.Trace(h.handlePrepareStarted())
+	case model.UpgradeSeriesCompleteStarted:
+		return errors.Trace(h.handleCompleteStarted())
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *upgradeSeriesHandler) TearDown() error {
+	return nil
+}
+
+func (h *upgradeSeriesHandler) handlePrepareStarted() error {
+	unitStatuses, ready, err := h.unitsInStatus(model.UpgradeSeriesPrepareCompleted)
+	if err != nil || !ready {
+		return errors.Trace(err)
+	}
+	series, err := h.config.Facade.TargetSeries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	agents := []service.AgentInfo{
+		service.NewMachineAgentInfo(h.config.MachineId, h.config.DataDir, h.config.LogDir),
+	}
+	for unitName := range unitStatuses {
+		agents = append(agents, service.NewUnitAgentInfo(unitName, h.config.DataDir, h.config.LogDir))
+	}
+	logger.Infof("writing agent service files for series %q", series)
+	if err := h.config.WriteAgentServiceFiles(agents, series); err != nil {
+		// Retrying is unlikely to help, so the failure is recorded
+		// for the operator, who may abort the series upgrade.
+		logger.Errorf("writing agent service files for series %q: %v", series, err)
+		return errors.Trace(h.config.Facade.SetMachineStatus(model.UpgradeSeriesError))
+	}
+	return errors.Trace(h.config.Facade.SetMachineStatus(model.UpgradeSeriesPrepareCompleted))
+}
+
+func (h *upgradeSeriesHandler) handleCompleteStarted() error {
+	_, ready, err := h.unitsInStatus(model.UpgradeSeriesCompleted)
+	if err != nil || !ready {
+		return errors.Trace(err)
+	}
+	logger.Infof("finishing series upgrade of machine %s", h.config.MachineId)
+	return errors.Trace(h.config.Facade.FinishUpgradeSeries())
+}
+
+// unitsInStatus returns the upgrade series statuses of the units on the
+// machine, and whether they all have the given status.
+func (h *upgradeSeriesHandler) unitsInStatus(status model.UpgradeSeriesStatus) (map[string]model.UpgradeSeriesStatus, bool, error) {
+	unitStatuses, err := h.config.Facade.UnitStatuses()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	ready := true
+	for unitName, unitStatus := range unitStatuses {
+		switch unitStatus {
+		case status:
+			continue
+		case model.UpgradeSeriesError:
+			// The unit waits for its failed hook to be resolved,
+			// or for the series upgrade to be aborted.
+			logger.Warningf("unit %s failed its series upgrade hook", unitName)
+		default:
+			logger.Debugf("waiting for unit %s (status %q)", unitName, unitStatus)
+		}
+		ready = false
+	}
+	if !ready {
+		return nil, false, nil
+	}
+	return unitStatuses, true, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/service"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/upgradeseries"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	facade *stubFacade
+	config upgradeseries.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.facade = &stubFacade{
+		stub:    s.stub,
+		changes: make(chan struct{}, 1),
+		done:    make(chan struct{}, 1),
+		target:  "bionic",
+	}
+	s.config = upgradeseries.Config{
+		Facade:    s.facade,
+		MachineId: "0",
+		DataDir:   "/var/lib/juju",
+		LogDir:    "/var/log/juju",
+		WriteAgentServiceFiles: func(agents []service.AgentInfo, series string) error {
+			s.stub.AddCall("WriteAgentServiceFiles", agents, series)
+			return s.stub.NextErr()
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.MachineId = ""
+	_, err := upgradeseries.New(s.config)
+	c.Check(err, gc.ErrorMatches, "empty MachineId not valid")
+
+	s.config.MachineId = "0"
+	s.config.WriteAgentServiceFiles = nil
+	_, err = upgradeseries.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil WriteAgentServiceFiles not valid")
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestPrepareUnitsNotReady(c *gc.C) {
+	s.facade.status = model.UpgradeSeriesPrepareStarted
+	s.facade.unitStatuses = map[string]model.UpgradeSeriesStatus{
+		"mysql/0": model.UpgradeSeriesPrepareStarted,
+	}
+	s.runWorker(c)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "MachineStatus", "UnitStatuses")
+}
+
+func (s *WorkerSuite) TestPrepareWritesServiceFiles(c *gc.C) {
+	s.facade.status = model.UpgradeSeriesPrepareStarted
+	s.facade.unitStatuses = map[string]model.UpgradeSeriesStatus{
+		"mysql/0": model.UpgradeSeriesPrepareCompleted,
+	}
+	s.runWorker(c)
+	s.stub.CheckCallNames(c,
+		"WatchUpgradeSeriesNotifications", "MachineStatus", "UnitStatuses",
+		"TargetSeries", "WriteAgentServiceFiles", "SetMachineStatus",
+	)
+	s.stub.CheckCall(c, 4, "WriteAgentServiceFiles", []service.AgentInfo{
+		service.NewMachineAgentInfo("0", "/var/lib/juju", "/var/log/juju"),
+		service.NewUnitAgentInfo("mysql/0", "/var/lib/juju", "/var/log/juju"),
+	}, "bionic")
+	s.stub.CheckCall(c, 5, "SetMachineStatus", model.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *WorkerSuite) TestPrepareUnitFailed(c *gc.C) {
+	s.facade.status = model.UpgradeSeriesPrepareStarted
+	s.facade.unitStatuses = map[string]model.UpgradeSeriesStatus{
+		"mysql/0": model.UpgradeSeriesError,
+	}
+	s.runWorker(c)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "MachineStatus", "UnitStatuses")
+}
+
+func (s *WorkerSuite) TestPrepareWriteServiceFilesError(c *gc.C) {
+	s.facade.status = model.UpgradeSeriesPrepareStarted
+	s.facade.unitStatuses = map[string]model.UpgradeSeriesStatus{
+		"mysql/0": model.UpgradeSeriesPrepareCompleted,
+	}
+	s.stub.SetErrors(nil, nil, nil, nil, errors.New("boom"))
+	s.runWorker(c)
+	s.stub.CheckCallNames(c,
+		"WatchUpgradeSeriesNotifications", "MachineStatus", "UnitStatuses",
+		"TargetSeries", "WriteAgentServiceFiles", "SetMachineStatus",
+	)
+	s.stub.CheckCall(c, 5, "SetMachineStatus", model.UpgradeSeriesError)
+}
+
+func (s *WorkerSuite) TestErrorDoesNothing(c *gc.C) {
+	s.facade.status = model.UpgradeSeriesError
+	s.runWorker(c)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "MachineStatus")
+}
+
+func (s *WorkerSuite) TestCompleteFinishesUpgrade(c *gc.C) {
+	s.facade.status = model.UpgradeSeriesCompleteStarted
+	s.facade.unitStatuses = map[string]model.UpgradeSeriesStatus{
+		"mysql/0": model.UpgradeSeriesCompleted,
+	}
+	s.runWorker(c)
+	s.stub.CheckCallNames(c,
+		"WatchUpgradeSeriesNotifications", "MachineStatus", "UnitStatuses", "FinishUpgradeSeries",
+	)
+}
+
+func (s *WorkerSuite) TestNotStartedDoesNothing(c *gc.C) {
+	s.facade.status = model.UpgradeSeriesNotStarted
+	s.runWorker(c)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "MachineStatus")
+}
+
+// runWorker starts the worker, delivers a single change and stops the
+// worker once the change has been handled.
+func (s *WorkerSuite) runWorker(c *gc.C) {
+	w, err := upgradeseries.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.changes <- struct{}{}
+	select {
+	case <-s.facade.done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for change to be handled")
+	}
+	workertest.CleanKill(c, w)
+}
+
+type stubFacade struct {
+	stub         *jujutesting.Stub
+	changes      chan struct{}
+	done         chan struct{}
+	status       model.UpgradeSeriesStatus
+	unitStatuses map[string]model.UpgradeSeriesStatus
+	target       string
+}
+
+func (f *stubFacade) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("WatchUpgradeSeriesNotifications")
+	return watchertest.NewMockNotifyWatcher(f.changes), f.stub.NextErr()
+}
+
+func (f *stubFacade) MachineStatus() (model.UpgradeSeriesStatus, error) {
+	f.stub.AddCall("MachineStatus")
+	switch f.status {
+	case model.UpgradeSeriesPrepareStarted, model.UpgradeSeriesCompleteStarted:
+	default:
+		f.done <- struct{}{}
+	}
+	return f.status, f.stub.NextErr()
+}
+
+func (f *stubFacade) SetMachineStatus(status model.UpgradeSeriesStatus) error {
+	f.stub.AddCall("SetMachineStatus", status)
+	f.done <- struct{}{}
+	return f.stub.NextErr()
+}
+
+func (f *stubFacade) TargetSeries() (string, error) {
+	f.stub.AddCall("TargetSeries")
+	return f.target, f.stub.NextErr()
+}
+
+func (f *stubFacade) UnitStatuses() (map[string]model.UpgradeSeriesStatus, error) {
+	f.stub.AddCall("UnitStatuses")
+	for _, status := range f.unitStatuses {
+		if status != model.UpgradeSeriesPrepareCompleted && status != model.UpgradeSeriesCompleted {
+			f.done <- struct{}{}
+		}
+	}
+	return f.unitStatuses, f.stub.NextErr()
+}
+
+func (f *stubFacade) FinishUpgradeSeries() error {
+	f.stub.AddCall("FinishUpgradeSeries")
+	f.done <- struct{}{}
+	return f.stub.NextErr()
+}